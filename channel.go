@@ -1,6 +1,8 @@
 package gomavlib
 
 import (
+	"context"
+	"fmt"
 	"io"
 
 	"github.com/aler9/gomavlib/pkg/frame"
@@ -18,43 +20,103 @@ type Channel struct {
 	rwc         io.ReadWriteCloser
 	n           *Node
 	transceiver *transceiver.Transceiver
+	stats       *statsCounters
+	sg          statsGroup
 	running     bool
+	highLatency bool
+	failover    *EndpointFailover
+	inFilters   []*inFilter
+	queueSize   int
+	batcher     *writeBatcher
+	sequences   sequenceTracker
 
 	// in
 	write     chan interface{}
 	terminate chan struct{}
 }
 
-func newChannel(n *Node, e Endpoint, label string, rwc io.ReadWriteCloser) (*Channel, error) {
+func newChannel(n *Node, e Endpoint, label string, rwc io.ReadWriteCloser,
+	endpointStats *statsCounters, opts *channelOptions) (*Channel, error) {
+	stats := &statsCounters{}
+	sg := statsGroup{stats, endpointStats, n.stats}
+	terminate := make(chan struct{})
+
+	var writer io.Writer = &statsWriter{rwc, sg}
+	var batcher *writeBatcher
+	if opts.rateLimit != nil {
+		writer = newRateLimitedWriter(writer, opts.rateLimit, terminate)
+	} else if bw, ok := rwc.(batchWriter); ok {
+		// rate limits are applied to single frames, therefore batches
+		// are used only when rate limits are disabled
+		batcher = &writeBatcher{w: bw, direct: writer, sg: sg}
+		writer = batcher
+	}
+
+	ch := &Channel{
+		e:           e,
+		label:       label,
+		rwc:         rwc,
+		n:           n,
+		stats:       stats,
+		sg:          sg,
+		highLatency: opts.highLatency,
+		failover:    opts.failover,
+		inFilters:   opts.inFilters,
+		queueSize:   opts.queueSize,
+		sequences:   sequenceTracker{timeout: n.conf.SystemTimeout},
+		batcher:     batcher,
+		write:       make(chan interface{}),
+		terminate:   terminate,
+	}
+
 	transceiver, err := transceiver.New(transceiver.Conf{
-		Reader:      rwc,
-		Writer:      rwc,
-		DialectDE:   n.dialectDE,
-		InKey:       n.conf.InKey,
+		Reader:       &statsReader{rwc, sg},
+		Writer:       writer,
+		DialectDE:    n.dialectDE,
+		LazyDecoding: n.conf.LazyDecoding,
+		Keys:         opts.keys,
+		SignaturePolicy: func() func(*transceiver.SignatureCheck) error {
+			if n.conf.SignaturePolicy == nil {
+				return nil
+			}
+			return func(c *transceiver.SignatureCheck) error {
+				return n.conf.SignaturePolicy(newSignatureCheck(ch, c))
+			}
+		}(),
 		OutSystemID: n.conf.OutSystemID,
 		OutVersion: func() transceiver.Version {
-			if n.conf.OutVersion == V2 {
+			if opts.outVersion == V2 {
 				return transceiver.V2
 			}
 			return transceiver.V1
 		}(),
 		OutComponentID:     n.conf.OutComponentID,
 		OutSignatureLinkID: randomByte(),
-		OutKey:             n.conf.OutKey,
+		OutTranslate:       n.conf.TranslateVersion,
+		OutFrameHook: func() func(frame.Frame) frame.Frame {
+			if len(n.conf.OutMiddlewares) == 0 {
+				return nil
+			}
+			return func(fr frame.Frame) frame.Frame {
+				return runMiddlewares(n.conf.OutMiddlewares, ch, fr)
+			}
+		}(),
+		OutSignatureTimestamp: func() func() uint64 {
+			if n.nodeSetupSigning != nil && n.conf.SetupSigningAccept != nil {
+				return n.nodeSetupSigning.nextTimestamp
+			}
+			if n.nodeSignatureTimestamp != nil {
+				return n.nodeSignatureTimestamp.next
+			}
+			return nil
+		}(),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &Channel{
-		e:           e,
-		label:       label,
-		rwc:         rwc,
-		n:           n,
-		transceiver: transceiver,
-		write:       make(chan interface{}),
-		terminate:   make(chan struct{}),
-	}, nil
+	ch.transceiver = transceiver
+	return ch, nil
 }
 
 func (ch *Channel) close() {
@@ -74,53 +136,304 @@ func (ch *Channel) start() {
 func (ch *Channel) run() {
 	defer ch.n.channelsWg.Done()
 
+	opened := make(chan struct{})
 	readerDone := make(chan struct{})
 	go func() {
 		defer close(readerDone)
 
 		// wait client here, in order to allow the writer goroutine to start
 		// and allow clients to write messages before starting listening to events
-		ch.n.events <- &EventChannelOpen{ch}
+		if ch.n.nodeMetrics != nil {
+			ch.n.nodeMetrics.onChannelOpen(ch)
+			defer ch.n.nodeMetrics.onChannelClose(ch)
+		}
+
+		if ch.n.nodeRouter != nil {
+			defer ch.n.nodeRouter.onChannelClose(ch)
+		}
+
+		if ch.n.nodeStreamRequest != nil {
+			defer ch.n.nodeStreamRequest.onChannelClose(ch)
+		}
+
+		defer ch.n.nodeSystems.onChannelClose(ch)
+
+		ch.n.nodeFailover.onChannelOpen(ch)
+		defer ch.n.nodeFailover.onChannelClose(ch)
+
+		ch.n.log(LogLevelInfo, "channel opened", "channel", ch)
+		ch.n.emitEvent(&EventChannelOpen{ch})
+		close(opened)
 
 		for {
 			frame, err := ch.transceiver.Read()
 			if err != nil {
 				// continue in case of parse errors
-				if _, ok := err.(*transceiver.Error); ok {
-					ch.n.events <- &EventParseError{err, ch}
+				if terr, ok := err.(*transceiver.Error); ok {
+					ch.sg.add(statsParseErrors, 1)
+					if terr.Type == transceiver.ErrorTypeChecksum {
+						ch.sg.add(statsChecksumErrors, 1)
+					}
+
+					if terr.Type == transceiver.ErrorTypeSignature {
+						ch.n.log(LogLevelWarn, "signature rejected", "channel", ch, "error", err)
+					} else {
+						ch.n.log(LogLevelDebug, "parse error", "channel", ch, "error", err)
+					}
+
+					ch.n.emitEvent(&EventParseError{
+						Error:   err,
+						Channel: ch,
+						Reason:  parseErrorReason(terr.Type),
+						Data:    terr.Data,
+					})
 					continue
 				}
 				return
 			}
 
-			evt := &EventFrame{frame, ch}
+			ch.sg.add(statsFramesIn, 1)
+
+			lost, restarted := ch.sequences.track(frame, ch.n.conf.Clock.Now())
+			if restarted {
+				ch.sg.add(statsSequenceRestarts, 1)
+			}
+			if lost != 0 {
+				ch.sg.add(statsLostFrames, uint64(lost))
+				if ch.n.conf.PacketLossEventsEnable {
+					ch.n.emitEvent(&EventPacketLoss{
+						Channel:     ch,
+						SystemID:    frame.GetSystemID(),
+						ComponentID: frame.GetComponentID(),
+						Lost:        lost,
+					})
+				}
+			}
+
+			if ch.n.conf.UnknownMessagesDisable {
+				if _, ok := ch.n.dialectDE.MessageDEs[frame.GetMessage().GetID()]; !ok {
+					ch.n.log(LogLevelDebug, "unknown message discarded", "channel", ch,
+						"message_id", frame.GetMessage().GetID())
+					ch.n.emitEvent(&EventParseError{
+						Error:   fmt.Errorf("unknown message (id=%d)", frame.GetMessage().GetID()),
+						Channel: ch,
+						Reason:  ParseErrorUnknownMessage,
+						Data:    encodeReceivedFrame(frame),
+					})
+					continue
+				}
+			}
+
+			if !ch.accepts(frame) {
+				ch.sg.add(statsFilteredFrames, 1)
+				ch.n.log(LogLevelDebug, "frame filtered", "channel", ch,
+					"system_id", frame.GetSystemID(), "component_id", frame.GetComponentID(),
+					"message_id", frame.GetMessage().GetID())
+				continue
+			}
+
+			if len(ch.n.conf.InMiddlewares) != 0 {
+				frame = runMiddlewares(ch.n.conf.InMiddlewares, ch, frame)
+				if frame == nil {
+					ch.sg.add(statsFilteredFrames, 1)
+					continue
+				}
+			}
+
+			evt := ch.n.newEventFrame(frame, ch, ch.transceiver.ReadKey())
+
+			var span Span
+			if ch.n.conf.Tracer != nil {
+				evt.ctx, span = ch.n.conf.Tracer.Start(context.Background(), SpanReceive,
+					frameTraceAttributes(ch, frame)...)
+			}
 
 			if ch.n.nodeStreamRequest != nil {
 				ch.n.nodeStreamRequest.onEventFrame(evt)
 			}
 
-			ch.n.events <- evt
+			if ch.n.nodeTimesync != nil {
+				ch.n.nodeTimesync.onEventFrame(evt)
+			}
+
+			if ch.n.nodeHighLatency != nil {
+				ch.n.nodeHighLatency.onEventFrame(evt)
+			}
+
+			if ch.n.nodeADSB != nil {
+				ch.n.nodeADSB.onEventFrame(evt)
+			}
+
+			if ch.n.nodeMessageInterval != nil {
+				ch.n.nodeMessageInterval.onEventFrame(evt)
+			}
+
+			if ch.n.nodePing != nil {
+				ch.n.nodePing.onEventFrame(evt)
+			}
+
+			if ch.n.nodeSetupSigning != nil {
+				ch.n.nodeSetupSigning.onEventFrame(evt)
+			}
+
+			if ch.n.nodeParam != nil {
+				ch.n.nodeParam.onEventFrame(evt)
+			}
+
+			if ch.n.nodeGimbal != nil {
+				ch.n.nodeGimbal.onEventFrame(evt)
+			}
+
+			if ch.n.nodeMetrics != nil {
+				ch.n.nodeMetrics.onEventFrame(evt)
+			}
+
+			ch.n.nodeFailover.onEventFrame(evt)
+
+			if ch.n.nodeRouter != nil {
+				ch.n.nodeRouter.onEventFrame(evt)
+			}
+
+			if ch.n.nodeSystemEvents != nil {
+				ch.n.nodeSystemEvents.onEventFrame(evt)
+			}
+
+			ch.n.nodeSystems.onEventFrame(evt)
+
+			ch.n.frameSubscribers.dispatch(evt)
+
+			ch.n.emitEvent(evt)
+
+			if span != nil {
+				span.End()
+			}
+		}
+	}()
+
+	reconnectTerminate := make(chan struct{})
+	reconnectDone := make(chan struct{})
+	go func() {
+		defer close(reconnectDone)
+
+		er, ok := ch.e.(endpointReconnecter)
+		if !ok {
+			return
+		}
+
+		// baud rate events are reported by serial endpoints only
+		var baudRateEvents chan *EventBaudRateDetected
+		if eb, ok := ch.e.(endpointBaudDetecter); ok {
+			baudRateEvents = eb.baudRateEvents()
+		}
+
+		// emit reconnection events after EventChannelOpen
+		select {
+		case <-opened:
+		case <-reconnectTerminate:
+			return
+		}
+
+		for {
+			select {
+			case evt := <-er.reconnectEvents():
+				evt.Channel = ch
+				ch.n.log(LogLevelWarn, "connection failed", "channel", ch,
+					"attempt", evt.Attempt, "delay", evt.Delay, "error", evt.Error)
+				ch.n.emitEvent(evt)
+
+			case evt := <-baudRateEvents:
+				evt.Channel = ch
+				ch.n.log(LogLevelInfo, "baud rate detected", "channel", ch,
+					"device", evt.Device, "baud", evt.BaudRate)
+				ch.n.emitEvent(evt)
+
+			case <-reconnectTerminate:
+				return
+			}
 		}
 	}()
 
+	// queue frames in order of priority, in order not to block other channels
+	next := func() (interface{}, bool) {
+		what, ok := <-ch.write
+		return what, ok
+	}
+	tryNext := func() (interface{}, bool) {
+		select {
+		case what, ok := <-ch.write:
+			return what, ok
+		default:
+			return nil, false
+		}
+	}
+	if ch.queueSize > 0 {
+		queue := newWriteQueue(ch.queueSize)
+		go func() {
+			defer queue.close()
+
+			for what := range ch.write {
+				if dropped, ok := queue.push(what, ch.n.writePriority(what)); !ok {
+					ch.sg.add(statsDroppedWrites, 1)
+					ch.n.log(LogLevelWarn, "queue is full, frame discarded", "channel", ch)
+					if rw, ok := dropped.(*reportedWrite); ok {
+						rw.report(ch, errorQueueFull)
+					}
+				}
+			}
+		}()
+		next = queue.pop
+		tryNext = func() (interface{}, bool) {
+			what, ok, _ := queue.tryPop()
+			return what, ok
+		}
+	}
+
 	writerDone := make(chan struct{})
 	go func() {
 		defer close(writerDone)
 
-		for what := range ch.write {
-			switch wh := what.(type) {
-			case msg.Message:
-				ch.transceiver.WriteMessage(wh)
+		for {
+			what, ok := next()
+			if !ok {
+				return
+			}
 
-			case frame.Frame:
-				ch.transceiver.WriteFrame(wh)
+			if ch.batcher == nil {
+				ch.endWrite(ch.startWrite(what))
+				continue
+			}
+
+			second, ok := tryNext()
+			if !ok {
+				ch.endWrite(ch.startWrite(what))
+				continue
+			}
+
+			// write together the frames that are already queued
+			ch.batcher.begin()
+			pending := []*pendingWrite{ch.startWrite(what), ch.startWrite(second)}
+			for len(pending) < writeBatchMaxSize {
+				what, ok := tryNext()
+				if !ok {
+					break
+				}
+				pending = append(pending, ch.startWrite(what))
+			}
+
+			n, err := ch.batcher.flush()
+			for _, p := range pending {
+				if p.batchIndex >= n {
+					p.err = err
+				}
+				ch.endWrite(p)
 			}
 		}
 	}()
 
 	select {
 	case <-readerDone:
-		ch.n.events <- &EventChannelClose{ch}
+		ch.n.log(LogLevelInfo, "channel closed", "channel", ch)
+		ch.n.emitEvent(&EventChannelClose{ch})
 
 		ch.n.channelClose <- ch
 		<-ch.terminate
@@ -130,17 +443,155 @@ func (ch *Channel) run() {
 
 		ch.rwc.Close()
 
+		close(reconnectTerminate)
+		<-reconnectDone
+
 	case <-ch.terminate:
-		ch.n.events <- &EventChannelClose{ch}
+		ch.n.log(LogLevelInfo, "channel closed", "channel", ch)
+		ch.n.emitEvent(&EventChannelClose{ch})
 
 		close(ch.write)
 		<-writerDone
 
 		ch.rwc.Close()
 		<-readerDone
+
+		close(reconnectTerminate)
+		<-reconnectDone
 	}
 }
 
+// pendingWrite is a write whose outcome has not been reported yet.
+type pendingWrite struct {
+	rw   *reportedWrite
+	span Span
+	err  error
+
+	// index of the frame in the current batch, or -1
+	batchIndex int
+}
+
+// startWrite writes a message or frame to the channel, or adds it to the
+// current batch.
+func (ch *Channel) startWrite(what interface{}) *pendingWrite {
+	what, rw, ctx := unwrapWrite(what)
+
+	p := &pendingWrite{
+		rw:         rw,
+		batchIndex: -1,
+	}
+
+	if ch.n.conf.Tracer != nil {
+		_, p.span = ch.n.conf.Tracer.Start(ctx, SpanWrite, writeTraceAttributes(ch, what)...)
+	}
+
+	before := 0
+	if ch.batcher != nil {
+		before = ch.batcher.len()
+	}
+
+	p.err = ch.writeEntry(what)
+
+	if ch.batcher != nil && ch.batcher.len() > before {
+		p.batchIndex = before
+	}
+
+	return p
+}
+
+// endWrite reports the outcome of a write.
+func (ch *Channel) endWrite(p *pendingWrite) {
+	if p.span != nil {
+		if p.err != nil {
+			p.span.SetError(p.err)
+		}
+		p.span.End()
+	}
+
+	switch p.err {
+	case nil:
+		ch.sg.add(statsFramesOut, 1)
+
+	case errorFrameDiscarded, errorFrameDiscardedMiddleware:
+
+	default:
+		ch.sg.add(statsDroppedWrites, 1)
+		ch.n.log(LogLevelWarn, "write failed", "channel", ch, "error", p.err)
+	}
+
+	if p.rw != nil {
+		p.rw.report(ch, p.err)
+	}
+}
+
+// writeEntry writes a message or a frame to the channel.
+func (ch *Channel) writeEntry(what interface{}) error {
+	switch wh := what.(type) {
+	case msg.Message:
+		return mapWriteError(ch.transceiver.WriteMessage(wh))
+
+	case componentMessage:
+		return mapWriteError(ch.transceiver.WriteMessageComponent(wh.componentID, wh.m))
+
+	case frame.Frame:
+		if ch.n.conf.RewriteFrame == nil && len(ch.n.conf.OutMiddlewares) == 0 {
+			return ch.transceiver.WriteFrame(wh)
+		}
+
+		fr := wh.Clone()
+
+		if ch.n.conf.RewriteFrame != nil {
+			fr = ch.n.conf.RewriteFrame(ch, fr)
+			if fr == nil {
+				return errorFrameDiscarded
+			}
+		}
+
+		fr = runMiddlewares(ch.n.conf.OutMiddlewares, ch, fr)
+		if fr == nil {
+			return errorFrameDiscardedMiddleware
+		}
+
+		return ch.transceiver.WriteFrameRewritten(fr)
+	}
+
+	return nil
+}
+
+// mapWriteError converts errors of the transceiver into errors of the node.
+func mapWriteError(err error) error {
+	if err == transceiver.ErrFrameDiscarded {
+		return errorFrameDiscardedMiddleware
+	}
+	return err
+}
+
+// encodeReceivedFrame returns the bytes of a frame whose message has not
+// been decoded.
+func encodeReceivedFrame(fr frame.Frame) []byte {
+	raw, ok := fr.GetMessage().(*msg.MessageRaw)
+	if !ok {
+		return nil
+	}
+
+	// header, payload, checksum and signature
+	buf, err := fr.Encode(make([]byte, 0, 10+len(raw.Content)+2+13), raw.Content)
+	if err != nil {
+		return nil
+	}
+	return buf
+}
+
+// accepts checks whether a received frame passes the filters of the endpoint.
+func (ch *Channel) accepts(fr frame.Frame) bool {
+	for _, f := range ch.inFilters {
+		if !f.accepts(fr) {
+			return false
+		}
+	}
+	return true
+}
+
 // String implements fmt.Stringer.
 func (ch *Channel) String() string {
 	return ch.label
@@ -150,3 +601,8 @@ func (ch *Channel) String() string {
 func (ch *Channel) Endpoint() Endpoint {
 	return ch.e
 }
+
+// Stats returns the channel statistics.
+func (ch *Channel) Stats() Stats {
+	return ch.stats.get()
+}
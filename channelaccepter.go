@@ -5,14 +5,19 @@ import (
 )
 
 type channelAccepter struct {
-	n   *Node
-	eca endpointChannelAccepter
+	n     *Node
+	eca   endpointChannelAccepter
+	stats *statsCounters
+	opts  *channelOptions
 }
 
-func newChannelAccepter(n *Node, eca endpointChannelAccepter) (*channelAccepter, error) {
+func newChannelAccepter(n *Node, eca endpointChannelAccepter,
+	stats *statsCounters, opts *channelOptions) (*channelAccepter, error) {
 	return &channelAccepter{
-		n:   n,
-		eca: eca,
+		n:     n,
+		eca:   eca,
+		stats: stats,
+		opts:  opts,
 	}, nil
 }
 
@@ -37,7 +42,7 @@ func (ca *channelAccepter) run1() {
 			break
 		}
 
-		ch, err := newChannel(ca.n, ca.eca, label, rwc)
+		ch, err := newChannel(ca.n, ca.eca, label, rwc, ca.stats, ca.opts)
 		if err != nil {
 			panic(fmt.Errorf("newChannel unexpected error: %s", err))
 		}
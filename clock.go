@@ -0,0 +1,42 @@
+package gomavlib
+
+import (
+	"time"
+)
+
+// Ticker delivers ticks at intervals. It is returned by Clock.
+type Ticker interface {
+	// C returns the channel on which ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// Clock is the source of time used by the periodic activities of the node
+// (heartbeats, stream requests, timeouts of systems and of responses).
+// It can be replaced in order to run tests without waiting real time.
+// Network deadlines, like read and idle timeouts, always use the system clock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTicker returns a ticker that ticks with the given period.
+	NewTicker(d time.Duration) Ticker
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
@@ -0,0 +1,181 @@
+package gomavlib
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type testTicker struct {
+	clock  *testClock
+	period time.Duration
+	next   time.Time
+	c      chan time.Time
+}
+
+func (t *testTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *testTicker) Stop() {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+	delete(t.clock.tickers, t)
+}
+
+// testClock is a clock that advances only when requested.
+type testClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	tickers map[*testTicker]struct{}
+}
+
+func newTestClock() *testClock {
+	return &testClock{
+		now:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		tickers: make(map[*testTicker]struct{}),
+	}
+}
+
+func (c *testClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *testClock) NewTicker(d time.Duration) Ticker {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	t := &testTicker{
+		clock:  c,
+		period: d,
+		next:   c.now.Add(d),
+		c:      make(chan time.Time, 1),
+	}
+	c.tickers[t] = struct{}{}
+	return t
+}
+
+// advance moves the clock forward and fires the expired tickers.
+// Like time.Ticker, ticks are dropped when receivers are slow.
+func (c *testClock) advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+
+	for t := range c.tickers {
+		for !t.next.After(c.now) {
+			select {
+			case t.c <- t.next:
+			default:
+			}
+			t.next = t.next.Add(t.period)
+		}
+	}
+}
+
+func TestNodeClock(t *testing.T) {
+	c1, c2 := net.Pipe()
+	clock := newTestClock()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:         &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:      V2,
+		OutSystemID:     10,
+		Endpoints:       []EndpointConf{EndpointCustom{c1}},
+		HeartbeatPeriod: time.Hour,
+		Clock:           clock,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	// the heartbeat routine may not have created its ticker yet:
+	// advance the clock until a heartbeat is emitted.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-time.After(10 * time.Millisecond):
+				clock.advance(time.Hour)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, byte(10), fr.SystemID())
+			require.IsType(t, &MessageHeartbeat{}, fr.Message())
+			break
+		}
+	}
+}
+
+func TestNodeClockTimeout(t *testing.T) {
+	c1, _ := net.Pipe()
+	clock := newTestClock()
+
+	node, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		Clock:            clock,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	go func() {
+		for range node.Events() {
+		}
+	}()
+
+	sub := node.Subscribe(func(*EventFrame) bool {
+		return true
+	})
+	defer sub.Close()
+
+	// the timeout expires when the clock is advanced, without waiting real time
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-time.After(10 * time.Millisecond):
+				clock.advance(time.Hour)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	_, err = sub.Wait(context.Background(), time.Hour)
+	require.Equal(t, ErrTimeout, err)
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var dialectTypeSizes = map[string]int{
+	"double":   8,
+	"uint64_t": 8,
+	"int64_t":  8,
+	"float":    4,
+	"uint32_t": 4,
+	"int32_t":  4,
+	"uint16_t": 2,
+	"int16_t":  2,
+	"uint8_t":  1,
+	"int8_t":   1,
+	"char":     1,
+}
+
+// codecField contains the informations needed to encode and decode a field
+// without reflection.
+type codecField struct {
+	name      string
+	goType    string // Go type of a single element, or enum name
+	wireType  string // dialect type of a single element
+	arrayLen  int    // length of arrays and strings, 0 otherwise
+	isString  bool
+	isEnum    bool
+	extension bool
+	index     int
+}
+
+func (f *codecField) size() int {
+	if f.arrayLen > 0 {
+		return dialectTypeSizes[f.wireType] * f.arrayLen
+	}
+	return dialectTypeSizes[f.wireType]
+}
+
+// codecSortFields sorts fields as they appear in the encoded message.
+// https://mavlink.io/en/guide/serialization.html#field_reordering
+func codecSortFields(fields []*codecField) []*codecField {
+	out := append([]*codecField(nil), fields...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].extension && !out[j].extension {
+			if w1, w2 := dialectTypeSizes[out[i].wireType], dialectTypeSizes[out[j].wireType]; w1 != w2 {
+				return w1 > w2
+			}
+		}
+		return out[i].index < out[j].index
+	})
+	return out
+}
+
+// codecEncodeElem returns a statement that encodes a single element.
+func codecEncodeElem(f *codecField, value string, offset string) string {
+	if f.isEnum {
+		switch f.wireType {
+		case "uint8_t", "int8_t":
+			return fmt.Sprintf("buf[%s] = byte(%s)", offset, value)
+		case "uint16_t", "int16_t":
+			return fmt.Sprintf("binary.LittleEndian.PutUint16(buf[%s:], uint16(%s))", offset, value)
+		case "uint32_t", "int32_t":
+			return fmt.Sprintf("binary.LittleEndian.PutUint32(buf[%s:], uint32(%s))", offset, value)
+		default:
+			return fmt.Sprintf("binary.LittleEndian.PutUint64(buf[%s:], uint64(%s))", offset, value)
+		}
+	}
+
+	switch f.goType {
+	case "uint8":
+		return fmt.Sprintf("buf[%s] = %s", offset, value)
+	case "int8":
+		return fmt.Sprintf("buf[%s] = byte(%s)", offset, value)
+	case "uint16":
+		return fmt.Sprintf("binary.LittleEndian.PutUint16(buf[%s:], %s)", offset, value)
+	case "int16":
+		return fmt.Sprintf("binary.LittleEndian.PutUint16(buf[%s:], uint16(%s))", offset, value)
+	case "uint32":
+		return fmt.Sprintf("binary.LittleEndian.PutUint32(buf[%s:], %s)", offset, value)
+	case "int32":
+		return fmt.Sprintf("binary.LittleEndian.PutUint32(buf[%s:], uint32(%s))", offset, value)
+	case "uint64":
+		return fmt.Sprintf("binary.LittleEndian.PutUint64(buf[%s:], %s)", offset, value)
+	case "int64":
+		return fmt.Sprintf("binary.LittleEndian.PutUint64(buf[%s:], uint64(%s))", offset, value)
+	case "float32":
+		return fmt.Sprintf("binary.LittleEndian.PutUint32(buf[%s:], math.Float32bits(%s))", offset, value)
+	default: // float64
+		return fmt.Sprintf("binary.LittleEndian.PutUint64(buf[%s:], math.Float64bits(%s))", offset, value)
+	}
+}
+
+// codecDecodeElem returns a statement that decodes a single element.
+func codecDecodeElem(f *codecField, value string, offset string) string {
+	if f.isEnum {
+		switch f.wireType {
+		case "uint8_t", "int8_t":
+			return fmt.Sprintf("%s = %s(buf[%s])", value, f.goType, offset)
+		case "uint16_t", "int16_t":
+			return fmt.Sprintf("%s = %s(binary.LittleEndian.Uint16(buf[%s:]))", value, f.goType, offset)
+		case "uint32_t", "int32_t":
+			return fmt.Sprintf("%s = %s(binary.LittleEndian.Uint32(buf[%s:]))", value, f.goType, offset)
+		default:
+			return fmt.Sprintf("%s = %s(binary.LittleEndian.Uint64(buf[%s:]))", value, f.goType, offset)
+		}
+	}
+
+	switch f.goType {
+	case "uint8":
+		return fmt.Sprintf("%s = buf[%s]", value, offset)
+	case "int8":
+		return fmt.Sprintf("%s = int8(buf[%s])", value, offset)
+	case "uint16":
+		return fmt.Sprintf("%s = binary.LittleEndian.Uint16(buf[%s:])", value, offset)
+	case "int16":
+		return fmt.Sprintf("%s = int16(binary.LittleEndian.Uint16(buf[%s:]))", value, offset)
+	case "uint32":
+		return fmt.Sprintf("%s = binary.LittleEndian.Uint32(buf[%s:])", value, offset)
+	case "int32":
+		return fmt.Sprintf("%s = int32(binary.LittleEndian.Uint32(buf[%s:]))", value, offset)
+	case "uint64":
+		return fmt.Sprintf("%s = binary.LittleEndian.Uint64(buf[%s:])", value, offset)
+	case "int64":
+		return fmt.Sprintf("%s = int64(binary.LittleEndian.Uint64(buf[%s:]))", value, offset)
+	case "float32":
+		return fmt.Sprintf("%s = math.Float32frombits(binary.LittleEndian.Uint32(buf[%s:]))", value, offset)
+	default: // float64
+		return fmt.Sprintf("%s = math.Float64frombits(binary.LittleEndian.Uint64(buf[%s:]))", value, offset)
+	}
+}
+
+// codecGenerate generates the bodies of the MarshalPayload and
+// UnmarshalPayload methods of a message.
+func codecGenerate(fields []*codecField) (string, string) {
+	var enc strings.Builder
+	var dec strings.Builder
+	offset := 0
+	inExtensions := false
+
+	for _, f := range codecSortFields(fields) {
+		// extensions are not present in V1 frames
+		if f.extension && !inExtensions {
+			inExtensions = true
+			enc.WriteString("\tif !isV2 {\n\t\treturn\n\t}\n")
+			dec.WriteString("\tif !isV2 {\n\t\treturn\n\t}\n")
+		}
+
+		value := "m." + f.name
+
+		switch {
+		case f.isString:
+			fmt.Fprintf(&enc, "\tcopy(buf[%d:%d], %s)\n", offset, offset+f.arrayLen, value)
+			fmt.Fprintf(&dec, "\t%s = codecDecodeString(buf[%d:%d])\n", value, offset, offset+f.arrayLen)
+
+		case f.arrayLen > 0:
+			elemOffset := fmt.Sprintf("%d+i", offset)
+			if s := dialectTypeSizes[f.wireType]; s > 1 {
+				elemOffset = fmt.Sprintf("%d+i*%d", offset, s)
+			}
+			fmt.Fprintf(&enc, "\tfor i := 0; i < %d; i++ {\n\t\t%s\n\t}\n",
+				f.arrayLen, codecEncodeElem(f, value+"[i]", elemOffset))
+			fmt.Fprintf(&dec, "\tfor i := 0; i < %d; i++ {\n\t\t%s\n\t}\n",
+				f.arrayLen, codecDecodeElem(f, value+"[i]", elemOffset))
+
+		default:
+			fmt.Fprintf(&enc, "\t%s\n", codecEncodeElem(f, value, fmt.Sprintf("%d", offset)))
+			fmt.Fprintf(&dec, "\t%s\n", codecDecodeElem(f, value, fmt.Sprintf("%d", offset)))
+		}
+
+		offset += f.size()
+	}
+
+	return enc.String(), dec.String()
+}
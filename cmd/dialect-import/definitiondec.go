@@ -5,14 +5,26 @@ import (
 	"strconv"
 )
 
+type definitionEnumValueParam struct {
+	Index       int    `xml:"index,attr"`
+	Label       string `xml:"label,attr"`
+	Reserved    bool   `xml:"reserved,attr"`
+	Default     string `xml:"default,attr"`
+	Units       string `xml:"units,attr"`
+	Description string `xml:",chardata"`
+}
+
 type definitionEnumValue struct {
-	Value       string `xml:"value,attr"`
-	Name        string `xml:"name,attr"`
-	Description string `xml:"description"`
+	Value       string                      `xml:"value,attr"`
+	Name        string                      `xml:"name,attr"`
+	Description string                      `xml:"description"`
+	HasLocation bool                        `xml:"hasLocation,attr"`
+	Params      []*definitionEnumValueParam `xml:"param"`
 }
 
 type definitionEnum struct {
 	Name        string                 `xml:"name,attr"`
+	Bitmask     bool                   `xml:"bitmask,attr"`
 	Description string                 `xml:"description"`
 	Values      []*definitionEnumValue `xml:"entry"`
 }
@@ -22,6 +34,8 @@ type dialectField struct {
 	Type        string `xml:"type,attr"`
 	Name        string `xml:"name,attr"`
 	Enum        string `xml:"enum,attr"`
+	Units       string `xml:"units,attr"`
+	Multiplier  string `xml:"multiplier,attr"`
 	Description string `xml:",innerxml"`
 }
 
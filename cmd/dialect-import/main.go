@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"net/url"
 	"os"
@@ -14,6 +16,8 @@ import (
 	"text/template"
 
 	"gopkg.in/alecthomas/kingpin.v2"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
 )
 
 var (
@@ -29,13 +33,21 @@ var tplDialect = template.Must(template.New("").Parse(
 package {{ .PkgName }}
 
 import (
+{{- if .CodecBinary }}
+	"encoding/binary"
+{{- end }}
 {{- if .Enums }}
 	"errors"
+{{- end }}
+{{- if or .CodecMath .CommandMath }}
+	"math"
+{{- end }}
+{{- if .Enums }}
 	"strconv"
 {{- end }}
 
-	"github.com/aler9/gomavlib/pkg/msg"
-	"github.com/aler9/gomavlib/pkg/dialect"
+	"{{ .ImportPath }}/pkg/msg"
+	"{{ .ImportPath }}/pkg/dialect"
 )
 
 // Dialect contains the dialect object that can be passed to the library.
@@ -51,9 +63,41 @@ var dial = &dialect.Dialect{ {{.Version}}, []msg.Message{
 {{- end }}
 } }
 
+// Metadata contains the metadata of the messages of the dialect.
+var Metadata = meta
+
+// metadata is not exposed directly such that it is not displayed in godoc.
+var meta = dialect.Metadata{
+{{- range .Defs }}
+{{- range .Messages }}
+	{{ .ID }}: {
+		Name:        {{ printf "%q" .DefName }},
+		Description: {{ printf "%q" .Description }},
+		Fields: []*dialect.FieldMetadata{
+{{- range .Fields }}
+			{{ .Metadata }},
+{{- end }}
+		},
+	},
+{{- end }}
+{{- end }}
+}
+{{- if .CodecString }}
+
+// codecDecodeString decodes a null-terminated string.
+func codecDecodeString(buf []byte) string {
+	for i, b := range buf {
+		if b == 0 {
+			return string(buf[:i])
+		}
+	}
+	return string(buf)
+}
+{{- end }}
+
 {{ range .Enums }}
 // {{ .Description }}
-type {{ .Name }} int
+type {{ .Name }} {{ .Type }}
 
 const (
 {{- $pn := .Name }}
@@ -92,9 +136,77 @@ func (e {{ .Name }}) String() string {
 	if err == nil {
 		return string(byts)
 	}
+{{- if eq .Type "int" }}
 	return strconv.FormatInt(int64(e), 10)
+{{- else }}
+	return strconv.FormatUint(uint64(e), 10)
+{{- end }}
+}
+
+// {{ .Name }}FromString returns the {{ .Name }} value with given name.
+func {{ .Name }}FromString(s string) ({{ .Name }}, error) {
+	var e {{ .Name }}
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+{{- if .Bitmask }}
+
+// Has returns whether all the given flags are set.
+func (e {{ .Name }}) Has(flags {{ .Name }}) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e {{ .Name }}) Set(flags {{ .Name }}) {{ .Name }} {
+	return e | flags
 }
 
+// Clear returns the value with the given flags cleared.
+func (e {{ .Name }}) Clear(flags {{ .Name }}) {{ .Name }} {
+	return e &^ flags
+}
+{{- end }}
+
+{{ end }}
+
+{{ range .Commands }}
+// {{ .Description }}
+type {{ .Name }} struct {
+{{- range .Params }}
+	// {{ .Description }}
+	{{ .Name }} {{ .Type }}
+{{- end }}
+}
+
+// GetCommand implements the dialect.Command interface.
+func (*{{ .Name }}) GetCommand() uint32 {
+	return {{ .ID }}
+}
+
+// MarshalParams implements the dialect.Command interface.
+func (c *{{ .Name }}) MarshalParams() [7]float32 {
+	return [7]float32{ {{ .Marshal }} }
+}
+
+// UnmarshalParams implements the dialect.Command interface.
+func (c *{{ .Name }}) UnmarshalParams(p [7]float32) {
+{{- range .Params }}
+	c.{{ .Name }} = {{ .Unmarshal }}
+{{- end }}
+}
+{{- if .LocationX }}
+
+// MarshalLocation implements the dialect.CommandLocation interface.
+func (c *{{ .Name }}) MarshalLocation() (int32, int32) {
+	return c.{{ .LocationX }}, c.{{ .LocationY }}
+}
+
+// UnmarshalLocation implements the dialect.CommandLocation interface.
+func (c *{{ .Name }}) UnmarshalLocation(x int32, y int32) {
+	c.{{ .LocationX }} = x
+	c.{{ .LocationY }} = y
+}
+{{- end }}
 {{ end }}
 
 {{ range .Defs }}
@@ -113,6 +225,16 @@ type Message{{ .Name }} struct {
 func (*Message{{ .Name }}) GetID() uint32 {
     return {{ .ID }}
 }
+{{- if $.Codec }}
+
+// MarshalPayload implements the msg.Marshaler interface.
+func (m *Message{{ .Name }}) MarshalPayload(buf []byte, isV2 bool) {
+{{ .Marshal }}}
+
+// UnmarshalPayload implements the msg.Unmarshaler interface.
+func (m *Message{{ .Name }}) UnmarshalPayload(buf []byte, isV2 bool) {
+{{ .Unmarshal }}}
+{{- end }}
 {{ end }}
 {{- end }}
 `))
@@ -158,24 +280,60 @@ type outEnumValue struct {
 	Value       string
 	Name        string
 	Description string
+
+	// command parameters, used by MAV_CMD values
+	params      []*definitionEnumValueParam
+	hasLocation bool
 }
 
 type outEnum struct {
 	Name        string
 	Description string
+	Type        string
+	Bitmask     bool
 	Values      []*outEnumValue
 }
 
+type outCommandParam struct {
+	Index       int
+	Name        string
+	Type        string
+	Description string
+	Unmarshal   string
+}
+
+type outCommand struct {
+	Name        string
+	Description string
+	ID          string
+	Params      []*outCommandParam
+	Marshal     string
+
+	// parameters that contain the latitude and longitude, if any
+	LocationX string
+	LocationY string
+}
+
 type outField struct {
 	Description string
 	Line        string
+	Metadata    string
+
+	// enum name and type, used to size enums
+	enum     string
+	enumType string
+
+	codec *codecField
 }
 
 type outMessage struct {
 	Name        string
+	DefName     string
 	Description string
 	ID          int
 	Fields      []*outField
+	Marshal     string
+	Unmarshal   string
 }
 
 type outDefinition struct {
@@ -184,7 +342,13 @@ type outDefinition struct {
 	Messages []*outMessage
 }
 
-func definitionProcess(version *string, defsProcessed map[string]struct{}, isRemote bool, defAddr string) ([]*outDefinition, error) {
+func definitionProcess(
+	version *string,
+	defsProcessed map[string]struct{},
+	isRemote bool,
+	includeDirs []string,
+	defAddr string,
+) ([]*outDefinition, error) {
 	// skip already processed
 	if _, ok := defsProcessed[defAddr]; ok {
 		return nil, nil
@@ -220,8 +384,14 @@ func definitionProcess(version *string, defsProcessed map[string]struct{}, isRem
 		// prepend url to remote address
 		if isRemote {
 			inc = addrPath + inc
+		} else {
+			var err error
+			inc, err = includeResolve(addrPath, includeDirs, inc)
+			if err != nil {
+				return nil, err
+			}
 		}
-		subDefs, err := definitionProcess(version, defsProcessed, isRemote, inc)
+		subDefs, err := definitionProcess(version, defsProcessed, isRemote, includeDirs, inc)
 		if err != nil {
 			return nil, err
 		}
@@ -237,12 +407,15 @@ func definitionProcess(version *string, defsProcessed map[string]struct{}, isRem
 		oute := &outEnum{
 			Name:        enum.Name,
 			Description: filterDesc(enum.Description),
+			Bitmask:     enum.Bitmask,
 		}
 		for _, val := range enum.Values {
 			oute.Values = append(oute.Values, &outEnumValue{
 				Value:       val.Value,
 				Name:        val.Name,
 				Description: filterDesc(val.Description),
+				params:      val.Params,
+				hasLocation: val.HasLocation,
 			})
 		}
 		outDef.Enums = append(outDef.Enums, oute)
@@ -261,6 +434,23 @@ func definitionProcess(version *string, defsProcessed map[string]struct{}, isRem
 	return outDefs, nil
 }
 
+// includeResolve finds a local include, first in the folder of the
+// definition that includes it, then in the include directories.
+func includeResolve(addrPath string, includeDirs []string, inc string) (string, error) {
+	if filepath.IsAbs(inc) {
+		return inc, nil
+	}
+
+	for _, dir := range append([]string{addrPath}, includeDirs...) {
+		fpath := filepath.Clean(filepath.Join(dir, inc))
+		if _, err := os.Stat(fpath); err == nil {
+			return fpath, nil
+		}
+	}
+
+	return "", fmt.Errorf("include '%s' not found", inc)
+}
+
 func definitionGet(isRemote bool, defAddr string) ([]byte, error) {
 	if isRemote {
 		byt, err := download(defAddr)
@@ -302,18 +492,24 @@ func messageProcess(msg *definitionMessage) (*outMessage, error) {
 
 	outMsg := &outMessage{
 		Name:        dialectMsgDefToGo(msg.Name),
+		DefName:     msg.Name,
 		Description: filterDesc(msg.Description),
 		ID:          msg.ID,
 	}
 
-	for _, f := range msg.Fields {
+	var codecFields []*codecField
+	for i, f := range msg.Fields {
 		outField, err := fieldProcess(f)
 		if err != nil {
 			return nil, err
 		}
+		outField.codec.index = i
 		outMsg.Fields = append(outMsg.Fields, outField)
+		codecFields = append(codecFields, outField.codec)
 	}
 
+	outMsg.Marshal, outMsg.Unmarshal = codecGenerate(codecFields)
+
 	return outMsg, nil
 }
 
@@ -357,9 +553,31 @@ func fieldProcess(field *dialectField) (*outField, error) {
 		tags["mavext"] = "true"
 	}
 
+	outF.codec = &codecField{
+		name:      newname,
+		wireType:  typ,
+		isEnum:    field.Enum != "",
+		extension: field.Extension,
+	}
+	if typ == "char" {
+		outF.codec.isString = true
+		outF.codec.arrayLen = 1
+		if l, ok := tags["mavlen"]; ok {
+			outF.codec.arrayLen, _ = strconv.Atoi(l)
+		}
+	} else if arrayLen != "" {
+		outF.codec.arrayLen, _ = strconv.Atoi(arrayLen)
+	}
+
+	wireType := typ
 	typ = dialectTypeToGo[typ]
 	if typ == "" {
-		return nil, fmt.Errorf("unknown type: %s", typ)
+		return nil, fmt.Errorf("unknown type: %s", wireType)
+	}
+
+	outF.codec.goType = typ
+	if field.Enum != "" {
+		outF.codec.goType = field.Enum
 	}
 
 	outF.Line += " "
@@ -369,6 +587,8 @@ func fieldProcess(field *dialectField) (*outField, error) {
 	if field.Enum != "" {
 		outF.Line += field.Enum
 		tags["mavenum"] = typ
+		outF.enum = field.Enum
+		outF.enumType = typ
 	} else {
 		outF.Line += typ
 	}
@@ -381,31 +601,231 @@ func fieldProcess(field *dialectField) (*outField, error) {
 		sort.Strings(tmp)
 		outF.Line += " `" + strings.Join(tmp, " ") + "`"
 	}
+
+	metadata, err := fieldMetadata(field, arrayLen)
+	if err != nil {
+		return nil, err
+	}
+	outF.Metadata = metadata
+
 	return outF, nil
 }
 
-func run() error {
-	kingpin.CommandLine.Help = "Convert Mavlink dialects from XML format into Go format."
+// fieldMetadata returns a dialect.FieldMetadata literal that describes a field.
+func fieldMetadata(field *dialectField, arrayLen string) (string, error) {
+	typ := field.Type
+	if typ == "uint8_t_mavlink_version" {
+		typ = "uint8_t"
+	}
+	if matches := reTypeIsArray.FindStringSubmatch(typ); matches != nil {
+		typ = matches[1]
+		arrayLen = matches[2]
+	}
 
-	argPkgName := kingpin.Flag("package", "Package name").Default("main").String()
-	argComment := kingpin.Flag("comment", "comment to add before the package name").Default("").String()
-	argMainDef := kingpin.Arg("xml", "Path or url pointing to a XML Mavlink dialect").Required().String()
+	entries := []string{
+		fmt.Sprintf("Name: %q", field.Name),
+		fmt.Sprintf("Type: %q", typ),
+	}
+	if arrayLen != "" {
+		entries = append(entries, "ArrayLength: "+arrayLen)
+	}
+	if field.Enum != "" {
+		entries = append(entries, fmt.Sprintf("Enum: %q", field.Enum))
+	}
+	if field.Units != "" {
+		entries = append(entries, fmt.Sprintf("Units: %q", field.Units))
+	}
 
-	kingpin.Parse()
+	scale := float64(1)
+	if field.Multiplier != "" {
+		v, err := strconv.ParseFloat(field.Multiplier, 64)
+		if err != nil {
+			return "", fmt.Errorf("field '%s' has invalid multiplier: %s", field.Name, field.Multiplier)
+		}
+		entries = append(entries, "Multiplier: "+strconv.FormatFloat(v, 'g', -1, 64))
+		scale = v
+	}
+	if field.Units != "" {
+		scaledUnits, factor := dialect.UnitScale(field.Units)
+		if scaledUnits != field.Units {
+			entries = append(entries, fmt.Sprintf("ScaledUnits: %q", scaledUnits))
+		}
+		scale *= factor
+	}
+	if scale != 1 {
+		entries = append(entries, "Scale: "+strconv.FormatFloat(scale, 'g', -1, 64))
+	}
+	if desc := filterDesc(field.Description); desc != "" {
+		entries = append(entries, fmt.Sprintf("Description: %q", desc))
+	}
+	if field.Extension {
+		entries = append(entries, "Extension: true")
+	}
 
-	mainDef := *argMainDef
-	comment := *argComment
-	pkgName := *argPkgName
+	return "{" + strings.Join(entries, ", ") + "}", nil
+}
 
+// enumType returns the Go type of an enum, given its values and the types
+// of the fields that use it.
+// Enums are ints, unless their values exceed int32: in this case, an unsigned
+// type is used, large enough to contain both the values and the fields,
+// in order to allow builds on 32-bit platforms.
+func enumType(enum *outEnum, usages []string) (string, error) {
+	var max uint64
+	for _, v := range enum.Values {
+		u, err := strconv.ParseUint(v.Value, 0, 64)
+		if err != nil {
+			// negative values always fit in an int
+			if _, err2 := strconv.ParseInt(v.Value, 0, 64); err2 == nil {
+				continue
+			}
+			return "", fmt.Errorf("enum %s has an invalid value: %s", enum.Name, v.Value)
+		}
+		if u > max {
+			max = u
+		}
+	}
+
+	if max <= math.MaxInt32 {
+		return "int", nil
+	}
+
+	if max > math.MaxUint32 {
+		return "uint64", nil
+	}
+
+	for _, typ := range usages {
+		if typ == "uint64" || typ == "int64" {
+			return "uint64", nil
+		}
+	}
+
+	return "uint32", nil
+}
+
+// commandParamName returns the Go name of a command parameter, given its label.
+func commandParamName(label string, index int) string {
+	var name string
+	for _, word := range regexp.MustCompile("[^A-Za-z0-9]+").Split(label, -1) {
+		if word != "" {
+			name += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return "Param" + strconv.Itoa(index)
+	}
+	return name
+}
+
+// commandParamIsCoordinate returns whether a command parameter is
+// a latitude or a longitude, that are param5 and param6 of commands
+// with a location.
+func commandParamIsCoordinate(val *outEnumValue, p *definitionEnumValueParam) bool {
+	if p.Index != 5 && p.Index != 6 {
+		return false
+	}
+
+	if val.hasLocation || p.Units == "degE7" {
+		return true
+	}
+
+	label := strings.ToLower(p.Label)
+	return label == "latitude" || label == "longitude"
+}
+
+// commandProcess converts a MAV_CMD value into a typed command, whose
+// parameters are the labeled or non-empty parameters of the definition.
+func commandProcess(val *outEnumValue) *outCommand {
+	cmd := &outCommand{
+		Name:        "Cmd" + dialectMsgDefToGo(strings.TrimPrefix(val.Name, "MAV_CMD_")),
+		Description: val.Description,
+		ID:          val.Value,
+	}
+
+	marshal := []string{"0", "0", "0", "0", "0", "0", "0"}
+	used := make(map[string]struct{})
+
+	for _, p := range val.params {
+		if p.Index < 1 || p.Index > 7 {
+			continue
+		}
+
+		desc := filterDesc(strings.TrimSpace(p.Description))
+
+		if p.Reserved || (p.Label == "" && (desc == "" || strings.HasPrefix(desc, "Empty") ||
+			strings.HasPrefix(desc, "Reserved"))) {
+			if p.Default == "NaN" {
+				marshal[p.Index-1] = "float32(math.NaN())"
+			}
+			continue
+		}
+
+		name := commandParamName(p.Label, p.Index)
+		if _, ok := used[name]; ok {
+			name += strconv.Itoa(p.Index)
+		}
+		used[name] = struct{}{}
+
+		param := &outCommandParam{
+			Index:       p.Index - 1,
+			Name:        name,
+			Type:        "float32",
+			Description: desc,
+			Unmarshal:   "p[" + strconv.Itoa(p.Index-1) + "]",
+		}
+
+		// coordinates are stored in degE7, as in COMMAND_INT, in order
+		// not to lose precision. COMMAND_LONG contains them in degrees.
+		if commandParamIsCoordinate(val, p) {
+			param.Type = "int32"
+			param.Unmarshal = "int32(math.Round(float64(p[" + strconv.Itoa(p.Index-1) + "]) * 1e7))"
+			marshal[p.Index-1] = "float32(float64(c." + name + ") / 1e7)"
+
+			if p.Index == 5 {
+				cmd.LocationX = name
+			} else {
+				cmd.LocationY = name
+			}
+		} else {
+			marshal[p.Index-1] = "c." + name
+		}
+
+		cmd.Params = append(cmd.Params, param)
+	}
+
+	// both coordinates are needed to implement dialect.CommandLocation
+	if cmd.LocationX == "" || cmd.LocationY == "" {
+		cmd.LocationX = ""
+		cmd.LocationY = ""
+	}
+
+	cmd.Marshal = strings.Join(marshal, ", ")
+	return cmd
+}
+
+type generateConf struct {
+	pkgName     string
+	comment     string
+	importPath  string
+	codec       bool
+	includeDirs []string
+}
+
+func generate(w io.Writer, conf generateConf, mainDef string) error {
 	version := ""
 	defsProcessed := make(map[string]struct{})
 	isRemote := func() bool {
-		_, err := url.ParseRequestURI(mainDef)
-		return err == nil
+		u, err := url.ParseRequestURI(mainDef)
+		return err == nil && u.Scheme != ""
 	}()
 
+	if !isRemote {
+		mainDef = filepath.Clean(mainDef)
+	}
+
 	// parse all definitions recursively
-	outDefs, err := definitionProcess(&version, defsProcessed, isRemote, mainDef)
+	outDefs, err := definitionProcess(&version, defsProcessed, isRemote, conf.includeDirs, mainDef)
 	if err != nil {
 		return err
 	}
@@ -422,6 +842,7 @@ func run() error {
 			}
 			enum := enums[defEnum.Name]
 
+			enum.Bitmask = enum.Bitmask || defEnum.Bitmask
 			enum.Values = append(enum.Values, defEnum.Values...)
 		}
 	}
@@ -440,19 +861,150 @@ func run() error {
 		}
 	}
 
+	// size enums
+	enumUsages := make(map[string][]string)
+	for _, def := range outDefs {
+		for _, msg := range def.Messages {
+			for _, f := range msg.Fields {
+				if f.enum != "" {
+					enumUsages[f.enum] = append(enumUsages[f.enum], f.enumType)
+				}
+			}
+		}
+	}
+	for _, enum := range enums {
+		typ, err := enumType(enum, enumUsages[enum.Name])
+		if err != nil {
+			return err
+		}
+		enum.Type = typ
+	}
+
+	// typed commands
+	var commands []*outCommand
+	commandMath := false
+	if enum, ok := enums["MAV_CMD"]; ok {
+		for _, v := range enum.Values {
+			cmd := commandProcess(v)
+			commandMath = commandMath || strings.Contains(cmd.Marshal, "math.")
+			for _, p := range cmd.Params {
+				commandMath = commandMath || strings.Contains(p.Unmarshal, "math.")
+			}
+			commands = append(commands, cmd)
+		}
+	}
+
+	// check which packages are needed by codecs
+	var codecBinary, codecMath, codecString bool
+	if conf.codec {
+		for _, def := range outDefs {
+			for _, msg := range def.Messages {
+				codecBinary = codecBinary || strings.Contains(msg.Marshal, "binary.")
+				codecMath = codecMath || strings.Contains(msg.Marshal, "math.")
+				codecString = codecString || strings.Contains(msg.Unmarshal, "codecDecodeString")
+			}
+		}
+	}
+
 	// dump
-	return tplDialect.Execute(os.Stdout, map[string]interface{}{
-		"PkgName": pkgName,
-		"Comment": comment,
+	return tplDialect.Execute(w, map[string]interface{}{
+		"PkgName":     conf.pkgName,
+		"Comment":     conf.comment,
+		"ImportPath":  conf.importPath,
+		"Codec":       conf.codec,
+		"CodecBinary": codecBinary,
+		"CodecMath":   codecMath,
+		"CodecString": codecString,
 		"Version": func() int {
 			ret, _ := strconv.Atoi(version)
 			return ret
 		}(),
-		"Defs":  outDefs,
-		"Enums": enums,
+		"Defs":        outDefs,
+		"Enums":       enums,
+		"Commands":    commands,
+		"CommandMath": commandMath,
 	})
 }
 
+// dialectPkgName returns the package name of a dialect, given the address
+// of its definition, i.e. "common" for ".../common.xml".
+func dialectPkgName(defAddr string) string {
+	name := defAddr[strings.LastIndexAny(defAddr, "/\\")+1:]
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	return strings.ReplaceAll(strings.ToLower(name), "_", "")
+}
+
+func generateFile(fpath string, conf generateConf, mainDef string) error {
+	err := os.MkdirAll(filepath.Dir(fpath), 0o755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(fpath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return generate(f, conf, mainDef)
+}
+
+func run() error {
+	kingpin.CommandLine.Help = "Convert Mavlink dialects from XML format into Go format."
+
+	argPkgName := kingpin.Flag("package", "Package name. When multiple dialects are generated,"+
+		" it defaults to the name of each definition").Default("").String()
+	argComment := kingpin.Flag("comment", "comment to add before the package name").Default("").String()
+	argCodec := kingpin.Flag("codec", "generate methods that encode and decode messages without reflection").Bool()
+	argIncludeDirs := kingpin.Flag("include-dir", "directory where included local definitions are searched,"+
+		" in addition to the directory of the including definition (can be repeated)").Strings()
+	argOutputDir := kingpin.Flag("output-dir", "write each dialect into <output-dir>/<package>/dialect.go"+
+		" instead of the standard output").Default("").String()
+	argImportPath := kingpin.Flag("import-path", "import path of the library").
+		Default("github.com/aler9/gomavlib").String()
+	argMainDefs := kingpin.Arg("xml", "Paths or urls pointing to XML Mavlink dialects").Required().Strings()
+
+	kingpin.Parse()
+
+	conf := generateConf{
+		pkgName:     *argPkgName,
+		comment:     *argComment,
+		importPath:  *argImportPath,
+		codec:       *argCodec,
+		includeDirs: *argIncludeDirs,
+	}
+
+	if *argOutputDir == "" {
+		if len(*argMainDefs) != 1 {
+			return fmt.Errorf("multiple dialects can be generated only when --output-dir is set")
+		}
+
+		if conf.pkgName == "" {
+			conf.pkgName = "main"
+		}
+
+		return generate(os.Stdout, conf, (*argMainDefs)[0])
+	}
+
+	if len(*argMainDefs) != 1 && conf.pkgName != "" {
+		return fmt.Errorf("--package can't be used when generating multiple dialects")
+	}
+
+	for _, mainDef := range *argMainDefs {
+		dconf := conf
+		if dconf.pkgName == "" {
+			dconf.pkgName = dialectPkgName(mainDef)
+		}
+
+		err := generateFile(filepath.Join(*argOutputDir, dconf.pkgName, "dialect.go"), dconf, mainDef)
+		if err != nil {
+			return fmt.Errorf("%s: %s", mainDef, err)
+		}
+	}
+
+	return nil
+}
+
 func main() {
 	err := run()
 	if err != nil {
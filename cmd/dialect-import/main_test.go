@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumType(t *testing.T) {
+	for _, ca := range []struct {
+		name   string
+		values []string
+		usages []string
+		typ    string
+	}{
+		{
+			"8 bits",
+			[]string{"0", "1", "255"},
+			[]string{"uint8"},
+			"int",
+		},
+		{
+			"16 bits",
+			[]string{"0", "256", "65535"},
+			[]string{"uint16"},
+			"int",
+		},
+		{
+			"negative",
+			[]string{"-1", "0", "1"},
+			[]string{"int8"},
+			"int",
+		},
+		{
+			"bitmask 32 bits",
+			[]string{"1", "2", "0x80000000"},
+			[]string{"uint32"},
+			"uint32",
+		},
+		{
+			"bitmask 32 bits in 64 bits field",
+			[]string{"1", "2", "0x80000000"},
+			[]string{"uint32", "uint64"},
+			"uint64",
+		},
+		{
+			"bitmask 64 bits",
+			[]string{"1", "0x8000000000000000"},
+			[]string{"uint64"},
+			"uint64",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			enum := &outEnum{Name: "TEST_ENUM"}
+			for _, v := range ca.values {
+				enum.Values = append(enum.Values, &outEnumValue{Value: v})
+			}
+
+			typ, err := enumType(enum, ca.usages)
+			require.NoError(t, err)
+			require.Equal(t, ca.typ, typ)
+		})
+	}
+}
+
+func TestGenerateEnums(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dialect-import")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fpath := filepath.Join(dir, "test.xml")
+	err = ioutil.WriteFile(fpath, []byte(`<?xml version="1.0"?>
+<mavlink>
+  <version>3</version>
+  <enums>
+    <enum name="SMALL_ENUM">
+      <description>An enum that fits in 8 bits.</description>
+      <entry value="200" name="SMALL_ENUM_A"><description>A.</description></entry>
+    </enum>
+    <enum name="MEDIUM_ENUM">
+      <description>An enum that needs 16 bits.</description>
+      <entry value="1000" name="MEDIUM_ENUM_A"><description>A.</description></entry>
+    </enum>
+    <enum name="LARGE_FLAGS" bitmask="true">
+      <description>A bitmask that needs 32 bits.</description>
+      <entry value="1" name="LARGE_FLAGS_A"><description>A.</description></entry>
+      <entry value="2147483648" name="LARGE_FLAGS_B"><description>B.</description></entry>
+    </enum>
+  </enums>
+  <messages>
+    <message id="1" name="TEST">
+      <description>Test message.</description>
+      <field type="uint8_t" name="small" enum="SMALL_ENUM">Small.</field>
+      <field type="uint16_t" name="medium" enum="MEDIUM_ENUM">Medium.</field>
+      <field type="uint32_t" name="flags" enum="LARGE_FLAGS">Flags.</field>
+    </message>
+  </messages>
+</mavlink>
+`), 0o644)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = generate(&buf, generateConf{
+		pkgName:    "test",
+		importPath: "github.com/aler9/gomavlib",
+	}, fpath)
+	require.NoError(t, err)
+	out := buf.String()
+
+	require.Contains(t, out, "type SMALL_ENUM int\n")
+	require.Contains(t, out, "type MEDIUM_ENUM int\n")
+	require.Contains(t, out, "type LARGE_FLAGS uint32\n")
+	require.Contains(t, out, "return strconv.FormatUint(uint64(e), 10)")
+	require.Contains(t, out, "func (e LARGE_FLAGS) Has(flags LARGE_FLAGS) bool {")
+	require.NotContains(t, out, "func (e SMALL_ENUM) Has(")
+	require.Contains(t, out, "Small SMALL_ENUM `mavenum:\"uint8\"`")
+	require.Contains(t, out, "Medium MEDIUM_ENUM `mavenum:\"uint16\"`")
+	require.Contains(t, out, "Flags LARGE_FLAGS `mavenum:\"uint32\"`")
+}
@@ -0,0 +1,294 @@
+// mavconvert converts telemetry logs and raw captures into JSON or CSV.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+
+	"github.com/aler9/gomavlib/pkg/csvlog"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+	"github.com/aler9/gomavlib/pkg/dialects/asluav"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/dialects/icarous"
+	"github.com/aler9/gomavlib/pkg/dialects/matrixpilot"
+	"github.com/aler9/gomavlib/pkg/dialects/minimal"
+	"github.com/aler9/gomavlib/pkg/dialects/paparazzi"
+	"github.com/aler9/gomavlib/pkg/dialects/standard"
+	"github.com/aler9/gomavlib/pkg/dialects/ualberta"
+	"github.com/aler9/gomavlib/pkg/dialects/uavionix"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/tlog"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+var dialects = map[string]*dialect.Dialect{
+	"ardupilotmega": ardupilotmega.Dialect,
+	"asluav":        asluav.Dialect,
+	"common":        common.Dialect,
+	"icarous":       icarous.Dialect,
+	"matrixpilot":   matrixpilot.Dialect,
+	"minimal":       minimal.Dialect,
+	"paparazzi":     paparazzi.Dialect,
+	"standard":      standard.Dialect,
+	"ualberta":      ualberta.Dialect,
+	"uavionix":      uavionix.Dialect,
+}
+
+type jsonFrame struct {
+	Time        *time.Time      `json:"time,omitempty"`
+	SystemID    byte            `json:"system_id"`
+	ComponentID byte            `json:"component_id"`
+	MessageID   uint32          `json:"message_id"`
+	Name        string          `json:"name,omitempty"`
+	Message     json.RawMessage `json:"message,omitempty"`
+	MessageRaw  []byte          `json:"message_raw,omitempty"`
+}
+
+// frameWriter is implemented by the output formats.
+type frameWriter interface {
+	WriteFrame(t time.Time, fr frame.Frame) error
+	Close() error
+}
+
+type jsonWriter struct {
+	de *dialect.DecEncoder
+	f  *os.File
+	bw *bufio.Writer
+}
+
+func (w *jsonWriter) WriteFrame(t time.Time, fr frame.Frame) error {
+	m := fr.GetMessage()
+
+	out := jsonFrame{
+		SystemID:    fr.GetSystemID(),
+		ComponentID: fr.GetComponentID(),
+		MessageID:   m.GetID(),
+	}
+
+	// raw captures don't contain the reception time
+	if !t.IsZero() {
+		t = t.UTC()
+		out.Time = &t
+	}
+
+	if mr, ok := m.(*msg.MessageRaw); ok {
+		out.MessageRaw = mr.Content
+	} else {
+		out.Name = w.de.MessageDEs[m.GetID()].Name()
+
+		var err error
+		out.Message, err = w.de.EncodeMessageJSON(m)
+		if err != nil {
+			return err
+		}
+	}
+
+	byts, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.bw.Write(append(byts, '\n'))
+	return err
+}
+
+func (w *jsonWriter) Close() error {
+	err := w.bw.Flush()
+	if w.f != os.Stdout {
+		err2 := w.f.Close()
+		if err == nil {
+			err = err2
+		}
+	}
+	return err
+}
+
+type csvWriter struct {
+	w *csvlog.Writer
+}
+
+func (w *csvWriter) WriteFrame(t time.Time, fr frame.Frame) error {
+	return w.w.WriteFrame(t, fr)
+}
+
+func (w *csvWriter) Close() error {
+	err := w.w.Flush()
+	err2 := w.w.Close()
+	if err == nil {
+		err = err2
+	}
+	return err
+}
+
+func run() error {
+	kingpin.CommandLine.Help = "Convert telemetry logs (tlog) and raw captures into JSON or CSV."
+
+	dialectNames := make([]string, 0, len(dialects))
+	for name := range dialects {
+		dialectNames = append(dialectNames, name)
+	}
+	sort.Strings(dialectNames)
+
+	argDialect := kingpin.Flag("dialect", "dialect used to decode messages").
+		Default("ardupilotmega").Enum(dialectNames...)
+	argRaw := kingpin.Flag("raw", "the input is a raw capture, i.e. a sequence of frames without timestamps").Bool()
+	argFormat := kingpin.Flag("format", "output format; JSON produces a frame per line, "+
+		"while CSV produces a file for each message type").Default("json").Enum("json", "csv")
+	argOutput := kingpin.Flag("output", "output file in case of JSON (defaults to the standard output),"+
+		" output directory in case of CSV (required)").Default("").String()
+	argSystemIDs := kingpin.Flag("sysid", "convert only frames with these system IDs"+
+		" (comma-separated, can be repeated)").Strings()
+	argNames := kingpin.Flag("name", "convert only frames with these message names"+
+		" (comma-separated, can be repeated)").Strings()
+	argInput := kingpin.Arg("input", "path of the telemetry log or raw capture").Required().String()
+
+	kingpin.Parse()
+
+	d := dialects[*argDialect]
+
+	de, err := dialect.NewDecEncoder(d)
+	if err != nil {
+		return err
+	}
+
+	var systemIDs map[byte]struct{}
+	for _, v := range *argSystemIDs {
+		for _, part := range strings.Split(v, ",") {
+			id, err := strconv.ParseUint(part, 10, 8)
+			if err != nil {
+				return fmt.Errorf("invalid system ID '%s'", part)
+			}
+
+			if systemIDs == nil {
+				systemIDs = make(map[byte]struct{})
+			}
+			systemIDs[byte(id)] = struct{}{}
+		}
+	}
+
+	var messageIDs map[uint32]struct{}
+	var messages []msg.Message
+	for _, v := range *argNames {
+		for _, name := range strings.Split(v, ",") {
+			m, ok := d.GetMessageByName(strings.ToUpper(name))
+			if !ok {
+				return fmt.Errorf("message '%s' is not in the dialect", name)
+			}
+
+			if messageIDs == nil {
+				messageIDs = make(map[uint32]struct{})
+			}
+			messageIDs[m.GetID()] = struct{}{}
+			messages = append(messages, m)
+		}
+	}
+
+	f, err := os.Open(*argInput)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r *tlog.Reader
+	if *argRaw {
+		r, err = tlog.NewRawReader(bufio.NewReader(f), d)
+	} else {
+		r, err = tlog.NewReader(bufio.NewReader(f), d)
+	}
+	if err != nil {
+		return err
+	}
+
+	var w frameWriter
+
+	switch *argFormat {
+	case "json":
+		out := os.Stdout
+		if *argOutput != "" {
+			out, err = os.Create(*argOutput)
+			if err != nil {
+				return err
+			}
+		}
+		w = &jsonWriter{de: de, f: out, bw: bufio.NewWriter(out)}
+
+	case "csv":
+		if *argOutput == "" {
+			return fmt.Errorf("--output is required when the format is CSV")
+		}
+
+		cw, err := csvlog.NewWriter(*argOutput, messages...)
+		if err != nil {
+			return err
+		}
+		w = &csvWriter{w: cw}
+	}
+
+	count := 0
+	parseErrors := 0
+
+	for {
+		t, fr, err := r.ReadFrame()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			var terr *transceiver.Error
+			if errors.As(err, &terr) {
+				parseErrors++
+				continue
+			}
+
+			w.Close()
+			return err
+		}
+
+		if systemIDs != nil {
+			if _, ok := systemIDs[fr.GetSystemID()]; !ok {
+				continue
+			}
+		}
+
+		if messageIDs != nil {
+			if _, ok := messageIDs[fr.GetMessage().GetID()]; !ok {
+				continue
+			}
+		}
+
+		err = w.WriteFrame(t, fr)
+		if err != nil {
+			w.Close()
+			return err
+		}
+		count++
+	}
+
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "converted %d frames, %d parse errors\n", count, parseErrors)
+	return nil
+}
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERR: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,285 @@
+// mavdump is a Mavlink sniffer, that prints the frames received from an endpoint.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+	"github.com/aler9/gomavlib/pkg/dialects/asluav"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/dialects/icarous"
+	"github.com/aler9/gomavlib/pkg/dialects/matrixpilot"
+	"github.com/aler9/gomavlib/pkg/dialects/minimal"
+	"github.com/aler9/gomavlib/pkg/dialects/paparazzi"
+	"github.com/aler9/gomavlib/pkg/dialects/standard"
+	"github.com/aler9/gomavlib/pkg/dialects/ualberta"
+	"github.com/aler9/gomavlib/pkg/dialects/uavionix"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type dialectEntry struct {
+	dialect  *dialect.Dialect
+	metadata dialect.Metadata
+}
+
+var dialects = map[string]dialectEntry{
+	"ardupilotmega": {ardupilotmega.Dialect, ardupilotmega.Metadata},
+	"asluav":        {asluav.Dialect, asluav.Metadata},
+	"common":        {common.Dialect, common.Metadata},
+	"icarous":       {icarous.Dialect, icarous.Metadata},
+	"matrixpilot":   {matrixpilot.Dialect, matrixpilot.Metadata},
+	"minimal":       {minimal.Dialect, minimal.Metadata},
+	"paparazzi":     {paparazzi.Dialect, paparazzi.Metadata},
+	"standard":      {standard.Dialect, standard.Metadata},
+	"ualberta":      {ualberta.Dialect, ualberta.Metadata},
+	"uavionix":      {uavionix.Dialect, uavionix.Metadata},
+}
+
+var endpointTypes = map[string]func(string) gomavlib.EndpointConf{
+	"serial": func(addr string) gomavlib.EndpointConf { return gomavlib.EndpointSerial{Address: addr} },
+	"udps":   func(addr string) gomavlib.EndpointConf { return gomavlib.EndpointUDPServer{Address: addr} },
+	"udpc":   func(addr string) gomavlib.EndpointConf { return gomavlib.EndpointUDPClient{Address: addr} },
+	"tcps":   func(addr string) gomavlib.EndpointConf { return gomavlib.EndpointTCPServer{Address: addr} },
+	"tcpc":   func(addr string) gomavlib.EndpointConf { return gomavlib.EndpointTCPClient{Address: addr} },
+}
+
+type jsonFrame struct {
+	Time        time.Time       `json:"time"`
+	Channel     string          `json:"channel"`
+	SystemID    byte            `json:"system_id"`
+	ComponentID byte            `json:"component_id"`
+	MessageID   uint32          `json:"message_id"`
+	Name        string          `json:"name,omitempty"`
+	Message     json.RawMessage `json:"message,omitempty"`
+	MessageRaw  []byte          `json:"message_raw,omitempty"`
+}
+
+// parseEndpoint parses an endpoint in the format type:address.
+func parseEndpoint(in string) (gomavlib.EndpointConf, error) {
+	i := strings.IndexByte(in, ':')
+	if i < 0 {
+		return nil, fmt.Errorf("invalid endpoint '%s'", in)
+	}
+
+	newConf, ok := endpointTypes[in[:i]]
+	if !ok {
+		return nil, fmt.Errorf("invalid endpoint type '%s'", in[:i])
+	}
+
+	return newConf(in[i+1:]), nil
+}
+
+type dumper struct {
+	de        *dialect.DecEncoder
+	md        dialect.Metadata
+	json      bool
+	systemIDs map[byte]struct{}
+	names     map[string]struct{}
+}
+
+func (d *dumper) messageName(m msg.Message) string {
+	if mde, ok := d.de.MessageDEs[m.GetID()]; ok {
+		return mde.Name()
+	}
+	return ""
+}
+
+func (d *dumper) accepts(evt *gomavlib.EventFrame) bool {
+	if d.systemIDs != nil {
+		if _, ok := d.systemIDs[evt.SystemID()]; !ok {
+			return false
+		}
+	}
+
+	if d.names != nil {
+		if _, ok := d.names[d.messageName(evt.Message())]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d *dumper) dump(t time.Time, evt *gomavlib.EventFrame) error {
+	m := evt.Message()
+
+	if !d.json {
+		fmt.Printf("%s [%s] %d:%d %s\n", t.Format("15:04:05.000"), evt.Channel,
+			evt.SystemID(), evt.ComponentID(), dialect.Format(m, d.md))
+		return nil
+	}
+
+	out := jsonFrame{
+		Time:        t,
+		Channel:     evt.Channel.String(),
+		SystemID:    evt.SystemID(),
+		ComponentID: evt.ComponentID(),
+		MessageID:   m.GetID(),
+	}
+
+	if mr, ok := m.(*msg.MessageRaw); ok {
+		out.MessageRaw = mr.Content
+	} else {
+		out.Name = d.messageName(m)
+
+		var err error
+		out.Message, err = d.de.EncodeMessageJSON(m)
+		if err != nil {
+			return err
+		}
+	}
+
+	byts, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(byts))
+	return nil
+}
+
+func run() error {
+	kingpin.CommandLine.Help = "Print Mavlink frames received from endpoints.\n\n" +
+		"Endpoints are in the format type:address, where type is one of:\n" +
+		"serial (serial port, i.e. serial:/dev/ttyUSB0:57600)\n" +
+		"udps (UDP server, i.e. udps:0.0.0.0:14550)\n" +
+		"udpc (UDP client, i.e. udpc:1.2.3.4:14550)\n" +
+		"tcps (TCP server, i.e. tcps:0.0.0.0:5760)\n" +
+		"tcpc (TCP client, i.e. tcpc:1.2.3.4:5760)"
+
+	dialectNames := make([]string, 0, len(dialects))
+	for name := range dialects {
+		dialectNames = append(dialectNames, name)
+	}
+	sort.Strings(dialectNames)
+
+	argDialect := kingpin.Flag("dialect", "dialect used to decode messages").
+		Default("ardupilotmega").Enum(dialectNames...)
+	argJSON := kingpin.Flag("json", "print frames in JSON format, one per line").Bool()
+	argSystemIDs := kingpin.Flag("sysid", "print only frames with these system IDs"+
+		" (comma-separated, can be repeated)").Strings()
+	argNames := kingpin.Flag("name", "print only frames with these message names"+
+		" (comma-separated, can be repeated)").Strings()
+	argParseErrors := kingpin.Flag("parse-errors", "print parse errors to the standard error").Bool()
+	argEndpoints := kingpin.Arg("endpoints", "endpoints, in the format type:address").Required().Strings()
+
+	kingpin.Parse()
+
+	entry := dialects[*argDialect]
+
+	de, err := dialect.NewDecEncoder(entry.dialect)
+	if err != nil {
+		return err
+	}
+
+	d := &dumper{
+		de:   de,
+		md:   entry.metadata,
+		json: *argJSON,
+	}
+
+	for _, v := range *argSystemIDs {
+		for _, part := range strings.Split(v, ",") {
+			id, err := strconv.ParseUint(part, 10, 8)
+			if err != nil {
+				return fmt.Errorf("invalid system ID '%s'", part)
+			}
+
+			if d.systemIDs == nil {
+				d.systemIDs = make(map[byte]struct{})
+			}
+			d.systemIDs[byte(id)] = struct{}{}
+		}
+	}
+
+	if len(*argNames) != 0 {
+		available := make(map[string]struct{})
+		for _, mde := range de.MessageDEs {
+			available[mde.Name()] = struct{}{}
+		}
+
+		d.names = make(map[string]struct{})
+		for _, v := range *argNames {
+			for _, name := range strings.Split(v, ",") {
+				name = strings.ToUpper(name)
+				if _, ok := available[name]; !ok {
+					return fmt.Errorf("message '%s' is not in the dialect", name)
+				}
+				d.names[name] = struct{}{}
+			}
+		}
+	}
+
+	var endpoints []gomavlib.EndpointConf
+	for _, in := range *argEndpoints {
+		ec, err := parseEndpoint(in)
+		if err != nil {
+			return err
+		}
+		endpoints = append(endpoints, ec)
+	}
+
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints:        endpoints,
+		Dialect:          entry.dialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      255,
+		HeartbeatDisable: true,
+		LazyDecoding:     true,
+	})
+	if err != nil {
+		return err
+	}
+	defer node.Close()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+
+	events := node.Events()
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			switch tevt := evt.(type) {
+			case *gomavlib.EventFrame:
+				if d.accepts(tevt) {
+					err := d.dump(time.Now(), tevt)
+					if err != nil {
+						return err
+					}
+				}
+
+			case *gomavlib.EventParseError:
+				if *argParseErrors {
+					fmt.Fprintf(os.Stderr, "[%s] parse error: %s\n", tevt.Channel, tevt.Error)
+				}
+			}
+
+		case <-interrupt:
+			return nil
+		}
+	}
+}
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERR: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,267 @@
+// mavrouter is a Mavlink router, that forwards frames between endpoints.
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/dialects/minimal"
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+var dialects = map[string]*dialect.Dialect{
+	"ardupilotmega": ardupilotmega.Dialect,
+	"common":        common.Dialect,
+	"minimal":       minimal.Dialect,
+}
+
+var logLevels = map[string]gomavlib.LogLevel{
+	"debug": gomavlib.LogLevelDebug,
+	"info":  gomavlib.LogLevelInfo,
+	"warn":  gomavlib.LogLevelWarn,
+	"error": gomavlib.LogLevelError,
+}
+
+var endpointTypes = map[string]func(string) gomavlib.EndpointConf{
+	"serial": func(addr string) gomavlib.EndpointConf { return gomavlib.EndpointSerial{Address: addr} },
+	"udps":   func(addr string) gomavlib.EndpointConf { return gomavlib.EndpointUDPServer{Address: addr} },
+	"udpc":   func(addr string) gomavlib.EndpointConf { return gomavlib.EndpointUDPClient{Address: addr} },
+	"udpb":   func(addr string) gomavlib.EndpointConf { return gomavlib.EndpointUDPBroadcast{BroadcastAddress: addr} },
+	"tcps":   func(addr string) gomavlib.EndpointConf { return gomavlib.EndpointTCPServer{Address: addr} },
+	"tcpc":   func(addr string) gomavlib.EndpointConf { return gomavlib.EndpointTCPClient{Address: addr} },
+}
+
+// parseEndpoint parses an endpoint in the format type:address.
+func parseEndpoint(in string) (gomavlib.EndpointConf, error) {
+	i := strings.IndexByte(in, ':')
+	if i < 0 {
+		return nil, fmt.Errorf("invalid endpoint '%s'", in)
+	}
+
+	newConf, ok := endpointTypes[in[:i]]
+	if !ok {
+		return nil, fmt.Errorf("invalid endpoint type '%s'", in[:i])
+	}
+
+	return newConf(in[i+1:]), nil
+}
+
+// parseKey parses a secret key in hexadecimal format.
+func parseKey(in string) (*frame.V2Key, error) {
+	byts, err := hex.DecodeString(in)
+	if err != nil || len(byts) != 32 {
+		return nil, fmt.Errorf("invalid key: it must be made of 64 hexadecimal characters")
+	}
+	return frame.NewV2Key(byts), nil
+}
+
+func parseIDs(in []string, max uint64) ([]uint32, error) {
+	var ret []uint32
+	for _, v := range in {
+		for _, part := range strings.Split(v, ",") {
+			id, err := strconv.ParseUint(part, 10, 32)
+			if err != nil || id > max {
+				return nil, fmt.Errorf("invalid ID '%s'", part)
+			}
+			ret = append(ret, uint32(id))
+		}
+	}
+	return ret, nil
+}
+
+func parseByteIDs(in []string) ([]byte, error) {
+	ids, err := parseIDs(in, 255)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []byte
+	for _, id := range ids {
+		ret = append(ret, byte(id))
+	}
+	return ret, nil
+}
+
+func run() error {
+	kingpin.CommandLine.Help = "Route Mavlink frames between endpoints.\n\n" +
+		"Endpoints are in the format type:address, where type is one of:\n" +
+		"serial (serial port, i.e. serial:/dev/ttyUSB0:57600)\n" +
+		"udps (UDP server, i.e. udps:0.0.0.0:14550)\n" +
+		"udpc (UDP client, i.e. udpc:1.2.3.4:14550)\n" +
+		"udpb (UDP broadcast, i.e. udpb:192.168.7.255:14550)\n" +
+		"tcps (TCP server, i.e. tcps:0.0.0.0:5760)\n" +
+		"tcpc (TCP client, i.e. tcpc:1.2.3.4:5760)"
+
+	argConf := kingpin.Flag("conf", "path to a mavlink-routerd configuration file,"+
+		" whose endpoints are added to the ones passed as arguments").Default("").String()
+	argDialect := kingpin.Flag("dialect", "dialect used to decode messages, in order to route"+
+		" messages with target fields to their targets only").Default("common").
+		Enum("ardupilotmega", "common", "minimal", "none")
+	argVersion := kingpin.Flag("version", "Mavlink version of frames emitted by the router").
+		Default("2").Enum("1", "2")
+	argSystemID := kingpin.Flag("sysid", "system ID of the router").Default("125").Uint8()
+	argComponentID := kingpin.Flag("compid", "component ID of the router").Default("191").Uint8()
+	argHeartbeat := kingpin.Flag("heartbeat", "emit heartbeats").Bool()
+	argInKeys := kingpin.Flag("in-key", "secret key used to validate incoming frames, in hexadecimal"+
+		" format (can be repeated)").Strings()
+	argOutKey := kingpin.Flag("out-key", "secret key used to sign outgoing frames, in hexadecimal format").
+		Default("").String()
+	argAllowSystemIDs := kingpin.Flag("allow-sysid", "accept only frames with these system IDs"+
+		" (comma-separated, can be repeated)").Strings()
+	argDenySystemIDs := kingpin.Flag("deny-sysid", "discard frames with these system IDs"+
+		" (comma-separated, can be repeated)").Strings()
+	argAllowMessageIDs := kingpin.Flag("allow-msgid", "accept only frames with these message IDs"+
+		" (comma-separated, can be repeated)").Strings()
+	argDenyMessageIDs := kingpin.Flag("deny-msgid", "discard frames with these message IDs"+
+		" (comma-separated, can be repeated)").Strings()
+	argLogLevel := kingpin.Flag("log-level", "minimum level of log entries").
+		Default("info").Enum("debug", "info", "warn", "error")
+	argStatsPeriod := kingpin.Flag("stats-period", "period of statistics printing, 0 to disable").
+		Default("0s").Duration()
+	argEndpoints := kingpin.Arg("endpoints", "endpoints, in the format type:address").Strings()
+
+	kingpin.Parse()
+
+	conf := &gomavlib.NodeConf{
+		RouterEnable:     true,
+		HeartbeatDisable: true,
+	}
+
+	if *argConf != "" {
+		f, err := os.Open(*argConf)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		conf, err = gomavlib.ReadMavlinkRouterConf(f)
+		if err != nil {
+			return fmt.Errorf("%s: %s", *argConf, err)
+		}
+	}
+
+	for _, in := range *argEndpoints {
+		ec, err := parseEndpoint(in)
+		if err != nil {
+			return err
+		}
+		conf.Endpoints = append(conf.Endpoints, ec)
+	}
+
+	if len(conf.Endpoints) == 0 {
+		return fmt.Errorf("at least one endpoint is required")
+	}
+
+	filter := gomavlib.EndpointFilter{}
+	var err error
+
+	filter.AllowSystemIDs, err = parseByteIDs(*argAllowSystemIDs)
+	if err != nil {
+		return err
+	}
+
+	filter.DenySystemIDs, err = parseByteIDs(*argDenySystemIDs)
+	if err != nil {
+		return err
+	}
+
+	filter.AllowMessageIDs, err = parseIDs(*argAllowMessageIDs, 0xFFFFFF)
+	if err != nil {
+		return err
+	}
+
+	filter.DenyMessageIDs, err = parseIDs(*argDenyMessageIDs, 0xFFFFFF)
+	if err != nil {
+		return err
+	}
+
+	// filters are applied to all endpoints
+	if filter.AllowSystemIDs != nil || filter.DenySystemIDs != nil ||
+		filter.AllowMessageIDs != nil || filter.DenyMessageIDs != nil {
+		for i, ec := range conf.Endpoints {
+			f := filter
+			f.EndpointConf = ec
+			conf.Endpoints[i] = f
+		}
+	}
+
+	for _, in := range *argInKeys {
+		key, err := parseKey(in)
+		if err != nil {
+			return err
+		}
+		conf.InKeys = append(conf.InKeys, key)
+	}
+
+	if *argOutKey != "" {
+		conf.OutKey, err = parseKey(*argOutKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	conf.Dialect = dialects[*argDialect]
+	if *argVersion == "1" {
+		conf.OutVersion = gomavlib.V1
+	} else {
+		conf.OutVersion = gomavlib.V2
+	}
+	conf.OutSystemID = *argSystemID
+	conf.OutComponentID = *argComponentID
+	conf.HeartbeatDisable = !*argHeartbeat
+	logger := log.New(os.Stdout, "", log.LstdFlags)
+	conf.Logger = gomavlib.NewStdLogger(logger, logLevels[*argLogLevel])
+
+	node, err := gomavlib.NewNode(*conf)
+	if err != nil {
+		return err
+	}
+	defer node.Close()
+
+	if *argStatsPeriod > 0 {
+		go func() {
+			for range time.NewTicker(*argStatsPeriod).C {
+				s := node.Stats()
+				logger.Printf("INFO stats: frames in: %d, frames out: %d, parse errors: %d, lost frames: %d",
+					s.FramesIn, s.FramesOut, s.ParseErrors, s.LostFrames)
+			}
+		}()
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+
+	events := node.Events()
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+		case <-interrupt:
+			return nil
+		}
+	}
+}
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERR: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -1,7 +1,10 @@
 package gomavlib
 
 import (
+	"fmt"
 	"io"
+
+	"github.com/aler9/gomavlib/pkg/transceiver"
 )
 
 // EndpointConf is the interface implemented by all endpoint configurations.
@@ -35,3 +38,102 @@ type endpointChannelAccepter interface {
 	Close() error
 	Accept() (string, io.ReadWriteCloser, error)
 }
+
+// endpointReconnecter is an endpoint that reconnects automatically and
+// reports failed attempts. Reports must be read until Close() is called.
+type endpointReconnecter interface {
+	reconnectEvents() chan *EventReconnect
+}
+
+// endpointBaudDetecter is an endpoint that detects the baud rate of a
+// serial port and reports it. Reports must be read until Close() is called.
+type endpointBaudDetecter interface {
+	baudRateEvents() chan *EventBaudRateDetected
+}
+
+// channelOptions contains the options of the channels of an endpoint.
+type channelOptions struct {
+	keys        *transceiver.Keys
+	outVersion  Version
+	highLatency bool
+	failover    *EndpointFailover
+	inFilters   []*inFilter
+	rateLimit   *EndpointRateLimit
+	queueSize   int
+}
+
+// endpointOptions returns the options of the channels of an endpoint,
+// by merging the node configuration with the one of endpoint wrappers.
+func (n *Node) endpointOptions(tconf EndpointConf) (*channelOptions, error) {
+	opts := &channelOptions{
+		keys:       n.keys,
+		outVersion: n.conf.OutVersion,
+	}
+
+	for {
+		switch ttconf := tconf.(type) {
+		case EndpointSigned:
+			opts.keys = transceiver.NewKeys(mergeKeys(ttconf.InKey, ttconf.InKeys), ttconf.OutKey)
+			tconf = ttconf.EndpointConf
+
+		case EndpointFilter:
+			opts.inFilters = append(opts.inFilters, newInFilter(ttconf))
+			tconf = ttconf.EndpointConf
+
+		case EndpointRateLimit:
+			err := ttconf.validate()
+			if err != nil {
+				return nil, err
+			}
+			opts.rateLimit = &ttconf
+
+			// delayed frames are queued
+			if !ttconf.Drop && opts.queueSize == 0 {
+				opts.queueSize = ttconf.QueueSize
+				if opts.queueSize == 0 {
+					opts.queueSize = writeQueueDefaultSize
+				}
+			}
+			tconf = ttconf.EndpointConf
+
+		case EndpointQueue:
+			if ttconf.Size < 0 {
+				return nil, fmt.Errorf("invalid queue size")
+			}
+			if opts.queueSize == 0 {
+				opts.queueSize = ttconf.Size
+				if opts.queueSize == 0 {
+					opts.queueSize = writeQueueDefaultSize
+				}
+			}
+			tconf = ttconf.EndpointConf
+
+		case EndpointHighLatency:
+			opts.highLatency = true
+			tconf = ttconf.EndpointConf
+
+		case EndpointFailover:
+			opts.failover = &ttconf
+			tconf = ttconf.EndpointConf
+
+		case EndpointReconnect:
+			tconf = ttconf.EndpointConf
+
+		case EndpointVersion:
+			if ttconf.Version != V1 && ttconf.Version != V2 {
+				return nil, fmt.Errorf("invalid endpoint version")
+			}
+			opts.outVersion = ttconf.Version
+			tconf = ttconf.EndpointConf
+
+		case nil:
+			return nil, fmt.Errorf("endpoint wrapper requires an endpoint configuration")
+
+		default:
+			if opts.keys.Out() != nil && opts.outVersion != V2 {
+				return nil, fmt.Errorf("OutKey requires V2 frames")
+			}
+			return opts, nil
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"reflect"
 	"strconv"
 	"time"
+
+	"github.com/aler9/gomavlib/pkg/mmsg"
 )
 
 // ipByBroadcastIP returns the ip of an interface associated with given broadcast ip
@@ -141,3 +143,11 @@ func (t *endpointUDPBroadcast) Write(buf []byte) (int, error) {
 	}
 	return t.pc.WriteTo(buf, t.broadcastAddr)
 }
+
+func (t *endpointUDPBroadcast) writeBatch(bufs [][]byte) (int, error) {
+	err := t.pc.SetWriteDeadline(time.Now().Add(netWriteTimeout))
+	if err != nil {
+		return 0, err
+	}
+	return mmsg.WriteBatch(t.pc.(*net.UDPConn), t.broadcastAddr.(*net.UDPAddr), bufs)
+}
@@ -0,0 +1,23 @@
+package gomavlib
+
+// EndpointFailover wraps an endpoint configuration and marks it as a
+// redundant link to a system (for instance, a radio link backed by a LTE
+// link). Endpoints with the same SystemID form a failover group: frames
+// written with WriteMessageAll(), WriteMessageExcept(), WriteFrameAll() and
+// WriteFrameExcept(), and frames routed by the router, are sent through
+// the active channel of the group only.
+// The active channel is the one with the lowest Priority among the channels
+// that are receiving heartbeats of the system within FailoverTimeout and
+// whose round-trip time doesn't exceed FailoverMaxLatency.
+// When the active channel changes, EventFailover is emitted.
+type EndpointFailover struct {
+	// the wrapped endpoint configuration.
+	EndpointConf
+
+	// the system reachable through the endpoint.
+	SystemID byte
+
+	// (optional) the priority of the endpoint inside the group.
+	// Endpoints with lower values are preferred. It defaults to 0.
+	Priority int
+}
@@ -0,0 +1,101 @@
+package gomavlib
+
+import (
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+// EndpointFilter wraps an endpoint configuration and filters the frames
+// received by the channels of the endpoint. Rejected frames are discarded
+// before events are emitted and before frames are routed, and are counted
+// in the FilteredFrames statistic.
+// A frame is accepted when it matches all the allow lists that are not empty
+// and none of the deny lists.
+// This allows, for instance, to prevent a public-facing link from injecting
+// commands into the vehicle network.
+type EndpointFilter struct {
+	// the wrapped endpoint configuration.
+	EndpointConf
+
+	// (optional) the system IDs that are accepted.
+	AllowSystemIDs []byte
+	// (optional) the system IDs that are rejected.
+	DenySystemIDs []byte
+	// (optional) the component IDs that are accepted.
+	AllowComponentIDs []byte
+	// (optional) the component IDs that are rejected.
+	DenyComponentIDs []byte
+	// (optional) the message IDs that are accepted.
+	AllowMessageIDs []uint32
+	// (optional) the message IDs that are rejected.
+	DenyMessageIDs []uint32
+}
+
+type idSet map[uint32]struct{}
+
+func newIDSet(ids []uint32) idSet {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	ret := make(idSet, len(ids))
+	for _, id := range ids {
+		ret[id] = struct{}{}
+	}
+	return ret
+}
+
+func newIDSetBytes(ids []byte) idSet {
+	tmp := make([]uint32, len(ids))
+	for i, id := range ids {
+		tmp[i] = uint32(id)
+	}
+	return newIDSet(tmp)
+}
+
+// allows checks whether an ID is accepted by an allow list.
+func (s idSet) allows(id uint32) bool {
+	if s == nil {
+		return true
+	}
+	_, ok := s[id]
+	return ok
+}
+
+// denies checks whether an ID is rejected by a deny list.
+func (s idSet) denies(id uint32) bool {
+	_, ok := s[id]
+	return ok
+}
+
+type inFilter struct {
+	allowSystemIDs    idSet
+	denySystemIDs     idSet
+	allowComponentIDs idSet
+	denyComponentIDs  idSet
+	allowMessageIDs   idSet
+	denyMessageIDs    idSet
+}
+
+func newInFilter(conf EndpointFilter) *inFilter {
+	return &inFilter{
+		allowSystemIDs:    newIDSetBytes(conf.AllowSystemIDs),
+		denySystemIDs:     newIDSetBytes(conf.DenySystemIDs),
+		allowComponentIDs: newIDSetBytes(conf.AllowComponentIDs),
+		denyComponentIDs:  newIDSetBytes(conf.DenyComponentIDs),
+		allowMessageIDs:   newIDSet(conf.AllowMessageIDs),
+		denyMessageIDs:    newIDSet(conf.DenyMessageIDs),
+	}
+}
+
+func (f *inFilter) accepts(fr frame.Frame) bool {
+	systemID := uint32(fr.GetSystemID())
+	componentID := uint32(fr.GetComponentID())
+	messageID := fr.GetMessage().GetID()
+
+	return f.allowSystemIDs.allows(systemID) &&
+		!f.denySystemIDs.denies(systemID) &&
+		f.allowComponentIDs.allows(componentID) &&
+		!f.denyComponentIDs.denies(componentID) &&
+		f.allowMessageIDs.allows(messageID) &&
+		!f.denyMessageIDs.denies(messageID)
+}
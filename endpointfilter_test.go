@@ -0,0 +1,101 @@
+package gomavlib
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestInFilter(t *testing.T) {
+	fr := &frame.V2Frame{
+		SystemID:    10,
+		ComponentID: 1,
+		Message:     &MessageHeartbeat{},
+	}
+
+	for _, ca := range []struct {
+		name     string
+		conf     EndpointFilter
+		accepted bool
+	}{
+		{"empty", EndpointFilter{}, true},
+		{"allow system", EndpointFilter{AllowSystemIDs: []byte{9, 10}}, true},
+		{"allow system not matching", EndpointFilter{AllowSystemIDs: []byte{11}}, false},
+		{"deny system", EndpointFilter{DenySystemIDs: []byte{10}}, false},
+		{"deny system not matching", EndpointFilter{DenySystemIDs: []byte{11}}, true},
+		{"allow component", EndpointFilter{AllowComponentIDs: []byte{1}}, true},
+		{"allow component not matching", EndpointFilter{AllowComponentIDs: []byte{2}}, false},
+		{"deny component", EndpointFilter{DenyComponentIDs: []byte{1}}, false},
+		{"allow message", EndpointFilter{AllowMessageIDs: []uint32{0}}, true},
+		{"allow message not matching", EndpointFilter{AllowMessageIDs: []uint32{76}}, false},
+		{"deny message", EndpointFilter{DenyMessageIDs: []uint32{0}}, false},
+		{"allow and deny", EndpointFilter{
+			AllowSystemIDs: []byte{10},
+			DenyMessageIDs: []uint32{0},
+		}, false},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			require.Equal(t, ca.accepted, newInFilter(ca.conf).accepts(fr))
+		})
+	}
+}
+
+func TestNodeEndpointFilter(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect: &dialect.Dialect{3, []msg.Message{ //nolint:govet
+			&MessageHeartbeat{},
+			&MessageRequestDataStream{},
+		}},
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	node2, err := NewNode(NodeConf{
+		Dialect: &dialect.Dialect{3, []msg.Message{ //nolint:govet
+			&MessageHeartbeat{},
+			&MessageRequestDataStream{},
+		}},
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointFilter{
+				EndpointConf:   EndpointCustom{c2},
+				AllowSystemIDs: []byte{10},
+				DenyMessageIDs: []uint32{66},
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	node1.WriteMessageAll(&MessageRequestDataStream{TargetSystem: 11})
+	node1.WriteMessageAll(&MessageHeartbeat{Type: 1})
+
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, &MessageHeartbeat{Type: 1}, fr.Message())
+			break
+		}
+	}
+
+	s := node2.Stats()
+	require.Equal(t, uint64(2), s.FramesIn)
+	require.Equal(t, uint64(1), s.FilteredFrames)
+}
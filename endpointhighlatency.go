@@ -0,0 +1,13 @@
+package gomavlib
+
+// EndpointHighLatency wraps an endpoint configuration and marks it as
+// a high latency link (for instance, a satellite or LTE fallback link).
+// Channels of the endpoint receive the HIGH_LATENCY2 messages produced when
+// HighLatencyEnable is true, and are excluded from WriteMessageAll(),
+// WriteMessageExcept(), WriteFrameAll() and WriteFrameExcept(), and
+// therefore from the routing of broadcast frames, in order not to saturate
+// them with regular telemetry.
+type EndpointHighLatency struct {
+	// the wrapped endpoint configuration.
+	EndpointConf
+}
@@ -0,0 +1,173 @@
+package gomavlib
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	rateLimitDefaultBurst = 1 * time.Second
+)
+
+var errorRateLimited = fmt.Errorf("rate limit exceeded")
+
+// EndpointRateLimit wraps an endpoint configuration and limits the rate of
+// outgoing frames of each channel of the endpoint, with a token bucket.
+// This allows to avoid saturating low-bandwidth links, like radio links.
+// Frames that exceed the limits are delayed or, if Drop is true, discarded.
+// Delayed frames are queued and written in order of priority (see Priority).
+// Discarded frames are counted in the DroppedWrites statistic.
+type EndpointRateLimit struct {
+	// the wrapped endpoint configuration.
+	EndpointConf
+
+	// (optional) the maximum number of bytes per second.
+	BytesPerSecond int
+	// (optional) the maximum number of frames per second.
+	FramesPerSecond int
+	// (optional) the duration of the traffic that can be sent in a single burst.
+	// It defaults to 1 second.
+	Burst time.Duration
+	// (optional) discard frames that exceed the limits, instead of delaying them.
+	Drop bool
+	// (optional) the maximum number of frames that can be delayed.
+	// When it is exceeded, frames with the lowest priority are discarded.
+	// It defaults to 64.
+	QueueSize int
+}
+
+func (conf EndpointRateLimit) validate() error {
+	if conf.BytesPerSecond < 0 || conf.FramesPerSecond < 0 ||
+		(conf.BytesPerSecond == 0 && conf.FramesPerSecond == 0) {
+		return fmt.Errorf("rate limit requires a positive BytesPerSecond or FramesPerSecond")
+	}
+	if conf.Burst < 0 {
+		return fmt.Errorf("invalid Burst")
+	}
+	if conf.QueueSize < 0 {
+		return fmt.Errorf("invalid QueueSize")
+	}
+	return nil
+}
+
+// tokenBucket is a token bucket that is filled at a constant rate.
+type tokenBucket struct {
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(rate float64, burst time.Duration) *tokenBucket {
+	capacity := rate * burst.Seconds()
+	return &tokenBucket{
+		rate:     rate,
+		capacity: capacity,
+		tokens:   capacity,
+		last:     time.Now(),
+	}
+}
+
+// delay returns the time to wait before n tokens are available.
+// Requests that exceed the capacity of the bucket are allowed when the bucket
+// is full, in order not to block them forever.
+func (b *tokenBucket) delay(now time.Time, n float64) time.Duration {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+
+	if n > b.capacity {
+		n = b.capacity
+	}
+	if b.tokens >= n {
+		return 0
+	}
+	return time.Duration((n - b.tokens) / b.rate * float64(time.Second))
+}
+
+func (b *tokenBucket) take(n float64) {
+	b.tokens -= n
+}
+
+// rateLimitedWriter is a io.Writer that limits the rate of writes.
+// Every write must contain a single frame.
+type rateLimitedWriter struct {
+	w         io.Writer
+	bytes     *tokenBucket
+	frames    *tokenBucket
+	drop      bool
+	terminate chan struct{}
+}
+
+func newRateLimitedWriter(w io.Writer, conf *EndpointRateLimit,
+	terminate chan struct{}) *rateLimitedWriter {
+	burst := conf.Burst
+	if burst == 0 {
+		burst = rateLimitDefaultBurst
+	}
+
+	rw := &rateLimitedWriter{
+		w:         w,
+		drop:      conf.Drop,
+		terminate: terminate,
+	}
+
+	if conf.BytesPerSecond > 0 {
+		rw.bytes = newTokenBucket(float64(conf.BytesPerSecond), burst)
+	}
+	if conf.FramesPerSecond > 0 {
+		rw.frames = newTokenBucket(float64(conf.FramesPerSecond), burst)
+	}
+
+	return rw
+}
+
+func (rw *rateLimitedWriter) delay(n int) time.Duration {
+	now := time.Now()
+	var d time.Duration
+
+	if rw.bytes != nil {
+		d = rw.bytes.delay(now, float64(n))
+	}
+
+	if rw.frames != nil {
+		if d2 := rw.frames.delay(now, 1); d2 > d {
+			d = d2
+		}
+	}
+
+	return d
+}
+
+func (rw *rateLimitedWriter) Write(buf []byte) (int, error) {
+	for {
+		d := rw.delay(len(buf))
+		if d == 0 {
+			break
+		}
+
+		if rw.drop {
+			return 0, errorRateLimited
+		}
+
+		t := time.NewTimer(d)
+		select {
+		case <-t.C:
+		case <-rw.terminate:
+			t.Stop()
+			return 0, errorTerminated
+		}
+	}
+
+	if rw.bytes != nil {
+		rw.bytes.take(float64(len(buf)))
+	}
+	if rw.frames != nil {
+		rw.frames.take(1)
+	}
+
+	return rw.w.Write(buf)
+}
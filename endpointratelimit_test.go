@@ -0,0 +1,163 @@
+package gomavlib
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(100, 1*time.Second)
+	now := b.last
+
+	require.Equal(t, time.Duration(0), b.delay(now, 60))
+	b.take(60)
+
+	require.Equal(t, 200*time.Millisecond, b.delay(now, 60))
+
+	require.Equal(t, time.Duration(0), b.delay(now.Add(200*time.Millisecond), 60))
+	b.take(60)
+
+	// requests bigger than the capacity are allowed when the bucket is full
+	require.Equal(t, 1*time.Second, b.delay(now.Add(200*time.Millisecond), 500))
+	require.Equal(t, time.Duration(0), b.delay(now.Add(1200*time.Millisecond), 500))
+}
+
+func TestRateLimitedWriterDrop(t *testing.T) {
+	var buf bytes.Buffer
+	rw := newRateLimitedWriter(&buf, &EndpointRateLimit{
+		BytesPerSecond: 10,
+		Drop:           true,
+	}, make(chan struct{}))
+
+	_, err := rw.Write([]byte("0123456789"))
+	require.NoError(t, err)
+
+	_, err = rw.Write([]byte("0123456789"))
+	require.Equal(t, errorRateLimited, err)
+
+	require.Equal(t, "0123456789", buf.String())
+}
+
+func TestRateLimitedWriterTerminate(t *testing.T) {
+	var buf bytes.Buffer
+	terminate := make(chan struct{})
+	rw := newRateLimitedWriter(&buf, &EndpointRateLimit{
+		FramesPerSecond: 1,
+	}, terminate)
+
+	_, err := rw.Write([]byte("first"))
+	require.NoError(t, err)
+
+	close(terminate)
+
+	_, err = rw.Write([]byte("second"))
+	require.Equal(t, errorTerminated, err)
+}
+
+func newTestRateLimitNodes(t *testing.T, conf EndpointRateLimit) (*Node, *Node) {
+	c1, c2 := net.Pipe()
+	conf.EndpointConf = EndpointCustom{c1}
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{conf},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	return node1, node2
+}
+
+func TestNodeEndpointRateLimitQueue(t *testing.T) {
+	node1, node2 := newTestRateLimitNodes(t, EndpointRateLimit{
+		FramesPerSecond: 10,
+		Burst:           100 * time.Millisecond,
+	})
+	defer node1.Close()
+	defer node2.Close()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		node1.WriteMessageAll(&MessageHeartbeat{Type: MAV_TYPE(i)})
+	}
+
+	count := 0
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, &MessageHeartbeat{Type: MAV_TYPE(count)}, fr.Message())
+			count++
+			if count == 4 {
+				break
+			}
+		}
+	}
+
+	// the first frame is sent immediately, the following ones every 100ms
+	require.True(t, time.Since(start) >= 250*time.Millisecond)
+}
+
+func TestNodeEndpointRateLimitDrop(t *testing.T) {
+	node1, node2 := newTestRateLimitNodes(t, EndpointRateLimit{
+		FramesPerSecond: 1,
+		Drop:            true,
+	})
+	defer node1.Close()
+	defer node2.Close()
+
+	recv := make(chan msg.Message, 4)
+	go func() {
+		for evt := range node2.Events() {
+			if fr, ok := evt.(*EventFrame); ok {
+				recv <- fr.Message()
+			}
+		}
+	}()
+
+	for i := 0; i < 4; i++ {
+		node1.WriteMessageAll(&MessageHeartbeat{Type: MAV_TYPE(i)})
+	}
+
+	// the first frame is sent, the following ones are discarded
+	require.Equal(t, &MessageHeartbeat{Type: 0}, <-recv)
+
+	for i := 0; i < 50 && node1.Stats().DroppedWrites != 3; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Equal(t, uint64(1), node1.Stats().FramesOut)
+	require.Equal(t, uint64(3), node1.Stats().DroppedWrites)
+}
+
+func TestNodeEndpointRateLimitErrors(t *testing.T) {
+	_, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointRateLimit{EndpointConf: EndpointCustom{nil}},
+		},
+	})
+	require.EqualError(t, err, "rate limit requires a positive BytesPerSecond or FramesPerSecond")
+}
@@ -0,0 +1,49 @@
+package gomavlib
+
+import (
+	"fmt"
+)
+
+// EndpointReconnect wraps an endpoint configuration and replaces the
+// strategy used to reconnect after a connection attempt has failed.
+// It can wrap TCP, UDP, TLS, WebSocket and Unix clients, and serial ports
+// in auto mode. Failed attempts are reported with EventReconnect.
+type EndpointReconnect struct {
+	// the wrapped endpoint configuration.
+	EndpointConf
+
+	// the reconnection strategy.
+	Policy ReconnectPolicy
+}
+
+func (conf EndpointReconnect) init() (Endpoint, error) {
+	// find the wrapped endpoint, skipping other wrappers
+	tconf := conf.EndpointConf
+	for {
+		switch ttconf := tconf.(type) {
+		case EndpointSigned:
+			tconf = ttconf.EndpointConf
+
+		case EndpointHighLatency:
+			tconf = ttconf.EndpointConf
+
+		case EndpointVersion:
+			tconf = ttconf.EndpointConf
+
+		case EndpointReconnect:
+			tconf = ttconf.EndpointConf
+
+		case endpointClientConf:
+			return initEndpointClient(ttconf, conf.Policy)
+
+		case EndpointSerial:
+			return ttconf.initReconnect(&conf.Policy)
+
+		case nil:
+			return nil, fmt.Errorf("endpoint wrapper requires an endpoint configuration")
+
+		default:
+			return nil, fmt.Errorf("endpoint %T does not support reconnection", tconf)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package gomavlib
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestReconnecterDelays(t *testing.T) {
+	rc := newReconnecter(ReconnectPolicy{
+		InitialDelay: 100 * time.Millisecond,
+		Multiplier:   2,
+		MaxDelay:     500 * time.Millisecond,
+		MaxAttempts:  6,
+	}, netReconnectPeriod)
+
+	var delays []time.Duration
+	for {
+		delay, ok := rc.next()
+		if !ok {
+			break
+		}
+		delays = append(delays, delay)
+	}
+
+	require.Equal(t, []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		500 * time.Millisecond,
+		500 * time.Millisecond,
+	}, delays)
+
+	rc.reset()
+	delay, ok := rc.next()
+	require.Equal(t, true, ok)
+	require.Equal(t, 100*time.Millisecond, delay)
+
+	// defaults
+	rc = newReconnecter(ReconnectPolicy{}, netReconnectPeriod)
+	for i := 0; i < 10; i++ {
+		delay, ok := rc.next()
+		require.Equal(t, true, ok)
+		require.Equal(t, netReconnectPeriod, delay)
+	}
+}
+
+func TestEndpointReconnect(t *testing.T) {
+	// find a port that is not in use
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	node, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointReconnect{
+				EndpointConf: EndpointTCPClient{Address: addr},
+				Policy: ReconnectPolicy{
+					InitialDelay: 20 * time.Millisecond,
+					Multiplier:   2,
+					MaxAttempts:  4,
+				},
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	evt := <-node.Events()
+	open, ok := evt.(*EventChannelOpen)
+	require.Equal(t, true, ok)
+
+	for i := 1; i <= 3; i++ {
+		evt = <-node.Events()
+		rec, ok := evt.(*EventReconnect)
+		require.Equal(t, true, ok)
+		require.Equal(t, open.Channel, rec.Channel)
+		require.Equal(t, i, rec.Attempt)
+		require.Equal(t, time.Duration(10<<i)*time.Millisecond, rec.Delay)
+		require.Error(t, rec.Error)
+	}
+
+	// attempts are exhausted
+	evt = <-node.Events()
+	_, ok = evt.(*EventChannelClose)
+	require.Equal(t, true, ok)
+}
+
+func TestEndpointReconnectErrors(t *testing.T) {
+	for _, ca := range []struct {
+		conf EndpointConf
+		err  string
+	}{
+		{
+			EndpointReconnect{EndpointConf: EndpointTCPServer{Address: "127.0.0.1:5600"}},
+			"endpoint gomavlib.EndpointTCPServer does not support reconnection",
+		},
+		{
+			EndpointReconnect{EndpointConf: EndpointSerial{Address: "/dev/ttyUSB0:57600"}},
+			"ReconnectPolicy requires a serial port in auto mode",
+		},
+		{
+			EndpointReconnect{
+				EndpointConf: EndpointUDPClient{Address: "127.0.0.1:5600"},
+				Policy:       ReconnectPolicy{Multiplier: 0.5},
+			},
+			"Multiplier must be greater or equal than 1",
+		},
+		{
+			EndpointReconnect{},
+			"endpoint wrapper requires an endpoint configuration",
+		},
+	} {
+		_, err := NewNode(NodeConf{
+			Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+			OutVersion:       V2,
+			OutSystemID:      10,
+			Endpoints:        []EndpointConf{ca.conf},
+			HeartbeatDisable: true,
+		})
+		require.EqualError(t, err, ca.err)
+	}
+}
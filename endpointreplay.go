@@ -0,0 +1,150 @@
+package gomavlib
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/tlog"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+// EndpointReplay sets up a endpoint that reads frames from a telemetry log
+// (tlog) or from a raw capture and feeds them into the node, respecting
+// their original timing. It allows to develop and test applications
+// without a vehicle.
+// Frames written to the endpoint are discarded.
+type EndpointReplay struct {
+	// the path of the file to replay.
+	Path string
+
+	// (optional) whether the file is a raw capture, i.e. a sequence of frames
+	// without timestamps. Since raw captures do not contain timing
+	// information, their frames are replayed as fast as possible.
+	Raw bool
+
+	// (optional) the speed multiplier, i.e. 2 replays the file two times
+	// faster than the original. It defaults to 1.
+	Speed float64
+}
+
+type endpointReplay struct {
+	conf    EndpointReplay
+	f       *os.File
+	r       *tlog.Reader
+	buf     []byte
+	pending []byte
+
+	// time of the first frame and time in which it was replayed
+	logStart    time.Time
+	replayStart time.Time
+
+	terminate chan struct{}
+}
+
+func (conf EndpointReplay) init() (Endpoint, error) {
+	if conf.Speed < 0 {
+		return nil, fmt.Errorf("invalid speed")
+	}
+	if conf.Speed == 0 {
+		conf.Speed = 1
+	}
+
+	f, err := os.Open(conf.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// messages are not decoded, since frames are passed to the node
+	// in encoded form
+	var r *tlog.Reader
+	if conf.Raw {
+		r, err = tlog.NewRawReader(f, nil)
+	} else {
+		r, err = tlog.NewReader(f, nil)
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	t := &endpointReplay{
+		conf:      conf,
+		f:         f,
+		r:         r,
+		buf:       make([]byte, bufferSize),
+		terminate: make(chan struct{}),
+	}
+	return t, nil
+}
+
+func (t *endpointReplay) isEndpoint() {}
+
+func (t *endpointReplay) Conf() EndpointConf {
+	return t.conf
+}
+
+func (t *endpointReplay) Label() string {
+	return fmt.Sprintf("replay:%s", t.conf.Path)
+}
+
+func (t *endpointReplay) Close() error {
+	close(t.terminate)
+	t.f.Close()
+	return nil
+}
+
+// readFrame reads the next frame and waits until it has to be replayed.
+func (t *endpointReplay) readFrame() ([]byte, error) {
+	for {
+		ts, fr, err := t.r.ReadFrame()
+		if err != nil {
+			// skip invalid frames
+			if _, ok := err.(*transceiver.Error); ok {
+				continue
+			}
+			return nil, err
+		}
+
+		if !ts.IsZero() {
+			if t.logStart.IsZero() {
+				t.logStart = ts
+				t.replayStart = time.Now()
+			}
+
+			elapsed := time.Duration(float64(ts.Sub(t.logStart)) / t.conf.Speed)
+			if wait := time.Until(t.replayStart.Add(elapsed)); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-timer.C:
+				case <-t.terminate:
+					timer.Stop()
+					return nil, errorTerminated
+				}
+			}
+		}
+
+		return fr.Encode(t.buf, fr.GetMessage().(*msg.MessageRaw).Content)
+	}
+}
+
+func (t *endpointReplay) Read(buf []byte) (int, error) {
+	if len(t.pending) == 0 {
+		byts, err := t.readFrame()
+		// once the file has been replayed, wait termination
+		if err != nil {
+			<-t.terminate
+			return 0, errorTerminated
+		}
+		t.pending = byts
+	}
+
+	n := copy(buf, t.pending)
+	t.pending = t.pending[n:]
+	return n, nil
+}
+
+func (t *endpointReplay) Write(buf []byte) (int, error) {
+	return len(buf), nil
+}
@@ -0,0 +1,84 @@
+package gomavlib
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/tlog"
+)
+
+func TestEndpointReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomavlib")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	d := &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}} //nolint:govet
+
+	fpath := filepath.Join(dir, "test.tlog")
+	f, err := os.Create(fpath)
+	require.NoError(t, err)
+
+	w, err := tlog.NewWriter(f, d)
+	require.NoError(t, err)
+
+	de, err := dialect.NewDecEncoder(d)
+	require.NoError(t, err)
+	mde := de.MessageDEs[0]
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		content, err := mde.Encode(&MessageHeartbeat{CustomMode: uint32(i)}, true)
+		require.NoError(t, err)
+
+		fr := &frame.V2Frame{
+			SequenceID:  byte(i),
+			SystemID:    1,
+			ComponentID: 1,
+			Message: &msg.MessageRaw{
+				ID:      0,
+				Content: content,
+			},
+		}
+		fr.Checksum = fr.GenChecksum(mde.CRCExtra())
+
+		err = w.WriteFrame(start.Add(time.Duration(i)*200*time.Millisecond), fr)
+		require.NoError(t, err)
+	}
+	f.Close()
+
+	node, err := NewNode(NodeConf{
+		Dialect:     d,
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{EndpointReplay{
+			Path:  fpath,
+			Speed: 2,
+		}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	var received []time.Time
+	for evt := range node.Events() {
+		if e, ok := evt.(*EventFrame); ok {
+			require.Equal(t, &MessageHeartbeat{CustomMode: uint32(len(received))}, e.Message())
+			received = append(received, time.Now())
+			if len(received) == 3 {
+				break
+			}
+		}
+	}
+
+	elapsed := received[2].Sub(received[0])
+	require.True(t, elapsed >= 180*time.Millisecond)
+	require.True(t, elapsed < 380*time.Millisecond)
+}
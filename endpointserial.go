@@ -1,21 +1,261 @@
 package gomavlib
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/tarm/serial"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+const (
+	// heartbeats are emitted with a frequency of 1Hz; the detection period
+	// must be long enough to receive at least one of them.
+	serialAutoBaudPeriod = 2500 * time.Millisecond
+
+	// the read timeout is implemented with VTIME, that is expressed in
+	// tenths of second and can't exceed 255.
+	serialMaxReadTimeout = 25500 * time.Millisecond
+)
+
+// SerialParity is the parity of a serial port.
+type SerialParity int
+
+// parities.
+const (
+	SerialParityNone SerialParity = iota
+	SerialParityOdd
+	SerialParityEven
+	SerialParityMark
+	SerialParitySpace
+)
+
+// SerialStopBits is the number of stop bits of a serial port.
+type SerialStopBits int
+
+// stop bits.
+const (
+	SerialStopBits1 SerialStopBits = iota
+	SerialStopBits1Half
+	SerialStopBits2
 )
 
-var reSerial = regexp.MustCompile("^(.+?):([0-9]+)$")
+var (
+	reSerial         = regexp.MustCompile("^(.+?):([0-9]+)$")
+	reSerialNameOnly = regexp.MustCompile("^([^:]+)$")
+)
+
+// baud rates that are tried when AutoBaud is enabled and AutoBaudRates is empty.
+var serialAutoBaudRates = []int{
+	57600,
+	115200,
+	921600,
+	460800,
+	500000,
+	1500000,
+	230400,
+	38400,
+	19200,
+	9600,
+}
+
+// serialHasFrames checks whether a buffer contains at least one valid frame.
+// Since the dialect is not known, the check is performed on heartbeats,
+// that are emitted periodically by every Mavlink device and whose CRC extra is fixed.
+func serialHasFrames(buf []byte) bool {
+	for i, b := range buf {
+		var f frame.Frame
+		switch b {
+		case frame.V1MagicByte:
+			f = &frame.V1Frame{}
+		case frame.V2MagicByte:
+			f = &frame.V2Frame{}
+		default:
+			continue
+		}
+
+		err := f.Decode(bufio.NewReader(bytes.NewReader(buf[i+1:])))
+		if err != nil {
+			continue
+		}
+
+		if f.GetMessage().GetID() == 0 && f.GenChecksum(50) == f.GetChecksum() {
+			return true
+		}
+	}
+	return false
+}
+
+// serialConfig returns the configuration of a serial port.
+func serialConfig(conf EndpointSerial, name string, baud int) (*serial.Config, error) {
+	if conf.DataBits != 0 && (conf.DataBits < 5 || conf.DataBits > 8) {
+		return nil, fmt.Errorf("DataBits must be between 5 and 8")
+	}
+
+	parity, ok := map[SerialParity]serial.Parity{
+		SerialParityNone:  serial.ParityNone,
+		SerialParityOdd:   serial.ParityOdd,
+		SerialParityEven:  serial.ParityEven,
+		SerialParityMark:  serial.ParityMark,
+		SerialParitySpace: serial.ParitySpace,
+	}[conf.Parity]
+	if !ok {
+		return nil, fmt.Errorf("invalid parity")
+	}
+
+	stopBits, ok := map[SerialStopBits]serial.StopBits{
+		SerialStopBits1:     serial.Stop1,
+		SerialStopBits1Half: serial.Stop1Half,
+		SerialStopBits2:     serial.Stop2,
+	}[conf.StopBits]
+	if !ok {
+		return nil, fmt.Errorf("invalid stop bits")
+	}
+
+	if conf.ReadTimeout < 0 || conf.ReadTimeout > serialMaxReadTimeout {
+		return nil, fmt.Errorf("ReadTimeout must be between 0 and %v", serialMaxReadTimeout)
+	}
+
+	return &serial.Config{
+		Name:        name,
+		Baud:        baud,
+		ReadTimeout: conf.ReadTimeout,
+		Size:        byte(conf.DataBits),
+		Parity:      parity,
+		StopBits:    stopBits,
+	}, nil
+}
+
+// serialOpen opens a serial port and applies the flow control.
+func serialOpen(conf EndpointSerial, name string, baud int) (io.ReadWriteCloser, error) {
+	sconf, err := serialConfig(conf, name, baud)
+	if err != nil {
+		return nil, err
+	}
+
+	port, err := serial.OpenPort(sconf)
+	if err != nil {
+		return nil, err
+	}
+
+	if conf.RTSCTS || conf.XONXOFF {
+		err := serialSetFlowControl(name, conf.RTSCTS, conf.XONXOFF)
+		if err != nil {
+			port.Close()
+			return nil, err
+		}
+	}
+
+	if conf.ReadTimeout > 0 {
+		return &serialTimedPort{port}, nil
+	}
+
+	return port, nil
+}
+
+// serialTimedPort converts the empty reads that are returned when
+// ReadTimeout expires into errors.
+type serialTimedPort struct {
+	io.ReadWriteCloser
+}
+
+func (p *serialTimedPort) Read(buf []byte) (int, error) {
+	n, err := p.ReadWriteCloser.Read(buf)
+	if n == 0 && (err == nil || err == io.EOF) {
+		return 0, errorTimeout
+	}
+	return n, err
+}
+
+// serialDetectBaud opens a serial port with the given baud rate and checks
+// whether valid frames are received within serialAutoBaudPeriod.
+// Detection is interrupted when terminate is closed.
+func serialDetectBaud(conf EndpointSerial, name string, baud int, terminate chan struct{}) bool {
+	conf.ReadTimeout = 100 * time.Millisecond
+	port, err := serialOpen(conf, name, baud)
+	if err != nil {
+		return false
+	}
+	defer port.Close()
+
+	var buf []byte
+	tmp := make([]byte, bufferSize)
+	deadline := time.Now().Add(serialAutoBaudPeriod)
+
+	for time.Now().Before(deadline) {
+		select {
+		case <-terminate:
+			return false
+		default:
+		}
+
+		n, err := port.Read(tmp)
+		if err != nil && err != errorTimeout {
+			return false
+		}
+
+		buf = append(buf, tmp[:n]...)
+		if serialHasFrames(buf) {
+			return true
+		}
+	}
+
+	return false
+}
 
 // EndpointSerial sets up a endpoint that works with a serial port.
 type EndpointSerial struct {
 	// the address of the serial port in format name:baudrate
 	// example: /dev/ttyUSB0:57600
+	// If AutoBaud is enabled, the baud rate can be omitted.
+	// If name is "auto", available serial ports are scanned and the first one
+	// that produces valid frames is used; when the port disappears,
+	// scanning starts again.
 	Address string
+
+	// (optional) automatically detect the baud rate, by trying every rate
+	// in AutoBaudRates until valid frames are received.
+	// Detection relies on heartbeats, that must be emitted by the device,
+	// and is performed after the node has been created; the detected rate
+	// is reported with EventBaudRateDetected. If detection fails, it is
+	// repeated periodically.
+	AutoBaud bool
+
+	// (optional) the baud rates that are tried when AutoBaud is enabled.
+	// It defaults to the most common rates used by telemetry radios
+	// and flight controllers.
+	AutoBaudRates []int
+
+	// (optional) the number of data bits, between 5 and 8.
+	// It defaults to 8.
+	DataBits int
+
+	// (optional) the parity.
+	// It defaults to SerialParityNone.
+	Parity SerialParity
+
+	// (optional) the number of stop bits.
+	// It defaults to SerialStopBits1.
+	StopBits SerialStopBits
+
+	// (optional) enable hardware flow control (RTS/CTS).
+	// It is supported on Linux only.
+	RTSCTS bool
+
+	// (optional) enable software flow control (XON/XOFF).
+	// It is supported on Linux only.
+	XONXOFF bool
+
+	// (optional) the maximum period without incoming data, after which the
+	// port is considered disconnected. It must be less or equal
+	// than 25.5 seconds. It defaults to zero, that means no timeout.
+	ReadTimeout time.Duration
 }
 
 type endpointSerial struct {
@@ -24,18 +264,47 @@ type endpointSerial struct {
 }
 
 func (conf EndpointSerial) init() (Endpoint, error) {
-	matches := reSerial.FindStringSubmatch(conf.Address)
-	if matches == nil {
+	return conf.initReconnect(nil)
+}
+
+func (conf EndpointSerial) initReconnect(policy *ReconnectPolicy) (Endpoint, error) {
+	var name string
+	var baud int
+
+	if matches := reSerial.FindStringSubmatch(conf.Address); matches != nil {
+		name = matches[1]
+		baud, _ = strconv.Atoi(matches[2])
+	} else if matches := reSerialNameOnly.FindStringSubmatch(conf.Address); matches != nil && conf.AutoBaud {
+		name = matches[1]
+	} else {
 		return nil, fmt.Errorf("invalid address")
 	}
 
-	name := matches[1]
-	baud, _ := strconv.Atoi(matches[2])
+	_, err := serialConfig(conf, name, baud)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != "auto" && policy != nil {
+		return nil, fmt.Errorf("ReconnectPolicy requires a serial port in auto mode")
+	}
+
+	// the baud rate is detected by the routine of the endpoint, in order
+	// not to block the creation of the node
+	if name == "auto" || conf.AutoBaud {
+		if policy == nil {
+			policy = &ReconnectPolicy{}
+		}
+
+		err := policy.validate()
+		if err != nil {
+			return nil, err
+		}
+
+		return initEndpointSerialAuto(conf, name, baud, *policy)
+	}
 
-	rwc, err := serial.OpenPort(&serial.Config{
-		Name: name,
-		Baud: baud,
-	})
+	rwc, err := serialOpen(conf, name, baud)
 	if err != nil {
 		return nil, err
 	}
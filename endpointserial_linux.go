@@ -0,0 +1,43 @@
+package gomavlib
+
+import (
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// serialSetFlowControl enables flow control on a serial port that is already
+// open. Settings are bound to the device, therefore they are applied with a
+// separate file descriptor.
+func serialSetFlowControl(name string, rtscts bool, xonxoff bool) error {
+	f, err := os.OpenFile(name, unix.O_RDWR|unix.O_NOCTTY|unix.O_NONBLOCK, 0o666)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var t unix.Termios
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(),
+		uintptr(unix.TCGETS), uintptr(unsafe.Pointer(&t)))
+	if errno != 0 {
+		return errno
+	}
+
+	if rtscts {
+		t.Cflag |= unix.CRTSCTS
+	}
+	if xonxoff {
+		t.Iflag |= unix.IXON | unix.IXOFF
+		t.Cc[unix.VSTART] = 0x11
+		t.Cc[unix.VSTOP] = 0x13
+	}
+
+	_, _, errno = unix.Syscall(unix.SYS_IOCTL, f.Fd(),
+		uintptr(unix.TCSETS), uintptr(unsafe.Pointer(&t)))
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package gomavlib
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+// openPty opens a pseudo terminal and returns its master and the name of
+// its slave.
+func openPty(t *testing.T) (*os.File, string) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skip("pseudo terminals are not available")
+	}
+
+	var unlock int32
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, master.Fd(),
+		uintptr(unix.TIOCSPTLCK), uintptr(unsafe.Pointer(&unlock)))
+	require.Equal(t, unix.Errno(0), errno)
+
+	var num uint32
+	_, _, errno = unix.Syscall(unix.SYS_IOCTL, master.Fd(),
+		uintptr(unix.TIOCGPTN), uintptr(unsafe.Pointer(&num)))
+	require.Equal(t, unix.Errno(0), errno)
+
+	name := fmt.Sprintf("/dev/pts/%d", num)
+	if _, err := os.Stat(name); err != nil {
+		master.Close()
+		t.Skip("pseudo terminals are not available")
+	}
+
+	return master, name
+}
+
+func TestSerialSetFlowControl(t *testing.T) {
+	master, name := openPty(t)
+	defer master.Close()
+
+	port, err := serialOpen(EndpointSerial{
+		RTSCTS:  true,
+		XONXOFF: true,
+	}, name, 57600)
+	require.NoError(t, err)
+	defer port.Close()
+
+	f, err := os.OpenFile(name, unix.O_RDWR|unix.O_NOCTTY|unix.O_NONBLOCK, 0)
+	require.NoError(t, err)
+	defer f.Close()
+
+	tio, err := unix.IoctlGetTermios(int(f.Fd()), unix.TCGETS)
+	require.NoError(t, err)
+
+	require.NotEqual(t, uint32(0), tio.Cflag&unix.CRTSCTS)
+	require.Equal(t, uint32(unix.IXON|unix.IXOFF), tio.Iflag&(unix.IXON|unix.IXOFF))
+}
+
+func TestEndpointSerialAutoBaud(t *testing.T) {
+	master, name := openPty(t)
+	defer master.Close()
+
+	// keep the slave open, in order not to hang up the pseudo terminal
+	// when the port used for detection is closed
+	slave, err := os.OpenFile(name, unix.O_RDWR|unix.O_NOCTTY, 0)
+	require.NoError(t, err)
+	defer slave.Close()
+
+	dialectDE, err := dialect.NewDecEncoder(&dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}) //nolint:govet
+	require.NoError(t, err)
+
+	tr, err := transceiver.New(transceiver.Conf{
+		Reader:      master,
+		Writer:      master,
+		DialectDE:   dialectDE,
+		OutVersion:  transceiver.V2,
+		OutSystemID: 11,
+	})
+	require.NoError(t, err)
+
+	// the device emits heartbeats
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-time.After(100 * time.Millisecond):
+				tr.WriteMessage(&MessageHeartbeat{})
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// detection is performed after the node has been created
+	node, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{EndpointSerial{
+			Address:       name,
+			AutoBaud:      true,
+			AutoBaudRates: []int{57600},
+		}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	for evt := range node.Events() {
+		if tevt, ok := evt.(*EventBaudRateDetected); ok {
+			require.Equal(t, name, tevt.Device)
+			require.Equal(t, 57600, tevt.BaudRate)
+			require.NotNil(t, tevt.Channel)
+			break
+		}
+	}
+
+	for evt := range node.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, byte(11), fr.SystemID())
+			break
+		}
+	}
+}
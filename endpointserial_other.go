@@ -0,0 +1,12 @@
+//go:build !linux
+// +build !linux
+
+package gomavlib
+
+import (
+	"fmt"
+)
+
+func serialSetFlowControl(name string, rtscts bool, xonxoff bool) error {
+	return fmt.Errorf("flow control is not supported on this platform")
+}
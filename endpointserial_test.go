@@ -0,0 +1,132 @@
+package gomavlib
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tarm/serial"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+func TestSerialHasFrames(t *testing.T) {
+	dialectDE, err := dialect.NewDecEncoder(&dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}) //nolint:govet
+	require.NoError(t, err)
+
+	for _, ver := range []transceiver.Version{transceiver.V1, transceiver.V2} {
+		var buf bytes.Buffer
+		tr, err := transceiver.New(transceiver.Conf{
+			Reader:      &buf,
+			Writer:      &buf,
+			DialectDE:   dialectDE,
+			OutVersion:  ver,
+			OutSystemID: 1,
+		})
+		require.NoError(t, err)
+
+		err = tr.WriteMessage(&MessageHeartbeat{
+			Type:           1,
+			Autopilot:      2,
+			BaseMode:       3,
+			CustomMode:     6,
+			SystemStatus:   4,
+			MavlinkVersion: 5,
+		})
+		require.NoError(t, err)
+
+		enc := buf.Bytes()
+
+		// valid frame preceded by garbage
+		require.Equal(t, true, serialHasFrames(append([]byte{0x01, 0xFD, 0x03}, enc...)))
+
+		// truncated frame
+		require.Equal(t, false, serialHasFrames(enc[:len(enc)-1]))
+
+		// corrupted frame, as received with a wrong baud rate
+		corrupted := append([]byte(nil), enc...)
+		corrupted[len(corrupted)-3] ^= 0xFF
+		require.Equal(t, false, serialHasFrames(corrupted))
+	}
+}
+
+func TestEndpointSerialAutoClose(t *testing.T) {
+	node, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointSerial{Address: "auto:57600"},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	node.Close()
+}
+
+func TestSerialConfig(t *testing.T) {
+	conf, err := serialConfig(EndpointSerial{}, "/dev/ttyUSB0", 57600)
+	require.NoError(t, err)
+	require.Equal(t, &serial.Config{
+		Name:     "/dev/ttyUSB0",
+		Baud:     57600,
+		Parity:   serial.ParityNone,
+		StopBits: serial.Stop1,
+	}, conf)
+
+	conf, err = serialConfig(EndpointSerial{
+		DataBits:    7,
+		Parity:      SerialParityOdd,
+		StopBits:    SerialStopBits2,
+		ReadTimeout: 2 * time.Second,
+	}, "/dev/ttyUSB0", 115200)
+	require.NoError(t, err)
+	require.Equal(t, &serial.Config{
+		Name:        "/dev/ttyUSB0",
+		Baud:        115200,
+		Size:        7,
+		Parity:      serial.ParityOdd,
+		StopBits:    serial.Stop2,
+		ReadTimeout: 2 * time.Second,
+	}, conf)
+
+	for _, ca := range []struct {
+		conf EndpointSerial
+		err  string
+	}{
+		{EndpointSerial{DataBits: 9}, "DataBits must be between 5 and 8"},
+		{EndpointSerial{Parity: 10}, "invalid parity"},
+		{EndpointSerial{StopBits: 10}, "invalid stop bits"},
+		{EndpointSerial{ReadTimeout: 30 * time.Second}, "ReadTimeout must be between 0 and 25.5s"},
+	} {
+		_, err := serialConfig(ca.conf, "/dev/ttyUSB0", 57600)
+		require.EqualError(t, err, ca.err)
+
+		ca.conf.Address = "/dev/ttyUSB0:57600"
+		_, err = NewNode(NodeConf{
+			Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+			OutVersion:  V2,
+			OutSystemID: 10,
+			Endpoints:   []EndpointConf{ca.conf},
+		})
+		require.EqualError(t, err, ca.err)
+	}
+}
+
+type testSerialEmptyReader struct {
+	io.ReadWriteCloser
+}
+
+func (testSerialEmptyReader) Read(buf []byte) (int, error) {
+	return 0, io.EOF
+}
+
+func TestSerialTimedPort(t *testing.T) {
+	p := &serialTimedPort{testSerialEmptyReader{}}
+	_, err := p.Read(make([]byte, 10))
+	require.Equal(t, errorTimeout, err)
+}
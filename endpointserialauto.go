@@ -0,0 +1,279 @@
+package gomavlib
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/multibuffer"
+)
+
+const (
+	serialRescanPeriod = 2 * time.Second
+)
+
+var errorSerialNoDevices = fmt.Errorf("no serial device produces valid frames")
+
+// serialListDevices returns the serial devices that are likely to be
+// connected to Mavlink devices.
+func serialListDevices() []string {
+	var patterns []string
+
+	switch runtime.GOOS {
+	case "windows":
+		var ret []string
+		for i := 1; i <= 32; i++ {
+			ret = append(ret, fmt.Sprintf("COM%d", i))
+		}
+		return ret
+
+	case "darwin":
+		patterns = []string{
+			"/dev/tty.usbserial*",
+			"/dev/tty.usbmodem*",
+			"/dev/tty.SLAB_USBtoUART*",
+		}
+
+	default:
+		patterns = []string{
+			"/dev/ttyUSB*",
+			"/dev/ttyACM*",
+		}
+	}
+
+	var ret []string
+	for _, pattern := range patterns {
+		matches, _ := filepath.Glob(pattern)
+		ret = append(ret, matches...)
+	}
+	return ret
+}
+
+// endpointSerialAuto is a serial endpoint that scans available devices,
+// attaches to the first one that produces valid frames and starts
+// scanning again when the device disappears. It is also used with a fixed
+// device when AutoBaud is enabled, in order to detect the baud rate in
+// background instead of blocking the creation of the node.
+type endpointSerialAuto struct {
+	conf        EndpointSerial
+	name        string
+	baud        int
+	policy      ReconnectPolicy
+	writerMutex sync.Mutex
+	writer      io.Writer
+
+	// in
+	terminate chan struct{}
+	read      chan []byte
+	reconnect chan *EventReconnect
+	baudRates chan *EventBaudRateDetected
+}
+
+func initEndpointSerialAuto(conf EndpointSerial, name string, baud int,
+	policy ReconnectPolicy) (Endpoint, error) {
+	t := &endpointSerialAuto{
+		conf:      conf,
+		name:      name,
+		baud:      baud,
+		policy:    policy,
+		terminate: make(chan struct{}),
+		read:      make(chan []byte),
+		reconnect: make(chan *EventReconnect),
+		baudRates: make(chan *EventBaudRateDetected),
+	}
+
+	go t.do()
+	return t, nil
+}
+
+func (t *endpointSerialAuto) isEndpoint() {}
+
+func (t *endpointSerialAuto) Conf() EndpointConf {
+	return t.conf
+}
+
+func (t *endpointSerialAuto) Label() string {
+	return "serial"
+}
+
+func (t *endpointSerialAuto) Close() error {
+	close(t.terminate)
+	return nil
+}
+
+func (t *endpointSerialAuto) reconnectEvents() chan *EventReconnect {
+	return t.reconnect
+}
+
+func (t *endpointSerialAuto) baudRateEvents() chan *EventBaudRateDetected {
+	return t.baudRates
+}
+
+// scan returns the first device that produces valid frames.
+func (t *endpointSerialAuto) scan() (string, int) {
+	devices := []string{t.name}
+	if t.name == "auto" {
+		devices = serialListDevices()
+	}
+
+	rates := []int{t.baud}
+	if t.conf.AutoBaud {
+		rates = t.conf.AutoBaudRates
+		if len(rates) == 0 {
+			rates = serialAutoBaudRates
+		}
+	}
+
+	for _, name := range devices {
+		for _, rate := range rates {
+			select {
+			case <-t.terminate:
+				return "", 0
+			default:
+			}
+
+			if serialDetectBaud(t.conf, name, rate, t.terminate) {
+				return name, rate
+			}
+		}
+	}
+
+	return "", 0
+}
+
+func (t *endpointSerialAuto) closeRead() {
+	go func() {
+		for range t.read {
+		}
+	}()
+	close(t.read)
+}
+
+func (t *endpointSerialAuto) do() {
+	mb := multibuffer.New(2, bufferSize)
+	rc := newReconnecter(t.policy, serialRescanPeriod)
+
+	for {
+		var port io.ReadWriteCloser
+		var scanErr error
+		var detected *EventBaudRateDetected
+		scanDone := make(chan struct{})
+		go func() {
+			defer close(scanDone)
+
+			name, baud := t.scan()
+			if name == "" {
+				if t.name == "auto" {
+					scanErr = errorSerialNoDevices
+				} else {
+					scanErr = fmt.Errorf("unable to detect the baud rate of %s", t.name)
+				}
+				return
+			}
+			detected = &EventBaudRateDetected{Device: name, BaudRate: baud}
+
+			port, scanErr = serialOpen(t.conf, name, baud)
+			if scanErr != nil {
+				port = nil // ensure port is nil in case of error
+			}
+		}()
+
+		select {
+		case <-scanDone:
+		case <-t.terminate:
+			<-scanDone
+			if port != nil {
+				port.Close()
+			}
+			t.closeRead()
+			return
+		}
+
+		if port == nil {
+			// wait before scanning again
+			if !rc.wait(scanErr, t.reconnect, t.terminate) {
+				t.closeRead()
+				return
+			}
+			continue
+		}
+
+		rc.reset()
+
+		if t.conf.AutoBaud {
+			select {
+			case t.baudRates <- detected:
+			case <-t.terminate:
+				port.Close()
+				t.closeRead()
+				return
+			}
+		}
+
+		func() {
+			t.writerMutex.Lock()
+			defer t.writerMutex.Unlock()
+			t.writer = port
+		}()
+
+		readerDone := make(chan struct{})
+		go func() {
+			defer close(readerDone)
+
+			for {
+				buf := mb.Next()
+				n, err := port.Read(buf)
+				if err != nil {
+					return
+				}
+
+				t.read <- buf[:n]
+			}
+		}()
+
+		select {
+		case <-readerDone:
+		case <-t.terminate:
+			go func() {
+				for range t.read {
+				}
+			}()
+			port.Close()
+			<-readerDone
+			close(t.read)
+			return
+		}
+
+		// device disappeared, scan again
+		port.Close()
+		func() {
+			t.writerMutex.Lock()
+			defer t.writerMutex.Unlock()
+			t.writer = nil
+		}()
+	}
+}
+
+func (t *endpointSerialAuto) Read(buf []byte) (int, error) {
+	src, ok := <-t.read
+	if !ok {
+		return 0, errorTerminated
+	}
+	n := copy(buf, src)
+	return n, nil
+}
+
+func (t *endpointSerialAuto) Write(buf []byte) (int, error) {
+	t.writerMutex.Lock()
+	defer t.writerMutex.Unlock()
+
+	// drop packets if disconnected
+	if t.writer == nil {
+		return 0, fmt.Errorf("disconnected")
+	}
+
+	return t.writer.Write(buf)
+}
@@ -1,16 +1,21 @@
 package gomavlib
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/aler9/gomavlib/pkg/udplistener"
+	"github.com/aler9/gomavlib/pkg/wsconn"
 )
 
 type endpointServerConf interface {
-	isUDP() bool
-	getAddress() string
+	getLabelPrefix() string
+	listen() (net.Listener, error)
 	init() (Endpoint, error)
 }
 
@@ -21,14 +26,58 @@ type endpointServerConf interface {
 type EndpointTCPServer struct {
 	// listen address, example: 0.0.0.0:5600
 	Address string
+
+	// (optional) the maximum number of simultaneous clients.
+	// Additional connections are closed as soon as they are accepted.
+	// It defaults to zero, that means no limit.
+	MaxClients int
+
+	// (optional) the maximum period without incoming data, after which
+	// a connection is closed. It defaults to 60 seconds.
+	IdleTimeout time.Duration
+
+	// (optional) the networks, in CIDR notation (i.e. 192.168.0.0/16),
+	// from which connections are accepted.
+	// It defaults to nil, that means all networks.
+	AllowedNetworks []string
 }
 
-func (EndpointTCPServer) isUDP() bool {
-	return false
+func (EndpointTCPServer) getLabelPrefix() string {
+	return "tcp"
 }
 
-func (conf EndpointTCPServer) getAddress() string {
-	return conf.Address
+func (conf EndpointTCPServer) listen() (net.Listener, error) {
+	return net.Listen("tcp4", conf.Address)
+}
+
+func (conf EndpointTCPServer) init() (Endpoint, error) {
+	_, _, err := net.SplitHostPort(conf.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address")
+	}
+
+	if conf.MaxClients < 0 {
+		return nil, fmt.Errorf("MaxClients must be positive")
+	}
+
+	if conf.IdleTimeout < 0 {
+		return nil, fmt.Errorf("IdleTimeout must be positive")
+	}
+
+	var allowed []*net.IPNet
+	for _, cidr := range conf.AllowedNetworks {
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network '%s'", cidr)
+		}
+		allowed = append(allowed, ipnet)
+	}
+
+	return initEndpointServer(conf, endpointServerLimits{
+		maxClients:      conf.MaxClients,
+		idleTimeout:     conf.IdleTimeout,
+		allowedNetworks: allowed,
+	})
 }
 
 // EndpointUDPServer sets up a endpoint that works with an UDP server.
@@ -37,50 +86,190 @@ func (conf EndpointTCPServer) getAddress() string {
 type EndpointUDPServer struct {
 	// listen address, example: 0.0.0.0:5600
 	Address string
+
+	// (optional) the maximum period without incoming data from a remote
+	// address, after which the address is considered disconnected and its
+	// channel is closed. It defaults to 60 seconds.
+	IdleTimeout time.Duration
 }
 
-func (EndpointUDPServer) isUDP() bool {
-	return true
+func (EndpointUDPServer) getLabelPrefix() string {
+	return "udp"
 }
 
-func (conf EndpointUDPServer) getAddress() string {
-	return conf.Address
+func (conf EndpointUDPServer) listen() (net.Listener, error) {
+	return udplistener.New("udp4", conf.Address)
 }
 
-type endpointServer struct {
-	conf     endpointServerConf
-	listener net.Listener
+func (conf EndpointUDPServer) init() (Endpoint, error) {
+	_, _, err := net.SplitHostPort(conf.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address")
+	}
 
-	// in
-	terminate chan struct{}
+	if conf.IdleTimeout < 0 {
+		return nil, fmt.Errorf("IdleTimeout must be positive")
+	}
+
+	return initEndpointServer(conf, endpointServerLimits{
+		idleTimeout: conf.IdleTimeout,
+	})
 }
 
-func (conf EndpointTCPServer) init() (Endpoint, error) {
-	return initEndpointServer(conf)
+// EndpointTLSServer sets up a endpoint that works with a TCP server
+// protected by TLS. It allows to route frames through the internet
+// without exposing their content.
+type EndpointTLSServer struct {
+	// listen address, example: 0.0.0.0:5600
+	Address string
+
+	// the TLS configuration. It must contain at least a server certificate.
+	// Client certificates can be requested and verified by filling
+	// the ClientAuth and ClientCAs fields.
+	Config *tls.Config
 }
 
-func (conf EndpointUDPServer) init() (Endpoint, error) {
-	return initEndpointServer(conf)
+func (EndpointTLSServer) getLabelPrefix() string {
+	return "tls"
+}
+
+func (conf EndpointTLSServer) listen() (net.Listener, error) {
+	ln, err := net.Listen("tcp4", conf.Address)
+	if err != nil {
+		return nil, err
+	}
+	return tls.NewListener(ln, conf.Config), nil
+}
+
+func (conf EndpointTLSServer) init() (Endpoint, error) {
+	_, _, err := net.SplitHostPort(conf.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address")
+	}
+	if conf.Config == nil ||
+		(len(conf.Config.Certificates) == 0 && conf.Config.GetCertificate == nil) {
+		return nil, fmt.Errorf("TLS configuration must contain a certificate")
+	}
+	return initEndpointServer(conf, endpointServerLimits{})
+}
+
+// EndpointWebsocketServer sets up a endpoint that works with a WebSocket server.
+// Frames are exchanged inside binary WebSocket messages. This allows
+// browser-based applications to communicate directly with the node.
+type EndpointWebsocketServer struct {
+	// listen address, example: 0.0.0.0:5600
+	Address string
+
+	// (optional) the HTTP path on which connections are accepted.
+	// It defaults to "/".
+	Path string
+
+	// (optional) the TLS configuration. If provided, the server
+	// accepts wss:// connections.
+	Config *tls.Config
+}
+
+func (EndpointWebsocketServer) getLabelPrefix() string {
+	return "ws"
+}
+
+func (conf EndpointWebsocketServer) listen() (net.Listener, error) {
+	path := conf.Path
+	if path == "" {
+		path = "/"
+	}
+	return wsconn.Listen(conf.Address, path, conf.Config)
 }
 
-func initEndpointServer(conf endpointServerConf) (Endpoint, error) {
-	_, _, err := net.SplitHostPort(conf.getAddress())
+func (conf EndpointWebsocketServer) init() (Endpoint, error) {
+	_, _, err := net.SplitHostPort(conf.Address)
 	if err != nil {
 		return nil, fmt.Errorf("invalid address")
 	}
+	return initEndpointServer(conf, endpointServerLimits{})
+}
+
+// EndpointUnixServer sets up a endpoint that works with a Unix domain socket server.
+// It allows processes running on the same machine to exchange frames without
+// using the network stack.
+type EndpointUnixServer struct {
+	// path of the socket, example: /tmp/mavlink.sock
+	Path string
+
+	// (optional) use datagram sockets (SOCK_DGRAM) instead of
+	// stream sockets (SOCK_STREAM). In this mode, a channel is created
+	// for every client that is bound to a path.
+	Datagram bool
+}
+
+func (EndpointUnixServer) getLabelPrefix() string {
+	return "unix"
+}
+
+func (conf EndpointUnixServer) listen() (net.Listener, error) {
+	// remove the socket left by a previous instance
+	if fi, err := os.Stat(conf.Path); err == nil && (fi.Mode()&os.ModeSocket) != 0 {
+		os.Remove(conf.Path)
+	}
+
+	if conf.Datagram {
+		return udplistener.New("unixgram", conf.Path)
+	}
+	return net.Listen("unix", conf.Path)
+}
+
+func (conf EndpointUnixServer) init() (Endpoint, error) {
+	if conf.Path == "" {
+		return nil, fmt.Errorf("invalid path")
+	}
+	return initEndpointServer(conf, endpointServerLimits{})
+}
+
+// endpointServerLimits contains the limits applied to incoming connections.
+type endpointServerLimits struct {
+	maxClients      int
+	idleTimeout     time.Duration
+	allowedNetworks []*net.IPNet
+}
 
-	var listener net.Listener
-	if conf.isUDP() {
-		listener, err = udplistener.New("udp4", conf.getAddress())
-	} else {
-		listener, err = net.Listen("tcp4", conf.getAddress())
+func (l endpointServerLimits) isAllowed(addr net.Addr) bool {
+	if len(l.allowedNetworks) == 0 {
+		return true
 	}
+
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+
+	for _, ipnet := range l.allowedNetworks {
+		if ipnet.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+type endpointServer struct {
+	conf       endpointServerConf
+	limits     endpointServerLimits
+	listener   net.Listener
+	mutex      sync.Mutex
+	numClients int
+
+	// in
+	terminate chan struct{}
+}
+
+func initEndpointServer(conf endpointServerConf, limits endpointServerLimits) (Endpoint, error) {
+	listener, err := conf.listen()
 	if err != nil {
 		return nil, err
 	}
 
 	t := &endpointServer{
 		conf:      conf,
+		limits:    limits,
 		listener:  listener,
 		terminate: make(chan struct{}),
 	}
@@ -99,22 +288,62 @@ func (t *endpointServer) Close() error {
 	return nil
 }
 
-func (t *endpointServer) Accept() (string, io.ReadWriteCloser, error) {
-	rawConn, err := t.listener.Accept()
-	// wait termination, do not report errors
-	if err != nil {
-		<-t.terminate
-		return "", nil, errorTerminated
+// addClient reserves a slot for a client, if MaxClients allows it.
+func (t *endpointServer) addClient() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.limits.maxClients != 0 && t.numClients >= t.limits.maxClients {
+		return false
 	}
 
-	label := fmt.Sprintf("%s:%s", func() string {
-		if t.conf.isUDP() {
-			return "udp"
+	t.numClients++
+	return true
+}
+
+func (t *endpointServer) removeClient() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.numClients--
+}
+
+func (t *endpointServer) Accept() (string, io.ReadWriteCloser, error) {
+	for {
+		rawConn, err := t.listener.Accept()
+		// wait termination, do not report errors
+		if err != nil {
+			<-t.terminate
+			return "", nil, errorTerminated
 		}
-		return "tcp"
-	}(), rawConn.RemoteAddr())
 
-	conn := &netTimedConn{rawConn}
+		if !t.limits.isAllowed(rawConn.RemoteAddr()) || !t.addClient() {
+			rawConn.Close()
+			continue
+		}
+
+		label := fmt.Sprintf("%s:%s", t.conf.getLabelPrefix(), rawConn.RemoteAddr())
+
+		conn := &endpointServerConn{
+			netTimedConn: netTimedConn{
+				conn:        rawConn,
+				readTimeout: t.limits.idleTimeout,
+			},
+			onClose: t.removeClient,
+		}
+
+		return label, conn, nil
+	}
+}
+
+// endpointServerConn is a connection that releases its slot when closed.
+type endpointServerConn struct {
+	netTimedConn
+	closeOnce sync.Once
+	onClose   func()
+}
 
-	return label, conn, nil
+func (c *endpointServerConn) Close() error {
+	err := c.netTimedConn.Close()
+	c.closeOnce.Do(c.onClose)
+	return err
 }
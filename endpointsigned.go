@@ -0,0 +1,23 @@
+package gomavlib
+
+import (
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+// EndpointSigned wraps an endpoint configuration and replaces the signing
+// configuration of the node (InKey, InKeys and OutKey) with a dedicated one,
+// that is used by all the channels of the endpoint.
+// Leave all keys empty to disable signing on the endpoint.
+type EndpointSigned struct {
+	// the wrapped endpoint configuration.
+	EndpointConf
+
+	// (optional) the secret key used to validate incoming frames.
+	// Non signed frames are discarded, as well as frames with a version < 2.0.
+	InKey *frame.V2Key
+	// (optional) additional secret keys used to validate incoming frames.
+	InKeys []*frame.V2Key
+	// (optional) the secret key used to sign outgoing frames.
+	// This feature requires a version >= 2.0.
+	OutKey *frame.V2Key
+}
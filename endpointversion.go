@@ -0,0 +1,14 @@
+package gomavlib
+
+// EndpointVersion wraps an endpoint configuration and replaces the Mavlink
+// version used to encode messages (OutVersion) with a dedicated one,
+// that is used by all the channels of the endpoint.
+// Together with NodeConf.TranslateVersion, this allows to route frames
+// between devices that support different versions.
+type EndpointVersion struct {
+	// the wrapped endpoint configuration.
+	EndpointConf
+
+	// Mavlink version used to encode messages.
+	Version Version
+}
@@ -0,0 +1,80 @@
+package gomavlib
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// EventQueuePolicy is the behavior of the node when the event queue is full.
+type EventQueuePolicy int
+
+const (
+	// EventQueueBlock waits until the event queue is read. Routines of the
+	// node that emit events, including the ones that read channels, are
+	// stalled until the user reads events.
+	EventQueueBlock EventQueuePolicy = iota
+
+	// EventQueueDropOldest discards the oldest event in the queue in order
+	// to make room for the new one.
+	EventQueueDropOldest
+
+	// EventQueueDropNewest discards the new event.
+	EventQueueDropNewest
+)
+
+// String implements fmt.Stringer.
+func (p EventQueuePolicy) String() string {
+	switch p {
+	case EventQueueBlock:
+		return "block"
+
+	case EventQueueDropOldest:
+		return "drop-oldest"
+
+	case EventQueueDropNewest:
+		return "drop-newest"
+	}
+	return "unknown"
+}
+
+// releaseEvent releases a discarded event, in case it comes from a pool.
+func releaseEvent(evt Event) {
+	if fevt, ok := evt.(*EventFrame); ok {
+		fevt.Release()
+	}
+}
+
+// pushEvent inserts an event into the event queue, by following the
+// overflow policy.
+func (n *Node) pushEvent(evt Event) {
+	switch n.conf.EventQueueOverflow {
+	case EventQueueDropOldest:
+		for {
+			select {
+			case n.events <- evt:
+				return
+			default:
+			}
+
+			select {
+			case old := <-n.events:
+				releaseEvent(old)
+				atomic.AddUint64(&n.stats.droppedEvents, 1)
+				n.log(LogLevelWarn, "event queue is full, event discarded", "event", fmt.Sprintf("%T", old))
+			default:
+			}
+		}
+
+	case EventQueueDropNewest:
+		select {
+		case n.events <- evt:
+		default:
+			releaseEvent(evt)
+			atomic.AddUint64(&n.stats.droppedEvents, 1)
+			n.log(LogLevelWarn, "event queue is full, event discarded", "event", fmt.Sprintf("%T", evt))
+		}
+
+	default:
+		n.events <- evt
+	}
+}
@@ -1,8 +1,13 @@
 package gomavlib
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"github.com/aler9/gomavlib/pkg/frame"
 	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/transceiver"
 )
 
 // Event is the interface implemented by all events received with node.Events().
@@ -31,10 +36,107 @@ type EventFrame struct {
 
 	// the channel from which the frame was received
 	Channel *Channel
+
+	// the key that validated the signature of the frame, among InKey and
+	// InKeys. It is nil if signatures are not validated.
+	Key *frame.V2Key
+
+	ctx        context.Context
+	decodeOnce sync.Once
+	decoded    msg.Message
+	pooled     bool
+	kept       bool
+}
+
+// eventFramePool contains the EventFrames emitted when PooledEvents is true.
+var eventFramePool = sync.Pool{
+	New: func() interface{} {
+		return new(EventFrame)
+	},
+}
+
+func (n *Node) newEventFrame(fr frame.Frame, ch *Channel, key *frame.V2Key) *EventFrame {
+	if !n.conf.PooledEvents {
+		return &EventFrame{Frame: fr, Channel: ch, Key: key}
+	}
+
+	evt := eventFramePool.Get().(*EventFrame)
+	evt.Frame = fr
+	evt.Channel = ch
+	evt.Key = key
+	evt.pooled = true
+	return evt
 }
 
 func (*EventFrame) isEventOut() {}
 
+// Release returns the event, and the buffer of its payload, to a pool,
+// in order to reuse them when receiving other frames.
+// It must be called once, when PooledEvents is true and the event, its frame
+// and its message are not used anymore. Otherwise, it has no effect.
+func (res *EventFrame) Release() {
+	if !res.pooled {
+		return
+	}
+
+	if !res.kept {
+		if raw, ok := res.Frame.GetMessage().(*msg.MessageRaw); ok {
+			frame.ReleasePayload(raw)
+		}
+	}
+
+	*res = EventFrame{}
+	eventFramePool.Put(res)
+}
+
+// keep prevents the payload from being reused after the release of the event,
+// since the frame is still used by the node.
+func (res *EventFrame) keep() {
+	res.kept = true
+}
+
+// detach returns an event that can be used after the release of this one.
+func (res *EventFrame) detach() *EventFrame {
+	if !res.pooled {
+		return res
+	}
+
+	res.kept = true
+	return &EventFrame{Frame: res.Frame, Channel: res.Channel, Key: res.Key, ctx: res.ctx}
+}
+
+// copyPayload returns a copy of a frame whose payload is not released
+// together with the event that contains the original frame.
+func copyPayload(fr frame.Frame) frame.Frame {
+	raw, ok := fr.GetMessage().(*msg.MessageRaw)
+	if !ok {
+		return fr
+	}
+
+	cpy := &msg.MessageRaw{
+		ID:      raw.ID,
+		Content: append([]byte(nil), raw.Content...),
+	}
+
+	fr = fr.Clone()
+	switch tfr := fr.(type) {
+	case *frame.V1Frame:
+		tfr.Message = cpy
+	case *frame.V2Frame:
+		tfr.Message = cpy
+	}
+	return fr
+}
+
+// Context returns the context of the span that traces the reception of the
+// frame, if a Tracer is set, or an empty context otherwise.
+func (res *EventFrame) Context() context.Context {
+	if res.ctx == nil {
+		return context.Background()
+	}
+	return res.ctx
+}
+
 // SystemID returns the frame system id.
 func (res *EventFrame) SystemID() byte {
 	return res.Frame.GetSystemID()
@@ -46,8 +148,94 @@ func (res *EventFrame) ComponentID() byte {
 }
 
 // Message returns the message inside the frame.
+// If LazyDecoding is enabled, the message is decoded on the first call.
+// If the message is not in the dialect, it is returned in the MessageRaw
+// struct, that contains the message ID and the encoded payload.
 func (res *EventFrame) Message() msg.Message {
-	return res.Frame.GetMessage()
+	if _, ok := res.Frame.GetMessage().(*msg.MessageRaw); !ok ||
+		res.Channel == nil || !res.Channel.n.conf.LazyDecoding {
+		return res.Frame.GetMessage()
+	}
+
+	res.decodeOnce.Do(func() {
+		m, err := res.Channel.transceiver.DecodeMessage(res.Frame)
+		if err != nil {
+			m = res.Frame.GetMessage()
+		}
+		res.decoded = m
+	})
+	return res.decoded
+}
+
+// messageID returns the message id without decoding the message.
+func (res *EventFrame) messageID() uint32 {
+	return res.Frame.GetMessage().GetID()
+}
+
+// ParseErrorReason is the reason of a parse error.
+type ParseErrorReason int
+
+const (
+	// ParseErrorMagicByte means that a byte that is not a magic byte has
+	// been received in place of the beginning of a frame.
+	ParseErrorMagicByte ParseErrorReason = iota + 1
+
+	// ParseErrorFrame means that the frame is malformed or truncated.
+	ParseErrorFrame
+
+	// ParseErrorSignature means that the frame signature is missing or invalid.
+	ParseErrorSignature
+
+	// ParseErrorChecksum means that the frame checksum does not match.
+	ParseErrorChecksum
+
+	// ParseErrorMessage means that the message cannot be decoded.
+	ParseErrorMessage
+
+	// ParseErrorUnknownMessage means that the message is not in the dialect.
+	// It is reported only when UnknownMessagesDisable is true.
+	ParseErrorUnknownMessage
+)
+
+// String implements fmt.Stringer.
+func (r ParseErrorReason) String() string {
+	switch r {
+	case ParseErrorMagicByte:
+		return "bad magic byte"
+
+	case ParseErrorFrame:
+		return "invalid frame"
+
+	case ParseErrorSignature:
+		return "invalid signature"
+
+	case ParseErrorChecksum:
+		return "checksum mismatch"
+
+	case ParseErrorMessage:
+		return "invalid message"
+
+	case ParseErrorUnknownMessage:
+		return "unknown message"
+	}
+	return "unknown"
+}
+
+func parseErrorReason(typ transceiver.ErrorType) ParseErrorReason {
+	switch typ {
+	case transceiver.ErrorTypeMagicByte:
+		return ParseErrorMagicByte
+
+	case transceiver.ErrorTypeSignature:
+		return ParseErrorSignature
+
+	case transceiver.ErrorTypeChecksum:
+		return ParseErrorChecksum
+
+	case transceiver.ErrorTypeMessage:
+		return ParseErrorMessage
+	}
+	return ParseErrorFrame
 }
 
 // EventParseError is the event fired when a parse error occurs.
@@ -57,6 +245,13 @@ type EventParseError struct {
 
 	// the channel used to send the frame
 	Channel *Channel
+
+	// the reason of the error
+	Reason ParseErrorReason
+
+	// the offending bytes: the invalid magic byte, or the frame that
+	// cannot be decoded or has been rejected
+	Data []byte
 }
 
 func (*EventParseError) isEventOut() {}
@@ -72,3 +267,85 @@ type EventStreamRequested struct {
 }
 
 func (*EventStreamRequested) isEventOut() {}
+
+// EventSystemOnline is the event fired when a system or component starts
+// sending heartbeats. It requires SystemEventsEnable to be true.
+type EventSystemOnline struct {
+	// the system id
+	SystemID byte
+	// the component id
+	ComponentID byte
+	// the channel from which the first heartbeat was received
+	Channel *Channel
+}
+
+func (*EventSystemOnline) isEventOut() {}
+
+// EventSystemOffline is the event fired when a system or component stops
+// sending heartbeats for a period longer than SystemTimeout.
+// It requires SystemEventsEnable to be true.
+type EventSystemOffline struct {
+	// the system id
+	SystemID byte
+	// the component id
+	ComponentID byte
+}
+
+func (*EventSystemOffline) isEventOut() {}
+
+// EventPacketLoss is the event fired when a gap is detected in the sequence
+// numbers of the frames of a system and component.
+// It requires PacketLossEventsEnable to be true.
+type EventPacketLoss struct {
+	// the channel from which frames were received
+	Channel *Channel
+	// the system id
+	SystemID byte
+	// the component id
+	ComponentID byte
+	// the number of lost frames
+	Lost int
+}
+
+func (*EventPacketLoss) isEventOut() {}
+
+// EventReconnect is the event fired when an endpoint fails to connect
+// and schedules a new attempt, according to its ReconnectPolicy.
+type EventReconnect struct {
+	// the channel of the endpoint
+	Channel *Channel
+	// the number of consecutive failed attempts
+	Attempt int
+	// the delay before the next attempt
+	Delay time.Duration
+	// the error of the failed attempt
+	Error error
+}
+
+func (*EventReconnect) isEventOut() {}
+
+// EventBaudRateDetected is the event fired when the baud rate of a serial
+// port has been detected (see EndpointSerial.AutoBaud).
+type EventBaudRateDetected struct {
+	// the channel of the endpoint
+	Channel *Channel
+	// the name of the serial port
+	Device string
+	// the detected baud rate
+	BaudRate int
+}
+
+func (*EventBaudRateDetected) isEventOut() {}
+
+// EventFailover is the event fired when the outgoing traffic of a failover
+// group (see EndpointFailover) switches to another channel.
+type EventFailover struct {
+	// the system id of the group
+	SystemID byte
+	// the channel that was used before the switchover
+	Previous *Channel
+	// the channel that is used now
+	Channel *Channel
+}
+
+func (*EventFailover) isEventOut() {}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func main() {
+	// create a node which
+	// - communicates with a serial endpoint
+	// - understands common dialect
+	// - writes messages with given system id
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointSerial{Address: "/dev/ttyUSB0:57600"},
+		},
+		Dialect:     common.Dialect,
+		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID: 10,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer node.Close()
+
+	// wait for the first heartbeat of a vehicle, then arm it
+	for evt := range node.Events() {
+		if frm, ok := evt.(*gomavlib.EventFrame); ok {
+			if _, ok := frm.Message().(*common.MessageHeartbeat); ok {
+				// events must be read while the command is being sent
+				go func() {
+					ack, err := node.SendCommand(context.Background(), &gomavlib.CommandRequest{
+						Channel:         frm.Channel,
+						TargetSystem:    frm.SystemID(),
+						TargetComponent: frm.ComponentID(),
+						Command:         int(common.MAV_CMD_COMPONENT_ARM_DISARM),
+						Params:          [7]float32{1},
+					})
+					if err != nil {
+						fmt.Printf("error: %v\n", err)
+						return
+					}
+					fmt.Printf("result: %v\n", common.MAV_RESULT(ack.Result))
+				}()
+				break
+			}
+		}
+	}
+
+	for range node.Events() {
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/csvlog"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+)
+
+func main() {
+	// create a node which
+	// - communicates with a serial port
+	// - understands ardupilotmega dialect
+	// - writes messages with given system id
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointSerial{Address: "/dev/ttyUSB0:57600"},
+		},
+		Dialect:     ardupilotmega.Dialect,
+		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID: 10,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer node.Close()
+
+	// write selected messages into CSV files in the current directory,
+	// one for each message type
+	w, err := csvlog.NewWriter(".",
+		&ardupilotmega.MessageAttitude{},
+		&ardupilotmega.MessageGlobalPositionInt{},
+		&ardupilotmega.MessageSysStatus{})
+	if err != nil {
+		panic(err)
+	}
+	defer w.Close()
+
+	for evt := range node.Events() {
+		if frm, ok := evt.(*gomavlib.EventFrame); ok {
+			err := w.WriteFrame(time.Now(), frm.Frame)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
 	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
 )
 
@@ -66,7 +67,7 @@ func main() {
 	// print every message we receive
 	for evt := range node.Events() {
 		if frm, ok := evt.(*gomavlib.EventFrame); ok {
-			fmt.Printf("received: id=%d, %+v\n", frm.Message().GetID(), frm.Message())
+			fmt.Printf("received: %s\n", dialect.Format(frm.Message(), ardupilotmega.Metadata))
 		}
 	}
 }
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+)
+
+func main() {
+	// create a node which
+	// - communicates with a serial endpoint, whose baud rate is detected automatically
+	// - understands ardupilotmega dialect
+	// - writes messages with given system id
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointSerial{
+				Address:       "/dev/ttyUSB0",
+				AutoBaud:      true,
+				AutoBaudRates: []int{57600, 115200, 921600},
+			},
+		},
+		Dialect:     ardupilotmega.Dialect,
+		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID: 10,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer node.Close()
+
+	// print the detected baud rate and every message we receive
+	for evt := range node.Events() {
+		switch tevt := evt.(type) {
+		case *gomavlib.EventBaudRateDetected:
+			fmt.Printf("detected baud rate of %s: %d\n", tevt.Device, tevt.BaudRate)
+
+		case *gomavlib.EventFrame:
+			fmt.Printf("received: %s\n", dialect.Format(tevt.Message(), ardupilotmega.Metadata))
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
 	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
 )
 
@@ -14,7 +15,7 @@ func main() {
 	// - writes messages with given system id
 	node, err := gomavlib.NewNode(gomavlib.NodeConf{
 		Endpoints: []gomavlib.EndpointConf{
-			gomavlib.EndpointTCPClient{"1.2.3.4:5600"},
+			gomavlib.EndpointTCPClient{Address: "1.2.3.4:5600"},
 		},
 		Dialect:     ardupilotmega.Dialect,
 		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
@@ -28,7 +29,7 @@ func main() {
 	// print every message we receive
 	for evt := range node.Events() {
 		if frm, ok := evt.(*gomavlib.EventFrame); ok {
-			fmt.Printf("received: id=%d, %+v\n", frm.Message().GetID(), frm.Message())
+			fmt.Printf("received: %s\n", dialect.Format(frm.Message(), ardupilotmega.Metadata))
 		}
 	}
 }
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+)
+
+func main() {
+	// create a node which
+	// - communicates with a serial port
+	// - understands ardupilotmega dialect
+	// - writes messages with given system id
+	// - invokes callbacks instead of emitting events on a channel
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointSerial{Address: "/dev/ttyUSB0:57600"},
+		},
+		Dialect:     ardupilotmega.Dialect,
+		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID: 10,
+		OnFrame: func(evt *gomavlib.EventFrame) {
+			fmt.Printf("frame received: %v\n", evt)
+		},
+		OnParseError: func(evt *gomavlib.EventParseError) {
+			fmt.Printf("parse error: %v\n", evt)
+		},
+		OnChannelOpen: func(evt *gomavlib.EventChannelOpen) {
+			fmt.Printf("channel opened: %v\n", evt)
+		},
+		OnChannelClose: func(evt *gomavlib.EventChannelClose) {
+			fmt.Printf("channel closed: %v\n", evt)
+		},
+		OnEvent: func(evt gomavlib.Event) {
+			fmt.Printf("event: %v\n", evt)
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer node.Close()
+
+	// callbacks are invoked by the node, therefore the main routine
+	// can be used for other purposes
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+	<-c
+}
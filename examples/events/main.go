@@ -12,13 +12,15 @@ func main() {
 	// - communicates with a serial port
 	// - understands ardupilotmega dialect
 	// - writes messages with given system id
+	// - emits events when other systems start and stop sending heartbeats
 	node, err := gomavlib.NewNode(gomavlib.NodeConf{
 		Endpoints: []gomavlib.EndpointConf{
-			gomavlib.EndpointSerial{"/dev/ttyUSB0:57600"},
+			gomavlib.EndpointSerial{Address: "/dev/ttyUSB0:57600"},
 		},
-		Dialect:     ardupilotmega.Dialect,
-		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
-		OutSystemID: 10,
+		Dialect:            ardupilotmega.Dialect,
+		OutVersion:         gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID:        10,
+		SystemEventsEnable: true,
 	})
 	if err != nil {
 		panic(err)
@@ -39,6 +41,12 @@ func main() {
 
 		case *gomavlib.EventChannelClose:
 			fmt.Printf("channel closed: %v\n", ee)
+
+		case *gomavlib.EventSystemOnline:
+			fmt.Printf("system online: %v\n", ee)
+
+		case *gomavlib.EventSystemOffline:
+			fmt.Printf("system offline: %v\n", ee)
 		}
 	}
 }
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+	"github.com/aler9/gomavlib/pkg/httpbridge"
+)
+
+func main() {
+	// create a node which
+	// - communicates with a serial endpoint
+	// - understands ardupilotmega dialect
+	// - writes messages with given system id
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointSerial{Address: "/dev/ttyUSB0:57600"},
+		},
+		Dialect:     ardupilotmega.Dialect,
+		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID: 10,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer node.Close()
+
+	// create a bridge which
+	// - exposes the state of the node and accepts messages and commands
+	//   in JSON format at http://localhost:8080
+	// - streams received messages at http://localhost:8080/stream
+	bridge, err := httpbridge.New(httpbridge.Conf{
+		Node:    node,
+		Dialect: ardupilotmega.Dialect,
+		Address: ":8080",
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer bridge.Close()
+
+	for range node.Events() {
+	}
+}
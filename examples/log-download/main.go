@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func main() {
+	// create a node which
+	// - communicates with a serial endpoint
+	// - understands common dialect
+	// - writes messages with given system id
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointSerial{Address: "/dev/ttyUSB0:57600"},
+		},
+		Dialect:     common.Dialect,
+		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID: 10,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer node.Close()
+
+	// wait for the first heartbeat of a vehicle, then download its logs
+	for evt := range node.Events() {
+		if frm, ok := evt.(*gomavlib.EventFrame); ok {
+			if _, ok := frm.Message().(*common.MessageHeartbeat); ok {
+				// events must be read while logs are being downloaded
+				go func() {
+					transfer := &gomavlib.LogTransfer{
+						Channel:         frm.Channel,
+						TargetSystem:    frm.SystemID(),
+						TargetComponent: frm.ComponentID(),
+					}
+
+					entries, err := node.ListLogs(context.Background(), transfer)
+					if err != nil {
+						fmt.Printf("error: %v\n", err)
+						return
+					}
+
+					for _, entry := range entries {
+						fmt.Printf("downloading log %d (%d bytes)\n", entry.ID, entry.Size)
+
+						f, err := os.Create(fmt.Sprintf("log%d.bin", entry.ID))
+						if err != nil {
+							fmt.Printf("error: %v\n", err)
+							return
+						}
+
+						err = node.DownloadLog(context.Background(), transfer, entry, 0, f)
+						f.Close()
+						if err != nil {
+							fmt.Printf("error: %v\n", err)
+							return
+						}
+					}
+				}()
+				break
+			}
+		}
+	}
+
+	for range node.Events() {
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+)
+
+func main() {
+	// create a node which
+	// - communicates with a serial endpoint
+	// - understands ardupilotmega dialect
+	// - writes messages with given system id
+	// - exposes metrics in the Prometheus format at http://localhost:9090/metrics
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointSerial{Address: "/dev/ttyUSB0:57600"},
+		},
+		Dialect:        ardupilotmega.Dialect,
+		OutVersion:     gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID:    10,
+		MetricsEnable:  true,
+		MetricsAddress: ":9090",
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer node.Close()
+
+	for range node.Events() {
+	}
+}
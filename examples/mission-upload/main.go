@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func main() {
+	// create a node which
+	// - communicates with a serial endpoint
+	// - understands common dialect
+	// - writes messages with given system id
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointSerial{Address: "/dev/ttyUSB0:57600"},
+		},
+		Dialect:     common.Dialect,
+		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID: 10,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer node.Close()
+
+	// wait for the first heartbeat of a vehicle, then upload a mission
+	for evt := range node.Events() {
+		if frm, ok := evt.(*gomavlib.EventFrame); ok {
+			if _, ok := frm.Message().(*common.MessageHeartbeat); ok {
+				// events must be read while the mission is being uploaded
+				go func() {
+					transfer := &gomavlib.MissionTransfer{
+						Channel:         frm.Channel,
+						TargetSystem:    frm.SystemID(),
+						TargetComponent: frm.ComponentID(),
+					}
+
+					err := node.UploadMission(context.Background(), transfer, []*gomavlib.MissionItem{
+						{
+							Frame:        int(common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT),
+							Command:      int(common.MAV_CMD_NAV_TAKEOFF),
+							Autocontinue: true,
+							Z:            10,
+						},
+						{
+							Frame:        int(common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT),
+							Command:      int(common.MAV_CMD_NAV_WAYPOINT),
+							Autocontinue: true,
+							X:            454642100,
+							Y:            91900000,
+							Z:            20,
+						},
+					})
+					if err != nil {
+						fmt.Printf("error: %v\n", err)
+						return
+					}
+
+					items, err := node.DownloadMission(context.Background(), transfer)
+					if err != nil {
+						fmt.Printf("error: %v\n", err)
+						return
+					}
+
+					for i, item := range items {
+						fmt.Printf("item %d: %+v\n", i, item)
+					}
+				}()
+				break
+			}
+		}
+	}
+
+	for range node.Events() {
+	}
+}
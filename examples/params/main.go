@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func main() {
+	// create a node which
+	// - communicates with a serial endpoint
+	// - understands common dialect
+	// - writes messages with given system id
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointSerial{Address: "/dev/ttyUSB0:57600"},
+		},
+		Dialect:     common.Dialect,
+		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID: 10,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer node.Close()
+
+	// wait for the first heartbeat of a vehicle, then read its parameters.
+	// The parameter encoding is detected automatically from the heartbeat.
+	for evt := range node.Events() {
+		if frm, ok := evt.(*gomavlib.EventFrame); ok {
+			if _, ok := frm.Message().(*common.MessageHeartbeat); ok {
+				// events must be read while parameters are being read
+				go func() {
+					params, err := node.ReadParams(context.Background(), &gomavlib.ParamTransfer{
+						Channel:         frm.Channel,
+						TargetSystem:    frm.SystemID(),
+						TargetComponent: frm.ComponentID(),
+					})
+					if err != nil {
+						fmt.Printf("error: %v\n", err)
+						return
+					}
+
+					for _, p := range params {
+						fmt.Printf("%s = %v\n", p.ID, p.Value)
+					}
+				}()
+				break
+			}
+		}
+	}
+
+	for range node.Events() {
+	}
+}
@@ -4,21 +4,28 @@ import (
 	"fmt"
 
 	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
 )
 
 func main() {
 	// create a node which
 	// - communicates with multiple endpoints
-	// - is dialect agnostic, does not attempt to decode messages (in a router it is preferable)
+	// - understands ardupilotmega dialect, in order to route messages
+	//   with target_system and target_component fields to their targets only
 	// - writes messages with given system id
+	// - forwards frames between channels automatically
+	// - decodes messages only when they are read, in order to save CPU
 	node, err := gomavlib.NewNode(gomavlib.NodeConf{
 		Endpoints: []gomavlib.EndpointConf{
-			gomavlib.EndpointSerial{"/dev/ttyUSB0:57600"},
-			gomavlib.EndpointUDPClient{"1.2.3.4:5900"},
+			gomavlib.EndpointSerial{Address: "/dev/ttyUSB0:57600"},
+			gomavlib.EndpointUDPClient{Address: "1.2.3.4:5900"},
 		},
-		Dialect:     nil,
-		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
-		OutSystemID: 10,
+		Dialect:      ardupilotmega.Dialect,
+		OutVersion:   gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID:  10,
+		RouterEnable: true,
+		LazyDecoding: true,
 	})
 	if err != nil {
 		panic(err)
@@ -28,10 +35,7 @@ func main() {
 	// print every message we receive
 	for evt := range node.Events() {
 		if frm, ok := evt.(*gomavlib.EventFrame); ok {
-			fmt.Printf("received: id=%d, %+v\n", frm.Message().GetID(), frm.Message())
-
-			// route frame to every other channel
-			node.WriteFrameExcept(frm.Channel, frm.Frame)
+			fmt.Printf("received: %s\n", dialect.Format(frm.Message(), ardupilotmega.Metadata))
 		}
 	}
 }
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+)
+
+func main() {
+	// create a node which
+	// - communicates with a serial endpoint
+	// - understands ardupilotmega dialect
+	// - writes messages with given system id
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointSerial{Address: "/dev/ttyUSB0:57600"},
+		},
+		Dialect:     ardupilotmega.Dialect,
+		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID: 10,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer node.Close()
+
+	// print statistics periodically
+	go func() {
+		for {
+			time.Sleep(5 * time.Second)
+			s := node.Stats()
+			fmt.Printf("bytes in=%d out=%d, frames in=%d out=%d, parse errors=%d, checksum errors=%d, dropped writes=%d\n",
+				s.BytesIn, s.BytesOut, s.FramesIn, s.FramesOut, s.ParseErrors, s.ChecksumErrors, s.DroppedWrites)
+		}
+	}()
+
+	for range node.Events() {
+	}
+}
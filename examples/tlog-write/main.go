@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/ardupilotmega"
+	"github.com/aler9/gomavlib/pkg/tlog"
+)
+
+func main() {
+	// create a node which
+	// - communicates with a serial port
+	// - understands ardupilotmega dialect
+	// - writes messages with given system id
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Endpoints: []gomavlib.EndpointConf{
+			gomavlib.EndpointSerial{Address: "/dev/ttyUSB0:57600"},
+		},
+		Dialect:     ardupilotmega.Dialect,
+		OutVersion:  gomavlib.V2, // change to V1 if you're unable to communicate with the target
+		OutSystemID: 10,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer node.Close()
+
+	// create a telemetry log, that can be opened with QGroundControl
+	// or Mission Planner
+	f, err := os.Create("flight.tlog")
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	w, err := tlog.NewWriter(f, ardupilotmega.Dialect)
+	if err != nil {
+		panic(err)
+	}
+
+	// record every frame we receive
+	for evt := range node.Events() {
+		if frm, ok := evt.(*gomavlib.EventFrame); ok {
+			err := w.WriteFrame(time.Now(), frm.Frame)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+}
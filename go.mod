@@ -6,8 +6,9 @@ require (
 	bou.ke/monkey v1.0.2
 	github.com/alecthomas/template v0.0.0-20160405071501-a0175ee3bccc // indirect
 	github.com/alecthomas/units v0.0.0-20151022065526-2efee857e7cf // indirect
+	github.com/gorilla/websocket v1.4.2
 	github.com/stretchr/testify v1.3.0
 	github.com/tarm/serial v0.0.0-20180830185346-98f6abe2eb07
-	golang.org/x/sys v0.0.0-20190310054646-10058d7d4faa // indirect
+	golang.org/x/sys v0.0.0-20190310054646-10058d7d4faa
 	gopkg.in/alecthomas/kingpin.v2 v2.2.6
 )
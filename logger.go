@@ -0,0 +1,90 @@
+package gomavlib
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+)
+
+// LogLevel is the level of a log entry.
+type LogLevel int
+
+// log levels.
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+// String implements fmt.Stringer.
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+
+	case LogLevelInfo:
+		return "INFO"
+
+	case LogLevelWarn:
+		return "WARN"
+
+	case LogLevelError:
+		return "ERROR"
+	}
+	return "UNKNOWN"
+}
+
+// Logger receives log entries about the internal activity of a node, like
+// opened and closed channels, failed connections, parse errors, rejected
+// signatures and discarded frames.
+// Entries are made of a level, a message and a list of alternating keys and
+// values, that allows to adapt the interface to structured logging libraries.
+// Log is called by multiple routines in parallel.
+type Logger interface {
+	Log(level LogLevel, msg string, keysAndValues ...interface{})
+}
+
+// stdLogger is a Logger that writes to a log.Logger.
+type stdLogger struct {
+	l        *log.Logger
+	minLevel LogLevel
+}
+
+// NewStdLogger returns a Logger that writes entries with a level equal or
+// greater than minLevel to a log.Logger, in the format
+// "LEVEL message key1=value1 key2=value2".
+func NewStdLogger(l *log.Logger, minLevel LogLevel) Logger {
+	return &stdLogger{
+		l:        l,
+		minLevel: minLevel,
+	}
+}
+
+func (sl *stdLogger) Log(level LogLevel, msg string, keysAndValues ...interface{}) {
+	if level < sl.minLevel {
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(level.String())
+	buf.WriteByte(' ')
+	buf.WriteString(msg)
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 < len(keysAndValues) {
+			fmt.Fprintf(&buf, " %v=%v", keysAndValues[i], keysAndValues[i+1])
+		} else {
+			fmt.Fprintf(&buf, " %v", keysAndValues[i])
+		}
+	}
+
+	sl.l.Print(buf.String())
+}
+
+// log sends an entry to the logger, if it is set.
+func (n *Node) log(level LogLevel, msg string, keysAndValues ...interface{}) {
+	if n.conf.Logger != nil {
+		n.conf.Logger.Log(level, msg, keysAndValues...)
+	}
+}
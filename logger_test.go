@@ -0,0 +1,95 @@
+package gomavlib
+
+import (
+	"bytes"
+	"log"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type testLogEntry struct {
+	level         LogLevel
+	msg           string
+	keysAndValues []interface{}
+}
+
+type testLogger struct {
+	mutex   sync.Mutex
+	entries []testLogEntry
+}
+
+func (l *testLogger) Log(level LogLevel, msg string, keysAndValues ...interface{}) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.entries = append(l.entries, testLogEntry{level, msg, keysAndValues})
+}
+
+func (l *testLogger) find(msg string) *testLogEntry {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for _, e := range l.entries {
+		if e.msg == msg {
+			return &e
+		}
+	}
+	return nil
+}
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewStdLogger(log.New(&buf, "", 0), LogLevelInfo)
+
+	l.Log(LogLevelDebug, "hidden")
+	l.Log(LogLevelWarn, "write failed", "channel", "tcp:1.2.3.4:5600", "error", "broken pipe", "odd")
+
+	require.Equal(t, "WARN write failed channel=tcp:1.2.3.4:5600 error=broken pipe odd\n", buf.String())
+}
+
+func TestNodeLogger(t *testing.T) {
+	c1, c2 := net.Pipe()
+	l := &testLogger{}
+
+	node, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		Logger:           l,
+	})
+	require.NoError(t, err)
+
+	go func() {
+		for range node.Events() {
+		}
+	}()
+
+	// invalid frame with a wrong checksum
+	_, err = c2.Write([]byte{0xFD, 0x01, 0x00, 0x00, 0x00, 0x0A, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00})
+	require.NoError(t, err)
+
+	for i := 0; i < 50 && l.find("parse error") == nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	node.Close()
+	c2.Close()
+
+	e := l.find("channel opened")
+	require.NotNil(t, e)
+	require.Equal(t, LogLevelInfo, e.level)
+	require.Equal(t, "channel", e.keysAndValues[0])
+
+	e = l.find("parse error")
+	require.NotNil(t, e)
+	require.Equal(t, LogLevelDebug, e.level)
+
+	require.NotNil(t, l.find("channel closed"))
+}
@@ -0,0 +1,27 @@
+package gomavlib
+
+import (
+	"fmt"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+var errorFrameDiscardedMiddleware = fmt.Errorf("frame discarded by a middleware")
+
+// FrameMiddleware is a function that can observe, modify or drop frames.
+// It receives the channel the frame was received from or is written to,
+// and the frame, and returns the frame to pass to the next middleware,
+// or nil to drop the frame.
+// Middlewares are called by multiple routines in parallel.
+type FrameMiddleware func(ch *Channel, fr frame.Frame) frame.Frame
+
+// runMiddlewares passes a frame through a chain of middlewares.
+func runMiddlewares(chain []FrameMiddleware, ch *Channel, fr frame.Frame) frame.Frame {
+	for _, mw := range chain {
+		fr = mw(ch, fr)
+		if fr == nil {
+			return nil
+		}
+	}
+	return fr
+}
@@ -0,0 +1,119 @@
+package gomavlib
+
+import (
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestNodeMiddlewares(t *testing.T) {
+	c1, c2 := net.Pipe()
+	c3, c4 := net.Pipe()
+
+	var outCount uint64
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		OutMiddlewares: []FrameMiddleware{
+			func(ch *Channel, fr frame.Frame) frame.Frame {
+				atomic.AddUint64(&outCount, 1)
+				return fr
+			},
+			func(ch *Channel, fr frame.Frame) frame.Frame {
+				if fr.GetMessage().(*MessageHeartbeat).Type == 9 {
+					return nil
+				}
+				fr.(*frame.V2Frame).ComponentID = 5
+				return fr
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	// the router forwards frames to node2 and changes their message
+	router, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}, EndpointCustom{c3}},
+		HeartbeatDisable: true,
+		RouterEnable:     true,
+		InMiddlewares: []FrameMiddleware{
+			func(ch *Channel, fr frame.Frame) frame.Frame {
+				if fr.GetMessage().(*MessageHeartbeat).Type == 8 {
+					return nil
+				}
+				return fr
+			},
+		},
+		OutMiddlewares: []FrameMiddleware{
+			func(ch *Channel, fr frame.Frame) frame.Frame {
+				if m := fr.GetMessage().(*MessageHeartbeat); m.Type == 1 {
+					fr.(*frame.V2Frame).Message = &MessageHeartbeat{Type: 2}
+				}
+				return fr
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer router.Close()
+
+	go func() {
+		for range router.Events() {
+		}
+	}()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      12,
+		Endpoints:        []EndpointConf{EndpointCustom{c4}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	node1.WriteMessageAll(&MessageHeartbeat{Type: 9}) // dropped by node1
+	node1.WriteMessageAll(&MessageHeartbeat{Type: 8}) // dropped by the router
+	node1.WriteMessageAll(&MessageHeartbeat{Type: 1})
+
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, byte(10), fr.SystemID())
+			require.Equal(t, byte(5), fr.ComponentID())
+			require.Equal(t, &MessageHeartbeat{Type: 2}, fr.Message())
+			break
+		}
+	}
+
+	require.Equal(t, uint64(3), atomic.LoadUint64(&outCount))
+	require.Equal(t, uint64(0), node1.Stats().DroppedWrites)
+	require.Equal(t, uint64(1), router.Stats().FilteredFrames)
+}
+
+func TestNodeMiddlewaresErrors(t *testing.T) {
+	_, err := NewNode(NodeConf{
+		OutVersion:  V2,
+		OutSystemID: 10,
+		OutMiddlewares: []FrameMiddleware{
+			func(ch *Channel, fr frame.Frame) frame.Frame { return fr },
+		},
+	})
+	require.EqualError(t, err, "OutMiddlewares requires a dialect")
+}
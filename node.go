@@ -21,7 +21,7 @@ Basic example (more are available at https://github.com/aler9/gomavlib/tree/mast
   func main() {
   	node, err := gomavlib.NewNode(gomavlib.NodeConf{
 		Endpoints: []gomavlib.EndpointConf{
-			gomavlib.EndpointSerial{"/dev/ttyUSB0:57600"},
+			gomavlib.EndpointSerial{Address: "/dev/ttyUSB0:57600"},
 		},
   		Dialect:     ardupilotmega.Dialect,
 		OutVersion:  gomavlib.V2,
@@ -43,6 +43,7 @@ Basic example (more are available at https://github.com/aler9/gomavlib/tree/mast
 package gomavlib
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -50,6 +51,7 @@ import (
 	"github.com/aler9/gomavlib/pkg/dialect"
 	"github.com/aler9/gomavlib/pkg/frame"
 	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/transceiver"
 )
 
 const (
@@ -70,19 +72,59 @@ type writeExceptReq struct {
 	what   interface{}
 }
 
+type endpointRemoveReq struct {
+	e   Endpoint
+	res chan error
+}
+
 // NodeConf allows to configure a Node.
 type NodeConf struct {
 	// the endpoints with which this node will
-	// communicate. Each endpoint contains zero or more channels
+	// communicate. Each endpoint contains zero or more channels.
+	// Endpoints can also be added and removed later with AddEndpoint()
+	// and RemoveEndpoint(), therefore a node can be created without
+	// endpoints.
 	Endpoints []EndpointConf
 
 	// (optional) the dialect which contains the messages that will be encoded and decoded.
 	// If not provided, messages are decoded in the MessageRaw struct.
 	Dialect *dialect.Dialect
+	// (optional) do not decode messages of incoming frames in advance, but
+	// only when EventFrame.Message() is called. Frames that are only routed
+	// are forwarded without being decoded. Frames whose message cannot be
+	// decoded do not produce parse errors, and their message is returned in
+	// the MessageRaw struct.
+	LazyDecoding bool
+	// (optional) take EventFrame objects, and the buffers of their payloads,
+	// from a pool, in order to avoid heap allocations per frame.
+	// Every EventFrame must be released with EventFrame.Release() when it is
+	// not used anymore. Payload buffers are pooled only with LazyDecoding.
+	// Frames passed to WriteFrame*() are copied, therefore events can be
+	// released right after forwarding their frames.
+	PooledEvents bool
+	// (optional) discards frames whose message is not in the dialect.
+	// By default, these frames are emitted and routed, and their message
+	// is returned in the MessageRaw struct, that contains the message ID
+	// and the encoded payload. This feature requires a dialect.
+	UnknownMessagesDisable bool
 
 	// (optional) the secret key used to validate incoming frames.
 	// Non signed frames are discarded, as well as frames with a version < 2.0.
 	InKey *frame.V2Key
+	// (optional) additional secret keys used to validate incoming frames.
+	// Frames are accepted if their signature is valid with any of InKey and
+	// InKeys, and this allows to rotate keys. The key that validated a frame
+	// is available in EventFrame.
+	InKeys []*frame.V2Key
+	// (optional) a function that decides whether incoming frames are
+	// accepted, in place of the default policy, that requires frames to be
+	// signed with any of InKey and InKeys (or the keys of the endpoint),
+	// with a timestamp not older than 10 seconds with respect to the previous
+	// frame. It allows to enforce custom replay windows, keys that depend on
+	// the link or to accept non signed frames from trusted channels.
+	// It is called for every frame and returns an error in order to discard it.
+	// The function is called by multiple routines in parallel.
+	SignaturePolicy func(*SignatureCheck) error
 
 	// Mavlink version used to encode messages. See Version
 	// for the available options.
@@ -95,6 +137,22 @@ type NodeConf struct {
 	// (optional) the secret key used to sign outgoing frames.
 	// This feature requires a version >= 2.0.
 	OutKey *frame.V2Key
+	// (optional) additional components of the node, that share its channels
+	// and system id, but have their own component id and heartbeats.
+	// They can be retrieved with Component().
+	Components []ComponentConf
+	// (optional) a store where the timestamp of signed outgoing frames is
+	// persisted, in order not to emit, after a restart, timestamps older than
+	// the ones emitted before. SignatureTimestampFile can be used.
+	// This feature requires OutKey.
+	OutSignatureTimestampStore SignatureTimestampStore
+	// (optional) a function that is called when a SETUP_SIGNING message
+	// directed to the node is received, and returns whether to accept it.
+	// Accepted requests replace the keys of the node, as with SetKeys(), and
+	// raise the timestamp of signed outgoing frames to the initial timestamp.
+	// Since keys are sent in clear, requests should be accepted only from
+	// secure links. This feature requires a version >= 2.0 and a dialect.
+	SetupSigningAccept func(*SetupSigningRequest) bool
 
 	// (optional) disables the periodic sending of heartbeats to open channels.
 	HeartbeatDisable bool
@@ -106,32 +164,215 @@ type NodeConf struct {
 	// (optional) the autopilot type advertised by heartbeats.
 	// It defaults to MAV_AUTOPILOT_GENERIC
 	HeartbeatAutopilotType int
+	// (optional) a function that is called before sending every heartbeat
+	// and returns its content. It allows to advertise a dynamic state and
+	// overrides HeartbeatSystemType and HeartbeatAutopilotType.
+	HeartbeatCallback func() HeartbeatContent
 
 	// (optional) automatically request streams to detected Ardupilot devices,
 	// that need an explicit request in order to emit telemetry stream.
 	StreamRequestEnable bool
 	// (optional) the requested stream frequency in Hz. It defaults to 4.
 	StreamRequestFrequency int
+	// (optional) the streams to request. It defaults to the streams
+	// requested by QGroundControl, with frequency StreamRequestFrequency.
+	StreamRequests []StreamRequest
+	// (optional) the period after which streams are requested again.
+	// Streams are also requested again when a device reconnects, that is
+	// when it does not send heartbeats for SystemTimeout.
+	// It defaults to 30 seconds.
+	StreamRequestPeriod time.Duration
+
+	// (optional) aggregates the telemetry of the system of the node (that is,
+	// of frames whose system id is equal to OutSystemID) into HIGH_LATENCY2
+	// messages, that are written periodically to channels of endpoints
+	// wrapped with EndpointHighLatency. Received HIGH_LATENCY2 messages can be
+	// converted with HighLatencyStateFromMessage().
+	HighLatencyEnable bool
+	// (optional) the period between HIGH_LATENCY2 messages.
+	// It defaults to 5 seconds.
+	HighLatencyPeriod time.Duration
+
+	// (optional) enables the tracking of ADS-B traffic: received ADSB_VEHICLE
+	// messages are deduplicated by ICAO address and can be read with
+	// ADSBTraffic(). This feature requires a dialect.
+	ADSBEnable bool
+	// (optional) the time after which an aircraft that is not reported
+	// anymore is removed from the traffic. It defaults to 30 seconds.
+	ADSBTimeout time.Duration
+	// (optional) a source of ADS-B reports, that are added to the traffic
+	// and written to all channels as ADSB_VEHICLE messages.
+	// It requires ADSBEnable.
+	ADSBSource ADSBSource
+
+	// (optional) enables the TIMESYNC protocol, that allows to estimate the
+	// clock offset between the node and other systems: TIMESYNC requests are
+	// sent periodically to open channels and TIMESYNC requests of other
+	// systems are answered.
+	TimesyncEnable bool
+	// (optional) the period between TIMESYNC requests. It defaults to 1 second.
+	TimesyncPeriod time.Duration
+
+	// (optional) enables metrics in the Prometheus text format, that can be
+	// exposed with MetricsHandler().
+	MetricsEnable bool
+	// (optional) the address of a HTTP server that serves metrics,
+	// i.e. ":9090". It requires MetricsEnable.
+	MetricsAddress string
+
+	// (optional) enables the router mode, in which received frames are
+	// forwarded to other channels. A routing table is built by associating
+	// the system and component IDs of received frames with their channels.
+	// Frames with target_system and target_component fields are forwarded
+	// only to the channels where the target has been seen, while the others
+	// are forwarded to all channels except the source one. Target fields
+	// are read only if messages are decoded, therefore a dialect is required
+	// in order to take them into account.
+	RouterEnable bool
+	// (optional) converts frames written with WriteFrame*() or routed
+	// by the router into the version used by the channel they are written to,
+	// that can be set with EndpointVersion. Frames that cannot be represented
+	// with V1 are dropped. This feature requires a dialect.
+	TranslateVersion bool
+	// (optional) a function that is called before a frame passed to
+	// WriteFrame*() or routed by the router is written to a channel, and that
+	// allows to rewrite it (for instance, to change its system ID or
+	// component ID). The function receives a copy of the frame, whose message
+	// is shared and must be replaced instead of modified, and returns the
+	// frame to write, or nil to discard it. Checksums are computed again,
+	// frames are signed again with OutKey or, if OutKey is not set, their
+	// signature is removed. The function is called by multiple routines in
+	// parallel. This feature requires a dialect.
+	RewriteFrame func(ch *Channel, fr frame.Frame) frame.Frame
+	// (optional) middlewares that are called, in order, on every frame
+	// received by a channel and accepted by its filters, before events are
+	// emitted and frames are routed. They can observe, modify or drop frames.
+	// Dropped frames are counted in the FilteredFrames statistic.
+	// Checksums and signatures of modified frames are not computed again,
+	// therefore frames that are modified and routed must be processed by
+	// OutMiddlewares or RewriteFrame too.
+	InMiddlewares []FrameMiddleware
+	// (optional) middlewares that are called, in order, on every frame
+	// written to a channel, including the ones built from messages, after
+	// RewriteFrame. They can observe, modify or drop frames. Middlewares
+	// receive a copy of the frame, whose message is shared and must be
+	// replaced instead of modified. Checksums are computed again, frames are
+	// signed again with OutKey or, if OutKey is not set, their signature is
+	// removed. This feature requires a dialect.
+	OutMiddlewares []FrameMiddleware
+	// (optional) a function that returns the priority of an outgoing message,
+	// that is used when frames are queued (see EndpointQueue and
+	// EndpointRateLimit). It defaults to DefaultPriority.
+	// The function is called by multiple routines in parallel.
+	WritePriority func(m msg.Message) Priority
+
+	// (optional) emits EventSystemOnline and EventSystemOffline when other
+	// systems and components start and stop sending heartbeats.
+	SystemEventsEnable bool
+	// (optional) the period after which a system that stopped sending
+	// heartbeats is considered offline. It defaults to 10 seconds.
+	SystemTimeout time.Duration
+	// (optional) emits EventPacketLoss when frames of other systems are lost,
+	// as detected through gaps in sequence numbers. Lost frames are counted
+	// in the LostFrames statistic anyway.
+	PacketLossEventsEnable bool
+	// (optional) the period after which the channel of a failover group
+	// (see EndpointFailover) that stopped receiving heartbeats of the system
+	// is considered down. It defaults to 3 seconds.
+	FailoverTimeout time.Duration
+	// (optional) the maximum round-trip time of the channels of a failover
+	// group, as measured by Ping() and TIMESYNC. Channels with a greater
+	// round-trip time are considered down. It defaults to 0 (no limit).
+	FailoverMaxLatency time.Duration
+
+	// (optional) the size of the event queue. It defaults to 0 (unbuffered)
+	// with EventQueueBlock and to 256 with the other overflow policies.
+	EventQueueSize int
+	// (optional) the behavior of the node when the event queue is full.
+	// It defaults to EventQueueBlock, in which reading routines are stalled
+	// until events are read by the user. With the other policies, events are
+	// discarded and counted in Stats.DroppedEvents.
+	EventQueueOverflow EventQueuePolicy
+
+	// (optional) callbacks that are invoked by the node in place of emitting
+	// events on the Events() channel. Events that have a callback are not
+	// emitted on the channel anymore; events without a callback are passed to
+	// OnEvent or, if OnEvent is nil, are emitted on the channel, that must be
+	// read. Callbacks are invoked by the routines of the node until Close()
+	// returns: callbacks related to a channel are invoked sequentially by
+	// the routine that reads the channel, while other callbacks can be
+	// invoked concurrently. Callbacks can call write functions, but must not
+	// block and must not call Close().
+	OnFrame func(*EventFrame)
+	// (optional) the callback invoked when a channel is opened.
+	OnChannelOpen func(*EventChannelOpen)
+	// (optional) the callback invoked when a channel is closed.
+	OnChannelClose func(*EventChannelClose)
+	// (optional) the callback invoked when a parse error occurs.
+	OnParseError func(*EventParseError)
+	// (optional) the callback invoked for events that don't have a
+	// dedicated callback.
+	OnEvent func(Event)
+
+	// (optional) a logger that receives entries about the internal activity
+	// of the node (opened and closed channels, failed connections, parse
+	// errors, rejected signatures, discarded frames and events).
+	// NewStdLogger can be used.
+	Logger Logger
+	// (optional) a tracer that creates spans about the reception, routing and
+	// writing of frames. See Tracer.
+	Tracer Tracer
+	// (optional) the clock used by the periodic activities of the node.
+	// It defaults to the system clock.
+	Clock Clock
 }
 
 // Node is a high-level Mavlink encoder and decoder that works with endpoints.
 type Node struct {
-	conf               NodeConf
-	dialectDE          *dialect.DecEncoder
-	channelAccepters   map[*channelAccepter]struct{}
-	channelAcceptersWg sync.WaitGroup
-	channels           map[*Channel]struct{}
-	channelsWg         sync.WaitGroup
-	nodeHeartbeat      *nodeHeartbeat
-	nodeStreamRequest  *nodeStreamRequest
+	conf                   NodeConf
+	dialectDE              *dialect.DecEncoder
+	channelAccepters       map[*channelAccepter]struct{}
+	channelAcceptersWg     sync.WaitGroup
+	channels               map[*Channel]struct{}
+	channelsWg             sync.WaitGroup
+	stats                  *statsCounters
+	endpointStatsMutex     sync.Mutex
+	endpointStats          map[Endpoint]*statsCounters
+	nodeHeartbeat          *nodeHeartbeat
+	nodeStreamRequest      *nodeStreamRequest
+	nodeTimesync           *nodeTimesync
+	nodeHighLatency        *nodeHighLatency
+	nodeADSB               *nodeADSB
+	components             []*Component
+	nodeCommand            *nodeCommand
+	nodeMessageInterval    *nodeMessageInterval
+	nodeMission            *nodeMission
+	nodeParam              *nodeParam
+	nodeLog                *nodeLog
+	nodeRTCM               *nodeRTCM
+	nodeCamera             *nodeCamera
+	nodeGimbal             *nodeGimbal
+	nodePing               *nodePing
+	nodeSetupSigning       *nodeSetupSigning
+	nodeMetrics            *nodeMetrics
+	nodeSignatureTimestamp *nodeSignatureTimestamp
+	nodeRouter             *nodeRouter
+	nodeSystemEvents       *nodeSystemEvents
+	nodeSystems            *nodeSystems
+	nodeFailover           *nodeFailover
+	frameSubscribers       frameSubscribers
+	keys                   *transceiver.Keys
 
 	// in
-	channelNew   chan *Channel
-	channelClose chan *Channel
-	writeTo      chan writeToReq
-	writeAll     chan interface{}
-	writeExcept  chan writeExceptReq
-	terminate    chan struct{}
+	endpointAdd    chan interface{}
+	endpointRemove chan endpointRemoveReq
+	channelNew     chan *Channel
+	channelClose   chan *Channel
+	writeTo        chan writeToReq
+	writeAll       chan interface{}
+	writeExcept    chan writeExceptReq
+	ctx            context.Context
+	ctxCancel      func()
 
 	// out
 	events chan Event
@@ -140,9 +381,12 @@ type Node struct {
 
 // NewNode allocates a Node. See NodeConf for the options.
 func NewNode(conf NodeConf) (*Node, error) {
-	if len(conf.Endpoints) == 0 {
-		return nil, fmt.Errorf("at least one endpoint must be provided")
-	}
+	return NewNodeContext(context.Background(), conf)
+}
+
+// NewNodeContext allocates a Node that is closed when the given context
+// is canceled. See NodeConf for the options.
+func NewNodeContext(ctx context.Context, conf NodeConf) (*Node, error) {
 	if conf.HeartbeatPeriod == 0 {
 		conf.HeartbeatPeriod = 5 * time.Second
 	}
@@ -152,9 +396,36 @@ func NewNode(conf NodeConf) (*Node, error) {
 	if conf.HeartbeatAutopilotType == 0 {
 		conf.HeartbeatAutopilotType = 0 // MAV_AUTOPILOT_GENERIC
 	}
+	if conf.EventQueueSize == 0 && conf.EventQueueOverflow != EventQueueBlock {
+		conf.EventQueueSize = 256
+	}
 	if conf.StreamRequestFrequency == 0 {
 		conf.StreamRequestFrequency = 4
 	}
+	if conf.StreamRequests == nil {
+		conf.StreamRequests = streamRequestsDefault
+	}
+	if conf.StreamRequestPeriod == 0 {
+		conf.StreamRequestPeriod = 30 * time.Second
+	}
+	if conf.HighLatencyPeriod == 0 {
+		conf.HighLatencyPeriod = 5 * time.Second
+	}
+	if conf.ADSBTimeout == 0 {
+		conf.ADSBTimeout = 30 * time.Second
+	}
+	if conf.TimesyncPeriod == 0 {
+		conf.TimesyncPeriod = 1 * time.Second
+	}
+	if conf.SystemTimeout == 0 {
+		conf.SystemTimeout = 10 * time.Second
+	}
+	if conf.FailoverTimeout == 0 {
+		conf.FailoverTimeout = 3 * time.Second
+	}
+	if conf.Clock == nil {
+		conf.Clock = systemClock{}
+	}
 
 	// check Transceiver configuration here, since Transceiver is created dynamically
 	if conf.OutVersion == 0 {
@@ -169,6 +440,47 @@ func NewNode(conf NodeConf) (*Node, error) {
 	if conf.OutKey != nil && conf.OutVersion != V2 {
 		return nil, fmt.Errorf("OutKey requires V2 frames")
 	}
+	if conf.SetupSigningAccept != nil && conf.OutVersion != V2 {
+		return nil, fmt.Errorf("SetupSigningAccept requires V2 frames")
+	}
+	if conf.SetupSigningAccept != nil && conf.Dialect == nil {
+		return nil, fmt.Errorf("SetupSigningAccept requires a dialect")
+	}
+	if conf.UnknownMessagesDisable && conf.Dialect == nil {
+		return nil, fmt.Errorf("UnknownMessagesDisable requires a dialect")
+	}
+	if len(conf.OutMiddlewares) != 0 && conf.Dialect == nil {
+		return nil, fmt.Errorf("OutMiddlewares requires a dialect")
+	}
+	if conf.RewriteFrame != nil && conf.Dialect == nil {
+		return nil, fmt.Errorf("RewriteFrame requires a dialect")
+	}
+	if conf.TranslateVersion && conf.Dialect == nil {
+		return nil, fmt.Errorf("TranslateVersion requires a dialect")
+	}
+	if conf.ADSBEnable && conf.Dialect == nil {
+		return nil, fmt.Errorf("ADSBEnable requires a dialect")
+	}
+	if conf.ADSBSource != nil && !conf.ADSBEnable {
+		return nil, fmt.Errorf("ADSBSource requires ADSBEnable")
+	}
+	conf.Components = append([]ComponentConf(nil), conf.Components...)
+	for i, cconf := range conf.Components {
+		if cconf.ComponentID < 1 {
+			return nil, fmt.Errorf("ComponentID must be >= 1")
+		}
+		if cconf.ComponentID == conf.OutComponentID {
+			return nil, fmt.Errorf("component %d is already used by the node", cconf.ComponentID)
+		}
+		for _, cconf2 := range conf.Components[:i] {
+			if cconf2.ComponentID == cconf.ComponentID {
+				return nil, fmt.Errorf("component %d is duplicated", cconf.ComponentID)
+			}
+		}
+		if cconf.HeartbeatSystemType == 0 {
+			conf.Components[i].HeartbeatSystemType = 6 // MAV_TYPE_GCS
+		}
+	}
 
 	dialectDE, err := func() (*dialect.DecEncoder, error) {
 		if conf.Dialect == nil {
@@ -180,22 +492,40 @@ func NewNode(conf NodeConf) (*Node, error) {
 		return nil, err
 	}
 
+	ctx, ctxCancel := context.WithCancel(ctx)
+
 	n := &Node{
 		conf:             conf,
 		dialectDE:        dialectDE,
 		channelAccepters: make(map[*channelAccepter]struct{}),
 		channels:         make(map[*Channel]struct{}),
+		stats:            &statsCounters{},
+		endpointStats:    make(map[Endpoint]*statsCounters),
+		endpointAdd:      make(chan interface{}),
+		endpointRemove:   make(chan endpointRemoveReq),
 		channelNew:       make(chan *Channel),
 		channelClose:     make(chan *Channel),
 		writeTo:          make(chan writeToReq),
 		writeAll:         make(chan interface{}),
 		writeExcept:      make(chan writeExceptReq),
-		terminate:        make(chan struct{}),
-		events:           make(chan Event),
+		ctx:              ctx,
+		ctxCancel:        ctxCancel,
+		events:           make(chan Event, conf.EventQueueSize),
 		done:             make(chan struct{}),
+		keys:             transceiver.NewKeys(mergeKeys(conf.InKey, conf.InKeys), conf.OutKey),
+	}
+
+	n.nodeSignatureTimestamp, err = newNodeSignatureTimestamp(n)
+	if err != nil {
+		ctxCancel()
+		return nil, err
 	}
 
+	// channels use this module to compute signature timestamps
+	n.nodeSetupSigning = newNodeSetupSigning(n)
+
 	closeExisting := func() {
+		ctxCancel()
 		for ch := range n.channels {
 			ch.close()
 		}
@@ -206,38 +536,49 @@ func NewNode(conf NodeConf) (*Node, error) {
 
 	// endpoints
 	for _, tconf := range conf.Endpoints {
-		tp, err := tconf.init()
+		_, item, err := n.initEndpoint(tconf)
 		if err != nil {
 			closeExisting()
 			return nil, err
 		}
 
-		switch ttp := tp.(type) {
-		case endpointChannelAccepter:
-			ca, err := newChannelAccepter(n, ttp)
-			if err != nil {
-				closeExisting()
-				return nil, err
-			}
-
-			n.channelAccepters[ca] = struct{}{}
-
-		case endpointChannelSingle:
-			ch, err := newChannel(n, ttp, ttp.Label(), ttp)
-			if err != nil {
-				closeExisting()
-				return nil, err
-			}
-
-			n.channels[ch] = struct{}{}
+		switch titem := item.(type) {
+		case *channelAccepter:
+			n.channelAccepters[titem] = struct{}{}
 
-		default:
-			panic(fmt.Errorf("endpoint %T does not implement any interface", tp))
+		case *Channel:
+			n.channels[titem] = struct{}{}
 		}
 	}
 
+	for _, cconf := range conf.Components {
+		n.components = append(n.components, newComponent(n, cconf))
+	}
+
 	n.nodeHeartbeat = newNodeHeartbeat(n)
 	n.nodeStreamRequest = newNodeStreamRequest(n)
+	n.nodeTimesync = newNodeTimesync(n)
+	n.nodeHighLatency = newNodeHighLatency(n)
+	n.nodeADSB = newNodeADSB(n)
+	n.nodeCommand = newNodeCommand(n)
+	n.nodeMessageInterval = newNodeMessageInterval(n)
+	n.nodeMission = newNodeMission(n)
+	n.nodeParam = newNodeParam(n)
+	n.nodeLog = newNodeLog(n)
+	n.nodeRTCM = newNodeRTCM(n)
+	n.nodeCamera = newNodeCamera(n)
+	n.nodeGimbal = newNodeGimbal(n)
+	n.nodePing = newNodePing(n)
+	n.nodeRouter = newNodeRouter(n)
+	n.nodeSystemEvents = newNodeSystemEvents(n)
+	n.nodeSystems = newNodeSystems(n)
+	n.nodeFailover = newNodeFailover(n)
+
+	n.nodeMetrics, err = newNodeMetrics(n)
+	if err != nil {
+		closeExisting()
+		return nil, err
+	}
 
 	if n.nodeHeartbeat != nil {
 		go n.nodeHeartbeat.run()
@@ -247,6 +588,28 @@ func NewNode(conf NodeConf) (*Node, error) {
 		go n.nodeStreamRequest.run()
 	}
 
+	if n.nodeTimesync != nil {
+		go n.nodeTimesync.run()
+	}
+
+	if n.nodeHighLatency != nil {
+		go n.nodeHighLatency.run()
+	}
+
+	if n.nodeADSB != nil {
+		go n.nodeADSB.run()
+	}
+
+	if n.nodeSystemEvents != nil {
+		go n.nodeSystemEvents.run()
+	}
+
+	go n.nodeFailover.run()
+
+	if n.nodeSignatureTimestamp != nil {
+		go n.nodeSignatureTimestamp.run()
+	}
+
 	for ch := range n.channels {
 		ch.start()
 	}
@@ -260,50 +623,191 @@ func NewNode(conf NodeConf) (*Node, error) {
 	return n, nil
 }
 
+// initEndpoint initializes an endpoint and allocates the associated
+// channelAccepter or Channel, without starting them.
+func (n *Node) initEndpoint(tconf EndpointConf) (Endpoint, interface{}, error) {
+	opts, err := n.endpointOptions(tconf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tp, err := tconf.init()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stats := &statsCounters{}
+
+	switch ttp := tp.(type) {
+	case endpointChannelAccepter:
+		ca, err := newChannelAccepter(n, ttp, stats, opts)
+		if err != nil {
+			ttp.Close()
+			return nil, nil, err
+		}
+		n.setEndpointStats(tp, stats)
+		return tp, ca, nil
+
+	case endpointChannelSingle:
+		ch, err := newChannel(n, ttp, ttp.Label(), ttp, stats, opts)
+		if err != nil {
+			ttp.Close()
+			return nil, nil, err
+		}
+		n.setEndpointStats(tp, stats)
+		return tp, ch, nil
+	}
+
+	panic(fmt.Errorf("endpoint %T does not implement any interface", tp))
+}
+
+func (n *Node) setEndpointStats(e Endpoint, stats *statsCounters) {
+	n.endpointStatsMutex.Lock()
+	defer n.endpointStatsMutex.Unlock()
+
+	if stats == nil {
+		delete(n.endpointStats, e)
+	} else {
+		n.endpointStats[e] = stats
+	}
+}
+
+func (n *Node) hasEndpoint(e Endpoint) bool {
+	for ca := range n.channelAccepters {
+		if ca.eca == e {
+			return true
+		}
+	}
+	for ch := range n.channels {
+		if ch.e == e {
+			return true
+		}
+	}
+	return false
+}
+
 func (n *Node) run() {
 	defer close(n.done)
 
 outer:
 	for {
 		select {
+		case item := <-n.endpointAdd:
+			switch titem := item.(type) {
+			case *channelAccepter:
+				n.channelAccepters[titem] = struct{}{}
+				titem.start()
+
+			case *Channel:
+				n.channels[titem] = struct{}{}
+				titem.start()
+			}
+
+		case req := <-n.endpointRemove:
+			if !n.hasEndpoint(req.e) {
+				req.res <- fmt.Errorf("endpoint not found")
+				continue
+			}
+
+			for ca := range n.channelAccepters {
+				if ca.eca == req.e {
+					delete(n.channelAccepters, ca)
+					ca.close()
+				}
+			}
+
+			for ch := range n.channels {
+				if ch.e == req.e {
+					delete(n.channels, ch)
+					ch.close()
+				}
+			}
+
+			n.setEndpointStats(req.e, nil)
+			req.res <- nil
+
 		case ch := <-n.channelNew:
+			// endpoint has been removed in the meanwhile
+			if !n.hasEndpoint(ch.e) {
+				ch.close()
+				continue
+			}
+
 			n.channels[ch] = struct{}{}
 			ch.start()
 
 		case ch := <-n.channelClose:
+			// channel has already been removed
+			if _, ok := n.channels[ch]; !ok {
+				continue
+			}
+
 			delete(n.channels, ch)
 			ch.close()
 
 		case req := <-n.writeTo:
-			if _, ok := n.channels[req.ch]; !ok {
-				return
+			if _, ok := n.channels[req.ch]; ok {
+				dispatchWrite(req.ch, req.what)
 			}
-			req.ch.write <- req.what
+			sealWrite(req.what)
 
 		case what := <-n.writeAll:
+			// high latency messages are written to high latency channels only
+			if hl, ok := what.(highLatencyMessage); ok {
+				for ch := range n.channels {
+					if ch.highLatency {
+						ch.write <- hl.m
+					}
+				}
+				continue
+			}
+
 			for ch := range n.channels {
-				ch.write <- what
+				if !ch.highLatency && !n.nodeFailover.isStandby(ch) {
+					dispatchWrite(ch, what)
+				}
 			}
+			sealWrite(what)
 
 		case req := <-n.writeExcept:
 			for ch := range n.channels {
-				if ch != req.except {
-					ch.write <- req.what
+				if ch != req.except && !ch.highLatency && !n.nodeFailover.isStandby(ch) {
+					dispatchWrite(ch, req.what)
 				}
 			}
+			sealWrite(req.what)
 
-		case <-n.terminate:
+		case <-n.ctx.Done():
 			break outer
 		}
 	}
 
+	// events are not read anymore by the user when the node is closed
+	go func() {
+		for range n.events {
+		}
+	}()
+
 	go func() {
 		for {
 			select {
-			case _, ok := <-n.channelNew:
+			case item := <-n.endpointAdd:
+				switch titem := item.(type) {
+				case *channelAccepter:
+					titem.close()
+
+				case *Channel:
+					titem.close()
+				}
+
+			case req := <-n.endpointRemove:
+				req.res <- fmt.Errorf("terminated")
+
+			case ch, ok := <-n.channelNew:
 				if !ok {
 					return
 				}
+				ch.close()
 
 			case <-n.channelClose:
 			case <-n.writeTo:
@@ -321,6 +825,28 @@ outer:
 		n.nodeStreamRequest.close()
 	}
 
+	if n.nodeTimesync != nil {
+		n.nodeTimesync.close()
+	}
+
+	if n.nodeHighLatency != nil {
+		n.nodeHighLatency.close()
+	}
+
+	if n.nodeADSB != nil {
+		n.nodeADSB.close()
+	}
+
+	if n.nodeMetrics != nil {
+		n.nodeMetrics.close()
+	}
+
+	if n.nodeSystemEvents != nil {
+		n.nodeSystemEvents.close()
+	}
+
+	n.nodeFailover.close()
+
 	for ca := range n.channelAccepters {
 		ca.close()
 	}
@@ -330,19 +856,64 @@ outer:
 		ch.close()
 	}
 	n.channelsWg.Wait()
+
+	if n.nodeMessageInterval != nil {
+		n.nodeMessageInterval.close()
+	}
+
+	for _, c := range n.components {
+		c.close()
+	}
+
+	// save the timestamp after channels are closed, since they use it
+	if n.nodeSignatureTimestamp != nil {
+		n.nodeSignatureTimestamp.close()
+	}
+
+	close(n.events)
 }
 
 // Close halts node operations and waits for all routines to return.
 func (n *Node) Close() {
-	go func() {
-		for range n.events {
+	n.ctxCancel()
+	<-n.done
+}
+
+// emitEvent passes an event to its callback or, if the callback is not
+// set, emits it on the event channel.
+func (n *Node) emitEvent(evt Event) {
+	switch tevt := evt.(type) {
+	case *EventFrame:
+		if n.conf.OnFrame != nil {
+			n.conf.OnFrame(tevt)
+			return
 		}
-	}()
 
-	close(n.terminate)
-	<-n.done
+	case *EventChannelOpen:
+		if n.conf.OnChannelOpen != nil {
+			n.conf.OnChannelOpen(tevt)
+			return
+		}
 
-	close(n.events)
+	case *EventChannelClose:
+		if n.conf.OnChannelClose != nil {
+			n.conf.OnChannelClose(tevt)
+			return
+		}
+
+	case *EventParseError:
+		if n.conf.OnParseError != nil {
+			n.conf.OnParseError(tevt)
+			return
+		}
+	}
+
+	if n.conf.OnEvent != nil {
+		n.conf.OnEvent(evt)
+		return
+	}
+
+	n.pushEvent(evt)
 }
 
 // Events returns a channel from which receiving events. Possible events are:
@@ -351,43 +922,150 @@ func (n *Node) Close() {
 //   *EventFrame
 //   *EventParseError
 //   *EventStreamRequested
+//   *EventSystemOnline
+//   *EventSystemOffline
+//   *EventPacketLoss
+//   *EventReconnect
+//   *EventBaudRateDetected
+//   *EventFailover
+// The channel is closed when the node is closed.
 // See individual events for meaning and content.
 func (n *Node) Events() chan Event {
 	return n.events
 }
 
+// AddEndpoint adds an endpoint to a running node.
+// It returns the Endpoint, that can be used to remove it with RemoveEndpoint().
+func (n *Node) AddEndpoint(conf EndpointConf) (Endpoint, error) {
+	if n.ctx.Err() != nil {
+		return nil, errorTerminated
+	}
+
+	e, item, err := n.initEndpoint(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case n.endpointAdd <- item:
+	case <-n.ctx.Done():
+		switch titem := item.(type) {
+		case *channelAccepter:
+			titem.close()
+
+		case *Channel:
+			titem.close()
+		}
+		return nil, errorTerminated
+	}
+	return e, nil
+}
+
+// RemoveEndpoint closes an endpoint and all its channels.
+// The Endpoint can be obtained from AddEndpoint() or from Channel.Endpoint().
+func (n *Node) RemoveEndpoint(e Endpoint) error {
+	res := make(chan error)
+	select {
+	case n.endpointRemove <- endpointRemoveReq{e, res}:
+	case <-n.ctx.Done():
+		return errorTerminated
+	}
+	return <-res
+}
+
+// Stats returns the statistics of the node, that are the sum of
+// the statistics of all the channels that have been opened.
+func (n *Node) Stats() Stats {
+	return n.stats.get()
+}
+
+// EndpointStats returns the statistics of an endpoint, that are the sum of
+// the statistics of all the channels that have been opened by the endpoint.
+// The Endpoint can be obtained from AddEndpoint() or from Channel.Endpoint().
+func (n *Node) EndpointStats(e Endpoint) (Stats, bool) {
+	n.endpointStatsMutex.Lock()
+	defer n.endpointStatsMutex.Unlock()
+
+	stats, ok := n.endpointStats[e]
+	if !ok {
+		return Stats{}, false
+	}
+	return stats.get(), true
+}
+
+func (n *Node) writeToWhat(channel *Channel, what interface{}) {
+	select {
+	case n.writeTo <- writeToReq{channel, what}:
+	case <-n.ctx.Done():
+	}
+}
+
+func (n *Node) writeExceptWhat(exceptChannel *Channel, what interface{}) {
+	select {
+	case n.writeExcept <- writeExceptReq{exceptChannel, what}:
+	case <-n.ctx.Done():
+	}
+}
+
 // WriteMessageTo writes a message to given channel.
 func (n *Node) WriteMessageTo(channel *Channel, m msg.Message) {
-	n.writeTo <- writeToReq{channel, m}
+	n.writeToWhat(channel, m)
 }
 
 // WriteMessageAll writes a message to all channels.
 func (n *Node) WriteMessageAll(m msg.Message) {
-	n.writeAll <- m
+	select {
+	case n.writeAll <- m:
+	case <-n.ctx.Done():
+	}
 }
 
 // WriteMessageExcept writes a message to all channels except specified channel.
 func (n *Node) WriteMessageExcept(exceptChannel *Channel, m msg.Message) {
-	n.writeExcept <- writeExceptReq{exceptChannel, m}
+	n.writeExceptWhat(exceptChannel, m)
+}
+
+// writeMessageToOrAll writes a message to given channel or, if the channel
+// is nil, to all channels.
+func (n *Node) writeMessageToOrAll(channel *Channel, m msg.Message) {
+	if channel != nil {
+		n.WriteMessageTo(channel, m)
+	} else {
+		n.WriteMessageAll(m)
+	}
+}
+
+// ownFrame returns a frame that can still be written after the release of
+// the event that contains it, since frames are written asynchronously.
+func (n *Node) ownFrame(fr frame.Frame) frame.Frame {
+	if !n.conf.PooledEvents {
+		return fr
+	}
+	return copyPayload(fr)
 }
 
 // WriteFrameTo writes a frame to given channel.
 // This function is intended only for routing pre-existing frames to other nodes,
 // since all frame fields must be filled manually.
 func (n *Node) WriteFrameTo(channel *Channel, fr frame.Frame) {
-	n.writeTo <- writeToReq{channel, fr}
+	n.writeToWhat(channel, n.ownFrame(fr))
 }
 
 // WriteFrameAll writes a frame to all channels.
 // This function is intended only for routing pre-existing frames to other nodes,
 // since all frame fields must be filled manually.
 func (n *Node) WriteFrameAll(fr frame.Frame) {
-	n.writeAll <- fr
+	fr = n.ownFrame(fr)
+
+	select {
+	case n.writeAll <- fr:
+	case <-n.ctx.Done():
+	}
 }
 
 // WriteFrameExcept writes a frame to all channels except specified channel.
 // This function is intended only for routing pre-existing frames to other nodes,
 // since all frame fields must be filled manually.
 func (n *Node) WriteFrameExcept(exceptChannel *Channel, fr frame.Frame) {
-	n.writeExcept <- writeExceptReq{exceptChannel, fr}
+	n.writeExceptWhat(exceptChannel, n.ownFrame(fr))
 }
@@ -2,8 +2,20 @@ package gomavlib
 
 import (
 	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sync"
 	"testing"
@@ -14,6 +26,7 @@ import (
 	"github.com/aler9/gomavlib/pkg/dialect"
 	"github.com/aler9/gomavlib/pkg/frame"
 	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/transceiver"
 )
 
 type (
@@ -181,11 +194,327 @@ func doTest(t *testing.T, t1 EndpointConf, t2 EndpointConf) {
 }
 
 func TestNodeTcpServerClient(t *testing.T) {
-	doTest(t, EndpointTCPServer{"127.0.0.1:5601"}, EndpointTCPClient{"127.0.0.1:5601"})
+	doTest(t, EndpointTCPServer{Address: "127.0.0.1:5601"}, EndpointTCPClient{Address: "127.0.0.1:5601"})
+}
+
+type testDialer struct {
+	mutex sync.Mutex
+	addrs []string
+}
+
+func (d *testDialer) Dial(network, address string) (net.Conn, error) {
+	d.mutex.Lock()
+	d.addrs = append(d.addrs, address)
+	d.mutex.Unlock()
+	return net.Dial(network, address)
+}
+
+func TestNodeTcpClientDialer(t *testing.T) {
+	d := &testDialer{}
+	doTest(t, EndpointTCPServer{Address: "127.0.0.1:5601"},
+		EndpointTCPClient{Address: "127.0.0.1:5601", Dialer: d})
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	require.Equal(t, "127.0.0.1:5601", d.addrs[0])
+}
+
+func TestNodeTcpClientInvalidProxy(t *testing.T) {
+	_, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointTCPClient{Address: "127.0.0.1:5601", Proxy: "ftp://1.2.3.4:21"},
+		},
+		HeartbeatDisable: true,
+	})
+	require.EqualError(t, err, "invalid proxy URL: unsupported proxy scheme 'ftp'")
+}
+
+func generateTestCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}
+}
+
+func TestNodeTLSServerClient(t *testing.T) {
+	cert := generateTestCertificate(t)
+	pool := x509.NewCertPool()
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	pool.AddCert(leaf)
+
+	doTest(t,
+		EndpointTLSServer{"127.0.0.1:5601", &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    pool,
+		}},
+		EndpointTLSClient{
+			Address: "127.0.0.1:5601",
+			Config: &tls.Config{
+				Certificates: []tls.Certificate{cert},
+				RootCAs:      pool,
+			},
+		})
+
+	d := &testDialer{}
+	doTest(t,
+		EndpointTLSServer{"127.0.0.1:5601", &tls.Config{
+			Certificates: []tls.Certificate{cert},
+		}},
+		EndpointTLSClient{
+			Address: "127.0.0.1:5601",
+			Config: &tls.Config{
+				RootCAs: pool,
+			},
+			Dialer: d,
+		})
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	require.Equal(t, "127.0.0.1:5601", d.addrs[0])
+}
+
+func TestNodeWebsocketServerClient(t *testing.T) {
+	doTest(t, EndpointWebsocketServer{"127.0.0.1:5601", "/mavlink", nil},
+		EndpointWebsocketClient{"ws://127.0.0.1:5601/mavlink", nil})
+}
+
+func TestNodeUnixServerClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomavlib")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	doTest(t, EndpointUnixServer{filepath.Join(dir, "server.sock"), false},
+		EndpointUnixClient{filepath.Join(dir, "server.sock"), false, ""})
+}
+
+func TestNodeUnixgramServerClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomavlib")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	doTest(t, EndpointUnixServer{filepath.Join(dir, "server.sock"), true},
+		EndpointUnixClient{filepath.Join(dir, "server.sock"), true, filepath.Join(dir, "client.sock")})
+}
+
+func TestNodeTLSServerNoCertificate(t *testing.T) {
+	_, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointTLSServer{"127.0.0.1:5600", nil},
+		},
+		HeartbeatDisable: true,
+	})
+	require.Error(t, err)
+}
+
+func TestNodeTcpServerLimits(t *testing.T) {
+	node, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointTCPServer{
+				Address:         "127.0.0.1:5600",
+				MaxClients:      1,
+				IdleTimeout:     500 * time.Millisecond,
+				AllowedNetworks: []string{"127.0.0.0/8"},
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	conn1, err := net.Dial("tcp4", "127.0.0.1:5600")
+	require.NoError(t, err)
+	defer conn1.Close()
+
+	_, ok := (<-node.Events()).(*EventChannelOpen)
+	require.Equal(t, true, ok)
+
+	// the second client exceeds MaxClients and is disconnected
+	conn2, err := net.Dial("tcp4", "127.0.0.1:5600")
+	require.NoError(t, err)
+	defer conn2.Close()
+
+	conn2.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, err = conn2.Read(make([]byte, 10))
+	require.Equal(t, io.EOF, err)
+
+	// the first client is disconnected after IdleTimeout
+	_, ok = (<-node.Events()).(*EventChannelClose)
+	require.Equal(t, true, ok)
+
+	// the slot is released
+	conn3, err := net.Dial("tcp4", "127.0.0.1:5600")
+	require.NoError(t, err)
+	defer conn3.Close()
+
+	_, ok = (<-node.Events()).(*EventChannelOpen)
+	require.Equal(t, true, ok)
+}
+
+func TestNodeTcpServerAllowedNetworks(t *testing.T) {
+	node, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointTCPServer{
+				Address:         "127.0.0.1:5600",
+				AllowedNetworks: []string{"10.0.0.0/8"},
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	conn, err := net.Dial("tcp4", "127.0.0.1:5600")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, err = conn.Read(make([]byte, 10))
+	require.Equal(t, io.EOF, err)
+
+	_, err = NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointTCPServer{
+				Address:         "127.0.0.1:5601",
+				AllowedNetworks: []string{"10.0.0.0"},
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.EqualError(t, err, "invalid network '10.0.0.0'")
 }
 
 func TestNodeUdpServerClient(t *testing.T) {
-	doTest(t, EndpointUDPServer{"127.0.0.1:5601"}, EndpointUDPClient{"127.0.0.1:5601"})
+	doTest(t, EndpointUDPServer{Address: "127.0.0.1:5601"}, EndpointUDPClient{Address: "127.0.0.1:5601"})
+}
+
+func TestNodeUdpClientLocalAddress(t *testing.T) {
+	doTest(t, EndpointUDPServer{Address: "127.0.0.1:5601"},
+		EndpointUDPClient{Address: "127.0.0.1:5601", LocalAddress: "127.0.0.1:5602"})
+
+	pc, err := net.ListenPacket("udp4", "127.0.0.1:5601")
+	require.NoError(t, err)
+	defer pc.Close()
+
+	node, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointUDPClient{Address: "127.0.0.1:5601", LocalAddress: "127.0.0.1:5602"},
+		},
+		HeartbeatPeriod: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	go func() {
+		for range node.Events() {
+		}
+	}()
+
+	pc.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, addr, err := pc.ReadFrom(make([]byte, 1024))
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:5602", addr.String())
+
+	_, err = NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointUDPClient{Address: "127.0.0.1:5601", LocalAddress: "127.0.0.1"},
+		},
+		HeartbeatDisable: true,
+	})
+	require.EqualError(t, err, "invalid local address")
+}
+
+func TestNodeUdpServerIdleTimeout(t *testing.T) {
+	server, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointUDPServer{
+				Address:     "127.0.0.1:5600",
+				IdleTimeout: 300 * time.Millisecond,
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	dialectDE, err := dialect.NewDecEncoder(&dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}) //nolint:govet
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	tr, err := transceiver.New(transceiver.Conf{
+		Reader:      &buf,
+		Writer:      &buf,
+		DialectDE:   dialectDE,
+		OutVersion:  transceiver.V2,
+		OutSystemID: 11,
+	})
+	require.NoError(t, err)
+
+	err = tr.WriteMessage(&MessageHeartbeat{Type: 1})
+	require.NoError(t, err)
+
+	conn, err := net.Dial("udp4", "127.0.0.1:5600")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	for i := 0; i < 2; i++ {
+		_, err = conn.Write(buf.Bytes())
+		require.NoError(t, err)
+
+		_, ok := (<-server.Events()).(*EventChannelOpen)
+		require.Equal(t, true, ok)
+
+		_, ok = (<-server.Events()).(*EventFrame)
+		require.Equal(t, true, ok)
+
+		// the remote address expires
+		_, ok = (<-server.Events()).(*EventChannelClose)
+		require.Equal(t, true, ok)
+	}
 }
 
 func TestNodeUdpBroadcastBroadcast(t *testing.T) {
@@ -210,7 +539,8 @@ func (ch testLoopback) Read(buf []byte) (int, error) {
 }
 
 func (ch testLoopback) Write(buf []byte) (int, error) {
-	ch <- buf
+	// buffer is reused by the writer, copy it
+	ch <- append([]byte(nil), buf...)
 	return len(buf), nil
 }
 
@@ -232,8 +562,8 @@ func TestNodeError(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 11,
 		Endpoints: []EndpointConf{
-			EndpointUDPServer{"127.0.0.1:5600"},
-			EndpointUDPServer{"127.0.0.1:5600"},
+			EndpointUDPServer{Address: "127.0.0.1:5600"},
+			EndpointUDPServer{Address: "127.0.0.1:5600"},
 		},
 		HeartbeatDisable: true,
 	})
@@ -246,7 +576,7 @@ func TestNodeCloseInLoop(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 11,
 		Endpoints: []EndpointConf{
-			EndpointUDPServer{"127.0.0.1:5600"},
+			EndpointUDPServer{Address: "127.0.0.1:5600"},
 		},
 		HeartbeatDisable: true,
 	})
@@ -257,7 +587,7 @@ func TestNodeCloseInLoop(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 11,
 		Endpoints: []EndpointConf{
-			EndpointUDPClient{"127.0.0.1:5600"},
+			EndpointUDPClient{Address: "127.0.0.1:5600"},
 		},
 		HeartbeatDisable: true,
 	})
@@ -292,116 +622,781 @@ func TestNodeWriteMultipleInLoop(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 11,
 		Endpoints: []EndpointConf{
-			EndpointUDPServer{"127.0.0.1:5600"},
+			EndpointUDPServer{Address: "127.0.0.1:5600"},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointUDPClient{Address: "127.0.0.1:5600"},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	go func() {
+		defer node2.Close()
+
+		// wait connection to server
+		time.Sleep(500 * time.Millisecond)
+
+		node2.WriteMessageAll(&MessageHeartbeat{
+			Type:           1,
+			Autopilot:      2,
+			BaseMode:       3,
+			CustomMode:     6,
+			SystemStatus:   4,
+			MavlinkVersion: 5,
+		})
+	}()
+
+	for evt := range node1.Events() {
+		if _, ok := evt.(*EventChannelOpen); ok {
+			for i := 0; i < 100; i++ {
+				node1.WriteMessageAll(&MessageHeartbeat{
+					Type:           1,
+					Autopilot:      2,
+					BaseMode:       3,
+					CustomMode:     6,
+					SystemStatus:   4,
+					MavlinkVersion: 5,
+				})
+			}
+			node1.Close()
+		}
+	}
+}
+
+func TestNodeContext(t *testing.T) {
+	c1, c2 := net.Pipe()
+	defer c2.Close()
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+
+	node, err := NewNodeContext(ctx, NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	evt := <-node.Events()
+	require.IsType(t, &EventChannelOpen{}, evt)
+
+	ctxCancel()
+
+	for range node.Events() {
+	}
+
+	// writes and endpoint operations must not block after termination
+	node.WriteMessageAll(&MessageHeartbeat{})
+
+	_, err = node.AddEndpoint(EndpointUDPServer{Address: "127.0.0.1:5600"})
+	require.Equal(t, errorTerminated, err)
+
+	node.Close()
+}
+
+func TestNodeCallbacks(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	frames := make(chan *EventFrame, 1)
+	opens := make(chan *EventChannelOpen, 1)
+	closes := make(chan *EventChannelClose, 1)
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		OnFrame: func(evt *EventFrame) {
+			frames <- evt
+		},
+		OnChannelOpen: func(evt *EventChannelOpen) {
+			opens <- evt
+		},
+		OnChannelClose: func(evt *EventChannelClose) {
+			closes <- evt
+		},
+		OnEvent: func(evt Event) {
+			t.Errorf("unexpected event: %v", evt)
 		},
+	})
+	require.NoError(t, err)
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
 		HeartbeatDisable: true,
 	})
 	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	open := <-opens
+
+	testMsg := &MessageHeartbeat{
+		Type:           1,
+		Autopilot:      2,
+		BaseMode:       3,
+		CustomMode:     6,
+		SystemStatus:   4,
+		MavlinkVersion: 5,
+	}
+	node2.WriteMessageAll(testMsg)
+
+	fr := <-frames
+	require.Equal(t, open.Channel, fr.Channel)
+	require.Equal(t, testMsg, fr.Message())
+
+	node1.Close()
+
+	require.Equal(t, &EventChannelClose{open.Channel}, <-closes)
+
+	// events with a callback are not emitted on the channel
+	_, ok := <-node1.Events()
+	require.False(t, ok)
+}
+
+func TestNodeEventQueue(t *testing.T) {
+	for _, ca := range []EventQueuePolicy{
+		EventQueueDropOldest,
+		EventQueueDropNewest,
+	} {
+		t.Run(ca.String(), func(t *testing.T) {
+			c1, c2 := net.Pipe()
+
+			node1, err := NewNode(NodeConf{
+				Dialect:            &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+				OutVersion:         V2,
+				OutSystemID:        10,
+				Endpoints:          []EndpointConf{EndpointCustom{c1}},
+				HeartbeatDisable:   true,
+				EventQueueSize:     1,
+				EventQueueOverflow: ca,
+			})
+			require.NoError(t, err)
+			defer node1.Close()
+
+			node2, err := NewNode(NodeConf{
+				Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+				OutVersion:       V2,
+				OutSystemID:      11,
+				Endpoints:        []EndpointConf{EndpointCustom{c2}},
+				HeartbeatDisable: true,
+			})
+			require.NoError(t, err)
+			defer node2.Close()
+
+			go func() {
+				for range node2.Events() {
+				}
+			}()
+
+			for i := 0; i < 3; i++ {
+				node2.WriteMessageAll(&MessageHeartbeat{
+					Type: MAV_TYPE(i),
+				})
+			}
+
+			for node1.Stats().FramesIn != 3 || node1.Stats().DroppedEvents != 3 {
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			evt := <-node1.Events()
+
+			if ca == EventQueueDropOldest {
+				require.IsType(t, &EventFrame{}, evt)
+				require.Equal(t, &MessageHeartbeat{Type: 2}, evt.(*EventFrame).Message())
+			} else {
+				require.IsType(t, &EventChannelOpen{}, evt)
+			}
+		})
+	}
+}
+
+func TestNodeLazyDecoding(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		LazyDecoding:     true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	testMsg := &MessageHeartbeat{
+		Type:           1,
+		Autopilot:      2,
+		BaseMode:       3,
+		CustomMode:     6,
+		SystemStatus:   4,
+		MavlinkVersion: 5,
+	}
+	node2.WriteMessageAll(testMsg)
+
+	for evt := range node1.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.IsType(t, &msg.MessageRaw{}, fr.Frame.GetMessage())
+			require.Equal(t, testMsg, fr.Message())
+			break
+		}
+	}
+}
+
+func TestNodePooledEvents(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		LazyDecoding:     true,
+		PooledEvents:     true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	go func() {
+		for i := 0; i < 20; i++ {
+			node2.WriteMessageAll(&MessageHeartbeat{Type: MAV_TYPE(i)})
+		}
+	}()
+
+	count := 0
+	for evt := range node1.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, &MessageHeartbeat{Type: MAV_TYPE(count)}, fr.Message())
+			require.Equal(t, byte(11), fr.SystemID())
+			fr.Release()
+			count++
+			if count == 20 {
+				break
+			}
+		}
+	}
+
+	// events that are not pooled are not affected
+	evt := &EventFrame{Frame: &frame.V2Frame{Message: &MessageHeartbeat{}}}
+	evt.Release()
+	require.NotEqual(t, nil, evt.Frame)
+}
+
+func TestNodePooledEventsForward(t *testing.T) {
+	c1, c2 := net.Pipe()
+	c3, c4 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}, EndpointCustom{c3}},
+		HeartbeatDisable: true,
+		LazyDecoding:     true,
+		PooledEvents:     true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	node3, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      12,
+		Endpoints:        []EndpointConf{EndpointCustom{c4}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node3.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	// frames are forwarded and released immediately, while their payload
+	// buffers are reused by the following frames
+	go func() {
+		for evt := range node1.Events() {
+			if fr, ok := evt.(*EventFrame); ok {
+				node1.WriteFrameExcept(fr.Channel, fr.Frame)
+				fr.Release()
+			}
+		}
+	}()
+
+	go func() {
+		for i := 0; i < 200; i++ {
+			node2.WriteMessageAll(&MessageHeartbeat{Type: MAV_TYPE(i % 256), CustomMode: uint32(i)})
+		}
+	}()
+
+	count := 0
+	for evt := range node3.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, &MessageHeartbeat{Type: MAV_TYPE(count % 256), CustomMode: uint32(count)}, fr.Message())
+			require.Equal(t, byte(11), fr.SystemID())
+			count++
+			if count == 200 {
+				break
+			}
+		}
+	}
+}
+
+func TestNodeUnknownMessages(t *testing.T) {
+	for _, ca := range []string{"emit", "disable"} {
+		t.Run(ca, func(t *testing.T) {
+			c1, c2 := net.Pipe()
+
+			node1, err := NewNode(NodeConf{
+				Dialect:                &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+				OutVersion:             V2,
+				OutSystemID:            10,
+				Endpoints:              []EndpointConf{EndpointCustom{c1}},
+				HeartbeatDisable:       true,
+				UnknownMessagesDisable: ca == "disable",
+			})
+			require.NoError(t, err)
+			defer node1.Close()
+
+			node2, err := NewNode(NodeConf{
+				Dialect: &dialect.Dialect{3, []msg.Message{ //nolint:govet
+					&MessageHeartbeat{},
+					&MessageRequestDataStream{},
+				}},
+				OutVersion:       V2,
+				OutSystemID:      11,
+				Endpoints:        []EndpointConf{EndpointCustom{c2}},
+				HeartbeatDisable: true,
+			})
+			require.NoError(t, err)
+			defer node2.Close()
+
+			go func() {
+				for range node2.Events() {
+				}
+			}()
+
+			node2.WriteMessageAll(&MessageRequestDataStream{ReqStreamId: 4})
+			node2.WriteMessageAll(&MessageHeartbeat{Type: 1})
+
+			parseErrorReceived := false
+
+			for evt := range node1.Events() {
+				if ee, ok := evt.(*EventParseError); ok {
+					require.Equal(t, ParseErrorUnknownMessage, ee.Reason)
+					require.Equal(t, []byte("\xfd\x05\x00\x00\x00\x0b\x01\x42\x00\x00"+
+						"\x00\x00\x00\x00\x04"), ee.Data[:15])
+					parseErrorReceived = true
+				}
+
+				if fr, ok := evt.(*EventFrame); ok {
+					if ca == "emit" {
+						require.Equal(t, &msg.MessageRaw{ //nolint:govet
+							66,
+							[]byte("\x00\x00\x00\x00\x04"),
+						}, fr.Message())
+					} else {
+						require.Equal(t, &MessageHeartbeat{Type: 1}, fr.Message())
+					}
+					break
+				}
+			}
+
+			require.Equal(t, ca == "disable", parseErrorReceived)
+		})
+	}
+}
+
+func TestNodeSignature(t *testing.T) {
+	key1 := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+	key2 := frame.NewV2Key(bytes.Repeat([]byte("\xA8"), 32))
+
+	testMsg := &MessageHeartbeat{
+		Type:           7,
+		Autopilot:      5,
+		BaseMode:       4,
+		CustomMode:     3,
+		SystemStatus:   2,
+		MavlinkVersion: 1,
+	}
+
+	node1, err := NewNode(NodeConf{
+		Dialect: &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		Endpoints: []EndpointConf{
+			EndpointUDPServer{Address: "127.0.0.1:5600"},
+		},
+		HeartbeatDisable: true,
+		InKey:            key2,
+		OutVersion:       V2,
+		OutSystemID:      10,
+		OutKey:           key1,
+	})
+	require.NoError(t, err)
+
+	node2, err := NewNode(NodeConf{
+		Dialect: &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		Endpoints: []EndpointConf{
+			EndpointUDPClient{Address: "127.0.0.1:5600"},
+		},
+		HeartbeatDisable: true,
+		InKey:            key1,
+		OutVersion:       V2,
+		OutSystemID:      11,
+		OutKey:           key2,
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		defer node1.Close()
+		<-node1.Events()
+	}()
+
+	go func() {
+		defer wg.Done()
+		defer node2.Close()
+
+		time.Sleep(500 * time.Millisecond)
+
+		node2.WriteMessageAll(testMsg)
+
+		<-node2.Events()
+	}()
+
+	wg.Wait()
+}
+
+func TestNodeSignatureKeyRing(t *testing.T) {
+	oldKey := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+	newKey := frame.NewV2Key(bytes.Repeat([]byte("\xA8"), 32))
+
+	c1, c2 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		InKey:            oldKey,
+		InKeys:           []*frame.V2Key{newKey},
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+		OutKey:           newKey,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	node2.WriteMessageAll(&MessageHeartbeat{Type: 1})
+
+	for evt := range node1.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, newKey, fr.Key)
+			break
+		}
+	}
+}
+
+func TestNodeSignatureEndpoint(t *testing.T) {
+	key := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+
+	c1, c2 := net.Pipe()
+	c3, c4 := net.Pipe()
+
+	// the node requires signed frames, except on the first endpoint
+	node1, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointSigned{EndpointConf: EndpointCustom{c1}},
+			EndpointCustom{c3},
+		},
+		HeartbeatDisable: true,
+		InKey:            key,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	for _, c := range []net.Conn{c2, c4} {
+		node2, err := NewNode(NodeConf{
+			Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+			OutVersion:       V2,
+			OutSystemID:      11,
+			Endpoints:        []EndpointConf{EndpointCustom{c}},
+			HeartbeatDisable: true,
+		})
+		require.NoError(t, err)
+		defer node2.Close()
+
+		go func() {
+			for range node2.Events() {
+			}
+		}()
+
+		node2.WriteMessageAll(&MessageHeartbeat{Type: 1})
+	}
+
+	frameReceived := false
+	parseErrorReceived := false
+
+	for evt := range node1.Events() {
+		switch tevt := evt.(type) {
+		case *EventFrame:
+			require.Equal(t, "custom", tevt.Channel.String())
+			require.Equal(t, c1, tevt.Channel.Endpoint().Conf().(EndpointCustom).ReadWriteCloser)
+			frameReceived = true
+
+		case *EventParseError:
+			require.Equal(t, c3, tevt.Channel.Endpoint().Conf().(EndpointCustom).ReadWriteCloser)
+			parseErrorReceived = true
+		}
+
+		if frameReceived && parseErrorReceived {
+			break
+		}
+	}
+}
+
+func TestNodeSignatureEndpointErrors(t *testing.T) {
+	_, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V1,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointSigned{
+				EndpointConf: EndpointCustom{nil},
+				OutKey:       frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32)),
+			},
+		},
+	})
+	require.EqualError(t, err, "OutKey requires V2 frames")
+}
+
+func TestNodeTranslateVersion(t *testing.T) {
+	c1, c2 := net.Pipe()
+	c3, c4 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
 
-	node2, err := NewNode(NodeConf{
+	// the router receives V2 frames and forwards them as V1 frames
+	router, err := NewNode(NodeConf{
 		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
 		OutVersion:  V2,
 		OutSystemID: 11,
 		Endpoints: []EndpointConf{
-			EndpointUDPClient{"127.0.0.1:5600"},
+			EndpointCustom{c2},
+			EndpointVersion{EndpointCustom{c3}, V1},
 		},
 		HeartbeatDisable: true,
+		RouterEnable:     true,
+		TranslateVersion: true,
 	})
 	require.NoError(t, err)
+	defer router.Close()
 
 	go func() {
-		defer node2.Close()
+		for range router.Events() {
+		}
+	}()
 
-		// wait connection to server
-		time.Sleep(500 * time.Millisecond)
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V1,
+		OutSystemID:      12,
+		Endpoints:        []EndpointConf{EndpointCustom{c4}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
 
-		node2.WriteMessageAll(&MessageHeartbeat{
-			Type:           1,
-			Autopilot:      2,
-			BaseMode:       3,
-			CustomMode:     6,
-			SystemStatus:   4,
-			MavlinkVersion: 5,
-		})
-	}()
+	node1.WriteMessageAll(&MessageHeartbeat{Type: 1})
 
-	for evt := range node1.Events() {
-		if _, ok := evt.(*EventChannelOpen); ok {
-			for i := 0; i < 100; i++ {
-				node1.WriteMessageAll(&MessageHeartbeat{
-					Type:           1,
-					Autopilot:      2,
-					BaseMode:       3,
-					CustomMode:     6,
-					SystemStatus:   4,
-					MavlinkVersion: 5,
-				})
-			}
-			node1.Close()
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.IsType(t, &frame.V1Frame{}, fr.Frame)
+			require.Equal(t, byte(10), fr.SystemID())
+			require.Equal(t, &MessageHeartbeat{Type: 1}, fr.Message())
+			break
 		}
 	}
 }
 
-func TestNodeSignature(t *testing.T) {
-	key1 := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
-	key2 := frame.NewV2Key(bytes.Repeat([]byte("\xA8"), 32))
-
-	testMsg := &MessageHeartbeat{
-		Type:           7,
-		Autopilot:      5,
-		BaseMode:       4,
-		CustomMode:     3,
-		SystemStatus:   2,
-		MavlinkVersion: 1,
-	}
+func TestNodeTranslateVersionErrors(t *testing.T) {
+	_, err := NewNode(NodeConf{
+		OutVersion:       V2,
+		OutSystemID:      10,
+		TranslateVersion: true,
+	})
+	require.EqualError(t, err, "TranslateVersion requires a dialect")
 
-	node1, err := NewNode(NodeConf{
-		Dialect: &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+	_, err = NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
 		Endpoints: []EndpointConf{
-			EndpointUDPServer{"127.0.0.1:5600"},
+			EndpointVersion{EndpointConf: EndpointCustom{nil}},
 		},
-		HeartbeatDisable: true,
-		InKey:            key2,
+	})
+	require.EqualError(t, err, "invalid endpoint version")
+}
+
+func TestNodeRewriteFrame(t *testing.T) {
+	c1, c2 := net.Pipe()
+	c3, c4 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
 		OutVersion:       V2,
 		OutSystemID:      10,
-		OutKey:           key1,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
 	})
 	require.NoError(t, err)
+	defer node1.Close()
 
-	node2, err := NewNode(NodeConf{
-		Dialect: &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	// the router changes the system ID of frames forwarded to the second channel
+	router, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 11,
 		Endpoints: []EndpointConf{
-			EndpointUDPClient{"127.0.0.1:5600"},
+			EndpointCustom{c2},
+			EndpointCustom{c3},
 		},
 		HeartbeatDisable: true,
-		InKey:            key1,
-		OutVersion:       V2,
-		OutSystemID:      11,
-		OutKey:           key2,
+		RouterEnable:     true,
+		RewriteFrame: func(ch *Channel, fr frame.Frame) frame.Frame {
+			if ch.Endpoint().Conf().(EndpointCustom).ReadWriteCloser != c3 {
+				return fr
+			}
+			fr.(*frame.V2Frame).SystemID += 10
+			return fr
+		},
 	})
 	require.NoError(t, err)
-
-	var wg sync.WaitGroup
-	wg.Add(2)
+	defer router.Close()
 
 	go func() {
-		defer wg.Done()
-		defer node1.Close()
-		<-node1.Events()
+		for range router.Events() {
+		}
 	}()
 
-	go func() {
-		defer wg.Done()
-		defer node2.Close()
-
-		time.Sleep(500 * time.Millisecond)
-
-		node2.WriteMessageAll(testMsg)
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      12,
+		Endpoints:        []EndpointConf{EndpointCustom{c4}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
 
-		<-node2.Events()
-	}()
+	node1.WriteMessageAll(&MessageHeartbeat{Type: 1})
 
-	wg.Wait()
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, byte(20), fr.SystemID())
+			require.Equal(t, &MessageHeartbeat{Type: 1}, fr.Message())
+			break
+		}
+	}
 }
 
 func TestNodeRouting(t *testing.T) {
@@ -419,7 +1414,7 @@ func TestNodeRouting(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 10,
 		Endpoints: []EndpointConf{
-			EndpointUDPClient{"127.0.0.1:5600"},
+			EndpointUDPClient{Address: "127.0.0.1:5600"},
 		},
 		HeartbeatDisable: true,
 	})
@@ -430,8 +1425,8 @@ func TestNodeRouting(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 11,
 		Endpoints: []EndpointConf{
-			EndpointUDPServer{"127.0.0.1:5600"},
-			EndpointUDPClient{"127.0.0.1:5601"},
+			EndpointUDPServer{Address: "127.0.0.1:5600"},
+			EndpointUDPClient{Address: "127.0.0.1:5601"},
 		},
 		HeartbeatDisable: true,
 	})
@@ -442,7 +1437,7 @@ func TestNodeRouting(t *testing.T) {
 		OutVersion:  V2,
 		OutSystemID: 12,
 		Endpoints: []EndpointConf{
-			EndpointUDPServer{"127.0.0.1:5601"},
+			EndpointUDPServer{Address: "127.0.0.1:5601"},
 		},
 		HeartbeatDisable: true,
 	})
@@ -499,7 +1494,7 @@ func TestNodeHeartbeat(t *testing.T) {
 			OutVersion:  V2,
 			OutSystemID: 10,
 			Endpoints: []EndpointConf{
-				EndpointUDPServer{"127.0.0.1:5600"},
+				EndpointUDPServer{Address: "127.0.0.1:5600"},
 			},
 			HeartbeatDisable: true,
 		})
@@ -511,7 +1506,7 @@ func TestNodeHeartbeat(t *testing.T) {
 			OutVersion:  V2,
 			OutSystemID: 11,
 			Endpoints: []EndpointConf{
-				EndpointUDPClient{"127.0.0.1:5600"},
+				EndpointUDPClient{Address: "127.0.0.1:5600"},
 			},
 			HeartbeatDisable: false,
 			HeartbeatPeriod:  500 * time.Millisecond,
@@ -529,6 +1524,61 @@ func TestNodeHeartbeat(t *testing.T) {
 	}()
 }
 
+func TestNodeHeartbeatCallback(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	count := 0
+	node2, err := NewNode(NodeConf{
+		Dialect:         &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:      V2,
+		OutSystemID:     11,
+		Endpoints:       []EndpointConf{EndpointCustom{c2}},
+		HeartbeatPeriod: 100 * time.Millisecond,
+		HeartbeatCallback: func() HeartbeatContent {
+			count++
+			return HeartbeatContent{
+				SystemType:    2,
+				AutopilotType: 3,
+				BaseMode:      4,
+				CustomMode:    uint32(count),
+				SystemStatus:  5,
+			}
+		},
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	for i := 1; i <= 2; {
+		evt := <-node1.Events()
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, &MessageHeartbeat{
+				Type:           2,
+				Autopilot:      3,
+				BaseMode:       4,
+				CustomMode:     uint32(i),
+				SystemStatus:   5,
+				MavlinkVersion: 3,
+			}, fr.Message())
+			i++
+		}
+	}
+}
+
 func TestNodeStreamRequest(t *testing.T) {
 	func() {
 		node1, err := NewNode(NodeConf{
@@ -539,7 +1589,7 @@ func TestNodeStreamRequest(t *testing.T) {
 			OutVersion:  V2,
 			OutSystemID: 10,
 			Endpoints: []EndpointConf{
-				EndpointUDPServer{"127.0.0.1:5600"},
+				EndpointUDPServer{Address: "127.0.0.1:5600"},
 			},
 			HeartbeatDisable:    true,
 			StreamRequestEnable: true,
@@ -555,7 +1605,7 @@ func TestNodeStreamRequest(t *testing.T) {
 			OutVersion:  V2,
 			OutSystemID: 10,
 			Endpoints: []EndpointConf{
-				EndpointUDPClient{"127.0.0.1:5600"},
+				EndpointUDPClient{Address: "127.0.0.1:5600"},
 			},
 			HeartbeatDisable:       false,
 			HeartbeatPeriod:        500 * time.Millisecond,
@@ -578,3 +1628,241 @@ func TestNodeStreamRequest(t *testing.T) {
 		}
 	}()
 }
+
+func TestNodeStreamRequestConf(t *testing.T) {
+	c1, c2 := net.Pipe()
+	clock := newTestClock()
+
+	node1, err := NewNode(NodeConf{
+		Dialect: &dialect.Dialect{3, []msg.Message{ //nolint:govet
+			&MessageHeartbeat{},
+			&MessageRequestDataStream{},
+		}},
+		OutVersion:          V2,
+		OutSystemID:         10,
+		Endpoints:           []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable:    true,
+		StreamRequestEnable: true,
+		StreamRequests: []StreamRequest{
+			{ID: 5, Frequency: 10},
+		},
+		SystemTimeout: 500 * time.Millisecond,
+		Clock:         clock,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect: &dialect.Dialect{3, []msg.Message{ //nolint:govet
+			&MessageHeartbeat{},
+			&MessageRequestDataStream{},
+		}},
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	requested := make(chan struct{}, 10)
+	go func() {
+		for evt := range node1.Events() {
+			if _, ok := evt.(*EventStreamRequested); ok {
+				requested <- struct{}{}
+			}
+		}
+	}()
+
+	hb := &MessageHeartbeat{Autopilot: 3} // MAV_AUTOPILOT_ARDUPILOTMEGA
+
+	// first heartbeat: streams are requested
+	node2.WriteMessageAll(hb)
+
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, &MessageRequestDataStream{
+				TargetSystem:    11,
+				TargetComponent: 1,
+				ReqStreamId:     5,
+				ReqMessageRate:  10,
+				StartStop:       1,
+			}, fr.Message())
+			break
+		}
+	}
+	<-requested
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	// heartbeats are received regularly: streams are not requested again
+	node2.WriteMessageAll(hb)
+	select {
+	case <-requested:
+		t.Errorf("unexpected stream request")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// heartbeats are interrupted: streams are requested again
+	clock.advance(500 * time.Millisecond)
+	node2.WriteMessageAll(hb)
+	<-requested
+}
+
+func TestNodeNoEndpoints(t *testing.T) {
+	node, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+	})
+	require.NoError(t, err)
+
+	// messages are discarded until an endpoint is added
+	node.WriteMessageAll(&MessageHeartbeat{})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range node.Events() {
+		}
+	}()
+
+	node.Close()
+	<-done
+}
+
+func TestNodeAddRemoveEndpoint(t *testing.T) {
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	e, err := node1.AddEndpoint(EndpointUDPServer{Address: "127.0.0.1:5600"})
+	require.NoError(t, err)
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{
+			EndpointUDPClient{Address: "127.0.0.1:5600"},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		node2.WriteMessageAll(&MessageHeartbeat{
+			Type:           1,
+			Autopilot:      2,
+			BaseMode:       3,
+			CustomMode:     6,
+			SystemStatus:   4,
+			MavlinkVersion: 5,
+		})
+	}()
+
+	for evt := range node1.Events() {
+		if ee, ok := evt.(*EventFrame); ok {
+			require.Equal(t, e, ee.Channel.Endpoint())
+			break
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for evt := range node1.Events() {
+			if _, ok := evt.(*EventChannelClose); ok {
+				return
+			}
+		}
+	}()
+
+	err = node1.RemoveEndpoint(e)
+	require.NoError(t, err)
+	<-done
+
+	err = node1.RemoveEndpoint(e)
+	require.Error(t, err)
+}
+
+func TestNodeStats(t *testing.T) {
+	testDialect := &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}} //nolint:govet
+
+	var buf bytes.Buffer
+	de, err := dialect.NewDecEncoder(testDialect)
+	require.NoError(t, err)
+	tr, err := transceiver.New(transceiver.Conf{
+		Reader:      &buf,
+		Writer:      &buf,
+		DialectDE:   de,
+		OutVersion:  transceiver.V2,
+		OutSystemID: 11,
+	})
+	require.NoError(t, err)
+	err = tr.WriteMessage(&MessageHeartbeat{
+		Type:           1,
+		Autopilot:      2,
+		BaseMode:       3,
+		CustomMode:     6,
+		SystemStatus:   4,
+		MavlinkVersion: 5,
+	})
+	require.NoError(t, err)
+	valid := buf.Bytes()
+	invalid := append([]byte(nil), valid...)
+	invalid[len(invalid)-1] ^= 0xFF
+
+	l1 := make(testLoopback)
+	l2 := make(testLoopback)
+
+	node, err := NewNode(NodeConf{
+		Dialect:          testDialect,
+		OutVersion:       V2,
+		OutSystemID:      10,
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	e, err := node.AddEndpoint(EndpointCustom{&testEndpoint{l1, l2}})
+	require.NoError(t, err)
+
+	go func() {
+		l1 <- valid
+		l1 <- invalid
+	}()
+
+	var ch *Channel
+	for evt := range node.Events() {
+		if ee, ok := evt.(*EventParseError); ok {
+			require.Equal(t, ParseErrorChecksum, ee.Reason)
+			require.Equal(t, invalid, ee.Data)
+			ch = ee.Channel
+			break
+		}
+	}
+
+	expected := Stats{
+		BytesIn:        uint64(len(valid) + len(invalid)),
+		FramesIn:       1,
+		ParseErrors:    1,
+		ChecksumErrors: 1,
+	}
+	require.Equal(t, expected, ch.Stats())
+	require.Equal(t, expected, node.Stats())
+
+	stats, ok := node.EndpointStats(e)
+	require.Equal(t, true, ok)
+	require.Equal(t, expected, stats)
+}
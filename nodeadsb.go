@@ -0,0 +1,231 @@
+package gomavlib
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// ADSBVehicle is an aircraft reported by ADS-B.
+type ADSBVehicle struct {
+	// the ICAO address of the aircraft.
+	ICAOAddress uint32
+
+	// the callsign of the aircraft.
+	Callsign string
+
+	// latitude, in degrees.
+	Latitude float64
+
+	// longitude, in degrees.
+	Longitude float64
+
+	// altitude, in meters.
+	Altitude float64
+
+	// the altitude type (ADSB_ALTITUDE_TYPE).
+	AltitudeType int
+
+	// course over ground, in degrees.
+	Heading float64
+
+	// horizontal velocity, in m/s.
+	HorizontalVelocity float64
+
+	// vertical velocity, in m/s, positive up.
+	VerticalVelocity float64
+
+	// the emitter type (ADSB_EMITTER_TYPE).
+	EmitterType int
+
+	// the squawk code.
+	Squawk uint16
+
+	// flags that indicate which fields are valid (ADSB_FLAGS).
+	Flags int
+
+	// the time of the last report.
+	LastSeen time.Time
+}
+
+// ADSBSource is a source of ADS-B reports, i.e. a receiver connected to
+// the node.
+type ADSBSource interface {
+	// Run reads reports and passes them to the report function,
+	// until the context is canceled.
+	Run(ctx context.Context, report func(*ADSBVehicle)) error
+}
+
+type nodeADSB struct {
+	n               *Node
+	msgADSBVehicle  msg.Message
+	mutex           sync.Mutex
+	vehicles        map[uint32]*ADSBVehicle
+	sourceCtx       context.Context
+	sourceCtxCancel func()
+
+	// out
+	done chan struct{}
+}
+
+func newNodeADSB(n *Node) *nodeADSB {
+	// module is disabled
+	if !n.conf.ADSBEnable {
+		return nil
+	}
+
+	// ADSB_VEHICLE message must exist in dialect and correspond to standard
+	msgADSBVehicle := dialectMessage(n.conf.Dialect, 246, 184)
+	if msgADSBVehicle == nil {
+		return nil
+	}
+
+	sourceCtx, sourceCtxCancel := context.WithCancel(n.ctx)
+
+	return &nodeADSB{
+		n:               n,
+		msgADSBVehicle:  msgADSBVehicle,
+		vehicles:        make(map[uint32]*ADSBVehicle),
+		sourceCtx:       sourceCtx,
+		sourceCtxCancel: sourceCtxCancel,
+		done:            make(chan struct{}),
+	}
+}
+
+func (a *nodeADSB) close() {
+	a.sourceCtxCancel()
+	<-a.done
+}
+
+func (a *nodeADSB) run() {
+	defer close(a.done)
+
+	if a.n.conf.ADSBSource == nil {
+		<-a.sourceCtx.Done()
+		return
+	}
+
+	// errors of the source are not fatal for the node
+	a.n.conf.ADSBSource.Run(a.sourceCtx, a.onReport) //nolint:errcheck
+}
+
+// onReport is called when a source reports a vehicle, that is stored and
+// written to all channels.
+func (a *nodeADSB) onReport(v *ADSBVehicle) {
+	vc := *v
+	if vc.LastSeen.IsZero() {
+		vc.LastSeen = time.Now()
+	}
+	a.update(&vc)
+
+	select {
+	case a.n.writeAll <- a.encode(&vc):
+	case <-a.sourceCtx.Done():
+	}
+}
+
+func (a *nodeADSB) update(v *ADSBVehicle) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	// the same vehicle can be reported by multiple systems or channels:
+	// keep the most recent report
+	cur, ok := a.vehicles[v.ICAOAddress]
+	if ok && cur.LastSeen.After(v.LastSeen) {
+		return
+	}
+
+	// remove expired vehicles before adding a new one
+	if !ok {
+		a.removeExpired()
+	}
+
+	a.vehicles[v.ICAOAddress] = v
+}
+
+func (a *nodeADSB) removeExpired() {
+	now := time.Now()
+	for addr, v := range a.vehicles {
+		if now.Sub(v.LastSeen) >= a.n.conf.ADSBTimeout {
+			delete(a.vehicles, addr)
+		}
+	}
+}
+
+func (a *nodeADSB) encode(v *ADSBVehicle) msg.Message {
+	m := newMessage(a.msgADSBVehicle).Elem()
+	m.FieldByName("IcaoAddress").SetUint(uint64(v.ICAOAddress))
+	m.FieldByName("Callsign").SetString(v.Callsign)
+	reflectSetNumber(m.FieldByName("Lat"), v.Latitude*1e7)
+	reflectSetNumber(m.FieldByName("Lon"), v.Longitude*1e7)
+	reflectSetNumber(m.FieldByName("Altitude"), v.Altitude*1000)
+	reflectSetNumber(m.FieldByName("AltitudeType"), float64(v.AltitudeType))
+	reflectSetNumber(m.FieldByName("Heading"), normalizeDegrees(v.Heading)*100)
+	reflectSetNumber(m.FieldByName("HorVelocity"), v.HorizontalVelocity*100)
+	reflectSetNumber(m.FieldByName("VerVelocity"), v.VerticalVelocity*100)
+	reflectSetNumber(m.FieldByName("EmitterType"), float64(v.EmitterType))
+	reflectSetNumber(m.FieldByName("Tslc"), math.Floor(time.Since(v.LastSeen).Seconds()))
+	reflectSetNumber(m.FieldByName("Flags"), float64(v.Flags))
+	m.FieldByName("Squawk").SetUint(uint64(v.Squawk))
+	return m.Addr().Interface().(msg.Message)
+}
+
+func (a *nodeADSB) onEventFrame(evt *EventFrame) {
+	if evt.messageID() != 246 ||
+		reflect.TypeOf(evt.Message()) != reflect.TypeOf(a.msgADSBVehicle) {
+		return
+	}
+
+	m := msgValue(evt.Message())
+
+	// the report may be delayed with respect to the last communication
+	// with the vehicle
+	lastSeen := time.Now().Add(-time.Duration(m.FieldByName("Tslc").Uint()) * time.Second)
+
+	a.update(&ADSBVehicle{
+		ICAOAddress:        uint32(m.FieldByName("IcaoAddress").Uint()),
+		Callsign:           m.FieldByName("Callsign").String(),
+		Latitude:           reflectNumber(m.FieldByName("Lat")) / 1e7,
+		Longitude:          reflectNumber(m.FieldByName("Lon")) / 1e7,
+		Altitude:           reflectNumber(m.FieldByName("Altitude")) / 1000,
+		AltitudeType:       int(reflectNumber(m.FieldByName("AltitudeType"))),
+		Heading:            reflectNumber(m.FieldByName("Heading")) / 100,
+		HorizontalVelocity: reflectNumber(m.FieldByName("HorVelocity")) / 100,
+		VerticalVelocity:   reflectNumber(m.FieldByName("VerVelocity")) / 100,
+		EmitterType:        int(reflectNumber(m.FieldByName("EmitterType"))),
+		Squawk:             uint16(m.FieldByName("Squawk").Uint()),
+		Flags:              int(reflectNumber(m.FieldByName("Flags"))),
+		LastSeen:           lastSeen,
+	})
+}
+
+// ADSBTraffic returns the aircrafts reported by ADS-B that have been seen
+// within ADSBTimeout, sorted by ICAO address. It requires ADSBEnable.
+func (n *Node) ADSBTraffic() []*ADSBVehicle {
+	a := n.nodeADSB
+	if a == nil {
+		return nil
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.removeExpired()
+
+	out := make([]*ADSBVehicle, 0, len(a.vehicles))
+	for _, v := range a.vehicles {
+		vc := *v
+		out = append(out, &vc)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].ICAOAddress < out[j].ICAOAddress
+	})
+
+	return out
+}
@@ -0,0 +1,162 @@
+package gomavlib
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+type testADSBSource struct {
+	vehicles []*ADSBVehicle
+}
+
+func (s *testADSBSource) Run(ctx context.Context, report func(*ADSBVehicle)) error {
+	for _, v := range s.vehicles {
+		report(v)
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestNodeADSBTraffic(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      255,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		ADSBEnable:       true,
+		ADSBTimeout:      500 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	vehicle, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      1,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer vehicle.Close()
+
+	go func() {
+		for range vehicle.Events() {
+		}
+	}()
+
+	for _, m := range []*common.MessageAdsbVehicle{
+		{IcaoAddress: 2, Callsign: "B", Lat: 454000000, Tslc: 1},
+		{IcaoAddress: 1, Callsign: "A", Lat: 450000000},
+		{IcaoAddress: 2, Callsign: "B", Lat: 455000000, Altitude: 1500000, Heading: 9000},
+		{IcaoAddress: 2, Callsign: "B", Lat: 453000000, Tslc: 2}, // older than the previous one
+	} {
+		vehicle.WriteMessageAll(m)
+		<-gcs.Events()
+	}
+
+	traffic := gcs.ADSBTraffic()
+	require.Equal(t, 2, len(traffic))
+	require.Equal(t, uint32(1), traffic[0].ICAOAddress)
+	require.Equal(t, "A", traffic[0].Callsign)
+	require.Equal(t, uint32(2), traffic[1].ICAOAddress)
+	require.Equal(t, 45.5, traffic[1].Latitude)
+	require.Equal(t, float64(1500), traffic[1].Altitude)
+	require.Equal(t, float64(90), traffic[1].Heading)
+
+	time.Sleep(600 * time.Millisecond)
+	require.Equal(t, 0, len(gcs.ADSBTraffic()))
+}
+
+func TestNodeADSBSource(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      255,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		ADSBEnable:       true,
+		ADSBSource: &testADSBSource{vehicles: []*ADSBVehicle{{
+			ICAOAddress:        0xABCDEF,
+			Callsign:           "TEST123",
+			Latitude:           45.4642,
+			Longitude:          9.19,
+			Altitude:           1200.5,
+			Heading:            -90,
+			HorizontalVelocity: 70.25,
+			VerticalVelocity:   -2.5,
+			Squawk:             7000,
+			Flags:              int(common.ADSB_FLAGS_VALID_COORDS | common.ADSB_FLAGS_VALID_ALTITUDE),
+		}}},
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	vehicle, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      1,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	var m *common.MessageAdsbVehicle
+	for evt := range vehicle.Events() {
+		if frm, ok := evt.(*EventFrame); ok {
+			if m, ok = frm.Message().(*common.MessageAdsbVehicle); ok {
+				break
+			}
+		}
+	}
+
+	require.Equal(t, &common.MessageAdsbVehicle{
+		IcaoAddress: 0xABCDEF,
+		Callsign:    "TEST123",
+		Lat:         454642000,
+		Lon:         91900000,
+		Altitude:    1200500,
+		Heading:     27000,
+		HorVelocity: 7025,
+		VerVelocity: -250,
+		Squawk:      7000,
+		Flags:       common.ADSB_FLAGS_VALID_COORDS | common.ADSB_FLAGS_VALID_ALTITUDE,
+	}, m)
+
+	traffic := gcs.ADSBTraffic()
+	require.Equal(t, 1, len(traffic))
+	require.Equal(t, "TEST123", traffic[0].Callsign)
+}
+
+func TestNodeADSBErrors(t *testing.T) {
+	_, err := NewNode(NodeConf{
+		OutVersion:  V2,
+		OutSystemID: 1,
+		ADSBEnable:  true,
+	})
+	require.EqualError(t, err, "ADSBEnable requires a dialect")
+
+	_, err = NewNode(NodeConf{
+		Dialect:     common.Dialect,
+		OutVersion:  V2,
+		OutSystemID: 1,
+		ADSBSource:  &testADSBSource{},
+	})
+	require.EqualError(t, err, "ADSBSource requires ADSBEnable")
+}
@@ -0,0 +1,198 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// MAV_CMD values
+const (
+	commandImageStartCapture = 2000 // MAV_CMD_IMAGE_START_CAPTURE
+	commandImageStopCapture  = 2001 // MAV_CMD_IMAGE_STOP_CAPTURE
+	commandVideoStartCapture = 2500 // MAV_CMD_VIDEO_START_CAPTURE
+	commandVideoStopCapture  = 2501 // MAV_CMD_VIDEO_STOP_CAPTURE
+)
+
+// CameraTarget is a camera that can be controlled with the camera helpers.
+type CameraTarget struct {
+	// (optional) the channel used to communicate with the camera.
+	// It defaults to all channels.
+	Channel *Channel
+
+	// the system id of the camera.
+	TargetSystem byte
+
+	// (optional) the component id of the camera.
+	// It defaults to 0, that means all components.
+	TargetComponent byte
+
+	// (optional) the time to wait for a response before sending
+	// a command again. It defaults to 1 second.
+	Timeout time.Duration
+
+	// (optional) the maximum number of times a command is sent.
+	// It defaults to 3.
+	Attempts int
+}
+
+type nodeCamera struct {
+	n        *Node
+	sequence uint32
+}
+
+func newNodeCamera(n *Node) *nodeCamera {
+	// commands must be supported
+	if n.nodeCommand == nil {
+		return nil
+	}
+
+	// camera messages must exist in dialect and correspond to standard
+	if dialectMessage(n.conf.Dialect, 259, 92) == nil ||
+		dialectMessage(n.conf.Dialect, 260, 146) == nil ||
+		dialectMessage(n.conf.Dialect, 263, 133) == nil {
+		return nil
+	}
+
+	return &nodeCamera{
+		n: n,
+	}
+}
+
+func (nc *nodeCamera) command(t *CameraTarget, cmd int, params [7]float32) *CommandRequest {
+	return &CommandRequest{
+		Channel:         t.Channel,
+		TargetSystem:    t.TargetSystem,
+		TargetComponent: t.TargetComponent,
+		Command:         cmd,
+		Params:          params,
+		Timeout:         t.Timeout,
+		Attempts:        t.Attempts,
+	}
+}
+
+func (nc *nodeCamera) captureImage(ctx context.Context, t *CameraTarget) (msg.Message, error) {
+	sub := nc.n.frameSubscribers.subscribe(func(evt *EventFrame) bool {
+		return evt.messageID() == 263 &&
+			(t.Channel == nil || evt.Channel == t.Channel) &&
+			evt.SystemID() == t.TargetSystem &&
+			(t.TargetComponent == 0 || evt.ComponentID() == t.TargetComponent)
+	})
+	defer nc.n.frameSubscribers.unsubscribe(sub)
+
+	// the sequence number prevents the camera from capturing multiple images
+	// when the command is sent again
+	seq := atomic.AddUint32(&nc.sequence, 1)
+
+	req := nc.n.nodeCommand.fillRequest(nc.command(t, commandImageStartCapture,
+		[7]float32{0, 0, 1, float32(seq)}))
+	err := nc.n.nodeCommand.sendAccepted(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	evt, err := nc.n.transact(ctx, sub, &Transaction{
+		Channel: t.Channel,
+		RequestFunc: func(attempt int) msg.Message {
+			// the command has already been accepted
+			if attempt == 0 {
+				return nil
+			}
+
+			// CAMERA_IMAGE_CAPTURED may have been lost: send the command
+			// again, that is not executed twice thanks to the sequence number
+			return nc.n.nodeCommand.encode(req, 0)
+		},
+		Timeout:  req.Timeout,
+		Attempts: req.Attempts,
+	})
+	if err == ErrNoResponse {
+		return nil, fmt.Errorf("CAMERA_IMAGE_CAPTURED has not been received")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return evt.Message(), nil
+}
+
+// CameraInformation requests and returns the CAMERA_INFORMATION message of
+// a camera.
+// The dialect must contain the messages of the command protocol and of the
+// camera protocol. Events() must be read in a separate routine, otherwise
+// the responses of the camera can't be received.
+func (n *Node) CameraInformation(ctx context.Context, t *CameraTarget) (msg.Message, error) {
+	if n.nodeCamera == nil {
+		return nil, fmt.Errorf("dialect does not support cameras")
+	}
+	return n.nodeCommand.requestMessage(ctx, n.nodeCamera.command(t, 0, [7]float32{}), 259)
+}
+
+// CameraSettings requests and returns the CAMERA_SETTINGS message of
+// a camera.
+// The dialect must contain the messages of the command protocol and of the
+// camera protocol. Events() must be read in a separate routine, otherwise
+// the responses of the camera can't be received.
+func (n *Node) CameraSettings(ctx context.Context, t *CameraTarget) (msg.Message, error) {
+	if n.nodeCamera == nil {
+		return nil, fmt.Errorf("dialect does not support cameras")
+	}
+	return n.nodeCommand.requestMessage(ctx, n.nodeCamera.command(t, 0, [7]float32{}), 260)
+}
+
+// CaptureImage captures a single image with a camera and returns the
+// resulting CAMERA_IMAGE_CAPTURED message.
+// The dialect must contain the messages of the command protocol and of the
+// camera protocol. Events() must be read in a separate routine, otherwise
+// the responses of the camera can't be received.
+func (n *Node) CaptureImage(ctx context.Context, t *CameraTarget) (msg.Message, error) {
+	if n.nodeCamera == nil {
+		return nil, fmt.Errorf("dialect does not support cameras")
+	}
+	return n.nodeCamera.captureImage(ctx, t)
+}
+
+// StartImageCapture starts capturing images with a camera, with given
+// interval. If count is 0, images are captured until StopImageCapture()
+// is called.
+// Resulting CAMERA_IMAGE_CAPTURED messages can be read from Events().
+func (n *Node) StartImageCapture(ctx context.Context, t *CameraTarget,
+	interval time.Duration, count int) error {
+	if n.nodeCamera == nil {
+		return fmt.Errorf("dialect does not support cameras")
+	}
+	return n.nodeCommand.sendAccepted(ctx, n.nodeCamera.command(t, commandImageStartCapture,
+		[7]float32{0, float32(interval.Seconds()), float32(count)}))
+}
+
+// StopImageCapture stops capturing images with a camera.
+func (n *Node) StopImageCapture(ctx context.Context, t *CameraTarget) error {
+	if n.nodeCamera == nil {
+		return fmt.Errorf("dialect does not support cameras")
+	}
+	return n.nodeCommand.sendAccepted(ctx, n.nodeCamera.command(t, commandImageStopCapture,
+		[7]float32{}))
+}
+
+// StartVideoCapture starts recording a video stream with a camera.
+// If streamID is 0, all streams are recorded.
+func (n *Node) StartVideoCapture(ctx context.Context, t *CameraTarget, streamID int) error {
+	if n.nodeCamera == nil {
+		return fmt.Errorf("dialect does not support cameras")
+	}
+	return n.nodeCommand.sendAccepted(ctx, n.nodeCamera.command(t, commandVideoStartCapture,
+		[7]float32{float32(streamID)}))
+}
+
+// StopVideoCapture stops recording a video stream with a camera.
+// If streamID is 0, all streams are stopped.
+func (n *Node) StopVideoCapture(ctx context.Context, t *CameraTarget, streamID int) error {
+	if n.nodeCamera == nil {
+		return fmt.Errorf("dialect does not support cameras")
+	}
+	return n.nodeCommand.sendAccepted(ctx, n.nodeCamera.command(t, commandVideoStopCapture,
+		[7]float32{float32(streamID)}))
+}
@@ -0,0 +1,73 @@
+package gomavlib
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeCamera(t *testing.T) {
+	gcs, camera := newTestNodePair(t)
+	defer gcs.Close()
+	defer camera.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	// simulate a camera
+	go func() {
+		for evt := range camera.Events() {
+			frm, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			m, ok := frm.Message().(*common.MessageCommandLong)
+			if !ok {
+				continue
+			}
+
+			camera.WriteMessageTo(frm.Channel, &common.MessageCommandAck{
+				Command: m.Command,
+				Result:  common.MAV_RESULT_ACCEPTED,
+			})
+
+			switch m.Command {
+			case common.MAV_CMD_REQUEST_MESSAGE:
+				if m.Param1 == 259 {
+					camera.WriteMessageTo(frm.Channel, &common.MessageCameraInformation{
+						FocalLength: 4.5,
+					})
+				}
+
+			case common.MAV_CMD_IMAGE_START_CAPTURE:
+				camera.WriteMessageTo(frm.Channel, &common.MessageCameraImageCaptured{
+					ImageIndex:    int32(m.Param4),
+					CaptureResult: 1,
+				})
+			}
+		}
+	}()
+
+	target := &CameraTarget{
+		TargetSystem: 1,
+		Timeout:      200 * time.Millisecond,
+	}
+
+	info, err := gcs.CameraInformation(context.Background(), target)
+	require.NoError(t, err)
+	require.Equal(t, float32(4.5), info.(*common.MessageCameraInformation).FocalLength)
+
+	img, err := gcs.CaptureImage(context.Background(), target)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), img.(*common.MessageCameraImageCaptured).ImageIndex)
+
+	err = gcs.StartVideoCapture(context.Background(), target, 0)
+	require.NoError(t, err)
+}
@@ -0,0 +1,287 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// MAV_CMD values
+const (
+	commandSetMessageInterval = 511 // MAV_CMD_SET_MESSAGE_INTERVAL
+	commandRequestMessage     = 512 // MAV_CMD_REQUEST_MESSAGE
+)
+
+// MAV_RESULT values
+const (
+	commandResultAccepted   = 0 // MAV_RESULT_ACCEPTED
+	commandResultInProgress = 5 // MAV_RESULT_IN_PROGRESS
+)
+
+// CommandRequest is a command that can be sent with Node.SendCommand().
+type CommandRequest struct {
+	// (optional) the channel where the command is sent.
+	// It defaults to all channels.
+	Channel *Channel
+
+	// the system id of the target.
+	TargetSystem byte
+
+	// (optional) the component id of the target.
+	// It defaults to 0, that means all components.
+	TargetComponent byte
+
+	// the command id (MAV_CMD).
+	Command int
+
+	// the command parameters.
+	// If UseInt is true, Params[4] and Params[5] are replaced by X and Y.
+	Params [7]float32
+
+	// (optional) a command with typed parameters, generated by
+	// dialect-import, that replaces Command and Params. If the command
+	// has a location (dialect.CommandLocation), it also replaces X and Y.
+	TypedCommand dialect.Command
+
+	// (optional) send a COMMAND_INT instead of a COMMAND_LONG.
+	UseInt bool
+
+	// (optional) the coordinate system (MAV_FRAME) of a COMMAND_INT.
+	Frame int
+
+	// (optional) the X coordinate of a COMMAND_INT.
+	X int32
+
+	// (optional) the Y coordinate of a COMMAND_INT.
+	Y int32
+
+	// (optional) the time to wait for an acknowledgement before sending the
+	// command again. It defaults to 1 second.
+	Timeout time.Duration
+
+	// (optional) the maximum number of times the command is sent.
+	// It defaults to 3.
+	Attempts int
+
+	// (optional) the factor by which the timeout is multiplied after
+	// every attempt. It defaults to 1.
+	Backoff float64
+}
+
+// CommandAck is the acknowledgement of a command.
+type CommandAck struct {
+	// the result of the command (MAV_RESULT).
+	Result int
+
+	// the progress of the command, if available.
+	Progress uint8
+
+	// additional result information, if available.
+	ResultParam2 int32
+}
+
+type nodeCommand struct {
+	n              *Node
+	msgCommandLong msg.Message
+	msgCommandInt  msg.Message
+	msgCommandAck  msg.Message
+}
+
+func newNodeCommand(n *Node) *nodeCommand {
+	// command messages must exist in dialect and correspond to standard
+	msgCommandLong := dialectMessage(n.conf.Dialect, 76, 152)
+	msgCommandInt := dialectMessage(n.conf.Dialect, 75, 158)
+	msgCommandAck := dialectMessage(n.conf.Dialect, 77, 143)
+	if msgCommandLong == nil || msgCommandInt == nil || msgCommandAck == nil {
+		return nil
+	}
+
+	return &nodeCommand{
+		n:              n,
+		msgCommandLong: msgCommandLong,
+		msgCommandInt:  msgCommandInt,
+		msgCommandAck:  msgCommandAck,
+	}
+}
+
+func (c *nodeCommand) encode(req *CommandRequest, attempt int) msg.Message {
+	if req.UseInt {
+		m := newMessage(c.msgCommandInt).Elem()
+		m.FieldByName("TargetSystem").SetUint(uint64(req.TargetSystem))
+		m.FieldByName("TargetComponent").SetUint(uint64(req.TargetComponent))
+		reflectSetInt(m.FieldByName("Frame"), int64(req.Frame))
+		reflectSetInt(m.FieldByName("Command"), int64(req.Command))
+		m.FieldByName("Param1").SetFloat(float64(req.Params[0]))
+		m.FieldByName("Param2").SetFloat(float64(req.Params[1]))
+		m.FieldByName("Param3").SetFloat(float64(req.Params[2]))
+		m.FieldByName("Param4").SetFloat(float64(req.Params[3]))
+		m.FieldByName("X").SetInt(int64(req.X))
+		m.FieldByName("Y").SetInt(int64(req.Y))
+		m.FieldByName("Z").SetFloat(float64(req.Params[6]))
+		return m.Addr().Interface().(msg.Message)
+	}
+
+	m := newMessage(c.msgCommandLong).Elem()
+	m.FieldByName("TargetSystem").SetUint(uint64(req.TargetSystem))
+	m.FieldByName("TargetComponent").SetUint(uint64(req.TargetComponent))
+	reflectSetInt(m.FieldByName("Command"), int64(req.Command))
+	m.FieldByName("Confirmation").SetUint(uint64(attempt))
+	for i, p := range req.Params {
+		m.FieldByName(fmt.Sprintf("Param%d", i+1)).SetFloat(float64(p))
+	}
+	return m.Addr().Interface().(msg.Message)
+}
+
+func (c *nodeCommand) isAck(req *CommandRequest, evt *EventFrame) bool {
+	if evt.messageID() != 77 {
+		return false
+	}
+
+	if req.Channel != nil && evt.Channel != req.Channel {
+		return false
+	}
+
+	if evt.SystemID() != req.TargetSystem ||
+		(req.TargetComponent != 0 && evt.ComponentID() != req.TargetComponent) {
+		return false
+	}
+
+	m := msgValue(evt.Message())
+
+	if int(reflectInt(m.FieldByName("Command"))) != req.Command {
+		return false
+	}
+
+	// target fields are extensions and may be empty
+	if ts := byte(m.FieldByName("TargetSystem").Uint()); ts != 0 && ts != c.n.conf.OutSystemID {
+		return false
+	}
+	if tc := byte(m.FieldByName("TargetComponent").Uint()); tc != 0 && tc != c.n.conf.OutComponentID {
+		return false
+	}
+
+	return true
+}
+
+func (c *nodeCommand) decodeAck(evt *EventFrame) *CommandAck {
+	m := msgValue(evt.Message())
+	return &CommandAck{
+		Result:       int(reflectInt(m.FieldByName("Result"))),
+		Progress:     uint8(m.FieldByName("Progress").Uint()),
+		ResultParam2: int32(m.FieldByName("ResultParam2").Int()),
+	}
+}
+
+func (c *nodeCommand) send(ctx context.Context, req *CommandRequest) (*CommandAck, error) {
+	evt, err := c.n.Transact(ctx, &Transaction{
+		Channel: req.Channel,
+		RequestFunc: func(attempt int) msg.Message {
+			return c.encode(req, attempt)
+		},
+		Match: func(evt *EventFrame) bool {
+			return c.isAck(req, evt)
+		},
+		InProgress: func(evt *EventFrame) bool {
+			return c.decodeAck(evt).Result == commandResultInProgress
+		},
+		Timeout:  req.Timeout,
+		Attempts: req.Attempts,
+		Backoff:  req.Backoff,
+	})
+	if err == ErrNoResponse {
+		return nil, fmt.Errorf("command has not been acknowledged")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.decodeAck(evt), nil
+}
+
+func (c *nodeCommand) fillRequest(req *CommandRequest) *CommandRequest {
+	rc := *req
+	if rc.TypedCommand != nil {
+		rc.Command = int(rc.TypedCommand.GetCommand())
+		rc.Params = rc.TypedCommand.MarshalParams()
+
+		// coordinates of COMMAND_INT are not taken from the parameters,
+		// that are floats, in order not to lose precision
+		if loc, ok := rc.TypedCommand.(dialect.CommandLocation); ok {
+			rc.X, rc.Y = loc.MarshalLocation()
+		}
+	}
+	if rc.Timeout == 0 {
+		rc.Timeout = 1 * time.Second
+	}
+	if rc.Attempts == 0 {
+		rc.Attempts = 3
+	}
+	if rc.Backoff == 0 {
+		rc.Backoff = 1
+	}
+	return &rc
+}
+
+// sendAccepted sends a command and returns an error if the command
+// is not accepted.
+func (c *nodeCommand) sendAccepted(ctx context.Context, req *CommandRequest) error {
+	ack, err := c.send(ctx, c.fillRequest(req))
+	if err != nil {
+		return err
+	}
+
+	if ack.Result != commandResultAccepted {
+		return fmt.Errorf("command rejected (result %d)", ack.Result)
+	}
+
+	return nil
+}
+
+// requestMessage requests a message with MAV_CMD_REQUEST_MESSAGE and
+// waits for it.
+func (c *nodeCommand) requestMessage(ctx context.Context, req *CommandRequest, id uint32) (msg.Message, error) {
+	req = c.fillRequest(req)
+	req.TypedCommand = nil
+	req.Command = commandRequestMessage
+	req.Params = [7]float32{float32(id)}
+
+	sub := c.n.frameSubscribers.subscribe(func(evt *EventFrame) bool {
+		return evt.messageID() == id &&
+			(req.Channel == nil || evt.Channel == req.Channel) &&
+			evt.SystemID() == req.TargetSystem &&
+			(req.TargetComponent == 0 || evt.ComponentID() == req.TargetComponent)
+	})
+	defer c.n.frameSubscribers.unsubscribe(sub)
+
+	err := c.sendAccepted(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	evt, err := sub.wait(ctx, c.n, req.Timeout)
+	if err == errorTimeout {
+		return nil, fmt.Errorf("requested message has not been received")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return evt.Message(), nil
+}
+
+// SendCommand sends a command (with a COMMAND_LONG or COMMAND_INT message),
+// waits for the corresponding COMMAND_ACK and returns it.
+// The command is sent again when the acknowledgement is not received
+// within the timeout.
+// The dialect must contain the COMMAND_LONG, COMMAND_INT and
+// COMMAND_ACK messages. Events() must be read in a separate routine,
+// otherwise the acknowledgement can't be received.
+func (n *Node) SendCommand(ctx context.Context, req *CommandRequest) (*CommandAck, error) {
+	if n.nodeCommand == nil {
+		return nil, fmt.Errorf("dialect does not support commands")
+	}
+	return n.nodeCommand.send(ctx, n.nodeCommand.fillRequest(req))
+}
@@ -0,0 +1,260 @@
+package gomavlib
+
+import (
+	"context"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+// newTestNodePair creates two nodes connected to each other, that use the
+// common dialect. The first one acts as a ground station, the second one
+// as a vehicle.
+func newTestNodePair(t *testing.T) (*Node, *Node) {
+	c1, c2 := net.Pipe()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      255,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	vehicle, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      1,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	return gcs, vehicle
+}
+
+func TestNodeSendCommand(t *testing.T) {
+	for _, ca := range []string{"long", "int"} {
+		t.Run(ca, func(t *testing.T) {
+			gcs, vehicle := newTestNodePair(t)
+			defer gcs.Close()
+			defer vehicle.Close()
+
+			go func() {
+				for range gcs.Events() {
+				}
+			}()
+
+			go func() {
+				received := 0
+				for evt := range vehicle.Events() {
+					frm, ok := evt.(*EventFrame)
+					if !ok {
+						continue
+					}
+
+					var cmd common.MAV_CMD
+					switch m := frm.Message().(type) {
+					case *common.MessageCommandLong:
+						if m.Param1 != 1 || m.Confirmation != uint8(received) {
+							continue
+						}
+						cmd = m.Command
+
+					case *common.MessageCommandInt:
+						if m.Param1 != 1 || m.X != 123456789 {
+							continue
+						}
+						cmd = m.Command
+
+					default:
+						continue
+					}
+
+					// ignore the first attempt
+					received++
+					if received == 1 {
+						continue
+					}
+
+					vehicle.WriteMessageTo(frm.Channel, &common.MessageCommandAck{
+						Command: cmd,
+						Result:  common.MAV_RESULT_IN_PROGRESS,
+					})
+					vehicle.WriteMessageTo(frm.Channel, &common.MessageCommandAck{
+						Command:         cmd,
+						Result:          common.MAV_RESULT_ACCEPTED,
+						TargetSystem:    255,
+						TargetComponent: 1,
+					})
+				}
+			}()
+
+			ack, err := gcs.SendCommand(context.Background(), &CommandRequest{
+				TargetSystem:    1,
+				TargetComponent: 1,
+				Command:         int(common.MAV_CMD_COMPONENT_ARM_DISARM),
+				Params:          [7]float32{1},
+				UseInt:          (ca == "int"),
+				X:               123456789,
+				Timeout:         200 * time.Millisecond,
+			})
+			require.NoError(t, err)
+			require.Equal(t, int(common.MAV_RESULT_ACCEPTED), ack.Result)
+		})
+	}
+}
+
+func TestNodeSendCommandTimeout(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	received := make(chan struct{}, 10)
+	go func() {
+		for evt := range vehicle.Events() {
+			if frm, ok := evt.(*EventFrame); ok {
+				if _, ok := frm.Message().(*common.MessageCommandLong); ok {
+					received <- struct{}{}
+				}
+			}
+		}
+	}()
+
+	_, err := gcs.SendCommand(context.Background(), &CommandRequest{
+		TargetSystem: 1,
+		Command:      int(common.MAV_CMD_COMPONENT_ARM_DISARM),
+		Timeout:      50 * time.Millisecond,
+		Attempts:     2,
+	})
+	require.EqualError(t, err, "command has not been acknowledged")
+	require.Equal(t, 2, len(received))
+}
+
+type testCommandTakeoff struct {
+	Pitch     float32
+	Latitude  int32
+	Longitude int32
+	Altitude  float32
+}
+
+func (*testCommandTakeoff) GetCommand() uint32 {
+	return uint32(common.MAV_CMD_NAV_TAKEOFF)
+}
+
+func (c *testCommandTakeoff) MarshalParams() [7]float32 {
+	return [7]float32{c.Pitch, 0, 0, 0, float32(float64(c.Latitude) / 1e7), float32(float64(c.Longitude) / 1e7), c.Altitude}
+}
+
+func (c *testCommandTakeoff) UnmarshalParams(p [7]float32) {
+	c.Pitch = p[0]
+	c.Latitude = int32(math.Round(float64(p[4]) * 1e7))
+	c.Longitude = int32(math.Round(float64(p[5]) * 1e7))
+	c.Altitude = p[6]
+}
+
+func (c *testCommandTakeoff) MarshalLocation() (int32, int32) {
+	return c.Latitude, c.Longitude
+}
+
+func (c *testCommandTakeoff) UnmarshalLocation(x int32, y int32) {
+	c.Latitude = x
+	c.Longitude = y
+}
+
+func TestNodeSendCommandTyped(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	received := make(chan *common.MessageCommandLong, 10)
+	go func() {
+		for evt := range vehicle.Events() {
+			if frm, ok := evt.(*EventFrame); ok {
+				if m, ok := frm.Message().(*common.MessageCommandLong); ok {
+					received <- m
+					vehicle.WriteMessageTo(frm.Channel, &common.MessageCommandAck{
+						Command: m.Command,
+						Result:  common.MAV_RESULT_ACCEPTED,
+					})
+				}
+			}
+		}
+	}()
+
+	ack, err := gcs.SendCommand(context.Background(), &CommandRequest{
+		TargetSystem: 1,
+		TypedCommand: &testCommandTakeoff{Pitch: 15, Altitude: 20},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int(common.MAV_RESULT_ACCEPTED), ack.Result)
+
+	m := <-received
+	require.Equal(t, common.MAV_CMD_NAV_TAKEOFF, m.Command)
+
+	var cmd testCommandTakeoff
+	cmd.UnmarshalParams([7]float32{m.Param1, m.Param2, m.Param3, m.Param4, m.Param5, m.Param6, m.Param7})
+	require.Equal(t, testCommandTakeoff{Pitch: 15, Altitude: 20}, cmd)
+}
+
+func TestNodeSendCommandTypedInt(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	received := make(chan *common.MessageCommandInt, 10)
+	go func() {
+		for evt := range vehicle.Events() {
+			if frm, ok := evt.(*EventFrame); ok {
+				if m, ok := frm.Message().(*common.MessageCommandInt); ok {
+					received <- m
+					vehicle.WriteMessageTo(frm.Channel, &common.MessageCommandAck{
+						Command: m.Command,
+						Result:  common.MAV_RESULT_ACCEPTED,
+					})
+				}
+			}
+		}
+	}()
+
+	// coordinates that can't be represented exactly by a float32
+	cmd := &testCommandTakeoff{
+		Latitude:  457654321,
+		Longitude: 91234567,
+		Altitude:  20,
+	}
+
+	_, err := gcs.SendCommand(context.Background(), &CommandRequest{
+		TargetSystem: 1,
+		TypedCommand: cmd,
+		UseInt:       true,
+	})
+	require.NoError(t, err)
+
+	m := <-received
+	require.Equal(t, common.MAV_CMD_NAV_TAKEOFF, m.Command)
+	require.Equal(t, int32(457654321), m.X)
+	require.Equal(t, int32(91234567), m.Y)
+	require.Equal(t, float32(20), m.Z)
+}
@@ -0,0 +1,113 @@
+package gomavlib
+
+import (
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// ComponentConf is the configuration of an additional component of a node.
+type ComponentConf struct {
+	// the component id, added to outgoing frames of the component.
+	ComponentID byte
+
+	// (optional) disables the periodic sending of heartbeats
+	// on behalf of the component.
+	HeartbeatDisable bool
+
+	// (optional) the system type advertised by heartbeats.
+	// It defaults to MAV_TYPE_GCS
+	HeartbeatSystemType int
+
+	// (optional) the autopilot type advertised by heartbeats.
+	// It defaults to MAV_AUTOPILOT_GENERIC
+	HeartbeatAutopilotType int
+
+	// (optional) a function that is called before sending every heartbeat
+	// and returns its content. It overrides HeartbeatSystemType and
+	// HeartbeatAutopilotType.
+	HeartbeatCallback func() HeartbeatContent
+}
+
+// componentMessage is a message written on behalf of a component.
+type componentMessage struct {
+	componentID byte
+	m           msg.Message
+}
+
+// Component is an additional component of a node (for instance, a camera or
+// a gimbal controlled by an onboard computer), that shares the node
+// channels and system id, but has its own component id and heartbeats.
+type Component struct {
+	n    *Node
+	conf ComponentConf
+	sub  *frameSubscriber
+}
+
+func newComponent(n *Node, conf ComponentConf) *Component {
+	c := &Component{
+		n:    n,
+		conf: conf,
+	}
+	c.sub = n.frameSubscribers.subscribe(c.isTarget)
+	return c
+}
+
+func (c *Component) close() {
+	c.n.frameSubscribers.unsubscribe(c.sub)
+	close(c.sub.frames)
+}
+
+// isTarget checks whether a frame is targeted to the component.
+func (c *Component) isTarget(evt *EventFrame) bool {
+	targetSystem, targetComponent := messageTarget(evt)
+	return targetComponent == c.conf.ComponentID &&
+		(targetSystem == 0 || targetSystem == c.n.conf.OutSystemID)
+}
+
+// ComponentID returns the component id.
+func (c *Component) ComponentID() byte {
+	return c.conf.ComponentID
+}
+
+// Frames returns a channel from which receiving the frames whose
+// target_component field is equal to the component id. Frames are also
+// emitted by Node.Events(). Frames are discarded when the channel is full.
+// The channel is closed when the node is closed.
+func (c *Component) Frames() chan *EventFrame {
+	return c.sub.frames
+}
+
+// WriteMessageTo writes a message to given channel, on behalf of the component.
+func (c *Component) WriteMessageTo(channel *Channel, m msg.Message) {
+	select {
+	case c.n.writeTo <- writeToReq{channel, componentMessage{c.conf.ComponentID, m}}:
+	case <-c.n.ctx.Done():
+	}
+}
+
+// WriteMessageAll writes a message to all channels, on behalf of the component.
+func (c *Component) WriteMessageAll(m msg.Message) {
+	select {
+	case c.n.writeAll <- componentMessage{c.conf.ComponentID, m}:
+	case <-c.n.ctx.Done():
+	}
+}
+
+// WriteMessageExcept writes a message to all channels except specified channel,
+// on behalf of the component.
+func (c *Component) WriteMessageExcept(exceptChannel *Channel, m msg.Message) {
+	select {
+	case c.n.writeExcept <- writeExceptReq{exceptChannel, componentMessage{c.conf.ComponentID, m}}:
+	case <-c.n.ctx.Done():
+	}
+}
+
+// Component returns the additional component with given id,
+// or nil if the component does not exist.
+func (n *Node) Component(id byte) *Component {
+	for _, c := range n.components {
+		if c.conf.ComponentID == id {
+			return c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package gomavlib
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeComponents(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      255,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	vehicle, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      1,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+		HeartbeatPeriod:  100 * time.Millisecond,
+		Components: []ComponentConf{{
+			ComponentID:         100,
+			HeartbeatSystemType: int(common.MAV_TYPE_CAMERA),
+		}},
+	})
+	require.NoError(t, err)
+	defer vehicle.Close()
+
+	go func() {
+		for range vehicle.Events() {
+		}
+	}()
+
+	camera := vehicle.Component(100)
+	require.NotNil(t, camera)
+	require.Nil(t, vehicle.Component(101))
+
+	// heartbeats are emitted on behalf of the component
+	for evt := range gcs.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			if m, ok := fr.Message().(*common.MessageHeartbeat); ok {
+				require.Equal(t, byte(100), fr.ComponentID())
+				require.Equal(t, common.MAV_TYPE_CAMERA, m.Type)
+				break
+			}
+		}
+	}
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	// only frames targeted to the component are received
+	gcs.WriteMessageAll(&common.MessageCommandLong{
+		TargetSystem:    1,
+		TargetComponent: 1,
+		Command:         common.MAV_CMD_COMPONENT_ARM_DISARM,
+	})
+	gcs.WriteMessageAll(&common.MessageCommandLong{
+		TargetSystem:    1,
+		TargetComponent: 100,
+		Command:         common.MAV_CMD_IMAGE_START_CAPTURE,
+	})
+
+	fr := <-camera.Frames()
+	require.Equal(t, common.MAV_CMD_IMAGE_START_CAPTURE, fr.Message().(*common.MessageCommandLong).Command)
+}
+
+func TestNodeComponentsErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name       string
+		components []ComponentConf
+		err        string
+	}{
+		{
+			"zero id",
+			[]ComponentConf{{}},
+			"ComponentID must be >= 1",
+		},
+		{
+			"node id",
+			[]ComponentConf{{ComponentID: 1}},
+			"component 1 is already used by the node",
+		},
+		{
+			"duplicated",
+			[]ComponentConf{{ComponentID: 2}, {ComponentID: 2}},
+			"component 2 is duplicated",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := NewNode(NodeConf{
+				Dialect:     common.Dialect,
+				OutVersion:  V2,
+				OutSystemID: 1,
+				Components:  ca.components,
+			})
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
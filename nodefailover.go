@@ -0,0 +1,260 @@
+package gomavlib
+
+import (
+	"sync"
+	"time"
+)
+
+type failoverGroup struct {
+	active *Channel
+
+	// time of the last heartbeat received by every channel
+	lastHeartbeats map[*Channel]time.Time
+}
+
+type nodeFailover struct {
+	n      *Node
+	mutex  sync.Mutex
+	groups map[byte]*failoverGroup
+
+	// in
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+func newNodeFailover(n *Node) *nodeFailover {
+	return &nodeFailover{
+		n:         n,
+		groups:    make(map[byte]*failoverGroup),
+		terminate: make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+func (f *nodeFailover) close() {
+	close(f.terminate)
+	<-f.done
+}
+
+func (f *nodeFailover) run() {
+	defer close(f.done)
+
+	// check timeouts with a resolution that is a fraction of the timeout
+	ticker := f.n.conf.Clock.NewTicker(f.n.conf.FailoverTimeout / 10)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			for _, evt := range f.update() {
+				f.n.emitEvent(evt)
+			}
+
+		case <-f.terminate:
+			return
+		}
+	}
+}
+
+// preferred returns whether a channel is preferred to another one.
+func (f *nodeFailover) preferred(a *Channel, b *Channel) bool {
+	if a.failover.Priority != b.failover.Priority {
+		return a.failover.Priority < b.failover.Priority
+	}
+	return a.label < b.label
+}
+
+func (f *nodeFailover) healthy(ch *Channel, lastHeartbeat time.Time, now time.Time) bool {
+	if lastHeartbeat.IsZero() || now.Sub(lastHeartbeat) >= f.n.conf.FailoverTimeout {
+		return false
+	}
+
+	if f.n.conf.FailoverMaxLatency != 0 {
+		// the round-trip time is zero if it has never been measured
+		if rtt := ch.stats.get().RTT; rtt > f.n.conf.FailoverMaxLatency {
+			return false
+		}
+	}
+
+	return true
+}
+
+// elect chooses the active channel of a group and returns an event if
+// the active channel has changed. It must be called with the mutex locked.
+func (f *nodeFailover) elect(systemID byte, g *failoverGroup) *EventFailover {
+	now := f.n.conf.Clock.Now()
+	var best *Channel
+
+	for ch, t := range g.lastHeartbeats {
+		if f.healthy(ch, t, now) && (best == nil || f.preferred(ch, best)) {
+			best = ch
+		}
+	}
+
+	// no channel is healthy: keep the active one or, if it has been closed,
+	// use the preferred one
+	if best == nil {
+		if _, ok := g.lastHeartbeats[g.active]; ok {
+			return nil
+		}
+
+		for ch := range g.lastHeartbeats {
+			if best == nil || f.preferred(ch, best) {
+				best = ch
+			}
+		}
+	}
+
+	if best == g.active {
+		return nil
+	}
+
+	prev := g.active
+	g.active = best
+
+	// the first election and the removal of all channels are not switchovers
+	if prev == nil || best == nil {
+		return nil
+	}
+
+	f.n.log(LogLevelWarn, "active channel changed", "system", systemID, "from", prev, "to", best)
+
+	return &EventFailover{
+		SystemID: systemID,
+		Previous: prev,
+		Channel:  best,
+	}
+}
+
+// update checks the health of the active channels.
+func (f *nodeFailover) update() []*EventFailover {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var out []*EventFailover
+
+	for systemID, g := range f.groups {
+		if evt := f.elect(systemID, g); evt != nil {
+			out = append(out, evt)
+		}
+	}
+
+	return out
+}
+
+func (f *nodeFailover) onChannelOpen(ch *Channel) {
+	if ch.failover == nil {
+		return
+	}
+
+	evt := func() *EventFailover {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+
+		g, ok := f.groups[ch.failover.SystemID]
+		if !ok {
+			g = &failoverGroup{
+				lastHeartbeats: make(map[*Channel]time.Time),
+			}
+			f.groups[ch.failover.SystemID] = g
+		}
+
+		g.lastHeartbeats[ch] = time.Time{}
+		return f.elect(ch.failover.SystemID, g)
+	}()
+
+	if evt != nil {
+		f.n.emitEvent(evt)
+	}
+}
+
+func (f *nodeFailover) onChannelClose(ch *Channel) {
+	if ch.failover == nil {
+		return
+	}
+
+	evt := func() *EventFailover {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+
+		g, ok := f.groups[ch.failover.SystemID]
+		if !ok {
+			return nil
+		}
+
+		delete(g.lastHeartbeats, ch)
+		evt := f.elect(ch.failover.SystemID, g)
+
+		if len(g.lastHeartbeats) == 0 {
+			delete(f.groups, ch.failover.SystemID)
+		}
+
+		return evt
+	}()
+
+	if evt != nil {
+		f.n.emitEvent(evt)
+	}
+}
+
+func (f *nodeFailover) onEventFrame(evt *EventFrame) {
+	ch := evt.Channel
+
+	// message must be a HEARTBEAT of the system of the group
+	if ch.failover == nil || evt.messageID() != 0 || evt.SystemID() != ch.failover.SystemID {
+		return
+	}
+
+	fevt := func() *EventFailover {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+
+		g, ok := f.groups[ch.failover.SystemID]
+		if !ok {
+			return nil
+		}
+
+		if _, ok := g.lastHeartbeats[ch]; !ok {
+			return nil
+		}
+
+		g.lastHeartbeats[ch] = f.n.conf.Clock.Now()
+		return f.elect(ch.failover.SystemID, g)
+	}()
+
+	if fevt != nil {
+		f.n.emitEvent(fevt)
+	}
+}
+
+// isStandby returns whether a channel belongs to a failover group and is
+// not the active channel of the group.
+func (f *nodeFailover) isStandby(ch *Channel) bool {
+	if ch.failover == nil {
+		return false
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	g, ok := f.groups[ch.failover.SystemID]
+	return !ok || g.active != ch
+}
+
+// ActiveChannel returns the channel that is currently used to send frames
+// to the given system, in case the system is reachable through a failover
+// group (see EndpointFailover), or nil otherwise.
+func (n *Node) ActiveChannel(systemID byte) *Channel {
+	f := n.nodeFailover
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	g, ok := f.groups[systemID]
+	if !ok {
+		return nil
+	}
+	return g.active
+}
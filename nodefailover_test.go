@@ -0,0 +1,129 @@
+package gomavlib
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestNodeFailover(t *testing.T) {
+	c1a, c2a := net.Pipe()
+	c1b, c2b := net.Pipe()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointFailover{EndpointCustom{c1a}, 1, 0},
+			EndpointFailover{EndpointCustom{c1b}, 1, 1},
+		},
+		HeartbeatDisable: true,
+		FailoverTimeout:  300 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	vehicle, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      1,
+		Endpoints:        []EndpointConf{EndpointCustom{c2a}, EndpointCustom{c2b}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer vehicle.Close()
+
+	// channels of the vehicle from which frames of the GCS are received
+	vehicleOpen := make(chan *Channel, 2)
+	vehicleRecv := make(chan *Channel, 10)
+	go func() {
+		for evt := range vehicle.Events() {
+			switch tevt := evt.(type) {
+			case *EventChannelOpen:
+				vehicleOpen <- tevt.Channel
+
+			case *EventFrame:
+				vehicleRecv <- tevt.Channel
+			}
+		}
+	}()
+
+	gcsFailover := make(chan *EventFailover, 10)
+	go func() {
+		for evt := range gcs.Events() {
+			if tevt, ok := evt.(*EventFailover); ok {
+				gcsFailover <- tevt
+			}
+		}
+	}()
+
+	vehicleChans := []*Channel{<-vehicleOpen, <-vehicleOpen}
+
+	// the primary channel is used before heartbeats are received
+	var primary *Channel
+	for i := 0; i < 20 && primary == nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+		primary = gcs.ActiveChannel(1)
+	}
+	require.NotNil(t, primary)
+
+	gcs.WriteMessageAll(&MessageHeartbeat{})
+	vehiclePrimary := <-vehicleRecv
+
+	var vehicleBackup *Channel
+	if vehicleChans[0] == vehiclePrimary {
+		vehicleBackup = vehicleChans[1]
+	} else {
+		vehicleBackup = vehicleChans[0]
+	}
+
+	// heartbeats are received through the backup link only
+	for i := 0; i < 3; i++ {
+		vehicle.WriteMessageTo(vehicleBackup, &MessageHeartbeat{})
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	evt := <-gcsFailover
+	require.Equal(t, byte(1), evt.SystemID)
+	require.True(t, primary == evt.Previous)
+	require.True(t, primary != evt.Channel)
+	require.True(t, evt.Channel == gcs.ActiveChannel(1))
+
+	gcs.WriteMessageAll(&MessageHeartbeat{})
+	require.True(t, vehicleBackup == <-vehicleRecv)
+
+	// the primary link is used again when it recovers
+	vehicle.WriteMessageTo(vehiclePrimary, &MessageHeartbeat{})
+
+	evt = <-gcsFailover
+	require.True(t, primary == evt.Channel)
+
+	gcs.WriteMessageAll(&MessageHeartbeat{})
+	require.True(t, vehiclePrimary == <-vehicleRecv)
+
+	// heartbeats of the primary link time out
+	go func() {
+		for i := 0; i < 10; i++ {
+			vehicle.WriteMessageTo(vehicleBackup, &MessageHeartbeat{})
+			time.Sleep(50 * time.Millisecond)
+		}
+	}()
+
+	evt = <-gcsFailover
+	require.True(t, primary == evt.Previous)
+
+	gcs.WriteMessageAll(&MessageHeartbeat{})
+	require.True(t, vehicleBackup == <-vehicleRecv)
+
+	select {
+	case ch := <-vehicleRecv:
+		t.Errorf("unexpected frame received from %v", ch)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
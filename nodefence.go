@@ -0,0 +1,286 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// MAV_MISSION_TYPE values
+const (
+	missionTypeFence = 1 // MAV_MISSION_TYPE_FENCE
+	missionTypeRally = 2 // MAV_MISSION_TYPE_RALLY
+)
+
+// MAV_CMD values of fence and rally items
+const (
+	commandFenceReturnPoint     = 5000 // MAV_CMD_NAV_FENCE_RETURN_POINT
+	commandFenceVertexInclusion = 5001 // MAV_CMD_NAV_FENCE_POLYGON_VERTEX_INCLUSION
+	commandFenceVertexExclusion = 5002 // MAV_CMD_NAV_FENCE_POLYGON_VERTEX_EXCLUSION
+	commandFenceCircleInclusion = 5003 // MAV_CMD_NAV_FENCE_CIRCLE_INCLUSION
+	commandFenceCircleExclusion = 5004 // MAV_CMD_NAV_FENCE_CIRCLE_EXCLUSION
+	commandRallyPoint           = 5100 // MAV_CMD_NAV_RALLY_POINT
+)
+
+// MAV_FRAME values
+const (
+	frameGlobal            = 0 // MAV_FRAME_GLOBAL
+	frameGlobalRelativeAlt = 3 // MAV_FRAME_GLOBAL_RELATIVE_ALT
+)
+
+// GeoPoint is a geographic position.
+type GeoPoint struct {
+	// latitude, in degrees.
+	Latitude float64
+
+	// longitude, in degrees.
+	Longitude float64
+
+	// altitude relative to the home position, in meters.
+	// It is ignored by polygon vertices and circles.
+	Altitude float32
+}
+
+func (p GeoPoint) encode(item *MissionItem) {
+	item.X = int32(math.Round(p.Latitude * 1e7))
+	item.Y = int32(math.Round(p.Longitude * 1e7))
+	item.Z = p.Altitude
+}
+
+func geoPointFromItem(item *MissionItem, withAltitude bool) GeoPoint {
+	p := GeoPoint{
+		Latitude:  float64(item.X) / 1e7,
+		Longitude: float64(item.Y) / 1e7,
+	}
+	if withAltitude {
+		p.Altitude = item.Z
+	}
+	return p
+}
+
+// FencePolygon is a polygonal area of a geofence.
+type FencePolygon struct {
+	// whether the vehicle must stay inside the area (true)
+	// or outside it (false).
+	Inclusion bool
+
+	// (optional) the group of an inclusion area. The vehicle must stay
+	// inside at least one area of each group.
+	InclusionGroup int
+
+	// the vertices of the polygon. There must be at least 3 vertices.
+	Vertices []GeoPoint
+}
+
+// FenceCircle is a circular area of a geofence.
+type FenceCircle struct {
+	// whether the vehicle must stay inside the area (true)
+	// or outside it (false).
+	Inclusion bool
+
+	// (optional) the group of an inclusion area. The vehicle must stay
+	// inside at least one area of each group.
+	InclusionGroup int
+
+	// the center of the circle.
+	Center GeoPoint
+
+	// the radius of the circle, in meters.
+	Radius float32
+}
+
+// Fence is a geofence, made of polygonal and circular areas.
+type Fence struct {
+	// (optional) the point where the vehicle returns after
+	// the geofence is breached.
+	ReturnPoint *GeoPoint
+
+	// the polygonal areas.
+	Polygons []*FencePolygon
+
+	// the circular areas.
+	Circles []*FenceCircle
+}
+
+// MissionItems converts the fence into mission items, that can be uploaded
+// with the MAV_MISSION_TYPE_FENCE mission type.
+func (f *Fence) MissionItems() ([]*MissionItem, error) {
+	var items []*MissionItem
+
+	if f.ReturnPoint != nil {
+		item := &MissionItem{
+			Frame:   frameGlobalRelativeAlt,
+			Command: commandFenceReturnPoint,
+		}
+		f.ReturnPoint.encode(item)
+		items = append(items, item)
+	}
+
+	for _, p := range f.Polygons {
+		if len(p.Vertices) < 3 {
+			return nil, fmt.Errorf("a polygon must have at least 3 vertices")
+		}
+
+		for _, v := range p.Vertices {
+			item := &MissionItem{
+				Frame:   frameGlobal,
+				Command: commandFenceVertexExclusion,
+				Params:  [4]float32{float32(len(p.Vertices))},
+			}
+			if p.Inclusion {
+				item.Command = commandFenceVertexInclusion
+				item.Params[1] = float32(p.InclusionGroup)
+			}
+			v.encode(item)
+			item.Z = 0
+			items = append(items, item)
+		}
+	}
+
+	for _, c := range f.Circles {
+		if c.Radius <= 0 {
+			return nil, fmt.Errorf("a circle must have a positive radius")
+		}
+
+		item := &MissionItem{
+			Frame:   frameGlobal,
+			Command: commandFenceCircleExclusion,
+			Params:  [4]float32{c.Radius},
+		}
+		if c.Inclusion {
+			item.Command = commandFenceCircleInclusion
+			item.Params[1] = float32(c.InclusionGroup)
+		}
+		c.Center.encode(item)
+		item.Z = 0
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// FenceFromMissionItems converts mission items of the MAV_MISSION_TYPE_FENCE
+// mission type into a fence.
+func FenceFromMissionItems(items []*MissionItem) (*Fence, error) {
+	f := &Fence{}
+
+	for i := 0; i < len(items); {
+		item := items[i]
+
+		switch item.Command {
+		case commandFenceReturnPoint:
+			p := geoPointFromItem(item, true)
+			f.ReturnPoint = &p
+			i++
+
+		case commandFenceVertexInclusion, commandFenceVertexExclusion:
+			count := int(item.Params[0])
+			if count < 3 || (i+count) > len(items) {
+				return nil, fmt.Errorf("item %d: invalid vertex count (%d)", i, count)
+			}
+
+			p := &FencePolygon{
+				Inclusion: item.Command == commandFenceVertexInclusion,
+			}
+			if p.Inclusion {
+				p.InclusionGroup = int(item.Params[1])
+			}
+
+			for _, v := range items[i : i+count] {
+				if v.Command != item.Command || int(v.Params[0]) != count {
+					return nil, fmt.Errorf("item %d: polygon is not complete", i)
+				}
+				p.Vertices = append(p.Vertices, geoPointFromItem(v, false))
+			}
+
+			f.Polygons = append(f.Polygons, p)
+			i += count
+
+		case commandFenceCircleInclusion, commandFenceCircleExclusion:
+			c := &FenceCircle{
+				Inclusion: item.Command == commandFenceCircleInclusion,
+				Center:    geoPointFromItem(item, false),
+				Radius:    item.Params[0],
+			}
+			if c.Inclusion {
+				c.InclusionGroup = int(item.Params[1])
+			}
+
+			f.Circles = append(f.Circles, c)
+			i++
+
+		default:
+			return nil, fmt.Errorf("item %d: unsupported command (%d)", i, item.Command)
+		}
+	}
+
+	return f, nil
+}
+
+// UploadFence uploads a geofence to a target, replacing the existing one.
+// The MissionType of the transfer is ignored.
+// The same requirements of UploadMission() apply.
+func (n *Node) UploadFence(ctx context.Context, t *MissionTransfer, f *Fence) error {
+	items, err := f.MissionItems()
+	if err != nil {
+		return err
+	}
+
+	tc := *t
+	tc.MissionType = missionTypeFence
+	return n.UploadMission(ctx, &tc, items)
+}
+
+// DownloadFence downloads the geofence of a target.
+// The MissionType of the transfer is ignored.
+// The same requirements of DownloadMission() apply.
+func (n *Node) DownloadFence(ctx context.Context, t *MissionTransfer) (*Fence, error) {
+	tc := *t
+	tc.MissionType = missionTypeFence
+	items, err := n.DownloadMission(ctx, &tc)
+	if err != nil {
+		return nil, err
+	}
+
+	return FenceFromMissionItems(items)
+}
+
+// UploadRallyPoints uploads rally points to a target, replacing the
+// existing ones. The MissionType of the transfer is ignored.
+// The same requirements of UploadMission() apply.
+func (n *Node) UploadRallyPoints(ctx context.Context, t *MissionTransfer, points []GeoPoint) error {
+	items := make([]*MissionItem, len(points))
+	for i, p := range points {
+		items[i] = &MissionItem{
+			Frame:   frameGlobalRelativeAlt,
+			Command: commandRallyPoint,
+		}
+		p.encode(items[i])
+	}
+
+	tc := *t
+	tc.MissionType = missionTypeRally
+	return n.UploadMission(ctx, &tc, items)
+}
+
+// DownloadRallyPoints downloads the rally points of a target.
+// The MissionType of the transfer is ignored.
+// The same requirements of DownloadMission() apply.
+func (n *Node) DownloadRallyPoints(ctx context.Context, t *MissionTransfer) ([]GeoPoint, error) {
+	tc := *t
+	tc.MissionType = missionTypeRally
+	items, err := n.DownloadMission(ctx, &tc)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]GeoPoint, len(items))
+	for i, item := range items {
+		if item.Command != commandRallyPoint {
+			return nil, fmt.Errorf("item %d: unsupported command (%d)", i, item.Command)
+		}
+		points[i] = geoPointFromItem(item, true)
+	}
+
+	return points, nil
+}
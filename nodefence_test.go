@@ -0,0 +1,167 @@
+package gomavlib
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+// runTestMissionStore simulates a vehicle that stores a list of items
+// for each mission type.
+func runTestMissionStore(vehicle *Node) {
+	missions := make(map[common.MAV_MISSION_TYPE][]*common.MessageMissionItemInt)
+	counts := make(map[common.MAV_MISSION_TYPE]int)
+
+	for evt := range vehicle.Events() {
+		frm, ok := evt.(*EventFrame)
+		if !ok {
+			continue
+		}
+
+		switch m := frm.Message().(type) {
+		case *common.MessageMissionCount:
+			counts[m.MissionType] = int(m.Count)
+			missions[m.MissionType] = nil
+			vehicle.WriteMessageTo(frm.Channel, &common.MessageMissionRequestInt{
+				TargetSystem:    frm.SystemID(),
+				TargetComponent: frm.ComponentID(),
+				MissionType:     m.MissionType,
+			})
+
+		case *common.MessageMissionItemInt:
+			if int(m.Seq) != len(missions[m.MissionType]) {
+				continue
+			}
+			missions[m.MissionType] = append(missions[m.MissionType], m)
+
+			if len(missions[m.MissionType]) < counts[m.MissionType] {
+				vehicle.WriteMessageTo(frm.Channel, &common.MessageMissionRequestInt{
+					TargetSystem:    frm.SystemID(),
+					TargetComponent: frm.ComponentID(),
+					Seq:             uint16(len(missions[m.MissionType])),
+					MissionType:     m.MissionType,
+				})
+			} else {
+				vehicle.WriteMessageTo(frm.Channel, &common.MessageMissionAck{
+					TargetSystem:    frm.SystemID(),
+					TargetComponent: frm.ComponentID(),
+					Type:            common.MAV_MISSION_ACCEPTED,
+					MissionType:     m.MissionType,
+				})
+			}
+
+		case *common.MessageMissionRequestList:
+			vehicle.WriteMessageTo(frm.Channel, &common.MessageMissionCount{
+				TargetSystem:    frm.SystemID(),
+				TargetComponent: frm.ComponentID(),
+				Count:           uint16(len(missions[m.MissionType])),
+				MissionType:     m.MissionType,
+			})
+
+		case *common.MessageMissionRequestInt:
+			item := *missions[m.MissionType][m.Seq]
+			item.TargetSystem = frm.SystemID()
+			item.TargetComponent = frm.ComponentID()
+			vehicle.WriteMessageTo(frm.Channel, &item)
+		}
+	}
+}
+
+func TestNodeFence(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	go runTestMissionStore(vehicle)
+
+	fence := &Fence{
+		ReturnPoint: &GeoPoint{Latitude: 45.4642, Longitude: 9.19, Altitude: 30},
+		Polygons: []*FencePolygon{
+			{
+				Inclusion:      true,
+				InclusionGroup: 1,
+				Vertices: []GeoPoint{
+					{Latitude: 45.46, Longitude: 9.18},
+					{Latitude: 45.47, Longitude: 9.18},
+					{Latitude: 45.47, Longitude: 9.2},
+					{Latitude: 45.46, Longitude: 9.2},
+				},
+			},
+			{
+				Vertices: []GeoPoint{
+					{Latitude: 45.465, Longitude: 9.185},
+					{Latitude: 45.466, Longitude: 9.185},
+					{Latitude: 45.466, Longitude: 9.186},
+				},
+			},
+		},
+		Circles: []*FenceCircle{
+			{
+				Center: GeoPoint{Latitude: 45.468, Longitude: 9.195},
+				Radius: 50,
+			},
+		},
+	}
+
+	transfer := &MissionTransfer{
+		TargetSystem: 1,
+		Timeout:      200 * time.Millisecond,
+	}
+
+	err := gcs.UploadFence(context.Background(), transfer, fence)
+	require.NoError(t, err)
+
+	points := []GeoPoint{
+		{Latitude: 45.461, Longitude: 9.181, Altitude: 20},
+		{Latitude: 45.462, Longitude: 9.182, Altitude: 25},
+	}
+
+	err = gcs.UploadRallyPoints(context.Background(), transfer, points)
+	require.NoError(t, err)
+
+	downloadedFence, err := gcs.DownloadFence(context.Background(), transfer)
+	require.NoError(t, err)
+	require.Equal(t, fence, downloadedFence)
+
+	downloadedPoints, err := gcs.DownloadRallyPoints(context.Background(), transfer)
+	require.NoError(t, err)
+	require.Equal(t, points, downloadedPoints)
+
+	mission, err := gcs.DownloadMission(context.Background(), transfer)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(mission))
+}
+
+func TestFenceMissionItemsErrors(t *testing.T) {
+	_, err := (&Fence{
+		Polygons: []*FencePolygon{{
+			Vertices: []GeoPoint{{}, {}},
+		}},
+	}).MissionItems()
+	require.EqualError(t, err, "a polygon must have at least 3 vertices")
+
+	_, err = (&Fence{
+		Circles: []*FenceCircle{{}},
+	}).MissionItems()
+	require.EqualError(t, err, "a circle must have a positive radius")
+
+	_, err = FenceFromMissionItems([]*MissionItem{
+		{Command: commandFenceVertexInclusion, Params: [4]float32{3}},
+		{Command: commandFenceVertexInclusion, Params: [4]float32{3}},
+	})
+	require.EqualError(t, err, "item 0: invalid vertex count (3)")
+
+	_, err = FenceFromMissionItems([]*MissionItem{
+		{Command: int(common.MAV_CMD_NAV_WAYPOINT)},
+	})
+	require.EqualError(t, err, "item 0: unsupported command (16)")
+}
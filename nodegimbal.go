@@ -0,0 +1,235 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// GimbalTarget is a gimbal that can be controlled with the gimbal helpers.
+type GimbalTarget struct {
+	// (optional) the channel used to communicate with the gimbal.
+	// It defaults to all channels.
+	Channel *Channel
+
+	// the system id of the gimbal manager or device.
+	TargetSystem byte
+
+	// (optional) the component id of the gimbal manager or device.
+	// It defaults to 0, that means all components.
+	TargetComponent byte
+
+	// (optional) the id of the gimbal device controlled by the gimbal manager.
+	// It defaults to 0, that means all gimbal devices.
+	GimbalDeviceID byte
+
+	// (optional) the time to wait for a response before sending
+	// a command again. It defaults to 1 second.
+	Timeout time.Duration
+
+	// (optional) the maximum number of times a command is sent.
+	// It defaults to 3.
+	Attempts int
+}
+
+// GimbalAttitude is the attitude of a gimbal device, as reported by
+// GIMBAL_DEVICE_ATTITUDE_STATUS.
+type GimbalAttitude struct {
+	// roll angle in radians.
+	Roll float64
+
+	// pitch angle in radians.
+	Pitch float64
+
+	// yaw angle in radians.
+	Yaw float64
+
+	// angular velocity around the X, Y and Z axes, in radians/second.
+	AngularVelocity [3]float32
+
+	// flags (GIMBAL_DEVICE_FLAGS).
+	Flags int
+
+	// failure flags (GIMBAL_DEVICE_ERROR_FLAGS).
+	FailureFlags int
+
+	// the time in which the attitude was received.
+	Time time.Time
+}
+
+// quaternionToEuler converts a quaternion (w, x, y, z) into Euler angles.
+func quaternionToEuler(q [4]float32) (float64, float64, float64) {
+	w, x, y, z := float64(q[0]), float64(q[1]), float64(q[2]), float64(q[3])
+
+	roll := math.Atan2(2*(w*x+y*z), 1-2*(x*x+y*y))
+
+	sinp := 2 * (w*y - z*x)
+	if sinp > 1 {
+		sinp = 1
+	} else if sinp < -1 {
+		sinp = -1
+	}
+	pitch := math.Asin(sinp)
+
+	yaw := math.Atan2(2*(w*z+x*y), 1-2*(y*y+z*z))
+
+	return roll, pitch, yaw
+}
+
+type gimbalKey struct {
+	systemID    byte
+	componentID byte
+}
+
+type nodeGimbal struct {
+	n                           *Node
+	msgGimbalManagerSetPitchyaw msg.Message
+	msgGimbalDeviceSetAttitude  msg.Message
+	attitudesMutex              sync.Mutex
+	attitudes                   map[gimbalKey]*GimbalAttitude
+}
+
+func newNodeGimbal(n *Node) *nodeGimbal {
+	// commands must be supported
+	if n.nodeCommand == nil {
+		return nil
+	}
+
+	// gimbal messages must exist in dialect and correspond to standard
+	msgGimbalManagerSetPitchyaw := dialectMessage(n.conf.Dialect, 287, 1)
+	msgGimbalDeviceSetAttitude := dialectMessage(n.conf.Dialect, 284, 99)
+	if dialectMessage(n.conf.Dialect, 280, 70) == nil || msgGimbalManagerSetPitchyaw == nil ||
+		msgGimbalDeviceSetAttitude == nil || dialectMessage(n.conf.Dialect, 285, 137) == nil {
+		return nil
+	}
+
+	return &nodeGimbal{
+		n:                           n,
+		msgGimbalManagerSetPitchyaw: msgGimbalManagerSetPitchyaw,
+		msgGimbalDeviceSetAttitude:  msgGimbalDeviceSetAttitude,
+		attitudes:                   make(map[gimbalKey]*GimbalAttitude),
+	}
+}
+
+func (ng *nodeGimbal) onEventFrame(evt *EventFrame) {
+	// message must be GIMBAL_DEVICE_ATTITUDE_STATUS
+	if evt.messageID() != 285 {
+		return
+	}
+
+	m := msgValue(evt.Message())
+
+	var q [4]float32
+	for i := range q {
+		q[i] = float32(m.FieldByName("Q").Index(i).Float())
+	}
+	roll, pitch, yaw := quaternionToEuler(q)
+
+	att := &GimbalAttitude{
+		Roll:  roll,
+		Pitch: pitch,
+		Yaw:   yaw,
+		AngularVelocity: [3]float32{
+			float32(m.FieldByName("AngularVelocityX").Float()),
+			float32(m.FieldByName("AngularVelocityY").Float()),
+			float32(m.FieldByName("AngularVelocityZ").Float()),
+		},
+		Flags:        int(reflectInt(m.FieldByName("Flags"))),
+		FailureFlags: int(reflectInt(m.FieldByName("FailureFlags"))),
+		Time:         time.Now(),
+	}
+
+	ng.attitudesMutex.Lock()
+	defer ng.attitudesMutex.Unlock()
+
+	ng.attitudes[gimbalKey{evt.SystemID(), evt.ComponentID()}] = att
+}
+
+func (ng *nodeGimbal) newMessage(t *GimbalTarget, tmpl msg.Message) msg.Message {
+	m := newMessage(tmpl).Elem()
+	m.FieldByName("TargetSystem").SetUint(uint64(t.TargetSystem))
+	m.FieldByName("TargetComponent").SetUint(uint64(t.TargetComponent))
+	return m.Addr().Interface().(msg.Message)
+}
+
+// GimbalManagerInformation requests and returns the
+// GIMBAL_MANAGER_INFORMATION message of a gimbal manager.
+// The dialect must contain the messages of the command protocol and of the
+// gimbal protocol v2. Events() must be read in a separate routine, otherwise
+// the responses of the gimbal manager can't be received.
+func (n *Node) GimbalManagerInformation(ctx context.Context, t *GimbalTarget) (msg.Message, error) {
+	if n.nodeGimbal == nil {
+		return nil, fmt.Errorf("dialect does not support gimbals")
+	}
+	return n.nodeCommand.requestMessage(ctx, &CommandRequest{
+		Channel:         t.Channel,
+		TargetSystem:    t.TargetSystem,
+		TargetComponent: t.TargetComponent,
+		Timeout:         t.Timeout,
+		Attempts:        t.Attempts,
+	}, 280)
+}
+
+// SetGimbalPitchYaw sends a GIMBAL_MANAGER_SET_PITCHYAW message to a gimbal
+// manager. Angles are in radians, rates in radians/second; NaN can be used to
+// leave a value unset. flags are GIMBAL_MANAGER_FLAGS.
+// This message is meant to be streamed, therefore it is not acknowledged.
+func (n *Node) SetGimbalPitchYaw(t *GimbalTarget, pitch float32, yaw float32,
+	pitchRate float32, yawRate float32, flags int) error {
+	if n.nodeGimbal == nil {
+		return fmt.Errorf("dialect does not support gimbals")
+	}
+
+	out := n.nodeGimbal.newMessage(t, n.nodeGimbal.msgGimbalManagerSetPitchyaw)
+	m := msgValue(out)
+	reflectSetInt(m.FieldByName("Flags"), int64(flags))
+	m.FieldByName("GimbalDeviceId").SetUint(uint64(t.GimbalDeviceID))
+	m.FieldByName("Pitch").SetFloat(float64(pitch))
+	m.FieldByName("Yaw").SetFloat(float64(yaw))
+	m.FieldByName("PitchRate").SetFloat(float64(pitchRate))
+	m.FieldByName("YawRate").SetFloat(float64(yawRate))
+	n.writeMessageToOrAll(t.Channel, out)
+	return nil
+}
+
+// SetGimbalDeviceAttitude sends a GIMBAL_DEVICE_SET_ATTITUDE message to
+// a gimbal device. q is a quaternion (w, x, y, z), angular velocity is in
+// radians/second; NaN can be used to leave a value unset.
+// flags are GIMBAL_DEVICE_FLAGS.
+// This message is meant to be streamed, therefore it is not acknowledged.
+func (n *Node) SetGimbalDeviceAttitude(t *GimbalTarget, q [4]float32,
+	angularVelocity [3]float32, flags int) error {
+	if n.nodeGimbal == nil {
+		return fmt.Errorf("dialect does not support gimbals")
+	}
+
+	out := n.nodeGimbal.newMessage(t, n.nodeGimbal.msgGimbalDeviceSetAttitude)
+	m := msgValue(out)
+	reflectSetInt(m.FieldByName("Flags"), int64(flags))
+	for i, v := range q {
+		m.FieldByName("Q").Index(i).SetFloat(float64(v))
+	}
+	m.FieldByName("AngularVelocityX").SetFloat(float64(angularVelocity[0]))
+	m.FieldByName("AngularVelocityY").SetFloat(float64(angularVelocity[1]))
+	m.FieldByName("AngularVelocityZ").SetFloat(float64(angularVelocity[2]))
+	n.writeMessageToOrAll(t.Channel, out)
+	return nil
+}
+
+// GimbalAttitude returns the last attitude reported by a gimbal device
+// through GIMBAL_DEVICE_ATTITUDE_STATUS.
+func (n *Node) GimbalAttitude(systemID byte, componentID byte) (*GimbalAttitude, bool) {
+	if n.nodeGimbal == nil {
+		return nil, false
+	}
+
+	n.nodeGimbal.attitudesMutex.Lock()
+	defer n.nodeGimbal.attitudesMutex.Unlock()
+
+	att, ok := n.nodeGimbal.attitudes[gimbalKey{systemID, componentID}]
+	return att, ok
+}
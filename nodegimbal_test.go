@@ -0,0 +1,89 @@
+package gomavlib
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestQuaternionToEuler(t *testing.T) {
+	// rotation of 90 degrees around Z
+	roll, pitch, yaw := quaternionToEuler([4]float32{float32(math.Sqrt2 / 2), 0, 0, float32(math.Sqrt2 / 2)})
+	require.InDelta(t, 0, roll, 0.0001)
+	require.InDelta(t, 0, pitch, 0.0001)
+	require.InDelta(t, math.Pi/2, yaw, 0.0001)
+}
+
+func TestNodeGimbal(t *testing.T) {
+	gcs, gimbal := newTestNodePair(t)
+	defer gcs.Close()
+	defer gimbal.Close()
+
+	frames := make(chan *EventFrame, 10)
+	go func() {
+		for evt := range gcs.Events() {
+			if frm, ok := evt.(*EventFrame); ok {
+				frames <- frm
+			}
+		}
+	}()
+
+	pitchyaw := make(chan *common.MessageGimbalManagerSetPitchyaw, 1)
+
+	// simulate a gimbal manager
+	go func() {
+		for evt := range gimbal.Events() {
+			frm, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			switch m := frm.Message().(type) {
+			case *common.MessageCommandLong:
+				gimbal.WriteMessageTo(frm.Channel, &common.MessageCommandAck{
+					Command: m.Command,
+					Result:  common.MAV_RESULT_ACCEPTED,
+				})
+				gimbal.WriteMessageTo(frm.Channel, &common.MessageGimbalManagerInformation{
+					GimbalDeviceId: 1,
+					PitchMin:       -1,
+				})
+
+			case *common.MessageGimbalManagerSetPitchyaw:
+				pitchyaw <- m
+				gimbal.WriteMessageTo(frm.Channel, &common.MessageGimbalDeviceAttitudeStatus{
+					Q: [4]float32{1, 0, 0, 0},
+				})
+			}
+		}
+	}()
+
+	target := &GimbalTarget{
+		TargetSystem: 1,
+		Timeout:      200 * time.Millisecond,
+	}
+
+	info, err := gcs.GimbalManagerInformation(context.Background(), target)
+	require.NoError(t, err)
+	require.Equal(t, float32(-1), info.(*common.MessageGimbalManagerInformation).PitchMin)
+
+	err = gcs.SetGimbalPitchYaw(target, -0.5, 0.2, float32(math.NaN()), float32(math.NaN()), 0)
+	require.NoError(t, err)
+	m := <-pitchyaw
+	require.Equal(t, float32(-0.5), m.Pitch)
+
+	for frm := range frames {
+		if _, ok := frm.Message().(*common.MessageGimbalDeviceAttitudeStatus); ok {
+			break
+		}
+	}
+
+	att, ok := gcs.GimbalAttitude(1, 1)
+	require.True(t, ok)
+	require.Equal(t, float64(0), att.Pitch)
+}
@@ -2,11 +2,28 @@ package gomavlib
 
 import (
 	"reflect"
-	"time"
 
 	"github.com/aler9/gomavlib/pkg/msg"
 )
 
+// HeartbeatContent is the content of heartbeats emitted by a node.
+type HeartbeatContent struct {
+	// the system type (MAV_TYPE).
+	SystemType int
+
+	// the autopilot type (MAV_AUTOPILOT).
+	AutopilotType int
+
+	// the system mode bitmap (MAV_MODE_FLAG).
+	BaseMode int
+
+	// a bitfield for use for autopilot-specific flags.
+	CustomMode uint32
+
+	// the system status (MAV_STATE).
+	SystemStatus int
+}
+
 type nodeHeartbeat struct {
 	n            *Node
 	msgHeartbeat msg.Message
@@ -20,7 +37,14 @@ type nodeHeartbeat struct {
 
 func newNodeHeartbeat(n *Node) *nodeHeartbeat {
 	// module is disabled
-	if n.conf.HeartbeatDisable {
+	if n.conf.HeartbeatDisable && func() bool {
+		for _, c := range n.components {
+			if !c.conf.HeartbeatDisable {
+				return false
+			}
+		}
+		return true
+	}() {
 		return nil
 	}
 
@@ -61,23 +85,50 @@ func (h *nodeHeartbeat) close() {
 	<-h.done
 }
 
+func heartbeatContent(callback func() HeartbeatContent,
+	systemType int, autopilotType int) HeartbeatContent {
+	if callback != nil {
+		return callback()
+	}
+
+	return HeartbeatContent{
+		SystemType:    systemType,
+		AutopilotType: autopilotType,
+		SystemStatus:  4, // MAV_STATE_ACTIVE
+	}
+}
+
+func (h *nodeHeartbeat) encode(c HeartbeatContent) msg.Message {
+	m := reflect.New(reflect.TypeOf(h.msgHeartbeat).Elem())
+	reflectSetInt(m.Elem().FieldByName("Type"), int64(c.SystemType))
+	reflectSetInt(m.Elem().FieldByName("Autopilot"), int64(c.AutopilotType))
+	reflectSetInt(m.Elem().FieldByName("BaseMode"), int64(c.BaseMode))
+	m.Elem().FieldByName("CustomMode").SetUint(uint64(c.CustomMode))
+	reflectSetInt(m.Elem().FieldByName("SystemStatus"), int64(c.SystemStatus))
+	m.Elem().FieldByName("MavlinkVersion").SetUint(uint64(h.n.conf.Dialect.Version))
+	return m.Interface().(msg.Message)
+}
+
 func (h *nodeHeartbeat) run() {
 	defer close(h.done)
 
-	ticker := time.NewTicker(h.n.conf.HeartbeatPeriod)
+	ticker := h.n.conf.Clock.NewTicker(h.n.conf.HeartbeatPeriod)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
-			m := reflect.New(reflect.TypeOf(h.msgHeartbeat).Elem())
-			m.Elem().FieldByName("Type").SetInt(int64(h.n.conf.HeartbeatSystemType))
-			m.Elem().FieldByName("Autopilot").SetInt(int64(h.n.conf.HeartbeatAutopilotType))
-			m.Elem().FieldByName("BaseMode").SetInt(0)
-			m.Elem().FieldByName("CustomMode").SetUint(0)
-			m.Elem().FieldByName("SystemStatus").SetInt(4) // MAV_STATE_ACTIVE
-			m.Elem().FieldByName("MavlinkVersion").SetUint(uint64(h.n.conf.Dialect.Version))
-			h.n.WriteMessageAll(m.Interface().(msg.Message))
+		case <-ticker.C():
+			if !h.n.conf.HeartbeatDisable {
+				h.n.WriteMessageAll(h.encode(heartbeatContent(h.n.conf.HeartbeatCallback,
+					h.n.conf.HeartbeatSystemType, h.n.conf.HeartbeatAutopilotType)))
+			}
+
+			for _, c := range h.n.components {
+				if !c.conf.HeartbeatDisable {
+					c.WriteMessageAll(h.encode(heartbeatContent(c.conf.HeartbeatCallback,
+						c.conf.HeartbeatSystemType, c.conf.HeartbeatAutopilotType)))
+				}
+			}
 
 		case <-h.terminate:
 			return
@@ -0,0 +1,365 @@
+package gomavlib
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// HighLatencyState is the state of a vehicle, as reported by
+// HIGH_LATENCY2 messages.
+type HighLatencyState struct {
+	// timestamp, in milliseconds since boot or Unix epoch.
+	Timestamp uint32
+
+	// the system type (MAV_TYPE).
+	SystemType int
+
+	// the autopilot type (MAV_AUTOPILOT).
+	AutopilotType int
+
+	// the lower 16 bits of the custom mode.
+	CustomMode uint16
+
+	// latitude, in degrees.
+	Latitude float64
+
+	// longitude, in degrees.
+	Longitude float64
+
+	// altitude above mean sea level, in meters.
+	Altitude float64
+
+	// altitude setpoint, in meters.
+	TargetAltitude float64
+
+	// heading, in degrees.
+	Heading float64
+
+	// heading setpoint, in degrees.
+	TargetHeading float64
+
+	// distance to the target waypoint, in meters.
+	TargetDistance float64
+
+	// throttle, in percent.
+	Throttle float64
+
+	// airspeed, in m/s.
+	Airspeed float64
+
+	// airspeed setpoint, in m/s.
+	AirspeedSetpoint float64
+
+	// groundspeed, in m/s.
+	Groundspeed float64
+
+	// windspeed, in m/s.
+	Windspeed float64
+
+	// wind heading, in degrees.
+	WindHeading float64
+
+	// maximum horizontal position error, in meters.
+	Eph float64
+
+	// maximum vertical position error, in meters.
+	Epv float64
+
+	// air temperature, in degrees Celsius.
+	TemperatureAir float64
+
+	// maximum climb rate magnitude, in m/s.
+	ClimbRate float64
+
+	// battery level, in percent, or -1 if it is not available.
+	Battery float64
+
+	// current waypoint number.
+	WaypointNumber int
+
+	// failure flags (HL_FAILURE_FLAG).
+	FailureFlags int
+}
+
+// HIGH_LATENCY2 fields, with the factor that converts state values
+// into field values.
+var highLatencyFields = []struct {
+	name   string
+	factor float64
+	value  func(s *HighLatencyState) *float64
+}{
+	{"Latitude", 1e7, func(s *HighLatencyState) *float64 { return &s.Latitude }},
+	{"Longitude", 1e7, func(s *HighLatencyState) *float64 { return &s.Longitude }},
+	{"Altitude", 1, func(s *HighLatencyState) *float64 { return &s.Altitude }},
+	{"TargetAltitude", 1, func(s *HighLatencyState) *float64 { return &s.TargetAltitude }},
+	{"Heading", 0.5, func(s *HighLatencyState) *float64 { return &s.Heading }},
+	{"TargetHeading", 0.5, func(s *HighLatencyState) *float64 { return &s.TargetHeading }},
+	{"TargetDistance", 0.1, func(s *HighLatencyState) *float64 { return &s.TargetDistance }},
+	{"Throttle", 1, func(s *HighLatencyState) *float64 { return &s.Throttle }},
+	{"Airspeed", 5, func(s *HighLatencyState) *float64 { return &s.Airspeed }},
+	{"AirspeedSp", 5, func(s *HighLatencyState) *float64 { return &s.AirspeedSetpoint }},
+	{"Groundspeed", 5, func(s *HighLatencyState) *float64 { return &s.Groundspeed }},
+	{"Windspeed", 5, func(s *HighLatencyState) *float64 { return &s.Windspeed }},
+	{"WindHeading", 0.5, func(s *HighLatencyState) *float64 { return &s.WindHeading }},
+	{"Eph", 10, func(s *HighLatencyState) *float64 { return &s.Eph }},
+	{"Epv", 10, func(s *HighLatencyState) *float64 { return &s.Epv }},
+	{"TemperatureAir", 1, func(s *HighLatencyState) *float64 { return &s.TemperatureAir }},
+	{"ClimbRate", 10, func(s *HighLatencyState) *float64 { return &s.ClimbRate }},
+	{"Battery", 1, func(s *HighLatencyState) *float64 { return &s.Battery }},
+}
+
+// reflectNumber returns the value of a numeric field.
+func reflectNumber(v reflect.Value) float64 {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	return 0
+}
+
+// reflectSetNumber sets the value of a numeric field, by rounding it and
+// by limiting it to the range of the field.
+func reflectSetNumber(v reflect.Value, f float64) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		max := math.Ldexp(1, v.Type().Bits()-1) - 1
+		v.SetInt(int64(math.Max(-max-1, math.Min(max, math.Round(f)))))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		max := math.Ldexp(1, v.Type().Bits()) - 1
+		v.SetUint(uint64(math.Max(0, math.Min(max, math.Round(f)))))
+
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(f)
+	}
+}
+
+// normalizeDegrees returns an angle in the range [0, 360).
+func normalizeDegrees(v float64) float64 {
+	v = math.Mod(v, 360)
+	if v < 0 {
+		v += 360
+	}
+	return v
+}
+
+// HighLatencyStateFromMessage converts a HIGH_LATENCY2 message into
+// a HighLatencyState.
+func HighLatencyStateFromMessage(m msg.Message) (*HighLatencyState, error) {
+	if m == nil || m.GetID() != 235 {
+		return nil, fmt.Errorf("message is not a HIGH_LATENCY2")
+	}
+
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct ||
+		!v.Elem().FieldByName("Latitude").IsValid() {
+		return nil, fmt.Errorf("message is not a decoded HIGH_LATENCY2")
+	}
+	v = v.Elem()
+
+	s := &HighLatencyState{
+		Timestamp:      uint32(reflectNumber(v.FieldByName("Timestamp"))),
+		SystemType:     int(reflectNumber(v.FieldByName("Type"))),
+		AutopilotType:  int(reflectNumber(v.FieldByName("Autopilot"))),
+		CustomMode:     uint16(reflectNumber(v.FieldByName("CustomMode"))),
+		WaypointNumber: int(reflectNumber(v.FieldByName("WpNum"))),
+		FailureFlags:   int(reflectNumber(v.FieldByName("FailureFlags"))),
+	}
+
+	for _, f := range highLatencyFields {
+		*f.value(s) = reflectNumber(v.FieldByName(f.name)) / f.factor
+	}
+
+	return s, nil
+}
+
+type nodeHighLatency struct {
+	n               *Node
+	msgHighLatency2 msg.Message
+	mutex           sync.Mutex
+	state           HighLatencyState
+	altError        float64
+	aspdError       float64
+
+	// in
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+// highLatencyMessage is a message that must be written to high latency
+// channels only.
+type highLatencyMessage struct {
+	m msg.Message
+}
+
+func newNodeHighLatency(n *Node) *nodeHighLatency {
+	// module is disabled
+	if !n.conf.HighLatencyEnable {
+		return nil
+	}
+
+	// HIGH_LATENCY2 message must exist in dialect and correspond to standard
+	msgHighLatency2 := dialectMessage(n.conf.Dialect, 235, 179)
+	if msgHighLatency2 == nil {
+		return nil
+	}
+
+	return &nodeHighLatency{
+		n:               n,
+		msgHighLatency2: msgHighLatency2,
+		state: HighLatencyState{
+			Battery: -1,
+		},
+		terminate: make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+func (hl *nodeHighLatency) close() {
+	close(hl.terminate)
+	<-hl.done
+}
+
+func (hl *nodeHighLatency) run() {
+	defer close(hl.done)
+
+	ticker := time.NewTicker(hl.n.conf.HighLatencyPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			select {
+			case hl.n.writeAll <- highLatencyMessage{hl.encode()}:
+			case <-hl.n.ctx.Done():
+			}
+
+		case <-hl.terminate:
+			return
+		}
+	}
+}
+
+// encode returns a HIGH_LATENCY2 message and resets the values that are
+// computed since the last message.
+func (hl *nodeHighLatency) encode() msg.Message {
+	hl.mutex.Lock()
+	defer hl.mutex.Unlock()
+
+	s := hl.state
+	s.TargetAltitude = s.Altitude + hl.altError
+	s.AirspeedSetpoint = s.Airspeed + hl.aspdError
+
+	m := newMessage(hl.msgHighLatency2).Elem()
+	m.FieldByName("Timestamp").SetUint(uint64(s.Timestamp))
+	reflectSetNumber(m.FieldByName("Type"), float64(s.SystemType))
+	reflectSetNumber(m.FieldByName("Autopilot"), float64(s.AutopilotType))
+	m.FieldByName("CustomMode").SetUint(uint64(s.CustomMode))
+	reflectSetNumber(m.FieldByName("WpNum"), float64(s.WaypointNumber))
+	reflectSetNumber(m.FieldByName("FailureFlags"), float64(s.FailureFlags))
+	for _, f := range highLatencyFields {
+		reflectSetNumber(m.FieldByName(f.name), *f.value(&s)*f.factor)
+	}
+
+	hl.state.Eph = 0
+	hl.state.Epv = 0
+	hl.state.ClimbRate = 0
+
+	return m.Addr().Interface().(msg.Message)
+}
+
+func (hl *nodeHighLatency) onEventFrame(evt *EventFrame) {
+	// aggregate telemetry of the system of the node only
+	if evt.SystemID() != hl.n.conf.OutSystemID {
+		return
+	}
+
+	switch evt.messageID() {
+	case 0, 1, 24, 33, 42, 62, 74, 231:
+	default:
+		return
+	}
+
+	v := reflect.ValueOf(evt.Message())
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	v = v.Elem()
+
+	get := func(name string) float64 {
+		f := v.FieldByName(name)
+		if !f.IsValid() {
+			return 0
+		}
+		return reflectNumber(f)
+	}
+
+	hl.mutex.Lock()
+	defer hl.mutex.Unlock()
+
+	s := &hl.state
+
+	switch evt.messageID() {
+	case 0: // HEARTBEAT
+		// use heartbeats of autopilots only
+		if get("Autopilot") == 8 { // MAV_AUTOPILOT_INVALID
+			return
+		}
+		s.SystemType = int(get("Type"))
+		s.AutopilotType = int(get("Autopilot"))
+		s.CustomMode = uint16(get("CustomMode"))
+
+	case 1: // SYS_STATUS
+		s.Battery = get("BatteryRemaining")
+
+	case 24: // GPS_RAW_INT
+		if hAcc := get("HAcc"); hAcc != 0 {
+			s.Eph = math.Max(s.Eph, hAcc/1000)
+		}
+		if vAcc := get("VAcc"); vAcc != 0 {
+			s.Epv = math.Max(s.Epv, vAcc/1000)
+		}
+
+	case 33: // GLOBAL_POSITION_INT
+		s.Timestamp = uint32(get("TimeBootMs"))
+		s.Latitude = get("Lat") / 1e7
+		s.Longitude = get("Lon") / 1e7
+		s.Altitude = get("Alt") / 1000
+		if hdg := get("Hdg"); hdg != math.MaxUint16 {
+			s.Heading = hdg / 100
+		}
+
+	case 42: // MISSION_CURRENT
+		s.WaypointNumber = int(get("Seq"))
+
+	case 62: // NAV_CONTROLLER_OUTPUT
+		s.TargetHeading = normalizeDegrees(get("NavBearing"))
+		s.TargetDistance = get("WpDist")
+		hl.altError = get("AltError")
+		hl.aspdError = get("AspdError")
+
+	case 74: // VFR_HUD
+		s.Airspeed = get("Airspeed")
+		s.Groundspeed = get("Groundspeed")
+		s.Throttle = get("Throttle")
+		s.ClimbRate = math.Max(s.ClimbRate, math.Abs(get("Climb")))
+
+	case 231: // WIND_COV
+		x, y := get("WindX"), get("WindY")
+		s.Windspeed = math.Hypot(x, y)
+		s.WindHeading = normalizeDegrees(math.Atan2(y, x) * 180 / math.Pi)
+	}
+}
@@ -0,0 +1,124 @@
+package gomavlib
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeHighLatency(t *testing.T) {
+	c1, c2 := net.Pipe()
+	c3, c4 := net.Pipe()
+
+	autopilot, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      1,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer autopilot.Close()
+
+	go func() {
+		for range autopilot.Events() {
+		}
+	}()
+
+	companion, err := NewNode(NodeConf{
+		Dialect:        common.Dialect,
+		OutVersion:     V2,
+		OutSystemID:    1,
+		OutComponentID: 191,
+		Endpoints: []EndpointConf{
+			EndpointCustom{c2},
+			EndpointHighLatency{EndpointCustom{c3}},
+		},
+		HeartbeatPeriod:   50 * time.Millisecond,
+		HighLatencyEnable: true,
+		HighLatencyPeriod: 100 * time.Millisecond,
+		RouterEnable:      true,
+	})
+	require.NoError(t, err)
+	defer companion.Close()
+
+	go func() {
+		for range companion.Events() {
+		}
+	}()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      255,
+		Endpoints:        []EndpointConf{EndpointCustom{c4}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	autopilot.WriteMessageAll(&common.MessageHeartbeat{
+		Type:       common.MAV_TYPE_QUADROTOR,
+		Autopilot:  common.MAV_AUTOPILOT_PX4,
+		CustomMode: 0x10004,
+	})
+	autopilot.WriteMessageAll(&common.MessageGlobalPositionInt{
+		TimeBootMs: 1234,
+		Lat:        455000000,
+		Lon:        -92500000,
+		Alt:        120500,
+		Hdg:        27000,
+	})
+	autopilot.WriteMessageAll(&common.MessageVfrHud{
+		Airspeed:    12.2,
+		Groundspeed: 10.4,
+		Throttle:    55,
+		Climb:       -1.5,
+	})
+
+	for evt := range gcs.Events() {
+		fr, ok := evt.(*EventFrame)
+		if !ok {
+			continue
+		}
+
+		// high latency channels receive HIGH_LATENCY2 messages only
+		require.IsType(t, &common.MessageHighLatency2{}, fr.Message())
+
+		s, err := HighLatencyStateFromMessage(fr.Message())
+		require.NoError(t, err)
+
+		if s.Groundspeed == 0 {
+			continue
+		}
+
+		require.Equal(t, byte(1), fr.SystemID())
+		require.Equal(t, &HighLatencyState{
+			Timestamp:        1234,
+			SystemType:       int(common.MAV_TYPE_QUADROTOR),
+			AutopilotType:    int(common.MAV_AUTOPILOT_PX4),
+			CustomMode:       4,
+			Latitude:         45.5,
+			Longitude:        -9.25,
+			Altitude:         121,
+			TargetAltitude:   121,
+			Heading:          270,
+			Throttle:         55,
+			Airspeed:         12.2,
+			AirspeedSetpoint: 12.2,
+			Groundspeed:      10.4,
+			ClimbRate:        1.5,
+			Battery:          -1,
+		}, s)
+		break
+	}
+}
+
+func TestHighLatencyStateFromMessageErrors(t *testing.T) {
+	_, err := HighLatencyStateFromMessage(&common.MessageHeartbeat{})
+	require.EqualError(t, err, "message is not a HIGH_LATENCY2")
+}
@@ -0,0 +1,31 @@
+package gomavlib
+
+import (
+	"fmt"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+// mergeKeys returns the keys used to validate incoming frames.
+func mergeKeys(inKey *frame.V2Key, inKeys []*frame.V2Key) []*frame.V2Key {
+	var out []*frame.V2Key
+	if inKey != nil {
+		out = append(out, inKey)
+	}
+	return append(out, inKeys...)
+}
+
+// SetKeys changes, at once, the keys used to validate incoming frames, that
+// replace InKey and InKeys, and the key used to sign outgoing frames, that
+// replaces OutKey, without closing channels. Channels of endpoints with
+// dedicated keys (EndpointSigned) are not affected.
+// Signature timestamps are kept, therefore frames received before the change
+// cannot be replayed and outgoing timestamps remain monotonic.
+func (n *Node) SetKeys(inKeys []*frame.V2Key, outKey *frame.V2Key) error {
+	if outKey != nil && n.conf.OutVersion != V2 {
+		return fmt.Errorf("OutKey requires V2 frames")
+	}
+
+	n.keys.Set(inKeys, outKey)
+	return nil
+}
@@ -0,0 +1,109 @@
+package gomavlib
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestNodeSetKeys(t *testing.T) {
+	key1 := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+	key2 := frame.NewV2Key(bytes.Repeat([]byte("\xA8"), 32))
+
+	c1, c2 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		InKey:            key1,
+		OutKey:           key1,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+		InKey:            key1,
+		OutKey:           key1,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	readFrame := func() *EventFrame {
+		for evt := range node1.Events() {
+			switch tevt := evt.(type) {
+			case *EventFrame:
+				return tevt
+
+			case *EventParseError:
+				require.EqualError(t, tevt.Error, "wrong signature")
+				return nil
+			}
+		}
+		return nil
+	}
+
+	node2.WriteMessageAll(&MessageHeartbeat{Type: 1})
+	evt := readFrame()
+	require.NotNil(t, evt)
+	require.Equal(t, key1, evt.Key)
+
+	// the old key is still accepted during the rotation
+	err = node1.SetKeys([]*frame.V2Key{key1, key2}, key2)
+	require.NoError(t, err)
+
+	node2.WriteMessageAll(&MessageHeartbeat{Type: 2})
+	evt = readFrame()
+	require.NotNil(t, evt)
+	require.Equal(t, key1, evt.Key)
+
+	err = node2.SetKeys([]*frame.V2Key{key2}, key2)
+	require.NoError(t, err)
+
+	node2.WriteMessageAll(&MessageHeartbeat{Type: 3})
+	evt = readFrame()
+	require.NotNil(t, evt)
+	require.Equal(t, key2, evt.Key)
+
+	// the old key is not accepted anymore
+	err = node1.SetKeys([]*frame.V2Key{key2}, key2)
+	require.NoError(t, err)
+
+	err = node2.SetKeys(nil, key1)
+	require.NoError(t, err)
+
+	node2.WriteMessageAll(&MessageHeartbeat{Type: 4})
+	require.Nil(t, readFrame())
+}
+
+func TestNodeSetKeysErrors(t *testing.T) {
+	node, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V1,
+		OutSystemID:      10,
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	err = node.SetKeys(nil, frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32)))
+	require.EqualError(t, err, "OutKey requires V2 frames")
+}
@@ -0,0 +1,350 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// LogEntry is an entry of the list of the logs stored by a target.
+type LogEntry struct {
+	// the log id.
+	ID uint16
+
+	// the UTC timestamp of the log, in seconds since 1970, or 0 if unavailable.
+	TimeUTC uint32
+
+	// the size of the log in bytes.
+	Size uint32
+}
+
+// LogTransfer contains the parameters of a log listing or download.
+type LogTransfer struct {
+	// (optional) the channel used to communicate with the target.
+	// It defaults to all channels.
+	Channel *Channel
+
+	// the system id of the target.
+	TargetSystem byte
+
+	// (optional) the component id of the target.
+	// It defaults to 0, that means all components.
+	TargetComponent byte
+
+	// (optional) the time to wait for a response before sending
+	// a request again. It defaults to 1 second.
+	Timeout time.Duration
+
+	// (optional) the maximum number of times a request is sent
+	// without receiving any response. It defaults to 3.
+	Attempts int
+}
+
+// logRange is a range of bytes [start, end).
+type logRange struct {
+	start uint32
+	end   uint32
+}
+
+// logRanges is a sorted list of non-overlapping ranges.
+type logRanges []logRange
+
+func (lr *logRanges) add(start uint32, end uint32) {
+	*lr = append(*lr, logRange{start, end})
+	sort.Slice(*lr, func(i, j int) bool {
+		return (*lr)[i].start < (*lr)[j].start
+	})
+
+	merged := (*lr)[:1]
+	for _, r := range (*lr)[1:] {
+		last := &merged[len(merged)-1]
+		if r.start <= last.end {
+			if r.end > last.end {
+				last.end = r.end
+			}
+		} else {
+			merged = append(merged, r)
+		}
+	}
+	*lr = merged
+}
+
+// gaps returns the ranges between start and end that are not in the list.
+func (lr logRanges) gaps(start uint32, end uint32) []logRange {
+	var ret []logRange
+	cur := start
+
+	for _, r := range lr {
+		if r.end <= cur {
+			continue
+		}
+		if r.start >= end {
+			break
+		}
+		if r.start > cur {
+			ret = append(ret, logRange{cur, r.start})
+		}
+		cur = r.end
+	}
+
+	if cur < end {
+		ret = append(ret, logRange{cur, end})
+	}
+
+	return ret
+}
+
+type nodeLog struct {
+	n                 *Node
+	msgLogRequestList msg.Message
+	msgLogRequestData msg.Message
+	msgLogRequestEnd  msg.Message
+	msgLogEntry       msg.Message
+	msgLogData        msg.Message
+}
+
+func newNodeLog(n *Node) *nodeLog {
+	// log messages must exist in dialect and correspond to standard
+	msgLogRequestList := dialectMessage(n.conf.Dialect, 117, 128)
+	msgLogEntry := dialectMessage(n.conf.Dialect, 118, 56)
+	msgLogRequestData := dialectMessage(n.conf.Dialect, 119, 116)
+	msgLogData := dialectMessage(n.conf.Dialect, 120, 134)
+	msgLogRequestEnd := dialectMessage(n.conf.Dialect, 122, 203)
+	if msgLogRequestList == nil || msgLogEntry == nil || msgLogRequestData == nil ||
+		msgLogData == nil || msgLogRequestEnd == nil {
+		return nil
+	}
+
+	return &nodeLog{
+		n:                 n,
+		msgLogRequestList: msgLogRequestList,
+		msgLogRequestData: msgLogRequestData,
+		msgLogRequestEnd:  msgLogRequestEnd,
+		msgLogEntry:       msgLogEntry,
+		msgLogData:        msgLogData,
+	}
+}
+
+func (nl *nodeLog) fillTransfer(t *LogTransfer) *LogTransfer {
+	tc := *t
+	if tc.Timeout == 0 {
+		tc.Timeout = 1 * time.Second
+	}
+	if tc.Attempts == 0 {
+		tc.Attempts = 3
+	}
+	return &tc
+}
+
+// subscribe subscribes to the messages with given ID sent by the target.
+func (nl *nodeLog) subscribe(t *LogTransfer, id uint32) *frameSubscriber {
+	return nl.n.frameSubscribers.subscribe(func(evt *EventFrame) bool {
+		if evt.messageID() != id {
+			return false
+		}
+
+		if t.Channel != nil && evt.Channel != t.Channel {
+			return false
+		}
+
+		return evt.SystemID() == t.TargetSystem &&
+			(t.TargetComponent == 0 || evt.ComponentID() == t.TargetComponent)
+	})
+}
+
+func (nl *nodeLog) newMessage(t *LogTransfer, tmpl msg.Message) msg.Message {
+	m := newMessage(tmpl).Elem()
+	m.FieldByName("TargetSystem").SetUint(uint64(t.TargetSystem))
+	m.FieldByName("TargetComponent").SetUint(uint64(t.TargetComponent))
+	return m.Addr().Interface().(msg.Message)
+}
+
+func (nl *nodeLog) newRequestList(t *LogTransfer, start uint16, end uint16) msg.Message {
+	out := nl.newMessage(t, nl.msgLogRequestList)
+	msgValue(out).FieldByName("Start").SetUint(uint64(start))
+	msgValue(out).FieldByName("End").SetUint(uint64(end))
+	return out
+}
+
+func (nl *nodeLog) list(ctx context.Context, t *LogTransfer) ([]*LogEntry, error) {
+	sub := nl.subscribe(t, 118)
+	defer nl.n.frameSubscribers.unsubscribe(sub)
+
+	entries := make(map[uint16]*LogEntry)
+	numLogs := -1
+	var lastLogNum uint16
+
+	for {
+		evt, err := nl.n.transact(ctx, sub, &Transaction{
+			Channel: t.Channel,
+			RequestFunc: func(attempt int) msg.Message {
+				// request the whole list if no entry was received
+				if numLogs < 0 {
+					return nl.newRequestList(t, 0, 0xFFFF)
+				}
+
+				// entries of the previous request are still being received
+				if attempt == 0 {
+					return nil
+				}
+
+				// request the range of the missing entries
+				firstLogNum := lastLogNum - uint16(numLogs) + 1
+				start, end := lastLogNum, firstLogNum
+				for i := 0; i < numLogs; i++ {
+					id := firstLogNum + uint16(i)
+					if _, ok := entries[id]; !ok {
+						if id < start {
+							start = id
+						}
+						if id > end {
+							end = id
+						}
+					}
+				}
+				return nl.newRequestList(t, start, end)
+			},
+			Timeout:  t.Timeout,
+			Attempts: t.Attempts,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		m := msgValue(evt.Message())
+		numLogs = int(m.FieldByName("NumLogs").Uint())
+		lastLogNum = uint16(m.FieldByName("LastLogNum").Uint())
+
+		// target has no logs
+		if numLogs == 0 {
+			return nil, nil
+		}
+
+		id := uint16(m.FieldByName("Id").Uint())
+		entries[id] = &LogEntry{
+			ID:      id,
+			TimeUTC: uint32(m.FieldByName("TimeUtc").Uint()),
+			Size:    uint32(m.FieldByName("Size").Uint()),
+		}
+
+		if len(entries) >= numLogs {
+			break
+		}
+	}
+
+	ret := make([]*LogEntry, 0, len(entries))
+	for _, e := range entries {
+		ret = append(ret, e)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID < ret[j].ID
+	})
+	return ret, nil
+}
+
+func (nl *nodeLog) newRequestData(t *LogTransfer, id uint16, r logRange) msg.Message {
+	out := nl.newMessage(t, nl.msgLogRequestData)
+	msgValue(out).FieldByName("Id").SetUint(uint64(id))
+	msgValue(out).FieldByName("Ofs").SetUint(uint64(r.start))
+	msgValue(out).FieldByName("Count").SetUint(uint64(r.end - r.start))
+	return out
+}
+
+func (nl *nodeLog) download(ctx context.Context, t *LogTransfer, entry *LogEntry,
+	offset uint32, w io.WriterAt) error {
+	sub := nl.subscribe(t, 120)
+	defer nl.n.frameSubscribers.unsubscribe(sub)
+
+	defer nl.n.writeMessageToOrAll(t.Channel, nl.newMessage(t, nl.msgLogRequestEnd))
+
+	var received logRanges
+	end := entry.Size
+	requested := false
+
+	for {
+		gaps := received.gaps(offset, end)
+		if len(gaps) == 0 {
+			return nil
+		}
+
+		evt, err := nl.n.transact(ctx, sub, &Transaction{
+			Channel: t.Channel,
+			RequestFunc: func(attempt int) msg.Message {
+				// data of the previous request is still being received
+				if attempt == 0 && requested {
+					return nil
+				}
+				requested = true
+
+				// request the first missing range
+				return nl.newRequestData(t, entry.ID, gaps[0])
+			},
+			Match: func(evt *EventFrame) bool {
+				return uint16(msgValue(evt.Message()).FieldByName("Id").Uint()) == entry.ID
+			},
+			Timeout:  t.Timeout,
+			Attempts: t.Attempts,
+		})
+		if err != nil {
+			return err
+		}
+
+		m := msgValue(evt.Message())
+		ofs := uint32(m.FieldByName("Ofs").Uint())
+		count := uint32(m.FieldByName("Count").Uint())
+
+		// end of log
+		if count == 0 {
+			if ofs < end {
+				end = ofs
+			}
+			continue
+		}
+
+		if ofs+count > end {
+			if ofs >= end {
+				continue
+			}
+			count = end - ofs
+		}
+
+		data := m.FieldByName("Data").Slice(0, int(count)).Bytes()
+		_, err = w.WriteAt(data, int64(ofs))
+		if err != nil {
+			return err
+		}
+
+		received.add(ofs, ofs+count)
+	}
+}
+
+// ListLogs returns the list of the logs stored by a target.
+// The dialect must contain the messages of the log protocol
+// (LOG_REQUEST_LIST, LOG_ENTRY, LOG_REQUEST_DATA, LOG_DATA and LOG_REQUEST_END).
+// Events() must be read in a separate routine, otherwise the responses
+// of the target can't be received.
+func (n *Node) ListLogs(ctx context.Context, t *LogTransfer) ([]*LogEntry, error) {
+	if n.nodeLog == nil {
+		return nil, fmt.Errorf("dialect does not support logs")
+	}
+	return n.nodeLog.list(ctx, n.nodeLog.fillTransfer(t))
+}
+
+// DownloadLog downloads a log stored by a target and writes it into w.
+// Missing parts are detected and requested again. Offset allows to resume
+// a previous download, and must be set to 0 to download the whole log.
+// The dialect must contain the messages of the log protocol
+// (LOG_REQUEST_LIST, LOG_ENTRY, LOG_REQUEST_DATA, LOG_DATA and LOG_REQUEST_END).
+// Events() must be read in a separate routine, otherwise the responses
+// of the target can't be received.
+func (n *Node) DownloadLog(ctx context.Context, t *LogTransfer, entry *LogEntry,
+	offset uint32, w io.WriterAt) error {
+	if n.nodeLog == nil {
+		return fmt.Errorf("dialect does not support logs")
+	}
+	return n.nodeLog.download(ctx, n.nodeLog.fillTransfer(t), entry, offset, w)
+}
@@ -0,0 +1,115 @@
+package gomavlib
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+type testWriterAt struct {
+	buf []byte
+}
+
+func (w *testWriterAt) WriteAt(p []byte, off int64) (int, error) {
+	if need := int(off) + len(p); need > len(w.buf) {
+		w.buf = append(w.buf, make([]byte, need-len(w.buf))...)
+	}
+	copy(w.buf[off:], p)
+	return len(p), nil
+}
+
+func TestLogRanges(t *testing.T) {
+	var lr logRanges
+	lr.add(10, 20)
+	lr.add(30, 40)
+	lr.add(15, 25)
+	require.Equal(t, logRanges{{10, 25}, {30, 40}}, lr)
+	require.Equal(t, []logRange{{0, 10}, {25, 30}, {40, 50}}, lr.gaps(0, 50))
+	require.Equal(t, []logRange(nil), lr.gaps(12, 22))
+
+	lr.add(25, 30)
+	require.Equal(t, logRanges{{10, 40}}, lr)
+}
+
+func TestNodeLog(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	logData := make([]byte, 1000)
+	for i := range logData {
+		logData[i] = byte(i)
+	}
+
+	// simulate a vehicle that stores two logs and loses some data
+	go func() {
+		lost := false
+
+		for evt := range vehicle.Events() {
+			frm, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			switch m := frm.Message().(type) {
+			case *common.MessageLogRequestList:
+				for id := uint16(1); id <= 2; id++ {
+					if id >= m.Start && id <= m.End {
+						vehicle.WriteMessageTo(frm.Channel, &common.MessageLogEntry{
+							Id:         id,
+							NumLogs:    2,
+							LastLogNum: 2,
+							Size:       uint32(len(logData)) * uint32(id),
+						})
+					}
+				}
+
+			case *common.MessageLogRequestData:
+				for ofs := m.Ofs; ofs < m.Ofs+m.Count && ofs < uint32(len(logData)); ofs += 90 {
+					// lose the second chunk of the first request
+					if ofs == 90 && !lost {
+						lost = true
+						continue
+					}
+
+					out := &common.MessageLogData{
+						Id:  m.Id,
+						Ofs: ofs,
+					}
+					out.Count = uint8(copy(out.Data[:], logData[ofs:]))
+					if uint32(out.Count) > m.Ofs+m.Count-ofs {
+						out.Count = uint8(m.Ofs + m.Count - ofs)
+					}
+					vehicle.WriteMessageTo(frm.Channel, out)
+				}
+			}
+		}
+	}()
+
+	transfer := &LogTransfer{
+		TargetSystem: 1,
+		Timeout:      200 * time.Millisecond,
+	}
+
+	entries, err := gcs.ListLogs(context.Background(), transfer)
+	require.NoError(t, err)
+	require.Equal(t, []*LogEntry{
+		{ID: 1, Size: 1000},
+		{ID: 2, Size: 2000},
+	}, entries)
+
+	var w testWriterAt
+	err = gcs.DownloadLog(context.Background(), transfer, entries[0], 0, &w)
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(logData, w.buf))
+}
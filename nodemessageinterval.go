@@ -0,0 +1,121 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type messageIntervalKey struct {
+	systemID  byte
+	messageID uint32
+}
+
+type nodeMessageInterval struct {
+	n              *Node
+	mutex          sync.Mutex
+	intervals      map[messageIntervalKey]float32
+	lastHeartbeats map[byte]time.Time
+	wg             sync.WaitGroup
+}
+
+func newNodeMessageInterval(n *Node) *nodeMessageInterval {
+	// commands and heartbeats must be supported
+	if n.nodeCommand == nil || dialectMessage(n.conf.Dialect, 0, 50) == nil {
+		return nil
+	}
+
+	return &nodeMessageInterval{
+		n:              n,
+		intervals:      make(map[messageIntervalKey]float32),
+		lastHeartbeats: make(map[byte]time.Time),
+	}
+}
+
+func (mi *nodeMessageInterval) close() {
+	mi.wg.Wait()
+}
+
+func (mi *nodeMessageInterval) send(ctx context.Context, systemID byte,
+	messageID uint32, interval float32) error {
+	return mi.n.nodeCommand.sendAccepted(ctx, &CommandRequest{
+		TargetSystem: systemID,
+		Command:      commandSetMessageInterval,
+		Params:       [7]float32{float32(messageID), interval},
+	})
+}
+
+func (mi *nodeMessageInterval) request(ctx context.Context, systemID byte,
+	messageID uint32, frequency float64) error {
+	var interval float32
+	switch {
+	case frequency < 0:
+		interval = -1
+
+	case frequency > 0:
+		interval = float32(1000000 / frequency)
+	}
+
+	func() {
+		mi.mutex.Lock()
+		defer mi.mutex.Unlock()
+
+		k := messageIntervalKey{systemID, messageID}
+		if interval == 0 {
+			delete(mi.intervals, k)
+		} else {
+			mi.intervals[k] = interval
+		}
+	}()
+
+	return mi.send(ctx, systemID, messageID, interval)
+}
+
+func (mi *nodeMessageInterval) onEventFrame(evt *EventFrame) {
+	if evt.messageID() != 0 {
+		return
+	}
+
+	mi.mutex.Lock()
+	defer mi.mutex.Unlock()
+
+	now := mi.n.conf.Clock.Now()
+	last, ok := mi.lastHeartbeats[evt.SystemID()]
+	mi.lastHeartbeats[evt.SystemID()] = now
+
+	// intervals are applied again when heartbeats are interrupted, that is
+	// when the link has been re-established or the system has been rebooted
+	if !ok || now.Sub(last) < mi.n.conf.SystemTimeout {
+		return
+	}
+
+	for k, interval := range mi.intervals {
+		if k.systemID != evt.SystemID() {
+			continue
+		}
+
+		mi.wg.Add(1)
+		go func(k messageIntervalKey, interval float32) {
+			defer mi.wg.Done()
+			mi.send(mi.n.ctx, k.systemID, k.messageID, interval) //nolint:errcheck
+		}(k, interval)
+	}
+}
+
+// RequestMessageInterval sets the frequency of a message emitted by a system,
+// by sending a MAV_CMD_SET_MESSAGE_INTERVAL command and waiting for its
+// acknowledgement. The frequency is expressed in Hz. A negative frequency
+// disables the message, while a zero frequency restores the default one.
+// The frequency is set again automatically when the system reconnects or
+// reboots, that is when it does not send heartbeats for SystemTimeout.
+// The dialect must contain the HEARTBEAT, COMMAND_LONG, COMMAND_INT and
+// COMMAND_ACK messages. Events() must be read in a separate routine,
+// otherwise the acknowledgement can't be received.
+func (n *Node) RequestMessageInterval(ctx context.Context, systemID byte,
+	messageID uint32, frequency float64) error {
+	if n.nodeMessageInterval == nil {
+		return fmt.Errorf("dialect does not support message intervals")
+	}
+	return n.nodeMessageInterval.request(ctx, systemID, messageID, frequency)
+}
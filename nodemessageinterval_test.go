@@ -0,0 +1,75 @@
+package gomavlib
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeRequestMessageInterval(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      255,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		SystemTimeout:    300 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	vehicle, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      1,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	received := make(chan *common.MessageCommandLong, 10)
+	go func() {
+		for evt := range vehicle.Events() {
+			if frm, ok := evt.(*EventFrame); ok {
+				if m, ok := frm.Message().(*common.MessageCommandLong); ok &&
+					m.Command == common.MAV_CMD_SET_MESSAGE_INTERVAL {
+					received <- m
+					vehicle.WriteMessageTo(frm.Channel, &common.MessageCommandAck{
+						Command: m.Command,
+						Result:  common.MAV_RESULT_ACCEPTED,
+					})
+				}
+			}
+		}
+	}()
+
+	err = gcs.RequestMessageInterval(context.Background(), 1, 33, 10)
+	require.NoError(t, err)
+
+	m := <-received
+	require.Equal(t, float32(33), m.Param1)
+	require.Equal(t, float32(100000), m.Param2)
+
+	vehicle.WriteMessageAll(&common.MessageHeartbeat{})
+
+	// the vehicle reboots: the interval is set again
+	time.Sleep(400 * time.Millisecond)
+	vehicle.WriteMessageAll(&common.MessageHeartbeat{})
+
+	m = <-received
+	require.Equal(t, float32(33), m.Param1)
+	require.Equal(t, float32(100000), m.Param2)
+}
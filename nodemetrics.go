@@ -0,0 +1,218 @@
+package gomavlib
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// systems that didn't send a heartbeat within this period are
+	// considered not present
+	metricsHeartbeatTimeout = 10 * time.Second
+)
+
+var metricsLabelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+type metricsHeartbeatKey struct {
+	systemID    byte
+	componentID byte
+}
+
+type nodeMetrics struct {
+	n          *Node
+	server     *http.Server
+	mutex      sync.Mutex
+	channels   map[*Channel]struct{}
+	messages   map[uint32]uint64
+	heartbeats map[metricsHeartbeatKey]time.Time
+
+	// out
+	done chan struct{}
+}
+
+func newNodeMetrics(n *Node) (*nodeMetrics, error) {
+	// module is disabled
+	if !n.conf.MetricsEnable {
+		return nil, nil
+	}
+
+	nm := &nodeMetrics{
+		n:          n,
+		channels:   make(map[*Channel]struct{}),
+		messages:   make(map[uint32]uint64),
+		heartbeats: make(map[metricsHeartbeatKey]time.Time),
+		done:       make(chan struct{}),
+	}
+
+	if n.conf.MetricsAddress != "" {
+		ln, err := net.Listen("tcp", n.conf.MetricsAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		nm.server = &http.Server{Handler: nm}
+		go nm.run(ln)
+	} else {
+		close(nm.done)
+	}
+
+	return nm, nil
+}
+
+func (nm *nodeMetrics) close() {
+	if nm.server != nil {
+		nm.server.Close()
+	}
+	<-nm.done
+}
+
+func (nm *nodeMetrics) run(ln net.Listener) {
+	defer close(nm.done)
+	nm.server.Serve(ln)
+}
+
+func (nm *nodeMetrics) onChannelOpen(ch *Channel) {
+	nm.mutex.Lock()
+	defer nm.mutex.Unlock()
+	nm.channels[ch] = struct{}{}
+}
+
+func (nm *nodeMetrics) onChannelClose(ch *Channel) {
+	nm.mutex.Lock()
+	defer nm.mutex.Unlock()
+	delete(nm.channels, ch)
+}
+
+func (nm *nodeMetrics) onEventFrame(evt *EventFrame) {
+	nm.mutex.Lock()
+	defer nm.mutex.Unlock()
+
+	id := evt.messageID()
+	nm.messages[id]++
+
+	// message is a HEARTBEAT
+	if id == 0 {
+		nm.heartbeats[metricsHeartbeatKey{evt.SystemID(), evt.ComponentID()}] = time.Now()
+	}
+}
+
+func writeMetric(buf *bytes.Buffer, name string, typ string, help string) {
+	fmt.Fprintf(buf, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(buf, "# TYPE %s %s\n", name, typ)
+}
+
+func writeStats(buf *bytes.Buffer, prefix string, labels []string, stats []Stats) {
+	for _, entry := range []struct {
+		name  string
+		help  string
+		value func(Stats) uint64
+	}{
+		{"bytes_in_total", "Received bytes.", func(s Stats) uint64 { return s.BytesIn }},
+		{"bytes_out_total", "Sent bytes.", func(s Stats) uint64 { return s.BytesOut }},
+		{"frames_in_total", "Received frames.", func(s Stats) uint64 { return s.FramesIn }},
+		{"frames_out_total", "Sent frames.", func(s Stats) uint64 { return s.FramesOut }},
+		{"parse_errors_total", "Frames that could not be parsed.", func(s Stats) uint64 { return s.ParseErrors }},
+		{"checksum_errors_total", "Frames with a wrong checksum.", func(s Stats) uint64 { return s.ChecksumErrors }},
+		{"dropped_writes_total", "Frames that could not be written.", func(s Stats) uint64 { return s.DroppedWrites }},
+		{"filtered_frames_total", "Received frames that were discarded by filters.", func(s Stats) uint64 { return s.FilteredFrames }},
+		{"lost_frames_total", "Frames that were lost, detected through gaps in sequence numbers.", func(s Stats) uint64 { return s.LostFrames }},
+		{"sequence_restarts_total", "Gaps in sequence numbers caused by senders that restarted.", func(s Stats) uint64 { return s.SequenceRestarts }},
+	} {
+		name := prefix + entry.name
+		writeMetric(buf, name, "counter", entry.help)
+		for i, s := range stats {
+			fmt.Fprintf(buf, "%s%s %d\n", name, labels[i], entry.value(s))
+		}
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (nm *nodeMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+
+	nm.mutex.Lock()
+
+	channels := make([]*Channel, 0, len(nm.channels))
+	for ch := range nm.channels {
+		channels = append(channels, ch)
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].label < channels[j].label
+	})
+
+	ids := make([]uint32, 0, len(nm.messages))
+	for id := range nm.messages {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	hkeys := make([]metricsHeartbeatKey, 0, len(nm.heartbeats))
+	for k := range nm.heartbeats {
+		hkeys = append(hkeys, k)
+	}
+	sort.Slice(hkeys, func(i, j int) bool {
+		if hkeys[i].systemID != hkeys[j].systemID {
+			return hkeys[i].systemID < hkeys[j].systemID
+		}
+		return hkeys[i].componentID < hkeys[j].componentID
+	})
+
+	// node statistics
+	ns := nm.n.Stats()
+	writeStats(&buf, "gomavlib_", []string{""}, []Stats{ns})
+	writeMetric(&buf, "gomavlib_dropped_events_total", "counter",
+		"Events that were discarded because the event queue was full.")
+	fmt.Fprintf(&buf, "gomavlib_dropped_events_total %d\n", ns.DroppedEvents)
+
+	// channel statistics
+	labels := make([]string, len(channels))
+	stats := make([]Stats, len(channels))
+	for i, ch := range channels {
+		labels[i] = fmt.Sprintf("{channel=\"%s\"}", metricsLabelReplacer.Replace(ch.label))
+		stats[i] = ch.Stats()
+	}
+	writeStats(&buf, "gomavlib_channel_", labels, stats)
+
+	// message counters
+	writeMetric(&buf, "gomavlib_messages_in_total", "counter", "Received messages, by message ID.")
+	for _, id := range ids {
+		fmt.Fprintf(&buf, "gomavlib_messages_in_total{message_id=\"%d\"} %d\n", id, nm.messages[id])
+	}
+
+	// heartbeat presence
+	now := time.Now()
+	writeMetric(&buf, "gomavlib_heartbeat_present", "gauge",
+		"Whether a heartbeat has been received recently from a system and component.")
+	for _, k := range hkeys {
+		present := 0
+		if now.Sub(nm.heartbeats[k]) <= metricsHeartbeatTimeout {
+			present = 1
+		}
+		fmt.Fprintf(&buf, "gomavlib_heartbeat_present{system_id=\"%d\",component_id=\"%d\"} %d\n",
+			k.systemID, k.componentID, present)
+	}
+
+	nm.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(buf.Bytes())
+}
+
+// MetricsHandler returns a http.Handler that exposes the metrics of the
+// node in the Prometheus text format, that include statistics of the node
+// and of its channels, counters of received messages and the presence of
+// heartbeats of other systems. It requires MetricsEnable to be true.
+func (n *Node) MetricsHandler() http.Handler {
+	if n.nodeMetrics == nil {
+		return http.NotFoundHandler()
+	}
+	return n.nodeMetrics
+}
@@ -0,0 +1,95 @@
+package gomavlib
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestNodeMetrics(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		MetricsEnable:    true,
+		MetricsAddress:   "localhost:9931",
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	node2.WriteMessageAll(&MessageHeartbeat{
+		Type:           1,
+		Autopilot:      2,
+		BaseMode:       3,
+		CustomMode:     6,
+		SystemStatus:   4,
+		MavlinkVersion: 5,
+	})
+
+	for evt := range node1.Events() {
+		if _, ok := evt.(*EventFrame); ok {
+			break
+		}
+	}
+
+	w := httptest.NewRecorder()
+	node1.MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+
+	require.Contains(t, body, "# TYPE gomavlib_frames_in_total counter\n")
+	require.Contains(t, body, "gomavlib_frames_in_total 1\n")
+	require.Contains(t, body, "gomavlib_channel_frames_in_total{channel=\"custom\"} 1\n")
+	require.Contains(t, body, "gomavlib_messages_in_total{message_id=\"0\"} 1\n")
+	require.Contains(t, body, "gomavlib_heartbeat_present{system_id=\"11\",component_id=\"1\"} 1\n")
+
+	res, err := http.Get("http://localhost:9931/metrics")
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	byts, err := ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(byts), "# HELP gomavlib_bytes_in_total "))
+}
+
+func TestNodeMetricsDisabled(t *testing.T) {
+	node, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	w := httptest.NewRecorder()
+	node.MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
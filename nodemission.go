@@ -0,0 +1,304 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	missionResultAccepted = 0 // MAV_MISSION_ACCEPTED
+)
+
+// MissionItem is an item of a mission.
+type MissionItem struct {
+	// the coordinate system of the item (MAV_FRAME).
+	Frame int
+
+	// the command of the item (MAV_CMD).
+	Command int
+
+	// whether the item is the current one.
+	Current bool
+
+	// whether to continue to the next item when the item is completed.
+	Autocontinue bool
+
+	// the parameters of the command.
+	Params [4]float32
+
+	// the X coordinate (usually latitude * 10^7).
+	X int32
+
+	// the Y coordinate (usually longitude * 10^7).
+	Y int32
+
+	// the Z coordinate (usually altitude).
+	Z float32
+}
+
+// MissionTransfer contains the parameters of a mission upload or download.
+type MissionTransfer struct {
+	// (optional) the channel used to communicate with the target.
+	// It defaults to all channels.
+	Channel *Channel
+
+	// the system id of the target.
+	TargetSystem byte
+
+	// (optional) the component id of the target.
+	// It defaults to 0, that means all components.
+	TargetComponent byte
+
+	// (optional) the mission type (MAV_MISSION_TYPE).
+	// It defaults to MAV_MISSION_TYPE_MISSION.
+	MissionType int
+
+	// (optional) the time to wait for a response before sending
+	// a message again. It defaults to 1.5 seconds.
+	Timeout time.Duration
+
+	// (optional) the maximum number of times a message is sent.
+	// It defaults to 5.
+	Attempts int
+}
+
+type nodeMission struct {
+	n                     *Node
+	msgMissionRequestList msg.Message
+	msgMissionCount       msg.Message
+	msgMissionRequestInt  msg.Message
+	msgMissionItemInt     msg.Message
+	msgMissionAck         msg.Message
+}
+
+func newNodeMission(n *Node) *nodeMission {
+	// mission messages must exist in dialect and correspond to standard
+	msgMissionRequestList := dialectMessage(n.conf.Dialect, 43, 132)
+	msgMissionCount := dialectMessage(n.conf.Dialect, 44, 221)
+	msgMissionRequestInt := dialectMessage(n.conf.Dialect, 51, 196)
+	msgMissionItemInt := dialectMessage(n.conf.Dialect, 73, 38)
+	msgMissionAck := dialectMessage(n.conf.Dialect, 47, 153)
+	if msgMissionRequestList == nil || msgMissionCount == nil || msgMissionRequestInt == nil ||
+		msgMissionItemInt == nil || msgMissionAck == nil {
+		return nil
+	}
+
+	return &nodeMission{
+		n:                     n,
+		msgMissionRequestList: msgMissionRequestList,
+		msgMissionCount:       msgMissionCount,
+		msgMissionRequestInt:  msgMissionRequestInt,
+		msgMissionItemInt:     msgMissionItemInt,
+		msgMissionAck:         msgMissionAck,
+	}
+}
+
+// subscribe subscribes to the mission messages sent by the target to the node.
+func (nm *nodeMission) subscribe(t *MissionTransfer, ids ...uint32) *frameSubscriber {
+	return nm.n.frameSubscribers.subscribe(func(evt *EventFrame) bool {
+		ok := false
+		for _, id := range ids {
+			if evt.messageID() == id {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+
+		// message is not in the dialect
+		if _, ok := evt.Message().(*msg.MessageRaw); ok {
+			return false
+		}
+
+		if t.Channel != nil && evt.Channel != t.Channel {
+			return false
+		}
+
+		if evt.SystemID() != t.TargetSystem ||
+			(t.TargetComponent != 0 && evt.ComponentID() != t.TargetComponent) {
+			return false
+		}
+
+		m := msgValue(evt.Message())
+
+		if ts := byte(m.FieldByName("TargetSystem").Uint()); ts != 0 && ts != nm.n.conf.OutSystemID {
+			return false
+		}
+
+		return int(reflectInt(m.FieldByName("MissionType"))) == t.MissionType
+	})
+}
+
+func (nm *nodeMission) newMessage(t *MissionTransfer, tmpl msg.Message) msg.Message {
+	m := newMessage(tmpl).Elem()
+	m.FieldByName("TargetSystem").SetUint(uint64(t.TargetSystem))
+	m.FieldByName("TargetComponent").SetUint(uint64(t.TargetComponent))
+	reflectSetInt(m.FieldByName("MissionType"), int64(t.MissionType))
+	return m.Addr().Interface().(msg.Message)
+}
+
+func (nm *nodeMission) encodeItem(t *MissionTransfer, seq int, item *MissionItem) msg.Message {
+	ret := nm.newMessage(t, nm.msgMissionItemInt)
+	m := msgValue(ret)
+	m.FieldByName("Seq").SetUint(uint64(seq))
+	reflectSetInt(m.FieldByName("Frame"), int64(item.Frame))
+	reflectSetInt(m.FieldByName("Command"), int64(item.Command))
+	if item.Current {
+		m.FieldByName("Current").SetUint(1)
+	}
+	if item.Autocontinue {
+		m.FieldByName("Autocontinue").SetUint(1)
+	}
+	for i, p := range item.Params {
+		m.FieldByName(fmt.Sprintf("Param%d", i+1)).SetFloat(float64(p))
+	}
+	m.FieldByName("X").SetInt(int64(item.X))
+	m.FieldByName("Y").SetInt(int64(item.Y))
+	m.FieldByName("Z").SetFloat(float64(item.Z))
+	return ret
+}
+
+func (nm *nodeMission) decodeItem(in msg.Message) *MissionItem {
+	m := msgValue(in)
+	item := &MissionItem{
+		Frame:        int(reflectInt(m.FieldByName("Frame"))),
+		Command:      int(reflectInt(m.FieldByName("Command"))),
+		Current:      m.FieldByName("Current").Uint() != 0,
+		Autocontinue: m.FieldByName("Autocontinue").Uint() != 0,
+		X:            int32(m.FieldByName("X").Int()),
+		Y:            int32(m.FieldByName("Y").Int()),
+		Z:            float32(m.FieldByName("Z").Float()),
+	}
+	for i := range item.Params {
+		item.Params[i] = float32(m.FieldByName(fmt.Sprintf("Param%d", i+1)).Float())
+	}
+	return item
+}
+
+func (nm *nodeMission) upload(ctx context.Context, t *MissionTransfer, items []*MissionItem) error {
+	sub := nm.subscribe(t, 40, 51, 47) // MISSION_REQUEST, MISSION_REQUEST_INT, MISSION_ACK
+	defer nm.n.frameSubscribers.unsubscribe(sub)
+
+	out := nm.newMessage(t, nm.msgMissionCount)
+	msgValue(out).FieldByName("Count").SetUint(uint64(len(items)))
+
+	for {
+		evt, err := nm.n.transact(ctx, sub, &Transaction{
+			Channel:  t.Channel,
+			Request:  out,
+			Timeout:  t.Timeout,
+			Attempts: t.Attempts,
+		})
+		if err != nil {
+			return err
+		}
+
+		m := msgValue(evt.Message())
+
+		// MISSION_ACK
+		if evt.messageID() == 47 {
+			res := int(reflectInt(m.FieldByName("Type")))
+			if res != missionResultAccepted {
+				return fmt.Errorf("mission rejected (result %d)", res)
+			}
+			return nil
+		}
+
+		// MISSION_REQUEST or MISSION_REQUEST_INT
+		seq := int(m.FieldByName("Seq").Uint())
+		if seq >= len(items) {
+			return fmt.Errorf("item %d requested, but mission contains %d items", seq, len(items))
+		}
+		out = nm.encodeItem(t, seq, items[seq])
+	}
+}
+
+func (nm *nodeMission) download(ctx context.Context, t *MissionTransfer) ([]*MissionItem, error) {
+	sub := nm.subscribe(t, 44, 73) // MISSION_COUNT, MISSION_ITEM_INT
+	defer nm.n.frameSubscribers.unsubscribe(sub)
+
+	out := nm.newMessage(t, nm.msgMissionRequestList)
+
+	evt, err := nm.n.transact(ctx, sub, &Transaction{
+		Channel: t.Channel,
+		Request: out,
+		Match: func(evt *EventFrame) bool {
+			return evt.messageID() == 44
+		},
+		Timeout:  t.Timeout,
+		Attempts: t.Attempts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	count := int(msgValue(evt.Message()).FieldByName("Count").Uint())
+	items := make([]*MissionItem, count)
+
+	for seq := 0; seq < count; seq++ {
+		out := nm.newMessage(t, nm.msgMissionRequestInt)
+		msgValue(out).FieldByName("Seq").SetUint(uint64(seq))
+
+		evt, err := nm.n.transact(ctx, sub, &Transaction{
+			Channel: t.Channel,
+			Request: out,
+			Match: func(evt *EventFrame) bool {
+				return evt.messageID() == 73 &&
+					int(msgValue(evt.Message()).FieldByName("Seq").Uint()) == seq
+			},
+			Timeout:  t.Timeout,
+			Attempts: t.Attempts,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		items[seq] = nm.decodeItem(evt.Message())
+	}
+
+	ack := nm.newMessage(t, nm.msgMissionAck)
+	reflectSetInt(msgValue(ack).FieldByName("Type"), missionResultAccepted)
+	nm.n.writeMessageToOrAll(t.Channel, ack)
+
+	return items, nil
+}
+
+func (nm *nodeMission) fillTransfer(t *MissionTransfer) *MissionTransfer {
+	tc := *t
+	if tc.Timeout == 0 {
+		tc.Timeout = 1500 * time.Millisecond
+	}
+	if tc.Attempts == 0 {
+		tc.Attempts = 5
+	}
+	return &tc
+}
+
+// UploadMission uploads a mission to a target, replacing the existing one.
+// The dialect must contain the messages of the mission protocol
+// (MISSION_REQUEST_LIST, MISSION_COUNT, MISSION_REQUEST_INT, MISSION_ITEM_INT
+// and MISSION_ACK). Events() must be read in a separate routine,
+// otherwise the responses of the target can't be received.
+func (n *Node) UploadMission(ctx context.Context, t *MissionTransfer, items []*MissionItem) error {
+	if n.nodeMission == nil {
+		return fmt.Errorf("dialect does not support missions")
+	}
+	return n.nodeMission.upload(ctx, n.nodeMission.fillTransfer(t), items)
+}
+
+// DownloadMission downloads the mission of a target.
+// The dialect must contain the messages of the mission protocol
+// (MISSION_REQUEST_LIST, MISSION_COUNT, MISSION_REQUEST_INT, MISSION_ITEM_INT
+// and MISSION_ACK). Events() must be read in a separate routine,
+// otherwise the responses of the target can't be received.
+func (n *Node) DownloadMission(ctx context.Context, t *MissionTransfer) ([]*MissionItem, error) {
+	if n.nodeMission == nil {
+		return nil, fmt.Errorf("dialect does not support missions")
+	}
+	return n.nodeMission.download(ctx, n.nodeMission.fillTransfer(t))
+}
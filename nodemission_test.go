@@ -0,0 +1,109 @@
+package gomavlib
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeMission(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	// simulate a vehicle that stores a mission
+	go func() {
+		var mission []*common.MessageMissionItemInt
+		var count int
+
+		for evt := range vehicle.Events() {
+			frm, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			switch m := frm.Message().(type) {
+			case *common.MessageMissionCount:
+				count = int(m.Count)
+				mission = nil
+				vehicle.WriteMessageTo(frm.Channel, &common.MessageMissionRequestInt{
+					TargetSystem:    frm.SystemID(),
+					TargetComponent: frm.ComponentID(),
+					Seq:             0,
+				})
+
+			case *common.MessageMissionItemInt:
+				if int(m.Seq) != len(mission) {
+					continue
+				}
+				mission = append(mission, m)
+
+				if len(mission) < count {
+					vehicle.WriteMessageTo(frm.Channel, &common.MessageMissionRequestInt{
+						TargetSystem:    frm.SystemID(),
+						TargetComponent: frm.ComponentID(),
+						Seq:             uint16(len(mission)),
+					})
+				} else {
+					vehicle.WriteMessageTo(frm.Channel, &common.MessageMissionAck{
+						TargetSystem:    frm.SystemID(),
+						TargetComponent: frm.ComponentID(),
+						Type:            common.MAV_MISSION_ACCEPTED,
+					})
+				}
+
+			case *common.MessageMissionRequestList:
+				vehicle.WriteMessageTo(frm.Channel, &common.MessageMissionCount{
+					TargetSystem:    frm.SystemID(),
+					TargetComponent: frm.ComponentID(),
+					Count:           uint16(len(mission)),
+				})
+
+			case *common.MessageMissionRequestInt:
+				item := *mission[m.Seq]
+				item.TargetSystem = frm.SystemID()
+				item.TargetComponent = frm.ComponentID()
+				vehicle.WriteMessageTo(frm.Channel, &item)
+			}
+		}
+	}()
+
+	items := []*MissionItem{
+		{
+			Frame:        int(common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT),
+			Command:      int(common.MAV_CMD_NAV_TAKEOFF),
+			Autocontinue: true,
+			Z:            10,
+		},
+		{
+			Frame:        int(common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT),
+			Command:      int(common.MAV_CMD_NAV_WAYPOINT),
+			Autocontinue: true,
+			Params:       [4]float32{1, 2, 0, 0},
+			X:            454642100,
+			Y:            91900000,
+			Z:            20,
+		},
+	}
+
+	transfer := &MissionTransfer{
+		TargetSystem: 1,
+		Timeout:      200 * time.Millisecond,
+	}
+
+	err := gcs.UploadMission(context.Background(), transfer, items)
+	require.NoError(t, err)
+
+	downloaded, err := gcs.DownloadMission(context.Background(), transfer)
+	require.NoError(t, err)
+	require.Equal(t, items, downloaded)
+}
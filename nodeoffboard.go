@@ -0,0 +1,163 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	// PX4 leaves offboard mode when setpoints are received at less than 2 Hz
+	offboardMaxPeriod = 500 * time.Millisecond
+)
+
+// OffboardConf is the configuration of an offboard setpoint stream.
+type OffboardConf struct {
+	// (optional) the channel where setpoints are sent.
+	// It defaults to all channels.
+	Channel *Channel
+
+	// the system id of the target.
+	TargetSystem byte
+
+	// (optional) the component id of the target.
+	// It defaults to 0, that means all components.
+	TargetComponent byte
+
+	// (optional) the period between setpoints.
+	// It defaults to 100 milliseconds, and must be less or equal than
+	// 500 milliseconds, in order to keep offboard mode active.
+	Period time.Duration
+
+	// a function that is called once per period and returns the setpoint
+	// to send, that must be a SET_POSITION_TARGET_LOCAL_NED,
+	// SET_POSITION_TARGET_GLOBAL_INT or SET_ATTITUDE_TARGET message.
+	// Target and timestamp fields are filled automatically.
+	// If the function returns nil or another message, the previous setpoint
+	// is sent again.
+	Setpoint func() msg.Message
+}
+
+// OffboardStream is a stream of offboard setpoints, that are sent
+// at a fixed rate.
+type OffboardStream struct {
+	n         *Node
+	conf      OffboardConf
+	start     time.Time
+	ctx       context.Context
+	ctxCancel func()
+
+	// out
+	done chan struct{}
+}
+
+// StartOffboard starts streaming offboard setpoints to a target.
+// Setpoints must be streamed before switching the target into offboard
+// mode, and must continue until the target switches into another mode.
+func (n *Node) StartOffboard(conf OffboardConf) (*OffboardStream, error) {
+	if conf.Setpoint == nil {
+		return nil, fmt.Errorf("Setpoint is required")
+	}
+	if conf.Period == 0 {
+		conf.Period = 100 * time.Millisecond
+	}
+	if conf.Period < 0 || conf.Period > offboardMaxPeriod {
+		return nil, fmt.Errorf("Period must be between 0 and %v", offboardMaxPeriod)
+	}
+
+	ctx, ctxCancel := context.WithCancel(n.ctx)
+
+	s := &OffboardStream{
+		n:         n,
+		conf:      conf,
+		start:     time.Now(),
+		ctx:       ctx,
+		ctxCancel: ctxCancel,
+		done:      make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s, nil
+}
+
+// Close stops the stream. Setpoints are not sent anymore after Close
+// returns.
+func (s *OffboardStream) Close() {
+	s.ctxCancel()
+	<-s.done
+}
+
+func (s *OffboardStream) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.conf.Period)
+	defer ticker.Stop()
+
+	var last msg.Message
+
+	for {
+		if m := s.conf.Setpoint(); s.isSupported(m) {
+			last = m
+		}
+
+		// setpoints are encoded again in order to update their timestamp
+		if last != nil && !s.write(s.encode(last)) {
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *OffboardStream) write(m msg.Message) bool {
+	if s.conf.Channel != nil {
+		select {
+		case s.n.writeTo <- writeToReq{s.conf.Channel, m}:
+			return true
+		case <-s.ctx.Done():
+			return false
+		}
+	}
+
+	select {
+	case s.n.writeAll <- m:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
+
+func (s *OffboardStream) isSupported(m msg.Message) bool {
+	if m == nil {
+		return false
+	}
+
+	switch m.GetID() {
+	case 82, 84, 86: // SET_ATTITUDE_TARGET, SET_POSITION_TARGET_LOCAL_NED, SET_POSITION_TARGET_GLOBAL_INT
+	default:
+		return false
+	}
+
+	v := reflect.ValueOf(m)
+	return v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Struct &&
+		v.Elem().FieldByName("TargetSystem").IsValid()
+}
+
+// encode returns a copy of a setpoint, with target and timestamp fields
+// filled.
+func (s *OffboardStream) encode(in msg.Message) msg.Message {
+	m := newMessage(in).Elem()
+	m.Set(msgValue(in))
+	m.FieldByName("TargetSystem").SetUint(uint64(s.conf.TargetSystem))
+	m.FieldByName("TargetComponent").SetUint(uint64(s.conf.TargetComponent))
+	m.FieldByName("TimeBootMs").SetUint(uint64(time.Since(s.start).Milliseconds()))
+	return m.Addr().Interface().(msg.Message)
+}
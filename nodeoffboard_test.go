@@ -0,0 +1,97 @@
+package gomavlib
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestNodeOffboard(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	var received int64
+	recv := make(chan *common.MessageSetPositionTargetLocalNed, 100)
+	go func() {
+		for evt := range vehicle.Events() {
+			if frm, ok := evt.(*EventFrame); ok {
+				if m, ok := frm.Message().(*common.MessageSetPositionTargetLocalNed); ok {
+					atomic.AddInt64(&received, 1)
+					select {
+					case recv <- m:
+					default:
+					}
+				}
+			}
+		}
+	}()
+
+	calls := 0
+	s, err := gcs.StartOffboard(OffboardConf{
+		TargetSystem:    1,
+		TargetComponent: 1,
+		Period:          20 * time.Millisecond,
+		Setpoint: func() msg.Message {
+			calls++
+			if calls%2 == 0 {
+				return nil
+			}
+			return &common.MessageSetPositionTargetLocalNed{
+				CoordinateFrame: common.MAV_FRAME_LOCAL_NED,
+				X:               float32(calls),
+			}
+		},
+	})
+	require.NoError(t, err)
+
+	var prev *common.MessageSetPositionTargetLocalNed
+	for i := 0; i < 4; i++ {
+		m := <-recv
+		require.Equal(t, uint8(1), m.TargetSystem)
+		require.Equal(t, uint8(1), m.TargetComponent)
+		require.Equal(t, common.MAV_FRAME_LOCAL_NED, m.CoordinateFrame)
+
+		// the previous setpoint is sent again when the callback returns nil
+		require.Equal(t, float32(i-i%2+1), m.X)
+
+		if prev != nil {
+			require.True(t, m.TimeBootMs > prev.TimeBootMs)
+		}
+		prev = m
+	}
+
+	s.Close()
+
+	// wait for setpoints that were already written
+	time.Sleep(50 * time.Millisecond)
+	n := atomic.LoadInt64(&received)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, n, atomic.LoadInt64(&received))
+}
+
+func TestNodeOffboardErrors(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	_, err := gcs.StartOffboard(OffboardConf{TargetSystem: 1})
+	require.EqualError(t, err, "Setpoint is required")
+
+	_, err = gcs.StartOffboard(OffboardConf{
+		TargetSystem: 1,
+		Period:       time.Second,
+		Setpoint:     func() msg.Message { return nil },
+	})
+	require.EqualError(t, err, "Period must be between 0 and 500ms")
+}
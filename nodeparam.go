@@ -0,0 +1,416 @@
+package gomavlib
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// MAV_PARAM_TYPE values
+const (
+	paramTypeUint8  = 1
+	paramTypeInt8   = 2
+	paramTypeUint16 = 3
+	paramTypeInt16  = 4
+	paramTypeUint32 = 5
+	paramTypeInt32  = 6
+)
+
+const (
+	autopilotArdupilot = 3 // MAV_AUTOPILOT_ARDUPILOTMEGA
+)
+
+// ParamEncoding is the way in which integer parameters are encoded into
+// the float field of PARAM_VALUE and PARAM_SET.
+type ParamEncoding int
+
+const (
+	// ParamEncodingAuto selects the encoding on the basis of the autopilot
+	// advertised by the heartbeats of the target: ParamEncodingCast is
+	// used with Ardupilot, ParamEncodingBytewise is used otherwise.
+	ParamEncodingAuto ParamEncoding = iota
+
+	// ParamEncodingBytewise copies the bytes of the value into the float
+	// (MAV_PROTOCOL_CAPABILITY_PARAM_ENCODE_BYTEWISE). It is used by PX4.
+	ParamEncodingBytewise
+
+	// ParamEncodingCast converts the value into a float
+	// (MAV_PROTOCOL_CAPABILITY_PARAM_ENCODE_C_CAST). It is used by Ardupilot.
+	ParamEncodingCast
+)
+
+// Param is a parameter.
+type Param struct {
+	// the parameter id.
+	ID string
+
+	// the parameter type (MAV_PARAM_TYPE).
+	Type int
+
+	// the parameter value.
+	Value float64
+
+	// the parameter index, filled when the parameter is received.
+	Index int
+}
+
+// ParamTransfer contains the parameters of a parameter read or write.
+type ParamTransfer struct {
+	// (optional) the channel used to communicate with the target.
+	// It defaults to all channels.
+	Channel *Channel
+
+	// the system id of the target.
+	TargetSystem byte
+
+	// (optional) the component id of the target.
+	// It defaults to 0, that means all components.
+	TargetComponent byte
+
+	// (optional) the encoding of integer parameters.
+	// It defaults to ParamEncodingAuto.
+	Encoding ParamEncoding
+
+	// (optional) the time to wait for a response before sending
+	// a message again. It defaults to 1 second.
+	Timeout time.Duration
+
+	// (optional) the maximum number of times a message is sent.
+	// It defaults to 3.
+	Attempts int
+}
+
+func paramEncode(enc ParamEncoding, typ int, v float64) float32 {
+	if enc == ParamEncodingCast {
+		return float32(v)
+	}
+
+	var buf [4]byte
+	switch typ {
+	case paramTypeUint8:
+		buf[0] = uint8(v)
+
+	case paramTypeInt8:
+		buf[0] = uint8(int8(v))
+
+	case paramTypeUint16:
+		binary.LittleEndian.PutUint16(buf[:], uint16(v))
+
+	case paramTypeInt16:
+		binary.LittleEndian.PutUint16(buf[:], uint16(int16(v)))
+
+	case paramTypeUint32:
+		binary.LittleEndian.PutUint32(buf[:], uint32(v))
+
+	case paramTypeInt32:
+		binary.LittleEndian.PutUint32(buf[:], uint32(int32(v)))
+
+	default:
+		return float32(v)
+	}
+
+	return math.Float32frombits(binary.LittleEndian.Uint32(buf[:]))
+}
+
+func paramDecode(enc ParamEncoding, typ int, v float32) float64 {
+	if enc == ParamEncodingCast {
+		return float64(v)
+	}
+
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], math.Float32bits(v))
+
+	switch typ {
+	case paramTypeUint8:
+		return float64(buf[0])
+
+	case paramTypeInt8:
+		return float64(int8(buf[0]))
+
+	case paramTypeUint16:
+		return float64(binary.LittleEndian.Uint16(buf[:]))
+
+	case paramTypeInt16:
+		return float64(int16(binary.LittleEndian.Uint16(buf[:])))
+
+	case paramTypeUint32:
+		return float64(binary.LittleEndian.Uint32(buf[:]))
+
+	case paramTypeInt32:
+		return float64(int32(binary.LittleEndian.Uint32(buf[:])))
+	}
+
+	return float64(v)
+}
+
+type nodeParam struct {
+	n                   *Node
+	msgParamRequestRead msg.Message
+	msgParamRequestList msg.Message
+	msgParamValue       msg.Message
+	msgParamSet         msg.Message
+	autopilotsMutex     sync.Mutex
+	autopilots          map[byte]int
+}
+
+func newNodeParam(n *Node) *nodeParam {
+	// parameter messages must exist in dialect and correspond to standard
+	msgParamRequestRead := dialectMessage(n.conf.Dialect, 20, 214)
+	msgParamRequestList := dialectMessage(n.conf.Dialect, 21, 159)
+	msgParamValue := dialectMessage(n.conf.Dialect, 22, 220)
+	msgParamSet := dialectMessage(n.conf.Dialect, 23, 168)
+	if msgParamRequestRead == nil || msgParamRequestList == nil ||
+		msgParamValue == nil || msgParamSet == nil {
+		return nil
+	}
+
+	return &nodeParam{
+		n:                   n,
+		msgParamRequestRead: msgParamRequestRead,
+		msgParamRequestList: msgParamRequestList,
+		msgParamValue:       msgParamValue,
+		msgParamSet:         msgParamSet,
+		autopilots:          make(map[byte]int),
+	}
+}
+
+func (np *nodeParam) onEventFrame(evt *EventFrame) {
+	// message must be a heartbeat
+	if evt.messageID() != 0 {
+		return
+	}
+	if _, ok := evt.Message().(*msg.MessageRaw); ok {
+		return
+	}
+
+	autopilot := int(reflectInt(msgValue(evt.Message()).FieldByName("Autopilot")))
+
+	np.autopilotsMutex.Lock()
+	defer np.autopilotsMutex.Unlock()
+
+	np.autopilots[evt.SystemID()] = autopilot
+}
+
+func (np *nodeParam) fillTransfer(t *ParamTransfer) *ParamTransfer {
+	tc := *t
+	if tc.Encoding == ParamEncodingAuto {
+		np.autopilotsMutex.Lock()
+		autopilot := np.autopilots[tc.TargetSystem]
+		np.autopilotsMutex.Unlock()
+
+		if autopilot == autopilotArdupilot {
+			tc.Encoding = ParamEncodingCast
+		} else {
+			tc.Encoding = ParamEncodingBytewise
+		}
+	}
+	if tc.Timeout == 0 {
+		tc.Timeout = 1 * time.Second
+	}
+	if tc.Attempts == 0 {
+		tc.Attempts = 3
+	}
+	return &tc
+}
+
+// subscribe subscribes to the PARAM_VALUE messages sent by the target.
+func (np *nodeParam) subscribe(t *ParamTransfer) *frameSubscriber {
+	return np.n.frameSubscribers.subscribe(func(evt *EventFrame) bool {
+		if evt.messageID() != 22 {
+			return false
+		}
+
+		if t.Channel != nil && evt.Channel != t.Channel {
+			return false
+		}
+
+		return evt.SystemID() == t.TargetSystem &&
+			(t.TargetComponent == 0 || evt.ComponentID() == t.TargetComponent)
+	})
+}
+
+func (np *nodeParam) newMessage(t *ParamTransfer, tmpl msg.Message) msg.Message {
+	m := newMessage(tmpl).Elem()
+	m.FieldByName("TargetSystem").SetUint(uint64(t.TargetSystem))
+	m.FieldByName("TargetComponent").SetUint(uint64(t.TargetComponent))
+	return m.Addr().Interface().(msg.Message)
+}
+
+func (np *nodeParam) decodeValue(t *ParamTransfer, in msg.Message) (*Param, int) {
+	m := msgValue(in)
+	typ := int(reflectInt(m.FieldByName("ParamType")))
+	return &Param{
+		ID:    m.FieldByName("ParamId").String(),
+		Type:  typ,
+		Value: paramDecode(t.Encoding, typ, float32(m.FieldByName("ParamValue").Float())),
+		Index: int(m.FieldByName("ParamIndex").Uint()),
+	}, int(m.FieldByName("ParamCount").Uint())
+}
+
+func (np *nodeParam) readByID(ctx context.Context, t *ParamTransfer, id string) (*Param, error) {
+	sub := np.subscribe(t)
+	defer np.n.frameSubscribers.unsubscribe(sub)
+
+	out := np.newMessage(t, np.msgParamRequestRead)
+	msgValue(out).FieldByName("ParamId").SetString(id)
+	msgValue(out).FieldByName("ParamIndex").SetInt(-1)
+
+	evt, err := np.n.transact(ctx, sub, &Transaction{
+		Channel: t.Channel,
+		Request: out,
+		Match: func(evt *EventFrame) bool {
+			return msgValue(evt.Message()).FieldByName("ParamId").String() == id
+		},
+		Timeout:  t.Timeout,
+		Attempts: t.Attempts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p, _ := np.decodeValue(t, evt.Message())
+	return p, nil
+}
+
+func (np *nodeParam) readAll(ctx context.Context, t *ParamTransfer) ([]*Param, error) {
+	sub := np.subscribe(t)
+	defer np.n.frameSubscribers.unsubscribe(sub)
+
+	params := make(map[int]*Param)
+	count := 0
+
+	// request the list and receive parameters until they stop coming
+	evt, err := np.n.transact(ctx, sub, &Transaction{
+		Channel:  t.Channel,
+		Request:  np.newMessage(t, np.msgParamRequestList),
+		Timeout:  t.Timeout,
+		Attempts: t.Attempts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		p, c := np.decodeValue(t, evt.Message())
+		count = c
+		if p.Index < count {
+			params[p.Index] = p
+		}
+
+		if len(params) >= count {
+			break
+		}
+
+		evt, err = sub.wait(ctx, np.n, t.Timeout)
+		if err == errorTimeout {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// request missing parameters one by one
+	for i := 0; i < count; i++ {
+		if _, ok := params[i]; ok {
+			continue
+		}
+
+		out := np.newMessage(t, np.msgParamRequestRead)
+		msgValue(out).FieldByName("ParamIndex").SetInt(int64(i))
+
+		idx := i
+		evt, err := np.n.transact(ctx, sub, &Transaction{
+			Channel: t.Channel,
+			Request: out,
+			Match: func(evt *EventFrame) bool {
+				return int(msgValue(evt.Message()).FieldByName("ParamIndex").Uint()) == idx
+			},
+			Timeout:  t.Timeout,
+			Attempts: t.Attempts,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		params[i], _ = np.decodeValue(t, evt.Message())
+	}
+
+	ret := make([]*Param, 0, len(params))
+	for _, p := range params {
+		ret = append(ret, p)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Index < ret[j].Index
+	})
+	return ret, nil
+}
+
+func (np *nodeParam) write(ctx context.Context, t *ParamTransfer, p *Param) (*Param, error) {
+	sub := np.subscribe(t)
+	defer np.n.frameSubscribers.unsubscribe(sub)
+
+	out := np.newMessage(t, np.msgParamSet)
+	msgValue(out).FieldByName("ParamId").SetString(p.ID)
+	reflectSetInt(msgValue(out).FieldByName("ParamType"), int64(p.Type))
+	msgValue(out).FieldByName("ParamValue").SetFloat(float64(paramEncode(t.Encoding, p.Type, p.Value)))
+
+	evt, err := np.n.transact(ctx, sub, &Transaction{
+		Channel: t.Channel,
+		Request: out,
+		Match: func(evt *EventFrame) bool {
+			return msgValue(evt.Message()).FieldByName("ParamId").String() == p.ID
+		},
+		Timeout:  t.Timeout,
+		Attempts: t.Attempts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ret, _ := np.decodeValue(t, evt.Message())
+	return ret, nil
+}
+
+// ReadParams reads all the parameters of a target.
+// The dialect must contain the messages of the parameter protocol
+// (PARAM_REQUEST_READ, PARAM_REQUEST_LIST, PARAM_VALUE and PARAM_SET).
+// Events() must be read in a separate routine, otherwise the responses
+// of the target can't be received.
+func (n *Node) ReadParams(ctx context.Context, t *ParamTransfer) ([]*Param, error) {
+	if n.nodeParam == nil {
+		return nil, fmt.Errorf("dialect does not support parameters")
+	}
+	return n.nodeParam.readAll(ctx, n.nodeParam.fillTransfer(t))
+}
+
+// ReadParam reads a parameter of a target.
+// The dialect must contain the messages of the parameter protocol
+// (PARAM_REQUEST_READ, PARAM_REQUEST_LIST, PARAM_VALUE and PARAM_SET).
+// Events() must be read in a separate routine, otherwise the responses
+// of the target can't be received.
+func (n *Node) ReadParam(ctx context.Context, t *ParamTransfer, id string) (*Param, error) {
+	if n.nodeParam == nil {
+		return nil, fmt.Errorf("dialect does not support parameters")
+	}
+	return n.nodeParam.readByID(ctx, n.nodeParam.fillTransfer(t), id)
+}
+
+// WriteParam writes a parameter of a target and returns the value
+// confirmed by the target.
+// The dialect must contain the messages of the parameter protocol
+// (PARAM_REQUEST_READ, PARAM_REQUEST_LIST, PARAM_VALUE and PARAM_SET).
+// Events() must be read in a separate routine, otherwise the responses
+// of the target can't be received.
+func (n *Node) WriteParam(ctx context.Context, t *ParamTransfer, p *Param) (*Param, error) {
+	if n.nodeParam == nil {
+		return nil, fmt.Errorf("dialect does not support parameters")
+	}
+	return n.nodeParam.write(ctx, n.nodeParam.fillTransfer(t), p)
+}
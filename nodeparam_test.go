@@ -0,0 +1,128 @@
+package gomavlib
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestParamEncoding(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		typ  int
+		v    float64
+	}{
+		{"uint8", int(common.MAV_PARAM_TYPE_UINT8), 250},
+		{"int8", int(common.MAV_PARAM_TYPE_INT8), -120},
+		{"uint16", int(common.MAV_PARAM_TYPE_UINT16), 65000},
+		{"int16", int(common.MAV_PARAM_TYPE_INT16), -32000},
+		{"uint32", int(common.MAV_PARAM_TYPE_UINT32), 4294967295},
+		{"int32", int(common.MAV_PARAM_TYPE_INT32), -16777217},
+		{"real32", int(common.MAV_PARAM_TYPE_REAL32), 1.5},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			enc := paramEncode(ParamEncodingBytewise, ca.typ, ca.v)
+			require.Equal(t, ca.v, paramDecode(ParamEncodingBytewise, ca.typ, enc))
+		})
+	}
+
+	require.Equal(t, float32(123), paramEncode(ParamEncodingCast, int(common.MAV_PARAM_TYPE_INT32), 123))
+	require.Equal(t, float64(123), paramDecode(ParamEncodingCast, int(common.MAV_PARAM_TYPE_INT32), 123))
+}
+
+func TestNodeParams(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	// simulate a vehicle that uses the bytewise encoding
+	params := []*common.MessageParamValue{
+		{
+			ParamId:    "PARAM_A",
+			ParamValue: paramEncode(ParamEncodingBytewise, int(common.MAV_PARAM_TYPE_INT32), 16777217),
+			ParamType:  common.MAV_PARAM_TYPE_INT32,
+		},
+		{
+			ParamId:    "PARAM_B",
+			ParamValue: 1.5,
+			ParamType:  common.MAV_PARAM_TYPE_REAL32,
+		},
+		{
+			ParamId:    "PARAM_C",
+			ParamValue: paramEncode(ParamEncodingBytewise, int(common.MAV_PARAM_TYPE_UINT8), 3),
+			ParamType:  common.MAV_PARAM_TYPE_UINT8,
+		},
+	}
+	for i, p := range params {
+		p.ParamIndex = uint16(i)
+		p.ParamCount = uint16(len(params))
+	}
+
+	go func() {
+		for evt := range vehicle.Events() {
+			frm, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			switch m := frm.Message().(type) {
+			case *common.MessageParamRequestList:
+				// skip a parameter, that must be requested again
+				for i, p := range params {
+					if i != 1 {
+						vehicle.WriteMessageTo(frm.Channel, p)
+					}
+				}
+
+			case *common.MessageParamRequestRead:
+				for i, p := range params {
+					if (m.ParamIndex == -1 && p.ParamId == m.ParamId) || int(m.ParamIndex) == i {
+						vehicle.WriteMessageTo(frm.Channel, p)
+					}
+				}
+
+			case *common.MessageParamSet:
+				for _, p := range params {
+					if p.ParamId == m.ParamId {
+						p.ParamValue = m.ParamValue
+						vehicle.WriteMessageTo(frm.Channel, p)
+					}
+				}
+			}
+		}
+	}()
+
+	transfer := &ParamTransfer{
+		TargetSystem: 1,
+		Timeout:      200 * time.Millisecond,
+	}
+
+	res, err := gcs.ReadParams(context.Background(), transfer)
+	require.NoError(t, err)
+	require.Equal(t, []*Param{
+		{ID: "PARAM_A", Type: int(common.MAV_PARAM_TYPE_INT32), Value: 16777217, Index: 0},
+		{ID: "PARAM_B", Type: int(common.MAV_PARAM_TYPE_REAL32), Value: 1.5, Index: 1},
+		{ID: "PARAM_C", Type: int(common.MAV_PARAM_TYPE_UINT8), Value: 3, Index: 2},
+	}, res)
+
+	p, err := gcs.WriteParam(context.Background(), transfer, &Param{
+		ID:    "PARAM_A",
+		Type:  int(common.MAV_PARAM_TYPE_INT32),
+		Value: -33554433,
+	})
+	require.NoError(t, err)
+	require.Equal(t, float64(-33554433), p.Value)
+
+	p, err = gcs.ReadParam(context.Background(), transfer, "PARAM_A")
+	require.NoError(t, err)
+	require.Equal(t, float64(-33554433), p.Value)
+}
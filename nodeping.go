@@ -0,0 +1,155 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	// responses to requests older than this are discarded
+	pingMaxRTT = 10 * time.Second
+)
+
+type nodePing struct {
+	n           *Node
+	msgPing     msg.Message
+	msgTimesync msg.Message
+	seq         uint32
+}
+
+func newNodePing(n *Node) *nodePing {
+	// PING or TIMESYNC must exist in dialect and correspond to standard
+	msgPing := dialectMessage(n.conf.Dialect, 4, 237)
+	msgTimesync := dialectMessage(n.conf.Dialect, 111, 34)
+	if msgPing == nil && msgTimesync == nil {
+		return nil
+	}
+
+	return &nodePing{
+		n:           n,
+		msgPing:     msgPing,
+		msgTimesync: msgTimesync,
+	}
+}
+
+func (np *nodePing) onEventFrame(evt *EventFrame) {
+	now := time.Now()
+
+	switch evt.messageID() {
+	case 4: // PING
+		if np.msgPing == nil {
+			return
+		}
+
+		m := msgValue(evt.Message())
+		targetSystem := byte(m.FieldByName("TargetSystem").Uint())
+		targetComponent := byte(m.FieldByName("TargetComponent").Uint())
+
+		// message is a request: send a response
+		if targetSystem == 0 && targetComponent == 0 {
+			res := newMessage(np.msgPing).Elem()
+			res.FieldByName("TimeUsec").SetUint(m.FieldByName("TimeUsec").Uint())
+			res.FieldByName("Seq").SetUint(m.FieldByName("Seq").Uint())
+			res.FieldByName("TargetSystem").SetUint(uint64(evt.SystemID()))
+			res.FieldByName("TargetComponent").SetUint(uint64(evt.ComponentID()))
+			np.n.WriteMessageTo(evt.Channel, res.Addr().Interface().(msg.Message))
+			return
+		}
+
+		// message is a response to one of our requests
+		if targetSystem == np.n.conf.OutSystemID && targetComponent == np.n.conf.OutComponentID {
+			sent := time.Unix(0, int64(m.FieldByName("TimeUsec").Uint())*1000)
+			np.addRTT(evt.Channel, now.Sub(sent))
+		}
+
+	case 111: // TIMESYNC
+		if np.msgTimesync == nil {
+			return
+		}
+
+		// message is a response to one of our requests
+		m := msgValue(evt.Message())
+		if m.FieldByName("Tc1").Int() != 0 {
+			sent := time.Unix(0, m.FieldByName("Ts1").Int())
+			np.addRTT(evt.Channel, now.Sub(sent))
+		}
+	}
+}
+
+func (np *nodePing) addRTT(ch *Channel, rtt time.Duration) {
+	if rtt < 0 || rtt > pingMaxRTT {
+		return
+	}
+	ch.stats.addRTT(rtt)
+}
+
+func (np *nodePing) ping(ctx context.Context, systemID byte) (time.Duration, error) {
+	now := time.Now()
+	var out msg.Message
+	var sub *frameSubscriber
+
+	if np.msgPing != nil {
+		seq := atomic.AddUint32(&np.seq, 1)
+
+		m := newMessage(np.msgPing).Elem()
+		m.FieldByName("TimeUsec").SetUint(uint64(now.UnixNano() / 1000))
+		m.FieldByName("Seq").SetUint(uint64(seq))
+		out = m.Addr().Interface().(msg.Message)
+
+		sub = np.n.frameSubscribers.subscribe(func(evt *EventFrame) bool {
+			if evt.messageID() != 4 || evt.SystemID() != systemID {
+				return false
+			}
+			m := msgValue(evt.Message())
+			return uint32(m.FieldByName("Seq").Uint()) == seq &&
+				byte(m.FieldByName("TargetSystem").Uint()) == np.n.conf.OutSystemID &&
+				byte(m.FieldByName("TargetComponent").Uint()) == np.n.conf.OutComponentID
+		})
+	} else {
+		ts1 := now.UnixNano()
+
+		m := newMessage(np.msgTimesync).Elem()
+		m.FieldByName("Ts1").SetInt(ts1)
+		out = m.Addr().Interface().(msg.Message)
+
+		sub = np.n.frameSubscribers.subscribe(func(evt *EventFrame) bool {
+			if evt.messageID() != 111 || evt.SystemID() != systemID {
+				return false
+			}
+			m := msgValue(evt.Message())
+			return m.FieldByName("Tc1").Int() != 0 && m.FieldByName("Ts1").Int() == ts1
+		})
+	}
+	defer np.n.frameSubscribers.unsubscribe(sub)
+
+	np.n.WriteMessageAll(out)
+
+	select {
+	case <-sub.frames:
+		return time.Since(now), nil
+
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	case <-np.n.ctx.Done():
+		return 0, errorTerminated
+	}
+}
+
+// Ping sends a PING message to all channels and waits for the response of
+// given system, then returns the round-trip time. If the dialect doesn't
+// contain PING, a TIMESYNC message is used instead.
+// The function waits until a response is received or the context is
+// canceled, therefore a context with a timeout should be used.
+// Events() must be read in a separate routine, otherwise the response can't
+// be received.
+func (n *Node) Ping(ctx context.Context, systemID byte) (time.Duration, error) {
+	if n.nodePing == nil {
+		return 0, fmt.Errorf("dialect does not support PING or TIMESYNC")
+	}
+	return n.nodePing.ping(ctx, systemID)
+}
@@ -0,0 +1,81 @@
+package gomavlib
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestNodePing(t *testing.T) {
+	for _, ca := range []string{"ping", "timesync"} {
+		t.Run(ca, func(t *testing.T) {
+			d := common.Dialect
+			if ca == "timesync" {
+				d = &dialect.Dialect{3, []msg.Message{&common.MessageTimesync{}}} //nolint:govet
+			}
+
+			c1, c2 := net.Pipe()
+
+			node1, err := NewNode(NodeConf{
+				Dialect:          d,
+				OutVersion:       V2,
+				OutSystemID:      10,
+				Endpoints:        []EndpointConf{EndpointCustom{c1}},
+				HeartbeatDisable: true,
+			})
+			require.NoError(t, err)
+			defer node1.Close()
+
+			node2, err := NewNode(NodeConf{
+				Dialect:          d,
+				OutVersion:       V2,
+				OutSystemID:      11,
+				Endpoints:        []EndpointConf{EndpointCustom{c2}},
+				HeartbeatDisable: true,
+				TimesyncEnable:   (ca == "timesync"),
+				TimesyncPeriod:   1 * time.Hour,
+			})
+			require.NoError(t, err)
+			defer node2.Close()
+
+			go func() {
+				for range node2.Events() {
+				}
+			}()
+
+			var ch *Channel
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for evt := range node1.Events() {
+					if frm, ok := evt.(*EventFrame); ok {
+						ch = frm.Channel
+						return
+					}
+				}
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+
+			rtt, err := node1.Ping(ctx, 11)
+			require.NoError(t, err)
+			require.True(t, rtt > 0)
+
+			<-done
+			require.True(t, ch.Stats().RTT > 0)
+
+			go func() {
+				for range node1.Events() {
+				}
+			}()
+		})
+	}
+}
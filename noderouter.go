@@ -0,0 +1,218 @@
+package gomavlib
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// Route is an entry of the routing table of a node.
+type Route struct {
+	// the system id.
+	SystemID byte
+
+	// the component id.
+	ComponentID byte
+
+	// the channel from which the system and component were seen.
+	Channel *Channel
+}
+
+type routerKey struct {
+	systemID    byte
+	componentID byte
+}
+
+type nodeRouter struct {
+	n      *Node
+	mutex  sync.Mutex
+	routes map[routerKey]map[*Channel]struct{}
+}
+
+func newNodeRouter(n *Node) *nodeRouter {
+	// module is disabled
+	if !n.conf.RouterEnable {
+		return nil
+	}
+
+	return &nodeRouter{
+		n:      n,
+		routes: make(map[routerKey]map[*Channel]struct{}),
+	}
+}
+
+// rawField returns the value of a single-byte field of an encoded message.
+func rawField(mde *msg.DecEncoder, raw *msg.MessageRaw, name string) byte {
+	offset, ok := mde.FieldOffset(name)
+	if !ok || offset >= len(raw.Content) {
+		// field is missing or has been truncated
+		return 0
+	}
+	return raw.Content[offset]
+}
+
+// messageTarget returns the target system and component of a message,
+// in case the message has target fields.
+func messageTarget(evt *EventFrame) (byte, byte) {
+	// read fields from the encoded message, in order to avoid decoding it
+	if raw, ok := evt.Frame.GetMessage().(*msg.MessageRaw); ok {
+		dde := evt.Channel.n.dialectDE
+		if dde == nil {
+			return 0, 0
+		}
+
+		mde, ok := dde.MessageDEs[raw.ID]
+		if !ok {
+			return 0, 0
+		}
+
+		return rawField(mde, raw, "target_system"), rawField(mde, raw, "target_component")
+	}
+
+	v := reflect.ValueOf(evt.Message())
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return 0, 0
+	}
+	v = v.Elem()
+
+	var targetSystem byte
+	if f := v.FieldByName("TargetSystem"); f.IsValid() && f.Kind() == reflect.Uint8 {
+		targetSystem = byte(f.Uint())
+	}
+
+	var targetComponent byte
+	if f := v.FieldByName("TargetComponent"); f.IsValid() && f.Kind() == reflect.Uint8 {
+		targetComponent = byte(f.Uint())
+	}
+
+	return targetSystem, targetComponent
+}
+
+func (r *nodeRouter) onChannelClose(ch *Channel) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for k, chans := range r.routes {
+		delete(chans, ch)
+		if len(chans) == 0 {
+			delete(r.routes, k)
+		}
+	}
+}
+
+// destinations returns the channels to which a frame must be forwarded.
+// A nil slice means all channels except the source one.
+func (r *nodeRouter) destinations(evt *EventFrame) ([]*Channel, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// learn route
+	k := routerKey{evt.SystemID(), evt.ComponentID()}
+	chans, ok := r.routes[k]
+	if !ok {
+		chans = make(map[*Channel]struct{})
+		r.routes[k] = chans
+	}
+	chans[evt.Channel] = struct{}{}
+
+	targetSystem, targetComponent := messageTarget(evt)
+
+	// broadcast message
+	if targetSystem == 0 {
+		return nil, true
+	}
+
+	// forward message to the channels where the target has been seen.
+	// Messages whose target is unknown or is the node itself are not forwarded.
+	var out []*Channel
+	seen := make(map[*Channel]struct{})
+
+	for k, chans := range r.routes {
+		if k.systemID != targetSystem ||
+			(targetComponent != 0 && k.componentID != targetComponent) {
+			continue
+		}
+
+		for ch := range chans {
+			if _, ok := seen[ch]; ok || ch == evt.Channel {
+				continue
+			}
+			seen[ch] = struct{}{}
+			out = append(out, ch)
+		}
+	}
+
+	return out, len(out) != 0
+}
+
+func (r *nodeRouter) onEventFrame(evt *EventFrame) {
+	dests, ok := r.destinations(evt)
+	if !ok {
+		return
+	}
+
+	// the frame is written after the release of the event
+	evt.keep()
+
+	var what interface{} = evt.Frame
+
+	if r.n.conf.Tracer != nil {
+		ctx, span := r.n.conf.Tracer.Start(evt.Context(), SpanRoute,
+			frameTraceAttributes(evt.Channel, evt.Frame)...)
+		defer span.End()
+		what = &tracedWrite{ctx, evt.Frame}
+	}
+
+	if dests == nil {
+		r.n.writeExceptWhat(evt.Channel, what)
+		return
+	}
+
+	for _, ch := range dests {
+		// redundant links are used one at a time
+		if r.n.nodeFailover.isStandby(ch) {
+			continue
+		}
+		r.n.writeToWhat(ch, what)
+	}
+}
+
+func (r *nodeRouter) table() []*Route {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var out []*Route
+	for k, chans := range r.routes {
+		for ch := range chans {
+			out = append(out, &Route{
+				SystemID:    k.systemID,
+				ComponentID: k.componentID,
+				Channel:     ch,
+			})
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].SystemID != out[j].SystemID {
+			return out[i].SystemID < out[j].SystemID
+		}
+		if out[i].ComponentID != out[j].ComponentID {
+			return out[i].ComponentID < out[j].ComponentID
+		}
+		return out[i].Channel.label < out[j].Channel.label
+	})
+
+	return out
+}
+
+// RoutingTable returns the routing table of the node, that contains
+// the systems and components that have been seen on every channel.
+// It requires RouterEnable to be true.
+func (n *Node) RoutingTable() []*Route {
+	if n.nodeRouter == nil {
+		return nil
+	}
+	return n.nodeRouter.table()
+}
@@ -0,0 +1,120 @@
+package gomavlib
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeRouter(t *testing.T) {
+	t.Run("standard", func(t *testing.T) {
+		testNodeRouter(t, false)
+	})
+
+	t.Run("lazy decoding", func(t *testing.T) {
+		testNodeRouter(t, true)
+	})
+}
+
+func testNodeRouter(t *testing.T, lazyDecoding bool) {
+	var routerEndpoints []EndpointConf
+	var nodes []*Node
+
+	for i := 0; i < 3; i++ {
+		c1, c2 := net.Pipe()
+		routerEndpoints = append(routerEndpoints, EndpointCustom{c1})
+
+		node, err := NewNode(NodeConf{
+			Dialect:          common.Dialect,
+			OutVersion:       V2,
+			OutSystemID:      byte(i + 1),
+			Endpoints:        []EndpointConf{EndpointCustom{c2}},
+			HeartbeatDisable: true,
+		})
+		require.NoError(t, err)
+		defer node.Close()
+		nodes = append(nodes, node)
+	}
+
+	router, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        routerEndpoints,
+		HeartbeatDisable: true,
+		RouterEnable:     true,
+		LazyDecoding:     lazyDecoding,
+	})
+	require.NoError(t, err)
+	defer router.Close()
+
+	go func() {
+		for range router.Events() {
+		}
+	}()
+
+	recv := func(n *Node) *EventFrame {
+		for evt := range n.Events() {
+			if fr, ok := evt.(*EventFrame); ok {
+				return fr
+			}
+		}
+		return nil
+	}
+
+	heartbeat := &common.MessageHeartbeat{
+		Type:           common.MAV_TYPE_GCS,
+		SystemStatus:   common.MAV_STATE_ACTIVE,
+		MavlinkVersion: 3,
+	}
+
+	// broadcast messages are forwarded to all other channels
+	for i, node := range nodes {
+		node.WriteMessageAll(heartbeat)
+
+		for j, other := range nodes {
+			if j == i {
+				continue
+			}
+			fr := recv(other)
+			require.Equal(t, byte(i+1), fr.SystemID())
+			require.Equal(t, heartbeat, fr.Message())
+		}
+	}
+
+	table := router.RoutingTable()
+	require.Equal(t, 3, len(table))
+	for i, r := range table {
+		require.Equal(t, byte(i+1), r.SystemID)
+		require.Equal(t, byte(1), r.ComponentID)
+	}
+
+	// targeted messages are forwarded to the target only
+	cmd := &common.MessageCommandLong{
+		TargetSystem:    2,
+		TargetComponent: 1,
+		Command:         common.MAV_CMD_COMPONENT_ARM_DISARM,
+		Param1:          1,
+	}
+	nodes[0].WriteMessageAll(cmd)
+
+	fr := recv(nodes[1])
+	require.Equal(t, byte(1), fr.SystemID())
+	require.Equal(t, cmd, fr.Message())
+
+	// messages addressed to unknown systems are not forwarded
+	nodes[0].WriteMessageAll(&common.MessageCommandLong{
+		TargetSystem:    20,
+		TargetComponent: 1,
+	})
+
+	nodes[0].WriteMessageAll(heartbeat)
+
+	for _, other := range nodes[1:] {
+		fr := recv(other)
+		require.Equal(t, heartbeat, fr.Message())
+	}
+}
@@ -0,0 +1,195 @@
+package gomavlib
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	rtcmFragmentLen   = 180 // length of the Data field of GPS_RTCM_DATA
+	rtcmMaxFragments  = 4
+	rtcmMaxMessageLen = rtcmFragmentLen * rtcmMaxFragments
+	rtcm3Preamble     = 0xD3
+	rtcm3HeaderLen    = 3
+	rtcm3CRCLen       = 3
+)
+
+// crc24q computes the CRC-24Q checksum used by RTCM3.
+func crc24q(buf []byte) uint32 {
+	crc := uint32(0)
+	for _, b := range buf {
+		crc ^= uint32(b) << 16
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if (crc & 0x1000000) != 0 {
+				crc ^= 0x1864CFB
+			}
+		}
+	}
+	return crc & 0xFFFFFF
+}
+
+type nodeRTCM struct {
+	n              *Node
+	msgGPSRTCMData msg.Message
+	mutex          sync.Mutex
+	sequenceID     uint8
+}
+
+func newNodeRTCM(n *Node) *nodeRTCM {
+	// GPS_RTCM_DATA message must exist in dialect and correspond to standard
+	msgGPSRTCMData := dialectMessage(n.conf.Dialect, 233, 35)
+	if msgGPSRTCMData == nil {
+		return nil
+	}
+
+	return &nodeRTCM{
+		n:              n,
+		msgGPSRTCMData: msgGPSRTCMData,
+	}
+}
+
+// fragments splits a RTCM message into GPS_RTCM_DATA messages.
+func (r *nodeRTCM) fragments(data []byte) []msg.Message {
+	r.mutex.Lock()
+	seq := r.sequenceID
+	r.sequenceID = (r.sequenceID + 1) & 0x1F
+	r.mutex.Unlock()
+
+	if len(data) <= rtcmFragmentLen {
+		return []msg.Message{r.encode(seq<<3, data)}
+	}
+
+	count := (len(data) + rtcmFragmentLen - 1) / rtcmFragmentLen
+
+	// the autopilot considers a message complete when it receives
+	// all fragments or a fragment that is not full: when the last fragment
+	// is full, an empty fragment is needed to terminate the message
+	if len(data)%rtcmFragmentLen == 0 && count < rtcmMaxFragments {
+		count++
+	}
+
+	ret := make([]msg.Message, count)
+	for i := range ret {
+		start := i * rtcmFragmentLen
+		if start > len(data) {
+			start = len(data)
+		}
+		end := start + rtcmFragmentLen
+		if end > len(data) {
+			end = len(data)
+		}
+		ret[i] = r.encode(1|uint8(i)<<1|seq<<3, data[start:end])
+	}
+
+	return ret
+}
+
+func (r *nodeRTCM) encode(flags uint8, data []byte) msg.Message {
+	m := newMessage(r.msgGPSRTCMData).Elem()
+	m.FieldByName("Flags").SetUint(uint64(flags))
+	m.FieldByName("Len").SetUint(uint64(len(data)))
+	buf := m.FieldByName("Data")
+	for i, b := range data {
+		buf.Index(i).SetUint(uint64(b))
+	}
+	return m.Addr().Interface().(msg.Message)
+}
+
+func (r *nodeRTCM) write(channel *Channel, data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("RTCM message is empty")
+	}
+	if len(data) > rtcmMaxMessageLen {
+		return fmt.Errorf("RTCM message is too big (%d bytes, maximum is %d)",
+			len(data), rtcmMaxMessageLen)
+	}
+
+	for _, m := range r.fragments(data) {
+		r.n.writeMessageToOrAll(channel, m)
+	}
+	return nil
+}
+
+// WriteRTCM writes a RTCM message (i.e. a RTK correction) to given channel
+// or, if the channel is nil, to all channels. The message is split into
+// GPS_RTCM_DATA messages, according to the fragmentation rules of the
+// protocol, and must not be longer than 720 bytes.
+// The dialect must contain the GPS_RTCM_DATA message.
+func (n *Node) WriteRTCM(channel *Channel, data []byte) error {
+	if n.nodeRTCM == nil {
+		return fmt.Errorf("dialect does not support RTCM")
+	}
+	return n.nodeRTCM.write(channel, data)
+}
+
+// RTCMWriter is a io.Writer that extracts RTCM3 messages from a byte stream
+// (i.e. the output of a base station) and writes them with WriteRTCM.
+// Bytes that do not belong to valid RTCM3 messages are discarded.
+type RTCMWriter struct {
+	n       *Node
+	channel *Channel
+	buf     []byte
+}
+
+// NewRTCMWriter allocates a RTCMWriter, that writes to given channel or,
+// if the channel is nil, to all channels.
+// The dialect must contain the GPS_RTCM_DATA message.
+func (n *Node) NewRTCMWriter(channel *Channel) (*RTCMWriter, error) {
+	if n.nodeRTCM == nil {
+		return nil, fmt.Errorf("dialect does not support RTCM")
+	}
+
+	return &RTCMWriter{
+		n:       n,
+		channel: channel,
+	}, nil
+}
+
+// Write implements io.Writer.
+func (w *RTCMWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		// find preamble
+		i := 0
+		for i < len(w.buf) && w.buf[i] != rtcm3Preamble {
+			i++
+		}
+		w.buf = w.buf[i:]
+
+		if len(w.buf) < rtcm3HeaderLen {
+			break
+		}
+
+		payloadLen := int(w.buf[1]&0x03)<<8 | int(w.buf[2])
+		frameLen := rtcm3HeaderLen + payloadLen + rtcm3CRCLen
+
+		// the 6 bits after the preamble are reserved and must be zero
+		if (w.buf[1] & 0xFC) != 0 {
+			w.buf = w.buf[1:]
+			continue
+		}
+
+		if len(w.buf) < frameLen {
+			break
+		}
+
+		crc := uint32(w.buf[frameLen-3])<<16 | uint32(w.buf[frameLen-2])<<8 | uint32(w.buf[frameLen-1])
+		if crc24q(w.buf[:frameLen-rtcm3CRCLen]) != crc {
+			w.buf = w.buf[1:]
+			continue
+		}
+
+		frame := make([]byte, frameLen)
+		copy(frame, w.buf)
+		w.buf = w.buf[frameLen:]
+
+		// frames longer than 720 bytes can't be transmitted with MAVLink
+		w.n.nodeRTCM.write(w.channel, frame) //nolint:errcheck
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,135 @@
+package gomavlib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func testRTCMFrame(payloadLen int, fill byte) []byte {
+	frame := []byte{rtcm3Preamble, byte(payloadLen >> 8), byte(payloadLen)}
+	for i := 0; i < payloadLen; i++ {
+		frame = append(frame, fill)
+	}
+	crc := crc24q(frame)
+	return append(frame, byte(crc>>16), byte(crc>>8), byte(crc))
+}
+
+func testReadRTCM(t *testing.T, n *Node, count int) []*common.MessageGpsRtcmData {
+	var ret []*common.MessageGpsRtcmData
+	for evt := range n.Events() {
+		if frm, ok := evt.(*EventFrame); ok {
+			if m, ok := frm.Message().(*common.MessageGpsRtcmData); ok {
+				ret = append(ret, m)
+				if len(ret) == count {
+					break
+				}
+			}
+		}
+	}
+	return ret
+}
+
+func TestCRC24Q(t *testing.T) {
+	require.Equal(t, uint32(0xCDE703), crc24q([]byte("123456789")))
+}
+
+func TestNodeWriteRTCM(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	for _, ca := range []struct {
+		name   string
+		length int
+		lens   []uint8
+		flags  []uint8
+	}{
+		{"single", 100, []uint8{100}, []uint8{0 << 3}},
+		{"fragmented", 400, []uint8{180, 180, 40}, []uint8{1 | 1<<3, 1 | 1<<1 | 1<<3, 1 | 2<<1 | 1<<3}},
+		{"full fragments", 360, []uint8{180, 180, 0}, []uint8{1 | 2<<3, 1 | 1<<1 | 2<<3, 1 | 2<<1 | 2<<3}},
+		{"maximum", 720, []uint8{180, 180, 180, 180}, []uint8{1 | 3<<3, 1 | 1<<1 | 3<<3, 1 | 2<<1 | 3<<3, 1 | 3<<1 | 3<<3}},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			data := make([]byte, ca.length)
+			for i := range data {
+				data[i] = byte(i)
+			}
+
+			writeErr := make(chan error)
+			go func() {
+				writeErr <- gcs.WriteRTCM(nil, data)
+			}()
+
+			msgs := testReadRTCM(t, vehicle, len(ca.lens))
+			require.NoError(t, <-writeErr)
+
+			var received []byte
+			for i, m := range msgs {
+				require.Equal(t, ca.lens[i], m.Len)
+				require.Equal(t, ca.flags[i], m.Flags)
+				received = append(received, m.Data[:m.Len]...)
+			}
+			require.Equal(t, data, received)
+		})
+	}
+
+	err := gcs.WriteRTCM(nil, make([]byte, 721))
+	require.EqualError(t, err, "RTCM message is too big (721 bytes, maximum is 720)")
+}
+
+func TestNodeRTCMWriter(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	w, err := gcs.NewRTCMWriter(nil)
+	require.NoError(t, err)
+
+	frame1 := testRTCMFrame(10, 1)
+	frame2 := testRTCMFrame(300, 2)
+	corrupted := testRTCMFrame(10, 3)
+	corrupted[5] = 4
+
+	var stream []byte
+	stream = append(stream, 0x01, 0x02, rtcm3Preamble) // garbage
+	stream = append(stream, frame1...)
+	stream = append(stream, corrupted...)
+	stream = append(stream, frame2...)
+
+	writeErr := make(chan error)
+	go func() {
+		// write the stream in small chunks
+		for len(stream) > 0 {
+			n := 7
+			if n > len(stream) {
+				n = len(stream)
+			}
+			_, err := w.Write(stream[:n])
+			if err != nil {
+				writeErr <- err
+				return
+			}
+			stream = stream[n:]
+		}
+		writeErr <- nil
+	}()
+
+	msgs := testReadRTCM(t, vehicle, 3)
+	require.NoError(t, <-writeErr)
+
+	require.Equal(t, frame1, msgs[0].Data[:msgs[0].Len])
+	require.Equal(t, frame2, append(msgs[1].Data[:msgs[1].Len], msgs[2].Data[:msgs[2].Len]...))
+}
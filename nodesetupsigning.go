@@ -0,0 +1,151 @@
+package gomavlib
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+// SetupSigningRequest is a request to change the signing key of the node,
+// received through a SETUP_SIGNING message.
+type SetupSigningRequest struct {
+	// the channel from which the request was received.
+	Channel *Channel
+
+	// the system id of the sender.
+	SystemID byte
+
+	// the component id of the sender.
+	ComponentID byte
+
+	// the new key, or nil if the sender requested to disable signing.
+	Key *frame.V2Key
+
+	// the minimum timestamp of signed outgoing frames.
+	InitialTimestamp uint64
+}
+
+type nodeSetupSigning struct {
+	n               *Node
+	msgSetupSigning msg.Message
+	minTime         uint64
+}
+
+func newNodeSetupSigning(n *Node) *nodeSetupSigning {
+	// SETUP_SIGNING must exist in dialect and correspond to standard
+	msgSetupSigning := dialectMessage(n.conf.Dialect, 256, 71)
+	if msgSetupSigning == nil {
+		return nil
+	}
+
+	return &nodeSetupSigning{
+		n:               n,
+		msgSetupSigning: msgSetupSigning,
+	}
+}
+
+func (ns *nodeSetupSigning) onEventFrame(evt *EventFrame) {
+	// module is disabled on the receiving side
+	if ns.n.conf.SetupSigningAccept == nil {
+		return
+	}
+
+	if evt.messageID() != 256 {
+		return
+	}
+
+	m := msgValue(evt.Message())
+
+	// message is not directed to the node
+	if byte(m.FieldByName("TargetSystem").Uint()) != ns.n.conf.OutSystemID ||
+		byte(m.FieldByName("TargetComponent").Uint()) != ns.n.conf.OutComponentID {
+		return
+	}
+
+	var key frame.V2Key
+	reflect.Copy(reflect.ValueOf(key[:]), m.FieldByName("SecretKey"))
+
+	req := &SetupSigningRequest{
+		Channel:          evt.Channel,
+		SystemID:         evt.SystemID(),
+		ComponentID:      evt.ComponentID(),
+		InitialTimestamp: m.FieldByName("InitialTimestamp").Uint(),
+	}
+
+	// a zero key and a zero timestamp disable signing
+	if key != (frame.V2Key{}) || req.InitialTimestamp != 0 {
+		req.Key = &key
+	}
+
+	if !ns.n.conf.SetupSigningAccept(req) {
+		return
+	}
+
+	if req.Key == nil {
+		ns.n.SetKeys(nil, nil) //nolint:errcheck
+		return
+	}
+
+	ns.raiseTimestamp(req.InitialTimestamp)
+	ns.n.SetKeys([]*frame.V2Key{req.Key}, req.Key) //nolint:errcheck
+}
+
+func (ns *nodeSetupSigning) raiseTimestamp(ts uint64) {
+	for {
+		cur := atomic.LoadUint64(&ns.minTime)
+		if ts <= cur || atomic.CompareAndSwapUint64(&ns.minTime, cur, ts) {
+			return
+		}
+	}
+}
+
+// nextTimestamp returns the timestamp of the next signed frame, that is
+// never lower than the initial timestamp of the last accepted request.
+func (ns *nodeSetupSigning) nextTimestamp() uint64 {
+	var ts uint64
+	if ns.n.nodeSignatureTimestamp != nil {
+		ts = ns.n.nodeSignatureTimestamp.next()
+	} else {
+		ts = transceiver.SignatureTimestamp(time.Now())
+	}
+
+	if minTime := atomic.LoadUint64(&ns.minTime); ts < minTime {
+		return minTime
+	}
+	return ts
+}
+
+// SetupSigning sends a SETUP_SIGNING message to given system and component,
+// in order to provision the key used to sign and validate frames.
+// If key is nil, the target is requested to disable signing.
+// initialTimestamp is the minimum timestamp of signed frames emitted by the
+// target; it can be computed with transceiver.SignatureTimestamp().
+// The message is written to given channel, or to all channels if channel is nil.
+// Since the key is sent in clear, the message must be sent through a secure link.
+func (n *Node) SetupSigning(channel *Channel, targetSystem byte, targetComponent byte,
+	key *frame.V2Key, initialTimestamp uint64) error {
+	if n.nodeSetupSigning == nil {
+		return fmt.Errorf("dialect does not support SETUP_SIGNING")
+	}
+
+	m := newMessage(n.nodeSetupSigning.msgSetupSigning).Elem()
+	m.FieldByName("TargetSystem").SetUint(uint64(targetSystem))
+	m.FieldByName("TargetComponent").SetUint(uint64(targetComponent))
+	if key != nil {
+		reflect.Copy(m.FieldByName("SecretKey"), reflect.ValueOf(key[:]))
+	}
+	m.FieldByName("InitialTimestamp").SetUint(initialTimestamp)
+	out := m.Addr().Interface().(msg.Message)
+
+	if channel != nil {
+		n.WriteMessageTo(channel, out)
+	} else {
+		n.WriteMessageAll(out)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package gomavlib
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+type MessageSetupSigning struct {
+	TargetSystem     uint8
+	TargetComponent  uint8
+	SecretKey        [32]uint8
+	InitialTimestamp uint64
+}
+
+func (*MessageSetupSigning) GetID() uint32 {
+	return 256
+}
+
+func TestNodeSetupSigning(t *testing.T) {
+	key := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+	initialTimestamp := transceiver.SignatureTimestamp(time.Now().Add(time.Hour))
+
+	c1, c2 := net.Pipe()
+
+	var req *SetupSigningRequest
+
+	vehicle, err := NewNode(NodeConf{
+		Dialect: &dialect.Dialect{3, []msg.Message{ //nolint:govet
+			&MessageHeartbeat{},
+			&MessageSetupSigning{},
+		}},
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		SetupSigningAccept: func(r *SetupSigningRequest) bool {
+			req = r
+			return true
+		},
+	})
+	require.NoError(t, err)
+	defer vehicle.Close()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect: &dialect.Dialect{3, []msg.Message{ //nolint:govet
+			&MessageHeartbeat{},
+			&MessageSetupSigning{},
+		}},
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+		InKey:            key,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	err = gcs.SetupSigning(nil, 10, 1, key, initialTimestamp)
+	require.NoError(t, err)
+
+	for evt := range vehicle.Events() {
+		if _, ok := evt.(*EventFrame); ok {
+			break
+		}
+	}
+
+	go func() {
+		for range vehicle.Events() {
+		}
+	}()
+
+	require.Equal(t, &SetupSigningRequest{
+		Channel:          req.Channel,
+		SystemID:         11,
+		ComponentID:      1,
+		Key:              key,
+		InitialTimestamp: initialTimestamp,
+	}, req)
+
+	vehicle.WriteMessageAll(&MessageHeartbeat{Type: 1})
+
+	for evt := range gcs.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, key, fr.Key)
+			require.True(t, fr.Frame.(*frame.V2Frame).SignatureTimestamp >= initialTimestamp)
+			break
+		}
+	}
+}
+
+func TestNodeSetupSigningDisable(t *testing.T) {
+	key := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+
+	c1, c2 := net.Pipe()
+
+	vehicle, err := NewNode(NodeConf{
+		Dialect: &dialect.Dialect{3, []msg.Message{ //nolint:govet
+			&MessageHeartbeat{},
+			&MessageSetupSigning{},
+		}},
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		InKey:            key,
+		OutKey:           key,
+		SetupSigningAccept: func(r *SetupSigningRequest) bool {
+			return r.Key == nil
+		},
+	})
+	require.NoError(t, err)
+	defer vehicle.Close()
+
+	gcs, err := NewNode(NodeConf{
+		Dialect: &dialect.Dialect{3, []msg.Message{ //nolint:govet
+			&MessageHeartbeat{},
+			&MessageSetupSigning{},
+		}},
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+		OutKey:           key,
+	})
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	err = gcs.SetupSigning(nil, 10, 1, nil, 0)
+	require.NoError(t, err)
+
+	for evt := range vehicle.Events() {
+		if _, ok := evt.(*EventFrame); ok {
+			break
+		}
+	}
+
+	go func() {
+		for range vehicle.Events() {
+		}
+	}()
+
+	vehicle.WriteMessageAll(&MessageHeartbeat{Type: 1})
+
+	for evt := range gcs.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Nil(t, fr.Frame.(*frame.V2Frame).Signature)
+			break
+		}
+	}
+}
+
+func TestNodeSetupSigningErrors(t *testing.T) {
+	_, err := NewNode(NodeConf{
+		Dialect:            &dialect.Dialect{3, []msg.Message{&MessageSetupSigning{}}}, //nolint:govet
+		OutVersion:         V1,
+		OutSystemID:        10,
+		SetupSigningAccept: func(*SetupSigningRequest) bool { return true },
+	})
+	require.EqualError(t, err, "SetupSigningAccept requires V2 frames")
+
+	node, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	err = node.SetupSigning(nil, 1, 1, nil, 0)
+	require.EqualError(t, err, "dialect does not support SETUP_SIGNING")
+}
@@ -0,0 +1,155 @@
+package gomavlib
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+const (
+	// period between saves of the signature timestamp
+	signatureTimestampSavePeriod = 10 * time.Second
+)
+
+// SignatureTimestampStore is a persistent storage for the timestamp of
+// signed outgoing frames, in 10 microsecond units since 1st January 2015 GMT.
+type SignatureTimestampStore interface {
+	// Load returns the last saved timestamp, or zero if no timestamp
+	// has been saved yet.
+	Load() (uint64, error)
+
+	// Save saves a timestamp.
+	Save(uint64) error
+}
+
+// SignatureTimestampFile is a SignatureTimestampStore that saves timestamps
+// into a file.
+type SignatureTimestampFile struct {
+	// the path of the file.
+	Path string
+}
+
+// Load implements SignatureTimestampStore.
+func (f SignatureTimestampFile) Load() (uint64, error) {
+	byts, err := ioutil.ReadFile(f.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return strconv.ParseUint(strings.TrimSpace(string(byts)), 10, 64)
+}
+
+// Save implements SignatureTimestampStore.
+func (f SignatureTimestampFile) Save(ts uint64) error {
+	// write a temporary file and rename it, in order not to corrupt the
+	// existing file in case of crashes
+	tmp := f.Path + ".tmp"
+	err := ioutil.WriteFile(tmp, []byte(strconv.FormatUint(ts, 10)+"\n"), 0o644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.Path)
+}
+
+type nodeSignatureTimestamp struct {
+	n     *Node
+	mutex sync.Mutex
+	last  uint64
+	saved uint64
+
+	// in
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+func newNodeSignatureTimestamp(n *Node) (*nodeSignatureTimestamp, error) {
+	// module is disabled
+	if n.conf.OutSignatureTimestampStore == nil {
+		return nil, nil
+	}
+
+	if n.conf.OutKey == nil {
+		return nil, fmt.Errorf("OutSignatureTimestampStore requires OutKey")
+	}
+
+	last, err := n.conf.OutSignatureTimestampStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the signature timestamp: %s", err)
+	}
+
+	// timestamps used after the last save may have been sent before a crash
+	if last != 0 {
+		last += uint64(signatureTimestampSavePeriod / (10 * time.Microsecond))
+	}
+
+	return &nodeSignatureTimestamp{
+		n:         n,
+		last:      last,
+		saved:     last,
+		terminate: make(chan struct{}),
+		done:      make(chan struct{}),
+	}, nil
+}
+
+func (st *nodeSignatureTimestamp) close() {
+	close(st.terminate)
+	<-st.done
+}
+
+func (st *nodeSignatureTimestamp) run() {
+	defer close(st.done)
+
+	ticker := time.NewTicker(signatureTimestampSavePeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			st.save()
+
+		case <-st.terminate:
+			st.save()
+			return
+		}
+	}
+}
+
+// next returns the timestamp of the next signed frame, that is always
+// greater than the previous one and than the persisted one.
+func (st *nodeSignatureTimestamp) next() uint64 {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	ts := transceiver.SignatureTimestamp(time.Now())
+	if ts <= st.last {
+		ts = st.last + 1
+	}
+	st.last = ts
+	return ts
+}
+
+func (st *nodeSignatureTimestamp) save() {
+	st.mutex.Lock()
+	last := st.last
+	st.mutex.Unlock()
+
+	if last == st.saved {
+		return
+	}
+
+	// errors are not fatal, since the timestamp is saved again later
+	if st.n.conf.OutSignatureTimestampStore.Save(last) == nil {
+		st.saved = last
+	}
+}
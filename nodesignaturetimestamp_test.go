@@ -0,0 +1,105 @@
+package gomavlib
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestSignatureTimestampFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomavlib")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	f := SignatureTimestampFile{Path: filepath.Join(dir, "ts")}
+
+	ts, err := f.Load()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), ts)
+
+	err = f.Save(123456)
+	require.NoError(t, err)
+
+	ts, err = f.Load()
+	require.NoError(t, err)
+	require.Equal(t, uint64(123456), ts)
+}
+
+func TestNodeSignatureTimestamp(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomavlib")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	key := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+
+	// a timestamp in the future, emitted before a restart
+	store := SignatureTimestampFile{Path: filepath.Join(dir, "ts")}
+	const prev = uint64(1) << 47
+	err = store.Save(prev)
+	require.NoError(t, err)
+
+	c1, c2 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		InKey:            key,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:                    &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:                 V2,
+		OutSystemID:                11,
+		Endpoints:                  []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable:           true,
+		OutKey:                     key,
+		OutSignatureTimestampStore: store,
+	})
+	require.NoError(t, err)
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	node2.WriteMessageAll(&MessageHeartbeat{Type: 1})
+
+	var sent uint64
+	for evt := range node1.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			sent = fr.Frame.(*frame.V2Frame).SignatureTimestamp
+			require.True(t, sent > prev)
+			break
+		}
+	}
+
+	node2.Close()
+
+	ts, err := store.Load()
+	require.NoError(t, err)
+	require.True(t, ts >= sent)
+}
+
+func TestNodeSignatureTimestampRequiresOutKey(t *testing.T) {
+	_, err := NewNode(NodeConf{
+		Dialect:                    &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:                 V2,
+		OutSystemID:                11,
+		OutSignatureTimestampStore: SignatureTimestampFile{Path: "ts"},
+	})
+	require.EqualError(t, err, "OutSignatureTimestampStore requires OutKey")
+}
@@ -8,9 +8,32 @@ import (
 	"github.com/aler9/gomavlib/pkg/msg"
 )
 
-const (
-	streamRequestPeriod = 30 * time.Second
-)
+// StreamRequest is a stream that is requested to Ardupilot devices.
+type StreamRequest struct {
+	// the stream ID (MAV_DATA_STREAM) or, if MessageInterval is true,
+	// the message ID.
+	ID int
+
+	// (optional) request the stream by setting the interval of the message
+	// with given ID through MAV_CMD_SET_MESSAGE_INTERVAL, instead of using
+	// REQUEST_DATA_STREAM.
+	MessageInterval bool
+
+	// (optional) the frequency in Hz. It defaults to StreamRequestFrequency.
+	Frequency int
+}
+
+// streams requested by QGroundControl.
+// https://github.com/mavlink/qgroundcontrol/blob/08f400355a8f3acf1dd8ed91f7f1c757323ac182/src/FirmwarePlugin/APM/APMFirmwarePlugin.cc#L626
+var streamRequestsDefault = []StreamRequest{
+	{ID: 1},  // common.MAV_DATA_STREAM_RAW_SENSORS,
+	{ID: 2},  // common.MAV_DATA_STREAM_EXTENDED_STATUS,
+	{ID: 3},  // common.MAV_DATA_STREAM_RC_CHANNELS,
+	{ID: 6},  // common.MAV_DATA_STREAM_POSITION,
+	{ID: 10}, // common.MAV_DATA_STREAM_EXTRA1,
+	{ID: 11}, // common.MAV_DATA_STREAM_EXTRA2,
+	{ID: 12}, // common.MAV_DATA_STREAM_EXTRA3,
+}
 
 type streamNode struct {
 	Channel     *Channel
@@ -18,12 +41,18 @@ type streamNode struct {
 	ComponentID byte
 }
 
+type streamNodeState struct {
+	lastRequest   time.Time
+	lastHeartbeat time.Time
+}
+
 type nodeStreamRequest struct {
 	n                    *Node
 	msgHeartbeat         msg.Message
 	msgRequestDataStream msg.Message
-	lastRequestsMutex    sync.Mutex
-	lastRequests         map[streamNode]time.Time
+	msgCommandLong       msg.Message
+	nodesMutex           sync.Mutex
+	nodes                map[streamNode]*streamNodeState
 
 	// in
 	terminate chan struct{}
@@ -77,11 +106,16 @@ func newNodeStreamRequest(n *Node) *nodeStreamRequest {
 		return nil
 	}
 
+	// command message is needed only by streams requested with
+	// MAV_CMD_SET_MESSAGE_INTERVAL
+	msgCommandLong := dialectMessage(n.conf.Dialect, 76, 152)
+
 	sr := &nodeStreamRequest{
 		n:                    n,
 		msgHeartbeat:         msgHeartbeat,
 		msgRequestDataStream: msgRequestDataStream,
-		lastRequests:         make(map[streamNode]time.Time),
+		msgCommandLong:       msgCommandLong,
+		nodes:                make(map[streamNode]*streamNodeState),
 		terminate:            make(chan struct{}),
 		done:                 make(chan struct{}),
 	}
@@ -97,20 +131,20 @@ func (sr *nodeStreamRequest) close() {
 func (sr *nodeStreamRequest) run() {
 	defer close(sr.done)
 
-	ticker := time.NewTicker(30 * time.Second)
+	ticker := sr.n.conf.Clock.NewTicker(sr.n.conf.StreamRequestPeriod)
 	defer ticker.Stop()
 
 	for {
 		select {
 		// periodic cleanup
-		case now := <-ticker.C:
+		case now := <-ticker.C():
 			func() {
-				sr.lastRequestsMutex.Lock()
-				defer sr.lastRequestsMutex.Unlock()
+				sr.nodesMutex.Lock()
+				defer sr.nodesMutex.Unlock()
 
-				for rnode, t := range sr.lastRequests {
-					if now.Sub(t) >= streamRequestPeriod {
-						delete(sr.lastRequests, rnode)
+				for rnode, st := range sr.nodes {
+					if now.Sub(st.lastHeartbeat) >= sr.n.conf.SystemTimeout {
+						delete(sr.nodes, rnode)
 					}
 				}
 			}()
@@ -123,8 +157,8 @@ func (sr *nodeStreamRequest) run() {
 
 func (sr *nodeStreamRequest) onEventFrame(evt *EventFrame) {
 	// message must be heartbeat and sender must be an ardupilot device
-	if evt.Message().GetID() != 0 ||
-		reflect.ValueOf(evt.Message()).Elem().FieldByName("Autopilot").Int() != 3 {
+	if evt.messageID() != 0 ||
+		reflectInt(reflect.ValueOf(evt.Message()).Elem().FieldByName("Autopilot")) != 3 {
 		return
 	}
 
@@ -134,50 +168,90 @@ func (sr *nodeStreamRequest) onEventFrame(evt *EventFrame) {
 		ComponentID: evt.ComponentID(),
 	}
 
-	// request streams if sender is new or a request has not been sent in some time
+	// request streams if sender is new, has reconnected or
+	// a request has not been sent in some time
 	request := false
 	func() {
-		sr.lastRequestsMutex.Lock()
-		defer sr.lastRequestsMutex.Unlock()
+		sr.nodesMutex.Lock()
+		defer sr.nodesMutex.Unlock()
 
-		now := time.Now()
+		now := sr.n.conf.Clock.Now()
+
+		st, ok := sr.nodes[rnode]
+		switch {
+		case !ok:
+			st = &streamNodeState{}
+			sr.nodes[rnode] = st
+			request = true
 
-		if _, ok := sr.lastRequests[rnode]; !ok {
-			sr.lastRequests[rnode] = time.Now()
+		// heartbeats were interrupted, the link has been re-established
+		// or the device has been rebooted
+		case now.Sub(st.lastHeartbeat) >= sr.n.conf.SystemTimeout:
 			request = true
 
-		} else if now.Sub(sr.lastRequests[rnode]) >= streamRequestPeriod {
+		case now.Sub(st.lastRequest) >= sr.n.conf.StreamRequestPeriod:
 			request = true
-			sr.lastRequests[rnode] = now
 		}
-	}()
 
-	if request {
-		// https://github.com/mavlink/qgroundcontrol/blob/08f400355a8f3acf1dd8ed91f7f1c757323ac182/src/FirmwarePlugin/APM/APMFirmwarePlugin.cc#L626
-		streams := []int{
-			1,  // common.MAV_DATA_STREAM_RAW_SENSORS,
-			2,  // common.MAV_DATA_STREAM_EXTENDED_STATUS,
-			3,  // common.MAV_DATA_STREAM_RC_CHANNELS,
-			6,  // common.MAV_DATA_STREAM_POSITION,
-			10, // common.MAV_DATA_STREAM_EXTRA1,
-			11, // common.MAV_DATA_STREAM_EXTRA2,
-			12, // common.MAV_DATA_STREAM_EXTRA3,
+		st.lastHeartbeat = now
+		if request {
+			st.lastRequest = now
 		}
+	}()
 
-		for _, stream := range streams {
-			m := reflect.New(reflect.TypeOf(sr.msgRequestDataStream).Elem())
-			m.Elem().FieldByName("TargetSystem").SetUint(uint64(evt.SystemID()))
-			m.Elem().FieldByName("TargetComponent").SetUint(uint64(evt.ComponentID()))
-			m.Elem().FieldByName("ReqStreamId").SetUint(uint64(stream))
-			m.Elem().FieldByName("ReqMessageRate").SetUint(uint64(sr.n.conf.StreamRequestFrequency))
-			m.Elem().FieldByName("StartStop").SetUint(uint64(1))
-			sr.n.WriteMessageTo(evt.Channel, m.Interface().(msg.Message))
+	if request {
+		for _, req := range sr.n.conf.StreamRequests {
+			m := sr.encode(evt.SystemID(), evt.ComponentID(), req)
+			if m != nil {
+				sr.n.WriteMessageTo(evt.Channel, m)
+			}
 		}
 
-		sr.n.events <- &EventStreamRequested{
+		sr.n.emitEvent(&EventStreamRequested{
 			Channel:     evt.Channel,
 			SystemID:    evt.SystemID(),
 			ComponentID: evt.ComponentID(),
+		})
+	}
+}
+
+func (sr *nodeStreamRequest) encode(systemID byte, componentID byte, req StreamRequest) msg.Message {
+	frequency := req.Frequency
+	if frequency == 0 {
+		frequency = sr.n.conf.StreamRequestFrequency
+	}
+
+	if req.MessageInterval {
+		if sr.msgCommandLong == nil {
+			return nil
+		}
+
+		m := newMessage(sr.msgCommandLong).Elem()
+		m.FieldByName("TargetSystem").SetUint(uint64(systemID))
+		m.FieldByName("TargetComponent").SetUint(uint64(componentID))
+		reflectSetInt(m.FieldByName("Command"), commandSetMessageInterval)
+		m.FieldByName("Param1").SetFloat(float64(req.ID))
+		m.FieldByName("Param2").SetFloat(float64(1000000 / frequency))
+		return m.Addr().Interface().(msg.Message)
+	}
+
+	m := newMessage(sr.msgRequestDataStream).Elem()
+	m.FieldByName("TargetSystem").SetUint(uint64(systemID))
+	m.FieldByName("TargetComponent").SetUint(uint64(componentID))
+	m.FieldByName("ReqStreamId").SetUint(uint64(req.ID))
+	m.FieldByName("ReqMessageRate").SetUint(uint64(frequency))
+	m.FieldByName("StartStop").SetUint(uint64(1))
+	return m.Addr().Interface().(msg.Message)
+}
+
+func (sr *nodeStreamRequest) onChannelClose(ch *Channel) {
+	sr.nodesMutex.Lock()
+	defer sr.nodesMutex.Unlock()
+
+	// streams are requested again when devices reconnect
+	for rnode := range sr.nodes {
+		if rnode.Channel == ch {
+			delete(sr.nodes, rnode)
 		}
 	}
 }
@@ -0,0 +1,88 @@
+package gomavlib
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// frameSubscriber receives the incoming frames that match a filter.
+// It is used by the protocol helpers in order to wait for responses.
+type frameSubscriber struct {
+	filter func(*EventFrame) bool
+	frames chan *EventFrame
+}
+
+type frameSubscribers struct {
+	mutex sync.Mutex
+	subs  map[*frameSubscriber]struct{}
+}
+
+func (fs *frameSubscribers) subscribe(filter func(*EventFrame) bool) *frameSubscriber {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	if fs.subs == nil {
+		fs.subs = make(map[*frameSubscriber]struct{})
+	}
+
+	sub := &frameSubscriber{
+		filter: filter,
+		frames: make(chan *EventFrame, 64),
+	}
+	fs.subs[sub] = struct{}{}
+	return sub
+}
+
+func (fs *frameSubscribers) unsubscribe(sub *frameSubscriber) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	delete(fs.subs, sub)
+}
+
+func (fs *frameSubscribers) dispatch(evt *EventFrame) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	var detached *EventFrame
+
+	for sub := range fs.subs {
+		if !sub.filter(evt) {
+			continue
+		}
+
+		// subscribers use the event after its release
+		if detached == nil {
+			detached = evt.detach()
+		}
+
+		// do not block the channel if the subscriber is slow
+		select {
+		case sub.frames <- detached:
+		default:
+		}
+	}
+}
+
+// wait waits for a frame until the timeout expires.
+func (sub *frameSubscriber) wait(ctx context.Context, n *Node, timeout time.Duration) (*EventFrame, error) {
+	// a ticker is used as a timer, since its first tick is emitted after
+	// the timeout
+	timer := n.conf.Clock.NewTicker(timeout)
+	defer timer.Stop()
+
+	select {
+	case evt := <-sub.frames:
+		return evt, nil
+
+	case <-timer.C():
+		return nil, errorTimeout
+
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	case <-n.ctx.Done():
+		return nil, errorTerminated
+	}
+}
@@ -0,0 +1,109 @@
+package gomavlib
+
+import (
+	"sync"
+	"time"
+)
+
+type systemEventsKey struct {
+	systemID    byte
+	componentID byte
+}
+
+type nodeSystemEvents struct {
+	n              *Node
+	mutex          sync.Mutex
+	lastHeartbeats map[systemEventsKey]time.Time
+
+	// in
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+func newNodeSystemEvents(n *Node) *nodeSystemEvents {
+	// module is disabled
+	if !n.conf.SystemEventsEnable {
+		return nil
+	}
+
+	return &nodeSystemEvents{
+		n:              n,
+		lastHeartbeats: make(map[systemEventsKey]time.Time),
+		terminate:      make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+}
+
+func (se *nodeSystemEvents) close() {
+	close(se.terminate)
+	<-se.done
+}
+
+func (se *nodeSystemEvents) run() {
+	defer close(se.done)
+
+	// check timeouts with a resolution that is a fraction of the timeout
+	ticker := se.n.conf.Clock.NewTicker(se.n.conf.SystemTimeout / 10)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			for _, k := range se.expired() {
+				se.n.emitEvent(&EventSystemOffline{
+					SystemID:    k.systemID,
+					ComponentID: k.componentID,
+				})
+			}
+
+		case <-se.terminate:
+			return
+		}
+	}
+}
+
+// expired removes and returns the systems whose heartbeat has timed out.
+func (se *nodeSystemEvents) expired() []systemEventsKey {
+	se.mutex.Lock()
+	defer se.mutex.Unlock()
+
+	now := se.n.conf.Clock.Now()
+	var out []systemEventsKey
+
+	for k, t := range se.lastHeartbeats {
+		if now.Sub(t) >= se.n.conf.SystemTimeout {
+			delete(se.lastHeartbeats, k)
+			out = append(out, k)
+		}
+	}
+
+	return out
+}
+
+func (se *nodeSystemEvents) onEventFrame(evt *EventFrame) {
+	// message must be a HEARTBEAT
+	if evt.messageID() != 0 {
+		return
+	}
+
+	k := systemEventsKey{evt.SystemID(), evt.ComponentID()}
+
+	online := func() bool {
+		se.mutex.Lock()
+		defer se.mutex.Unlock()
+
+		_, ok := se.lastHeartbeats[k]
+		se.lastHeartbeats[k] = se.n.conf.Clock.Now()
+		return !ok
+	}()
+
+	if online {
+		se.n.emitEvent(&EventSystemOnline{
+			SystemID:    k.systemID,
+			ComponentID: k.componentID,
+			Channel:     evt.Channel,
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package gomavlib
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestNodeSystemEvents(t *testing.T) {
+	c1, c2 := net.Pipe()
+	clock := newTestClock()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:            &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:         V2,
+		OutSystemID:        10,
+		Endpoints:          []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable:   true,
+		SystemEventsEnable: true,
+		SystemTimeout:      500 * time.Millisecond,
+		Clock:              clock,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	evt := <-node1.Events()
+	require.IsType(t, &EventChannelOpen{}, evt)
+	ch := evt.(*EventChannelOpen).Channel
+
+	for i := 0; i < 2; i++ {
+		node2.WriteMessageAll(&MessageHeartbeat{
+			Type:           1,
+			Autopilot:      2,
+			BaseMode:       3,
+			CustomMode:     6,
+			SystemStatus:   4,
+			MavlinkVersion: 5,
+		})
+
+		evt = <-node1.Events()
+		require.Equal(t, &EventSystemOnline{
+			SystemID:    11,
+			ComponentID: 1,
+			Channel:     ch,
+		}, evt)
+
+		evt = <-node1.Events()
+		require.IsType(t, &EventFrame{}, evt)
+
+		// the system is still online
+		select {
+		case evt = <-node1.Events():
+			t.Fatalf("unexpected event %T", evt)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		// advance the clock until the timeout is detected
+	outer:
+		for {
+			clock.advance(50 * time.Millisecond)
+			select {
+			case evt = <-node1.Events():
+				break outer
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+
+		require.Equal(t, &EventSystemOffline{
+			SystemID:    11,
+			ComponentID: 1,
+		}, evt)
+	}
+}
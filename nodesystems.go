@@ -0,0 +1,150 @@
+package gomavlib
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// System contains informations about a system or component that sent
+// frames to the node.
+type System struct {
+	// the system id.
+	SystemID byte
+
+	// the component id.
+	ComponentID byte
+
+	// the time of the last received frame.
+	LastSeen time.Time
+
+	// the time of the last received heartbeat.
+	// It is zero if no heartbeat has been received.
+	LastHeartbeat time.Time
+
+	// the type of the system (MAV_TYPE), as reported by the heartbeat.
+	Type int
+
+	// the autopilot type (MAV_AUTOPILOT), as reported by the heartbeat.
+	Autopilot int
+
+	// the capabilities of the system (MAV_PROTOCOL_CAPABILITY), as reported
+	// by AUTOPILOT_VERSION. It is zero if AUTOPILOT_VERSION has not been
+	// received. AUTOPILOT_VERSION can be requested with SendCommand() and
+	// MAV_CMD_REQUEST_MESSAGE.
+	Capabilities uint64
+
+	// the channels from which frames of the system have been received.
+	Channels []*Channel
+}
+
+type systemsKey struct {
+	systemID    byte
+	componentID byte
+}
+
+type systemsEntry struct {
+	sys      System
+	channels map[*Channel]struct{}
+}
+
+type nodeSystems struct {
+	n                   *Node
+	msgHeartbeat        msg.Message
+	msgAutopilotVersion msg.Message
+	mutex               sync.Mutex
+	entries             map[systemsKey]*systemsEntry
+}
+
+func newNodeSystems(n *Node) *nodeSystems {
+	return &nodeSystems{
+		n:                   n,
+		msgHeartbeat:        dialectMessage(n.conf.Dialect, 0, 50),
+		msgAutopilotVersion: dialectMessage(n.conf.Dialect, 148, 178),
+		entries:             make(map[systemsKey]*systemsEntry),
+	}
+}
+
+func (s *nodeSystems) onChannelClose(ch *Channel) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, e := range s.entries {
+		delete(e.channels, ch)
+	}
+}
+
+func (s *nodeSystems) onEventFrame(evt *EventFrame) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	k := systemsKey{evt.SystemID(), evt.ComponentID()}
+	e, ok := s.entries[k]
+	if !ok {
+		e = &systemsEntry{
+			sys: System{
+				SystemID:    k.systemID,
+				ComponentID: k.componentID,
+			},
+			channels: make(map[*Channel]struct{}),
+		}
+		s.entries[k] = e
+	}
+
+	now := s.n.conf.Clock.Now()
+	e.sys.LastSeen = now
+	e.channels[evt.Channel] = struct{}{}
+
+	switch evt.messageID() {
+	case 0:
+		e.sys.LastHeartbeat = now
+
+		if s.msgHeartbeat != nil && reflect.TypeOf(evt.Message()) == reflect.TypeOf(s.msgHeartbeat) {
+			m := msgValue(evt.Message())
+			e.sys.Type = int(reflectUint(m.FieldByName("Type")))
+			e.sys.Autopilot = int(reflectUint(m.FieldByName("Autopilot")))
+		}
+
+	case 148:
+		if s.msgAutopilotVersion != nil &&
+			reflect.TypeOf(evt.Message()) == reflect.TypeOf(s.msgAutopilotVersion) {
+			m := msgValue(evt.Message())
+			e.sys.Capabilities = reflectUint(m.FieldByName("Capabilities"))
+		}
+	}
+}
+
+// Systems returns the systems and components that sent frames to the node.
+func (n *Node) Systems() []*System {
+	s := n.nodeSystems
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	out := make([]*System, 0, len(s.entries))
+	for _, e := range s.entries {
+		sys := e.sys
+
+		sys.Channels = make([]*Channel, 0, len(e.channels))
+		for ch := range e.channels {
+			sys.Channels = append(sys.Channels, ch)
+		}
+		sort.Slice(sys.Channels, func(i, j int) bool {
+			return sys.Channels[i].label < sys.Channels[j].label
+		})
+
+		out = append(out, &sys)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].SystemID != out[j].SystemID {
+			return out[i].SystemID < out[j].SystemID
+		}
+		return out[i].ComponentID < out[j].ComponentID
+	})
+
+	return out
+}
@@ -0,0 +1,83 @@
+package gomavlib
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeSystems(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	require.Equal(t, []*System{}, node1.Systems())
+
+	node2.WriteMessageAll(&common.MessageHeartbeat{
+		Type:           common.MAV_TYPE_QUADROTOR,
+		Autopilot:      common.MAV_AUTOPILOT_ARDUPILOTMEGA,
+		SystemStatus:   common.MAV_STATE_ACTIVE,
+		MavlinkVersion: 3,
+	})
+
+	node2.WriteMessageAll(&common.MessageAutopilotVersion{
+		Capabilities: common.MAV_PROTOCOL_CAPABILITY_MISSION_INT |
+			common.MAV_PROTOCOL_CAPABILITY_MAVLINK2,
+	})
+
+	var ch *Channel
+	frames := 0
+	for evt := range node1.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			ch = fr.Channel
+			frames++
+			if frames == 2 {
+				break
+			}
+		}
+	}
+
+	systems := node1.Systems()
+	require.Equal(t, 1, len(systems))
+
+	sys := systems[0]
+	require.False(t, sys.LastSeen.IsZero())
+	require.False(t, sys.LastHeartbeat.IsZero())
+	require.Equal(t, &System{
+		SystemID:      11,
+		ComponentID:   1,
+		LastSeen:      sys.LastSeen,
+		LastHeartbeat: sys.LastHeartbeat,
+		Type:          int(common.MAV_TYPE_QUADROTOR),
+		Autopilot:     int(common.MAV_AUTOPILOT_ARDUPILOTMEGA),
+		Capabilities: uint64(common.MAV_PROTOCOL_CAPABILITY_MISSION_INT |
+			common.MAV_PROTOCOL_CAPABILITY_MAVLINK2),
+		Channels: []*Channel{ch},
+	}, sys)
+}
@@ -0,0 +1,165 @@
+package gomavlib
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	// weight of new samples in the offset and round-trip time filters
+	timesyncFilterAlpha = 0.1
+
+	// samples needed before starting to reject outliers
+	timesyncConvergenceSamples = 5
+
+	// samples whose round-trip time is greater than the filtered
+	// round-trip time multiplied by this value are rejected
+	timesyncMaxRTTRatio = 3
+
+	// responses to requests older than this are discarded
+	timesyncMaxRTT = 10 * time.Second
+)
+
+// Timesync contains the clock synchronization status of a system,
+// estimated with the TIMESYNC protocol.
+type Timesync struct {
+	// the offset between the clock of the system and the local clock.
+	// The time of the system is the local time plus the offset.
+	Offset time.Duration
+
+	// the round-trip time between the node and the system.
+	RTT time.Duration
+
+	// the number of samples used to compute offset and round-trip time.
+	Samples int
+}
+
+type nodeTimesync struct {
+	n            *Node
+	msgTimesync  msg.Message
+	systemsMutex sync.Mutex
+	systems      map[byte]*Timesync
+
+	// in
+	terminate chan struct{}
+
+	// out
+	done chan struct{}
+}
+
+func newNodeTimesync(n *Node) *nodeTimesync {
+	// module is disabled
+	if !n.conf.TimesyncEnable {
+		return nil
+	}
+
+	// timesync message must exist in dialect and correspond to standard
+	msgTimesync := dialectMessage(n.conf.Dialect, 111, 34)
+	if msgTimesync == nil {
+		return nil
+	}
+
+	return &nodeTimesync{
+		n:           n,
+		msgTimesync: msgTimesync,
+		systems:     make(map[byte]*Timesync),
+		terminate:   make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+func (ts *nodeTimesync) close() {
+	close(ts.terminate)
+	<-ts.done
+}
+
+func (ts *nodeTimesync) run() {
+	defer close(ts.done)
+
+	ticker := time.NewTicker(ts.n.conf.TimesyncPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ts.n.WriteMessageAll(ts.newTimesync(0, time.Now().UnixNano()))
+
+		case <-ts.terminate:
+			return
+		}
+	}
+}
+
+func (ts *nodeTimesync) newTimesync(tc1 int64, ts1 int64) msg.Message {
+	m := newMessage(ts.msgTimesync).Elem()
+	m.FieldByName("Tc1").SetInt(tc1)
+	m.FieldByName("Ts1").SetInt(ts1)
+	return m.Addr().Interface().(msg.Message)
+}
+
+func (ts *nodeTimesync) onEventFrame(evt *EventFrame) {
+	// message must be TIMESYNC
+	if evt.messageID() != 111 {
+		return
+	}
+
+	now := time.Now().UnixNano()
+	m := msgValue(evt.Message())
+	tc1 := m.FieldByName("Tc1").Int()
+	ts1 := m.FieldByName("Ts1").Int()
+
+	// message is a request: send a response
+	if tc1 == 0 {
+		ts.n.WriteMessageTo(evt.Channel, ts.newTimesync(now, ts1))
+		return
+	}
+
+	// message is a response to one of our requests
+	rtt := now - ts1
+	if rtt < 0 || rtt > int64(timesyncMaxRTT) {
+		return
+	}
+	offset := tc1 - (ts1+now)/2
+
+	ts.systemsMutex.Lock()
+	defer ts.systemsMutex.Unlock()
+
+	sys, ok := ts.systems[evt.SystemID()]
+	if !ok {
+		ts.systems[evt.SystemID()] = &Timesync{
+			Offset:  time.Duration(offset),
+			RTT:     time.Duration(rtt),
+			Samples: 1,
+		}
+		return
+	}
+
+	// reject outliers
+	if sys.Samples >= timesyncConvergenceSamples &&
+		time.Duration(rtt) > sys.RTT*timesyncMaxRTTRatio {
+		return
+	}
+
+	sys.Offset += time.Duration(timesyncFilterAlpha * float64(time.Duration(offset)-sys.Offset))
+	sys.RTT += time.Duration(timesyncFilterAlpha * float64(time.Duration(rtt)-sys.RTT))
+	sys.Samples++
+}
+
+// Timesync returns the clock synchronization status of a system, estimated
+// with the TIMESYNC protocol. It requires TimesyncEnable to be true.
+func (n *Node) Timesync(systemID byte) (Timesync, bool) {
+	if n.nodeTimesync == nil {
+		return Timesync{}, false
+	}
+
+	n.nodeTimesync.systemsMutex.Lock()
+	defer n.nodeTimesync.systemsMutex.Unlock()
+
+	sys, ok := n.nodeTimesync.systems[systemID]
+	if !ok {
+		return Timesync{}, false
+	}
+	return *sys, true
+}
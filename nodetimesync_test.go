@@ -0,0 +1,57 @@
+package gomavlib
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestNodeTimesync(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+		TimesyncEnable:   true,
+		TimesyncPeriod:   20 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+		TimesyncEnable:   true,
+		TimesyncPeriod:   20 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	_, ok := node1.Timesync(11)
+	require.False(t, ok)
+
+	for evt := range node1.Events() {
+		if _, ok := evt.(*EventFrame); ok {
+			if ts, ok := node1.Timesync(11); ok && ts.Samples >= 3 {
+				// clocks are the same, therefore offset must be smaller than RTT
+				require.True(t, ts.Offset <= ts.RTT && ts.Offset >= -ts.RTT)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package gomavlib
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+// gaps larger than this value are caused by reordered frames or by senders
+// that restarted, and are not counted as losses. The sequence is restarted
+// instead.
+const sequenceMaxGap = 128
+
+type sequenceKey struct {
+	systemID    byte
+	componentID byte
+}
+
+type sequenceState struct {
+	last     byte
+	lastSeen time.Time
+	received uint64
+	lost     uint64
+}
+
+// sequenceTracker detects lost frames by looking for gaps in the sequence
+// numbers of the frames received by a channel. Every component has its
+// own sequence, that is removed when no frames are received within
+// the timeout.
+type sequenceTracker struct {
+	timeout time.Duration
+
+	mutex     sync.Mutex
+	states    map[sequenceKey]*sequenceState
+	lastPurge time.Time
+}
+
+func frameSequenceID(fr frame.Frame) byte {
+	switch ff := fr.(type) {
+	case *frame.V1Frame:
+		return ff.SequenceID
+
+	case *frame.V2Frame:
+		return ff.SequenceID
+	}
+	return 0
+}
+
+// purge removes the sequences of components that stopped sending frames.
+func (t *sequenceTracker) purge(now time.Time) {
+	if now.Sub(t.lastPurge) < t.timeout {
+		return
+	}
+	t.lastPurge = now
+
+	for k, st := range t.states {
+		if now.Sub(st.lastSeen) >= t.timeout {
+			delete(t.states, k)
+		}
+	}
+}
+
+// track processes a frame received at the given time and returns the number
+// of frames that have been lost before it, and whether the sequence has
+// been restarted.
+func (t *sequenceTracker) track(fr frame.Frame, now time.Time) (int, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.states == nil {
+		t.states = make(map[sequenceKey]*sequenceState)
+	}
+
+	t.purge(now)
+
+	seq := frameSequenceID(fr)
+	k := sequenceKey{fr.GetSystemID(), fr.GetComponentID()}
+
+	st, ok := t.states[k]
+	if !ok {
+		t.states[k] = &sequenceState{last: seq, lastSeen: now, received: 1}
+		return 0, false
+	}
+
+	st.lastSeen = now
+	st.received++
+
+	// duplicate
+	if seq == st.last {
+		return 0, false
+	}
+
+	gap := int(seq - st.last - 1)
+	st.last = seq
+
+	if gap > sequenceMaxGap {
+		return 0, true
+	}
+
+	st.lost += uint64(gap)
+	return gap, false
+}
+
+// packetLoss returns the percentage of lost frames of a system.
+func (t *sequenceTracker) packetLoss(systemID byte) (float64, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var received, lost uint64
+	found := false
+
+	for k, st := range t.states {
+		if k.systemID == systemID {
+			received += st.received
+			lost += st.lost
+			found = true
+		}
+	}
+
+	if !found {
+		return 0, false
+	}
+	return lossPercentage(received, lost), true
+}
+
+func lossPercentage(received uint64, lost uint64) float64 {
+	if received+lost == 0 {
+		return 0
+	}
+	return float64(lost) * 100 / float64(received+lost)
+}
+
+// PacketLoss returns the percentage of frames of a system that were lost
+// on the channel, detected through gaps in their sequence numbers.
+// It returns false if no frames of the system have been received.
+func (ch *Channel) PacketLoss(systemID byte) (float64, bool) {
+	return ch.sequences.packetLoss(systemID)
+}
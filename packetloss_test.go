@@ -0,0 +1,145 @@
+package gomavlib
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+func TestSequenceTracker(t *testing.T) {
+	st := sequenceTracker{timeout: 10 * time.Second}
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, ca := range []struct {
+		systemID    byte
+		componentID byte
+		seq         byte
+		lost        int
+		restarted   bool
+	}{
+		{1, 1, 254, 0, false},
+		{1, 1, 255, 0, false},
+		{1, 1, 1, 1, false}, // wrap around
+		{1, 2, 10, 0, false},
+		{1, 2, 11, 0, false},
+		{1, 1, 1, 0, false}, // duplicate
+		{1, 1, 2, 0, false},
+		{1, 1, 200, 0, true}, // restart
+		{1, 1, 203, 2, false},
+	} {
+		lost, restarted := st.track(&frame.V2Frame{
+			SystemID:    ca.systemID,
+			ComponentID: ca.componentID,
+			SequenceID:  ca.seq,
+		}, now)
+		require.Equal(t, ca.lost, lost)
+		require.Equal(t, ca.restarted, restarted)
+	}
+
+	loss, ok := st.packetLoss(1)
+	require.Equal(t, true, ok)
+	require.Equal(t, float64(3)*100/12, loss)
+
+	_, ok = st.packetLoss(2)
+	require.Equal(t, false, ok)
+}
+
+func TestSequenceTrackerExpiry(t *testing.T) {
+	st := sequenceTracker{timeout: 10 * time.Second}
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	st.track(&frame.V2Frame{SystemID: 1, ComponentID: 1, SequenceID: 10}, now)
+	st.track(&frame.V2Frame{SystemID: 2, ComponentID: 1, SequenceID: 10}, now)
+
+	now = now.Add(5 * time.Second)
+	st.track(&frame.V2Frame{SystemID: 2, ComponentID: 1, SequenceID: 11}, now)
+
+	// the sequence of system 1 is removed, while the one of system 2 is not
+	now = now.Add(6 * time.Second)
+	lost, restarted := st.track(&frame.V2Frame{SystemID: 2, ComponentID: 1, SequenceID: 13}, now)
+	require.Equal(t, 1, lost)
+	require.Equal(t, false, restarted)
+
+	_, ok := st.packetLoss(1)
+	require.Equal(t, false, ok)
+	require.Equal(t, 1, len(st.states))
+
+	// a gap after the expiry is not counted as a loss
+	lost, _ = st.track(&frame.V2Frame{SystemID: 1, ComponentID: 1, SequenceID: 20}, now)
+	require.Equal(t, 0, lost)
+}
+
+func TestNodePacketLoss(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	node, err := NewNode(NodeConf{
+		Dialect:                &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:             V2,
+		OutSystemID:            10,
+		Endpoints:              []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable:       true,
+		PacketLossEventsEnable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	de, err := dialect.NewDecEncoder(&dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}) //nolint:govet
+	require.NoError(t, err)
+
+	tr, err := transceiver.New(transceiver.Conf{
+		Reader:      c2,
+		Writer:      c2,
+		DialectDE:   de,
+		OutVersion:  transceiver.V2,
+		OutSystemID: 11,
+	})
+	require.NoError(t, err)
+
+	go func() {
+		for _, seq := range []byte{0, 1, 4, 5} {
+			tr.WriteFrameRewritten(&frame.V2Frame{ //nolint:errcheck
+				SequenceID:  seq,
+				SystemID:    11,
+				ComponentID: 1,
+				Message:     &MessageHeartbeat{Type: MAV_TYPE(seq)},
+			})
+		}
+	}()
+
+	var ch *Channel
+	count := 0
+
+	for evt := range node.Events() {
+		switch tevt := evt.(type) {
+		case *EventPacketLoss:
+			require.Equal(t, &EventPacketLoss{
+				Channel:     tevt.Channel,
+				SystemID:    11,
+				ComponentID: 1,
+				Lost:        2,
+			}, tevt)
+
+		case *EventFrame:
+			ch = tevt.Channel
+			count++
+		}
+
+		if count == 4 {
+			break
+		}
+	}
+
+	require.Equal(t, uint64(2), ch.Stats().LostFrames)
+	require.Equal(t, float64(2)*100/6, node.Stats().PacketLoss())
+
+	loss, ok := ch.PacketLoss(11)
+	require.Equal(t, true, ok)
+	require.Equal(t, float64(2)*100/6, loss)
+}
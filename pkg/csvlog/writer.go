@@ -0,0 +1,208 @@
+// Package csvlog contains a writer that exports messages into CSV files,
+// one for each message type, that can be opened with spreadsheets and
+// data-analysis tools.
+// Each file is named after the message (i.e. HEARTBEAT.csv) and contains
+// a column with the reception time, columns with the system and component
+// IDs of the sender, and a column for each message field. Arrays are split
+// into a column for each element.
+package csvlog
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type writerFile struct {
+	f  *os.File
+	w  *csv.Writer
+	de *msg.DecEncoder
+}
+
+func newWriterFile(dir string, m msg.Message) (*writerFile, error) {
+	de, err := msg.NewDecEncoder(m)
+	if err != nil {
+		return nil, fmt.Errorf("message %T: %s", m, err)
+	}
+
+	f, err := os.Create(filepath.Join(dir, de.Name()+".csv"))
+	if err != nil {
+		return nil, err
+	}
+
+	wf := &writerFile{
+		f:  f,
+		w:  csv.NewWriter(f),
+		de: de,
+	}
+
+	header := []string{"time", "system_id", "component_id"}
+	rv := reflect.ValueOf(m).Elem()
+	for i, fd := range de.Fields() {
+		if fv := rv.Field(i); fv.Kind() == reflect.Array {
+			for j := 0; j < fv.Len(); j++ {
+				header = append(header, fd.Name+"_"+strconv.Itoa(j))
+			}
+		} else {
+			header = append(header, fd.Name)
+		}
+	}
+
+	err = wf.w.Write(header)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return wf, nil
+}
+
+func (wf *writerFile) close() error {
+	wf.w.Flush()
+	err := wf.w.Error()
+	err2 := wf.f.Close()
+	if err != nil {
+		return err
+	}
+	return err2
+}
+
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32)
+
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	}
+
+	return v.String()
+}
+
+func (wf *writerFile) write(t time.Time, fr frame.Frame) error {
+	record := []string{
+		t.UTC().Format(time.RFC3339Nano),
+		strconv.FormatUint(uint64(fr.GetSystemID()), 10),
+		strconv.FormatUint(uint64(fr.GetComponentID()), 10),
+	}
+
+	rv := reflect.ValueOf(fr.GetMessage()).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		if fv := rv.Field(i); fv.Kind() == reflect.Array {
+			for j := 0; j < fv.Len(); j++ {
+				record = append(record, formatValue(fv.Index(j)))
+			}
+		} else {
+			record = append(record, formatValue(fv))
+		}
+	}
+
+	return wf.w.Write(record)
+}
+
+// Writer writes messages into CSV files.
+type Writer struct {
+	dir      string
+	selected map[reflect.Type]struct{}
+	files    map[reflect.Type]*writerFile
+}
+
+// NewWriter allocates a Writer, that writes CSV files into the given
+// directory. Only messages of the given types are written; if no type
+// is provided, all messages are written.
+// Files are created when the first message of each type is written.
+func NewWriter(dir string, messages ...msg.Message) (*Writer, error) {
+	st, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !st.IsDir() {
+		return nil, fmt.Errorf("'%s' is not a directory", dir)
+	}
+
+	w := &Writer{
+		dir:   dir,
+		files: make(map[reflect.Type]*writerFile),
+	}
+
+	if len(messages) > 0 {
+		w.selected = make(map[reflect.Type]struct{})
+		for _, m := range messages {
+			_, err := msg.NewDecEncoder(m)
+			if err != nil {
+				return nil, fmt.Errorf("message %T: %s", m, err)
+			}
+			w.selected[reflect.TypeOf(m)] = struct{}{}
+		}
+	}
+
+	return w, nil
+}
+
+// Close closes the Writer and all the CSV files.
+func (w *Writer) Close() error {
+	var ret error
+	for _, wf := range w.files {
+		err := wf.close()
+		if err != nil && ret == nil {
+			ret = err
+		}
+	}
+	return ret
+}
+
+// WriteFrame writes the message of a frame, received at the given time.
+// Frames with messages that are not selected or that are not decoded
+// (*msg.MessageRaw) are ignored.
+// It must not be called by multiple routines in parallel.
+func (w *Writer) WriteFrame(t time.Time, fr frame.Frame) error {
+	m := fr.GetMessage()
+	if _, ok := m.(*msg.MessageRaw); ok {
+		return nil
+	}
+
+	typ := reflect.TypeOf(m)
+
+	if w.selected != nil {
+		if _, ok := w.selected[typ]; !ok {
+			return nil
+		}
+	}
+
+	wf, ok := w.files[typ]
+	if !ok {
+		var err error
+		wf, err = newWriterFile(w.dir, m)
+		if err != nil {
+			return err
+		}
+		w.files[typ] = wf
+	}
+
+	return wf.write(t, fr)
+}
+
+// Flush writes buffered records into the CSV files.
+func (w *Writer) Flush() error {
+	for _, wf := range w.files {
+		wf.w.Flush()
+		err := wf.w.Error()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
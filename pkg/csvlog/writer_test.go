@@ -0,0 +1,112 @@
+package csvlog
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type MessageHeartbeat struct {
+	Type           uint8
+	Autopilot      uint8
+	BaseMode       uint8
+	CustomMode     uint32
+	SystemStatus   uint8
+	MavlinkVersion uint8
+}
+
+func (*MessageHeartbeat) GetID() uint32 {
+	return 0
+}
+
+type MessageParamValue struct {
+	ParamId    string `mavlen:"16"` //nolint:golint
+	ParamValue float32
+	ParamType  uint8
+	ParamCount uint16
+	ParamIndex uint16
+}
+
+func (*MessageParamValue) GetID() uint32 {
+	return 22
+}
+
+type MessageTest struct {
+	Values [2]int16
+}
+
+func (*MessageTest) GetID() uint32 {
+	return 100
+}
+
+func TestWriter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomavlib-csvlog")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, err := NewWriter(dir, &MessageHeartbeat{}, &MessageTest{})
+	require.NoError(t, err)
+
+	for _, m := range []msg.Message{
+		&MessageHeartbeat{Type: 1, Autopilot: 2, BaseMode: 3, CustomMode: 6, SystemStatus: 4, MavlinkVersion: 5},
+		&MessageParamValue{ParamId: "test", ParamValue: 1.5},
+		&MessageTest{Values: [2]int16{-1, 2}},
+		&MessageHeartbeat{Type: 7},
+		&msg.MessageRaw{ID: 0, Content: []byte{1}},
+	} {
+		err = w.WriteFrame(time.Date(2021, 1, 2, 3, 4, 5, 6000, time.UTC), &frame.V2Frame{
+			SystemID:    1,
+			ComponentID: 2,
+			Message:     m,
+		})
+		require.NoError(t, err)
+	}
+
+	err = w.Close()
+	require.NoError(t, err)
+
+	byts, err := ioutil.ReadFile(filepath.Join(dir, "HEARTBEAT.csv"))
+	require.NoError(t, err)
+	require.Equal(t, "time,system_id,component_id,type,autopilot,base_mode,custom_mode,system_status,mavlink_version\n"+
+		"2021-01-02T03:04:05.000006Z,1,2,1,2,3,6,4,5\n"+
+		"2021-01-02T03:04:05.000006Z,1,2,7,0,0,0,0,0\n", string(byts))
+
+	byts, err = ioutil.ReadFile(filepath.Join(dir, "TEST.csv"))
+	require.NoError(t, err)
+	require.Equal(t, "time,system_id,component_id,values_0,values_1\n"+
+		"2021-01-02T03:04:05.000006Z,1,2,-1,2\n", string(byts))
+
+	_, err = os.Stat(filepath.Join(dir, "PARAM_VALUE.csv"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestWriterAllMessages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomavlib-csvlog")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, err := NewWriter(dir)
+	require.NoError(t, err)
+
+	err = w.WriteFrame(time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC), &frame.V1Frame{
+		SystemID:    1,
+		ComponentID: 2,
+		Message:     &MessageParamValue{ParamId: "test,1", ParamValue: 1.5},
+	})
+	require.NoError(t, err)
+
+	err = w.Close()
+	require.NoError(t, err)
+
+	byts, err := ioutil.ReadFile(filepath.Join(dir, "PARAM_VALUE.csv"))
+	require.NoError(t, err)
+	require.Equal(t, "time,system_id,component_id,param_id,param_value,param_type,param_count,param_index\n"+
+		"2021-01-02T03:04:05Z,1,2,\"test,1\",1.5,0,0,0\n", string(byts))
+}
@@ -0,0 +1,30 @@
+package dialect
+
+// Command is a command (MAV_CMD) with typed parameters, generated by
+// dialect-import, that is encoded into the parameters of a COMMAND_LONG
+// or COMMAND_INT message.
+type Command interface {
+	// GetCommand returns the command ID (MAV_CMD).
+	GetCommand() uint32
+
+	// MarshalParams returns the parameters of the command (param1 to param7).
+	MarshalParams() [7]float32
+
+	// UnmarshalParams fills the command with the given parameters.
+	UnmarshalParams([7]float32)
+}
+
+// CommandLocation is implemented by commands whose param5 and param6 are
+// a latitude and a longitude. Coordinates are stored in degE7, in order to
+// be sent with COMMAND_INT without losing precision.
+type CommandLocation interface {
+	Command
+
+	// MarshalLocation returns the latitude and longitude of the command,
+	// in degE7.
+	MarshalLocation() (int32, int32)
+
+	// UnmarshalLocation fills the latitude and longitude of the command,
+	// in degE7.
+	UnmarshalLocation(int32, int32)
+}
@@ -0,0 +1,44 @@
+package dialect
+
+import (
+	"fmt"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// ConvertMessage converts a message of another dialect into the message of
+// this dialect with the same ID, i.e. a common.MessageHeartbeat into an
+// ardupilotmega.MessageHeartbeat.
+// The two messages must share the same base definition (i.e. the same CRC
+// extra), but can have different extension fields: extension fields that are
+// missing in the source message are filled with zero values, while the ones
+// that are missing in the destination message are discarded.
+// Raw messages (msg.MessageRaw) are decoded too.
+func (d *DecEncoder) ConvertMessage(m msg.Message) (msg.Message, error) {
+	dstDE, ok := d.MessageDEs[m.GetID()]
+	if !ok {
+		return nil, fmt.Errorf("message %d is not in the dialect", m.GetID())
+	}
+
+	if raw, ok := m.(*msg.MessageRaw); ok {
+		return dstDE.Decode(raw.Content, true)
+	}
+
+	srcDE, err := msg.NewDecEncoder(m)
+	if err != nil {
+		return nil, fmt.Errorf("message %T: %s", m, err)
+	}
+
+	if srcDE.CRCExtra() != dstDE.CRCExtra() {
+		return nil, fmt.Errorf("message %d has a different definition in the dialect", m.GetID())
+	}
+
+	// V2 payloads contain extensions, and are decoded even when they are
+	// shorter or longer than the destination message.
+	buf, err := srcDE.Encode(m, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return dstDE.Decode(buf, true)
+}
@@ -0,0 +1,44 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type MessageConvertTest struct {
+	TestByte byte
+	TestUint uint32
+	TestExt  int16 `mavext:"true"`
+}
+
+func (m *MessageConvertTest) GetID() uint32 {
+	return 7
+}
+
+func TestConvertMessageRaw(t *testing.T) {
+	de, err := NewDecEncoder(&Dialect{3, []msg.Message{&MessageConvertTest{}}})
+	require.NoError(t, err)
+
+	m, err := de.ConvertMessage(&msg.MessageRaw{ID: 7, Content: []byte{2, 0, 0, 0, 1, 0xFD, 0xFF}})
+	require.NoError(t, err)
+	require.Equal(t, &MessageConvertTest{TestByte: 1, TestUint: 2, TestExt: -3}, m)
+
+	// missing extensions are filled with zeros
+	m, err = de.ConvertMessage(&msg.MessageRaw{ID: 7, Content: []byte{2, 0, 0, 0, 1}})
+	require.NoError(t, err)
+	require.Equal(t, &MessageConvertTest{TestByte: 1, TestUint: 2}, m)
+}
+
+func TestConvertMessageErrors(t *testing.T) {
+	de, err := NewDecEncoder(&Dialect{3, []msg.Message{&MessageTest6{}}})
+	require.NoError(t, err)
+
+	_, err = de.ConvertMessage(&MessageTest5{})
+	require.EqualError(t, err, "message 5 is not in the dialect")
+
+	_, err = de.ConvertMessage(&MessageTest6Conflict{})
+	require.EqualError(t, err, "message 6 has a different definition in the dialect")
+}
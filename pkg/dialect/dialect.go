@@ -3,9 +3,15 @@
 package dialect
 
 import (
+	"reflect"
+	"regexp"
+	"strings"
+
 	"github.com/aler9/gomavlib/pkg/msg"
 )
 
+var reMsgNameUpper = regexp.MustCompile("([A-Z])")
+
 // Dialect is a Mavlink dialect.
 type Dialect struct {
 	// Version is the dialect version.
@@ -14,3 +20,39 @@ type Dialect struct {
 	// Messages contains the messages of the dialect.
 	Messages []msg.Message
 }
+
+func newMessage(m msg.Message) msg.Message {
+	return reflect.New(reflect.TypeOf(m).Elem()).Interface().(msg.Message)
+}
+
+// messageName returns the name of a message, as in the definition,
+// i.e. "SYS_STATUS" for MessageSysStatus.
+func messageName(m msg.Message) string {
+	name := strings.TrimPrefix(reflect.TypeOf(m).Elem().Name(), "Message")
+	if name == "" {
+		return ""
+	}
+	name = reMsgNameUpper.ReplaceAllString(name, "_${1}")
+	return strings.ToUpper(name[1:])
+}
+
+// GetMessageByID returns a new instance of the message with the given ID.
+func (d *Dialect) GetMessageByID(id uint32) (msg.Message, bool) {
+	for _, m := range d.Messages {
+		if m.GetID() == id {
+			return newMessage(m), true
+		}
+	}
+	return nil, false
+}
+
+// GetMessageByName returns a new instance of the message with the given name,
+// as in the definition, i.e. "HEARTBEAT".
+func (d *Dialect) GetMessageByName(name string) (msg.Message, bool) {
+	for _, m := range d.Messages {
+		if messageName(m) == name {
+			return newMessage(m), true
+		}
+	}
+	return nil, false
+}
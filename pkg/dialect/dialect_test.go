@@ -0,0 +1,28 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestDialectGetMessage(t *testing.T) {
+	d := &Dialect{3, []msg.Message{&MessageTest5{}, &MessageConvertTest{}}}
+
+	m, ok := d.GetMessageByID(7)
+	require.True(t, ok)
+	require.Equal(t, &MessageConvertTest{}, m)
+	require.True(t, d.Messages[1] != m)
+
+	m, ok = d.GetMessageByName("CONVERT_TEST")
+	require.True(t, ok)
+	require.Equal(t, &MessageConvertTest{}, m)
+
+	_, ok = d.GetMessageByID(8)
+	require.False(t, ok)
+
+	_, ok = d.GetMessageByName("HEARTBEAT")
+	require.False(t, ok)
+}
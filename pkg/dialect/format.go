@@ -0,0 +1,160 @@
+package dialect
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func formatNumber(v reflect.Value, f *FieldMetadata) string {
+	if f == nil || (f.Scale == 0 && f.ScaledUnits == "") {
+		return fmt.Sprintf("%v", v.Interface())
+	}
+
+	var fv float64
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fv = float64(v.Int())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fv = float64(v.Uint())
+
+	case reflect.Float32, reflect.Float64:
+		fv = v.Float()
+
+	default:
+		return fmt.Sprintf("%v", v.Interface())
+	}
+
+	fv, _ = f.ScaleValue(fv)
+	return strconv.FormatFloat(fv, 'g', -1, 64)
+}
+
+// isBitmask returns whether an enum is a bitmask.
+// Bitmasks are generated with the Has method.
+func isBitmask(t reflect.Type) bool {
+	_, ok := t.MethodByName("Has")
+	return ok
+}
+
+func enumUint(v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(v.Int())
+	}
+	return v.Uint()
+}
+
+func enumSetUint(v reflect.Value, u uint64) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(u))
+	default:
+		v.SetUint(u)
+	}
+}
+
+// isEnumName returns whether the string representation of an enum value
+// is a name, since unknown values are printed as numbers.
+func isEnumName(s string) bool {
+	_, err := strconv.ParseInt(s, 10, 64)
+	return err != nil
+}
+
+// formatBitmask decomposes a bitmask value into the names of its flags,
+// i.e. FLAG_A|FLAG_B. Bits without a name are printed as a number.
+func formatBitmask(v reflect.Value) string {
+	u := enumUint(v)
+	var names []string
+	var unknown uint64
+
+	for i := 0; i < v.Type().Bits(); i++ {
+		bit := uint64(1) << i
+		if u&bit == 0 {
+			continue
+		}
+
+		flag := reflect.New(v.Type()).Elem()
+		enumSetUint(flag, bit)
+		name := flag.Interface().(fmt.Stringer).String()
+
+		if isEnumName(name) {
+			names = append(names, name)
+		} else {
+			unknown |= bit
+		}
+	}
+
+	if unknown != 0 || len(names) == 0 {
+		names = append(names, strconv.FormatUint(unknown, 10))
+	}
+
+	return strings.Join(names, "|")
+}
+
+func formatValue(v reflect.Value, f *FieldMetadata) string {
+	// enums
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		str := s.String()
+		if !isEnumName(str) && isBitmask(v.Type()) {
+			return formatBitmask(v)
+		}
+		return str
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Quote(v.String())
+
+	case reflect.Array:
+		elems := make([]string, v.Len())
+		for i := range elems {
+			elems[i] = formatValue(v.Index(i), f)
+		}
+		return "[" + strings.Join(elems, " ") + "]"
+	}
+
+	return formatNumber(v, f)
+}
+
+// Format returns a human-readable representation of a message, i.e.
+//
+//	HEARTBEAT {type: MAV_TYPE_QUADROTOR, autopilot: MAV_AUTOPILOT_PX4, ...}
+//
+// Enums are printed by name, while bitmasks are decomposed into the names of
+// their flags, i.e. MAV_MODE_FLAG_SAFETY_ARMED|MAV_MODE_FLAG_GUIDED_ENABLED.
+// If the metadata of the dialect is provided, values are scaled and printed
+// together with their units. Metadata can be nil.
+func Format(m msg.Message, md Metadata) string {
+	if raw, ok := m.(*msg.MessageRaw); ok {
+		return fmt.Sprintf("RAW(%d) {content: %x}", raw.ID, raw.Content)
+	}
+
+	de, err := msg.NewDecEncoder(m)
+	if err != nil {
+		return fmt.Sprintf("%+v", m)
+	}
+
+	rv := reflect.ValueOf(m).Elem()
+	fields := de.Fields()
+	entries := make([]string, len(fields))
+
+	for i, f := range fields {
+		fmd, _ := md.Field(m.GetID(), f.Name)
+
+		entry := f.Name + ": " + formatValue(rv.Field(i), fmd)
+
+		if fmd != nil {
+			if _, units := fmd.ScaleValue(0); units != "" {
+				entry += " " + units
+			}
+		}
+
+		entries[i] = entry
+	}
+
+	return de.Name() + " {" + strings.Join(entries, ", ") + "}"
+}
@@ -0,0 +1,142 @@
+package dialect
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type formatTestEnum uint32
+
+func (e formatTestEnum) String() string {
+	return "ENUM_VALUE"
+}
+
+type formatTestBitmask uint32
+
+const (
+	formatTestFlagA formatTestBitmask = 1
+	formatTestFlagB formatTestBitmask = 4
+)
+
+func (e formatTestBitmask) MarshalText() ([]byte, error) {
+	switch e {
+	case formatTestFlagA:
+		return []byte("FLAG_A"), nil
+	case formatTestFlagB:
+		return []byte("FLAG_B"), nil
+	}
+	return nil, errors.New("invalid value")
+}
+
+func (e formatTestBitmask) String() string {
+	byts, err := e.MarshalText()
+	if err == nil {
+		return string(byts)
+	}
+	return strconv.FormatUint(uint64(e), 10)
+}
+
+func (e formatTestBitmask) Has(flags formatTestBitmask) bool {
+	return e&flags == flags
+}
+
+type MessageFormatTest struct {
+	Kind    formatTestEnum    `mavenum:"uint8"`
+	Flags   formatTestBitmask `mavenum:"uint8"`
+	Alt     int32
+	Temp    [2]int16
+	Voltage uint16
+	Name    string `mavlen:"8"`
+}
+
+func (m *MessageFormatTest) GetID() uint32 {
+	return 8
+}
+
+func TestFormat(t *testing.T) {
+	md := Metadata{
+		8: {
+			Name: "FORMAT_TEST",
+			Fields: []*FieldMetadata{
+				{Name: "alt", Type: "int32_t", Units: "cm", ScaledUnits: "m", Scale: 0.01},
+				{Name: "temp", Type: "int16_t", ArrayLength: 2, Units: "cdegC", ScaledUnits: "degC", Scale: 0.01},
+				{Name: "voltage", Type: "uint16_t", Units: "V"},
+			},
+		},
+	}
+
+	for _, ca := range []struct {
+		name string
+		msg  msg.Message
+		md   Metadata
+		out  string
+	}{
+		{
+			"no metadata",
+			&MessageFormatTest{
+				Kind:    1,
+				Flags:   formatTestFlagA,
+				Alt:     -150,
+				Temp:    [2]int16{2530, 2600},
+				Voltage: 12,
+				Name:    "test",
+			},
+			nil,
+			`FORMAT_TEST {kind: ENUM_VALUE, flags: FLAG_A, alt: -150, temp: [2530 2600], ` +
+				`voltage: 12, name: "test"}`,
+		},
+		{
+			"scaled fields",
+			&MessageFormatTest{
+				Kind:    1,
+				Flags:   formatTestFlagB,
+				Alt:     -150,
+				Temp:    [2]int16{2530, 2600},
+				Voltage: 12,
+				Name:    "test",
+			},
+			md,
+			`FORMAT_TEST {kind: ENUM_VALUE, flags: FLAG_B, alt: -1.5 m, temp: [25.3 26] degC, ` +
+				`voltage: 12 V, name: "test"}`,
+		},
+		{
+			"combined flags",
+			&MessageFormatTest{
+				Flags: formatTestFlagA | formatTestFlagB,
+			},
+			md,
+			`FORMAT_TEST {kind: ENUM_VALUE, flags: FLAG_A|FLAG_B, alt: 0 m, temp: [0 0] degC, ` +
+				`voltage: 0 V, name: ""}`,
+		},
+		{
+			"unknown flags",
+			&MessageFormatTest{
+				Flags: formatTestFlagA | 2 | 8,
+			},
+			nil,
+			`FORMAT_TEST {kind: ENUM_VALUE, flags: FLAG_A|10, alt: 0, temp: [0 0], ` +
+				`voltage: 0, name: ""}`,
+		},
+		{
+			"empty bitmask",
+			&MessageFormatTest{},
+			nil,
+			`FORMAT_TEST {kind: ENUM_VALUE, flags: 0, alt: 0, temp: [0 0], voltage: 0, name: ""}`,
+		},
+		{
+			"raw",
+			&msg.MessageRaw{ID: 5, Content: []byte{1, 2}},
+			md,
+			"RAW(5) {content: 0102}",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			require.Equal(t, ca.out, Format(ca.msg, ca.md))
+		})
+	}
+}
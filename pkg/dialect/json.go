@@ -0,0 +1,149 @@
+package dialect
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type jsonFrame struct {
+	Version             int             `json:"version"`
+	IncompatibilityFlag byte            `json:"incompatibility_flag,omitempty"`
+	CompatibilityFlag   byte            `json:"compatibility_flag,omitempty"`
+	SequenceID          byte            `json:"sequence_id"`
+	SystemID            byte            `json:"system_id"`
+	ComponentID         byte            `json:"component_id"`
+	MessageID           uint32          `json:"message_id"`
+	Message             json.RawMessage `json:"message,omitempty"`
+	MessageRaw          []byte          `json:"message_raw,omitempty"`
+	Checksum            uint16          `json:"checksum"`
+	SignatureLinkID     byte            `json:"signature_link_id,omitempty"`
+	SignatureTimestamp  uint64          `json:"signature_timestamp,omitempty"`
+	Signature           []byte          `json:"signature,omitempty"`
+}
+
+// EncodeMessageJSON encodes a message in JSON format.
+// See msg.DecEncoder.EncodeJSON for details about the format.
+func (d *DecEncoder) EncodeMessageJSON(m msg.Message) ([]byte, error) {
+	mde, ok := d.MessageDEs[m.GetID()]
+	if !ok {
+		return nil, fmt.Errorf("message %d is not in the dialect", m.GetID())
+	}
+	return mde.EncodeJSON(m)
+}
+
+// DecodeMessageJSON decodes a message with given ID in JSON format.
+// See msg.DecEncoder.DecodeJSON for details about the format.
+func (d *DecEncoder) DecodeMessageJSON(id uint32, byts []byte) (msg.Message, error) {
+	mde, ok := d.MessageDEs[id]
+	if !ok {
+		return nil, fmt.Errorf("message %d is not in the dialect", id)
+	}
+	return mde.DecodeJSON(byts)
+}
+
+// EncodeFrameJSON encodes a frame in JSON format.
+// Messages that are not in the dialect are encoded in raw format.
+func (d *DecEncoder) EncodeFrameJSON(fr frame.Frame) ([]byte, error) {
+	var jf jsonFrame
+
+	switch tfr := fr.(type) {
+	case *frame.V1Frame:
+		jf.Version = 1
+		jf.SequenceID = tfr.SequenceID
+
+	case *frame.V2Frame:
+		jf.Version = 2
+		jf.IncompatibilityFlag = tfr.IncompatibilityFlag
+		jf.CompatibilityFlag = tfr.CompatibilityFlag
+		jf.SequenceID = tfr.SequenceID
+		jf.SignatureLinkID = tfr.SignatureLinkID
+		jf.SignatureTimestamp = tfr.SignatureTimestamp
+		if tfr.Signature != nil {
+			jf.Signature = tfr.Signature[:]
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported frame type: %T", fr)
+	}
+
+	jf.SystemID = fr.GetSystemID()
+	jf.ComponentID = fr.GetComponentID()
+	jf.Checksum = fr.GetChecksum()
+
+	m := fr.GetMessage()
+	jf.MessageID = m.GetID()
+
+	if mr, ok := m.(*msg.MessageRaw); ok {
+		jf.MessageRaw = mr.Content
+	} else {
+		byts, err := d.EncodeMessageJSON(m)
+		if err != nil {
+			return nil, err
+		}
+		jf.Message = byts
+	}
+
+	return json.Marshal(jf)
+}
+
+// DecodeFrameJSON decodes a frame in JSON format, produced by EncodeFrameJSON.
+func (d *DecEncoder) DecodeFrameJSON(byts []byte) (frame.Frame, error) {
+	var jf jsonFrame
+	err := json.Unmarshal(byts, &jf)
+	if err != nil {
+		return nil, err
+	}
+
+	var m msg.Message
+	if jf.Message != nil {
+		m, err = d.DecodeMessageJSON(jf.MessageID, jf.Message)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		m = &msg.MessageRaw{
+			ID:      jf.MessageID,
+			Content: jf.MessageRaw,
+		}
+	}
+
+	switch jf.Version {
+	case 1:
+		return &frame.V1Frame{
+			SequenceID:  jf.SequenceID,
+			SystemID:    jf.SystemID,
+			ComponentID: jf.ComponentID,
+			Message:     m,
+			Checksum:    jf.Checksum,
+		}, nil
+
+	case 2:
+		fr := &frame.V2Frame{
+			IncompatibilityFlag: jf.IncompatibilityFlag,
+			CompatibilityFlag:   jf.CompatibilityFlag,
+			SequenceID:          jf.SequenceID,
+			SystemID:            jf.SystemID,
+			ComponentID:         jf.ComponentID,
+			Message:             m,
+			Checksum:            jf.Checksum,
+			SignatureLinkID:     jf.SignatureLinkID,
+			SignatureTimestamp:  jf.SignatureTimestamp,
+		}
+
+		if jf.Signature != nil {
+			if len(jf.Signature) != len(frame.V2Signature{}) {
+				return nil, fmt.Errorf("invalid signature length")
+			}
+			sig := new(frame.V2Signature)
+			copy(sig[:], jf.Signature)
+			fr.Signature = sig
+		}
+
+		return fr, nil
+	}
+
+	return nil, fmt.Errorf("unsupported frame version: %d", jf.Version)
+}
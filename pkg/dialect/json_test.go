@@ -0,0 +1,80 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestFrameJSON(t *testing.T) {
+	de, err := NewDecEncoder(&Dialect{3, []msg.Message{&MessageTest5{}}}) //nolint:govet
+	require.NoError(t, err)
+
+	for _, ca := range []struct {
+		name string
+		fr   frame.Frame
+		enc  string
+	}{
+		{
+			"v1",
+			&frame.V1Frame{
+				SequenceID:  1,
+				SystemID:    2,
+				ComponentID: 3,
+				Message: &MessageTest5{
+					TestByte: 4,
+					TestUint: 5,
+				},
+				Checksum: 6,
+			},
+			`{"version":1,"sequence_id":1,"system_id":2,"component_id":3,"message_id":5,` +
+				`"message":{"test_byte":4,"test_uint":5},"checksum":6}`,
+		},
+		{
+			"v2 signed",
+			&frame.V2Frame{
+				IncompatibilityFlag: frame.V2FlagSigned,
+				SequenceID:          1,
+				SystemID:            2,
+				ComponentID:         3,
+				Message: &MessageTest5{
+					TestByte: 4,
+					TestUint: 5,
+				},
+				Checksum:           6,
+				SignatureLinkID:    7,
+				SignatureTimestamp: 8,
+				Signature:          &frame.V2Signature{1, 2, 3, 4, 5, 6},
+			},
+			`{"version":2,"incompatibility_flag":1,"sequence_id":1,"system_id":2,"component_id":3,` +
+				`"message_id":5,"message":{"test_byte":4,"test_uint":5},"checksum":6,` +
+				`"signature_link_id":7,"signature_timestamp":8,"signature":"AQIDBAUG"}`,
+		},
+		{
+			"v2 raw",
+			&frame.V2Frame{
+				SystemID:    2,
+				ComponentID: 3,
+				Message: &msg.MessageRaw{
+					ID:      10,
+					Content: []byte{1, 2},
+				},
+			},
+			`{"version":2,"sequence_id":0,"system_id":2,"component_id":3,` +
+				`"message_id":10,"message_raw":"AQI=","checksum":0}`,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := de.EncodeFrameJSON(ca.fr)
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, string(enc))
+
+			dec, err := de.DecodeFrameJSON(enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.fr, dec)
+		})
+	}
+}
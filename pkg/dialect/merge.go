@@ -0,0 +1,48 @@
+package dialect
+
+import (
+	"fmt"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// Merge merges several dialects into a single one.
+// This allows, for instance, to use a generated dialect together with a
+// vendor extension without generating a dialect that includes both.
+// Messages with the same ID are allowed only if they have the same
+// definition (i.e. the same CRC extra), in which case the message of the
+// first dialect is kept. The version of the resulting dialect is the
+// version of the first dialect.
+func Merge(ds ...*Dialect) (*Dialect, error) {
+	if len(ds) == 0 {
+		return nil, fmt.Errorf("no dialects provided")
+	}
+
+	ret := &Dialect{
+		Version: ds[0].Version,
+	}
+
+	crcExtras := make(map[uint32]byte)
+
+	for _, d := range ds {
+		for _, m := range d.Messages {
+			de, err := msg.NewDecEncoder(m)
+			if err != nil {
+				return nil, fmt.Errorf("message %T: %s", m, err)
+			}
+
+			if crcExtra, ok := crcExtras[m.GetID()]; ok {
+				if crcExtra != de.CRCExtra() {
+					return nil, fmt.Errorf("message with id %d is defined in multiple dialects with different definitions",
+						m.GetID())
+				}
+				continue
+			}
+
+			crcExtras[m.GetID()] = de.CRCExtra()
+			ret.Messages = append(ret.Messages, m)
+		}
+	}
+
+	return ret, nil
+}
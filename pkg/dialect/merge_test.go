@@ -0,0 +1,55 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type MessageTest5 struct {
+	TestByte byte
+	TestUint uint32
+}
+
+func (m *MessageTest5) GetID() uint32 {
+	return 5
+}
+
+type MessageTest6 struct {
+	TestByte byte
+	TestUint uint32
+}
+
+func (m *MessageTest6) GetID() uint32 {
+	return 6
+}
+
+type MessageTest6Conflict struct {
+	TestUint uint32
+}
+
+func (m *MessageTest6Conflict) GetID() uint32 {
+	return 6
+}
+
+func TestMerge(t *testing.T) {
+	d, err := Merge(
+		&Dialect{3, []msg.Message{&MessageTest5{}, &MessageTest6{}}},
+		&Dialect{2, []msg.Message{&MessageTest6{}}},
+	)
+	require.NoError(t, err)
+	require.Equal(t, &Dialect{3, []msg.Message{&MessageTest5{}, &MessageTest6{}}}, d)
+
+	_, err = NewDecEncoder(d)
+	require.NoError(t, err)
+}
+
+func TestMergeConflict(t *testing.T) {
+	_, err := Merge(
+		&Dialect{3, []msg.Message{&MessageTest5{}, &MessageTest6{}}},
+		&Dialect{3, []msg.Message{&MessageTest6Conflict{}}},
+	)
+	require.EqualError(t, err, "message with id 6 is defined in multiple dialects with different definitions")
+}
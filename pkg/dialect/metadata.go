@@ -0,0 +1,83 @@
+package dialect
+
+// FieldMetadata contains the metadata of a message field, as defined in
+// the XML definition of the dialect.
+type FieldMetadata struct {
+	// the field name, i.e. "target_system".
+	Name string
+
+	// the Mavlink type of the field, i.e. "uint8_t".
+	// In case of arrays and strings, it is the type of the elements.
+	Type string
+
+	// the length of the field, in case of arrays and strings.
+	ArrayLength int
+
+	// the name of the enum associated with the field, if any.
+	Enum string
+
+	// the units of the field, if any, i.e. "cm".
+	Units string
+
+	// the multiplier of the field, if any, as declared in the definition.
+	Multiplier float64
+
+	// the units of the scaled value, if different from Units, i.e. "m".
+	ScaledUnits string
+
+	// the factor that converts raw values into scaled values, if different
+	// from 1. It includes the multiplier and the conversion from Units
+	// into ScaledUnits.
+	Scale float64
+
+	// the field description.
+	Description string
+
+	// whether the field is an extension.
+	Extension bool
+}
+
+// ScaleValue converts a raw value of the field into a scaled value, and
+// returns it together with its units, i.e. 1.5 and "m" for 150 "cm".
+func (f *FieldMetadata) ScaleValue(v float64) (float64, string) {
+	if f.Scale != 0 {
+		v *= f.Scale
+	}
+	if f.ScaledUnits != "" {
+		return v, f.ScaledUnits
+	}
+	return v, f.Units
+}
+
+// MessageMetadata contains the metadata of a message, as defined in
+// the XML definition of the dialect.
+type MessageMetadata struct {
+	// the message name, i.e. "HEARTBEAT".
+	Name string
+
+	// the message description.
+	Description string
+
+	// the message fields, in the order of the definition.
+	Fields []*FieldMetadata
+}
+
+// Metadata contains the metadata of the messages of a dialect,
+// indexed by message ID.
+type Metadata map[uint32]*MessageMetadata
+
+// Field returns the metadata of a field, given the message ID
+// and the field name.
+func (md Metadata) Field(id uint32, name string) (*FieldMetadata, bool) {
+	m, ok := md[id]
+	if !ok {
+		return nil, false
+	}
+
+	for _, f := range m.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,63 @@
+package dialect
+
+type unitScale struct {
+	units  string
+	factor float64
+}
+
+// scales of units that are multiples or fractions of other units, as listed in
+// https://mavlink.io/en/guide/xml_schema.html#units
+var unitScales = map[string]unitScale{
+	"ds":     {"s", 1e-1},
+	"cs":     {"s", 1e-2},
+	"ms":     {"s", 1e-3},
+	"us":     {"s", 1e-6},
+	"MHz":    {"Hz", 1e6},
+	"km":     {"m", 1e3},
+	"dam":    {"m", 1e1},
+	"dm":     {"m", 1e-1},
+	"cm":     {"m", 1e-2},
+	"mm":     {"m", 1e-3},
+	"dm/s":   {"m/s", 1e-1},
+	"cm/s":   {"m/s", 1e-2},
+	"mm/s":   {"m/s", 1e-3},
+	"m/s*5":  {"m/s", 5},
+	"cm^2":   {"m^2", 1e-4},
+	"cdegC":  {"degC", 1e-2},
+	"mrad":   {"rad", 1e-3},
+	"mrad/s": {"rad/s", 1e-3},
+	"deg/2":  {"deg", 2},
+	"cdeg":   {"deg", 1e-2},
+	"cdeg/s": {"deg/s", 1e-2},
+	"degE5":  {"deg", 1e-5},
+	"degE7":  {"deg", 1e-7},
+	"cV":     {"V", 1e-2},
+	"mV":     {"V", 1e-3},
+	"cA":     {"A", 1e-2},
+	"mA":     {"A", 1e-3},
+	"mW":     {"W", 1e-3},
+	"hPa":    {"Pa", 1e2},
+	"kPa":    {"Pa", 1e3},
+	"mbar":   {"Pa", 1e2},
+	"d%":     {"%", 1e-1},
+	"c%":     {"%", 1e-2},
+	"mgauss": {"gauss", 1e-3},
+	"mT":     {"T", 1e-3},
+	"mG":     {"G", 1e-3},
+	"KiB/s":  {"bytes/s", 1024},
+	"MiB/s":  {"bytes/s", 1024 * 1024},
+	"KiB":    {"bytes", 1024},
+	"MiB":    {"bytes", 1024 * 1024},
+}
+
+// UnitScale returns the units in which a value expressed in the given units
+// is conveniently displayed, and the factor that converts the value into
+// them, i.e. "m" and 0.01 for "cm".
+// Units that are not multiples or fractions of other units are returned
+// unchanged, with a factor of 1.
+func UnitScale(units string) (string, float64) {
+	if s, ok := unitScales[units]; ok {
+		return s.units, s.factor
+	}
+	return units, 1
+}
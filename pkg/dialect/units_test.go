@@ -0,0 +1,29 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitScale(t *testing.T) {
+	units, factor := UnitScale("cdegC")
+	require.Equal(t, "degC", units)
+	require.Equal(t, 0.01, factor)
+
+	units, factor = UnitScale("m")
+	require.Equal(t, "m", units)
+	require.Equal(t, float64(1), factor)
+}
+
+func TestFieldMetadataScaleValue(t *testing.T) {
+	f := &FieldMetadata{Units: "cm", ScaledUnits: "m", Scale: 0.01}
+	v, units := f.ScaleValue(150)
+	require.Equal(t, 1.5, v)
+	require.Equal(t, "m", units)
+
+	f = &FieldMetadata{Units: "m"}
+	v, units = f.ScaleValue(150)
+	require.Equal(t, float64(150), v)
+	require.Equal(t, "m", units)
+}
@@ -0,0 +1,211 @@
+package dialect
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type validateDefinitionMessage struct {
+	ID     uint32
+	Name   string
+	Fields []msg.FieldDef
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// Fields are unmarshaled manually in order to detect extensions.
+func (m *validateDefinitionMessage) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "id":
+			v, err := strconv.ParseUint(a.Value, 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid message id: %s", a.Value)
+			}
+			m.ID = uint32(v)
+
+		case "name":
+			m.Name = a.Value
+		}
+	}
+
+	inExtensions := false
+	for {
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tt := t.(type) {
+		case xml.StartElement:
+			switch tt.Name.Local {
+			case "extensions":
+				inExtensions = true
+
+			case "field":
+				f := msg.FieldDef{Extension: inExtensions}
+				for _, a := range tt.Attr {
+					switch a.Name.Local {
+					case "type":
+						f.Type = a.Value
+					case "name":
+						f.Name = a.Value
+					}
+				}
+				m.Fields = append(m.Fields, f)
+			}
+
+			err := d.Skip()
+			if err != nil {
+				return err
+			}
+
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+type validateDefinition struct {
+	Messages []*validateDefinitionMessage `xml:"messages>message"`
+}
+
+// Discrepancy is a difference between a dialect and its XML definition.
+type Discrepancy struct {
+	// the message ID.
+	MessageID uint32
+
+	// the message name, as in the definition, i.e. "HEARTBEAT".
+	MessageName string
+
+	// a description of the difference.
+	Description string
+}
+
+// String implements fmt.Stringer.
+func (d *Discrepancy) String() string {
+	return fmt.Sprintf("message %d (%s): %s", d.MessageID, d.MessageName, d.Description)
+}
+
+func normalizeFieldType(typ string) string {
+	// uint8_t_mavlink_version is a uint8_t
+	return strings.Replace(typ, "_mavlink_version", "", 1)
+}
+
+func validateMessage(de *msg.DecEncoder, def *validateDefinitionMessage) []string {
+	var ret []string
+
+	if de.Name() != def.Name {
+		ret = append(ret, fmt.Sprintf("name is '%s', expected '%s'", de.Name(), def.Name))
+	}
+
+	fields := de.Fields()
+
+	if len(fields) != len(def.Fields) {
+		ret = append(ret, fmt.Sprintf("has %d fields, expected %d", len(fields), len(def.Fields)))
+	}
+
+	for i := 0; i < len(fields) && i < len(def.Fields); i++ {
+		f := fields[i]
+		df := def.Fields[i]
+
+		if f.Name != df.Name {
+			ret = append(ret, fmt.Sprintf("field %d is '%s', expected '%s'", i, f.Name, df.Name))
+			continue
+		}
+
+		if f.Type != normalizeFieldType(df.Type) {
+			ret = append(ret, fmt.Sprintf("field '%s' has type '%s', expected '%s'", f.Name, f.Type, df.Type))
+		}
+
+		if f.Extension != df.Extension {
+			if df.Extension {
+				ret = append(ret, fmt.Sprintf("field '%s' is not an extension, but should be", f.Name))
+			} else {
+				ret = append(ret, fmt.Sprintf("field '%s' is an extension, but should not be", f.Name))
+			}
+		}
+	}
+
+	crcExtra, err := msg.CRCExtra(def.Name, def.Fields)
+	if err != nil {
+		ret = append(ret, fmt.Sprintf("invalid definition: %s", err))
+	} else if de.CRCExtra() != crcExtra {
+		ret = append(ret, fmt.Sprintf("CRC extra is %d, expected %d", de.CRCExtra(), crcExtra))
+	}
+
+	return ret
+}
+
+// Validate cross-checks a dialect against the XML definitions it has been
+// generated from, and returns the discrepancies between them: messages that
+// are missing, fields with different names, types or order, extension
+// fields that are not marked as such and wrong CRC extras.
+// It allows to verify dialects that are generated or written manually.
+// Includes are not resolved, therefore the definitions of all the included
+// files must be provided too.
+func Validate(d *Dialect, definitions ...[]byte) ([]*Discrepancy, error) {
+	defs := make(map[uint32]*validateDefinitionMessage)
+
+	for _, content := range definitions {
+		var def validateDefinition
+		err := xml.Unmarshal(content, &def)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode definition: %s", err)
+		}
+
+		for _, m := range def.Messages {
+			defs[m.ID] = m
+		}
+	}
+
+	var ret []*Discrepancy
+	found := make(map[uint32]struct{})
+
+	for _, m := range d.Messages {
+		de, err := msg.NewDecEncoder(m)
+		if err != nil {
+			return nil, fmt.Errorf("message %T: %s", m, err)
+		}
+
+		found[m.GetID()] = struct{}{}
+
+		def, ok := defs[m.GetID()]
+		if !ok {
+			ret = append(ret, &Discrepancy{
+				MessageID:   m.GetID(),
+				MessageName: de.Name(),
+				Description: "message is not in the definitions",
+			})
+			continue
+		}
+
+		for _, desc := range validateMessage(de, def) {
+			ret = append(ret, &Discrepancy{
+				MessageID:   m.GetID(),
+				MessageName: def.Name,
+				Description: desc,
+			})
+		}
+	}
+
+	for id, def := range defs {
+		if _, ok := found[id]; !ok {
+			ret = append(ret, &Discrepancy{
+				MessageID:   id,
+				MessageName: def.Name,
+				Description: "message is missing from the dialect",
+			})
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].MessageID < ret[j].MessageID
+	})
+
+	return ret, nil
+}
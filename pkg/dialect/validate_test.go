@@ -0,0 +1,90 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type MessageValidateTest struct {
+	ParamId    string `mavlen:"16"` //nolint:golint
+	ParamValue float32
+	ParamType  uint8
+	ParamCount uint16
+	ParamIndex uint16
+	Extra      uint8 `mavext:"true"`
+}
+
+func (m *MessageValidateTest) GetID() uint32 {
+	return 22
+}
+
+type MessageValidateTestWrong struct {
+	ParamId    string `mavlen:"16"` //nolint:golint
+	ParamValue float32
+	ParamType  uint16
+	ParamCount uint16
+	ParamIndex uint16
+	Extra      uint8
+}
+
+func (m *MessageValidateTestWrong) GetID() uint32 {
+	return 22
+}
+
+var validateTestDefinition = []byte(`<?xml version="1.0"?>
+<mavlink>
+  <messages>
+    <message id="22" name="VALIDATE_TEST">
+      <description>test.</description>
+      <field type="char[16]" name="param_id">Parameter id.</field>
+      <field type="float" name="param_value">Parameter value.</field>
+      <field type="uint8_t" name="param_type">Parameter type.</field>
+      <field type="uint16_t" name="param_count">Parameter count.</field>
+      <field type="uint16_t" name="param_index">Parameter index.</field>
+      <extensions/>
+      <field type="uint8_t" name="extra">Extra.</field>
+    </message>
+    <message id="23" name="MISSING">
+      <field type="uint8_t" name="a">A.</field>
+    </message>
+  </messages>
+</mavlink>
+`)
+
+func TestValidate(t *testing.T) {
+	dis, err := Validate(&Dialect{3, []msg.Message{&MessageValidateTest{}}}, validateTestDefinition)
+	require.NoError(t, err)
+	require.Equal(t, []*Discrepancy{{
+		MessageID:   23,
+		MessageName: "MISSING",
+		Description: "message is missing from the dialect",
+	}}, dis)
+}
+
+func TestValidateDiscrepancies(t *testing.T) {
+	dis, err := Validate(&Dialect{3, []msg.Message{&MessageValidateTestWrong{}, &MessageTest5{}}},
+		validateTestDefinition)
+	require.NoError(t, err)
+
+	var strs []string
+	for _, d := range dis {
+		strs = append(strs, d.String())
+	}
+
+	require.Equal(t, []string{
+		"message 5 (TEST5): message is not in the definitions",
+		"message 22 (VALIDATE_TEST): name is 'VALIDATE_TEST_WRONG', expected 'VALIDATE_TEST'",
+		"message 22 (VALIDATE_TEST): field 'param_type' has type 'uint16_t', expected 'uint8_t'",
+		"message 22 (VALIDATE_TEST): field 'extra' is not an extension, but should be",
+		"message 22 (VALIDATE_TEST): CRC extra is 53, expected 7",
+		"message 23 (MISSING): message is missing from the dialect",
+	}, strs)
+}
+
+func TestValidateInvalidDefinition(t *testing.T) {
+	_, err := Validate(&Dialect{3, nil}, []byte("<mavlink><messages>"))
+	require.Error(t, err)
+}
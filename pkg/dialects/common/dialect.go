@@ -234,6 +234,3055 @@ var dial = &dialect.Dialect{3, []msg.Message{
 	&MessageOpenDroneIdMessagePack{},
 }}
 
+// Metadata contains the metadata of the messages of the dialect.
+var Metadata = meta
+
+// metadata is not exposed directly such that it is not displayed in godoc.
+var meta = dialect.Metadata{
+	0: {
+		Name:        "HEARTBEAT",
+		Description: "The heartbeat message shows that a system or component is present and responding. The type and autopilot fields (along with the message component id), allow the receiving system to treat further messages from this system appropriately (e.g. by laying out the user interface based on the autopilot). This microservice is documented at https://mavlink.io/en/services/heartbeat.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "type", Type: "uint8_t", Enum: "MAV_TYPE", Description: "Vehicle or component type. For a flight controller component the vehicle type (quadrotor, helicopter, etc.). For other components the component type (e.g. camera, gimbal, etc.). This should be used in preference to component id for identifying the component type."},
+			{Name: "autopilot", Type: "uint8_t", Enum: "MAV_AUTOPILOT", Description: "Autopilot type / class. Use MAV_AUTOPILOT_INVALID for components that are not flight controllers."},
+			{Name: "base_mode", Type: "uint8_t", Enum: "MAV_MODE_FLAG", Description: "System mode bitmap."},
+			{Name: "custom_mode", Type: "uint32_t", Description: "A bitfield for use for autopilot-specific flags"},
+			{Name: "system_status", Type: "uint8_t", Enum: "MAV_STATE", Description: "System status flag."},
+			{Name: "mavlink_version", Type: "uint8_t", Description: "MAVLink version, not writable by user, gets added by protocol because of magic data type: uint8_t_mavlink_version"},
+		},
+	},
+	300: {
+		Name:        "PROTOCOL_VERSION",
+		Description: "Version and capability of protocol version. This message can be requested with MAV_CMD_REQUEST_MESSAGE and is used as part of the handshaking to establish which MAVLink version should be used on the network. Every node should respond to a request for PROTOCOL_VERSION to enable the handshaking. Library implementers should consider adding this into the default decoding state machine to allow the protocol core to respond directly.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "version", Type: "uint16_t", Description: "Currently active MAVLink version number * 100: v1.0 is 100, v2.0 is 200, etc."},
+			{Name: "min_version", Type: "uint16_t", Description: "Minimum MAVLink version supported"},
+			{Name: "max_version", Type: "uint16_t", Description: "Maximum MAVLink version supported (set to the same value as version by default)"},
+			{Name: "spec_version_hash", Type: "uint8_t", ArrayLength: 8, Description: "The first 8 bytes (not characters printed in hex!) of the git hash."},
+			{Name: "library_version_hash", Type: "uint8_t", ArrayLength: 8, Description: "The first 8 bytes (not characters printed in hex!) of the git hash."},
+		},
+	},
+	1: {
+		Name:        "SYS_STATUS",
+		Description: "The general system state. If the system is following the MAVLink standard, the system state is mainly defined by three orthogonal states/modes: The system mode, which is either LOCKED (motors shut down and locked), MANUAL (system under RC control), GUIDED (system with autonomous position control, position setpoint controlled manually) or AUTO (system guided by path/waypoint planner). The NAV_MODE defined the current flight state: LIFTOFF (often an open-loop maneuver), LANDING, WAYPOINTS or VECTOR. This represents the internal navigation state machine. The system status shows whether the system is currently active or not and if an emergency occurred. During the CRITICAL and EMERGENCY states the MAV is still considered to be active, but should start emergency procedures autonomously. After a failure occurred it should first move from active to critical to allow manual intervention and then move to emergency after a certain timeout.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "onboard_control_sensors_present", Type: "uint32_t", Enum: "MAV_SYS_STATUS_SENSOR", Description: "Bitmap showing which onboard controllers and sensors are present. Value of 0: not present. Value of 1: present."},
+			{Name: "onboard_control_sensors_enabled", Type: "uint32_t", Enum: "MAV_SYS_STATUS_SENSOR", Description: "Bitmap showing which onboard controllers and sensors are enabled:  Value of 0: not enabled. Value of 1: enabled."},
+			{Name: "onboard_control_sensors_health", Type: "uint32_t", Enum: "MAV_SYS_STATUS_SENSOR", Description: "Bitmap showing which onboard controllers and sensors have an error (or are operational). Value of 0: error. Value of 1: healthy."},
+			{Name: "load", Type: "uint16_t", Description: "Maximum usage in percent of the mainloop time. Values: [0-1000] - should always be below 1000"},
+			{Name: "voltage_battery", Type: "uint16_t", Description: "Battery voltage, UINT16_MAX: Voltage not sent by autopilot"},
+			{Name: "current_battery", Type: "int16_t", Description: "Battery current, -1: Current not sent by autopilot"},
+			{Name: "battery_remaining", Type: "int8_t", Description: "Battery energy remaining, -1: Battery remaining energy not sent by autopilot"},
+			{Name: "drop_rate_comm", Type: "uint16_t", Description: "Communication drop rate, (UART, I2C, SPI, CAN), dropped packets on all links (packets that were corrupted on reception on the MAV)"},
+			{Name: "errors_comm", Type: "uint16_t", Description: "Communication errors (UART, I2C, SPI, CAN), dropped packets on all links (packets that were corrupted on reception on the MAV)"},
+			{Name: "errors_count1", Type: "uint16_t", Description: "Autopilot-specific errors"},
+			{Name: "errors_count2", Type: "uint16_t", Description: "Autopilot-specific errors"},
+			{Name: "errors_count3", Type: "uint16_t", Description: "Autopilot-specific errors"},
+			{Name: "errors_count4", Type: "uint16_t", Description: "Autopilot-specific errors"},
+		},
+	},
+	2: {
+		Name:        "SYSTEM_TIME",
+		Description: "The system time is the time of the master clock, typically the computer clock of the main onboard computer.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_unix_usec", Type: "uint64_t", Description: "Timestamp (UNIX epoch time)."},
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+		},
+	},
+	4: {
+		Name:        "PING",
+		Description: "A ping message either requesting or responding to a ping. This allows to measure the system latencies, including serial port, radio modem and UDP connections. The ping microservice is documented at https://mavlink.io/en/services/ping.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "seq", Type: "uint32_t", Description: "PING sequence"},
+			{Name: "target_system", Type: "uint8_t", Description: "0: request ping from all receiving systems. If greater than 0: message is a ping response and number is the system id of the requesting system"},
+			{Name: "target_component", Type: "uint8_t", Description: "0: request ping from all receiving components. If greater than 0: message is a ping response and number is the component id of the requesting component."},
+		},
+	},
+	5: {
+		Name:        "CHANGE_OPERATOR_CONTROL",
+		Description: "Request to control this MAV",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System the GCS requests control for"},
+			{Name: "control_request", Type: "uint8_t", Description: "0: request control of this MAV, 1: Release control of this MAV"},
+			{Name: "version", Type: "uint8_t", Description: "0: key as plaintext, 1-255: future, different hashing/encryption variants. The GCS should in general use the safest mode possible initially and then gradually move down the encryption level if it gets a NACK message indicating an encryption mismatch."},
+			{Name: "passkey", Type: "char", ArrayLength: 25, Description: "Password / Key, depending on version plaintext or encrypted. 25 or less characters, NULL terminated. The characters may involve A-Z, a-z, 0-9, and \"!?,.-\""},
+		},
+	},
+	6: {
+		Name:        "CHANGE_OPERATOR_CONTROL_ACK",
+		Description: "Accept / deny control of this MAV",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "gcs_system_id", Type: "uint8_t", Description: "ID of the GCS this message"},
+			{Name: "control_request", Type: "uint8_t", Description: "0: request control of this MAV, 1: Release control of this MAV"},
+			{Name: "ack", Type: "uint8_t", Description: "0: ACK, 1: NACK: Wrong passkey, 2: NACK: Unsupported passkey encryption method, 3: NACK: Already under control"},
+		},
+	},
+	7: {
+		Name:        "AUTH_KEY",
+		Description: "Emit an encrypted signature / key identifying this system. PLEASE NOTE: This protocol has been kept simple, so transmitting the key requires an encrypted channel for true safety.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "key", Type: "char", ArrayLength: 32, Description: "key"},
+		},
+	},
+	8: {
+		Name:        "LINK_NODE_STATUS",
+		Description: "Status generated in each node in the communication chain and injected into MAVLink stream.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "timestamp", Type: "uint64_t", Description: "Timestamp (time since system boot)."},
+			{Name: "tx_buf", Type: "uint8_t", Description: "Remaining free transmit buffer space"},
+			{Name: "rx_buf", Type: "uint8_t", Description: "Remaining free receive buffer space"},
+			{Name: "tx_rate", Type: "uint32_t", Description: "Transmit rate"},
+			{Name: "rx_rate", Type: "uint32_t", Description: "Receive rate"},
+			{Name: "rx_parse_err", Type: "uint16_t", Description: "Number of bytes that could not be parsed correctly."},
+			{Name: "tx_overflows", Type: "uint16_t", Description: "Transmit buffer overflows. This number wraps around as it reaches UINT16_MAX"},
+			{Name: "rx_overflows", Type: "uint16_t", Description: "Receive buffer overflows. This number wraps around as it reaches UINT16_MAX"},
+			{Name: "messages_sent", Type: "uint32_t", Description: "Messages sent"},
+			{Name: "messages_received", Type: "uint32_t", Description: "Messages received (estimated from counting seq)"},
+			{Name: "messages_lost", Type: "uint32_t", Description: "Messages lost (estimated from counting seq)"},
+		},
+	},
+	11: {
+		Name:        "SET_MODE",
+		Description: "Set the system mode, as defined by enum MAV_MODE. There is no target component id as the mode is by definition for the overall aircraft, not only for one component.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "The system setting the mode"},
+			{Name: "base_mode", Type: "uint8_t", Enum: "MAV_MODE", Description: "The new base mode."},
+			{Name: "custom_mode", Type: "uint32_t", Description: "The new autopilot-specific mode. This field can be ignored by an autopilot."},
+		},
+	},
+	19: {
+		Name:        "PARAM_ACK_TRANSACTION",
+		Description: "Response from a PARAM_SET message when it is used in a transaction.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "Id of system that sent PARAM_SET message."},
+			{Name: "target_component", Type: "uint8_t", Description: "Id of system that sent PARAM_SET message."},
+			{Name: "param_id", Type: "char", ArrayLength: 16, Description: "Parameter id, terminated by NULL if the length is less than 16 human-readable chars and WITHOUT null termination (NULL) byte if the length is exactly 16 chars - applications have to provide 16+1 bytes storage if the ID is stored as string"},
+			{Name: "param_value", Type: "float", Description: "Parameter value (new value if PARAM_ACCEPTED, current value otherwise)"},
+			{Name: "param_type", Type: "uint8_t", Enum: "MAV_PARAM_TYPE", Description: "Parameter type."},
+			{Name: "param_result", Type: "uint8_t", Enum: "PARAM_ACK", Description: "Result code."},
+		},
+	},
+	20: {
+		Name:        "PARAM_REQUEST_READ",
+		Description: "Request to read the onboard parameter with the param_id string id. Onboard parameters are stored as key[const char*] -> value[float]. This allows to send a parameter to any other component (such as the GCS) without the need of previous knowledge of possible parameter names. Thus the same GCS can store different parameters for different autopilots. See also https://mavlink.io/en/services/parameter.html for a full documentation of QGroundControl and IMU code.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "param_id", Type: "char", ArrayLength: 16, Description: "Onboard parameter id, terminated by NULL if the length is less than 16 human-readable chars and WITHOUT null termination (NULL) byte if the length is exactly 16 chars - applications have to provide 16+1 bytes storage if the ID is stored as string"},
+			{Name: "param_index", Type: "int16_t", Description: "Parameter index. Send -1 to use the param ID field as identifier (else the param id will be ignored)"},
+		},
+	},
+	21: {
+		Name:        "PARAM_REQUEST_LIST",
+		Description: "Request all parameters of this component. After this request, all parameters are emitted. The parameter microservice is documented at https://mavlink.io/en/services/parameter.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+		},
+	},
+	22: {
+		Name:        "PARAM_VALUE",
+		Description: "Emit the value of a onboard parameter. The inclusion of param_count and param_index in the message allows the recipient to keep track of received parameters and allows him to re-request missing parameters after a loss or timeout. The parameter microservice is documented at https://mavlink.io/en/services/parameter.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "param_id", Type: "char", ArrayLength: 16, Description: "Onboard parameter id, terminated by NULL if the length is less than 16 human-readable chars and WITHOUT null termination (NULL) byte if the length is exactly 16 chars - applications have to provide 16+1 bytes storage if the ID is stored as string"},
+			{Name: "param_value", Type: "float", Description: "Onboard parameter value"},
+			{Name: "param_type", Type: "uint8_t", Enum: "MAV_PARAM_TYPE", Description: "Onboard parameter type."},
+			{Name: "param_count", Type: "uint16_t", Description: "Total number of onboard parameters"},
+			{Name: "param_index", Type: "uint16_t", Description: "Index of this onboard parameter"},
+		},
+	},
+	23: {
+		Name:        "PARAM_SET",
+		Description: "Set a parameter value (write new value to permanent storage).        The receiving component should acknowledge the new parameter value by broadcasting a PARAM_VALUE message (broadcasting ensures that multiple GCS all have an up-to-date list of all parameters). If the sending GCS did not receive a PARAM_VALUE within its timeout time, it should re-send the PARAM_SET message. The parameter microservice is documented at https://mavlink.io/en/services/parameter.html.        PARAM_SET may also be called within the context of a transaction (started with MAV_CMD_PARAM_TRANSACTION). Within a transaction the receiving component should respond with PARAM_ACK_TRANSACTION to the setter component (instead of broadcasting PARAM_VALUE), and PARAM_SET should be re-sent if this is ACK not received.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "param_id", Type: "char", ArrayLength: 16, Description: "Onboard parameter id, terminated by NULL if the length is less than 16 human-readable chars and WITHOUT null termination (NULL) byte if the length is exactly 16 chars - applications have to provide 16+1 bytes storage if the ID is stored as string"},
+			{Name: "param_value", Type: "float", Description: "Onboard parameter value"},
+			{Name: "param_type", Type: "uint8_t", Enum: "MAV_PARAM_TYPE", Description: "Onboard parameter type."},
+		},
+	},
+	24: {
+		Name:        "GPS_RAW_INT",
+		Description: "The global position, as returned by the Global Positioning System (GPS). This is                NOT the global position estimate of the system, but rather a RAW sensor value. See message GLOBAL_POSITION for the global position estimate.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "fix_type", Type: "uint8_t", Enum: "GPS_FIX_TYPE", Description: "GPS fix type."},
+			{Name: "lat", Type: "int32_t", Description: "Latitude (WGS84, EGM96 ellipsoid)"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude (WGS84, EGM96 ellipsoid)"},
+			{Name: "alt", Type: "int32_t", Description: "Altitude (MSL). Positive for up. Note that virtually all GPS modules provide the MSL altitude in addition to the WGS84 altitude."},
+			{Name: "eph", Type: "uint16_t", Description: "GPS HDOP horizontal dilution of position (unitless * 100). If unknown, set to: UINT16_MAX"},
+			{Name: "epv", Type: "uint16_t", Description: "GPS VDOP vertical dilution of position (unitless * 100). If unknown, set to: UINT16_MAX"},
+			{Name: "vel", Type: "uint16_t", Description: "GPS ground speed. If unknown, set to: UINT16_MAX"},
+			{Name: "cog", Type: "uint16_t", Description: "Course over ground (NOT heading, but direction of movement) in degrees * 100, 0.0..359.99 degrees. If unknown, set to: UINT16_MAX"},
+			{Name: "satellites_visible", Type: "uint8_t", Description: "Number of satellites visible. If unknown, set to UINT8_MAX"},
+			{Name: "alt_ellipsoid", Type: "int32_t", Description: "Altitude (above WGS84, EGM96 ellipsoid). Positive for up.", Extension: true},
+			{Name: "h_acc", Type: "uint32_t", Description: "Position uncertainty.", Extension: true},
+			{Name: "v_acc", Type: "uint32_t", Description: "Altitude uncertainty.", Extension: true},
+			{Name: "vel_acc", Type: "uint32_t", Description: "Speed uncertainty.", Extension: true},
+			{Name: "hdg_acc", Type: "uint32_t", Description: "Heading / track uncertainty", Extension: true},
+			{Name: "yaw", Type: "uint16_t", Description: "Yaw in earth frame from north. Use 0 if this GPS does not provide yaw. Use UINT16_MAX if this GPS is configured to provide yaw and is currently unable to provide it. Use 36000 for north.", Extension: true},
+		},
+	},
+	25: {
+		Name:        "GPS_STATUS",
+		Description: "The positioning status, as reported by GPS. This message is intended to display status information about each satellite visible to the receiver. See message GLOBAL_POSITION for the global position estimate. This message can contain information for up to 20 satellites.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "satellites_visible", Type: "uint8_t", Description: "Number of satellites visible"},
+			{Name: "satellite_prn", Type: "uint8_t", ArrayLength: 20, Description: "Global satellite ID"},
+			{Name: "satellite_used", Type: "uint8_t", ArrayLength: 20, Description: "0: Satellite not used, 1: used for localization"},
+			{Name: "satellite_elevation", Type: "uint8_t", ArrayLength: 20, Description: "Elevation (0: right on top of receiver, 90: on the horizon) of satellite"},
+			{Name: "satellite_azimuth", Type: "uint8_t", ArrayLength: 20, Description: "Direction of satellite, 0: 0 deg, 255: 360 deg."},
+			{Name: "satellite_snr", Type: "uint8_t", ArrayLength: 20, Description: "Signal to noise ratio of satellite"},
+		},
+	},
+	26: {
+		Name:        "SCALED_IMU",
+		Description: "The RAW IMU readings for the usual 9DOF sensor setup. This message should contain the scaled values to the described units",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "xacc", Type: "int16_t", Description: "X acceleration"},
+			{Name: "yacc", Type: "int16_t", Description: "Y acceleration"},
+			{Name: "zacc", Type: "int16_t", Description: "Z acceleration"},
+			{Name: "xgyro", Type: "int16_t", Description: "Angular speed around X axis"},
+			{Name: "ygyro", Type: "int16_t", Description: "Angular speed around Y axis"},
+			{Name: "zgyro", Type: "int16_t", Description: "Angular speed around Z axis"},
+			{Name: "xmag", Type: "int16_t", Description: "X Magnetic field"},
+			{Name: "ymag", Type: "int16_t", Description: "Y Magnetic field"},
+			{Name: "zmag", Type: "int16_t", Description: "Z Magnetic field"},
+			{Name: "temperature", Type: "int16_t", Description: "Temperature, 0: IMU does not provide temperature values. If the IMU is at 0C it must send 1 (0.01C).", Extension: true},
+		},
+	},
+	27: {
+		Name:        "RAW_IMU",
+		Description: "The RAW IMU readings for a 9DOF sensor, which is identified by the id (default IMU1). This message should always contain the true raw values without any scaling to allow data capture and system debugging.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "xacc", Type: "int16_t", Description: "X acceleration (raw)"},
+			{Name: "yacc", Type: "int16_t", Description: "Y acceleration (raw)"},
+			{Name: "zacc", Type: "int16_t", Description: "Z acceleration (raw)"},
+			{Name: "xgyro", Type: "int16_t", Description: "Angular speed around X axis (raw)"},
+			{Name: "ygyro", Type: "int16_t", Description: "Angular speed around Y axis (raw)"},
+			{Name: "zgyro", Type: "int16_t", Description: "Angular speed around Z axis (raw)"},
+			{Name: "xmag", Type: "int16_t", Description: "X Magnetic field (raw)"},
+			{Name: "ymag", Type: "int16_t", Description: "Y Magnetic field (raw)"},
+			{Name: "zmag", Type: "int16_t", Description: "Z Magnetic field (raw)"},
+			{Name: "id", Type: "uint8_t", Description: "Id. Ids are numbered from 0 and map to IMUs numbered from 1 (e.g. IMU1 will have a message with id=0)", Extension: true},
+			{Name: "temperature", Type: "int16_t", Description: "Temperature, 0: IMU does not provide temperature values. If the IMU is at 0C it must send 1 (0.01C).", Extension: true},
+		},
+	},
+	28: {
+		Name:        "RAW_PRESSURE",
+		Description: "The RAW pressure readings for the typical setup of one absolute pressure and one differential pressure sensor. The sensor values should be the raw, UNSCALED ADC values.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "press_abs", Type: "int16_t", Description: "Absolute pressure (raw)"},
+			{Name: "press_diff1", Type: "int16_t", Description: "Differential pressure 1 (raw, 0 if nonexistent)"},
+			{Name: "press_diff2", Type: "int16_t", Description: "Differential pressure 2 (raw, 0 if nonexistent)"},
+			{Name: "temperature", Type: "int16_t", Description: "Raw Temperature measurement (raw)"},
+		},
+	},
+	29: {
+		Name:        "SCALED_PRESSURE",
+		Description: "The pressure readings for the typical setup of one absolute and differential pressure sensor. The units are as specified in each field.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "press_abs", Type: "float", Description: "Absolute pressure"},
+			{Name: "press_diff", Type: "float", Description: "Differential pressure 1"},
+			{Name: "temperature", Type: "int16_t", Description: "Absolute pressure temperature"},
+			{Name: "temperature_press_diff", Type: "int16_t", Description: "Differential pressure temperature (0, if not available). Report values of 0 (or 1) as 1 cdegC.", Extension: true},
+		},
+	},
+	30: {
+		Name:        "ATTITUDE",
+		Description: "The attitude in the aeronautical frame (right-handed, Z-down, X-front, Y-right).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "roll", Type: "float", Description: "Roll angle (-pi..+pi)"},
+			{Name: "pitch", Type: "float", Description: "Pitch angle (-pi..+pi)"},
+			{Name: "yaw", Type: "float", Description: "Yaw angle (-pi..+pi)"},
+			{Name: "rollspeed", Type: "float", Description: "Roll angular speed"},
+			{Name: "pitchspeed", Type: "float", Description: "Pitch angular speed"},
+			{Name: "yawspeed", Type: "float", Description: "Yaw angular speed"},
+		},
+	},
+	31: {
+		Name:        "ATTITUDE_QUATERNION",
+		Description: "The attitude in the aeronautical frame (right-handed, Z-down, X-front, Y-right), expressed as quaternion. Quaternion order is w, x, y, z and a zero rotation would be expressed as (1 0 0 0).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "q1", Type: "float", Description: "Quaternion component 1, w (1 in null-rotation)"},
+			{Name: "q2", Type: "float", Description: "Quaternion component 2, x (0 in null-rotation)"},
+			{Name: "q3", Type: "float", Description: "Quaternion component 3, y (0 in null-rotation)"},
+			{Name: "q4", Type: "float", Description: "Quaternion component 4, z (0 in null-rotation)"},
+			{Name: "rollspeed", Type: "float", Description: "Roll angular speed"},
+			{Name: "pitchspeed", Type: "float", Description: "Pitch angular speed"},
+			{Name: "yawspeed", Type: "float", Description: "Yaw angular speed"},
+			{Name: "repr_offset_q", Type: "float", ArrayLength: 4, Description: "Rotation offset by which the attitude quaternion and angular speed vector should be rotated for user display (quaternion with [w, x, y, z] order, zero-rotation is [1, 0, 0, 0], send [0, 0, 0, 0] if field not supported). This field is intended for systems in which the reference attitude may change during flight. For example, tailsitters VTOLs rotate their reference attitude by 90 degrees between hover mode and fixed wing mode, thus repr_offset_q is equal to [1, 0, 0, 0] in hover mode and equal to [0.7071, 0, 0.7071, 0] in fixed wing mode.", Extension: true},
+		},
+	},
+	32: {
+		Name:        "LOCAL_POSITION_NED",
+		Description: "The filtered local position (e.g. fused computer vision and accelerometers). Coordinate frame is right-handed, Z-axis down (aeronautical frame, NED / north-east-down convention)",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "x", Type: "float", Description: "X Position"},
+			{Name: "y", Type: "float", Description: "Y Position"},
+			{Name: "z", Type: "float", Description: "Z Position"},
+			{Name: "vx", Type: "float", Description: "X Speed"},
+			{Name: "vy", Type: "float", Description: "Y Speed"},
+			{Name: "vz", Type: "float", Description: "Z Speed"},
+		},
+	},
+	33: {
+		Name:        "GLOBAL_POSITION_INT",
+		Description: "The filtered global position (e.g. fused GPS and accelerometers). The position is in GPS-frame (right-handed, Z-up). It               is designed as scaled integer message since the resolution of float is not sufficient.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "lat", Type: "int32_t", Description: "Latitude, expressed"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude, expressed"},
+			{Name: "alt", Type: "int32_t", Description: "Altitude (MSL). Note that virtually all GPS modules provide both WGS84 and MSL."},
+			{Name: "relative_alt", Type: "int32_t", Description: "Altitude above ground"},
+			{Name: "vx", Type: "int16_t", Description: "Ground X Speed (Latitude, positive north)"},
+			{Name: "vy", Type: "int16_t", Description: "Ground Y Speed (Longitude, positive east)"},
+			{Name: "vz", Type: "int16_t", Description: "Ground Z Speed (Altitude, positive down)"},
+			{Name: "hdg", Type: "uint16_t", Description: "Vehicle heading (yaw angle), 0.0..359.99 degrees. If unknown, set to: UINT16_MAX"},
+		},
+	},
+	34: {
+		Name:        "RC_CHANNELS_SCALED",
+		Description: "The scaled values of the RC channels received: (-100%) -10000, (0%) 0, (100%) 10000. Channels that are inactive should be set to UINT16_MAX.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "port", Type: "uint8_t", Description: "Servo output port (set of 8 outputs = 1 port). Flight stacks running on Pixhawk should use: 0 = MAIN, 1 = AUX."},
+			{Name: "chan1_scaled", Type: "int16_t", Description: "RC channel 1 value scaled."},
+			{Name: "chan2_scaled", Type: "int16_t", Description: "RC channel 2 value scaled."},
+			{Name: "chan3_scaled", Type: "int16_t", Description: "RC channel 3 value scaled."},
+			{Name: "chan4_scaled", Type: "int16_t", Description: "RC channel 4 value scaled."},
+			{Name: "chan5_scaled", Type: "int16_t", Description: "RC channel 5 value scaled."},
+			{Name: "chan6_scaled", Type: "int16_t", Description: "RC channel 6 value scaled."},
+			{Name: "chan7_scaled", Type: "int16_t", Description: "RC channel 7 value scaled."},
+			{Name: "chan8_scaled", Type: "int16_t", Description: "RC channel 8 value scaled."},
+			{Name: "rssi", Type: "uint8_t", Description: "Receive signal strength indicator in device-dependent units/scale. Values: [0-254], UINT8_MAX: invalid/unknown."},
+		},
+	},
+	35: {
+		Name:        "RC_CHANNELS_RAW",
+		Description: "The RAW values of the RC channels received. The standard PPM modulation is as follows: 1000 microseconds: 0%, 2000 microseconds: 100%. A value of UINT16_MAX implies the channel is unused. Individual receivers/transmitters might violate this specification.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "port", Type: "uint8_t", Description: "Servo output port (set of 8 outputs = 1 port). Flight stacks running on Pixhawk should use: 0 = MAIN, 1 = AUX."},
+			{Name: "chan1_raw", Type: "uint16_t", Description: "RC channel 1 value."},
+			{Name: "chan2_raw", Type: "uint16_t", Description: "RC channel 2 value."},
+			{Name: "chan3_raw", Type: "uint16_t", Description: "RC channel 3 value."},
+			{Name: "chan4_raw", Type: "uint16_t", Description: "RC channel 4 value."},
+			{Name: "chan5_raw", Type: "uint16_t", Description: "RC channel 5 value."},
+			{Name: "chan6_raw", Type: "uint16_t", Description: "RC channel 6 value."},
+			{Name: "chan7_raw", Type: "uint16_t", Description: "RC channel 7 value."},
+			{Name: "chan8_raw", Type: "uint16_t", Description: "RC channel 8 value."},
+			{Name: "rssi", Type: "uint8_t", Description: "Receive signal strength indicator in device-dependent units/scale. Values: [0-254], UINT8_MAX: invalid/unknown."},
+		},
+	},
+	36: {
+		Name:        "SERVO_OUTPUT_RAW",
+		Description: "Superseded by ACTUATOR_OUTPUT_STATUS. The RAW values of the servo outputs (for RC input from the remote, use the RC_CHANNELS messages). The standard PPM modulation is as follows: 1000 microseconds: 0%, 2000 microseconds: 100%.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint32_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "port", Type: "uint8_t", Description: "Servo output port (set of 8 outputs = 1 port). Flight stacks running on Pixhawk should use: 0 = MAIN, 1 = AUX."},
+			{Name: "servo1_raw", Type: "uint16_t", Description: "Servo output 1 value"},
+			{Name: "servo2_raw", Type: "uint16_t", Description: "Servo output 2 value"},
+			{Name: "servo3_raw", Type: "uint16_t", Description: "Servo output 3 value"},
+			{Name: "servo4_raw", Type: "uint16_t", Description: "Servo output 4 value"},
+			{Name: "servo5_raw", Type: "uint16_t", Description: "Servo output 5 value"},
+			{Name: "servo6_raw", Type: "uint16_t", Description: "Servo output 6 value"},
+			{Name: "servo7_raw", Type: "uint16_t", Description: "Servo output 7 value"},
+			{Name: "servo8_raw", Type: "uint16_t", Description: "Servo output 8 value"},
+			{Name: "servo9_raw", Type: "uint16_t", Description: "Servo output 9 value", Extension: true},
+			{Name: "servo10_raw", Type: "uint16_t", Description: "Servo output 10 value", Extension: true},
+			{Name: "servo11_raw", Type: "uint16_t", Description: "Servo output 11 value", Extension: true},
+			{Name: "servo12_raw", Type: "uint16_t", Description: "Servo output 12 value", Extension: true},
+			{Name: "servo13_raw", Type: "uint16_t", Description: "Servo output 13 value", Extension: true},
+			{Name: "servo14_raw", Type: "uint16_t", Description: "Servo output 14 value", Extension: true},
+			{Name: "servo15_raw", Type: "uint16_t", Description: "Servo output 15 value", Extension: true},
+			{Name: "servo16_raw", Type: "uint16_t", Description: "Servo output 16 value", Extension: true},
+		},
+	},
+	37: {
+		Name:        "MISSION_REQUEST_PARTIAL_LIST",
+		Description: "Request a partial list of mission items from the system/component. https://mavlink.io/en/services/mission.html. If start and end index are the same, just send one waypoint.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "start_index", Type: "int16_t", Description: "Start index"},
+			{Name: "end_index", Type: "int16_t", Description: "End index, -1 by default (-1: send list to end). Else a valid index of the list"},
+			{Name: "mission_type", Type: "uint8_t", Enum: "MAV_MISSION_TYPE", Description: "Mission type.", Extension: true},
+		},
+	},
+	38: {
+		Name:        "MISSION_WRITE_PARTIAL_LIST",
+		Description: "This message is sent to the MAV to write a partial list. If start index == end index, only one item will be transmitted / updated. If the start index is NOT 0 and above the current list size, this request should be REJECTED!",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "start_index", Type: "int16_t", Description: "Start index. Must be smaller / equal to the largest index of the current onboard list."},
+			{Name: "end_index", Type: "int16_t", Description: "End index, equal or greater than start index."},
+			{Name: "mission_type", Type: "uint8_t", Enum: "MAV_MISSION_TYPE", Description: "Mission type.", Extension: true},
+		},
+	},
+	39: {
+		Name:        "MISSION_ITEM",
+		Description: "Message encoding a mission item. This message is emitted to announce                the presence of a mission item and to set a mission item on the system. The mission item can be either in x, y, z meters (type: LOCAL) or x:lat, y:lon, z:altitude. Local frame is Z-down, right handed (NED), global frame is Z-up, right handed (ENU). NaN may be used to indicate an optional/default value (e.g. to use the system's current latitude or yaw rather than a specific value). See also https://mavlink.io/en/services/mission.html.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "seq", Type: "uint16_t", Description: "Sequence"},
+			{Name: "frame", Type: "uint8_t", Enum: "MAV_FRAME", Description: "The coordinate system of the waypoint."},
+			{Name: "command", Type: "uint16_t", Enum: "MAV_CMD", Description: "The scheduled action for the waypoint."},
+			{Name: "current", Type: "uint8_t", Description: "false:0, true:1"},
+			{Name: "autocontinue", Type: "uint8_t", Description: "Autocontinue to next waypoint"},
+			{Name: "param1", Type: "float", Description: "PARAM1, see MAV_CMD enum"},
+			{Name: "param2", Type: "float", Description: "PARAM2, see MAV_CMD enum"},
+			{Name: "param3", Type: "float", Description: "PARAM3, see MAV_CMD enum"},
+			{Name: "param4", Type: "float", Description: "PARAM4, see MAV_CMD enum"},
+			{Name: "x", Type: "float", Description: "PARAM5 / local: X coordinate, global: latitude"},
+			{Name: "y", Type: "float", Description: "PARAM6 / local: Y coordinate, global: longitude"},
+			{Name: "z", Type: "float", Description: "PARAM7 / local: Z coordinate, global: altitude (relative or absolute, depending on frame)."},
+			{Name: "mission_type", Type: "uint8_t", Enum: "MAV_MISSION_TYPE", Description: "Mission type.", Extension: true},
+		},
+	},
+	40: {
+		Name:        "MISSION_REQUEST",
+		Description: "Request the information of the mission item with the sequence number seq. The response of the system to this message should be a MISSION_ITEM message. https://mavlink.io/en/services/mission.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "seq", Type: "uint16_t", Description: "Sequence"},
+			{Name: "mission_type", Type: "uint8_t", Enum: "MAV_MISSION_TYPE", Description: "Mission type.", Extension: true},
+		},
+	},
+	41: {
+		Name:        "MISSION_SET_CURRENT",
+		Description: "Set the mission item with sequence number seq as current item. This means that the MAV will continue to this mission item on the shortest path (not following the mission items in-between).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "seq", Type: "uint16_t", Description: "Sequence"},
+		},
+	},
+	42: {
+		Name:        "MISSION_CURRENT",
+		Description: "Message that announces the sequence number of the current active mission item. The MAV will fly towards this mission item.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "seq", Type: "uint16_t", Description: "Sequence"},
+		},
+	},
+	43: {
+		Name:        "MISSION_REQUEST_LIST",
+		Description: "Request the overall list of mission items from the system/component.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "mission_type", Type: "uint8_t", Enum: "MAV_MISSION_TYPE", Description: "Mission type.", Extension: true},
+		},
+	},
+	44: {
+		Name:        "MISSION_COUNT",
+		Description: "This message is emitted as response to MISSION_REQUEST_LIST by the MAV and to initiate a write transaction. The GCS can then request the individual mission item based on the knowledge of the total number of waypoints.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "count", Type: "uint16_t", Description: "Number of mission items in the sequence"},
+			{Name: "mission_type", Type: "uint8_t", Enum: "MAV_MISSION_TYPE", Description: "Mission type.", Extension: true},
+		},
+	},
+	45: {
+		Name:        "MISSION_CLEAR_ALL",
+		Description: "Delete all mission items at once.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "mission_type", Type: "uint8_t", Enum: "MAV_MISSION_TYPE", Description: "Mission type.", Extension: true},
+		},
+	},
+	46: {
+		Name:        "MISSION_ITEM_REACHED",
+		Description: "A certain mission item has been reached. The system will either hold this position (or circle on the orbit) or (if the autocontinue on the WP was set) continue to the next waypoint.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "seq", Type: "uint16_t", Description: "Sequence"},
+		},
+	},
+	47: {
+		Name:        "MISSION_ACK",
+		Description: "Acknowledgment message during waypoint handling. The type field states if this message is a positive ack (type=0) or if an error happened (type=non-zero).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "type", Type: "uint8_t", Enum: "MAV_MISSION_RESULT", Description: "Mission result."},
+			{Name: "mission_type", Type: "uint8_t", Enum: "MAV_MISSION_TYPE", Description: "Mission type.", Extension: true},
+		},
+	},
+	48: {
+		Name:        "SET_GPS_GLOBAL_ORIGIN",
+		Description: "Sets the GPS co-ordinates of the vehicle local origin (0,0,0) position. Vehicle should emit GPS_GLOBAL_ORIGIN irrespective of whether the origin is changed. This enables transform between the local coordinate frame and the global (GPS) coordinate frame, which may be necessary when (for example) indoor and outdoor settings are connected and the MAV should move from in- to outdoor.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "latitude", Type: "int32_t", Description: "Latitude (WGS84)"},
+			{Name: "longitude", Type: "int32_t", Description: "Longitude (WGS84)"},
+			{Name: "altitude", Type: "int32_t", Description: "Altitude (MSL). Positive for up."},
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number.", Extension: true},
+		},
+	},
+	49: {
+		Name:        "GPS_GLOBAL_ORIGIN",
+		Description: "Publishes the GPS co-ordinates of the vehicle local origin (0,0,0) position. Emitted whenever a new GPS-Local position mapping is requested or set - e.g. following SET_GPS_GLOBAL_ORIGIN message.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "latitude", Type: "int32_t", Description: "Latitude (WGS84)"},
+			{Name: "longitude", Type: "int32_t", Description: "Longitude (WGS84)"},
+			{Name: "altitude", Type: "int32_t", Description: "Altitude (MSL). Positive for up."},
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number.", Extension: true},
+		},
+	},
+	50: {
+		Name:        "PARAM_MAP_RC",
+		Description: "Bind a RC channel to a parameter. The parameter should change according to the RC channel value.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "param_id", Type: "char", ArrayLength: 16, Description: "Onboard parameter id, terminated by NULL if the length is less than 16 human-readable chars and WITHOUT null termination (NULL) byte if the length is exactly 16 chars - applications have to provide 16+1 bytes storage if the ID is stored as string"},
+			{Name: "param_index", Type: "int16_t", Description: "Parameter index. Send -1 to use the param ID field as identifier (else the param id will be ignored), send -2 to disable any existing map for this rc_channel_index."},
+			{Name: "parameter_rc_channel_index", Type: "uint8_t", Description: "Index of parameter RC channel. Not equal to the RC channel id. Typically corresponds to a potentiometer-knob on the RC."},
+			{Name: "param_value0", Type: "float", Description: "Initial parameter value"},
+			{Name: "scale", Type: "float", Description: "Scale, maps the RC range [-1, 1] to a parameter value"},
+			{Name: "param_value_min", Type: "float", Description: "Minimum param value. The protocol does not define if this overwrites an onboard minimum value. (Depends on implementation)"},
+			{Name: "param_value_max", Type: "float", Description: "Maximum param value. The protocol does not define if this overwrites an onboard maximum value. (Depends on implementation)"},
+		},
+	},
+	51: {
+		Name:        "MISSION_REQUEST_INT",
+		Description: "Request the information of the mission item with the sequence number seq. The response of the system to this message should be a MISSION_ITEM_INT message. https://mavlink.io/en/services/mission.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "seq", Type: "uint16_t", Description: "Sequence"},
+			{Name: "mission_type", Type: "uint8_t", Enum: "MAV_MISSION_TYPE", Description: "Mission type.", Extension: true},
+		},
+	},
+	52: {
+		Name:        "MISSION_CHANGED",
+		Description: "A broadcast message to notify any ground station or SDK if a mission, geofence or safe points have changed on the vehicle.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "start_index", Type: "int16_t", Description: "Start index for partial mission change (-1 for all items)."},
+			{Name: "end_index", Type: "int16_t", Description: "End index of a partial mission change. -1 is a synonym for the last mission item (i.e. selects all items from start_index). Ignore field if start_index=-1."},
+			{Name: "origin_sysid", Type: "uint8_t", Description: "System ID of the author of the new mission."},
+			{Name: "origin_compid", Type: "uint8_t", Enum: "MAV_COMPONENT", Description: "Compnent ID of the author of the new mission."},
+			{Name: "mission_type", Type: "uint8_t", Enum: "MAV_MISSION_TYPE", Description: "Mission type."},
+		},
+	},
+	54: {
+		Name:        "SAFETY_SET_ALLOWED_AREA",
+		Description: "Set a safety zone (volume), which is defined by two corners of a cube. This message can be used to tell the MAV which setpoints/waypoints to accept and which to reject. Safety areas are often enforced by national or competition regulations.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "frame", Type: "uint8_t", Enum: "MAV_FRAME", Description: "Coordinate frame. Can be either global, GPS, right-handed with Z axis up or local, right handed, Z axis down."},
+			{Name: "p1x", Type: "float", Description: "x position 1 / Latitude 1"},
+			{Name: "p1y", Type: "float", Description: "y position 1 / Longitude 1"},
+			{Name: "p1z", Type: "float", Description: "z position 1 / Altitude 1"},
+			{Name: "p2x", Type: "float", Description: "x position 2 / Latitude 2"},
+			{Name: "p2y", Type: "float", Description: "y position 2 / Longitude 2"},
+			{Name: "p2z", Type: "float", Description: "z position 2 / Altitude 2"},
+		},
+	},
+	55: {
+		Name:        "SAFETY_ALLOWED_AREA",
+		Description: "Read out the safety zone the MAV currently assumes.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "frame", Type: "uint8_t", Enum: "MAV_FRAME", Description: "Coordinate frame. Can be either global, GPS, right-handed with Z axis up or local, right handed, Z axis down."},
+			{Name: "p1x", Type: "float", Description: "x position 1 / Latitude 1"},
+			{Name: "p1y", Type: "float", Description: "y position 1 / Longitude 1"},
+			{Name: "p1z", Type: "float", Description: "z position 1 / Altitude 1"},
+			{Name: "p2x", Type: "float", Description: "x position 2 / Latitude 2"},
+			{Name: "p2y", Type: "float", Description: "y position 2 / Longitude 2"},
+			{Name: "p2z", Type: "float", Description: "z position 2 / Altitude 2"},
+		},
+	},
+	61: {
+		Name:        "ATTITUDE_QUATERNION_COV",
+		Description: "The attitude in the aeronautical frame (right-handed, Z-down, X-front, Y-right), expressed as quaternion. Quaternion order is w, x, y, z and a zero rotation would be expressed as (1 0 0 0).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "Quaternion components, w, x, y, z (1 0 0 0 is the null-rotation)"},
+			{Name: "rollspeed", Type: "float", Description: "Roll angular speed"},
+			{Name: "pitchspeed", Type: "float", Description: "Pitch angular speed"},
+			{Name: "yawspeed", Type: "float", Description: "Yaw angular speed"},
+			{Name: "covariance", Type: "float", ArrayLength: 9, Description: "Row-major representation of a 3x3 attitude covariance matrix (states: roll, pitch, yaw; first three entries are the first ROW, next three entries are the second row, etc.). If unknown, assign NaN value to first element in the array."},
+		},
+	},
+	62: {
+		Name:        "NAV_CONTROLLER_OUTPUT",
+		Description: "The state of the navigation and position controller.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "nav_roll", Type: "float", Description: "Current desired roll"},
+			{Name: "nav_pitch", Type: "float", Description: "Current desired pitch"},
+			{Name: "nav_bearing", Type: "int16_t", Description: "Current desired heading"},
+			{Name: "target_bearing", Type: "int16_t", Description: "Bearing to current waypoint/target"},
+			{Name: "wp_dist", Type: "uint16_t", Description: "Distance to active waypoint"},
+			{Name: "alt_error", Type: "float", Description: "Current altitude error"},
+			{Name: "aspd_error", Type: "float", Description: "Current airspeed error"},
+			{Name: "xtrack_error", Type: "float", Description: "Current crosstrack error on x-y plane"},
+		},
+	},
+	63: {
+		Name:        "GLOBAL_POSITION_INT_COV",
+		Description: "The filtered global position (e.g. fused GPS and accelerometers). The position is in GPS-frame (right-handed, Z-up). It  is designed as scaled integer message since the resolution of float is not sufficient. NOTE: This message is intended for onboard networks / companion computers and higher-bandwidth links and optimized for accuracy and completeness. Please use the GLOBAL_POSITION_INT message for a minimal subset.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "estimator_type", Type: "uint8_t", Enum: "MAV_ESTIMATOR_TYPE", Description: "Class id of the estimator this estimate originated from."},
+			{Name: "lat", Type: "int32_t", Description: "Latitude"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude"},
+			{Name: "alt", Type: "int32_t", Description: "Altitude in meters above MSL"},
+			{Name: "relative_alt", Type: "int32_t", Description: "Altitude above ground"},
+			{Name: "vx", Type: "float", Description: "Ground X Speed (Latitude)"},
+			{Name: "vy", Type: "float", Description: "Ground Y Speed (Longitude)"},
+			{Name: "vz", Type: "float", Description: "Ground Z Speed (Altitude)"},
+			{Name: "covariance", Type: "float", ArrayLength: 36, Description: "Row-major representation of a 6x6 position and velocity 6x6 cross-covariance matrix (states: lat, lon, alt, vx, vy, vz; first six entries are the first ROW, next six entries are the second row, etc.). If unknown, assign NaN value to first element in the array."},
+		},
+	},
+	64: {
+		Name:        "LOCAL_POSITION_NED_COV",
+		Description: "The filtered local position (e.g. fused computer vision and accelerometers). Coordinate frame is right-handed, Z-axis down (aeronautical frame, NED / north-east-down convention)",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "estimator_type", Type: "uint8_t", Enum: "MAV_ESTIMATOR_TYPE", Description: "Class id of the estimator this estimate originated from."},
+			{Name: "x", Type: "float", Description: "X Position"},
+			{Name: "y", Type: "float", Description: "Y Position"},
+			{Name: "z", Type: "float", Description: "Z Position"},
+			{Name: "vx", Type: "float", Description: "X Speed"},
+			{Name: "vy", Type: "float", Description: "Y Speed"},
+			{Name: "vz", Type: "float", Description: "Z Speed"},
+			{Name: "ax", Type: "float", Description: "X Acceleration"},
+			{Name: "ay", Type: "float", Description: "Y Acceleration"},
+			{Name: "az", Type: "float", Description: "Z Acceleration"},
+			{Name: "covariance", Type: "float", ArrayLength: 45, Description: "Row-major representation of position, velocity and acceleration 9x9 cross-covariance matrix upper right triangle (states: x, y, z, vx, vy, vz, ax, ay, az; first nine entries are the first ROW, next eight entries are the second row, etc.). If unknown, assign NaN value to first element in the array."},
+		},
+	},
+	65: {
+		Name:        "RC_CHANNELS",
+		Description: "The PPM values of the RC channels received. The standard PPM modulation is as follows: 1000 microseconds: 0%, 2000 microseconds: 100%.  A value of UINT16_MAX implies the channel is unused. Individual receivers/transmitters might violate this specification.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "chancount", Type: "uint8_t", Description: "Total number of RC channels being received. This can be larger than 18, indicating that more channels are available but not given in this message. This value should be 0 when no RC channels are available."},
+			{Name: "chan1_raw", Type: "uint16_t", Description: "RC channel 1 value."},
+			{Name: "chan2_raw", Type: "uint16_t", Description: "RC channel 2 value."},
+			{Name: "chan3_raw", Type: "uint16_t", Description: "RC channel 3 value."},
+			{Name: "chan4_raw", Type: "uint16_t", Description: "RC channel 4 value."},
+			{Name: "chan5_raw", Type: "uint16_t", Description: "RC channel 5 value."},
+			{Name: "chan6_raw", Type: "uint16_t", Description: "RC channel 6 value."},
+			{Name: "chan7_raw", Type: "uint16_t", Description: "RC channel 7 value."},
+			{Name: "chan8_raw", Type: "uint16_t", Description: "RC channel 8 value."},
+			{Name: "chan9_raw", Type: "uint16_t", Description: "RC channel 9 value."},
+			{Name: "chan10_raw", Type: "uint16_t", Description: "RC channel 10 value."},
+			{Name: "chan11_raw", Type: "uint16_t", Description: "RC channel 11 value."},
+			{Name: "chan12_raw", Type: "uint16_t", Description: "RC channel 12 value."},
+			{Name: "chan13_raw", Type: "uint16_t", Description: "RC channel 13 value."},
+			{Name: "chan14_raw", Type: "uint16_t", Description: "RC channel 14 value."},
+			{Name: "chan15_raw", Type: "uint16_t", Description: "RC channel 15 value."},
+			{Name: "chan16_raw", Type: "uint16_t", Description: "RC channel 16 value."},
+			{Name: "chan17_raw", Type: "uint16_t", Description: "RC channel 17 value."},
+			{Name: "chan18_raw", Type: "uint16_t", Description: "RC channel 18 value."},
+			{Name: "rssi", Type: "uint8_t", Description: "Receive signal strength indicator in device-dependent units/scale. Values: [0-254], UINT8_MAX: invalid/unknown."},
+		},
+	},
+	66: {
+		Name:        "REQUEST_DATA_STREAM",
+		Description: "Request a data stream.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "The target requested to send the message stream."},
+			{Name: "target_component", Type: "uint8_t", Description: "The target requested to send the message stream."},
+			{Name: "req_stream_id", Type: "uint8_t", Description: "The ID of the requested data stream"},
+			{Name: "req_message_rate", Type: "uint16_t", Description: "The requested message rate"},
+			{Name: "start_stop", Type: "uint8_t", Description: "1 to start sending, 0 to stop sending."},
+		},
+	},
+	67: {
+		Name:        "DATA_STREAM",
+		Description: "Data stream status information.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "stream_id", Type: "uint8_t", Description: "The ID of the requested data stream"},
+			{Name: "message_rate", Type: "uint16_t", Description: "The message rate"},
+			{Name: "on_off", Type: "uint8_t", Description: "1 stream is enabled, 0 stream is stopped."},
+		},
+	},
+	69: {
+		Name:        "MANUAL_CONTROL",
+		Description: "This message provides an API for manually controlling the vehicle using standard joystick axes nomenclature, along with a joystick-like input device. Unused axes can be disabled an buttons are also transmit as boolean values of their",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target", Type: "uint8_t", Description: "The system to be controlled."},
+			{Name: "x", Type: "int16_t", Description: "X-axis, normalized to the range [-1000,1000]. A value of INT16_MAX indicates that this axis is invalid. Generally corresponds to forward(1000)-backward(-1000) movement on a joystick and the pitch of a vehicle."},
+			{Name: "y", Type: "int16_t", Description: "Y-axis, normalized to the range [-1000,1000]. A value of INT16_MAX indicates that this axis is invalid. Generally corresponds to left(-1000)-right(1000) movement on a joystick and the roll of a vehicle."},
+			{Name: "z", Type: "int16_t", Description: "Z-axis, normalized to the range [-1000,1000]. A value of INT16_MAX indicates that this axis is invalid. Generally corresponds to a separate slider movement with maximum being 1000 and minimum being -1000 on a joystick and the thrust of a vehicle. Positive values are positive thrust, negative values are negative thrust."},
+			{Name: "r", Type: "int16_t", Description: "R-axis, normalized to the range [-1000,1000]. A value of INT16_MAX indicates that this axis is invalid. Generally corresponds to a twisting of the joystick, with counter-clockwise being 1000 and clockwise being -1000, and the yaw of a vehicle."},
+			{Name: "buttons", Type: "uint16_t", Description: "A bitfield corresponding to the joystick buttons' current state, 1 for pressed, 0 for released. The lowest bit corresponds to Button 1."},
+		},
+	},
+	70: {
+		Name:        "RC_CHANNELS_OVERRIDE",
+		Description: "The RAW values of the RC channels sent to the MAV to override info received from the RC radio. The standard PPM modulation is as follows: 1000 microseconds: 0%, 2000 microseconds: 100%. Individual receivers/transmitters might violate this specification.  Note carefully the semantic differences between the first 8 channels and the subsequent channels",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "chan1_raw", Type: "uint16_t", Description: "RC channel 1 value. A value of UINT16_MAX means to ignore this field. A value of 0 means to release this channel back to the RC radio."},
+			{Name: "chan2_raw", Type: "uint16_t", Description: "RC channel 2 value. A value of UINT16_MAX means to ignore this field. A value of 0 means to release this channel back to the RC radio."},
+			{Name: "chan3_raw", Type: "uint16_t", Description: "RC channel 3 value. A value of UINT16_MAX means to ignore this field. A value of 0 means to release this channel back to the RC radio."},
+			{Name: "chan4_raw", Type: "uint16_t", Description: "RC channel 4 value. A value of UINT16_MAX means to ignore this field. A value of 0 means to release this channel back to the RC radio."},
+			{Name: "chan5_raw", Type: "uint16_t", Description: "RC channel 5 value. A value of UINT16_MAX means to ignore this field. A value of 0 means to release this channel back to the RC radio."},
+			{Name: "chan6_raw", Type: "uint16_t", Description: "RC channel 6 value. A value of UINT16_MAX means to ignore this field. A value of 0 means to release this channel back to the RC radio."},
+			{Name: "chan7_raw", Type: "uint16_t", Description: "RC channel 7 value. A value of UINT16_MAX means to ignore this field. A value of 0 means to release this channel back to the RC radio."},
+			{Name: "chan8_raw", Type: "uint16_t", Description: "RC channel 8 value. A value of UINT16_MAX means to ignore this field. A value of 0 means to release this channel back to the RC radio."},
+			{Name: "chan9_raw", Type: "uint16_t", Description: "RC channel 9 value. A value of 0 or UINT16_MAX means to ignore this field. A value of UINT16_MAX-1 means to release this channel back to the RC radio.", Extension: true},
+			{Name: "chan10_raw", Type: "uint16_t", Description: "RC channel 10 value. A value of 0 or UINT16_MAX means to ignore this field. A value of UINT16_MAX-1 means to release this channel back to the RC radio.", Extension: true},
+			{Name: "chan11_raw", Type: "uint16_t", Description: "RC channel 11 value. A value of 0 or UINT16_MAX means to ignore this field. A value of UINT16_MAX-1 means to release this channel back to the RC radio.", Extension: true},
+			{Name: "chan12_raw", Type: "uint16_t", Description: "RC channel 12 value. A value of 0 or UINT16_MAX means to ignore this field. A value of UINT16_MAX-1 means to release this channel back to the RC radio.", Extension: true},
+			{Name: "chan13_raw", Type: "uint16_t", Description: "RC channel 13 value. A value of 0 or UINT16_MAX means to ignore this field. A value of UINT16_MAX-1 means to release this channel back to the RC radio.", Extension: true},
+			{Name: "chan14_raw", Type: "uint16_t", Description: "RC channel 14 value. A value of 0 or UINT16_MAX means to ignore this field. A value of UINT16_MAX-1 means to release this channel back to the RC radio.", Extension: true},
+			{Name: "chan15_raw", Type: "uint16_t", Description: "RC channel 15 value. A value of 0 or UINT16_MAX means to ignore this field. A value of UINT16_MAX-1 means to release this channel back to the RC radio.", Extension: true},
+			{Name: "chan16_raw", Type: "uint16_t", Description: "RC channel 16 value. A value of 0 or UINT16_MAX means to ignore this field. A value of UINT16_MAX-1 means to release this channel back to the RC radio.", Extension: true},
+			{Name: "chan17_raw", Type: "uint16_t", Description: "RC channel 17 value. A value of 0 or UINT16_MAX means to ignore this field. A value of UINT16_MAX-1 means to release this channel back to the RC radio.", Extension: true},
+			{Name: "chan18_raw", Type: "uint16_t", Description: "RC channel 18 value. A value of 0 or UINT16_MAX means to ignore this field. A value of UINT16_MAX-1 means to release this channel back to the RC radio.", Extension: true},
+		},
+	},
+	73: {
+		Name:        "MISSION_ITEM_INT",
+		Description: "Message encoding a mission item. This message is emitted to announce                the presence of a mission item and to set a mission item on the system. The mission item can be either in x, y, z meters (type: LOCAL) or x:lat, y:lon, z:altitude. Local frame is Z-down, right handed (NED), global frame is Z-up, right handed (ENU). NaN or INT32_MAX may be used in float/integer params (respectively) to indicate optional/default values (e.g. to use the component's current latitude, yaw rather than a specific value). See also https://mavlink.io/en/services/mission.html.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "seq", Type: "uint16_t", Description: "Waypoint ID (sequence number). Starts at zero. Increases monotonically for each waypoint, no gaps in the sequence (0,1,2,3,4)."},
+			{Name: "frame", Type: "uint8_t", Enum: "MAV_FRAME", Description: "The coordinate system of the waypoint."},
+			{Name: "command", Type: "uint16_t", Enum: "MAV_CMD", Description: "The scheduled action for the waypoint."},
+			{Name: "current", Type: "uint8_t", Description: "false:0, true:1"},
+			{Name: "autocontinue", Type: "uint8_t", Description: "Autocontinue to next waypoint"},
+			{Name: "param1", Type: "float", Description: "PARAM1, see MAV_CMD enum"},
+			{Name: "param2", Type: "float", Description: "PARAM2, see MAV_CMD enum"},
+			{Name: "param3", Type: "float", Description: "PARAM3, see MAV_CMD enum"},
+			{Name: "param4", Type: "float", Description: "PARAM4, see MAV_CMD enum"},
+			{Name: "x", Type: "int32_t", Description: "PARAM5 / local: x position in meters * 1e4, global: latitude in degrees * 10^7"},
+			{Name: "y", Type: "int32_t", Description: "PARAM6 / y position: local: x position in meters * 1e4, global: longitude in degrees *10^7"},
+			{Name: "z", Type: "float", Description: "PARAM7 / z position: global: altitude in meters (relative or absolute, depending on frame."},
+			{Name: "mission_type", Type: "uint8_t", Enum: "MAV_MISSION_TYPE", Description: "Mission type.", Extension: true},
+		},
+	},
+	74: {
+		Name:        "VFR_HUD",
+		Description: "Metrics typically displayed on a HUD for fixed wing aircraft.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "airspeed", Type: "float", Description: "Vehicle speed in form appropriate for vehicle type. For standard aircraft this is typically calibrated airspeed (CAS) or indicated airspeed (IAS) - either of which can be used by a pilot to estimate stall speed."},
+			{Name: "groundspeed", Type: "float", Description: "Current ground speed."},
+			{Name: "heading", Type: "int16_t", Description: "Current heading in compass units (0-360, 0=north)."},
+			{Name: "throttle", Type: "uint16_t", Description: "Current throttle setting (0 to 100)."},
+			{Name: "alt", Type: "float", Description: "Current altitude (MSL)."},
+			{Name: "climb", Type: "float", Description: "Current climb rate."},
+		},
+	},
+	75: {
+		Name:        "COMMAND_INT",
+		Description: "Message encoding a command with parameters as scaled integers. Scaling depends on the actual command value. NaN or INT32_MAX may be used in float/integer params (respectively) to indicate optional/default values (e.g. to use the component's current latitude, yaw rather than a specific value). The command microservice is documented at https://mavlink.io/en/services/command.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "frame", Type: "uint8_t", Enum: "MAV_FRAME", Description: "The coordinate system of the COMMAND."},
+			{Name: "command", Type: "uint16_t", Enum: "MAV_CMD", Description: "The scheduled action for the mission item."},
+			{Name: "current", Type: "uint8_t", Description: "Not used."},
+			{Name: "autocontinue", Type: "uint8_t", Description: "Not used (set 0)."},
+			{Name: "param1", Type: "float", Description: "PARAM1, see MAV_CMD enum"},
+			{Name: "param2", Type: "float", Description: "PARAM2, see MAV_CMD enum"},
+			{Name: "param3", Type: "float", Description: "PARAM3, see MAV_CMD enum"},
+			{Name: "param4", Type: "float", Description: "PARAM4, see MAV_CMD enum"},
+			{Name: "x", Type: "int32_t", Description: "PARAM5 / local: x position in meters * 1e4, global: latitude in degrees * 10^7"},
+			{Name: "y", Type: "int32_t", Description: "PARAM6 / local: y position in meters * 1e4, global: longitude in degrees * 10^7"},
+			{Name: "z", Type: "float", Description: "PARAM7 / z position: global: altitude in meters (relative or absolute, depending on frame)."},
+		},
+	},
+	76: {
+		Name:        "COMMAND_LONG",
+		Description: "Send a command with up to seven parameters to the MAV. The command microservice is documented at https://mavlink.io/en/services/command.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System which should execute the command"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component which should execute the command, 0 for all components"},
+			{Name: "command", Type: "uint16_t", Enum: "MAV_CMD", Description: "Command ID (of command to send)."},
+			{Name: "confirmation", Type: "uint8_t", Description: "0: First transmission of this command. 1-255: Confirmation transmissions (e.g. for kill command)"},
+			{Name: "param1", Type: "float", Description: "Parameter 1 (for the specific command)."},
+			{Name: "param2", Type: "float", Description: "Parameter 2 (for the specific command)."},
+			{Name: "param3", Type: "float", Description: "Parameter 3 (for the specific command)."},
+			{Name: "param4", Type: "float", Description: "Parameter 4 (for the specific command)."},
+			{Name: "param5", Type: "float", Description: "Parameter 5 (for the specific command)."},
+			{Name: "param6", Type: "float", Description: "Parameter 6 (for the specific command)."},
+			{Name: "param7", Type: "float", Description: "Parameter 7 (for the specific command)."},
+		},
+	},
+	77: {
+		Name:        "COMMAND_ACK",
+		Description: "Report status of a command. Includes feedback whether the command was executed. The command microservice is documented at https://mavlink.io/en/services/command.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "command", Type: "uint16_t", Enum: "MAV_CMD", Description: "Command ID (of acknowledged command)."},
+			{Name: "result", Type: "uint8_t", Enum: "MAV_RESULT", Description: "Result of command."},
+			{Name: "progress", Type: "uint8_t", Description: "WIP: Also used as result_param1, it can be set with an enum containing the errors reasons of why the command was denied, or the progress percentage when result is MAV_RESULT_IN_PROGRESS (UINT8_MAX if the progress is unknown).", Extension: true},
+			{Name: "result_param2", Type: "int32_t", Description: "WIP: Additional parameter of the result, example: which parameter of MAV_CMD_NAV_WAYPOINT caused it to be denied.", Extension: true},
+			{Name: "target_system", Type: "uint8_t", Description: "WIP: System ID of the target recipient. This is the ID of the system that sent the command for which this COMMAND_ACK is an acknowledgement.", Extension: true},
+			{Name: "target_component", Type: "uint8_t", Description: "WIP: Component ID of the target recipient. This is the ID of the system that sent the command for which this COMMAND_ACK is an acknowledgement.", Extension: true},
+		},
+	},
+	80: {
+		Name:        "COMMAND_CANCEL",
+		Description: "Cancel a long running command. The target system should respond with a COMMAND_ACK to the original command with result=MAV_RESULT_CANCELLED if the long running process was cancelled. If it has already completed, the cancel action can be ignored. The cancel action can be retried until some sort of acknowledgement to the original command has been received. The command microservice is documented at https://mavlink.io/en/services/command.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System executing long running command. Should not be broadcast (0)."},
+			{Name: "target_component", Type: "uint8_t", Description: "Component executing long running command."},
+			{Name: "command", Type: "uint16_t", Enum: "MAV_CMD", Description: "Command ID (of command to cancel)."},
+		},
+	},
+	81: {
+		Name:        "MANUAL_SETPOINT",
+		Description: "Setpoint in roll, pitch, yaw and thrust from the operator",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "roll", Type: "float", Description: "Desired roll rate"},
+			{Name: "pitch", Type: "float", Description: "Desired pitch rate"},
+			{Name: "yaw", Type: "float", Description: "Desired yaw rate"},
+			{Name: "thrust", Type: "float", Description: "Collective thrust, normalized to 0 .. 1"},
+			{Name: "mode_switch", Type: "uint8_t", Description: "Flight mode switch position, 0.. 255"},
+			{Name: "manual_override_switch", Type: "uint8_t", Description: "Override mode switch position, 0.. 255"},
+		},
+	},
+	82: {
+		Name:        "SET_ATTITUDE_TARGET",
+		Description: "Sets a desired vehicle attitude. Used by an external controller to command the vehicle (manual controller or other system).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "type_mask", Type: "uint8_t", Enum: "ATTITUDE_TARGET_TYPEMASK", Description: "Bitmap to indicate which dimensions should be ignored by the vehicle."},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "Attitude quaternion (w, x, y, z order, zero-rotation is 1, 0, 0, 0)"},
+			{Name: "body_roll_rate", Type: "float", Description: "Body roll rate"},
+			{Name: "body_pitch_rate", Type: "float", Description: "Body pitch rate"},
+			{Name: "body_yaw_rate", Type: "float", Description: "Body yaw rate"},
+			{Name: "thrust", Type: "float", Description: "Collective thrust, normalized to 0 .. 1 (-1 .. 1 for vehicles capable of reverse trust)"},
+			{Name: "thrust_body", Type: "float", ArrayLength: 3, Description: "3D thrust setpoint in the body NED frame, normalized to -1 .. 1", Extension: true},
+		},
+	},
+	83: {
+		Name:        "ATTITUDE_TARGET",
+		Description: "Reports the current commanded attitude of the vehicle as specified by the autopilot. This should match the commands sent in a SET_ATTITUDE_TARGET message if the vehicle is being controlled this way.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "type_mask", Type: "uint8_t", Enum: "ATTITUDE_TARGET_TYPEMASK", Description: "Bitmap to indicate which dimensions should be ignored by the vehicle."},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "Attitude quaternion (w, x, y, z order, zero-rotation is 1, 0, 0, 0)"},
+			{Name: "body_roll_rate", Type: "float", Description: "Body roll rate"},
+			{Name: "body_pitch_rate", Type: "float", Description: "Body pitch rate"},
+			{Name: "body_yaw_rate", Type: "float", Description: "Body yaw rate"},
+			{Name: "thrust", Type: "float", Description: "Collective thrust, normalized to 0 .. 1 (-1 .. 1 for vehicles capable of reverse trust)"},
+		},
+	},
+	84: {
+		Name:        "SET_POSITION_TARGET_LOCAL_NED",
+		Description: "Sets a desired vehicle position in a local north-east-down coordinate frame. Used by an external controller to command the vehicle (manual controller or other system).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "coordinate_frame", Type: "uint8_t", Enum: "MAV_FRAME", Description: "Valid options are: MAV_FRAME_LOCAL_NED = 1, MAV_FRAME_LOCAL_OFFSET_NED = 7, MAV_FRAME_BODY_NED = 8, MAV_FRAME_BODY_OFFSET_NED = 9"},
+			{Name: "type_mask", Type: "uint16_t", Enum: "POSITION_TARGET_TYPEMASK", Description: "Bitmap to indicate which dimensions should be ignored by the vehicle."},
+			{Name: "x", Type: "float", Description: "X Position in NED frame"},
+			{Name: "y", Type: "float", Description: "Y Position in NED frame"},
+			{Name: "z", Type: "float", Description: "Z Position in NED frame (note, altitude is negative in NED)"},
+			{Name: "vx", Type: "float", Description: "X velocity in NED frame"},
+			{Name: "vy", Type: "float", Description: "Y velocity in NED frame"},
+			{Name: "vz", Type: "float", Description: "Z velocity in NED frame"},
+			{Name: "afx", Type: "float", Description: "X acceleration or force (if bit 10 of type_mask is set) in NED frame in meter / s^2 or N"},
+			{Name: "afy", Type: "float", Description: "Y acceleration or force (if bit 10 of type_mask is set) in NED frame in meter / s^2 or N"},
+			{Name: "afz", Type: "float", Description: "Z acceleration or force (if bit 10 of type_mask is set) in NED frame in meter / s^2 or N"},
+			{Name: "yaw", Type: "float", Description: "yaw setpoint"},
+			{Name: "yaw_rate", Type: "float", Description: "yaw rate setpoint"},
+		},
+	},
+	85: {
+		Name:        "POSITION_TARGET_LOCAL_NED",
+		Description: "Reports the current commanded vehicle position, velocity, and acceleration as specified by the autopilot. This should match the commands sent in SET_POSITION_TARGET_LOCAL_NED if the vehicle is being controlled this way.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "coordinate_frame", Type: "uint8_t", Enum: "MAV_FRAME", Description: "Valid options are: MAV_FRAME_LOCAL_NED = 1, MAV_FRAME_LOCAL_OFFSET_NED = 7, MAV_FRAME_BODY_NED = 8, MAV_FRAME_BODY_OFFSET_NED = 9"},
+			{Name: "type_mask", Type: "uint16_t", Enum: "POSITION_TARGET_TYPEMASK", Description: "Bitmap to indicate which dimensions should be ignored by the vehicle."},
+			{Name: "x", Type: "float", Description: "X Position in NED frame"},
+			{Name: "y", Type: "float", Description: "Y Position in NED frame"},
+			{Name: "z", Type: "float", Description: "Z Position in NED frame (note, altitude is negative in NED)"},
+			{Name: "vx", Type: "float", Description: "X velocity in NED frame"},
+			{Name: "vy", Type: "float", Description: "Y velocity in NED frame"},
+			{Name: "vz", Type: "float", Description: "Z velocity in NED frame"},
+			{Name: "afx", Type: "float", Description: "X acceleration or force (if bit 10 of type_mask is set) in NED frame in meter / s^2 or N"},
+			{Name: "afy", Type: "float", Description: "Y acceleration or force (if bit 10 of type_mask is set) in NED frame in meter / s^2 or N"},
+			{Name: "afz", Type: "float", Description: "Z acceleration or force (if bit 10 of type_mask is set) in NED frame in meter / s^2 or N"},
+			{Name: "yaw", Type: "float", Description: "yaw setpoint"},
+			{Name: "yaw_rate", Type: "float", Description: "yaw rate setpoint"},
+		},
+	},
+	86: {
+		Name:        "SET_POSITION_TARGET_GLOBAL_INT",
+		Description: "Sets a desired vehicle position, velocity, and/or acceleration in a global coordinate system (WGS84). Used by an external controller to command the vehicle (manual controller or other system).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot). The rationale for the timestamp in the setpoint is to allow the system to compensate for the transport delay of the setpoint. This allows the system to compensate processing latency."},
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "coordinate_frame", Type: "uint8_t", Enum: "MAV_FRAME", Description: "Valid options are: MAV_FRAME_GLOBAL_INT = 5, MAV_FRAME_GLOBAL_RELATIVE_ALT_INT = 6, MAV_FRAME_GLOBAL_TERRAIN_ALT_INT = 11"},
+			{Name: "type_mask", Type: "uint16_t", Enum: "POSITION_TARGET_TYPEMASK", Description: "Bitmap to indicate which dimensions should be ignored by the vehicle."},
+			{Name: "lat_int", Type: "int32_t", Description: "X Position in WGS84 frame"},
+			{Name: "lon_int", Type: "int32_t", Description: "Y Position in WGS84 frame"},
+			{Name: "alt", Type: "float", Description: "Altitude (MSL, Relative to home, or AGL - depending on frame)"},
+			{Name: "vx", Type: "float", Description: "X velocity in NED frame"},
+			{Name: "vy", Type: "float", Description: "Y velocity in NED frame"},
+			{Name: "vz", Type: "float", Description: "Z velocity in NED frame"},
+			{Name: "afx", Type: "float", Description: "X acceleration or force (if bit 10 of type_mask is set) in NED frame in meter / s^2 or N"},
+			{Name: "afy", Type: "float", Description: "Y acceleration or force (if bit 10 of type_mask is set) in NED frame in meter / s^2 or N"},
+			{Name: "afz", Type: "float", Description: "Z acceleration or force (if bit 10 of type_mask is set) in NED frame in meter / s^2 or N"},
+			{Name: "yaw", Type: "float", Description: "yaw setpoint"},
+			{Name: "yaw_rate", Type: "float", Description: "yaw rate setpoint"},
+		},
+	},
+	87: {
+		Name:        "POSITION_TARGET_GLOBAL_INT",
+		Description: "Reports the current commanded vehicle position, velocity, and acceleration as specified by the autopilot. This should match the commands sent in SET_POSITION_TARGET_GLOBAL_INT if the vehicle is being controlled this way.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot). The rationale for the timestamp in the setpoint is to allow the system to compensate for the transport delay of the setpoint. This allows the system to compensate processing latency."},
+			{Name: "coordinate_frame", Type: "uint8_t", Enum: "MAV_FRAME", Description: "Valid options are: MAV_FRAME_GLOBAL_INT = 5, MAV_FRAME_GLOBAL_RELATIVE_ALT_INT = 6, MAV_FRAME_GLOBAL_TERRAIN_ALT_INT = 11"},
+			{Name: "type_mask", Type: "uint16_t", Enum: "POSITION_TARGET_TYPEMASK", Description: "Bitmap to indicate which dimensions should be ignored by the vehicle."},
+			{Name: "lat_int", Type: "int32_t", Description: "X Position in WGS84 frame"},
+			{Name: "lon_int", Type: "int32_t", Description: "Y Position in WGS84 frame"},
+			{Name: "alt", Type: "float", Description: "Altitude (MSL, AGL or relative to home altitude, depending on frame)"},
+			{Name: "vx", Type: "float", Description: "X velocity in NED frame"},
+			{Name: "vy", Type: "float", Description: "Y velocity in NED frame"},
+			{Name: "vz", Type: "float", Description: "Z velocity in NED frame"},
+			{Name: "afx", Type: "float", Description: "X acceleration or force (if bit 10 of type_mask is set) in NED frame in meter / s^2 or N"},
+			{Name: "afy", Type: "float", Description: "Y acceleration or force (if bit 10 of type_mask is set) in NED frame in meter / s^2 or N"},
+			{Name: "afz", Type: "float", Description: "Z acceleration or force (if bit 10 of type_mask is set) in NED frame in meter / s^2 or N"},
+			{Name: "yaw", Type: "float", Description: "yaw setpoint"},
+			{Name: "yaw_rate", Type: "float", Description: "yaw rate setpoint"},
+		},
+	},
+	89: {
+		Name:        "LOCAL_POSITION_NED_SYSTEM_GLOBAL_OFFSET",
+		Description: "The offset in X, Y, Z and yaw between the LOCAL_POSITION_NED messages of MAV X and the global coordinate frame in NED coordinates. Coordinate frame is right-handed, Z-axis down (aeronautical frame, NED / north-east-down convention)",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "x", Type: "float", Description: "X Position"},
+			{Name: "y", Type: "float", Description: "Y Position"},
+			{Name: "z", Type: "float", Description: "Z Position"},
+			{Name: "roll", Type: "float", Description: "Roll"},
+			{Name: "pitch", Type: "float", Description: "Pitch"},
+			{Name: "yaw", Type: "float", Description: "Yaw"},
+		},
+	},
+	90: {
+		Name:        "HIL_STATE",
+		Description: "Sent from simulation to autopilot. This packet is useful for high throughput applications such as hardware in the loop simulations.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "roll", Type: "float", Description: "Roll angle"},
+			{Name: "pitch", Type: "float", Description: "Pitch angle"},
+			{Name: "yaw", Type: "float", Description: "Yaw angle"},
+			{Name: "rollspeed", Type: "float", Description: "Body frame roll / phi angular speed"},
+			{Name: "pitchspeed", Type: "float", Description: "Body frame pitch / theta angular speed"},
+			{Name: "yawspeed", Type: "float", Description: "Body frame yaw / psi angular speed"},
+			{Name: "lat", Type: "int32_t", Description: "Latitude"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude"},
+			{Name: "alt", Type: "int32_t", Description: "Altitude"},
+			{Name: "vx", Type: "int16_t", Description: "Ground X Speed (Latitude)"},
+			{Name: "vy", Type: "int16_t", Description: "Ground Y Speed (Longitude)"},
+			{Name: "vz", Type: "int16_t", Description: "Ground Z Speed (Altitude)"},
+			{Name: "xacc", Type: "int16_t", Description: "X acceleration"},
+			{Name: "yacc", Type: "int16_t", Description: "Y acceleration"},
+			{Name: "zacc", Type: "int16_t", Description: "Z acceleration"},
+		},
+	},
+	91: {
+		Name:        "HIL_CONTROLS",
+		Description: "Sent from autopilot to simulation. Hardware in the loop control outputs",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "roll_ailerons", Type: "float", Description: "Control output -1 .. 1"},
+			{Name: "pitch_elevator", Type: "float", Description: "Control output -1 .. 1"},
+			{Name: "yaw_rudder", Type: "float", Description: "Control output -1 .. 1"},
+			{Name: "throttle", Type: "float", Description: "Throttle 0 .. 1"},
+			{Name: "aux1", Type: "float", Description: "Aux 1, -1 .. 1"},
+			{Name: "aux2", Type: "float", Description: "Aux 2, -1 .. 1"},
+			{Name: "aux3", Type: "float", Description: "Aux 3, -1 .. 1"},
+			{Name: "aux4", Type: "float", Description: "Aux 4, -1 .. 1"},
+			{Name: "mode", Type: "uint8_t", Enum: "MAV_MODE", Description: "System mode."},
+			{Name: "nav_mode", Type: "uint8_t", Description: "Navigation mode (MAV_NAV_MODE)"},
+		},
+	},
+	92: {
+		Name:        "HIL_RC_INPUTS_RAW",
+		Description: "Sent from simulation to autopilot. The RAW values of the RC channels received. The standard PPM modulation is as follows: 1000 microseconds: 0%, 2000 microseconds: 100%. Individual receivers/transmitters might violate this specification.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "chan1_raw", Type: "uint16_t", Description: "RC channel 1 value"},
+			{Name: "chan2_raw", Type: "uint16_t", Description: "RC channel 2 value"},
+			{Name: "chan3_raw", Type: "uint16_t", Description: "RC channel 3 value"},
+			{Name: "chan4_raw", Type: "uint16_t", Description: "RC channel 4 value"},
+			{Name: "chan5_raw", Type: "uint16_t", Description: "RC channel 5 value"},
+			{Name: "chan6_raw", Type: "uint16_t", Description: "RC channel 6 value"},
+			{Name: "chan7_raw", Type: "uint16_t", Description: "RC channel 7 value"},
+			{Name: "chan8_raw", Type: "uint16_t", Description: "RC channel 8 value"},
+			{Name: "chan9_raw", Type: "uint16_t", Description: "RC channel 9 value"},
+			{Name: "chan10_raw", Type: "uint16_t", Description: "RC channel 10 value"},
+			{Name: "chan11_raw", Type: "uint16_t", Description: "RC channel 11 value"},
+			{Name: "chan12_raw", Type: "uint16_t", Description: "RC channel 12 value"},
+			{Name: "rssi", Type: "uint8_t", Description: "Receive signal strength indicator in device-dependent units/scale. Values: [0-254], UINT8_MAX: invalid/unknown."},
+		},
+	},
+	93: {
+		Name:        "HIL_ACTUATOR_CONTROLS",
+		Description: "Sent from autopilot to simulation. Hardware in the loop control outputs (replacement for HIL_CONTROLS)",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "controls", Type: "float", ArrayLength: 16, Description: "Control outputs -1 .. 1. Channel assignment depends on the simulated hardware."},
+			{Name: "mode", Type: "uint8_t", Enum: "MAV_MODE_FLAG", Description: "System mode. Includes arming state."},
+			{Name: "flags", Type: "uint64_t", Description: "Flags as bitfield, 1: indicate simulation using lockstep."},
+		},
+	},
+	100: {
+		Name:        "OPTICAL_FLOW",
+		Description: "Optical flow from a flow sensor (e.g. optical mouse sensor)",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "sensor_id", Type: "uint8_t", Description: "Sensor ID"},
+			{Name: "flow_x", Type: "int16_t", Description: "Flow in x-sensor direction"},
+			{Name: "flow_y", Type: "int16_t", Description: "Flow in y-sensor direction"},
+			{Name: "flow_comp_m_x", Type: "float", Description: "Flow in x-sensor direction, angular-speed compensated"},
+			{Name: "flow_comp_m_y", Type: "float", Description: "Flow in y-sensor direction, angular-speed compensated"},
+			{Name: "quality", Type: "uint8_t", Description: "Optical flow quality / confidence. 0: bad, 255: maximum quality"},
+			{Name: "ground_distance", Type: "float", Description: "Ground distance. Positive value: distance known. Negative value: Unknown distance"},
+			{Name: "flow_rate_x", Type: "float", Description: "Flow rate about X axis", Extension: true},
+			{Name: "flow_rate_y", Type: "float", Description: "Flow rate about Y axis", Extension: true},
+		},
+	},
+	101: {
+		Name:        "GLOBAL_VISION_POSITION_ESTIMATE",
+		Description: "Global position/attitude estimate from a vision source.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "usec", Type: "uint64_t", Description: "Timestamp (UNIX time or since system boot)"},
+			{Name: "x", Type: "float", Description: "Global X position"},
+			{Name: "y", Type: "float", Description: "Global Y position"},
+			{Name: "z", Type: "float", Description: "Global Z position"},
+			{Name: "roll", Type: "float", Description: "Roll angle"},
+			{Name: "pitch", Type: "float", Description: "Pitch angle"},
+			{Name: "yaw", Type: "float", Description: "Yaw angle"},
+			{Name: "covariance", Type: "float", ArrayLength: 21, Description: "Row-major representation of pose 6x6 cross-covariance matrix upper right triangle (states: x_global, y_global, z_global, roll, pitch, yaw; first six entries are the first ROW, next five entries are the second ROW, etc.). If unknown, assign NaN value to first element in the array.", Extension: true},
+			{Name: "reset_counter", Type: "uint8_t", Description: "Estimate reset counter. This should be incremented when the estimate resets in any of the dimensions (position, velocity, attitude, angular speed). This is designed to be used when e.g an external SLAM system detects a loop-closure and the estimate jumps.", Extension: true},
+		},
+	},
+	102: {
+		Name:        "VISION_POSITION_ESTIMATE",
+		Description: "Local position/attitude estimate from a vision source.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "usec", Type: "uint64_t", Description: "Timestamp (UNIX time or time since system boot)"},
+			{Name: "x", Type: "float", Description: "Local X position"},
+			{Name: "y", Type: "float", Description: "Local Y position"},
+			{Name: "z", Type: "float", Description: "Local Z position"},
+			{Name: "roll", Type: "float", Description: "Roll angle"},
+			{Name: "pitch", Type: "float", Description: "Pitch angle"},
+			{Name: "yaw", Type: "float", Description: "Yaw angle"},
+			{Name: "covariance", Type: "float", ArrayLength: 21, Description: "Row-major representation of pose 6x6 cross-covariance matrix upper right triangle (states: x, y, z, roll, pitch, yaw; first six entries are the first ROW, next five entries are the second ROW, etc.). If unknown, assign NaN value to first element in the array.", Extension: true},
+			{Name: "reset_counter", Type: "uint8_t", Description: "Estimate reset counter. This should be incremented when the estimate resets in any of the dimensions (position, velocity, attitude, angular speed). This is designed to be used when e.g an external SLAM system detects a loop-closure and the estimate jumps.", Extension: true},
+		},
+	},
+	103: {
+		Name:        "VISION_SPEED_ESTIMATE",
+		Description: "Speed estimate from a vision source.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "usec", Type: "uint64_t", Description: "Timestamp (UNIX time or time since system boot)"},
+			{Name: "x", Type: "float", Description: "Global X speed"},
+			{Name: "y", Type: "float", Description: "Global Y speed"},
+			{Name: "z", Type: "float", Description: "Global Z speed"},
+			{Name: "covariance", Type: "float", ArrayLength: 9, Description: "Row-major representation of 3x3 linear velocity covariance matrix (states: vx, vy, vz; 1st three entries - 1st row, etc.). If unknown, assign NaN value to first element in the array.", Extension: true},
+			{Name: "reset_counter", Type: "uint8_t", Description: "Estimate reset counter. This should be incremented when the estimate resets in any of the dimensions (position, velocity, attitude, angular speed). This is designed to be used when e.g an external SLAM system detects a loop-closure and the estimate jumps.", Extension: true},
+		},
+	},
+	104: {
+		Name:        "VICON_POSITION_ESTIMATE",
+		Description: "Global position estimate from a Vicon motion system source.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "usec", Type: "uint64_t", Description: "Timestamp (UNIX time or time since system boot)"},
+			{Name: "x", Type: "float", Description: "Global X position"},
+			{Name: "y", Type: "float", Description: "Global Y position"},
+			{Name: "z", Type: "float", Description: "Global Z position"},
+			{Name: "roll", Type: "float", Description: "Roll angle"},
+			{Name: "pitch", Type: "float", Description: "Pitch angle"},
+			{Name: "yaw", Type: "float", Description: "Yaw angle"},
+			{Name: "covariance", Type: "float", ArrayLength: 21, Description: "Row-major representation of 6x6 pose cross-covariance matrix upper right triangle (states: x, y, z, roll, pitch, yaw; first six entries are the first ROW, next five entries are the second ROW, etc.). If unknown, assign NaN value to first element in the array.", Extension: true},
+		},
+	},
+	105: {
+		Name:        "HIGHRES_IMU",
+		Description: "The IMU readings in SI units in NED body frame",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "xacc", Type: "float", Description: "X acceleration"},
+			{Name: "yacc", Type: "float", Description: "Y acceleration"},
+			{Name: "zacc", Type: "float", Description: "Z acceleration"},
+			{Name: "xgyro", Type: "float", Description: "Angular speed around X axis"},
+			{Name: "ygyro", Type: "float", Description: "Angular speed around Y axis"},
+			{Name: "zgyro", Type: "float", Description: "Angular speed around Z axis"},
+			{Name: "xmag", Type: "float", Description: "X Magnetic field"},
+			{Name: "ymag", Type: "float", Description: "Y Magnetic field"},
+			{Name: "zmag", Type: "float", Description: "Z Magnetic field"},
+			{Name: "abs_pressure", Type: "float", Description: "Absolute pressure"},
+			{Name: "diff_pressure", Type: "float", Description: "Differential pressure"},
+			{Name: "pressure_alt", Type: "float", Description: "Altitude calculated from pressure"},
+			{Name: "temperature", Type: "float", Description: "Temperature"},
+			{Name: "fields_updated", Type: "uint16_t", Description: "Bitmap for fields that have updated since last message, bit 0 = xacc, bit 12: temperature"},
+			{Name: "id", Type: "uint8_t", Description: "Id. Ids are numbered from 0 and map to IMUs numbered from 1 (e.g. IMU1 will have a message with id=0)", Extension: true},
+		},
+	},
+	106: {
+		Name:        "OPTICAL_FLOW_RAD",
+		Description: "Optical flow from an angular rate flow sensor (e.g. PX4FLOW or mouse sensor)",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "sensor_id", Type: "uint8_t", Description: "Sensor ID"},
+			{Name: "integration_time_us", Type: "uint32_t", Description: "Integration time. Divide integrated_x and integrated_y by the integration time to obtain average flow. The integration time also indicates the."},
+			{Name: "integrated_x", Type: "float", Description: "Flow around X axis (Sensor RH rotation about the X axis induces a positive flow. Sensor linear motion along the positive Y axis induces a negative flow.)"},
+			{Name: "integrated_y", Type: "float", Description: "Flow around Y axis (Sensor RH rotation about the Y axis induces a positive flow. Sensor linear motion along the positive X axis induces a positive flow.)"},
+			{Name: "integrated_xgyro", Type: "float", Description: "RH rotation around X axis"},
+			{Name: "integrated_ygyro", Type: "float", Description: "RH rotation around Y axis"},
+			{Name: "integrated_zgyro", Type: "float", Description: "RH rotation around Z axis"},
+			{Name: "temperature", Type: "int16_t", Description: "Temperature"},
+			{Name: "quality", Type: "uint8_t", Description: "Optical flow quality / confidence. 0: no valid flow, 255: maximum quality"},
+			{Name: "time_delta_distance_us", Type: "uint32_t", Description: "Time since the distance was sampled."},
+			{Name: "distance", Type: "float", Description: "Distance to the center of the flow field. Positive value (including zero): distance known. Negative value: Unknown distance."},
+		},
+	},
+	107: {
+		Name:        "HIL_SENSOR",
+		Description: "The IMU readings in SI units in NED body frame",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "xacc", Type: "float", Description: "X acceleration"},
+			{Name: "yacc", Type: "float", Description: "Y acceleration"},
+			{Name: "zacc", Type: "float", Description: "Z acceleration"},
+			{Name: "xgyro", Type: "float", Description: "Angular speed around X axis in body frame"},
+			{Name: "ygyro", Type: "float", Description: "Angular speed around Y axis in body frame"},
+			{Name: "zgyro", Type: "float", Description: "Angular speed around Z axis in body frame"},
+			{Name: "xmag", Type: "float", Description: "X Magnetic field"},
+			{Name: "ymag", Type: "float", Description: "Y Magnetic field"},
+			{Name: "zmag", Type: "float", Description: "Z Magnetic field"},
+			{Name: "abs_pressure", Type: "float", Description: "Absolute pressure"},
+			{Name: "diff_pressure", Type: "float", Description: "Differential pressure (airspeed)"},
+			{Name: "pressure_alt", Type: "float", Description: "Altitude calculated from pressure"},
+			{Name: "temperature", Type: "float", Description: "Temperature"},
+			{Name: "fields_updated", Type: "uint32_t", Description: "Bitmap for fields that have updated since last message, bit 0 = xacc, bit 12: temperature, bit 31: full reset of attitude/position/velocities/etc was performed in sim."},
+			{Name: "id", Type: "uint8_t", Description: "Sensor ID (zero indexed). Used for multiple sensor inputs", Extension: true},
+		},
+	},
+	108: {
+		Name:        "SIM_STATE",
+		Description: "Status of simulation environment, if used",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "q1", Type: "float", Description: "True attitude quaternion component 1, w (1 in null-rotation)"},
+			{Name: "q2", Type: "float", Description: "True attitude quaternion component 2, x (0 in null-rotation)"},
+			{Name: "q3", Type: "float", Description: "True attitude quaternion component 3, y (0 in null-rotation)"},
+			{Name: "q4", Type: "float", Description: "True attitude quaternion component 4, z (0 in null-rotation)"},
+			{Name: "roll", Type: "float", Description: "Attitude roll expressed as Euler angles, not recommended except for human-readable outputs"},
+			{Name: "pitch", Type: "float", Description: "Attitude pitch expressed as Euler angles, not recommended except for human-readable outputs"},
+			{Name: "yaw", Type: "float", Description: "Attitude yaw expressed as Euler angles, not recommended except for human-readable outputs"},
+			{Name: "xacc", Type: "float", Description: "X acceleration"},
+			{Name: "yacc", Type: "float", Description: "Y acceleration"},
+			{Name: "zacc", Type: "float", Description: "Z acceleration"},
+			{Name: "xgyro", Type: "float", Description: "Angular speed around X axis"},
+			{Name: "ygyro", Type: "float", Description: "Angular speed around Y axis"},
+			{Name: "zgyro", Type: "float", Description: "Angular speed around Z axis"},
+			{Name: "lat", Type: "float", Description: "Latitude"},
+			{Name: "lon", Type: "float", Description: "Longitude"},
+			{Name: "alt", Type: "float", Description: "Altitude"},
+			{Name: "std_dev_horz", Type: "float", Description: "Horizontal position standard deviation"},
+			{Name: "std_dev_vert", Type: "float", Description: "Vertical position standard deviation"},
+			{Name: "vn", Type: "float", Description: "True velocity in north direction in earth-fixed NED frame"},
+			{Name: "ve", Type: "float", Description: "True velocity in east direction in earth-fixed NED frame"},
+			{Name: "vd", Type: "float", Description: "True velocity in down direction in earth-fixed NED frame"},
+		},
+	},
+	109: {
+		Name:        "RADIO_STATUS",
+		Description: "Status generated by radio and injected into MAVLink stream.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "rssi", Type: "uint8_t", Description: "Local (message sender) recieved signal strength indication in device-dependent units/scale. Values: [0-254], UINT8_MAX: invalid/unknown."},
+			{Name: "remrssi", Type: "uint8_t", Description: "Remote (message receiver) signal strength indication in device-dependent units/scale. Values: [0-254], UINT8_MAX: invalid/unknown."},
+			{Name: "txbuf", Type: "uint8_t", Description: "Remaining free transmitter buffer space."},
+			{Name: "noise", Type: "uint8_t", Description: "Local background noise level. These are device dependent RSSI values (scale as approx 2x dB on SiK radios). Values: [0-254], UINT8_MAX: invalid/unknown."},
+			{Name: "remnoise", Type: "uint8_t", Description: "Remote background noise level. These are device dependent RSSI values (scale as approx 2x dB on SiK radios). Values: [0-254], UINT8_MAX: invalid/unknown."},
+			{Name: "rxerrors", Type: "uint16_t", Description: "Count of radio packet receive errors (since boot)."},
+			{Name: "fixed", Type: "uint16_t", Description: "Count of error corrected radio packets (since boot)."},
+		},
+	},
+	110: {
+		Name:        "FILE_TRANSFER_PROTOCOL",
+		Description: "File transfer message",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_network", Type: "uint8_t", Description: "Network ID (0 for broadcast)"},
+			{Name: "target_system", Type: "uint8_t", Description: "System ID (0 for broadcast)"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID (0 for broadcast)"},
+			{Name: "payload", Type: "uint8_t", ArrayLength: 251, Description: "Variable length payload. The length is defined by the remaining message length when subtracting the header and other fields.  The entire content of this block is opaque unless you understand any the encoding message_type.  The particular encoding used can be extension specific and might not always be documented as part of the mavlink specification."},
+		},
+	},
+	111: {
+		Name:        "TIMESYNC",
+		Description: "Time synchronization message.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "tc1", Type: "int64_t", Description: "Time sync timestamp 1"},
+			{Name: "ts1", Type: "int64_t", Description: "Time sync timestamp 2"},
+		},
+	},
+	112: {
+		Name:        "CAMERA_TRIGGER",
+		Description: "Camera-IMU triggering and synchronisation message.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp for image frame (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "seq", Type: "uint32_t", Description: "Image frame sequence"},
+		},
+	},
+	113: {
+		Name:        "HIL_GPS",
+		Description: "The global position, as returned by the Global Positioning System (GPS). This is                 NOT the global position estimate of the sytem, but rather a RAW sensor value. See message GLOBAL_POSITION for the global position estimate.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "fix_type", Type: "uint8_t", Description: "0-1: no fix, 2: 2D fix, 3: 3D fix. Some applications will not use the value of this field unless it is at least two, so always correctly fill in the fix."},
+			{Name: "lat", Type: "int32_t", Description: "Latitude (WGS84)"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude (WGS84)"},
+			{Name: "alt", Type: "int32_t", Description: "Altitude (MSL). Positive for up."},
+			{Name: "eph", Type: "uint16_t", Description: "GPS HDOP horizontal dilution of position (unitless * 100). If unknown, set to: UINT16_MAX"},
+			{Name: "epv", Type: "uint16_t", Description: "GPS VDOP vertical dilution of position (unitless * 100). If unknown, set to: UINT16_MAX"},
+			{Name: "vel", Type: "uint16_t", Description: "GPS ground speed. If unknown, set to: UINT16_MAX"},
+			{Name: "vn", Type: "int16_t", Description: "GPS velocity in north direction in earth-fixed NED frame"},
+			{Name: "ve", Type: "int16_t", Description: "GPS velocity in east direction in earth-fixed NED frame"},
+			{Name: "vd", Type: "int16_t", Description: "GPS velocity in down direction in earth-fixed NED frame"},
+			{Name: "cog", Type: "uint16_t", Description: "Course over ground (NOT heading, but direction of movement), 0.0..359.99 degrees. If unknown, set to: UINT16_MAX"},
+			{Name: "satellites_visible", Type: "uint8_t", Description: "Number of satellites visible. If unknown, set to UINT8_MAX"},
+			{Name: "id", Type: "uint8_t", Description: "GPS ID (zero indexed). Used for multiple GPS inputs", Extension: true},
+			{Name: "yaw", Type: "uint16_t", Description: "Yaw of vehicle relative to Earth's North, zero means not available, use 36000 for north", Extension: true},
+		},
+	},
+	114: {
+		Name:        "HIL_OPTICAL_FLOW",
+		Description: "Simulated optical flow from a flow sensor (e.g. PX4FLOW or optical mouse sensor)",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "sensor_id", Type: "uint8_t", Description: "Sensor ID"},
+			{Name: "integration_time_us", Type: "uint32_t", Description: "Integration time. Divide integrated_x and integrated_y by the integration time to obtain average flow. The integration time also indicates the."},
+			{Name: "integrated_x", Type: "float", Description: "Flow in radians around X axis (Sensor RH rotation about the X axis induces a positive flow. Sensor linear motion along the positive Y axis induces a negative flow.)"},
+			{Name: "integrated_y", Type: "float", Description: "Flow in radians around Y axis (Sensor RH rotation about the Y axis induces a positive flow. Sensor linear motion along the positive X axis induces a positive flow.)"},
+			{Name: "integrated_xgyro", Type: "float", Description: "RH rotation around X axis"},
+			{Name: "integrated_ygyro", Type: "float", Description: "RH rotation around Y axis"},
+			{Name: "integrated_zgyro", Type: "float", Description: "RH rotation around Z axis"},
+			{Name: "temperature", Type: "int16_t", Description: "Temperature"},
+			{Name: "quality", Type: "uint8_t", Description: "Optical flow quality / confidence. 0: no valid flow, 255: maximum quality"},
+			{Name: "time_delta_distance_us", Type: "uint32_t", Description: "Time since the distance was sampled."},
+			{Name: "distance", Type: "float", Description: "Distance to the center of the flow field. Positive value (including zero): distance known. Negative value: Unknown distance."},
+		},
+	},
+	115: {
+		Name:        "HIL_STATE_QUATERNION",
+		Description: "Sent from simulation to autopilot, avoids in contrast to HIL_STATE singularities. This packet is useful for high throughput applications such as hardware in the loop simulations.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "attitude_quaternion", Type: "float", ArrayLength: 4, Description: "Vehicle attitude expressed as normalized quaternion in w, x, y, z order (with 1 0 0 0 being the null-rotation)"},
+			{Name: "rollspeed", Type: "float", Description: "Body frame roll / phi angular speed"},
+			{Name: "pitchspeed", Type: "float", Description: "Body frame pitch / theta angular speed"},
+			{Name: "yawspeed", Type: "float", Description: "Body frame yaw / psi angular speed"},
+			{Name: "lat", Type: "int32_t", Description: "Latitude"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude"},
+			{Name: "alt", Type: "int32_t", Description: "Altitude"},
+			{Name: "vx", Type: "int16_t", Description: "Ground X Speed (Latitude)"},
+			{Name: "vy", Type: "int16_t", Description: "Ground Y Speed (Longitude)"},
+			{Name: "vz", Type: "int16_t", Description: "Ground Z Speed (Altitude)"},
+			{Name: "ind_airspeed", Type: "uint16_t", Description: "Indicated airspeed"},
+			{Name: "true_airspeed", Type: "uint16_t", Description: "True airspeed"},
+			{Name: "xacc", Type: "int16_t", Description: "X acceleration"},
+			{Name: "yacc", Type: "int16_t", Description: "Y acceleration"},
+			{Name: "zacc", Type: "int16_t", Description: "Z acceleration"},
+		},
+	},
+	116: {
+		Name:        "SCALED_IMU2",
+		Description: "The RAW IMU readings for secondary 9DOF sensor setup. This message should contain the scaled values to the described units",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "xacc", Type: "int16_t", Description: "X acceleration"},
+			{Name: "yacc", Type: "int16_t", Description: "Y acceleration"},
+			{Name: "zacc", Type: "int16_t", Description: "Z acceleration"},
+			{Name: "xgyro", Type: "int16_t", Description: "Angular speed around X axis"},
+			{Name: "ygyro", Type: "int16_t", Description: "Angular speed around Y axis"},
+			{Name: "zgyro", Type: "int16_t", Description: "Angular speed around Z axis"},
+			{Name: "xmag", Type: "int16_t", Description: "X Magnetic field"},
+			{Name: "ymag", Type: "int16_t", Description: "Y Magnetic field"},
+			{Name: "zmag", Type: "int16_t", Description: "Z Magnetic field"},
+			{Name: "temperature", Type: "int16_t", Description: "Temperature, 0: IMU does not provide temperature values. If the IMU is at 0C it must send 1 (0.01C).", Extension: true},
+		},
+	},
+	117: {
+		Name:        "LOG_REQUEST_LIST",
+		Description: "Request a list of available logs. On some systems calling this may stop on-board logging until LOG_REQUEST_END is called. If there are no log files available this request shall be answered with one LOG_ENTRY message with id = 0 and num_logs = 0.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "start", Type: "uint16_t", Description: "First log id (0 for first available)"},
+			{Name: "end", Type: "uint16_t", Description: "Last log id (0xffff for last available)"},
+		},
+	},
+	118: {
+		Name:        "LOG_ENTRY",
+		Description: "Reply to LOG_REQUEST_LIST",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "id", Type: "uint16_t", Description: "Log id"},
+			{Name: "num_logs", Type: "uint16_t", Description: "Total number of logs"},
+			{Name: "last_log_num", Type: "uint16_t", Description: "High log number"},
+			{Name: "time_utc", Type: "uint32_t", Description: "UTC timestamp of log since 1970, or 0 if not available"},
+			{Name: "size", Type: "uint32_t", Description: "Size of the log (may be approximate)"},
+		},
+	},
+	119: {
+		Name:        "LOG_REQUEST_DATA",
+		Description: "Request a chunk of a log",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "id", Type: "uint16_t", Description: "Log id (from LOG_ENTRY reply)"},
+			{Name: "ofs", Type: "uint32_t", Description: "Offset into the log"},
+			{Name: "count", Type: "uint32_t", Description: "Number of bytes"},
+		},
+	},
+	120: {
+		Name:        "LOG_DATA",
+		Description: "Reply to LOG_REQUEST_DATA",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "id", Type: "uint16_t", Description: "Log id (from LOG_ENTRY reply)"},
+			{Name: "ofs", Type: "uint32_t", Description: "Offset into the log"},
+			{Name: "count", Type: "uint8_t", Description: "Number of bytes (zero for end of log)"},
+			{Name: "data", Type: "uint8_t", ArrayLength: 90, Description: "log data"},
+		},
+	},
+	121: {
+		Name:        "LOG_ERASE",
+		Description: "Erase all logs",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+		},
+	},
+	122: {
+		Name:        "LOG_REQUEST_END",
+		Description: "Stop log transfer and resume normal logging",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+		},
+	},
+	123: {
+		Name:        "GPS_INJECT_DATA",
+		Description: "Data for injecting into the onboard GPS (used for DGPS)",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "len", Type: "uint8_t", Description: "Data length"},
+			{Name: "data", Type: "uint8_t", ArrayLength: 110, Description: "Raw data (110 is enough for 12 satellites of RTCMv2)"},
+		},
+	},
+	124: {
+		Name:        "GPS2_RAW",
+		Description: "Second GPS data.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "fix_type", Type: "uint8_t", Enum: "GPS_FIX_TYPE", Description: "GPS fix type."},
+			{Name: "lat", Type: "int32_t", Description: "Latitude (WGS84)"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude (WGS84)"},
+			{Name: "alt", Type: "int32_t", Description: "Altitude (MSL). Positive for up."},
+			{Name: "eph", Type: "uint16_t", Description: "GPS HDOP horizontal dilution of position (unitless * 100). If unknown, set to: UINT16_MAX"},
+			{Name: "epv", Type: "uint16_t", Description: "GPS VDOP vertical dilution of position (unitless * 100). If unknown, set to: UINT16_MAX"},
+			{Name: "vel", Type: "uint16_t", Description: "GPS ground speed. If unknown, set to: UINT16_MAX"},
+			{Name: "cog", Type: "uint16_t", Description: "Course over ground (NOT heading, but direction of movement): 0.0..359.99 degrees. If unknown, set to: UINT16_MAX"},
+			{Name: "satellites_visible", Type: "uint8_t", Description: "Number of satellites visible. If unknown, set to UINT8_MAX"},
+			{Name: "dgps_numch", Type: "uint8_t", Description: "Number of DGPS satellites"},
+			{Name: "dgps_age", Type: "uint32_t", Description: "Age of DGPS info"},
+			{Name: "yaw", Type: "uint16_t", Description: "Yaw in earth frame from north. Use 0 if this GPS does not provide yaw. Use UINT16_MAX if this GPS is configured to provide yaw and is currently unable to provide it. Use 36000 for north.", Extension: true},
+			{Name: "alt_ellipsoid", Type: "int32_t", Description: "Altitude (above WGS84, EGM96 ellipsoid). Positive for up.", Extension: true},
+			{Name: "h_acc", Type: "uint32_t", Description: "Position uncertainty.", Extension: true},
+			{Name: "v_acc", Type: "uint32_t", Description: "Altitude uncertainty.", Extension: true},
+			{Name: "vel_acc", Type: "uint32_t", Description: "Speed uncertainty.", Extension: true},
+			{Name: "hdg_acc", Type: "uint32_t", Description: "Heading / track uncertainty", Extension: true},
+		},
+	},
+	125: {
+		Name:        "POWER_STATUS",
+		Description: "Power supply status",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "Vcc", Type: "uint16_t", Description: "5V rail voltage."},
+			{Name: "Vservo", Type: "uint16_t", Description: "Servo rail voltage."},
+			{Name: "flags", Type: "uint16_t", Enum: "MAV_POWER_STATUS", Description: "Bitmap of power supply status flags."},
+		},
+	},
+	126: {
+		Name:        "SERIAL_CONTROL",
+		Description: "Control a serial port. This can be used for raw access to an onboard serial peripheral such as a GPS or telemetry radio. It is designed to make it possible to update the devices firmware via MAVLink messages or change the devices settings. A message with zero bytes can be used to change just the baudrate.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "device", Type: "uint8_t", Enum: "SERIAL_CONTROL_DEV", Description: "Serial control device type."},
+			{Name: "flags", Type: "uint8_t", Enum: "SERIAL_CONTROL_FLAG", Description: "Bitmap of serial control flags."},
+			{Name: "timeout", Type: "uint16_t", Description: "Timeout for reply data"},
+			{Name: "baudrate", Type: "uint32_t", Description: "Baudrate of transfer. Zero means no change."},
+			{Name: "count", Type: "uint8_t", Description: "how many bytes in this transfer"},
+			{Name: "data", Type: "uint8_t", ArrayLength: 70, Description: "serial data"},
+		},
+	},
+	127: {
+		Name:        "GPS_RTK",
+		Description: "RTK GPS data. Gives information on the relative baseline calculation the GPS is reporting",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_last_baseline_ms", Type: "uint32_t", Description: "Time since boot of last baseline message received."},
+			{Name: "rtk_receiver_id", Type: "uint8_t", Description: "Identification of connected RTK receiver."},
+			{Name: "wn", Type: "uint16_t", Description: "GPS Week Number of last baseline"},
+			{Name: "tow", Type: "uint32_t", Description: "GPS Time of Week of last baseline"},
+			{Name: "rtk_health", Type: "uint8_t", Description: "GPS-specific health report for RTK data."},
+			{Name: "rtk_rate", Type: "uint8_t", Description: "Rate of baseline messages being received by GPS"},
+			{Name: "nsats", Type: "uint8_t", Description: "Current number of sats used for RTK calculation."},
+			{Name: "baseline_coords_type", Type: "uint8_t", Enum: "RTK_BASELINE_COORDINATE_SYSTEM", Description: "Coordinate system of baseline"},
+			{Name: "baseline_a_mm", Type: "int32_t", Description: "Current baseline in ECEF x or NED north component."},
+			{Name: "baseline_b_mm", Type: "int32_t", Description: "Current baseline in ECEF y or NED east component."},
+			{Name: "baseline_c_mm", Type: "int32_t", Description: "Current baseline in ECEF z or NED down component."},
+			{Name: "accuracy", Type: "uint32_t", Description: "Current estimate of baseline accuracy."},
+			{Name: "iar_num_hypotheses", Type: "int32_t", Description: "Current number of integer ambiguity hypotheses."},
+		},
+	},
+	128: {
+		Name:        "GPS2_RTK",
+		Description: "RTK GPS data. Gives information on the relative baseline calculation the GPS is reporting",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_last_baseline_ms", Type: "uint32_t", Description: "Time since boot of last baseline message received."},
+			{Name: "rtk_receiver_id", Type: "uint8_t", Description: "Identification of connected RTK receiver."},
+			{Name: "wn", Type: "uint16_t", Description: "GPS Week Number of last baseline"},
+			{Name: "tow", Type: "uint32_t", Description: "GPS Time of Week of last baseline"},
+			{Name: "rtk_health", Type: "uint8_t", Description: "GPS-specific health report for RTK data."},
+			{Name: "rtk_rate", Type: "uint8_t", Description: "Rate of baseline messages being received by GPS"},
+			{Name: "nsats", Type: "uint8_t", Description: "Current number of sats used for RTK calculation."},
+			{Name: "baseline_coords_type", Type: "uint8_t", Enum: "RTK_BASELINE_COORDINATE_SYSTEM", Description: "Coordinate system of baseline"},
+			{Name: "baseline_a_mm", Type: "int32_t", Description: "Current baseline in ECEF x or NED north component."},
+			{Name: "baseline_b_mm", Type: "int32_t", Description: "Current baseline in ECEF y or NED east component."},
+			{Name: "baseline_c_mm", Type: "int32_t", Description: "Current baseline in ECEF z or NED down component."},
+			{Name: "accuracy", Type: "uint32_t", Description: "Current estimate of baseline accuracy."},
+			{Name: "iar_num_hypotheses", Type: "int32_t", Description: "Current number of integer ambiguity hypotheses."},
+		},
+	},
+	129: {
+		Name:        "SCALED_IMU3",
+		Description: "The RAW IMU readings for 3rd 9DOF sensor setup. This message should contain the scaled values to the described units",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "xacc", Type: "int16_t", Description: "X acceleration"},
+			{Name: "yacc", Type: "int16_t", Description: "Y acceleration"},
+			{Name: "zacc", Type: "int16_t", Description: "Z acceleration"},
+			{Name: "xgyro", Type: "int16_t", Description: "Angular speed around X axis"},
+			{Name: "ygyro", Type: "int16_t", Description: "Angular speed around Y axis"},
+			{Name: "zgyro", Type: "int16_t", Description: "Angular speed around Z axis"},
+			{Name: "xmag", Type: "int16_t", Description: "X Magnetic field"},
+			{Name: "ymag", Type: "int16_t", Description: "Y Magnetic field"},
+			{Name: "zmag", Type: "int16_t", Description: "Z Magnetic field"},
+			{Name: "temperature", Type: "int16_t", Description: "Temperature, 0: IMU does not provide temperature values. If the IMU is at 0C it must send 1 (0.01C).", Extension: true},
+		},
+	},
+	130: {
+		Name:        "DATA_TRANSMISSION_HANDSHAKE",
+		Description: "Handshake message to initiate, control and stop image streaming when using the Image Transmission Protocol: https://mavlink.io/en/services/image_transmission.html.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "type", Type: "uint8_t", Enum: "MAVLINK_DATA_STREAM_TYPE", Description: "Type of requested/acknowledged data."},
+			{Name: "size", Type: "uint32_t", Description: "total data size (set on ACK only)."},
+			{Name: "width", Type: "uint16_t", Description: "Width of a matrix or image."},
+			{Name: "height", Type: "uint16_t", Description: "Height of a matrix or image."},
+			{Name: "packets", Type: "uint16_t", Description: "Number of packets being sent (set on ACK only)."},
+			{Name: "payload", Type: "uint8_t", Description: "Payload size per packet (normally 253 byte, see DATA field size in message ENCAPSULATED_DATA) (set on ACK only)."},
+			{Name: "jpg_quality", Type: "uint8_t", Description: "JPEG quality. Values: [1-100]."},
+		},
+	},
+	131: {
+		Name:        "ENCAPSULATED_DATA",
+		Description: "Data packet for images sent using the Image Transmission Protocol: https://mavlink.io/en/services/image_transmission.html.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "seqnr", Type: "uint16_t", Description: "sequence number (starting with 0 on every transmission)"},
+			{Name: "data", Type: "uint8_t", ArrayLength: 253, Description: "image data bytes"},
+		},
+	},
+	132: {
+		Name:        "DISTANCE_SENSOR",
+		Description: "Distance sensor information for an onboard rangefinder.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "min_distance", Type: "uint16_t", Description: "Minimum distance the sensor can measure"},
+			{Name: "max_distance", Type: "uint16_t", Description: "Maximum distance the sensor can measure"},
+			{Name: "current_distance", Type: "uint16_t", Description: "Current distance reading"},
+			{Name: "type", Type: "uint8_t", Enum: "MAV_DISTANCE_SENSOR", Description: "Type of distance sensor."},
+			{Name: "id", Type: "uint8_t", Description: "Onboard ID of the sensor"},
+			{Name: "orientation", Type: "uint8_t", Enum: "MAV_SENSOR_ORIENTATION", Description: "Direction the sensor faces. downward-facing: ROTATION_PITCH_270, upward-facing: ROTATION_PITCH_90, backward-facing: ROTATION_PITCH_180, forward-facing: ROTATION_NONE, left-facing: ROTATION_YAW_90, right-facing: ROTATION_YAW_270"},
+			{Name: "covariance", Type: "uint8_t", Description: "Measurement variance. Max standard deviation is 6cm. UINT8_MAX if unknown."},
+			{Name: "horizontal_fov", Type: "float", Description: "Horizontal Field of View (angle) where the distance measurement is valid and the field of view is known. Otherwise this is set to 0.", Extension: true},
+			{Name: "vertical_fov", Type: "float", Description: "Vertical Field of View (angle) where the distance measurement is valid and the field of view is known. Otherwise this is set to 0.", Extension: true},
+			{Name: "quaternion", Type: "float", ArrayLength: 4, Description: "Quaternion of the sensor orientation in vehicle body frame (w, x, y, z order, zero-rotation is 1, 0, 0, 0). Zero-rotation is along the vehicle body x-axis. This field is required if the orientation is set to MAV_SENSOR_ROTATION_CUSTOM. Set it to 0 if invalid.\"", Extension: true},
+			{Name: "signal_quality", Type: "uint8_t", Description: "Signal quality of the sensor. Specific to each sensor type, representing the relation of the signal strength with the target reflectivity, distance, size or aspect, but normalised as a percentage. 0 = unknown/unset signal quality, 1 = invalid signal, 100 = perfect signal.", Extension: true},
+		},
+	},
+	133: {
+		Name:        "TERRAIN_REQUEST",
+		Description: "Request for terrain data and terrain status. See terrain protocol docs: https://mavlink.io/en/services/terrain.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "lat", Type: "int32_t", Description: "Latitude of SW corner of first grid"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude of SW corner of first grid"},
+			{Name: "grid_spacing", Type: "uint16_t", Description: "Grid spacing"},
+			{Name: "mask", Type: "uint64_t", Description: "Bitmask of requested 4x4 grids (row major 8x7 array of grids, 56 bits)"},
+		},
+	},
+	134: {
+		Name:        "TERRAIN_DATA",
+		Description: "Terrain data sent from GCS. The lat/lon and grid_spacing must be the same as a lat/lon from a TERRAIN_REQUEST. See terrain protocol docs: https://mavlink.io/en/services/terrain.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "lat", Type: "int32_t", Description: "Latitude of SW corner of first grid"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude of SW corner of first grid"},
+			{Name: "grid_spacing", Type: "uint16_t", Description: "Grid spacing"},
+			{Name: "gridbit", Type: "uint8_t", Description: "bit within the terrain request mask"},
+			{Name: "data", Type: "int16_t", ArrayLength: 16, Description: "Terrain data MSL"},
+		},
+	},
+	135: {
+		Name:        "TERRAIN_CHECK",
+		Description: "Request that the vehicle report terrain height at the given location (expected response is a TERRAIN_REPORT). Used by GCS to check if vehicle has all terrain data needed for a mission.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "lat", Type: "int32_t", Description: "Latitude"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude"},
+		},
+	},
+	136: {
+		Name:        "TERRAIN_REPORT",
+		Description: "Streamed from drone to report progress of terrain map download (initiated by TERRAIN_REQUEST), or sent as a response to a TERRAIN_CHECK request. See terrain protocol docs: https://mavlink.io/en/services/terrain.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "lat", Type: "int32_t", Description: "Latitude"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude"},
+			{Name: "spacing", Type: "uint16_t", Description: "grid spacing (zero if terrain at this location unavailable)"},
+			{Name: "terrain_height", Type: "float", Description: "Terrain height MSL"},
+			{Name: "current_height", Type: "float", Description: "Current vehicle height above lat/lon terrain height"},
+			{Name: "pending", Type: "uint16_t", Description: "Number of 4x4 terrain blocks waiting to be received or read from disk"},
+			{Name: "loaded", Type: "uint16_t", Description: "Number of 4x4 terrain blocks in memory"},
+		},
+	},
+	137: {
+		Name:        "SCALED_PRESSURE2",
+		Description: "Barometer readings for 2nd barometer",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "press_abs", Type: "float", Description: "Absolute pressure"},
+			{Name: "press_diff", Type: "float", Description: "Differential pressure"},
+			{Name: "temperature", Type: "int16_t", Description: "Absolute pressure temperature"},
+			{Name: "temperature_press_diff", Type: "int16_t", Description: "Differential pressure temperature (0, if not available). Report values of 0 (or 1) as 1 cdegC.", Extension: true},
+		},
+	},
+	138: {
+		Name:        "ATT_POS_MOCAP",
+		Description: "Motion capture attitude and position",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "Attitude quaternion (w, x, y, z order, zero-rotation is 1, 0, 0, 0)"},
+			{Name: "x", Type: "float", Description: "X position (NED)"},
+			{Name: "y", Type: "float", Description: "Y position (NED)"},
+			{Name: "z", Type: "float", Description: "Z position (NED)"},
+			{Name: "covariance", Type: "float", ArrayLength: 21, Description: "Row-major representation of a pose 6x6 cross-covariance matrix upper right triangle (states: x, y, z, roll, pitch, yaw; first six entries are the first ROW, next five entries are the second ROW, etc.). If unknown, assign NaN value to first element in the array.", Extension: true},
+		},
+	},
+	139: {
+		Name:        "SET_ACTUATOR_CONTROL_TARGET",
+		Description: "Set the vehicle attitude and body angular rates.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "group_mlx", Type: "uint8_t", Description: "Actuator group. The \"_mlx\" indicates this is a multi-instance message and a MAVLink parser should use this field to difference between instances."},
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "controls", Type: "float", ArrayLength: 8, Description: "Actuator controls. Normed to -1..+1 where 0 is neutral position. Throttle for single rotation direction motors is 0..1, negative range for reverse direction. Standard mapping for attitude controls (group 0): (index 0-7): roll, pitch, yaw, throttle, flaps, spoilers, airbrakes, landing gear. Load a pass-through mixer to repurpose them as generic outputs."},
+		},
+	},
+	140: {
+		Name:        "ACTUATOR_CONTROL_TARGET",
+		Description: "Set the vehicle attitude and body angular rates.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "group_mlx", Type: "uint8_t", Description: "Actuator group. The \"_mlx\" indicates this is a multi-instance message and a MAVLink parser should use this field to difference between instances."},
+			{Name: "controls", Type: "float", ArrayLength: 8, Description: "Actuator controls. Normed to -1..+1 where 0 is neutral position. Throttle for single rotation direction motors is 0..1, negative range for reverse direction. Standard mapping for attitude controls (group 0): (index 0-7): roll, pitch, yaw, throttle, flaps, spoilers, airbrakes, landing gear. Load a pass-through mixer to repurpose them as generic outputs."},
+		},
+	},
+	141: {
+		Name:        "ALTITUDE",
+		Description: "The current system altitude.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "altitude_monotonic", Type: "float", Description: "This altitude measure is initialized on system boot and monotonic (it is never reset, but represents the local altitude change). The only guarantee on this field is that it will never be reset and is consistent within a flight. The recommended value for this field is the uncorrected barometric altitude at boot time. This altitude will also drift and vary between flights."},
+			{Name: "altitude_amsl", Type: "float", Description: "This altitude measure is strictly above mean sea level and might be non-monotonic (it might reset on events like GPS lock or when a new QNH value is set). It should be the altitude to which global altitude waypoints are compared to. Note that it is *not* the GPS altitude, however, most GPS modules already output MSL by default and not the WGS84 altitude."},
+			{Name: "altitude_local", Type: "float", Description: "This is the local altitude in the local coordinate frame. It is not the altitude above home, but in reference to the coordinate origin (0, 0, 0). It is up-positive."},
+			{Name: "altitude_relative", Type: "float", Description: "This is the altitude above the home position. It resets on each change of the current home position."},
+			{Name: "altitude_terrain", Type: "float", Description: "This is the altitude above terrain. It might be fed by a terrain database or an altimeter. Values smaller than -1000 should be interpreted as unknown."},
+			{Name: "bottom_clearance", Type: "float", Description: "This is not the altitude, but the clear space below the system according to the fused clearance estimate. It generally should max out at the maximum range of e.g. the laser altimeter. It is generally a moving target. A negative value indicates no measurement available."},
+		},
+	},
+	142: {
+		Name:        "RESOURCE_REQUEST",
+		Description: "The autopilot is requesting a resource (file, binary, other type of data)",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "request_id", Type: "uint8_t", Description: "Request ID. This ID should be re-used when sending back URI contents"},
+			{Name: "uri_type", Type: "uint8_t", Description: "The type of requested URI. 0 = a file via URL. 1 = a UAVCAN binary"},
+			{Name: "uri", Type: "uint8_t", ArrayLength: 120, Description: "The requested unique resource identifier (URI). It is not necessarily a straight domain name (depends on the URI type enum)"},
+			{Name: "transfer_type", Type: "uint8_t", Description: "The way the autopilot wants to receive the URI. 0 = MAVLink FTP. 1 = binary stream."},
+			{Name: "storage", Type: "uint8_t", ArrayLength: 120, Description: "The storage path the autopilot wants the URI to be stored in. Will only be valid if the transfer_type has a storage associated (e.g. MAVLink FTP)."},
+		},
+	},
+	143: {
+		Name:        "SCALED_PRESSURE3",
+		Description: "Barometer readings for 3rd barometer",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "press_abs", Type: "float", Description: "Absolute pressure"},
+			{Name: "press_diff", Type: "float", Description: "Differential pressure"},
+			{Name: "temperature", Type: "int16_t", Description: "Absolute pressure temperature"},
+			{Name: "temperature_press_diff", Type: "int16_t", Description: "Differential pressure temperature (0, if not available). Report values of 0 (or 1) as 1 cdegC.", Extension: true},
+		},
+	},
+	144: {
+		Name:        "FOLLOW_TARGET",
+		Description: "Current motion information from a designated system",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "timestamp", Type: "uint64_t", Description: "Timestamp (time since system boot)."},
+			{Name: "est_capabilities", Type: "uint8_t", Description: "bit positions for tracker reporting capabilities (POS = 0, VEL = 1, ACCEL = 2, ATT + RATES = 3)"},
+			{Name: "lat", Type: "int32_t", Description: "Latitude (WGS84)"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude (WGS84)"},
+			{Name: "alt", Type: "float", Description: "Altitude (MSL)"},
+			{Name: "vel", Type: "float", ArrayLength: 3, Description: "target velocity (0,0,0) for unknown"},
+			{Name: "acc", Type: "float", ArrayLength: 3, Description: "linear target acceleration (0,0,0) for unknown"},
+			{Name: "attitude_q", Type: "float", ArrayLength: 4, Description: "(0 0 0 0 for unknown)"},
+			{Name: "rates", Type: "float", ArrayLength: 3, Description: "(0 0 0 for unknown)"},
+			{Name: "position_cov", Type: "float", ArrayLength: 3, Description: "eph epv"},
+			{Name: "custom_state", Type: "uint64_t", Description: "button states or switches of a tracker device"},
+		},
+	},
+	146: {
+		Name:        "CONTROL_SYSTEM_STATE",
+		Description: "The smoothed, monotonic system state used to feed the control loops of the system.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "x_acc", Type: "float", Description: "X acceleration in body frame"},
+			{Name: "y_acc", Type: "float", Description: "Y acceleration in body frame"},
+			{Name: "z_acc", Type: "float", Description: "Z acceleration in body frame"},
+			{Name: "x_vel", Type: "float", Description: "X velocity in body frame"},
+			{Name: "y_vel", Type: "float", Description: "Y velocity in body frame"},
+			{Name: "z_vel", Type: "float", Description: "Z velocity in body frame"},
+			{Name: "x_pos", Type: "float", Description: "X position in local frame"},
+			{Name: "y_pos", Type: "float", Description: "Y position in local frame"},
+			{Name: "z_pos", Type: "float", Description: "Z position in local frame"},
+			{Name: "airspeed", Type: "float", Description: "Airspeed, set to -1 if unknown"},
+			{Name: "vel_variance", Type: "float", ArrayLength: 3, Description: "Variance of body velocity estimate"},
+			{Name: "pos_variance", Type: "float", ArrayLength: 3, Description: "Variance in local position"},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "The attitude, represented as Quaternion"},
+			{Name: "roll_rate", Type: "float", Description: "Angular rate in roll axis"},
+			{Name: "pitch_rate", Type: "float", Description: "Angular rate in pitch axis"},
+			{Name: "yaw_rate", Type: "float", Description: "Angular rate in yaw axis"},
+		},
+	},
+	147: {
+		Name:        "BATTERY_STATUS",
+		Description: "Battery information. Updates GCS with flight controller battery status. Smart batteries also use this message, but may additionally send SMART_BATTERY_INFO.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "id", Type: "uint8_t", Description: "Battery ID"},
+			{Name: "battery_function", Type: "uint8_t", Enum: "MAV_BATTERY_FUNCTION", Description: "Function of the battery"},
+			{Name: "type", Type: "uint8_t", Enum: "MAV_BATTERY_TYPE", Description: "Type (chemistry) of the battery"},
+			{Name: "temperature", Type: "int16_t", Description: "Temperature of the battery. INT16_MAX for unknown temperature."},
+			{Name: "voltages", Type: "uint16_t", ArrayLength: 10, Description: "Battery voltage of cells 1 to 10 (see voltages_ext for cells 11-14). Cells in this field above the valid cell count for this battery should have the UINT16_MAX value. If individual cell voltages are unknown or not measured for this battery, then the overall battery voltage should be filled in cell 0, with all others set to UINT16_MAX. If the voltage of the battery is greater than (UINT16_MAX - 1), then cell 0 should be set to (UINT16_MAX - 1), and cell 1 to the remaining voltage. This can be extended to multiple cells if the total voltage is greater than 2 * (UINT16_MAX - 1)."},
+			{Name: "current_battery", Type: "int16_t", Description: "Battery current, -1: autopilot does not measure the current"},
+			{Name: "current_consumed", Type: "int32_t", Description: "Consumed charge, -1: autopilot does not provide consumption estimate"},
+			{Name: "energy_consumed", Type: "int32_t", Description: "Consumed energy, -1: autopilot does not provide energy consumption estimate"},
+			{Name: "battery_remaining", Type: "int8_t", Description: "Remaining battery energy. Values: [0-100], -1: autopilot does not estimate the remaining battery."},
+			{Name: "time_remaining", Type: "int32_t", Description: "Remaining battery time, 0: autopilot does not provide remaining battery time estimate", Extension: true},
+			{Name: "charge_state", Type: "uint8_t", Enum: "MAV_BATTERY_CHARGE_STATE", Description: "State for extent of discharge, provided by autopilot for warning or external reactions", Extension: true},
+			{Name: "voltages_ext", Type: "uint16_t", ArrayLength: 4, Description: "Battery voltages for cells 11 to 14. Cells above the valid cell count for this battery should have a value of 0, where zero indicates not supported (note, this is different than for the voltages field and allows empty byte truncation). If the measured value is 0 then 1 should be sent instead.", Extension: true},
+			{Name: "mode", Type: "uint8_t", Enum: "MAV_BATTERY_MODE", Description: "Battery mode. Default (0) is that battery mode reporting is not supported or battery is in normal-use mode.", Extension: true},
+			{Name: "fault_bitmask", Type: "uint32_t", Enum: "MAV_BATTERY_FAULT", Description: "Fault/health indications. These should be set when charge_state is MAV_BATTERY_CHARGE_STATE_FAILED or MAV_BATTERY_CHARGE_STATE_UNHEALTHY (if not, fault reporting is not supported).", Extension: true},
+		},
+	},
+	148: {
+		Name:        "AUTOPILOT_VERSION",
+		Description: "Version and capability of autopilot software. This should be emitted in response to a request with MAV_CMD_REQUEST_MESSAGE.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "capabilities", Type: "uint64_t", Enum: "MAV_PROTOCOL_CAPABILITY", Description: "Bitmap of capabilities"},
+			{Name: "flight_sw_version", Type: "uint32_t", Description: "Firmware version number"},
+			{Name: "middleware_sw_version", Type: "uint32_t", Description: "Middleware version number"},
+			{Name: "os_sw_version", Type: "uint32_t", Description: "Operating system version number"},
+			{Name: "board_version", Type: "uint32_t", Description: "HW / board version (last 8 bytes should be silicon ID, if any)"},
+			{Name: "flight_custom_version", Type: "uint8_t", ArrayLength: 8, Description: "Custom version field, commonly the first 8 bytes of the git hash. This is not an unique identifier, but should allow to identify the commit using the main version number even for very large code bases."},
+			{Name: "middleware_custom_version", Type: "uint8_t", ArrayLength: 8, Description: "Custom version field, commonly the first 8 bytes of the git hash. This is not an unique identifier, but should allow to identify the commit using the main version number even for very large code bases."},
+			{Name: "os_custom_version", Type: "uint8_t", ArrayLength: 8, Description: "Custom version field, commonly the first 8 bytes of the git hash. This is not an unique identifier, but should allow to identify the commit using the main version number even for very large code bases."},
+			{Name: "vendor_id", Type: "uint16_t", Description: "ID of the board vendor"},
+			{Name: "product_id", Type: "uint16_t", Description: "ID of the product"},
+			{Name: "uid", Type: "uint64_t", Description: "UID if provided by hardware (see uid2)"},
+			{Name: "uid2", Type: "uint8_t", ArrayLength: 18, Description: "UID if provided by hardware (supersedes the uid field. If this is non-zero, use this field, otherwise use uid)", Extension: true},
+		},
+	},
+	149: {
+		Name:        "LANDING_TARGET",
+		Description: "The location of a landing target. See: https://mavlink.io/en/services/landing_target.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "target_num", Type: "uint8_t", Description: "The ID of the target if multiple targets are present"},
+			{Name: "frame", Type: "uint8_t", Enum: "MAV_FRAME", Description: "Coordinate frame used for following fields."},
+			{Name: "angle_x", Type: "float", Description: "X-axis angular offset of the target from the center of the image"},
+			{Name: "angle_y", Type: "float", Description: "Y-axis angular offset of the target from the center of the image"},
+			{Name: "distance", Type: "float", Description: "Distance to the target from the vehicle"},
+			{Name: "size_x", Type: "float", Description: "Size of target along x-axis"},
+			{Name: "size_y", Type: "float", Description: "Size of target along y-axis"},
+			{Name: "x", Type: "float", Description: "X Position of the landing target in MAV_FRAME", Extension: true},
+			{Name: "y", Type: "float", Description: "Y Position of the landing target in MAV_FRAME", Extension: true},
+			{Name: "z", Type: "float", Description: "Z Position of the landing target in MAV_FRAME", Extension: true},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "Quaternion of landing target orientation (w, x, y, z order, zero-rotation is 1, 0, 0, 0)", Extension: true},
+			{Name: "type", Type: "uint8_t", Enum: "LANDING_TARGET_TYPE", Description: "Type of landing target", Extension: true},
+			{Name: "position_valid", Type: "uint8_t", Description: "Boolean indicating whether the position fields (x, y, z, q, type) contain valid target position information (valid: 1, invalid: 0). Default is 0 (invalid).", Extension: true},
+		},
+	},
+	162: {
+		Name:        "FENCE_STATUS",
+		Description: "Status of geo-fencing. Sent in extended status stream when fencing enabled.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "breach_status", Type: "uint8_t", Description: "Breach status (0 if currently inside fence, 1 if outside)."},
+			{Name: "breach_count", Type: "uint16_t", Description: "Number of fence breaches."},
+			{Name: "breach_type", Type: "uint8_t", Enum: "FENCE_BREACH", Description: "Last breach type."},
+			{Name: "breach_time", Type: "uint32_t", Description: "Time (since boot) of last breach."},
+			{Name: "breach_mitigation", Type: "uint8_t", Enum: "FENCE_MITIGATE", Description: "Active action to prevent fence breach", Extension: true},
+		},
+	},
+	192: {
+		Name:        "MAG_CAL_REPORT",
+		Description: "Reports results of completed compass calibration. Sent until MAG_CAL_ACK received.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "compass_id", Type: "uint8_t", Description: "Compass being calibrated."},
+			{Name: "cal_mask", Type: "uint8_t", Description: "Bitmask of compasses being calibrated."},
+			{Name: "cal_status", Type: "uint8_t", Enum: "MAG_CAL_STATUS", Description: "Calibration Status."},
+			{Name: "autosaved", Type: "uint8_t", Description: "0=requires a MAV_CMD_DO_ACCEPT_MAG_CAL, 1=saved to parameters."},
+			{Name: "fitness", Type: "float", Description: "RMS milligauss residuals."},
+			{Name: "ofs_x", Type: "float", Description: "X offset."},
+			{Name: "ofs_y", Type: "float", Description: "Y offset."},
+			{Name: "ofs_z", Type: "float", Description: "Z offset."},
+			{Name: "diag_x", Type: "float", Description: "X diagonal (matrix 11)."},
+			{Name: "diag_y", Type: "float", Description: "Y diagonal (matrix 22)."},
+			{Name: "diag_z", Type: "float", Description: "Z diagonal (matrix 33)."},
+			{Name: "offdiag_x", Type: "float", Description: "X off-diagonal (matrix 12 and 21)."},
+			{Name: "offdiag_y", Type: "float", Description: "Y off-diagonal (matrix 13 and 31)."},
+			{Name: "offdiag_z", Type: "float", Description: "Z off-diagonal (matrix 32 and 23)."},
+			{Name: "orientation_confidence", Type: "float", Description: "Confidence in orientation (higher is better).", Extension: true},
+			{Name: "old_orientation", Type: "uint8_t", Enum: "MAV_SENSOR_ORIENTATION", Description: "orientation before calibration.", Extension: true},
+			{Name: "new_orientation", Type: "uint8_t", Enum: "MAV_SENSOR_ORIENTATION", Description: "orientation after calibration.", Extension: true},
+			{Name: "scale_factor", Type: "float", Description: "field radius correction factor", Extension: true},
+		},
+	},
+	225: {
+		Name:        "EFI_STATUS",
+		Description: "EFI status output",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "health", Type: "uint8_t", Description: "EFI health status"},
+			{Name: "ecu_index", Type: "float", Description: "ECU index"},
+			{Name: "rpm", Type: "float", Description: "RPM"},
+			{Name: "fuel_consumed", Type: "float", Description: "Fuel consumed"},
+			{Name: "fuel_flow", Type: "float", Description: "Fuel flow rate"},
+			{Name: "engine_load", Type: "float", Description: "Engine load"},
+			{Name: "throttle_position", Type: "float", Description: "Throttle position"},
+			{Name: "spark_dwell_time", Type: "float", Description: "Spark dwell time"},
+			{Name: "barometric_pressure", Type: "float", Description: "Barometric pressure"},
+			{Name: "intake_manifold_pressure", Type: "float", Description: "Intake manifold pressure("},
+			{Name: "intake_manifold_temperature", Type: "float", Description: "Intake manifold temperature"},
+			{Name: "cylinder_head_temperature", Type: "float", Description: "Cylinder head temperature"},
+			{Name: "ignition_timing", Type: "float", Description: "Ignition timing (Crank angle degrees)"},
+			{Name: "injection_time", Type: "float", Description: "Injection time"},
+			{Name: "exhaust_gas_temperature", Type: "float", Description: "Exhaust gas temperature"},
+			{Name: "throttle_out", Type: "float", Description: "Output throttle"},
+			{Name: "pt_compensation", Type: "float", Description: "Pressure/temperature compensation"},
+		},
+	},
+	230: {
+		Name:        "ESTIMATOR_STATUS",
+		Description: "Estimator status message including flags, innovation test ratios and estimated accuracies. The flags message is an integer bitmask containing information on which EKF outputs are valid. See the ESTIMATOR_STATUS_FLAGS enum definition for further information. The innovation test ratios show the magnitude of the sensor innovation divided by the innovation check threshold. Under normal operation the innovation test ratios should be below 0.5 with occasional values up to 1.0. Values greater than 1.0 should be rare under normal operation and indicate that a measurement has been rejected by the filter. The user should be notified if an innovation test ratio greater than 1.0 is recorded. Notifications for values in the range between 0.5 and 1.0 should be optional and controllable by the user.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "flags", Type: "uint16_t", Enum: "ESTIMATOR_STATUS_FLAGS", Description: "Bitmap indicating which EKF outputs are valid."},
+			{Name: "vel_ratio", Type: "float", Description: "Velocity innovation test ratio"},
+			{Name: "pos_horiz_ratio", Type: "float", Description: "Horizontal position innovation test ratio"},
+			{Name: "pos_vert_ratio", Type: "float", Description: "Vertical position innovation test ratio"},
+			{Name: "mag_ratio", Type: "float", Description: "Magnetometer innovation test ratio"},
+			{Name: "hagl_ratio", Type: "float", Description: "Height above terrain innovation test ratio"},
+			{Name: "tas_ratio", Type: "float", Description: "True airspeed innovation test ratio"},
+			{Name: "pos_horiz_accuracy", Type: "float", Description: "Horizontal position 1-STD accuracy relative to the EKF local origin"},
+			{Name: "pos_vert_accuracy", Type: "float", Description: "Vertical position 1-STD accuracy relative to the EKF local origin"},
+		},
+	},
+	231: {
+		Name:        "WIND_COV",
+		Description: "Wind covariance estimate from vehicle.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "wind_x", Type: "float", Description: "Wind in X (NED) direction"},
+			{Name: "wind_y", Type: "float", Description: "Wind in Y (NED) direction"},
+			{Name: "wind_z", Type: "float", Description: "Wind in Z (NED) direction"},
+			{Name: "var_horiz", Type: "float", Description: "Variability of the wind in XY. RMS of a 1 Hz lowpassed wind estimate."},
+			{Name: "var_vert", Type: "float", Description: "Variability of the wind in Z. RMS of a 1 Hz lowpassed wind estimate."},
+			{Name: "wind_alt", Type: "float", Description: "Altitude (MSL) that this measurement was taken at"},
+			{Name: "horiz_accuracy", Type: "float", Description: "Horizontal speed 1-STD accuracy"},
+			{Name: "vert_accuracy", Type: "float", Description: "Vertical speed 1-STD accuracy"},
+		},
+	},
+	232: {
+		Name:        "GPS_INPUT",
+		Description: "GPS sensor input message.  This is a raw sensor value sent by the GPS. This is NOT the global position estimate of the system.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "gps_id", Type: "uint8_t", Description: "ID of the GPS for multiple GPS inputs"},
+			{Name: "ignore_flags", Type: "uint16_t", Enum: "GPS_INPUT_IGNORE_FLAGS", Description: "Bitmap indicating which GPS input flags fields to ignore.  All other fields must be provided."},
+			{Name: "time_week_ms", Type: "uint32_t", Description: "GPS time (from start of GPS week)"},
+			{Name: "time_week", Type: "uint16_t", Description: "GPS week number"},
+			{Name: "fix_type", Type: "uint8_t", Description: "0-1: no fix, 2: 2D fix, 3: 3D fix. 4: 3D with DGPS. 5: 3D with RTK"},
+			{Name: "lat", Type: "int32_t", Description: "Latitude (WGS84)"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude (WGS84)"},
+			{Name: "alt", Type: "float", Description: "Altitude (MSL). Positive for up."},
+			{Name: "hdop", Type: "float", Description: "GPS HDOP horizontal dilution of position (unitless). If unknown, set to: UINT16_MAX"},
+			{Name: "vdop", Type: "float", Description: "GPS VDOP vertical dilution of position (unitless). If unknown, set to: UINT16_MAX"},
+			{Name: "vn", Type: "float", Description: "GPS velocity in north direction in earth-fixed NED frame"},
+			{Name: "ve", Type: "float", Description: "GPS velocity in east direction in earth-fixed NED frame"},
+			{Name: "vd", Type: "float", Description: "GPS velocity in down direction in earth-fixed NED frame"},
+			{Name: "speed_accuracy", Type: "float", Description: "GPS speed accuracy"},
+			{Name: "horiz_accuracy", Type: "float", Description: "GPS horizontal accuracy"},
+			{Name: "vert_accuracy", Type: "float", Description: "GPS vertical accuracy"},
+			{Name: "satellites_visible", Type: "uint8_t", Description: "Number of satellites visible."},
+			{Name: "yaw", Type: "uint16_t", Description: "Yaw of vehicle relative to Earth's North, zero means not available, use 36000 for north", Extension: true},
+		},
+	},
+	233: {
+		Name:        "GPS_RTCM_DATA",
+		Description: "RTCM message for injecting into the onboard GPS (used for DGPS)",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "flags", Type: "uint8_t", Description: "LSB: 1 means message is fragmented, next 2 bits are the fragment ID, the remaining 5 bits are used for the sequence ID. Messages are only to be flushed to the GPS when the entire message has been reconstructed on the autopilot. The fragment ID specifies which order the fragments should be assembled into a buffer, while the sequence ID is used to detect a mismatch between different buffers. The buffer is considered fully reconstructed when either all 4 fragments are present, or all the fragments before the first fragment with a non full payload is received. This management is used to ensure that normal GPS operation doesn't corrupt RTCM data, and to recover from a unreliable transport delivery order."},
+			{Name: "len", Type: "uint8_t", Description: "data length"},
+			{Name: "data", Type: "uint8_t", ArrayLength: 180, Description: "RTCM message (may be fragmented)"},
+		},
+	},
+	234: {
+		Name:        "HIGH_LATENCY",
+		Description: "Message appropriate for high latency connections like Iridium",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "base_mode", Type: "uint8_t", Enum: "MAV_MODE_FLAG", Description: "Bitmap of enabled system modes."},
+			{Name: "custom_mode", Type: "uint32_t", Description: "A bitfield for use for autopilot-specific flags."},
+			{Name: "landed_state", Type: "uint8_t", Enum: "MAV_LANDED_STATE", Description: "The landed state. Is set to MAV_LANDED_STATE_UNDEFINED if landed state is unknown."},
+			{Name: "roll", Type: "int16_t", Description: "roll"},
+			{Name: "pitch", Type: "int16_t", Description: "pitch"},
+			{Name: "heading", Type: "uint16_t", Description: "heading"},
+			{Name: "throttle", Type: "int8_t", Description: "throttle (percentage)"},
+			{Name: "heading_sp", Type: "int16_t", Description: "heading setpoint"},
+			{Name: "latitude", Type: "int32_t", Description: "Latitude"},
+			{Name: "longitude", Type: "int32_t", Description: "Longitude"},
+			{Name: "altitude_amsl", Type: "int16_t", Description: "Altitude above mean sea level"},
+			{Name: "altitude_sp", Type: "int16_t", Description: "Altitude setpoint relative to the home position"},
+			{Name: "airspeed", Type: "uint8_t", Description: "airspeed"},
+			{Name: "airspeed_sp", Type: "uint8_t", Description: "airspeed setpoint"},
+			{Name: "groundspeed", Type: "uint8_t", Description: "groundspeed"},
+			{Name: "climb_rate", Type: "int8_t", Description: "climb rate"},
+			{Name: "gps_nsat", Type: "uint8_t", Description: "Number of satellites visible. If unknown, set to UINT8_MAX"},
+			{Name: "gps_fix_type", Type: "uint8_t", Enum: "GPS_FIX_TYPE", Description: "GPS Fix type."},
+			{Name: "battery_remaining", Type: "uint8_t", Description: "Remaining battery (percentage)"},
+			{Name: "temperature", Type: "int8_t", Description: "Autopilot temperature (degrees C)"},
+			{Name: "temperature_air", Type: "int8_t", Description: "Air temperature (degrees C) from airspeed sensor"},
+			{Name: "failsafe", Type: "uint8_t", Description: "failsafe (each bit represents a failsafe where 0=ok, 1=failsafe active (bit0:RC, bit1:batt, bit2:GPS, bit3:GCS, bit4:fence)"},
+			{Name: "wp_num", Type: "uint8_t", Description: "current waypoint number"},
+			{Name: "wp_distance", Type: "uint16_t", Description: "distance to target"},
+		},
+	},
+	235: {
+		Name:        "HIGH_LATENCY2",
+		Description: "Message appropriate for high latency connections like Iridium (version 2)",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "timestamp", Type: "uint32_t", Description: "Timestamp (milliseconds since boot or Unix epoch)"},
+			{Name: "type", Type: "uint8_t", Enum: "MAV_TYPE", Description: "Type of the MAV (quadrotor, helicopter, etc.)"},
+			{Name: "autopilot", Type: "uint8_t", Enum: "MAV_AUTOPILOT", Description: "Autopilot type / class. Use MAV_AUTOPILOT_INVALID for components that are not flight controllers."},
+			{Name: "custom_mode", Type: "uint16_t", Description: "A bitfield for use for autopilot-specific flags (2 byte version)."},
+			{Name: "latitude", Type: "int32_t", Description: "Latitude"},
+			{Name: "longitude", Type: "int32_t", Description: "Longitude"},
+			{Name: "altitude", Type: "int16_t", Description: "Altitude above mean sea level"},
+			{Name: "target_altitude", Type: "int16_t", Description: "Altitude setpoint"},
+			{Name: "heading", Type: "uint8_t", Description: "Heading"},
+			{Name: "target_heading", Type: "uint8_t", Description: "Heading setpoint"},
+			{Name: "target_distance", Type: "uint16_t", Description: "Distance to target waypoint or position"},
+			{Name: "throttle", Type: "uint8_t", Description: "Throttle"},
+			{Name: "airspeed", Type: "uint8_t", Description: "Airspeed"},
+			{Name: "airspeed_sp", Type: "uint8_t", Description: "Airspeed setpoint"},
+			{Name: "groundspeed", Type: "uint8_t", Description: "Groundspeed"},
+			{Name: "windspeed", Type: "uint8_t", Description: "Windspeed"},
+			{Name: "wind_heading", Type: "uint8_t", Description: "Wind heading"},
+			{Name: "eph", Type: "uint8_t", Description: "Maximum error horizontal position since last message"},
+			{Name: "epv", Type: "uint8_t", Description: "Maximum error vertical position since last message"},
+			{Name: "temperature_air", Type: "int8_t", Description: "Air temperature from airspeed sensor"},
+			{Name: "climb_rate", Type: "int8_t", Description: "Maximum climb rate magnitude since last message"},
+			{Name: "battery", Type: "int8_t", Description: "Battery level (-1 if field not provided)."},
+			{Name: "wp_num", Type: "uint16_t", Description: "Current waypoint number"},
+			{Name: "failure_flags", Type: "uint16_t", Enum: "HL_FAILURE_FLAG", Description: "Bitmap of failure flags."},
+			{Name: "custom0", Type: "int8_t", Description: "Field for custom payload."},
+			{Name: "custom1", Type: "int8_t", Description: "Field for custom payload."},
+			{Name: "custom2", Type: "int8_t", Description: "Field for custom payload."},
+		},
+	},
+	241: {
+		Name:        "VIBRATION",
+		Description: "Vibration levels and accelerometer clipping",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "vibration_x", Type: "float", Description: "Vibration levels on X-axis"},
+			{Name: "vibration_y", Type: "float", Description: "Vibration levels on Y-axis"},
+			{Name: "vibration_z", Type: "float", Description: "Vibration levels on Z-axis"},
+			{Name: "clipping_0", Type: "uint32_t", Description: "first accelerometer clipping count"},
+			{Name: "clipping_1", Type: "uint32_t", Description: "second accelerometer clipping count"},
+			{Name: "clipping_2", Type: "uint32_t", Description: "third accelerometer clipping count"},
+		},
+	},
+	242: {
+		Name:        "HOME_POSITION",
+		Description: "This message can be requested by sending the MAV_CMD_GET_HOME_POSITION command. The position the system will return to and land on. The position is set automatically by the system during the takeoff in case it was not explicitly set by the operator before or after. The global and local positions encode the position in the respective coordinate frames, while the q parameter encodes the orientation of the surface. Under normal conditions it describes the heading and terrain slope, which can be used by the aircraft to adjust the approach. The approach 3D vector describes the point to which the system should fly in normal flight mode and then perform a landing sequence along the vector.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "latitude", Type: "int32_t", Description: "Latitude (WGS84)"},
+			{Name: "longitude", Type: "int32_t", Description: "Longitude (WGS84)"},
+			{Name: "altitude", Type: "int32_t", Description: "Altitude (MSL). Positive for up."},
+			{Name: "x", Type: "float", Description: "Local X position of this position in the local coordinate frame"},
+			{Name: "y", Type: "float", Description: "Local Y position of this position in the local coordinate frame"},
+			{Name: "z", Type: "float", Description: "Local Z position of this position in the local coordinate frame"},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "World to surface normal and heading transformation of the takeoff position. Used to indicate the heading and slope of the ground"},
+			{Name: "approach_x", Type: "float", Description: "Local X position of the end of the approach vector. Multicopters should set this position based on their takeoff path. Grass-landing fixed wing aircraft should set it the same way as multicopters. Runway-landing fixed wing aircraft should set it to the opposite direction of the takeoff, assuming the takeoff happened from the threshold / touchdown zone."},
+			{Name: "approach_y", Type: "float", Description: "Local Y position of the end of the approach vector. Multicopters should set this position based on their takeoff path. Grass-landing fixed wing aircraft should set it the same way as multicopters. Runway-landing fixed wing aircraft should set it to the opposite direction of the takeoff, assuming the takeoff happened from the threshold / touchdown zone."},
+			{Name: "approach_z", Type: "float", Description: "Local Z position of the end of the approach vector. Multicopters should set this position based on their takeoff path. Grass-landing fixed wing aircraft should set it the same way as multicopters. Runway-landing fixed wing aircraft should set it to the opposite direction of the takeoff, assuming the takeoff happened from the threshold / touchdown zone."},
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number.", Extension: true},
+		},
+	},
+	243: {
+		Name:        "SET_HOME_POSITION",
+		Description: "The position the system will return to and land on. The position is set automatically by the system during the takeoff in case it was not explicitly set by the operator before or after. The global and local positions encode the position in the respective coordinate frames, while the q parameter encodes the orientation of the surface. Under normal conditions it describes the heading and terrain slope, which can be used by the aircraft to adjust the approach. The approach 3D vector describes the point to which the system should fly in normal flight mode and then perform a landing sequence along the vector.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID."},
+			{Name: "latitude", Type: "int32_t", Description: "Latitude (WGS84)"},
+			{Name: "longitude", Type: "int32_t", Description: "Longitude (WGS84)"},
+			{Name: "altitude", Type: "int32_t", Description: "Altitude (MSL). Positive for up."},
+			{Name: "x", Type: "float", Description: "Local X position of this position in the local coordinate frame"},
+			{Name: "y", Type: "float", Description: "Local Y position of this position in the local coordinate frame"},
+			{Name: "z", Type: "float", Description: "Local Z position of this position in the local coordinate frame"},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "World to surface normal and heading transformation of the takeoff position. Used to indicate the heading and slope of the ground"},
+			{Name: "approach_x", Type: "float", Description: "Local X position of the end of the approach vector. Multicopters should set this position based on their takeoff path. Grass-landing fixed wing aircraft should set it the same way as multicopters. Runway-landing fixed wing aircraft should set it to the opposite direction of the takeoff, assuming the takeoff happened from the threshold / touchdown zone."},
+			{Name: "approach_y", Type: "float", Description: "Local Y position of the end of the approach vector. Multicopters should set this position based on their takeoff path. Grass-landing fixed wing aircraft should set it the same way as multicopters. Runway-landing fixed wing aircraft should set it to the opposite direction of the takeoff, assuming the takeoff happened from the threshold / touchdown zone."},
+			{Name: "approach_z", Type: "float", Description: "Local Z position of the end of the approach vector. Multicopters should set this position based on their takeoff path. Grass-landing fixed wing aircraft should set it the same way as multicopters. Runway-landing fixed wing aircraft should set it to the opposite direction of the takeoff, assuming the takeoff happened from the threshold / touchdown zone."},
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number.", Extension: true},
+		},
+	},
+	244: {
+		Name:        "MESSAGE_INTERVAL",
+		Description: "The interval between messages for a particular MAVLink message ID. This message is the response to the MAV_CMD_GET_MESSAGE_INTERVAL command. This interface replaces DATA_STREAM.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "message_id", Type: "uint16_t", Description: "The ID of the requested MAVLink message. v1.0 is limited to 254 messages."},
+			{Name: "interval_us", Type: "int32_t", Description: "The interval between two messages. A value of -1 indicates this stream is disabled, 0 indicates it is not available, &gt; 0 indicates the interval at which it is sent."},
+		},
+	},
+	245: {
+		Name:        "EXTENDED_SYS_STATE",
+		Description: "Provides state for additional features",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "vtol_state", Type: "uint8_t", Enum: "MAV_VTOL_STATE", Description: "The VTOL state if applicable. Is set to MAV_VTOL_STATE_UNDEFINED if UAV is not in VTOL configuration."},
+			{Name: "landed_state", Type: "uint8_t", Enum: "MAV_LANDED_STATE", Description: "The landed state. Is set to MAV_LANDED_STATE_UNDEFINED if landed state is unknown."},
+		},
+	},
+	246: {
+		Name:        "ADSB_VEHICLE",
+		Description: "The location and information of an ADSB vehicle",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "ICAO_address", Type: "uint32_t", Description: "ICAO address"},
+			{Name: "lat", Type: "int32_t", Description: "Latitude"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude"},
+			{Name: "altitude_type", Type: "uint8_t", Enum: "ADSB_ALTITUDE_TYPE", Description: "ADSB altitude type."},
+			{Name: "altitude", Type: "int32_t", Description: "Altitude(ASL)"},
+			{Name: "heading", Type: "uint16_t", Description: "Course over ground"},
+			{Name: "hor_velocity", Type: "uint16_t", Description: "The horizontal velocity"},
+			{Name: "ver_velocity", Type: "int16_t", Description: "The vertical velocity. Positive is up"},
+			{Name: "callsign", Type: "char", ArrayLength: 9, Description: "The callsign, 8+null"},
+			{Name: "emitter_type", Type: "uint8_t", Enum: "ADSB_EMITTER_TYPE", Description: "ADSB emitter type."},
+			{Name: "tslc", Type: "uint8_t", Description: "Time since last communication in seconds"},
+			{Name: "flags", Type: "uint16_t", Enum: "ADSB_FLAGS", Description: "Bitmap to indicate various statuses including valid data fields"},
+			{Name: "squawk", Type: "uint16_t", Description: "Squawk code"},
+		},
+	},
+	247: {
+		Name:        "COLLISION",
+		Description: "Information about a potential collision",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "src", Type: "uint8_t", Enum: "MAV_COLLISION_SRC", Description: "Collision data source"},
+			{Name: "id", Type: "uint32_t", Description: "Unique identifier, domain based on src field"},
+			{Name: "action", Type: "uint8_t", Enum: "MAV_COLLISION_ACTION", Description: "Action that is being taken to avoid this collision"},
+			{Name: "threat_level", Type: "uint8_t", Enum: "MAV_COLLISION_THREAT_LEVEL", Description: "How concerned the aircraft is about this collision"},
+			{Name: "time_to_minimum_delta", Type: "float", Description: "Estimated time until collision occurs"},
+			{Name: "altitude_minimum_delta", Type: "float", Description: "Closest vertical distance between vehicle and object"},
+			{Name: "horizontal_minimum_delta", Type: "float", Description: "Closest horizontal distance between vehicle and object"},
+		},
+	},
+	248: {
+		Name:        "V2_EXTENSION",
+		Description: "Message implementing parts of the V2 payload specs in V1 frames for transitional support.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_network", Type: "uint8_t", Description: "Network ID (0 for broadcast)"},
+			{Name: "target_system", Type: "uint8_t", Description: "System ID (0 for broadcast)"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID (0 for broadcast)"},
+			{Name: "message_type", Type: "uint16_t", Description: "A code that identifies the software component that understands this message (analogous to USB device classes or mime type strings). If this code is less than 32768, it is considered a 'registered' protocol extension and the corresponding entry should be added to https://github.com/mavlink/mavlink/definition_files/extension_message_ids.xml. Software creators can register blocks of message IDs as needed (useful for GCS specific metadata, etc...). Message_types greater than 32767 are considered local experiments and should not be checked in to any widely distributed codebase."},
+			{Name: "payload", Type: "uint8_t", ArrayLength: 249, Description: "Variable length payload. The length must be encoded in the payload as part of the message_type protocol, e.g. by including the length as payload data, or by terminating the payload data with a non-zero marker. This is required in order to reconstruct zero-terminated payloads that are (or otherwise would be) trimmed by MAVLink 2 empty-byte truncation. The entire content of the payload block is opaque unless you understand the encoding message_type. The particular encoding used can be extension specific and might not always be documented as part of the MAVLink specification."},
+		},
+	},
+	249: {
+		Name:        "MEMORY_VECT",
+		Description: "Send raw controller memory. The use of this message is discouraged for normal packets, but a quite efficient way for testing new messages and getting experimental debug output.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "address", Type: "uint16_t", Description: "Starting address of the debug variables"},
+			{Name: "ver", Type: "uint8_t", Description: "Version code of the type variable. 0=unknown, type ignored and assumed int16_t. 1=as below"},
+			{Name: "type", Type: "uint8_t", Description: "Type code of the memory variables. for ver = 1: 0=16 x int16_t, 1=16 x uint16_t, 2=16 x Q15, 3=16 x 1Q14"},
+			{Name: "value", Type: "int8_t", ArrayLength: 32, Description: "Memory contents at specified address"},
+		},
+	},
+	250: {
+		Name:        "DEBUG_VECT",
+		Description: "To debug something using a named 3D vector.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "name", Type: "char", ArrayLength: 10, Description: "Name"},
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "x", Type: "float", Description: "x"},
+			{Name: "y", Type: "float", Description: "y"},
+			{Name: "z", Type: "float", Description: "z"},
+		},
+	},
+	251: {
+		Name:        "NAMED_VALUE_FLOAT",
+		Description: "Send a key-value pair as float. The use of this message is discouraged for normal packets, but a quite efficient way for testing new messages and getting experimental debug output.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "name", Type: "char", ArrayLength: 10, Description: "Name of the debug variable"},
+			{Name: "value", Type: "float", Description: "Floating point value"},
+		},
+	},
+	252: {
+		Name:        "NAMED_VALUE_INT",
+		Description: "Send a key-value pair as integer. The use of this message is discouraged for normal packets, but a quite efficient way for testing new messages and getting experimental debug output.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "name", Type: "char", ArrayLength: 10, Description: "Name of the debug variable"},
+			{Name: "value", Type: "int32_t", Description: "Signed integer value"},
+		},
+	},
+	253: {
+		Name:        "STATUSTEXT",
+		Description: "Status text message. These messages are printed in yellow in the COMM console of QGroundControl. WARNING: They consume quite some bandwidth, so use only for important status and error messages. If implemented wisely, these messages are buffered on the MCU and sent only at a limited rate (e.g. 10 Hz).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "severity", Type: "uint8_t", Enum: "MAV_SEVERITY", Description: "Severity of status. Relies on the definitions within RFC-5424."},
+			{Name: "text", Type: "char", ArrayLength: 50, Description: "Status text message, without null termination character"},
+			{Name: "id", Type: "uint16_t", Description: "Unique (opaque) identifier for this statustext message.  May be used to reassemble a logical long-statustext message from a sequence of chunks.  A value of zero indicates this is the only chunk in the sequence and the message can be emitted immediately.", Extension: true},
+			{Name: "chunk_seq", Type: "uint8_t", Description: "This chunk's sequence number; indexing is from zero.  Any null character in the text field is taken to mean this was the last chunk.", Extension: true},
+		},
+	},
+	254: {
+		Name:        "DEBUG",
+		Description: "Send a debug value. The index is used to discriminate between values. These values show up in the plot of QGroundControl as DEBUG N.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "ind", Type: "uint8_t", Description: "index of debug variable"},
+			{Name: "value", Type: "float", Description: "DEBUG value"},
+		},
+	},
+	256: {
+		Name:        "SETUP_SIGNING",
+		Description: "Setup a MAVLink2 signing key. If called with secret_key of all zero and zero initial_timestamp will disable signing",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "system id of the target"},
+			{Name: "target_component", Type: "uint8_t", Description: "component ID of the target"},
+			{Name: "secret_key", Type: "uint8_t", ArrayLength: 32, Description: "signing key"},
+			{Name: "initial_timestamp", Type: "uint64_t", Description: "initial timestamp"},
+		},
+	},
+	257: {
+		Name:        "BUTTON_CHANGE",
+		Description: "Report button state change.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "last_change_ms", Type: "uint32_t", Description: "Time of last change of button state."},
+			{Name: "state", Type: "uint8_t", Description: "Bitmap for state of buttons."},
+		},
+	},
+	258: {
+		Name:        "PLAY_TUNE",
+		Description: "Control vehicle tone generation (buzzer).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "tune", Type: "char", ArrayLength: 30, Description: "tune in board specific format"},
+			{Name: "tune2", Type: "char", ArrayLength: 200, Description: "tune extension (appended to tune)", Extension: true},
+		},
+	},
+	259: {
+		Name:        "CAMERA_INFORMATION",
+		Description: "Information about a camera. Can be requested with a MAV_CMD_REQUEST_MESSAGE command.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "vendor_name", Type: "uint8_t", ArrayLength: 32, Description: "Name of the camera vendor"},
+			{Name: "model_name", Type: "uint8_t", ArrayLength: 32, Description: "Name of the camera model"},
+			{Name: "firmware_version", Type: "uint32_t", Description: "Version of the camera firmware, encoded as: (Dev &amp; 0xff) &lt;&lt; 24 | (Patch &amp; 0xff) &lt;&lt; 16 | (Minor &amp; 0xff) &lt;&lt; 8 | (Major &amp; 0xff)"},
+			{Name: "focal_length", Type: "float", Description: "Focal length"},
+			{Name: "sensor_size_h", Type: "float", Description: "Image sensor size horizontal"},
+			{Name: "sensor_size_v", Type: "float", Description: "Image sensor size vertical"},
+			{Name: "resolution_h", Type: "uint16_t", Description: "Horizontal image resolution"},
+			{Name: "resolution_v", Type: "uint16_t", Description: "Vertical image resolution"},
+			{Name: "lens_id", Type: "uint8_t", Description: "Reserved for a lens ID"},
+			{Name: "flags", Type: "uint32_t", Enum: "CAMERA_CAP_FLAGS", Description: "Bitmap of camera capability flags."},
+			{Name: "cam_definition_version", Type: "uint16_t", Description: "Camera definition version (iteration)"},
+			{Name: "cam_definition_uri", Type: "char", ArrayLength: 140, Description: "Camera definition URI (if any, otherwise only basic functions will be available). HTTP- (http://) and MAVLink FTP- (mavlinkftp://) formatted URIs are allowed (and both must be supported by any GCS that implements the Camera Protocol). The definition file may be xz compressed, which will be indicated by the file extension .xml.xz (a GCS that implements the protocol must support decompressing the file)."},
+		},
+	},
+	260: {
+		Name:        "CAMERA_SETTINGS",
+		Description: "Settings of a camera. Can be requested with a MAV_CMD_REQUEST_MESSAGE command.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "mode_id", Type: "uint8_t", Enum: "CAMERA_MODE", Description: "Camera mode"},
+			{Name: "zoomLevel", Type: "float", Description: "Current zoom level (0.0 to 100.0, NaN if not known)", Extension: true},
+			{Name: "focusLevel", Type: "float", Description: "Current focus level (0.0 to 100.0, NaN if not known)", Extension: true},
+		},
+	},
+	261: {
+		Name:        "STORAGE_INFORMATION",
+		Description: "Information about a storage medium. This message is sent in response to a request with MAV_CMD_REQUEST_MESSAGE and whenever the status of the storage changes (STORAGE_STATUS). Use MAV_CMD_REQUEST_MESSAGE.param2 to indicate the index/id of requested storage: 0 for all, 1 for first, 2 for second, etc.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "storage_id", Type: "uint8_t", Description: "Storage ID (1 for first, 2 for second, etc.)"},
+			{Name: "storage_count", Type: "uint8_t", Description: "Number of storage devices"},
+			{Name: "status", Type: "uint8_t", Enum: "STORAGE_STATUS", Description: "Status of storage"},
+			{Name: "total_capacity", Type: "float", Description: "Total capacity. If storage is not ready (STORAGE_STATUS_READY) value will be ignored."},
+			{Name: "used_capacity", Type: "float", Description: "Used capacity. If storage is not ready (STORAGE_STATUS_READY) value will be ignored."},
+			{Name: "available_capacity", Type: "float", Description: "Available storage capacity. If storage is not ready (STORAGE_STATUS_READY) value will be ignored."},
+			{Name: "read_speed", Type: "float", Description: "Read speed."},
+			{Name: "write_speed", Type: "float", Description: "Write speed."},
+			{Name: "type", Type: "uint8_t", Enum: "STORAGE_TYPE", Description: "Type of storage", Extension: true},
+			{Name: "name", Type: "char", ArrayLength: 32, Description: "Textual storage name to be used in UI (microSD 1, Internal Memory, etc.) This is a NULL terminated string. If it is exactly 32 characters long, add a terminating NULL. If this string is empty, the generic type is shown to the user.", Extension: true},
+		},
+	},
+	262: {
+		Name:        "CAMERA_CAPTURE_STATUS",
+		Description: "Information about the status of a capture. Can be requested with a MAV_CMD_REQUEST_MESSAGE command.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "image_status", Type: "uint8_t", Description: "Current status of image capturing (0: idle, 1: capture in progress, 2: interval set but idle, 3: interval set and capture in progress)"},
+			{Name: "video_status", Type: "uint8_t", Description: "Current status of video capturing (0: idle, 1: capture in progress)"},
+			{Name: "image_interval", Type: "float", Description: "Image capture interval"},
+			{Name: "recording_time_ms", Type: "uint32_t", Description: "Elapsed time since recording started (0: Not supported/available). A GCS should compute recording time and use non-zero values of this field to correct any discrepancy."},
+			{Name: "available_capacity", Type: "float", Description: "Available storage capacity."},
+			{Name: "image_count", Type: "int32_t", Description: "Total number of images captured ('forever', or until reset using MAV_CMD_STORAGE_FORMAT).", Extension: true},
+		},
+	},
+	263: {
+		Name:        "CAMERA_IMAGE_CAPTURED",
+		Description: "Information about a captured image. This is emitted every time a message is captured. It may be re-requested using MAV_CMD_REQUEST_MESSAGE, using param2 to indicate the sequence number for the missing image.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "time_utc", Type: "uint64_t", Description: "Timestamp (time since UNIX epoch) in UTC. 0 for unknown."},
+			{Name: "camera_id", Type: "uint8_t", Description: "Deprecated/unused. Component IDs are used to differentiate multiple cameras."},
+			{Name: "lat", Type: "int32_t", Description: "Latitude where image was taken"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude where capture was taken"},
+			{Name: "alt", Type: "int32_t", Description: "Altitude (MSL) where image was taken"},
+			{Name: "relative_alt", Type: "int32_t", Description: "Altitude above ground"},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "Quaternion of camera orientation (w, x, y, z order, zero-rotation is 1, 0, 0, 0)"},
+			{Name: "image_index", Type: "int32_t", Description: "Zero based index of this image (i.e. a new image will have index CAMERA_CAPTURE_STATUS.image count -1)"},
+			{Name: "capture_result", Type: "int8_t", Description: "Boolean indicating success (1) or failure (0) while capturing this image."},
+			{Name: "file_url", Type: "char", ArrayLength: 205, Description: "URL of image taken. Either local storage or http://foo.jpg if camera provides an HTTP interface."},
+		},
+	},
+	264: {
+		Name:        "FLIGHT_INFORMATION",
+		Description: "Information about flight since last arming.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "arming_time_utc", Type: "uint64_t", Description: "Timestamp at arming (time since UNIX epoch) in UTC, 0 for unknown"},
+			{Name: "takeoff_time_utc", Type: "uint64_t", Description: "Timestamp at takeoff (time since UNIX epoch) in UTC, 0 for unknown"},
+			{Name: "flight_uuid", Type: "uint64_t", Description: "Universally unique identifier (UUID) of flight, should correspond to name of log files"},
+		},
+	},
+	265: {
+		Name:        "MOUNT_ORIENTATION",
+		Description: "Orientation of a mount",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "roll", Type: "float", Description: "Roll in global frame (set to NaN for invalid)."},
+			{Name: "pitch", Type: "float", Description: "Pitch in global frame (set to NaN for invalid)."},
+			{Name: "yaw", Type: "float", Description: "Yaw relative to vehicle (set to NaN for invalid)."},
+			{Name: "yaw_absolute", Type: "float", Description: "Yaw in absolute frame relative to Earth's North, north is 0 (set to NaN for invalid).", Extension: true},
+		},
+	},
+	266: {
+		Name:        "LOGGING_DATA",
+		Description: "A message containing logged data (see also MAV_CMD_LOGGING_START)",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "system ID of the target"},
+			{Name: "target_component", Type: "uint8_t", Description: "component ID of the target"},
+			{Name: "sequence", Type: "uint16_t", Description: "sequence number (can wrap)"},
+			{Name: "length", Type: "uint8_t", Description: "data length"},
+			{Name: "first_message_offset", Type: "uint8_t", Description: "offset into data where first message starts. This can be used for recovery, when a previous message got lost (set to UINT8_MAX if no start exists)."},
+			{Name: "data", Type: "uint8_t", ArrayLength: 249, Description: "logged data"},
+		},
+	},
+	267: {
+		Name:        "LOGGING_DATA_ACKED",
+		Description: "A message containing logged data which requires a LOGGING_ACK to be sent back",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "system ID of the target"},
+			{Name: "target_component", Type: "uint8_t", Description: "component ID of the target"},
+			{Name: "sequence", Type: "uint16_t", Description: "sequence number (can wrap)"},
+			{Name: "length", Type: "uint8_t", Description: "data length"},
+			{Name: "first_message_offset", Type: "uint8_t", Description: "offset into data where first message starts. This can be used for recovery, when a previous message got lost (set to UINT8_MAX if no start exists)."},
+			{Name: "data", Type: "uint8_t", ArrayLength: 249, Description: "logged data"},
+		},
+	},
+	268: {
+		Name:        "LOGGING_ACK",
+		Description: "An ack for a LOGGING_DATA_ACKED message",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "system ID of the target"},
+			{Name: "target_component", Type: "uint8_t", Description: "component ID of the target"},
+			{Name: "sequence", Type: "uint16_t", Description: "sequence number (must match the one in LOGGING_DATA_ACKED)"},
+		},
+	},
+	269: {
+		Name:        "VIDEO_STREAM_INFORMATION",
+		Description: "Information about video stream. It may be requested using MAV_CMD_REQUEST_MESSAGE, where param2 indicates the video stream id: 0 for all streams, 1 for first, 2 for second, etc.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "stream_id", Type: "uint8_t", Description: "Video Stream ID (1 for first, 2 for second, etc.)"},
+			{Name: "count", Type: "uint8_t", Description: "Number of streams available."},
+			{Name: "type", Type: "uint8_t", Enum: "VIDEO_STREAM_TYPE", Description: "Type of stream."},
+			{Name: "flags", Type: "uint16_t", Enum: "VIDEO_STREAM_STATUS_FLAGS", Description: "Bitmap of stream status flags."},
+			{Name: "framerate", Type: "float", Description: "Frame rate."},
+			{Name: "resolution_h", Type: "uint16_t", Description: "Horizontal resolution."},
+			{Name: "resolution_v", Type: "uint16_t", Description: "Vertical resolution."},
+			{Name: "bitrate", Type: "uint32_t", Description: "Bit rate."},
+			{Name: "rotation", Type: "uint16_t", Description: "Video image rotation clockwise."},
+			{Name: "hfov", Type: "uint16_t", Description: "Horizontal Field of view."},
+			{Name: "name", Type: "char", ArrayLength: 32, Description: "Stream name."},
+			{Name: "uri", Type: "char", ArrayLength: 160, Description: "Video stream URI (TCP or RTSP URI ground station should connect to) or port number (UDP port ground station should listen to)."},
+		},
+	},
+	270: {
+		Name:        "VIDEO_STREAM_STATUS",
+		Description: "Information about the status of a video stream. It may be requested using MAV_CMD_REQUEST_MESSAGE.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "stream_id", Type: "uint8_t", Description: "Video Stream ID (1 for first, 2 for second, etc.)"},
+			{Name: "flags", Type: "uint16_t", Enum: "VIDEO_STREAM_STATUS_FLAGS", Description: "Bitmap of stream status flags"},
+			{Name: "framerate", Type: "float", Description: "Frame rate"},
+			{Name: "resolution_h", Type: "uint16_t", Description: "Horizontal resolution"},
+			{Name: "resolution_v", Type: "uint16_t", Description: "Vertical resolution"},
+			{Name: "bitrate", Type: "uint32_t", Description: "Bit rate"},
+			{Name: "rotation", Type: "uint16_t", Description: "Video image rotation clockwise"},
+			{Name: "hfov", Type: "uint16_t", Description: "Horizontal Field of view"},
+		},
+	},
+	271: {
+		Name:        "CAMERA_FOV_STATUS",
+		Description: "Information about the field of view of a camera. Can be requested with a MAV_CMD_REQUEST_MESSAGE command.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "lat_camera", Type: "int32_t", Description: "Latitude of camera (INT32_MAX if unknown)."},
+			{Name: "lon_camera", Type: "int32_t", Description: "Longitude of camera (INT32_MAX if unknown)."},
+			{Name: "alt_camera", Type: "int32_t", Description: "Altitude (MSL) of camera (INT32_MAX if unknown)."},
+			{Name: "lat_image", Type: "int32_t", Description: "Latitude of center of image (INT32_MAX if unknown, INT32_MIN if at infinity, not intersecting with horizon)."},
+			{Name: "lon_image", Type: "int32_t", Description: "Longitude of center of image (INT32_MAX if unknown, INT32_MIN if at infinity, not intersecting with horizon)."},
+			{Name: "alt_image", Type: "int32_t", Description: "Altitude (MSL) of center of image (INT32_MAX if unknown, INT32_MIN if at infinity, not intersecting with horizon)."},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "Quaternion of camera orientation (w, x, y, z order, zero-rotation is 1, 0, 0, 0)"},
+			{Name: "hfov", Type: "float", Description: "Horizontal field of view (NaN if unknown)."},
+			{Name: "vfov", Type: "float", Description: "Vertical field of view (NaN if unknown)."},
+		},
+	},
+	275: {
+		Name:        "CAMERA_TRACKING_IMAGE_STATUS",
+		Description: "Camera tracking status, sent while in active tracking. Use MAV_CMD_SET_MESSAGE_INTERVAL to define message interval.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "tracking_status", Type: "uint8_t", Enum: "CAMERA_TRACKING_STATUS_FLAGS", Description: "Current tracking status"},
+			{Name: "tracking_mode", Type: "uint8_t", Enum: "CAMERA_TRACKING_MODE", Description: "Current tracking mode"},
+			{Name: "target_data", Type: "uint8_t", Enum: "CAMERA_TRACKING_TARGET_DATA", Description: "Defines location of target data"},
+			{Name: "point_x", Type: "float", Description: "Current tracked point x value if CAMERA_TRACKING_MODE_POINT (normalized 0..1, 0 is left, 1 is right), NAN if unknown"},
+			{Name: "point_y", Type: "float", Description: "Current tracked point y value if CAMERA_TRACKING_MODE_POINT (normalized 0..1, 0 is top, 1 is bottom), NAN if unknown"},
+			{Name: "radius", Type: "float", Description: "Current tracked radius if CAMERA_TRACKING_MODE_POINT (normalized 0..1, 0 is image left, 1 is image right), NAN if unknown"},
+			{Name: "rec_top_x", Type: "float", Description: "Current tracked rectangle top x value if CAMERA_TRACKING_MODE_RECTANGLE (normalized 0..1, 0 is left, 1 is right), NAN if unknown"},
+			{Name: "rec_top_y", Type: "float", Description: "Current tracked rectangle top y value if CAMERA_TRACKING_MODE_RECTANGLE (normalized 0..1, 0 is top, 1 is bottom), NAN if unknown"},
+			{Name: "rec_bottom_x", Type: "float", Description: "Current tracked rectangle bottom x value if CAMERA_TRACKING_MODE_RECTANGLE (normalized 0..1, 0 is left, 1 is right), NAN if unknown"},
+			{Name: "rec_bottom_y", Type: "float", Description: "Current tracked rectangle bottom y value if CAMERA_TRACKING_MODE_RECTANGLE (normalized 0..1, 0 is top, 1 is bottom), NAN if unknown"},
+		},
+	},
+	276: {
+		Name:        "CAMERA_TRACKING_GEO_STATUS",
+		Description: "Camera tracking status, sent while in active tracking. Use MAV_CMD_SET_MESSAGE_INTERVAL to define message interval.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "tracking_status", Type: "uint8_t", Enum: "CAMERA_TRACKING_STATUS_FLAGS", Description: "Current tracking status"},
+			{Name: "lat", Type: "int32_t", Description: "Latitude of tracked object"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude of tracked object"},
+			{Name: "alt", Type: "float", Description: "Altitude of tracked object(AMSL, WGS84)"},
+			{Name: "h_acc", Type: "float", Description: "Horizontal accuracy. NAN if unknown"},
+			{Name: "v_acc", Type: "float", Description: "Vertical accuracy. NAN if unknown"},
+			{Name: "vel_n", Type: "float", Description: "North velocity of tracked object. NAN if unknown"},
+			{Name: "vel_e", Type: "float", Description: "East velocity of tracked object. NAN if unknown"},
+			{Name: "vel_d", Type: "float", Description: "Down velocity of tracked object. NAN if unknown"},
+			{Name: "vel_acc", Type: "float", Description: "Velocity accuracy. NAN if unknown"},
+			{Name: "dist", Type: "float", Description: "Distance between camera and tracked object. NAN if unknown"},
+			{Name: "hdg", Type: "float", Description: "Heading in radians, in NED. NAN if unknown"},
+			{Name: "hdg_acc", Type: "float", Description: "Accuracy of heading, in NED. NAN if unknown"},
+		},
+	},
+	280: {
+		Name:        "GIMBAL_MANAGER_INFORMATION",
+		Description: "Information about a high level gimbal manager. This message should be requested by a ground station using MAV_CMD_REQUEST_MESSAGE.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "cap_flags", Type: "uint32_t", Enum: "GIMBAL_MANAGER_CAP_FLAGS", Description: "Bitmap of gimbal capability flags."},
+			{Name: "gimbal_device_id", Type: "uint8_t", Description: "Gimbal device ID that this gimbal manager is responsible for."},
+			{Name: "roll_min", Type: "float", Description: "Minimum hardware roll angle (positive: rolling to the right, negative: rolling to the left)"},
+			{Name: "roll_max", Type: "float", Description: "Maximum hardware roll angle (positive: rolling to the right, negative: rolling to the left)"},
+			{Name: "pitch_min", Type: "float", Description: "Minimum pitch angle (positive: up, negative: down)"},
+			{Name: "pitch_max", Type: "float", Description: "Maximum pitch angle (positive: up, negative: down)"},
+			{Name: "yaw_min", Type: "float", Description: "Minimum yaw angle (positive: to the right, negative: to the left)"},
+			{Name: "yaw_max", Type: "float", Description: "Maximum yaw angle (positive: to the right, negative: to the left)"},
+		},
+	},
+	281: {
+		Name:        "GIMBAL_MANAGER_STATUS",
+		Description: "Current status about a high level gimbal manager. This message should be broadcast at a low regular rate (e.g. 5Hz).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "flags", Type: "uint32_t", Enum: "GIMBAL_MANAGER_FLAGS", Description: "High level gimbal manager flags currently applied."},
+			{Name: "gimbal_device_id", Type: "uint8_t", Description: "Gimbal device ID that this gimbal manager is responsible for."},
+			{Name: "primary_control_sysid", Type: "uint8_t", Description: "System ID of MAVLink component with primary control, 0 for none."},
+			{Name: "primary_control_compid", Type: "uint8_t", Description: "Component ID of MAVLink component with primary control, 0 for none."},
+			{Name: "secondary_control_sysid", Type: "uint8_t", Description: "System ID of MAVLink component with secondary control, 0 for none."},
+			{Name: "secondary_control_compid", Type: "uint8_t", Description: "Component ID of MAVLink component with secondary control, 0 for none."},
+		},
+	},
+	282: {
+		Name:        "GIMBAL_MANAGER_SET_ATTITUDE",
+		Description: "High level message to control a gimbal's attitude. This message is to be sent to the gimbal manager (e.g. from a ground station). Angles and rates can be set to NaN according to use case.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "flags", Type: "uint32_t", Enum: "GIMBAL_MANAGER_FLAGS", Description: "High level gimbal manager flags to use."},
+			{Name: "gimbal_device_id", Type: "uint8_t", Description: "Component ID of gimbal device to address (or 1-6 for non-MAVLink gimbal), 0 for all gimbal device components. Send command multiple times for more than one gimbal (but not all gimbals)."},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "Quaternion components, w, x, y, z (1 0 0 0 is the null-rotation, the frame is depends on whether the flag GIMBAL_MANAGER_FLAGS_YAW_LOCK is set)"},
+			{Name: "angular_velocity_x", Type: "float", Description: "X component of angular velocity, positive is rolling to the right, NaN to be ignored."},
+			{Name: "angular_velocity_y", Type: "float", Description: "Y component of angular velocity, positive is pitching up, NaN to be ignored."},
+			{Name: "angular_velocity_z", Type: "float", Description: "Z component of angular velocity, positive is yawing to the right, NaN to be ignored."},
+		},
+	},
+	283: {
+		Name:        "GIMBAL_DEVICE_INFORMATION",
+		Description: "Information about a low level gimbal. This message should be requested by the gimbal manager or a ground station using MAV_CMD_REQUEST_MESSAGE. The maximum angles and rates are the limits by hardware. However, the limits by software used are likely different/smaller and dependent on mode/settings/etc..",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "vendor_name", Type: "char", ArrayLength: 32, Description: "Name of the gimbal vendor."},
+			{Name: "model_name", Type: "char", ArrayLength: 32, Description: "Name of the gimbal model."},
+			{Name: "custom_name", Type: "char", ArrayLength: 32, Description: "Custom name of the gimbal given to it by the user."},
+			{Name: "firmware_version", Type: "uint32_t", Description: "Version of the gimbal firmware, encoded as: (Dev &amp; 0xff) &lt;&lt; 24 | (Patch &amp; 0xff) &lt;&lt; 16 | (Minor &amp; 0xff) &lt;&lt; 8 | (Major &amp; 0xff)."},
+			{Name: "hardware_version", Type: "uint32_t", Description: "Version of the gimbal hardware, encoded as: (Dev &amp; 0xff) &lt;&lt; 24 | (Patch &amp; 0xff) &lt;&lt; 16 | (Minor &amp; 0xff) &lt;&lt; 8 | (Major &amp; 0xff)."},
+			{Name: "uid", Type: "uint64_t", Description: "UID of gimbal hardware (0 if unknown)."},
+			{Name: "cap_flags", Type: "uint16_t", Enum: "GIMBAL_DEVICE_CAP_FLAGS", Description: "Bitmap of gimbal capability flags."},
+			{Name: "custom_cap_flags", Type: "uint16_t", Description: "Bitmap for use for gimbal-specific capability flags."},
+			{Name: "roll_min", Type: "float", Description: "Minimum hardware roll angle (positive: rolling to the right, negative: rolling to the left)"},
+			{Name: "roll_max", Type: "float", Description: "Maximum hardware roll angle (positive: rolling to the right, negative: rolling to the left)"},
+			{Name: "pitch_min", Type: "float", Description: "Minimum hardware pitch angle (positive: up, negative: down)"},
+			{Name: "pitch_max", Type: "float", Description: "Maximum hardware pitch angle (positive: up, negative: down)"},
+			{Name: "yaw_min", Type: "float", Description: "Minimum hardware yaw angle (positive: to the right, negative: to the left)"},
+			{Name: "yaw_max", Type: "float", Description: "Maximum hardware yaw angle (positive: to the right, negative: to the left)"},
+		},
+	},
+	284: {
+		Name:        "GIMBAL_DEVICE_SET_ATTITUDE",
+		Description: "Low level message to control a gimbal device's attitude. This message is to be sent from the gimbal manager to the gimbal device component. Angles and rates can be set to NaN according to use case.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "flags", Type: "uint16_t", Enum: "GIMBAL_DEVICE_FLAGS", Description: "Low level gimbal flags."},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "Quaternion components, w, x, y, z (1 0 0 0 is the null-rotation, the frame is depends on whether the flag GIMBAL_DEVICE_FLAGS_YAW_LOCK is set, set all fields to NaN if only angular velocity should be used)"},
+			{Name: "angular_velocity_x", Type: "float", Description: "X component of angular velocity, positive is rolling to the right, NaN to be ignored."},
+			{Name: "angular_velocity_y", Type: "float", Description: "Y component of angular velocity, positive is pitching up, NaN to be ignored."},
+			{Name: "angular_velocity_z", Type: "float", Description: "Z component of angular velocity, positive is yawing to the right, NaN to be ignored."},
+		},
+	},
+	285: {
+		Name:        "GIMBAL_DEVICE_ATTITUDE_STATUS",
+		Description: "Message reporting the status of a gimbal device. This message should be broadcasted by a gimbal device component. The angles encoded in the quaternion are in the global frame (roll: positive is rolling to the right, pitch: positive is pitching up, yaw is turn to the right). This message should be broadcast at a low regular rate (e.g. 10Hz).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "flags", Type: "uint16_t", Enum: "GIMBAL_DEVICE_FLAGS", Description: "Current gimbal flags set."},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "Quaternion components, w, x, y, z (1 0 0 0 is the null-rotation, the frame is depends on whether the flag GIMBAL_DEVICE_FLAGS_YAW_LOCK is set)"},
+			{Name: "angular_velocity_x", Type: "float", Description: "X component of angular velocity (NaN if unknown)"},
+			{Name: "angular_velocity_y", Type: "float", Description: "Y component of angular velocity (NaN if unknown)"},
+			{Name: "angular_velocity_z", Type: "float", Description: "Z component of angular velocity (NaN if unknown)"},
+			{Name: "failure_flags", Type: "uint32_t", Enum: "GIMBAL_DEVICE_ERROR_FLAGS", Description: "Failure flags (0 for no failure)"},
+		},
+	},
+	286: {
+		Name:        "AUTOPILOT_STATE_FOR_GIMBAL_DEVICE",
+		Description: "Low level message containing autopilot state relevant for a gimbal device. This message is to be sent from the gimbal manager to the gimbal device component. The data of this message server for the gimbal's estimator corrections in particular horizon compensation, as well as the autopilot's control intention e.g. feed forward angular control in z-axis.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "time_boot_us", Type: "uint64_t", Description: "Timestamp (time since system boot)."},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "Quaternion components of autopilot attitude: w, x, y, z (1 0 0 0 is the null-rotation, Hamilton convention)."},
+			{Name: "q_estimated_delay_us", Type: "uint32_t", Description: "Estimated delay of the attitude data."},
+			{Name: "vx", Type: "float", Description: "X Speed in NED (North, East, Down)."},
+			{Name: "vy", Type: "float", Description: "Y Speed in NED (North, East, Down)."},
+			{Name: "vz", Type: "float", Description: "Z Speed in NED (North, East, Down)."},
+			{Name: "v_estimated_delay_us", Type: "uint32_t", Description: "Estimated delay of the speed data."},
+			{Name: "feed_forward_angular_velocity_z", Type: "float", Description: "Feed forward Z component of angular velocity, positive is yawing to the right, NaN to be ignored. This is to indicate if the autopilot is actively yawing."},
+			{Name: "estimator_status", Type: "uint16_t", Enum: "ESTIMATOR_STATUS_FLAGS", Description: "Bitmap indicating which estimator outputs are valid."},
+			{Name: "landed_state", Type: "uint8_t", Enum: "MAV_LANDED_STATE", Description: "The landed state. Is set to MAV_LANDED_STATE_UNDEFINED if landed state is unknown."},
+		},
+	},
+	287: {
+		Name:        "GIMBAL_MANAGER_SET_PITCHYAW",
+		Description: "High level message to control a gimbal's pitch and yaw angles. This message is to be sent to the gimbal manager (e.g. from a ground station). Angles and rates can be set to NaN according to use case.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "flags", Type: "uint32_t", Enum: "GIMBAL_MANAGER_FLAGS", Description: "High level gimbal manager flags to use."},
+			{Name: "gimbal_device_id", Type: "uint8_t", Description: "Component ID of gimbal device to address (or 1-6 for non-MAVLink gimbal), 0 for all gimbal device components. Send command multiple times for more than one gimbal (but not all gimbals)."},
+			{Name: "pitch", Type: "float", Description: "Pitch angle (positive: up, negative: down, NaN to be ignored)."},
+			{Name: "yaw", Type: "float", Description: "Yaw angle (positive: to the right, negative: to the left, NaN to be ignored)."},
+			{Name: "pitch_rate", Type: "float", Description: "Pitch angular rate (positive: up, negative: down, NaN to be ignored)."},
+			{Name: "yaw_rate", Type: "float", Description: "Yaw angular rate (positive: to the right, negative: to the left, NaN to be ignored)."},
+		},
+	},
+	288: {
+		Name:        "GIMBAL_MANAGER_SET_MANUAL_CONTROL",
+		Description: "High level message to control a gimbal manually. The angles or angular rates are unitless; the actual rates will depend on internal gimbal manager settings/configuration (e.g. set by parameters). This message is to be sent to the gimbal manager (e.g. from a ground station). Angles and rates can be set to NaN according to use case.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "flags", Type: "uint32_t", Enum: "GIMBAL_MANAGER_FLAGS", Description: "High level gimbal manager flags."},
+			{Name: "gimbal_device_id", Type: "uint8_t", Description: "Component ID of gimbal device to address (or 1-6 for non-MAVLink gimbal), 0 for all gimbal device components. Send command multiple times for more than one gimbal (but not all gimbals)."},
+			{Name: "pitch", Type: "float", Description: "Pitch angle unitless (-1..1, positive: up, negative: down, NaN to be ignored)."},
+			{Name: "yaw", Type: "float", Description: "Yaw angle unitless (-1..1, positive: to the right, negative: to the left, NaN to be ignored)."},
+			{Name: "pitch_rate", Type: "float", Description: "Pitch angular rate unitless (-1..1, positive: up, negative: down, NaN to be ignored)."},
+			{Name: "yaw_rate", Type: "float", Description: "Yaw angular rate unitless (-1..1, positive: to the right, negative: to the left, NaN to be ignored)."},
+		},
+	},
+	290: {
+		Name:        "ESC_INFO",
+		Description: "ESC information for lower rate streaming. Recommended streaming rate 1Hz. See ESC_STATUS for higher-rate ESC data.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "index", Type: "uint8_t", Description: "Index of the first ESC in this message. minValue = 0, maxValue = 60, increment = 4."},
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude the number."},
+			{Name: "counter", Type: "uint16_t", Description: "Counter of data packets received."},
+			{Name: "count", Type: "uint8_t", Description: "Total number of ESCs in all messages of this type. Message fields with an index higher than this should be ignored because they contain invalid data."},
+			{Name: "connection_type", Type: "uint8_t", Enum: "ESC_CONNECTION_TYPE", Description: "Connection type protocol for all ESC."},
+			{Name: "info", Type: "uint8_t", Description: "Information regarding online/offline status of each ESC."},
+			{Name: "failure_flags", Type: "uint16_t", ArrayLength: 4, Enum: "ESC_FAILURE_FLAGS", Description: "Bitmap of ESC failure flags."},
+			{Name: "error_count", Type: "uint32_t", ArrayLength: 4, Description: "Number of reported errors by each ESC since boot."},
+			{Name: "temperature", Type: "uint8_t", ArrayLength: 4, Description: "Temperature measured by each ESC. UINT8_MAX if data not supplied by ESC."},
+		},
+	},
+	291: {
+		Name:        "ESC_STATUS",
+		Description: "ESC information for higher rate streaming. Recommended streaming rate is ~10 Hz. Information that changes more slowly is sent in ESC_INFO. It should typically only be streamed on high-bandwidth links (i.e. to a companion computer).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "index", Type: "uint8_t", Description: "Index of the first ESC in this message. minValue = 0, maxValue = 60, increment = 4."},
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude the number."},
+			{Name: "rpm", Type: "int32_t", ArrayLength: 4, Description: "Reported motor RPM from each ESC (negative for reverse rotation)."},
+			{Name: "voltage", Type: "float", ArrayLength: 4, Description: "Voltage measured from each ESC."},
+			{Name: "current", Type: "float", ArrayLength: 4, Description: "Current measured from each ESC."},
+		},
+	},
+	299: {
+		Name:        "WIFI_CONFIG_AP",
+		Description: "Configure WiFi AP SSID, password, and mode. This message is re-emitted as an acknowledgement by the AP. The message may also be explicitly requested using MAV_CMD_REQUEST_MESSAGE",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "ssid", Type: "char", ArrayLength: 32, Description: "Name of Wi-Fi network (SSID). Blank to leave it unchanged when setting. Current SSID when sent back as a response."},
+			{Name: "password", Type: "char", ArrayLength: 64, Description: "Password. Blank for an open AP. MD5 hash when message is sent back as a response."},
+			{Name: "mode", Type: "int8_t", Enum: "WIFI_CONFIG_AP_MODE", Description: "WiFi Mode.", Extension: true},
+			{Name: "response", Type: "int8_t", Enum: "WIFI_CONFIG_AP_RESPONSE", Description: "Message acceptance response (sent back to GS).", Extension: true},
+		},
+	},
+	301: {
+		Name:        "AIS_VESSEL",
+		Description: "The location and information of an AIS vessel",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "MMSI", Type: "uint32_t", Description: "Mobile Marine Service Identifier, 9 decimal digits"},
+			{Name: "lat", Type: "int32_t", Description: "Latitude"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude"},
+			{Name: "COG", Type: "uint16_t", Description: "Course over ground"},
+			{Name: "heading", Type: "uint16_t", Description: "True heading"},
+			{Name: "velocity", Type: "uint16_t", Description: "Speed over ground"},
+			{Name: "turn_rate", Type: "int8_t", Description: "Turn rate"},
+			{Name: "navigational_status", Type: "uint8_t", Enum: "AIS_NAV_STATUS", Description: "Navigational status"},
+			{Name: "type", Type: "uint8_t", Enum: "AIS_TYPE", Description: "Type of vessels"},
+			{Name: "dimension_bow", Type: "uint16_t", Description: "Distance from lat/lon location to bow"},
+			{Name: "dimension_stern", Type: "uint16_t", Description: "Distance from lat/lon location to stern"},
+			{Name: "dimension_port", Type: "uint8_t", Description: "Distance from lat/lon location to port side"},
+			{Name: "dimension_starboard", Type: "uint8_t", Description: "Distance from lat/lon location to starboard side"},
+			{Name: "callsign", Type: "char", ArrayLength: 7, Description: "The vessel callsign"},
+			{Name: "name", Type: "char", ArrayLength: 20, Description: "The vessel name"},
+			{Name: "tslc", Type: "uint16_t", Description: "Time since last communication in seconds"},
+			{Name: "flags", Type: "uint16_t", Enum: "AIS_FLAGS", Description: "Bitmask to indicate various statuses including valid data fields"},
+		},
+	},
+	310: {
+		Name:        "UAVCAN_NODE_STATUS",
+		Description: "General status information of an UAVCAN node. Please refer to the definition of the UAVCAN message \"uavcan.protocol.NodeStatus\" for the background information. The UAVCAN specification is available at http://uavcan.org.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "uptime_sec", Type: "uint32_t", Description: "Time since the start-up of the node."},
+			{Name: "health", Type: "uint8_t", Enum: "UAVCAN_NODE_HEALTH", Description: "Generalized node health status."},
+			{Name: "mode", Type: "uint8_t", Enum: "UAVCAN_NODE_MODE", Description: "Generalized operating mode."},
+			{Name: "sub_mode", Type: "uint8_t", Description: "Not used currently."},
+			{Name: "vendor_specific_status_code", Type: "uint16_t", Description: "Vendor-specific status information."},
+		},
+	},
+	311: {
+		Name:        "UAVCAN_NODE_INFO",
+		Description: "General information describing a particular UAVCAN node. Please refer to the definition of the UAVCAN service \"uavcan.protocol.GetNodeInfo\" for the background information. This message should be emitted by the system whenever a new node appears online, or an existing node reboots. Additionally, it can be emitted upon request from the other end of the MAVLink channel (see MAV_CMD_UAVCAN_GET_NODE_INFO). It is also not prohibited to emit this message unconditionally at a low frequency. The UAVCAN specification is available at http://uavcan.org.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "uptime_sec", Type: "uint32_t", Description: "Time since the start-up of the node."},
+			{Name: "name", Type: "char", ArrayLength: 80, Description: "Node name string. For example, \"sapog.px4.io\"."},
+			{Name: "hw_version_major", Type: "uint8_t", Description: "Hardware major version number."},
+			{Name: "hw_version_minor", Type: "uint8_t", Description: "Hardware minor version number."},
+			{Name: "hw_unique_id", Type: "uint8_t", ArrayLength: 16, Description: "Hardware unique 128-bit ID."},
+			{Name: "sw_version_major", Type: "uint8_t", Description: "Software major version number."},
+			{Name: "sw_version_minor", Type: "uint8_t", Description: "Software minor version number."},
+			{Name: "sw_vcs_commit", Type: "uint32_t", Description: "Version control system (VCS) revision identifier (e.g. git short commit hash). 0 if unknown."},
+		},
+	},
+	320: {
+		Name:        "PARAM_EXT_REQUEST_READ",
+		Description: "Request to read the value of a parameter with either the param_id string id or param_index. PARAM_EXT_VALUE should be emitted in response.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "param_id", Type: "char", ArrayLength: 16, Description: "Parameter id, terminated by NULL if the length is less than 16 human-readable chars and WITHOUT null termination (NULL) byte if the length is exactly 16 chars - applications have to provide 16+1 bytes storage if the ID is stored as string"},
+			{Name: "param_index", Type: "int16_t", Description: "Parameter index. Set to -1 to use the Parameter ID field as identifier (else param_id will be ignored)"},
+		},
+	},
+	321: {
+		Name:        "PARAM_EXT_REQUEST_LIST",
+		Description: "Request all parameters of this component. All parameters should be emitted in response as PARAM_EXT_VALUE.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+		},
+	},
+	322: {
+		Name:        "PARAM_EXT_VALUE",
+		Description: "Emit the value of a parameter. The inclusion of param_count and param_index in the message allows the recipient to keep track of received parameters and allows them to re-request missing parameters after a loss or timeout.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "param_id", Type: "char", ArrayLength: 16, Description: "Parameter id, terminated by NULL if the length is less than 16 human-readable chars and WITHOUT null termination (NULL) byte if the length is exactly 16 chars - applications have to provide 16+1 bytes storage if the ID is stored as string"},
+			{Name: "param_value", Type: "char", ArrayLength: 128, Description: "Parameter value"},
+			{Name: "param_type", Type: "uint8_t", Enum: "MAV_PARAM_EXT_TYPE", Description: "Parameter type."},
+			{Name: "param_count", Type: "uint16_t", Description: "Total number of parameters"},
+			{Name: "param_index", Type: "uint16_t", Description: "Index of this parameter"},
+		},
+	},
+	323: {
+		Name:        "PARAM_EXT_SET",
+		Description: "Set a parameter value. In order to deal with message loss (and retransmission of PARAM_EXT_SET), when setting a parameter value and the new value is the same as the current value, you will immediately get a PARAM_ACK_ACCEPTED response. If the current state is PARAM_ACK_IN_PROGRESS, you will accordingly receive a PARAM_ACK_IN_PROGRESS in response.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "param_id", Type: "char", ArrayLength: 16, Description: "Parameter id, terminated by NULL if the length is less than 16 human-readable chars and WITHOUT null termination (NULL) byte if the length is exactly 16 chars - applications have to provide 16+1 bytes storage if the ID is stored as string"},
+			{Name: "param_value", Type: "char", ArrayLength: 128, Description: "Parameter value"},
+			{Name: "param_type", Type: "uint8_t", Enum: "MAV_PARAM_EXT_TYPE", Description: "Parameter type."},
+		},
+	},
+	324: {
+		Name:        "PARAM_EXT_ACK",
+		Description: "Response from a PARAM_EXT_SET message.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "param_id", Type: "char", ArrayLength: 16, Description: "Parameter id, terminated by NULL if the length is less than 16 human-readable chars and WITHOUT null termination (NULL) byte if the length is exactly 16 chars - applications have to provide 16+1 bytes storage if the ID is stored as string"},
+			{Name: "param_value", Type: "char", ArrayLength: 128, Description: "Parameter value (new value if PARAM_ACK_ACCEPTED, current value otherwise)"},
+			{Name: "param_type", Type: "uint8_t", Enum: "MAV_PARAM_EXT_TYPE", Description: "Parameter type."},
+			{Name: "param_result", Type: "uint8_t", Enum: "PARAM_ACK", Description: "Result code."},
+		},
+	},
+	330: {
+		Name:        "OBSTACLE_DISTANCE",
+		Description: "Obstacle distances in front of the sensor, starting from the left in increment degrees to the right",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "sensor_type", Type: "uint8_t", Enum: "MAV_DISTANCE_SENSOR", Description: "Class id of the distance sensor type."},
+			{Name: "distances", Type: "uint16_t", ArrayLength: 72, Description: "Distance of obstacles around the vehicle with index 0 corresponding to north + angle_offset, unless otherwise specified in the frame. A value of 0 is valid and means that the obstacle is practically touching the sensor. A value of max_distance +1 means no obstacle is present. A value of UINT16_MAX for unknown/not used. In a array element, one unit corresponds to 1cm."},
+			{Name: "increment", Type: "uint8_t", Description: "Angular width in degrees of each array element. Increment direction is clockwise. This field is ignored if increment_f is non-zero."},
+			{Name: "min_distance", Type: "uint16_t", Description: "Minimum distance the sensor can measure."},
+			{Name: "max_distance", Type: "uint16_t", Description: "Maximum distance the sensor can measure."},
+			{Name: "increment_f", Type: "float", Description: "Angular width in degrees of each array element as a float. If non-zero then this value is used instead of the uint8_t increment field. Positive is clockwise direction, negative is counter-clockwise.", Extension: true},
+			{Name: "angle_offset", Type: "float", Description: "Relative angle offset of the 0-index element in the distances array. Value of 0 corresponds to forward. Positive is clockwise direction, negative is counter-clockwise.", Extension: true},
+			{Name: "frame", Type: "uint8_t", Enum: "MAV_FRAME", Description: "Coordinate frame of reference for the yaw rotation and offset of the sensor data. Defaults to MAV_FRAME_GLOBAL, which is north aligned. For body-mounted sensors use MAV_FRAME_BODY_FRD, which is vehicle front aligned.", Extension: true},
+		},
+	},
+	331: {
+		Name:        "ODOMETRY",
+		Description: "Odometry message to communicate odometry information with an external interface. Fits ROS REP 147 standard for aerial vehicles (http://www.ros.org/reps/rep-0147.html).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "frame_id", Type: "uint8_t", Enum: "MAV_FRAME", Description: "Coordinate frame of reference for the pose data."},
+			{Name: "child_frame_id", Type: "uint8_t", Enum: "MAV_FRAME", Description: "Coordinate frame of reference for the velocity in free space (twist) data."},
+			{Name: "x", Type: "float", Description: "X Position"},
+			{Name: "y", Type: "float", Description: "Y Position"},
+			{Name: "z", Type: "float", Description: "Z Position"},
+			{Name: "q", Type: "float", ArrayLength: 4, Description: "Quaternion components, w, x, y, z (1 0 0 0 is the null-rotation)"},
+			{Name: "vx", Type: "float", Description: "X linear speed"},
+			{Name: "vy", Type: "float", Description: "Y linear speed"},
+			{Name: "vz", Type: "float", Description: "Z linear speed"},
+			{Name: "rollspeed", Type: "float", Description: "Roll angular speed"},
+			{Name: "pitchspeed", Type: "float", Description: "Pitch angular speed"},
+			{Name: "yawspeed", Type: "float", Description: "Yaw angular speed"},
+			{Name: "pose_covariance", Type: "float", ArrayLength: 21, Description: "Row-major representation of a 6x6 pose cross-covariance matrix upper right triangle (states: x, y, z, roll, pitch, yaw; first six entries are the first ROW, next five entries are the second ROW, etc.). If unknown, assign NaN value to first element in the array."},
+			{Name: "velocity_covariance", Type: "float", ArrayLength: 21, Description: "Row-major representation of a 6x6 velocity cross-covariance matrix upper right triangle (states: vx, vy, vz, rollspeed, pitchspeed, yawspeed; first six entries are the first ROW, next five entries are the second ROW, etc.). If unknown, assign NaN value to first element in the array."},
+			{Name: "reset_counter", Type: "uint8_t", Description: "Estimate reset counter. This should be incremented when the estimate resets in any of the dimensions (position, velocity, attitude, angular speed). This is designed to be used when e.g an external SLAM system detects a loop-closure and the estimate jumps.", Extension: true},
+			{Name: "estimator_type", Type: "uint8_t", Enum: "MAV_ESTIMATOR_TYPE", Description: "Type of estimator that is providing the odometry.", Extension: true},
+		},
+	},
+	332: {
+		Name:        "TRAJECTORY_REPRESENTATION_WAYPOINTS",
+		Description: "Describe a trajectory using an array of up-to 5 waypoints in the local frame (MAV_FRAME_LOCAL_NED).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "valid_points", Type: "uint8_t", Description: "Number of valid points (up-to 5 waypoints are possible)"},
+			{Name: "pos_x", Type: "float", ArrayLength: 5, Description: "X-coordinate of waypoint, set to NaN if not being used"},
+			{Name: "pos_y", Type: "float", ArrayLength: 5, Description: "Y-coordinate of waypoint, set to NaN if not being used"},
+			{Name: "pos_z", Type: "float", ArrayLength: 5, Description: "Z-coordinate of waypoint, set to NaN if not being used"},
+			{Name: "vel_x", Type: "float", ArrayLength: 5, Description: "X-velocity of waypoint, set to NaN if not being used"},
+			{Name: "vel_y", Type: "float", ArrayLength: 5, Description: "Y-velocity of waypoint, set to NaN if not being used"},
+			{Name: "vel_z", Type: "float", ArrayLength: 5, Description: "Z-velocity of waypoint, set to NaN if not being used"},
+			{Name: "acc_x", Type: "float", ArrayLength: 5, Description: "X-acceleration of waypoint, set to NaN if not being used"},
+			{Name: "acc_y", Type: "float", ArrayLength: 5, Description: "Y-acceleration of waypoint, set to NaN if not being used"},
+			{Name: "acc_z", Type: "float", ArrayLength: 5, Description: "Z-acceleration of waypoint, set to NaN if not being used"},
+			{Name: "pos_yaw", Type: "float", ArrayLength: 5, Description: "Yaw angle, set to NaN if not being used"},
+			{Name: "vel_yaw", Type: "float", ArrayLength: 5, Description: "Yaw rate, set to NaN if not being used"},
+			{Name: "command", Type: "uint16_t", ArrayLength: 5, Enum: "MAV_CMD", Description: "Scheduled action for each waypoint, UINT16_MAX if not being used."},
+		},
+	},
+	333: {
+		Name:        "TRAJECTORY_REPRESENTATION_BEZIER",
+		Description: "Describe a trajectory using an array of up-to 5 bezier control points in the local frame (MAV_FRAME_LOCAL_NED).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "valid_points", Type: "uint8_t", Description: "Number of valid control points (up-to 5 points are possible)"},
+			{Name: "pos_x", Type: "float", ArrayLength: 5, Description: "X-coordinate of bezier control points. Set to NaN if not being used"},
+			{Name: "pos_y", Type: "float", ArrayLength: 5, Description: "Y-coordinate of bezier control points. Set to NaN if not being used"},
+			{Name: "pos_z", Type: "float", ArrayLength: 5, Description: "Z-coordinate of bezier control points. Set to NaN if not being used"},
+			{Name: "delta", Type: "float", ArrayLength: 5, Description: "Bezier time horizon. Set to NaN if velocity/acceleration should not be incorporated"},
+			{Name: "pos_yaw", Type: "float", ArrayLength: 5, Description: "Yaw. Set to NaN for unchanged"},
+		},
+	},
+	334: {
+		Name:        "CELLULAR_STATUS",
+		Description: "Report current used cellular network status",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "status", Type: "uint8_t", Enum: "CELLULAR_STATUS_FLAG", Description: "Cellular modem status"},
+			{Name: "failure_reason", Type: "uint8_t", Enum: "CELLULAR_NETWORK_FAILED_REASON", Description: "Failure reason when status in in CELLUAR_STATUS_FAILED"},
+			{Name: "type", Type: "uint8_t", Enum: "CELLULAR_NETWORK_RADIO_TYPE", Description: "Cellular network radio type: gsm, cdma, lte..."},
+			{Name: "quality", Type: "uint8_t", Description: "Signal quality in percent. If unknown, set to UINT8_MAX"},
+			{Name: "mcc", Type: "uint16_t", Description: "Mobile country code. If unknown, set to UINT16_MAX"},
+			{Name: "mnc", Type: "uint16_t", Description: "Mobile network code. If unknown, set to UINT16_MAX"},
+			{Name: "lac", Type: "uint16_t", Description: "Location area code. If unknown, set to 0"},
+		},
+	},
+	335: {
+		Name:        "ISBD_LINK_STATUS",
+		Description: "Status of the Iridium SBD link.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "timestamp", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "last_heartbeat", Type: "uint64_t", Description: "Timestamp of the last successful sbd session. The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "failed_sessions", Type: "uint16_t", Description: "Number of failed SBD sessions."},
+			{Name: "successful_sessions", Type: "uint16_t", Description: "Number of successful SBD sessions."},
+			{Name: "signal_quality", Type: "uint8_t", Description: "Signal quality equal to the number of bars displayed on the ISU signal strength indicator. Range is 0 to 5, where 0 indicates no signal and 5 indicates maximum signal strength."},
+			{Name: "ring_pending", Type: "uint8_t", Description: "1: Ring call pending, 0: No call pending."},
+			{Name: "tx_session_pending", Type: "uint8_t", Description: "1: Transmission session pending, 0: No transmission session pending."},
+			{Name: "rx_session_pending", Type: "uint8_t", Description: "1: Receiving session pending, 0: No receiving session pending."},
+		},
+	},
+	336: {
+		Name:        "CELLULAR_CONFIG",
+		Description: "Configure cellular modems. This message is re-emitted as an acknowledgement by the modem. The message may also be explicitly requested using MAV_CMD_REQUEST_MESSAGE.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "enable_lte", Type: "uint8_t", Description: "Enable/disable LTE. 0: setting unchanged, 1: disabled, 2: enabled. Current setting when sent back as a response."},
+			{Name: "enable_pin", Type: "uint8_t", Description: "Enable/disable PIN on the SIM card. 0: setting unchanged, 1: disabled, 2: enabled. Current setting when sent back as a response."},
+			{Name: "pin", Type: "char", ArrayLength: 16, Description: "PIN sent to the SIM card. Blank when PIN is disabled. Empty when message is sent back as a response."},
+			{Name: "new_pin", Type: "char", ArrayLength: 16, Description: "New PIN when changing the PIN. Blank to leave it unchanged. Empty when message is sent back as a response."},
+			{Name: "apn", Type: "char", ArrayLength: 32, Description: "Name of the cellular APN. Blank to leave it unchanged. Current APN when sent back as a response."},
+			{Name: "puk", Type: "char", ArrayLength: 16, Description: "Required PUK code in case the user failed to authenticate 3 times with the PIN. Empty when message is sent back as a response."},
+			{Name: "roaming", Type: "uint8_t", Description: "Enable/disable roaming. 0: setting unchanged, 1: disabled, 2: enabled. Current setting when sent back as a response."},
+			{Name: "response", Type: "uint8_t", Enum: "CELLULAR_CONFIG_RESPONSE", Description: "Message acceptance response (sent back to GS)."},
+		},
+	},
+	339: {
+		Name:        "RAW_RPM",
+		Description: "RPM sensor data message.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "index", Type: "uint8_t", Description: "Index of this RPM sensor (0-indexed)"},
+			{Name: "frequency", Type: "float", Description: "Indicated rate"},
+		},
+	},
+	340: {
+		Name:        "UTM_GLOBAL_POSITION",
+		Description: "The global position resulting from GPS and sensor fusion.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time", Type: "uint64_t", Description: "Time of applicability of position (microseconds since UNIX epoch)."},
+			{Name: "uas_id", Type: "uint8_t", ArrayLength: 18, Description: "Unique UAS ID."},
+			{Name: "lat", Type: "int32_t", Description: "Latitude (WGS84)"},
+			{Name: "lon", Type: "int32_t", Description: "Longitude (WGS84)"},
+			{Name: "alt", Type: "int32_t", Description: "Altitude (WGS84)"},
+			{Name: "relative_alt", Type: "int32_t", Description: "Altitude above ground"},
+			{Name: "vx", Type: "int16_t", Description: "Ground X speed (latitude, positive north)"},
+			{Name: "vy", Type: "int16_t", Description: "Ground Y speed (longitude, positive east)"},
+			{Name: "vz", Type: "int16_t", Description: "Ground Z speed (altitude, positive down)"},
+			{Name: "h_acc", Type: "uint16_t", Description: "Horizontal position uncertainty (standard deviation)"},
+			{Name: "v_acc", Type: "uint16_t", Description: "Altitude uncertainty (standard deviation)"},
+			{Name: "vel_acc", Type: "uint16_t", Description: "Speed uncertainty (standard deviation)"},
+			{Name: "next_lat", Type: "int32_t", Description: "Next waypoint, latitude (WGS84)"},
+			{Name: "next_lon", Type: "int32_t", Description: "Next waypoint, longitude (WGS84)"},
+			{Name: "next_alt", Type: "int32_t", Description: "Next waypoint, altitude (WGS84)"},
+			{Name: "update_rate", Type: "uint16_t", Description: "Time until next update. Set to 0 if unknown or in data driven mode."},
+			{Name: "flight_state", Type: "uint8_t", Enum: "UTM_FLIGHT_STATE", Description: "Flight state"},
+			{Name: "flags", Type: "uint8_t", Enum: "UTM_DATA_AVAIL_FLAGS", Description: "Bitwise OR combination of the data available flags."},
+		},
+	},
+	350: {
+		Name:        "DEBUG_FLOAT_ARRAY",
+		Description: "Large debug/prototyping array. The message uses the maximum available payload for data. The array_id and name fields are used to discriminate between messages in code and in user interfaces (respectively). Do not use in production code.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "name", Type: "char", ArrayLength: 10, Description: "Name, for human-friendly display in a Ground Control Station"},
+			{Name: "array_id", Type: "uint16_t", Description: "Unique ID used to discriminate between arrays"},
+			{Name: "data", Type: "float", ArrayLength: 58, Description: "data", Extension: true},
+		},
+	},
+	360: {
+		Name:        "ORBIT_EXECUTION_STATUS",
+		Description: "Vehicle status report that is sent out while orbit execution is in progress (see MAV_CMD_DO_ORBIT).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "radius", Type: "float", Description: "Radius of the orbit circle. Positive values orbit clockwise, negative values orbit counter-clockwise."},
+			{Name: "frame", Type: "uint8_t", Enum: "MAV_FRAME", Description: "The coordinate system of the fields: x, y, z."},
+			{Name: "x", Type: "int32_t", Description: "X coordinate of center point. Coordinate system depends on frame field: local = x position in meters * 1e4, global = latitude in degrees * 1e7."},
+			{Name: "y", Type: "int32_t", Description: "Y coordinate of center point.  Coordinate system depends on frame field: local = x position in meters * 1e4, global = latitude in degrees * 1e7."},
+			{Name: "z", Type: "float", Description: "Altitude of center point. Coordinate system depends on frame field."},
+		},
+	},
+	370: {
+		Name:        "SMART_BATTERY_INFO",
+		Description: "Smart Battery information (static/infrequent update). Use for updates from: smart battery to flight stack, flight stack to GCS. Use BATTERY_STATUS for smart battery frequent updates.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "id", Type: "uint8_t", Description: "Battery ID"},
+			{Name: "battery_function", Type: "uint8_t", Enum: "MAV_BATTERY_FUNCTION", Description: "Function of the battery"},
+			{Name: "type", Type: "uint8_t", Enum: "MAV_BATTERY_TYPE", Description: "Type (chemistry) of the battery"},
+			{Name: "capacity_full_specification", Type: "int32_t", Description: "Capacity when full according to manufacturer, -1: field not provided."},
+			{Name: "capacity_full", Type: "int32_t", Description: "Capacity when full (accounting for battery degradation), -1: field not provided."},
+			{Name: "cycle_count", Type: "uint16_t", Description: "Charge/discharge cycle count. UINT16_MAX: field not provided."},
+			{Name: "serial_number", Type: "char", ArrayLength: 16, Description: "Serial number in ASCII characters, 0 terminated. All 0: field not provided."},
+			{Name: "device_name", Type: "char", ArrayLength: 50, Description: "Static device name in ASCII characters, 0 terminated. All 0: field not provided. Encode as manufacturer name then product name separated using an underscore."},
+			{Name: "weight", Type: "uint16_t", Description: "Battery weight. 0: field not provided."},
+			{Name: "discharge_minimum_voltage", Type: "uint16_t", Description: "Minimum per-cell voltage when discharging. If not supplied set to UINT16_MAX value."},
+			{Name: "charging_minimum_voltage", Type: "uint16_t", Description: "Minimum per-cell voltage when charging. If not supplied set to UINT16_MAX value."},
+			{Name: "resting_minimum_voltage", Type: "uint16_t", Description: "Minimum per-cell voltage when resting. If not supplied set to UINT16_MAX value."},
+			{Name: "charging_maximum_voltage", Type: "uint16_t", Description: "Maximum per-cell voltage when charged. 0: field not provided.", Extension: true},
+			{Name: "cells_in_series", Type: "uint8_t", Description: "Number of battery cells in series. 0: field not provided.", Extension: true},
+			{Name: "discharge_maximum_current", Type: "uint32_t", Description: "Maximum pack discharge current. 0: field not provided.", Extension: true},
+			{Name: "discharge_maximum_burst_current", Type: "uint32_t", Description: "Maximum pack discharge burst current. 0: field not provided.", Extension: true},
+			{Name: "manufacture_date", Type: "char", ArrayLength: 11, Description: "Manufacture date (DD/MM/YYYY) in ASCII characters, 0 terminated. All 0: field not provided.", Extension: true},
+		},
+	},
+	373: {
+		Name:        "GENERATOR_STATUS",
+		Description: "Telemetry of power generation system. Alternator or mechanical generator.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "status", Type: "uint64_t", Enum: "MAV_GENERATOR_STATUS_FLAG", Description: "Status flags."},
+			{Name: "generator_speed", Type: "uint16_t", Description: "Speed of electrical generator or alternator. UINT16_MAX: field not provided."},
+			{Name: "battery_current", Type: "float", Description: "Current into/out of battery. Positive for out. Negative for in. NaN: field not provided."},
+			{Name: "load_current", Type: "float", Description: "Current going to the UAV. If battery current not available this is the DC current from the generator. Positive for out. Negative for in. NaN: field not provided"},
+			{Name: "power_generated", Type: "float", Description: "The power being generated. NaN: field not provided"},
+			{Name: "bus_voltage", Type: "float", Description: "Voltage of the bus seen at the generator, or battery bus if battery bus is controlled by generator and at a different voltage to main bus."},
+			{Name: "rectifier_temperature", Type: "int16_t", Description: "The temperature of the rectifier or power converter. INT16_MAX: field not provided."},
+			{Name: "bat_current_setpoint", Type: "float", Description: "The target battery current. Positive for out. Negative for in. NaN: field not provided"},
+			{Name: "generator_temperature", Type: "int16_t", Description: "The temperature of the mechanical motor, fuel cell core or generator. INT16_MAX: field not provided."},
+			{Name: "runtime", Type: "uint32_t", Description: "Seconds this generator has run since it was rebooted. UINT32_MAX: field not provided."},
+			{Name: "time_until_maintenance", Type: "int32_t", Description: "Seconds until this generator requires maintenance.  A negative value indicates maintenance is past-due. INT32_MAX: field not provided."},
+		},
+	},
+	375: {
+		Name:        "ACTUATOR_OUTPUT_STATUS",
+		Description: "The raw values of the actuator outputs (e.g. on Pixhawk, from MAIN, AUX ports). This message supersedes SERVO_OUTPUT_RAW.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (since system boot)."},
+			{Name: "active", Type: "uint32_t", Description: "Active outputs"},
+			{Name: "actuator", Type: "float", ArrayLength: 32, Description: "Servo / motor output array values. Zero values indicate unused channels."},
+		},
+	},
+	380: {
+		Name:        "TIME_ESTIMATE_TO_TARGET",
+		Description: "Time/duration estimates for various events and actions given the current vehicle state and position.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "safe_return", Type: "int32_t", Description: "Estimated time to complete the vehicle's configured \"safe return\" action from its current position (e.g. RTL, Smart RTL, etc.). -1 indicates that the vehicle is landed, or that no time estimate available."},
+			{Name: "land", Type: "int32_t", Description: "Estimated time for vehicle to complete the LAND action from its current position. -1 indicates that the vehicle is landed, or that no time estimate available."},
+			{Name: "mission_next_item", Type: "int32_t", Description: "Estimated time for reaching/completing the currently active mission item. -1 means no time estimate available."},
+			{Name: "mission_end", Type: "int32_t", Description: "Estimated time for completing the current mission. -1 means no mission active and/or no estimate available."},
+			{Name: "commanded_action", Type: "int32_t", Description: "Estimated time for completing the current commanded action (i.e. Go To, Takeoff, Land, etc.). -1 means no action active and/or no estimate available."},
+		},
+	},
+	385: {
+		Name:        "TUNNEL",
+		Description: "Message for transporting \"arbitrary\" variable-length data from one component to another (broadcast is not forbidden, but discouraged). The encoding of the data is usually extension specific, i.e. determined by the source, and is usually not documented as part of the MAVLink specification.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID (can be 0 for broadcast, but this is discouraged)"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID (can be 0 for broadcast, but this is discouraged)"},
+			{Name: "payload_type", Type: "uint16_t", Enum: "MAV_TUNNEL_PAYLOAD_TYPE", Description: "A code that identifies the content of the payload (0 for unknown, which is the default). If this code is less than 32768, it is a 'registered' payload type and the corresponding code should be added to the MAV_TUNNEL_PAYLOAD_TYPE enum. Software creators can register blocks of types as needed. Codes greater than 32767 are considered local experiments and should not be checked in to any widely distributed codebase."},
+			{Name: "payload_length", Type: "uint8_t", Description: "Length of the data transported in payload"},
+			{Name: "payload", Type: "uint8_t", ArrayLength: 128, Description: "Variable length payload. The payload length is defined by payload_length. The entire content of this block is opaque unless you understand the encoding specified by payload_type."},
+		},
+	},
+	390: {
+		Name:        "ONBOARD_COMPUTER_STATUS",
+		Description: "Hardware status sent by an onboard computer.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (UNIX Epoch time or time since system boot). The receiving end can infer timestamp format (since 1.1.1970 or since system boot) by checking for the magnitude of the number."},
+			{Name: "uptime", Type: "uint32_t", Description: "Time since system boot."},
+			{Name: "type", Type: "uint8_t", Description: "Type of the onboard computer: 0: Mission computer primary, 1: Mission computer backup 1, 2: Mission computer backup 2, 3: Compute node, 4-5: Compute spares, 6-9: Payload computers."},
+			{Name: "cpu_cores", Type: "uint8_t", ArrayLength: 8, Description: "CPU usage on the component in percent (100 - idle). A value of UINT8_MAX implies the field is unused."},
+			{Name: "cpu_combined", Type: "uint8_t", ArrayLength: 10, Description: "Combined CPU usage as the last 10 slices of 100 MS (a histogram). This allows to identify spikes in load that max out the system, but only for a short amount of time. A value of UINT8_MAX implies the field is unused."},
+			{Name: "gpu_cores", Type: "uint8_t", ArrayLength: 4, Description: "GPU usage on the component in percent (100 - idle). A value of UINT8_MAX implies the field is unused."},
+			{Name: "gpu_combined", Type: "uint8_t", ArrayLength: 10, Description: "Combined GPU usage as the last 10 slices of 100 MS (a histogram). This allows to identify spikes in load that max out the system, but only for a short amount of time. A value of UINT8_MAX implies the field is unused."},
+			{Name: "temperature_board", Type: "int8_t", Description: "Temperature of the board. A value of INT8_MAX implies the field is unused."},
+			{Name: "temperature_core", Type: "int8_t", ArrayLength: 8, Description: "Temperature of the CPU core. A value of INT8_MAX implies the field is unused."},
+			{Name: "fan_speed", Type: "int16_t", ArrayLength: 4, Description: "Fan speeds. A value of INT16_MAX implies the field is unused."},
+			{Name: "ram_usage", Type: "uint32_t", Description: "Amount of used RAM on the component system. A value of UINT32_MAX implies the field is unused."},
+			{Name: "ram_total", Type: "uint32_t", Description: "Total amount of RAM on the component system. A value of UINT32_MAX implies the field is unused."},
+			{Name: "storage_type", Type: "uint32_t", ArrayLength: 4, Description: "Storage type: 0: HDD, 1: SSD, 2: EMMC, 3: SD card (non-removable), 4: SD card (removable). A value of UINT32_MAX implies the field is unused."},
+			{Name: "storage_usage", Type: "uint32_t", ArrayLength: 4, Description: "Amount of used storage space on the component system. A value of UINT32_MAX implies the field is unused."},
+			{Name: "storage_total", Type: "uint32_t", ArrayLength: 4, Description: "Total amount of storage space on the component system. A value of UINT32_MAX implies the field is unused."},
+			{Name: "link_type", Type: "uint32_t", ArrayLength: 6, Description: "Link type: 0-9: UART, 10-19: Wired network, 20-29: Wifi, 30-39: Point-to-point proprietary, 40-49: Mesh proprietary"},
+			{Name: "link_tx_rate", Type: "uint32_t", ArrayLength: 6, Description: "Network traffic from the component system. A value of UINT32_MAX implies the field is unused."},
+			{Name: "link_rx_rate", Type: "uint32_t", ArrayLength: 6, Description: "Network traffic to the component system. A value of UINT32_MAX implies the field is unused."},
+			{Name: "link_tx_max", Type: "uint32_t", ArrayLength: 6, Description: "Network capacity from the component system. A value of UINT32_MAX implies the field is unused."},
+			{Name: "link_rx_max", Type: "uint32_t", ArrayLength: 6, Description: "Network capacity to the component system. A value of UINT32_MAX implies the field is unused."},
+		},
+	},
+	395: {
+		Name:        "COMPONENT_INFORMATION",
+		Description: "Information about a component. For camera components instead use CAMERA_INFORMATION, and for autopilots additionally use AUTOPILOT_VERSION. Components including GCSes should consider supporting requests of this message via MAV_CMD_REQUEST_MESSAGE.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot)."},
+			{Name: "general_metadata_file_crc", Type: "uint32_t", Description: "CRC32 of the TYPE_GENERAL file (can be used by a GCS for file caching)."},
+			{Name: "general_metadata_uri", Type: "char", ArrayLength: 100, Description: "Component definition URI for TYPE_GENERAL. This must be a MAVLink FTP URI and the file might be compressed with xz."},
+			{Name: "peripherals_metadata_file_crc", Type: "uint32_t", Description: "CRC32 of the TYPE_PERIPHERALS file (can be used by a GCS for file caching)."},
+			{Name: "peripherals_metadata_uri", Type: "char", ArrayLength: 100, Description: "(Optional) Component definition URI for TYPE_PERIPHERALS. This must be a MAVLink FTP URI and the file might be compressed with xz."},
+		},
+	},
+	400: {
+		Name:        "PLAY_TUNE_V2",
+		Description: "Play vehicle tone/tune (buzzer). Supersedes message PLAY_TUNE.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "format", Type: "uint32_t", Enum: "TUNE_FORMAT", Description: "Tune format"},
+			{Name: "tune", Type: "char", ArrayLength: 248, Description: "Tune definition as a NULL-terminated string."},
+		},
+	},
+	401: {
+		Name:        "SUPPORTED_TUNES",
+		Description: "Tune formats supported by vehicle. This should be emitted as response to MAV_CMD_REQUEST_MESSAGE.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "format", Type: "uint32_t", Enum: "TUNE_FORMAT", Description: "Bitfield of supported tune formats."},
+		},
+	},
+	410: {
+		Name:        "EVENT",
+		Description: "Event message. Each new event from a particular component gets a new sequence number. The same message might be sent multiple times if (re-)requested. Most events are broadcast, some can be specific to a target component (as receivers keep track of the sequence for missed events, all events need to be broadcast. Thus we use destination_component instead of target_component).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "destination_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "destination_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "id", Type: "uint32_t", Description: "Event ID (as defined in the component metadata)"},
+			{Name: "event_time_boot_ms", Type: "uint32_t", Description: "Timestamp (time since system boot when the event happened)."},
+			{Name: "sequence", Type: "uint16_t", Description: "Sequence number."},
+			{Name: "log_levels", Type: "uint8_t", Description: "Log levels: 4 bits MSB: internal (for logging purposes), 4 bits LSB: external. Levels: Emergency = 0, Alert = 1, Critical = 2, Error = 3, Warning = 4, Notice = 5, Info = 6, Debug = 7, Protocol = 8, Disabled = 9"},
+			{Name: "arguments", Type: "uint8_t", ArrayLength: 40, Description: "Arguments (depend on event ID)."},
+		},
+	},
+	411: {
+		Name:        "CURRENT_EVENT_SEQUENCE",
+		Description: "Regular broadcast for the current latest event sequence number for a component. This is used to check for dropped events.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "sequence", Type: "uint16_t", Description: "Sequence number."},
+			{Name: "flags", Type: "uint8_t", Enum: "MAV_EVENT_CURRENT_SEQUENCE_FLAGS", Description: "Flag bitset."},
+		},
+	},
+	412: {
+		Name:        "REQUEST_EVENT",
+		Description: "Request one or more events to be (re-)sent. If first_sequence==last_sequence, only a single event is requested. Note that first_sequence can be larger than last_sequence (because the sequence number can wrap). Each sequence will trigger an EVENT or EVENT_ERROR response.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "first_sequence", Type: "uint16_t", Description: "First sequence number of the requested event."},
+			{Name: "last_sequence", Type: "uint16_t", Description: "Last sequence number of the requested event."},
+		},
+	},
+	413: {
+		Name:        "RESPONSE_EVENT_ERROR",
+		Description: "Response to a REQUEST_EVENT in case of an error (e.g. the event is not available anymore).",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID"},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID"},
+			{Name: "sequence", Type: "uint16_t", Description: "Sequence number."},
+			{Name: "sequence_oldest_available", Type: "uint16_t", Description: "Oldest Sequence number that is still available after the sequence set in REQUEST_EVENT."},
+			{Name: "reason", Type: "uint8_t", Enum: "MAV_EVENT_ERROR_REASON", Description: "Error reason."},
+		},
+	},
+	9000: {
+		Name:        "WHEEL_DISTANCE",
+		Description: "Cumulative distance traveled for each reported wheel.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (synced to UNIX time or since system boot)."},
+			{Name: "count", Type: "uint8_t", Description: "Number of wheels reported."},
+			{Name: "distance", Type: "double", ArrayLength: 16, Description: "Distance reported by individual wheel encoders. Forward rotations increase values, reverse rotations decrease them. Not all wheels will necessarily have wheel encoders; the mapping of encoders to wheel positions must be agreed/understood by the endpoints."},
+		},
+	},
+	9005: {
+		Name:        "WINCH_STATUS",
+		Description: "Winch status.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "time_usec", Type: "uint64_t", Description: "Timestamp (synced to UNIX time or since system boot)."},
+			{Name: "line_length", Type: "float", Description: "Length of line released. NaN if unknown"},
+			{Name: "speed", Type: "float", Description: "Speed line is being released or retracted. Positive values if being released, negative values if being retracted, NaN if unknown"},
+			{Name: "tension", Type: "float", Description: "Tension on the line. NaN if unknown"},
+			{Name: "voltage", Type: "float", Description: "Voltage of the battery supplying the winch. NaN if unknown"},
+			{Name: "current", Type: "float", Description: "Current draw from the winch. NaN if unknown"},
+			{Name: "temperature", Type: "int16_t", Description: "Temperature of the motor. INT16_MAX if unknown"},
+			{Name: "status", Type: "uint32_t", Enum: "MAV_WINCH_STATUS_FLAG", Description: "Status flags"},
+		},
+	},
+	12900: {
+		Name:        "OPEN_DRONE_ID_BASIC_ID",
+		Description: "Data for filling the OpenDroneID Basic ID message. This and the below messages are primarily meant for feeding data to/from an OpenDroneID implementation. E.g. https://github.com/opendroneid/opendroneid-core-c. These messages are compatible with the ASTM Remote ID standard at https://www.astm.org/Standards/F3411.htm and the ASD-STAN Direct Remote ID standard. The usage of these messages is documented at https://mavlink.io/en/services/opendroneid.html.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID (0 for broadcast)."},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID (0 for broadcast)."},
+			{Name: "id_or_mac", Type: "uint8_t", ArrayLength: 20, Description: "Only used for drone ID data received from other UAs. See detailed description at https://mavlink.io/en/services/opendroneid.html."},
+			{Name: "id_type", Type: "uint8_t", Enum: "MAV_ODID_ID_TYPE", Description: "Indicates the format for the uas_id field of this message."},
+			{Name: "ua_type", Type: "uint8_t", Enum: "MAV_ODID_UA_TYPE", Description: "Indicates the type of UA (Unmanned Aircraft)."},
+			{Name: "uas_id", Type: "uint8_t", ArrayLength: 20, Description: "UAS (Unmanned Aircraft System) ID following the format specified by id_type. Shall be filled with nulls in the unused portion of the field."},
+		},
+	},
+	12901: {
+		Name:        "OPEN_DRONE_ID_LOCATION",
+		Description: "Data for filling the OpenDroneID Location message. The float data types are 32-bit IEEE 754. The Location message provides the location, altitude, direction and speed of the aircraft.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID (0 for broadcast)."},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID (0 for broadcast)."},
+			{Name: "id_or_mac", Type: "uint8_t", ArrayLength: 20, Description: "Only used for drone ID data received from other UAs. See detailed description at https://mavlink.io/en/services/opendroneid.html."},
+			{Name: "status", Type: "uint8_t", Enum: "MAV_ODID_STATUS", Description: "Indicates whether the unmanned aircraft is on the ground or in the air."},
+			{Name: "direction", Type: "uint16_t", Description: "Direction over ground (not heading, but direction of movement) measured clockwise from true North: 0 - 35999 centi-degrees. If unknown: 36100 centi-degrees."},
+			{Name: "speed_horizontal", Type: "uint16_t", Description: "Ground speed. Positive only. If unknown: 25500 cm/s. If speed is larger than 25425 cm/s, use 25425 cm/s."},
+			{Name: "speed_vertical", Type: "int16_t", Description: "The vertical speed. Up is positive. If unknown: 6300 cm/s. If speed is larger than 6200 cm/s, use 6200 cm/s. If lower than -6200 cm/s, use -6200 cm/s."},
+			{Name: "latitude", Type: "int32_t", Description: "Current latitude of the unmanned aircraft. If unknown: 0 (both Lat/Lon)."},
+			{Name: "longitude", Type: "int32_t", Description: "Current longitude of the unmanned aircraft. If unknown: 0 (both Lat/Lon)."},
+			{Name: "altitude_barometric", Type: "float", Description: "The altitude calculated from the barometric pressue. Reference is against 29.92inHg or 1013.2mb. If unknown: -1000 m."},
+			{Name: "altitude_geodetic", Type: "float", Description: "The geodetic altitude as defined by WGS84. If unknown: -1000 m."},
+			{Name: "height_reference", Type: "uint8_t", Enum: "MAV_ODID_HEIGHT_REF", Description: "Indicates the reference point for the height field."},
+			{Name: "height", Type: "float", Description: "The current height of the unmanned aircraft above the take-off location or the ground as indicated by height_reference. If unknown: -1000 m."},
+			{Name: "horizontal_accuracy", Type: "uint8_t", Enum: "MAV_ODID_HOR_ACC", Description: "The accuracy of the horizontal position."},
+			{Name: "vertical_accuracy", Type: "uint8_t", Enum: "MAV_ODID_VER_ACC", Description: "The accuracy of the vertical position."},
+			{Name: "barometer_accuracy", Type: "uint8_t", Enum: "MAV_ODID_VER_ACC", Description: "The accuracy of the barometric altitude."},
+			{Name: "speed_accuracy", Type: "uint8_t", Enum: "MAV_ODID_SPEED_ACC", Description: "The accuracy of the horizontal and vertical speed."},
+			{Name: "timestamp", Type: "float", Description: "Seconds after the full hour with reference to UTC time. Typically the GPS outputs a time-of-week value in milliseconds. First convert that to UTC and then convert for this field using ((float) (time_week_ms % (60*60*1000))) / 1000."},
+			{Name: "timestamp_accuracy", Type: "uint8_t", Enum: "MAV_ODID_TIME_ACC", Description: "The accuracy of the timestamps."},
+		},
+	},
+	12902: {
+		Name:        "OPEN_DRONE_ID_AUTHENTICATION",
+		Description: "Data for filling the OpenDroneID Authentication message. The Authentication Message defines a field that can provide a means of authenticity for the identity of the UAS (Unmanned Aircraft System). The Authentication message can have two different formats. Five data pages are supported. For data page 0, the fields PageCount, Length and TimeStamp are present and AuthData is only 17 bytes. For data page 1 through 4, PageCount, Length and TimeStamp are not present and the size of AuthData is 23 bytes.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID (0 for broadcast)."},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID (0 for broadcast)."},
+			{Name: "id_or_mac", Type: "uint8_t", ArrayLength: 20, Description: "Only used for drone ID data received from other UAs. See detailed description at https://mavlink.io/en/services/opendroneid.html."},
+			{Name: "authentication_type", Type: "uint8_t", Enum: "MAV_ODID_AUTH_TYPE", Description: "Indicates the type of authentication."},
+			{Name: "data_page", Type: "uint8_t", Description: "Allowed range is 0 - 4."},
+			{Name: "page_count", Type: "uint8_t", Description: "This field is only present for page 0. Allowed range is 0 - 5."},
+			{Name: "length", Type: "uint8_t", Description: "This field is only present for page 0. Total bytes of authentication_data from all data pages. Allowed range is 0 - 109 (17 + 23*4)."},
+			{Name: "timestamp", Type: "uint32_t", Description: "This field is only present for page 0. 32 bit Unix Timestamp in seconds since 00:00:00 01/01/2019."},
+			{Name: "authentication_data", Type: "uint8_t", ArrayLength: 23, Description: "Opaque authentication data. For page 0, the size is only 17 bytes. For other pages, the size is 23 bytes. Shall be filled with nulls in the unused portion of the field."},
+		},
+	},
+	12903: {
+		Name:        "OPEN_DRONE_ID_SELF_ID",
+		Description: "Data for filling the OpenDroneID Self ID message. The Self ID Message is an opportunity for the operator to (optionally) declare their identity and purpose of the flight. This message can provide additional information that could reduce the threat profile of a UA (Unmanned Aircraft) flying in a particular area or manner.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID (0 for broadcast)."},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID (0 for broadcast)."},
+			{Name: "id_or_mac", Type: "uint8_t", ArrayLength: 20, Description: "Only used for drone ID data received from other UAs. See detailed description at https://mavlink.io/en/services/opendroneid.html."},
+			{Name: "description_type", Type: "uint8_t", Enum: "MAV_ODID_DESC_TYPE", Description: "Indicates the type of the description field."},
+			{Name: "description", Type: "char", ArrayLength: 23, Description: "Text description or numeric value expressed as ASCII characters. Shall be filled with nulls in the unused portion of the field."},
+		},
+	},
+	12904: {
+		Name:        "OPEN_DRONE_ID_SYSTEM",
+		Description: "Data for filling the OpenDroneID System message. The System Message contains general system information including the operator location and possible aircraft group information.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID (0 for broadcast)."},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID (0 for broadcast)."},
+			{Name: "id_or_mac", Type: "uint8_t", ArrayLength: 20, Description: "Only used for drone ID data received from other UAs. See detailed description at https://mavlink.io/en/services/opendroneid.html."},
+			{Name: "operator_location_type", Type: "uint8_t", Enum: "MAV_ODID_OPERATOR_LOCATION_TYPE", Description: "Specifies the operator location type."},
+			{Name: "classification_type", Type: "uint8_t", Enum: "MAV_ODID_CLASSIFICATION_TYPE", Description: "Specifies the classification type of the UA."},
+			{Name: "operator_latitude", Type: "int32_t", Description: "Latitude of the operator. If unknown: 0 (both Lat/Lon)."},
+			{Name: "operator_longitude", Type: "int32_t", Description: "Longitude of the operator. If unknown: 0 (both Lat/Lon)."},
+			{Name: "area_count", Type: "uint16_t", Description: "Number of aircraft in the area, group or formation (default 1)."},
+			{Name: "area_radius", Type: "uint16_t", Description: "Radius of the cylindrical area of the group or formation (default 0)."},
+			{Name: "area_ceiling", Type: "float", Description: "Area Operations Ceiling relative to WGS84. If unknown: -1000 m."},
+			{Name: "area_floor", Type: "float", Description: "Area Operations Floor relative to WGS84. If unknown: -1000 m."},
+			{Name: "category_eu", Type: "uint8_t", Enum: "MAV_ODID_CATEGORY_EU", Description: "When classification_type is MAV_ODID_CLASSIFICATION_TYPE_EU, specifies the category of the UA."},
+			{Name: "class_eu", Type: "uint8_t", Enum: "MAV_ODID_CLASS_EU", Description: "When classification_type is MAV_ODID_CLASSIFICATION_TYPE_EU, specifies the class of the UA."},
+		},
+	},
+	12905: {
+		Name:        "OPEN_DRONE_ID_OPERATOR_ID",
+		Description: "Data for filling the OpenDroneID Operator ID message, which contains the CAA (Civil Aviation Authority) issued operator ID.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID (0 for broadcast)."},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID (0 for broadcast)."},
+			{Name: "id_or_mac", Type: "uint8_t", ArrayLength: 20, Description: "Only used for drone ID data received from other UAs. See detailed description at https://mavlink.io/en/services/opendroneid.html."},
+			{Name: "operator_id_type", Type: "uint8_t", Enum: "MAV_ODID_OPERATOR_ID_TYPE", Description: "Indicates the type of the operator_id field."},
+			{Name: "operator_id", Type: "char", ArrayLength: 20, Description: "Text description or numeric value expressed as ASCII characters. Shall be filled with nulls in the unused portion of the field."},
+		},
+	},
+	12915: {
+		Name:        "OPEN_DRONE_ID_MESSAGE_PACK",
+		Description: "An OpenDroneID message pack is a container for multiple encoded OpenDroneID messages (i.e. not in the format given for the above messages descriptions but after encoding into the compressed OpenDroneID byte format). Used e.g. when transmitting on Bluetooth 5.0 Long Range/Extended Advertising or on WiFi Neighbor Aware Networking.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "target_system", Type: "uint8_t", Description: "System ID (0 for broadcast)."},
+			{Name: "target_component", Type: "uint8_t", Description: "Component ID (0 for broadcast)."},
+			{Name: "single_message_size", Type: "uint8_t", Description: "This field must currently always be equal to 25 (bytes), since all encoded OpenDroneID messages are specificed to have this length."},
+			{Name: "msg_pack_size", Type: "uint8_t", Description: "Number of encoded messages in the pack (not the number of bytes). Allowed range is 1 - 10."},
+			{Name: "messages", Type: "uint8_t", ArrayLength: 250, Description: "Concatenation of encoded OpenDroneID messages. Shall be filled with nulls in the unused portion of the field."},
+		},
+	},
+}
+
 // Enumeration of the ADSB altimeter types
 type ADSB_ALTITUDE_TYPE int
 
@@ -277,6 +3326,13 @@ func (e ADSB_ALTITUDE_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// ADSB_ALTITUDE_TYPEFromString returns the ADSB_ALTITUDE_TYPE value with given name.
+func ADSB_ALTITUDE_TYPEFromString(s string) (ADSB_ALTITUDE_TYPE, error) {
+	var e ADSB_ALTITUDE_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // ADSB classification for the type of vehicle emitting the transponder signal
 type ADSB_EMITTER_TYPE int
 
@@ -446,6 +3502,13 @@ func (e ADSB_EMITTER_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// ADSB_EMITTER_TYPEFromString returns the ADSB_EMITTER_TYPE value with given name.
+func ADSB_EMITTER_TYPEFromString(s string) (ADSB_EMITTER_TYPE, error) {
+	var e ADSB_EMITTER_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // These flags indicate status such as data validity of each data source. Set = data valid
 type ADSB_FLAGS int
 
@@ -545,6 +3608,28 @@ func (e ADSB_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// ADSB_FLAGSFromString returns the ADSB_FLAGS value with given name.
+func ADSB_FLAGSFromString(s string) (ADSB_FLAGS, error) {
+	var e ADSB_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e ADSB_FLAGS) Has(flags ADSB_FLAGS) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e ADSB_FLAGS) Set(flags ADSB_FLAGS) ADSB_FLAGS {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e ADSB_FLAGS) Clear(flags ADSB_FLAGS) ADSB_FLAGS {
+	return e &^ flags
+}
+
 // These flags are used in the AIS_VESSEL.fields bitmask to indicate validity of data in the other message fields. When set, the data is valid.
 type AIS_FLAGS int
 
@@ -665,6 +3750,28 @@ func (e AIS_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// AIS_FLAGSFromString returns the AIS_FLAGS value with given name.
+func AIS_FLAGSFromString(s string) (AIS_FLAGS, error) {
+	var e AIS_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e AIS_FLAGS) Has(flags AIS_FLAGS) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e AIS_FLAGS) Set(flags AIS_FLAGS) AIS_FLAGS {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e AIS_FLAGS) Clear(flags AIS_FLAGS) AIS_FLAGS {
+	return e &^ flags
+}
+
 // Navigational status of AIS vessel, enum duplicated from AIS standard, https://gpsd.gitlab.io/gpsd/AIVDM.html
 type AIS_NAV_STATUS int
 
@@ -806,6 +3913,13 @@ func (e AIS_NAV_STATUS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// AIS_NAV_STATUSFromString returns the AIS_NAV_STATUS value with given name.
+func AIS_NAV_STATUSFromString(s string) (AIS_NAV_STATUS, error) {
+	var e AIS_NAV_STATUS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Type of AIS vessel, enum duplicated from AIS standard, https://gpsd.gitlab.io/gpsd/AIVDM.html
 type AIS_TYPE int
 
@@ -1535,6 +4649,13 @@ func (e AIS_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// AIS_TYPEFromString returns the AIS_TYPE value with given name.
+func AIS_TYPEFromString(s string) (AIS_TYPE, error) {
+	var e AIS_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Bitmap to indicate which dimensions should be ignored by the vehicle: a value of 0b00000000 indicates that none of the setpoint dimensions should be ignored.
 type ATTITUDE_TARGET_TYPEMASK int
 
@@ -1606,6 +4727,28 @@ func (e ATTITUDE_TARGET_TYPEMASK) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// ATTITUDE_TARGET_TYPEMASKFromString returns the ATTITUDE_TARGET_TYPEMASK value with given name.
+func ATTITUDE_TARGET_TYPEMASKFromString(s string) (ATTITUDE_TARGET_TYPEMASK, error) {
+	var e ATTITUDE_TARGET_TYPEMASK
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e ATTITUDE_TARGET_TYPEMASK) Has(flags ATTITUDE_TARGET_TYPEMASK) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e ATTITUDE_TARGET_TYPEMASK) Set(flags ATTITUDE_TARGET_TYPEMASK) ATTITUDE_TARGET_TYPEMASK {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e ATTITUDE_TARGET_TYPEMASK) Clear(flags ATTITUDE_TARGET_TYPEMASK) ATTITUDE_TARGET_TYPEMASK {
+	return e &^ flags
+}
+
 // Camera capability flags (Bitmap)
 type CAMERA_CAP_FLAGS int
 
@@ -1719,6 +4862,28 @@ func (e CAMERA_CAP_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// CAMERA_CAP_FLAGSFromString returns the CAMERA_CAP_FLAGS value with given name.
+func CAMERA_CAP_FLAGSFromString(s string) (CAMERA_CAP_FLAGS, error) {
+	var e CAMERA_CAP_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e CAMERA_CAP_FLAGS) Has(flags CAMERA_CAP_FLAGS) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e CAMERA_CAP_FLAGS) Set(flags CAMERA_CAP_FLAGS) CAMERA_CAP_FLAGS {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e CAMERA_CAP_FLAGS) Clear(flags CAMERA_CAP_FLAGS) CAMERA_CAP_FLAGS {
+	return e &^ flags
+}
+
 // Camera Modes.
 type CAMERA_MODE int
 
@@ -1769,6 +4934,13 @@ func (e CAMERA_MODE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// CAMERA_MODEFromString returns the CAMERA_MODE value with given name.
+func CAMERA_MODEFromString(s string) (CAMERA_MODE, error) {
+	var e CAMERA_MODE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Camera tracking modes
 type CAMERA_TRACKING_MODE int
 
@@ -1819,6 +4991,13 @@ func (e CAMERA_TRACKING_MODE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// CAMERA_TRACKING_MODEFromString returns the CAMERA_TRACKING_MODE value with given name.
+func CAMERA_TRACKING_MODEFromString(s string) (CAMERA_TRACKING_MODE, error) {
+	var e CAMERA_TRACKING_MODE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Camera tracking status flags
 type CAMERA_TRACKING_STATUS_FLAGS int
 
@@ -1869,6 +5048,13 @@ func (e CAMERA_TRACKING_STATUS_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// CAMERA_TRACKING_STATUS_FLAGSFromString returns the CAMERA_TRACKING_STATUS_FLAGS value with given name.
+func CAMERA_TRACKING_STATUS_FLAGSFromString(s string) (CAMERA_TRACKING_STATUS_FLAGS, error) {
+	var e CAMERA_TRACKING_STATUS_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Camera tracking target data (shows where tracked target is within image)
 type CAMERA_TRACKING_TARGET_DATA int
 
@@ -1926,6 +5112,13 @@ func (e CAMERA_TRACKING_TARGET_DATA) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// CAMERA_TRACKING_TARGET_DATAFromString returns the CAMERA_TRACKING_TARGET_DATA value with given name.
+func CAMERA_TRACKING_TARGET_DATAFromString(s string) (CAMERA_TRACKING_TARGET_DATA, error) {
+	var e CAMERA_TRACKING_TARGET_DATA
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Zoom types for MAV_CMD_SET_CAMERA_ZOOM
 type CAMERA_ZOOM_TYPE int
 
@@ -1983,6 +5176,13 @@ func (e CAMERA_ZOOM_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// CAMERA_ZOOM_TYPEFromString returns the CAMERA_ZOOM_TYPE value with given name.
+func CAMERA_ZOOM_TYPEFromString(s string) (CAMERA_ZOOM_TYPE, error) {
+	var e CAMERA_ZOOM_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Possible responses from a CELLULAR_CONFIG message.
 type CELLULAR_CONFIG_RESPONSE int
 
@@ -2047,6 +5247,13 @@ func (e CELLULAR_CONFIG_RESPONSE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// CELLULAR_CONFIG_RESPONSEFromString returns the CELLULAR_CONFIG_RESPONSE value with given name.
+func CELLULAR_CONFIG_RESPONSEFromString(s string) (CELLULAR_CONFIG_RESPONSE, error) {
+	var e CELLULAR_CONFIG_RESPONSE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // These flags are used to diagnose the failure state of CELLULAR_STATUS
 type CELLULAR_NETWORK_FAILED_REASON int
 
@@ -2104,6 +5311,13 @@ func (e CELLULAR_NETWORK_FAILED_REASON) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// CELLULAR_NETWORK_FAILED_REASONFromString returns the CELLULAR_NETWORK_FAILED_REASON value with given name.
+func CELLULAR_NETWORK_FAILED_REASONFromString(s string) (CELLULAR_NETWORK_FAILED_REASON, error) {
+	var e CELLULAR_NETWORK_FAILED_REASON
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Cellular network radio type
 type CELLULAR_NETWORK_RADIO_TYPE int
 
@@ -2168,6 +5382,13 @@ func (e CELLULAR_NETWORK_RADIO_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// CELLULAR_NETWORK_RADIO_TYPEFromString returns the CELLULAR_NETWORK_RADIO_TYPE value with given name.
+func CELLULAR_NETWORK_RADIO_TYPEFromString(s string) (CELLULAR_NETWORK_RADIO_TYPE, error) {
+	var e CELLULAR_NETWORK_RADIO_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // These flags encode the cellular network status
 type CELLULAR_STATUS_FLAG int
 
@@ -2288,6 +5509,13 @@ func (e CELLULAR_STATUS_FLAG) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// CELLULAR_STATUS_FLAGFromString returns the CELLULAR_STATUS_FLAG value with given name.
+func CELLULAR_STATUS_FLAGFromString(s string) (CELLULAR_STATUS_FLAG, error) {
+	var e CELLULAR_STATUS_FLAG
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Component capability flags (Bitmap)
 type COMPONENT_CAP_FLAGS int
 
@@ -2331,6 +5559,13 @@ func (e COMPONENT_CAP_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// COMPONENT_CAP_FLAGSFromString returns the COMPONENT_CAP_FLAGS value with given name.
+func COMPONENT_CAP_FLAGSFromString(s string) (COMPONENT_CAP_FLAGS, error) {
+	var e COMPONENT_CAP_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Supported component metadata types. These are used in the "general" metadata file returned by COMPONENT_INFORMATION to provide information about supported metadata types. The types are not used directly in MAVLink messages.
 type COMP_METADATA_TYPE int
 
@@ -2395,6 +5630,13 @@ func (e COMP_METADATA_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// COMP_METADATA_TYPEFromString returns the COMP_METADATA_TYPE value with given name.
+func COMP_METADATA_TYPEFromString(s string) (COMP_METADATA_TYPE, error) {
+	var e COMP_METADATA_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Indicates the ESC connection type.
 type ESC_CONNECTION_TYPE int
 
@@ -2466,6 +5708,13 @@ func (e ESC_CONNECTION_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// ESC_CONNECTION_TYPEFromString returns the ESC_CONNECTION_TYPE value with given name.
+func ESC_CONNECTION_TYPEFromString(s string) (ESC_CONNECTION_TYPE, error) {
+	var e ESC_CONNECTION_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Flags to report ESC failures.
 type ESC_FAILURE_FLAGS int
 
@@ -2551,6 +5800,13 @@ func (e ESC_FAILURE_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// ESC_FAILURE_FLAGSFromString returns the ESC_FAILURE_FLAGS value with given name.
+func ESC_FAILURE_FLAGSFromString(s string) (ESC_FAILURE_FLAGS, error) {
+	var e ESC_FAILURE_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Flags in ESTIMATOR_STATUS message
 type ESTIMATOR_STATUS_FLAGS int
 
@@ -2664,6 +5920,28 @@ func (e ESTIMATOR_STATUS_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// ESTIMATOR_STATUS_FLAGSFromString returns the ESTIMATOR_STATUS_FLAGS value with given name.
+func ESTIMATOR_STATUS_FLAGSFromString(s string) (ESTIMATOR_STATUS_FLAGS, error) {
+	var e ESTIMATOR_STATUS_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e ESTIMATOR_STATUS_FLAGS) Has(flags ESTIMATOR_STATUS_FLAGS) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e ESTIMATOR_STATUS_FLAGS) Set(flags ESTIMATOR_STATUS_FLAGS) ESTIMATOR_STATUS_FLAGS {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e ESTIMATOR_STATUS_FLAGS) Clear(flags ESTIMATOR_STATUS_FLAGS) ESTIMATOR_STATUS_FLAGS {
+	return e &^ flags
+}
+
 // List of possible failure type to inject.
 type FAILURE_TYPE int
 
@@ -2749,6 +6027,13 @@ func (e FAILURE_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// FAILURE_TYPEFromString returns the FAILURE_TYPE value with given name.
+func FAILURE_TYPEFromString(s string) (FAILURE_TYPE, error) {
+	var e FAILURE_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // List of possible units where failures can be injected.
 type FAILURE_UNIT int
 
@@ -2883,6 +6168,13 @@ func (e FAILURE_UNIT) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// FAILURE_UNITFromString returns the FAILURE_UNIT value with given name.
+func FAILURE_UNITFromString(s string) (FAILURE_UNIT, error) {
+	var e FAILURE_UNIT
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Actions following geofence breach.
 type FENCE_ACTION int
 
@@ -2968,7 +6260,13 @@ func (e FENCE_ACTION) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// FENCE_ACTIONFromString returns the FENCE_ACTION value with given name.
+func FENCE_ACTIONFromString(s string) (FENCE_ACTION, error) {
+	var e FENCE_ACTION
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type FENCE_BREACH int
 
 const (
@@ -3025,6 +6323,13 @@ func (e FENCE_BREACH) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// FENCE_BREACHFromString returns the FENCE_BREACH value with given name.
+func FENCE_BREACHFromString(s string) (FENCE_BREACH, error) {
+	var e FENCE_BREACH
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Actions being taken to mitigate/prevent fence breach
 type FENCE_MITIGATE int
 
@@ -3075,6 +6380,13 @@ func (e FENCE_MITIGATE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// FENCE_MITIGATEFromString returns the FENCE_MITIGATE value with given name.
+func FENCE_MITIGATEFromString(s string) (FENCE_MITIGATE, error) {
+	var e FENCE_MITIGATE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // These values define the type of firmware release.  These values indicate the first version or release of this type.  For example the first alpha release would be 64, the second would be 65.
 type FIRMWARE_VERSION_TYPE int
 
@@ -3139,6 +6451,13 @@ func (e FIRMWARE_VERSION_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// FIRMWARE_VERSION_TYPEFromString returns the FIRMWARE_VERSION_TYPE value with given name.
+func FIRMWARE_VERSION_TYPEFromString(s string) (FIRMWARE_VERSION_TYPE, error) {
+	var e FIRMWARE_VERSION_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Gimbal device (low level) capability flags (bitmap)
 type GIMBAL_DEVICE_CAP_FLAGS int
 
@@ -3252,6 +6571,28 @@ func (e GIMBAL_DEVICE_CAP_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// GIMBAL_DEVICE_CAP_FLAGSFromString returns the GIMBAL_DEVICE_CAP_FLAGS value with given name.
+func GIMBAL_DEVICE_CAP_FLAGSFromString(s string) (GIMBAL_DEVICE_CAP_FLAGS, error) {
+	var e GIMBAL_DEVICE_CAP_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e GIMBAL_DEVICE_CAP_FLAGS) Has(flags GIMBAL_DEVICE_CAP_FLAGS) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e GIMBAL_DEVICE_CAP_FLAGS) Set(flags GIMBAL_DEVICE_CAP_FLAGS) GIMBAL_DEVICE_CAP_FLAGS {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e GIMBAL_DEVICE_CAP_FLAGS) Clear(flags GIMBAL_DEVICE_CAP_FLAGS) GIMBAL_DEVICE_CAP_FLAGS {
+	return e &^ flags
+}
+
 // Gimbal device (low level) error flags (bitmap, 0 means no error)
 type GIMBAL_DEVICE_ERROR_FLAGS int
 
@@ -3344,6 +6685,13 @@ func (e GIMBAL_DEVICE_ERROR_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// GIMBAL_DEVICE_ERROR_FLAGSFromString returns the GIMBAL_DEVICE_ERROR_FLAGS value with given name.
+func GIMBAL_DEVICE_ERROR_FLAGSFromString(s string) (GIMBAL_DEVICE_ERROR_FLAGS, error) {
+	var e GIMBAL_DEVICE_ERROR_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Flags for gimbal device (lower level) operation.
 type GIMBAL_DEVICE_FLAGS int
 
@@ -3408,6 +6756,28 @@ func (e GIMBAL_DEVICE_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// GIMBAL_DEVICE_FLAGSFromString returns the GIMBAL_DEVICE_FLAGS value with given name.
+func GIMBAL_DEVICE_FLAGSFromString(s string) (GIMBAL_DEVICE_FLAGS, error) {
+	var e GIMBAL_DEVICE_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e GIMBAL_DEVICE_FLAGS) Has(flags GIMBAL_DEVICE_FLAGS) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e GIMBAL_DEVICE_FLAGS) Set(flags GIMBAL_DEVICE_FLAGS) GIMBAL_DEVICE_FLAGS {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e GIMBAL_DEVICE_FLAGS) Clear(flags GIMBAL_DEVICE_FLAGS) GIMBAL_DEVICE_FLAGS {
+	return e &^ flags
+}
+
 // Gimbal manager high level capability flags (bitmap). The first 16 bits are identical to the GIMBAL_DEVICE_CAP_FLAGS which are identical with GIMBAL_DEVICE_FLAGS. However, the gimbal manager does not need to copy the flags from the gimbal but can also enhance the capabilities and thus add flags.
 type GIMBAL_MANAGER_CAP_FLAGS int
 
@@ -3535,6 +6905,28 @@ func (e GIMBAL_MANAGER_CAP_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// GIMBAL_MANAGER_CAP_FLAGSFromString returns the GIMBAL_MANAGER_CAP_FLAGS value with given name.
+func GIMBAL_MANAGER_CAP_FLAGSFromString(s string) (GIMBAL_MANAGER_CAP_FLAGS, error) {
+	var e GIMBAL_MANAGER_CAP_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e GIMBAL_MANAGER_CAP_FLAGS) Has(flags GIMBAL_MANAGER_CAP_FLAGS) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e GIMBAL_MANAGER_CAP_FLAGS) Set(flags GIMBAL_MANAGER_CAP_FLAGS) GIMBAL_MANAGER_CAP_FLAGS {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e GIMBAL_MANAGER_CAP_FLAGS) Clear(flags GIMBAL_MANAGER_CAP_FLAGS) GIMBAL_MANAGER_CAP_FLAGS {
+	return e &^ flags
+}
+
 // Flags for high level gimbal manager operation The first 16 bytes are identical to the GIMBAL_DEVICE_FLAGS.
 type GIMBAL_MANAGER_FLAGS int
 
@@ -3599,6 +6991,28 @@ func (e GIMBAL_MANAGER_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// GIMBAL_MANAGER_FLAGSFromString returns the GIMBAL_MANAGER_FLAGS value with given name.
+func GIMBAL_MANAGER_FLAGSFromString(s string) (GIMBAL_MANAGER_FLAGS, error) {
+	var e GIMBAL_MANAGER_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e GIMBAL_MANAGER_FLAGS) Has(flags GIMBAL_MANAGER_FLAGS) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e GIMBAL_MANAGER_FLAGS) Set(flags GIMBAL_MANAGER_FLAGS) GIMBAL_MANAGER_FLAGS {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e GIMBAL_MANAGER_FLAGS) Clear(flags GIMBAL_MANAGER_FLAGS) GIMBAL_MANAGER_FLAGS {
+	return e &^ flags
+}
+
 // Type of GPS fix
 type GPS_FIX_TYPE int
 
@@ -3691,7 +7105,13 @@ func (e GPS_FIX_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// GPS_FIX_TYPEFromString returns the GPS_FIX_TYPE value with given name.
+func GPS_FIX_TYPEFromString(s string) (GPS_FIX_TYPE, error) {
+	var e GPS_FIX_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type GPS_INPUT_IGNORE_FLAGS int
 
 const (
@@ -3776,6 +7196,28 @@ func (e GPS_INPUT_IGNORE_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// GPS_INPUT_IGNORE_FLAGSFromString returns the GPS_INPUT_IGNORE_FLAGS value with given name.
+func GPS_INPUT_IGNORE_FLAGSFromString(s string) (GPS_INPUT_IGNORE_FLAGS, error) {
+	var e GPS_INPUT_IGNORE_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e GPS_INPUT_IGNORE_FLAGS) Has(flags GPS_INPUT_IGNORE_FLAGS) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e GPS_INPUT_IGNORE_FLAGS) Set(flags GPS_INPUT_IGNORE_FLAGS) GPS_INPUT_IGNORE_FLAGS {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e GPS_INPUT_IGNORE_FLAGS) Clear(flags GPS_INPUT_IGNORE_FLAGS) GPS_INPUT_IGNORE_FLAGS {
+	return e &^ flags
+}
+
 // Gripper actions.
 type GRIPPER_ACTIONS int
 
@@ -3819,6 +7261,13 @@ func (e GRIPPER_ACTIONS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// GRIPPER_ACTIONSFromString returns the GRIPPER_ACTIONS value with given name.
+func GRIPPER_ACTIONSFromString(s string) (GRIPPER_ACTIONS, error) {
+	var e GRIPPER_ACTIONS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Flags to report failure cases over the high latency telemtry.
 type HL_FAILURE_FLAG int
 
@@ -3946,6 +7395,28 @@ func (e HL_FAILURE_FLAG) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// HL_FAILURE_FLAGFromString returns the HL_FAILURE_FLAG value with given name.
+func HL_FAILURE_FLAGFromString(s string) (HL_FAILURE_FLAG, error) {
+	var e HL_FAILURE_FLAG
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e HL_FAILURE_FLAG) Has(flags HL_FAILURE_FLAG) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e HL_FAILURE_FLAG) Set(flags HL_FAILURE_FLAG) HL_FAILURE_FLAG {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e HL_FAILURE_FLAG) Clear(flags HL_FAILURE_FLAG) HL_FAILURE_FLAG {
+	return e &^ flags
+}
+
 // Type of landing target
 type LANDING_TARGET_TYPE int
 
@@ -4003,7 +7474,13 @@ func (e LANDING_TARGET_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// LANDING_TARGET_TYPEFromString returns the LANDING_TARGET_TYPE value with given name.
+func LANDING_TARGET_TYPEFromString(s string) (LANDING_TARGET_TYPE, error) {
+	var e LANDING_TARGET_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAG_CAL_STATUS int
 
 const (
@@ -4088,7 +7565,13 @@ func (e MAG_CAL_STATUS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAG_CAL_STATUSFromString returns the MAG_CAL_STATUS value with given name.
+func MAG_CAL_STATUSFromString(s string) (MAG_CAL_STATUS, error) {
+	var e MAG_CAL_STATUS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAVLINK_DATA_STREAM_TYPE int
 
 const (
@@ -4159,7 +7642,13 @@ func (e MAVLINK_DATA_STREAM_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAVLINK_DATA_STREAM_TYPEFromString returns the MAVLINK_DATA_STREAM_TYPE value with given name.
+func MAVLINK_DATA_STREAM_TYPEFromString(s string) (MAVLINK_DATA_STREAM_TYPE, error) {
+	var e MAVLINK_DATA_STREAM_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ARM_AUTH_DENIED_REASON int
 
 const (
@@ -4230,6 +7719,13 @@ func (e MAV_ARM_AUTH_DENIED_REASON) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_ARM_AUTH_DENIED_REASONFromString returns the MAV_ARM_AUTH_DENIED_REASON value with given name.
+func MAV_ARM_AUTH_DENIED_REASONFromString(s string) (MAV_ARM_AUTH_DENIED_REASON, error) {
+	var e MAV_ARM_AUTH_DENIED_REASON
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Micro air vehicle / autopilot classes. This identifies the individual model.
 type MAV_AUTOPILOT int
 
@@ -4399,6 +7895,13 @@ func (e MAV_AUTOPILOT) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_AUTOPILOTFromString returns the MAV_AUTOPILOT value with given name.
+func MAV_AUTOPILOTFromString(s string) (MAV_AUTOPILOT, error) {
+	var e MAV_AUTOPILOT
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Enumeration for battery charge states.
 type MAV_BATTERY_CHARGE_STATE int
 
@@ -4484,6 +7987,13 @@ func (e MAV_BATTERY_CHARGE_STATE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_BATTERY_CHARGE_STATEFromString returns the MAV_BATTERY_CHARGE_STATE value with given name.
+func MAV_BATTERY_CHARGE_STATEFromString(s string) (MAV_BATTERY_CHARGE_STATE, error) {
+	var e MAV_BATTERY_CHARGE_STATE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Smart battery supply status/fault flags (bitmask) for health indication. The battery must also report either MAV_BATTERY_CHARGE_STATE_FAILED or MAV_BATTERY_CHARGE_STATE_UNHEALTHY if any of these are set.
 type MAV_BATTERY_FAULT int
 
@@ -4576,6 +8086,28 @@ func (e MAV_BATTERY_FAULT) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_BATTERY_FAULTFromString returns the MAV_BATTERY_FAULT value with given name.
+func MAV_BATTERY_FAULTFromString(s string) (MAV_BATTERY_FAULT, error) {
+	var e MAV_BATTERY_FAULT
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e MAV_BATTERY_FAULT) Has(flags MAV_BATTERY_FAULT) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e MAV_BATTERY_FAULT) Set(flags MAV_BATTERY_FAULT) MAV_BATTERY_FAULT {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e MAV_BATTERY_FAULT) Clear(flags MAV_BATTERY_FAULT) MAV_BATTERY_FAULT {
+	return e &^ flags
+}
+
 // Enumeration of battery functions
 type MAV_BATTERY_FUNCTION int
 
@@ -4640,6 +8172,13 @@ func (e MAV_BATTERY_FUNCTION) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_BATTERY_FUNCTIONFromString returns the MAV_BATTERY_FUNCTION value with given name.
+func MAV_BATTERY_FUNCTIONFromString(s string) (MAV_BATTERY_FUNCTION, error) {
+	var e MAV_BATTERY_FUNCTION
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Battery mode. Note, the normal operation mode (i.e. when flying) should be reported as MAV_BATTERY_MODE_UNKNOWN to allow message trimming in normal flight.
 type MAV_BATTERY_MODE int
 
@@ -4690,6 +8229,13 @@ func (e MAV_BATTERY_MODE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_BATTERY_MODEFromString returns the MAV_BATTERY_MODE value with given name.
+func MAV_BATTERY_MODEFromString(s string) (MAV_BATTERY_MODE, error) {
+	var e MAV_BATTERY_MODE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Enumeration of battery types
 type MAV_BATTERY_TYPE int
 
@@ -4754,6 +8300,13 @@ func (e MAV_BATTERY_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_BATTERY_TYPEFromString returns the MAV_BATTERY_TYPE value with given name.
+func MAV_BATTERY_TYPEFromString(s string) (MAV_BATTERY_TYPE, error) {
+	var e MAV_BATTERY_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Commands to be executed by the MAV. They can be executed on user request, or as part of a mission script. If the action is used in a mission, the parameter mapping to the waypoint/mission message is as follows: Param 1, Param 2, Param 3, Param 4, X: Param 5, Y:Param 6, Z:Param 7. This command list is similar what ARINC 424 is for commercial aircraft: A data format how to interpret waypoint/mission data. NaN and INT32_MAX may be used in float/integer params (respectively) to indicate optional/default values (e.g. to use the component's current yaw or latitude rather than a specific value). See https://mavlink.io/en/guide/xml_schema.html#MAV_CMD for information about the structure of the MAV_CMD entries
 type MAV_CMD int
 
@@ -5875,6 +9428,13 @@ func (e MAV_CMD) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_CMDFromString returns the MAV_CMD value with given name.
+func MAV_CMDFromString(s string) (MAV_CMD, error) {
+	var e MAV_CMD
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // ACK / NACK / ERROR values as a result of MAV_CMDs and for mission item transmission.
 type MAV_CMD_ACK int
 
@@ -5967,6 +9527,13 @@ func (e MAV_CMD_ACK) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_CMD_ACKFromString returns the MAV_CMD_ACK value with given name.
+func MAV_CMD_ACKFromString(s string) (MAV_CMD_ACK, error) {
+	var e MAV_CMD_ACK
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Possible actions an aircraft can take to avoid a collision.
 type MAV_COLLISION_ACTION int
 
@@ -6045,6 +9612,13 @@ func (e MAV_COLLISION_ACTION) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_COLLISION_ACTIONFromString returns the MAV_COLLISION_ACTION value with given name.
+func MAV_COLLISION_ACTIONFromString(s string) (MAV_COLLISION_ACTION, error) {
+	var e MAV_COLLISION_ACTION
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Source of information about this collision.
 type MAV_COLLISION_SRC int
 
@@ -6088,6 +9662,13 @@ func (e MAV_COLLISION_SRC) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_COLLISION_SRCFromString returns the MAV_COLLISION_SRC value with given name.
+func MAV_COLLISION_SRCFromString(s string) (MAV_COLLISION_SRC, error) {
+	var e MAV_COLLISION_SRC
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Aircraft-rated danger from this threat.
 type MAV_COLLISION_THREAT_LEVEL int
 
@@ -6138,6 +9719,13 @@ func (e MAV_COLLISION_THREAT_LEVEL) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_COLLISION_THREAT_LEVELFromString returns the MAV_COLLISION_THREAT_LEVEL value with given name.
+func MAV_COLLISION_THREAT_LEVELFromString(s string) (MAV_COLLISION_THREAT_LEVEL, error) {
+	var e MAV_COLLISION_THREAT_LEVEL
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Component ids (values) for the different types and instances of onboard hardware/software that might make up a MAVLink system (autopilot, cameras, servos, GPS systems, avoidance systems etc.).      Components must use the appropriate ID in their source address when sending messages. Components can also use IDs to determine if they are the intended recipient of an incoming message. The MAV_COMP_ID_ALL value is used to indicate messages that must be processed by all components.      When creating new entries, components that can have multiple instances (e.g. cameras, servos etc.) should be allocated sequential values. An appropriate number of values should be left free after these components to allow the number of instances to be expanded.
 type MAV_COMPONENT int
 
@@ -7098,6 +10686,13 @@ func (e MAV_COMPONENT) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_COMPONENTFromString returns the MAV_COMPONENT value with given name.
+func MAV_COMPONENTFromString(s string) (MAV_COMPONENT, error) {
+	var e MAV_COMPONENT
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // A data stream is not a fixed set of messages, but rather a     recommendation to the autopilot software. Individual autopilots may or may not obey     the recommended messages.
 type MAV_DATA_STREAM int
 
@@ -7190,6 +10785,13 @@ func (e MAV_DATA_STREAM) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_DATA_STREAMFromString returns the MAV_DATA_STREAM value with given name.
+func MAV_DATA_STREAMFromString(s string) (MAV_DATA_STREAM, error) {
+	var e MAV_DATA_STREAM
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Enumeration of distance sensor types
 type MAV_DISTANCE_SENSOR int
 
@@ -7254,6 +10856,13 @@ func (e MAV_DISTANCE_SENSOR) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_DISTANCE_SENSORFromString returns the MAV_DISTANCE_SENSOR value with given name.
+func MAV_DISTANCE_SENSORFromString(s string) (MAV_DISTANCE_SENSOR, error) {
+	var e MAV_DISTANCE_SENSOR
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Bitmap of options for the MAV_CMD_DO_REPOSITION
 type MAV_DO_REPOSITION_FLAGS int
 
@@ -7290,6 +10899,28 @@ func (e MAV_DO_REPOSITION_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_DO_REPOSITION_FLAGSFromString returns the MAV_DO_REPOSITION_FLAGS value with given name.
+func MAV_DO_REPOSITION_FLAGSFromString(s string) (MAV_DO_REPOSITION_FLAGS, error) {
+	var e MAV_DO_REPOSITION_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e MAV_DO_REPOSITION_FLAGS) Has(flags MAV_DO_REPOSITION_FLAGS) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e MAV_DO_REPOSITION_FLAGS) Set(flags MAV_DO_REPOSITION_FLAGS) MAV_DO_REPOSITION_FLAGS {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e MAV_DO_REPOSITION_FLAGS) Clear(flags MAV_DO_REPOSITION_FLAGS) MAV_DO_REPOSITION_FLAGS {
+	return e &^ flags
+}
+
 // Enumeration of estimator types
 type MAV_ESTIMATOR_TYPE int
 
@@ -7382,6 +11013,13 @@ func (e MAV_ESTIMATOR_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_ESTIMATOR_TYPEFromString returns the MAV_ESTIMATOR_TYPE value with given name.
+func MAV_ESTIMATOR_TYPEFromString(s string) (MAV_ESTIMATOR_TYPE, error) {
+	var e MAV_ESTIMATOR_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Flags for CURRENT_EVENT_SEQUENCE.
 type MAV_EVENT_CURRENT_SEQUENCE_FLAGS int
 
@@ -7418,6 +11056,13 @@ func (e MAV_EVENT_CURRENT_SEQUENCE_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_EVENT_CURRENT_SEQUENCE_FLAGSFromString returns the MAV_EVENT_CURRENT_SEQUENCE_FLAGS value with given name.
+func MAV_EVENT_CURRENT_SEQUENCE_FLAGSFromString(s string) (MAV_EVENT_CURRENT_SEQUENCE_FLAGS, error) {
+	var e MAV_EVENT_CURRENT_SEQUENCE_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Reason for an event error response.
 type MAV_EVENT_ERROR_REASON int
 
@@ -7454,7 +11099,13 @@ func (e MAV_EVENT_ERROR_REASON) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_EVENT_ERROR_REASONFromString returns the MAV_EVENT_ERROR_REASON value with given name.
+func MAV_EVENT_ERROR_REASONFromString(s string) (MAV_EVENT_ERROR_REASON, error) {
+	var e MAV_EVENT_ERROR_REASON
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_FRAME int
 
 const (
@@ -7637,6 +11288,13 @@ func (e MAV_FRAME) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_FRAMEFromString returns the MAV_FRAME value with given name.
+func MAV_FRAMEFromString(s string) (MAV_FRAME, error) {
+	var e MAV_FRAME
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Flags to report status/failure cases for a power generator (used in GENERATOR_STATUS). Note that FAULTS are conditions that cause the generator to fail. Warnings are conditions that require attention before the next use (they indicate the system is not operating properly).
 type MAV_GENERATOR_STATUS_FLAG int
 
@@ -7827,6 +11485,28 @@ func (e MAV_GENERATOR_STATUS_FLAG) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_GENERATOR_STATUS_FLAGFromString returns the MAV_GENERATOR_STATUS_FLAG value with given name.
+func MAV_GENERATOR_STATUS_FLAGFromString(s string) (MAV_GENERATOR_STATUS_FLAG, error) {
+	var e MAV_GENERATOR_STATUS_FLAG
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e MAV_GENERATOR_STATUS_FLAG) Has(flags MAV_GENERATOR_STATUS_FLAG) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e MAV_GENERATOR_STATUS_FLAG) Set(flags MAV_GENERATOR_STATUS_FLAG) MAV_GENERATOR_STATUS_FLAG {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e MAV_GENERATOR_STATUS_FLAG) Clear(flags MAV_GENERATOR_STATUS_FLAG) MAV_GENERATOR_STATUS_FLAG {
+	return e &^ flags
+}
+
 // Actions that may be specified in MAV_CMD_OVERRIDE_GOTO to override mission execution.
 type MAV_GOTO int
 
@@ -7884,6 +11564,13 @@ func (e MAV_GOTO) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_GOTOFromString returns the MAV_GOTO value with given name.
+func MAV_GOTOFromString(s string) (MAV_GOTO, error) {
+	var e MAV_GOTO
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Enumeration of landed detector states
 type MAV_LANDED_STATE int
 
@@ -7948,6 +11635,13 @@ func (e MAV_LANDED_STATE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_LANDED_STATEFromString returns the MAV_LANDED_STATE value with given name.
+func MAV_LANDED_STATEFromString(s string) (MAV_LANDED_STATE, error) {
+	var e MAV_LANDED_STATE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Result of mission operation (in a MISSION_ACK message).
 type MAV_MISSION_RESULT int
 
@@ -8089,6 +11783,13 @@ func (e MAV_MISSION_RESULT) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_MISSION_RESULTFromString returns the MAV_MISSION_RESULT value with given name.
+func MAV_MISSION_RESULTFromString(s string) (MAV_MISSION_RESULT, error) {
+	var e MAV_MISSION_RESULT
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Type of mission items being requested/sent in mission protocol.
 type MAV_MISSION_TYPE int
 
@@ -8146,6 +11847,13 @@ func (e MAV_MISSION_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_MISSION_TYPEFromString returns the MAV_MISSION_TYPE value with given name.
+func MAV_MISSION_TYPEFromString(s string) (MAV_MISSION_TYPE, error) {
+	var e MAV_MISSION_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // These defines are predefined OR-combined mode flags. There is no need to use values from this enum, but it               simplifies the use of the mode flags. Note that manual input is enabled in all modes as a safety override.
 type MAV_MODE int
 
@@ -8252,6 +11960,13 @@ func (e MAV_MODE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_MODEFromString returns the MAV_MODE value with given name.
+func MAV_MODEFromString(s string) (MAV_MODE, error) {
+	var e MAV_MODE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // These flags encode the MAV mode.
 type MAV_MODE_FLAG int
 
@@ -8337,6 +12052,28 @@ func (e MAV_MODE_FLAG) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_MODE_FLAGFromString returns the MAV_MODE_FLAG value with given name.
+func MAV_MODE_FLAGFromString(s string) (MAV_MODE_FLAG, error) {
+	var e MAV_MODE_FLAG
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e MAV_MODE_FLAG) Has(flags MAV_MODE_FLAG) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e MAV_MODE_FLAG) Set(flags MAV_MODE_FLAG) MAV_MODE_FLAG {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e MAV_MODE_FLAG) Clear(flags MAV_MODE_FLAG) MAV_MODE_FLAG {
+	return e &^ flags
+}
+
 // These values encode the bit positions of the decode position. These values can be used to read the value of a flag bit by combining the base_mode variable with AND with the flag position value. The result will be either 0 or 1, depending on if the flag is set or not.
 type MAV_MODE_FLAG_DECODE_POSITION int
 
@@ -8422,6 +12159,28 @@ func (e MAV_MODE_FLAG_DECODE_POSITION) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_MODE_FLAG_DECODE_POSITIONFromString returns the MAV_MODE_FLAG_DECODE_POSITION value with given name.
+func MAV_MODE_FLAG_DECODE_POSITIONFromString(s string) (MAV_MODE_FLAG_DECODE_POSITION, error) {
+	var e MAV_MODE_FLAG_DECODE_POSITION
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e MAV_MODE_FLAG_DECODE_POSITION) Has(flags MAV_MODE_FLAG_DECODE_POSITION) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e MAV_MODE_FLAG_DECODE_POSITION) Set(flags MAV_MODE_FLAG_DECODE_POSITION) MAV_MODE_FLAG_DECODE_POSITION {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e MAV_MODE_FLAG_DECODE_POSITION) Clear(flags MAV_MODE_FLAG_DECODE_POSITION) MAV_MODE_FLAG_DECODE_POSITION {
+	return e &^ flags
+}
+
 // Enumeration of possible mount operation modes. This message is used by obsolete/deprecated gimbal messages.
 type MAV_MOUNT_MODE int
 
@@ -8500,7 +12259,13 @@ func (e MAV_MOUNT_MODE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_MOUNT_MODEFromString returns the MAV_MOUNT_MODE value with given name.
+func MAV_MOUNT_MODEFromString(s string) (MAV_MOUNT_MODE, error) {
+	var e MAV_MOUNT_MODE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_AUTH_TYPE int
 
 const (
@@ -8564,7 +12329,13 @@ func (e MAV_ODID_AUTH_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_ODID_AUTH_TYPEFromString returns the MAV_ODID_AUTH_TYPE value with given name.
+func MAV_ODID_AUTH_TYPEFromString(s string) (MAV_ODID_AUTH_TYPE, error) {
+	var e MAV_ODID_AUTH_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_CATEGORY_EU int
 
 const (
@@ -8621,7 +12392,13 @@ func (e MAV_ODID_CATEGORY_EU) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_ODID_CATEGORY_EUFromString returns the MAV_ODID_CATEGORY_EU value with given name.
+func MAV_ODID_CATEGORY_EUFromString(s string) (MAV_ODID_CATEGORY_EU, error) {
+	var e MAV_ODID_CATEGORY_EU
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_CLASSIFICATION_TYPE int
 
 const (
@@ -8664,7 +12441,13 @@ func (e MAV_ODID_CLASSIFICATION_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_ODID_CLASSIFICATION_TYPEFromString returns the MAV_ODID_CLASSIFICATION_TYPE value with given name.
+func MAV_ODID_CLASSIFICATION_TYPEFromString(s string) (MAV_ODID_CLASSIFICATION_TYPE, error) {
+	var e MAV_ODID_CLASSIFICATION_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_CLASS_EU int
 
 const (
@@ -8749,7 +12532,13 @@ func (e MAV_ODID_CLASS_EU) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_ODID_CLASS_EUFromString returns the MAV_ODID_CLASS_EU value with given name.
+func MAV_ODID_CLASS_EUFromString(s string) (MAV_ODID_CLASS_EU, error) {
+	var e MAV_ODID_CLASS_EU
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_DESC_TYPE int
 
 const (
@@ -8785,7 +12574,13 @@ func (e MAV_ODID_DESC_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_ODID_DESC_TYPEFromString returns the MAV_ODID_DESC_TYPE value with given name.
+func MAV_ODID_DESC_TYPEFromString(s string) (MAV_ODID_DESC_TYPE, error) {
+	var e MAV_ODID_DESC_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_HEIGHT_REF int
 
 const (
@@ -8828,7 +12623,13 @@ func (e MAV_ODID_HEIGHT_REF) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_ODID_HEIGHT_REFFromString returns the MAV_ODID_HEIGHT_REF value with given name.
+func MAV_ODID_HEIGHT_REFFromString(s string) (MAV_ODID_HEIGHT_REF, error) {
+	var e MAV_ODID_HEIGHT_REF
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_HOR_ACC int
 
 const (
@@ -8948,7 +12749,13 @@ func (e MAV_ODID_HOR_ACC) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_ODID_HOR_ACCFromString returns the MAV_ODID_HOR_ACC value with given name.
+func MAV_ODID_HOR_ACCFromString(s string) (MAV_ODID_HOR_ACC, error) {
+	var e MAV_ODID_HOR_ACC
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_ID_TYPE int
 
 const (
@@ -9005,7 +12812,13 @@ func (e MAV_ODID_ID_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_ODID_ID_TYPEFromString returns the MAV_ODID_ID_TYPE value with given name.
+func MAV_ODID_ID_TYPEFromString(s string) (MAV_ODID_ID_TYPE, error) {
+	var e MAV_ODID_ID_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_OPERATOR_ID_TYPE int
 
 const (
@@ -9041,7 +12854,13 @@ func (e MAV_ODID_OPERATOR_ID_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_ODID_OPERATOR_ID_TYPEFromString returns the MAV_ODID_OPERATOR_ID_TYPE value with given name.
+func MAV_ODID_OPERATOR_ID_TYPEFromString(s string) (MAV_ODID_OPERATOR_ID_TYPE, error) {
+	var e MAV_ODID_OPERATOR_ID_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_OPERATOR_LOCATION_TYPE int
 
 const (
@@ -9091,7 +12910,13 @@ func (e MAV_ODID_OPERATOR_LOCATION_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_ODID_OPERATOR_LOCATION_TYPEFromString returns the MAV_ODID_OPERATOR_LOCATION_TYPE value with given name.
+func MAV_ODID_OPERATOR_LOCATION_TYPEFromString(s string) (MAV_ODID_OPERATOR_LOCATION_TYPE, error) {
+	var e MAV_ODID_OPERATOR_LOCATION_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_SPEED_ACC int
 
 const (
@@ -9155,7 +12980,13 @@ func (e MAV_ODID_SPEED_ACC) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_ODID_SPEED_ACCFromString returns the MAV_ODID_SPEED_ACC value with given name.
+func MAV_ODID_SPEED_ACCFromString(s string) (MAV_ODID_SPEED_ACC, error) {
+	var e MAV_ODID_SPEED_ACC
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_STATUS int
 
 const (
@@ -9212,7 +13043,13 @@ func (e MAV_ODID_STATUS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_ODID_STATUSFromString returns the MAV_ODID_STATUS value with given name.
+func MAV_ODID_STATUSFromString(s string) (MAV_ODID_STATUS, error) {
+	var e MAV_ODID_STATUS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_TIME_ACC int
 
 const (
@@ -9353,7 +13190,13 @@ func (e MAV_ODID_TIME_ACC) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_ODID_TIME_ACCFromString returns the MAV_ODID_TIME_ACC value with given name.
+func MAV_ODID_TIME_ACCFromString(s string) (MAV_ODID_TIME_ACC, error) {
+	var e MAV_ODID_TIME_ACC
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_UA_TYPE int
 
 const (
@@ -9494,7 +13337,13 @@ func (e MAV_ODID_UA_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_ODID_UA_TYPEFromString returns the MAV_ODID_UA_TYPE value with given name.
+func MAV_ODID_UA_TYPEFromString(s string) (MAV_ODID_UA_TYPE, error) {
+	var e MAV_ODID_UA_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_ODID_VER_ACC int
 
 const (
@@ -9572,6 +13421,13 @@ func (e MAV_ODID_VER_ACC) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_ODID_VER_ACCFromString returns the MAV_ODID_VER_ACC value with given name.
+func MAV_ODID_VER_ACCFromString(s string) (MAV_ODID_VER_ACC, error) {
+	var e MAV_ODID_VER_ACC
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Specifies the datatype of a MAVLink extended parameter.
 type MAV_PARAM_EXT_TYPE int
 
@@ -9678,6 +13534,13 @@ func (e MAV_PARAM_EXT_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_PARAM_EXT_TYPEFromString returns the MAV_PARAM_EXT_TYPE value with given name.
+func MAV_PARAM_EXT_TYPEFromString(s string) (MAV_PARAM_EXT_TYPE, error) {
+	var e MAV_PARAM_EXT_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Specifies the datatype of a MAVLink parameter.
 type MAV_PARAM_TYPE int
 
@@ -9777,6 +13640,13 @@ func (e MAV_PARAM_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_PARAM_TYPEFromString returns the MAV_PARAM_TYPE value with given name.
+func MAV_PARAM_TYPEFromString(s string) (MAV_PARAM_TYPE, error) {
+	var e MAV_PARAM_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Power supply status flags (bitmask)
 type MAV_POWER_STATUS int
 
@@ -9848,6 +13718,28 @@ func (e MAV_POWER_STATUS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_POWER_STATUSFromString returns the MAV_POWER_STATUS value with given name.
+func MAV_POWER_STATUSFromString(s string) (MAV_POWER_STATUS, error) {
+	var e MAV_POWER_STATUS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e MAV_POWER_STATUS) Has(flags MAV_POWER_STATUS) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e MAV_POWER_STATUS) Set(flags MAV_POWER_STATUS) MAV_POWER_STATUS {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e MAV_POWER_STATUS) Clear(flags MAV_POWER_STATUS) MAV_POWER_STATUS {
+	return e &^ flags
+}
+
 // Bitmask of (optional) autopilot capabilities (64 bit). If a bit is set, the autopilot supports this capability.
 type MAV_PROTOCOL_CAPABILITY int
 
@@ -9996,6 +13888,28 @@ func (e MAV_PROTOCOL_CAPABILITY) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_PROTOCOL_CAPABILITYFromString returns the MAV_PROTOCOL_CAPABILITY value with given name.
+func MAV_PROTOCOL_CAPABILITYFromString(s string) (MAV_PROTOCOL_CAPABILITY, error) {
+	var e MAV_PROTOCOL_CAPABILITY
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e MAV_PROTOCOL_CAPABILITY) Has(flags MAV_PROTOCOL_CAPABILITY) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e MAV_PROTOCOL_CAPABILITY) Set(flags MAV_PROTOCOL_CAPABILITY) MAV_PROTOCOL_CAPABILITY {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e MAV_PROTOCOL_CAPABILITY) Clear(flags MAV_PROTOCOL_CAPABILITY) MAV_PROTOCOL_CAPABILITY {
+	return e &^ flags
+}
+
 // Result from a MAVLink command (MAV_CMD)
 type MAV_RESULT int
 
@@ -10074,6 +13988,13 @@ func (e MAV_RESULT) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_RESULTFromString returns the MAV_RESULT value with given name.
+func MAV_RESULTFromString(s string) (MAV_RESULT, error) {
+	var e MAV_RESULT
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // The ROI (region of interest) for the vehicle. This can be                be used by the vehicle for camera/vehicle attitude alignment (see                MAV_CMD_NAV_ROI).
 type MAV_ROI int
 
@@ -10138,6 +14059,13 @@ func (e MAV_ROI) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_ROIFromString returns the MAV_ROI value with given name.
+func MAV_ROIFromString(s string) (MAV_ROI, error) {
+	var e MAV_ROI
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Enumeration of sensor orientation, according to its rotations
 type MAV_SENSOR_ORIENTATION int
 
@@ -10461,6 +14389,13 @@ func (e MAV_SENSOR_ORIENTATION) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_SENSOR_ORIENTATIONFromString returns the MAV_SENSOR_ORIENTATION value with given name.
+func MAV_SENSOR_ORIENTATIONFromString(s string) (MAV_SENSOR_ORIENTATION, error) {
+	var e MAV_SENSOR_ORIENTATION
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Indicates the severity level, generally used for status messages to indicate their relative urgency. Based on RFC-5424 using expanded definitions at: http://www.kiwisyslog.com/kb/info:-syslog-message-levels/.
 type MAV_SEVERITY int
 
@@ -10546,7 +14481,13 @@ func (e MAV_SEVERITY) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_SEVERITYFromString returns the MAV_SEVERITY value with given name.
+func MAV_SEVERITYFromString(s string) (MAV_SEVERITY, error) {
+	var e MAV_SEVERITY
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type MAV_STATE int
 
 const (
@@ -10638,6 +14579,13 @@ func (e MAV_STATE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_STATEFromString returns the MAV_STATE value with given name.
+func MAV_STATEFromString(s string) (MAV_STATE, error) {
+	var e MAV_STATE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // These encode the sensors whose status is sent as part of the SYS_STATUS message.
 type MAV_SYS_STATUS_SENSOR int
 
@@ -10884,7 +14832,28 @@ func (e MAV_SYS_STATUS_SENSOR) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_SYS_STATUS_SENSORFromString returns the MAV_SYS_STATUS_SENSOR value with given name.
+func MAV_SYS_STATUS_SENSORFromString(s string) (MAV_SYS_STATUS_SENSOR, error) {
+	var e MAV_SYS_STATUS_SENSOR
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e MAV_SYS_STATUS_SENSOR) Has(flags MAV_SYS_STATUS_SENSOR) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e MAV_SYS_STATUS_SENSOR) Set(flags MAV_SYS_STATUS_SENSOR) MAV_SYS_STATUS_SENSOR {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e MAV_SYS_STATUS_SENSOR) Clear(flags MAV_SYS_STATUS_SENSOR) MAV_SYS_STATUS_SENSOR {
+	return e &^ flags
+}
+
 type MAV_TUNNEL_PAYLOAD_TYPE int
 
 const (
@@ -10990,6 +14959,13 @@ func (e MAV_TUNNEL_PAYLOAD_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_TUNNEL_PAYLOAD_TYPEFromString returns the MAV_TUNNEL_PAYLOAD_TYPE value with given name.
+func MAV_TUNNEL_PAYLOAD_TYPEFromString(s string) (MAV_TUNNEL_PAYLOAD_TYPE, error) {
+	var e MAV_TUNNEL_PAYLOAD_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // MAVLINK component type reported in HEARTBEAT message. Flight controllers must report the type of the vehicle on which they are mounted (e.g. MAV_TYPE_OCTOROTOR). All other components must report a value appropriate for their type (e.g. a camera must use MAV_TYPE_CAMERA).
 type MAV_TYPE int
 
@@ -11285,6 +15261,13 @@ func (e MAV_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_TYPEFromString returns the MAV_TYPE value with given name.
+func MAV_TYPEFromString(s string) (MAV_TYPE, error) {
+	var e MAV_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Enumeration of VTOL states
 type MAV_VTOL_STATE int
 
@@ -11349,6 +15332,13 @@ func (e MAV_VTOL_STATE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_VTOL_STATEFromString returns the MAV_VTOL_STATE value with given name.
+func MAV_VTOL_STATEFromString(s string) (MAV_VTOL_STATE, error) {
+	var e MAV_VTOL_STATE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Winch status flags used in WINCH_STATUS
 type MAV_WINCH_STATUS_FLAG int
 
@@ -11406,6 +15396,28 @@ func (e MAV_WINCH_STATUS_FLAG) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_WINCH_STATUS_FLAGFromString returns the MAV_WINCH_STATUS_FLAG value with given name.
+func MAV_WINCH_STATUS_FLAGFromString(s string) (MAV_WINCH_STATUS_FLAG, error) {
+	var e MAV_WINCH_STATUS_FLAG
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e MAV_WINCH_STATUS_FLAG) Has(flags MAV_WINCH_STATUS_FLAG) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e MAV_WINCH_STATUS_FLAG) Set(flags MAV_WINCH_STATUS_FLAG) MAV_WINCH_STATUS_FLAG {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e MAV_WINCH_STATUS_FLAG) Clear(flags MAV_WINCH_STATUS_FLAG) MAV_WINCH_STATUS_FLAG {
+	return e &^ flags
+}
+
 // Sequence that motors are tested when using MAV_CMD_DO_MOTOR_TEST.
 type MOTOR_TEST_ORDER int
 
@@ -11456,6 +15468,13 @@ func (e MOTOR_TEST_ORDER) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MOTOR_TEST_ORDERFromString returns the MOTOR_TEST_ORDER value with given name.
+func MOTOR_TEST_ORDERFromString(s string) (MOTOR_TEST_ORDER, error) {
+	var e MOTOR_TEST_ORDER
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Defines how throttle value is represented in MAV_CMD_DO_MOTOR_TEST.
 type MOTOR_TEST_THROTTLE_TYPE int
 
@@ -11513,7 +15532,13 @@ func (e MOTOR_TEST_THROTTLE_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MOTOR_TEST_THROTTLE_TYPEFromString returns the MOTOR_TEST_THROTTLE_TYPE value with given name.
+func MOTOR_TEST_THROTTLE_TYPEFromString(s string) (MOTOR_TEST_THROTTLE_TYPE, error) {
+	var e MOTOR_TEST_THROTTLE_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type NAV_VTOL_LAND_OPTIONS int
 
 const (
@@ -11563,6 +15588,13 @@ func (e NAV_VTOL_LAND_OPTIONS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// NAV_VTOL_LAND_OPTIONSFromString returns the NAV_VTOL_LAND_OPTIONS value with given name.
+func NAV_VTOL_LAND_OPTIONSFromString(s string) (NAV_VTOL_LAND_OPTIONS, error) {
+	var e NAV_VTOL_LAND_OPTIONS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Yaw behaviour during orbit flight.
 type ORBIT_YAW_BEHAVIOUR int
 
@@ -11627,6 +15659,13 @@ func (e ORBIT_YAW_BEHAVIOUR) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// ORBIT_YAW_BEHAVIOURFromString returns the ORBIT_YAW_BEHAVIOUR value with given name.
+func ORBIT_YAW_BEHAVIOURFromString(s string) (ORBIT_YAW_BEHAVIOUR, error) {
+	var e ORBIT_YAW_BEHAVIOUR
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Parachute actions. Trigger release and enable/disable auto-release.
 type PARACHUTE_ACTION int
 
@@ -11677,6 +15716,13 @@ func (e PARACHUTE_ACTION) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// PARACHUTE_ACTIONFromString returns the PARACHUTE_ACTION value with given name.
+func PARACHUTE_ACTIONFromString(s string) (PARACHUTE_ACTION, error) {
+	var e PARACHUTE_ACTION
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Result from PARAM_EXT_SET message (or a PARAM_SET within a transaction).
 type PARAM_ACK int
 
@@ -11734,6 +15780,13 @@ func (e PARAM_ACK) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// PARAM_ACKFromString returns the PARAM_ACK value with given name.
+func PARAM_ACKFromString(s string) (PARAM_ACK, error) {
+	var e PARAM_ACK
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Possible parameter transaction actions.
 type PARAM_TRANSACTION_ACTION int
 
@@ -11784,6 +15837,13 @@ func (e PARAM_TRANSACTION_ACTION) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// PARAM_TRANSACTION_ACTIONFromString returns the PARAM_TRANSACTION_ACTION value with given name.
+func PARAM_TRANSACTION_ACTIONFromString(s string) (PARAM_TRANSACTION_ACTION, error) {
+	var e PARAM_TRANSACTION_ACTION
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Possible transport layers to set and get parameters via mavlink during a parameter transaction.
 type PARAM_TRANSACTION_TRANSPORT int
 
@@ -11827,6 +15887,13 @@ func (e PARAM_TRANSACTION_TRANSPORT) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// PARAM_TRANSACTION_TRANSPORTFromString returns the PARAM_TRANSACTION_TRANSPORT value with given name.
+func PARAM_TRANSACTION_TRANSPORTFromString(s string) (PARAM_TRANSACTION_TRANSPORT, error) {
+	var e PARAM_TRANSACTION_TRANSPORT
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Bitmap to indicate which dimensions should be ignored by the vehicle: a value of 0b0000000000000000 or 0b0000001000000000 indicates that none of the setpoint dimensions should be ignored. If bit 9 is set the floats afx afy afz should be interpreted as force instead of acceleration.
 type POSITION_TARGET_TYPEMASK int
 
@@ -11940,6 +16007,28 @@ func (e POSITION_TARGET_TYPEMASK) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// POSITION_TARGET_TYPEMASKFromString returns the POSITION_TARGET_TYPEMASK value with given name.
+func POSITION_TARGET_TYPEMASKFromString(s string) (POSITION_TARGET_TYPEMASK, error) {
+	var e POSITION_TARGET_TYPEMASK
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e POSITION_TARGET_TYPEMASK) Has(flags POSITION_TARGET_TYPEMASK) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e POSITION_TARGET_TYPEMASK) Set(flags POSITION_TARGET_TYPEMASK) POSITION_TARGET_TYPEMASK {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e POSITION_TARGET_TYPEMASK) Clear(flags POSITION_TARGET_TYPEMASK) POSITION_TARGET_TYPEMASK {
+	return e &^ flags
+}
+
 // Precision land modes (used in MAV_CMD_NAV_LAND).
 type PRECISION_LAND_MODE int
 
@@ -11990,6 +16079,13 @@ func (e PRECISION_LAND_MODE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// PRECISION_LAND_MODEFromString returns the PRECISION_LAND_MODE value with given name.
+func PRECISION_LAND_MODEFromString(s string) (PRECISION_LAND_MODE, error) {
+	var e PRECISION_LAND_MODE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // RC type
 type RC_TYPE int
 
@@ -12033,6 +16129,13 @@ func (e RC_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// RC_TYPEFromString returns the RC_TYPE value with given name.
+func RC_TYPEFromString(s string) (RC_TYPE, error) {
+	var e RC_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // RTK GPS baseline coordinate system, used for RTK corrections
 type RTK_BASELINE_COORDINATE_SYSTEM int
 
@@ -12076,6 +16179,13 @@ func (e RTK_BASELINE_COORDINATE_SYSTEM) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// RTK_BASELINE_COORDINATE_SYSTEMFromString returns the RTK_BASELINE_COORDINATE_SYSTEM value with given name.
+func RTK_BASELINE_COORDINATE_SYSTEMFromString(s string) (RTK_BASELINE_COORDINATE_SYSTEM, error) {
+	var e RTK_BASELINE_COORDINATE_SYSTEM
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // SERIAL_CONTROL device types
 type SERIAL_CONTROL_DEV int
 
@@ -12210,6 +16320,13 @@ func (e SERIAL_CONTROL_DEV) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// SERIAL_CONTROL_DEVFromString returns the SERIAL_CONTROL_DEV value with given name.
+func SERIAL_CONTROL_DEVFromString(s string) (SERIAL_CONTROL_DEV, error) {
+	var e SERIAL_CONTROL_DEV
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // SERIAL_CONTROL flags (bitmask)
 type SERIAL_CONTROL_FLAG int
 
@@ -12274,6 +16391,28 @@ func (e SERIAL_CONTROL_FLAG) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// SERIAL_CONTROL_FLAGFromString returns the SERIAL_CONTROL_FLAG value with given name.
+func SERIAL_CONTROL_FLAGFromString(s string) (SERIAL_CONTROL_FLAG, error) {
+	var e SERIAL_CONTROL_FLAG
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e SERIAL_CONTROL_FLAG) Has(flags SERIAL_CONTROL_FLAG) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e SERIAL_CONTROL_FLAG) Set(flags SERIAL_CONTROL_FLAG) SERIAL_CONTROL_FLAG {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e SERIAL_CONTROL_FLAG) Clear(flags SERIAL_CONTROL_FLAG) SERIAL_CONTROL_FLAG {
+	return e &^ flags
+}
+
 // Focus types for MAV_CMD_SET_CAMERA_FOCUS
 type SET_FOCUS_TYPE int
 
@@ -12331,6 +16470,13 @@ func (e SET_FOCUS_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// SET_FOCUS_TYPEFromString returns the SET_FOCUS_TYPE value with given name.
+func SET_FOCUS_TYPEFromString(s string) (SET_FOCUS_TYPE, error) {
+	var e SET_FOCUS_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Flags to indicate the status of camera storage.
 type STORAGE_STATUS int
 
@@ -12388,6 +16534,13 @@ func (e STORAGE_STATUS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// STORAGE_STATUSFromString returns the STORAGE_STATUS value with given name.
+func STORAGE_STATUSFromString(s string) (STORAGE_STATUS, error) {
+	var e STORAGE_STATUS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Flags to indicate the type of storage.
 type STORAGE_TYPE int
 
@@ -12480,6 +16633,13 @@ func (e STORAGE_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// STORAGE_TYPEFromString returns the STORAGE_TYPE value with given name.
+func STORAGE_TYPEFromString(s string) (STORAGE_TYPE, error) {
+	var e STORAGE_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Tune formats (used for vehicle buzzer/tone generation).
 type TUNE_FORMAT int
 
@@ -12523,6 +16683,13 @@ func (e TUNE_FORMAT) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// TUNE_FORMATFromString returns the TUNE_FORMAT value with given name.
+func TUNE_FORMATFromString(s string) (TUNE_FORMAT, error) {
+	var e TUNE_FORMAT
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Generalized UAVCAN node health
 type UAVCAN_NODE_HEALTH int
 
@@ -12580,6 +16747,13 @@ func (e UAVCAN_NODE_HEALTH) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// UAVCAN_NODE_HEALTHFromString returns the UAVCAN_NODE_HEALTH value with given name.
+func UAVCAN_NODE_HEALTHFromString(s string) (UAVCAN_NODE_HEALTH, error) {
+	var e UAVCAN_NODE_HEALTH
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Generalized UAVCAN node mode
 type UAVCAN_NODE_MODE int
 
@@ -12644,6 +16818,13 @@ func (e UAVCAN_NODE_MODE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// UAVCAN_NODE_MODEFromString returns the UAVCAN_NODE_MODE value with given name.
+func UAVCAN_NODE_MODEFromString(s string) (UAVCAN_NODE_MODE, error) {
+	var e UAVCAN_NODE_MODE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Flags for the global position report.
 type UTM_DATA_AVAIL_FLAGS int
 
@@ -12729,6 +16910,28 @@ func (e UTM_DATA_AVAIL_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// UTM_DATA_AVAIL_FLAGSFromString returns the UTM_DATA_AVAIL_FLAGS value with given name.
+func UTM_DATA_AVAIL_FLAGSFromString(s string) (UTM_DATA_AVAIL_FLAGS, error) {
+	var e UTM_DATA_AVAIL_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e UTM_DATA_AVAIL_FLAGS) Has(flags UTM_DATA_AVAIL_FLAGS) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e UTM_DATA_AVAIL_FLAGS) Set(flags UTM_DATA_AVAIL_FLAGS) UTM_DATA_AVAIL_FLAGS {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e UTM_DATA_AVAIL_FLAGS) Clear(flags UTM_DATA_AVAIL_FLAGS) UTM_DATA_AVAIL_FLAGS {
+	return e &^ flags
+}
+
 // Airborne status of UAS.
 type UTM_FLIGHT_STATE int
 
@@ -12793,6 +16996,13 @@ func (e UTM_FLIGHT_STATE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// UTM_FLIGHT_STATEFromString returns the UTM_FLIGHT_STATE value with given name.
+func UTM_FLIGHT_STATEFromString(s string) (UTM_FLIGHT_STATE, error) {
+	var e UTM_FLIGHT_STATE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Stream status flags (Bitmap)
 type VIDEO_STREAM_STATUS_FLAGS int
 
@@ -12836,6 +17046,13 @@ func (e VIDEO_STREAM_STATUS_FLAGS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// VIDEO_STREAM_STATUS_FLAGSFromString returns the VIDEO_STREAM_STATUS_FLAGS value with given name.
+func VIDEO_STREAM_STATUS_FLAGSFromString(s string) (VIDEO_STREAM_STATUS_FLAGS, error) {
+	var e VIDEO_STREAM_STATUS_FLAGS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Video stream types
 type VIDEO_STREAM_TYPE int
 
@@ -12893,6 +17110,13 @@ func (e VIDEO_STREAM_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// VIDEO_STREAM_TYPEFromString returns the VIDEO_STREAM_TYPE value with given name.
+func VIDEO_STREAM_TYPEFromString(s string) (VIDEO_STREAM_TYPE, error) {
+	var e VIDEO_STREAM_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Direction of VTOL transition
 type VTOL_TRANSITION_HEADING int
 
@@ -12957,6 +17181,13 @@ func (e VTOL_TRANSITION_HEADING) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// VTOL_TRANSITION_HEADINGFromString returns the VTOL_TRANSITION_HEADING value with given name.
+func VTOL_TRANSITION_HEADINGFromString(s string) (VTOL_TRANSITION_HEADING, error) {
+	var e VTOL_TRANSITION_HEADING
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // WiFi Mode.
 type WIFI_CONFIG_AP_MODE int
 
@@ -13014,6 +17245,13 @@ func (e WIFI_CONFIG_AP_MODE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// WIFI_CONFIG_AP_MODEFromString returns the WIFI_CONFIG_AP_MODE value with given name.
+func WIFI_CONFIG_AP_MODEFromString(s string) (WIFI_CONFIG_AP_MODE, error) {
+	var e WIFI_CONFIG_AP_MODE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Possible responses from a WIFI_CONFIG_AP message.
 type WIFI_CONFIG_AP_RESPONSE int
 
@@ -13085,6 +17323,13 @@ func (e WIFI_CONFIG_AP_RESPONSE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// WIFI_CONFIG_AP_RESPONSEFromString returns the WIFI_CONFIG_AP_RESPONSE value with given name.
+func WIFI_CONFIG_AP_RESPONSEFromString(s string) (WIFI_CONFIG_AP_RESPONSE, error) {
+	var e WIFI_CONFIG_AP_RESPONSE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Winch actions.
 type WINCH_ACTIONS int
 
@@ -13135,6 +17380,13 @@ func (e WINCH_ACTIONS) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// WINCH_ACTIONSFromString returns the WINCH_ACTIONS value with given name.
+func WINCH_ACTIONSFromString(s string) (WINCH_ACTIONS, error) {
+	var e WINCH_ACTIONS
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // minimal.xml
 
 // The heartbeat message shows that a system or component is present and responding. The type and autopilot fields (along with the message component id), allow the receiving system to treat further messages from this system appropriately (e.g. by laying out the user interface based on the autopilot). This microservice is documented at https://mavlink.io/en/services/heartbeat.html
@@ -0,0 +1,37 @@
+package dialects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/dialects/minimal"
+)
+
+func TestConvertMessage(t *testing.T) {
+	de, err := dialect.NewDecEncoder(minimal.Dialect)
+	require.NoError(t, err)
+
+	m, err := de.ConvertMessage(&common.MessageHeartbeat{
+		Type:           common.MAV_TYPE_QUADROTOR,
+		Autopilot:      common.MAV_AUTOPILOT_ARDUPILOTMEGA,
+		BaseMode:       common.MAV_MODE_FLAG_SAFETY_ARMED,
+		CustomMode:     3,
+		SystemStatus:   common.MAV_STATE_ACTIVE,
+		MavlinkVersion: 2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &minimal.MessageHeartbeat{
+		Type:           minimal.MAV_TYPE_QUADROTOR,
+		Autopilot:      minimal.MAV_AUTOPILOT_ARDUPILOTMEGA,
+		BaseMode:       minimal.MAV_MODE_FLAG_SAFETY_ARMED,
+		CustomMode:     3,
+		SystemStatus:   minimal.MAV_STATE_ACTIVE,
+		MavlinkVersion: 2,
+	}, m)
+
+	_, err = de.ConvertMessage(&common.MessageSysStatus{})
+	require.EqualError(t, err, "message 1 is not in the dialect")
+}
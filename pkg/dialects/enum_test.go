@@ -0,0 +1,34 @@
+package dialects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestEnumString(t *testing.T) {
+	require.Equal(t, "MAV_STATE_ACTIVE", common.MAV_STATE_ACTIVE.String())
+	require.Equal(t, "1234", common.MAV_STATE(1234).String())
+}
+
+func TestEnumFromString(t *testing.T) {
+	v, err := common.MAV_STATEFromString("MAV_STATE_ACTIVE")
+	require.NoError(t, err)
+	require.Equal(t, common.MAV_STATE_ACTIVE, v)
+
+	_, err = common.MAV_STATEFromString("MAV_STATE_UNKNOWN")
+	require.Error(t, err)
+}
+
+func TestEnumBitmask(t *testing.T) {
+	v := common.MAV_MODE_FLAG_SAFETY_ARMED.Set(common.MAV_MODE_FLAG_GUIDED_ENABLED)
+	require.True(t, v.Has(common.MAV_MODE_FLAG_SAFETY_ARMED))
+	require.True(t, v.Has(common.MAV_MODE_FLAG_SAFETY_ARMED|common.MAV_MODE_FLAG_GUIDED_ENABLED))
+	require.False(t, v.Has(common.MAV_MODE_FLAG_SAFETY_ARMED|common.MAV_MODE_FLAG_AUTO_ENABLED))
+
+	v = v.Clear(common.MAV_MODE_FLAG_SAFETY_ARMED)
+	require.False(t, v.Has(common.MAV_MODE_FLAG_SAFETY_ARMED))
+	require.Equal(t, common.MAV_MODE_FLAG_GUIDED_ENABLED, v)
+}
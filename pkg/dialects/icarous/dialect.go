@@ -19,7 +19,42 @@ var dial = &dialect.Dialect{0, []msg.Message{
 	&MessageIcarousKinematicBands{},
 }}
 
-//
+// Metadata contains the metadata of the messages of the dialect.
+var Metadata = meta
+
+// metadata is not exposed directly such that it is not displayed in godoc.
+var meta = dialect.Metadata{
+	42000: {
+		Name:        "ICAROUS_HEARTBEAT",
+		Description: "ICAROUS heartbeat",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "status", Type: "uint8_t", Enum: "ICAROUS_FMS_STATE", Description: "See the FMS_STATE enum."},
+		},
+	},
+	42001: {
+		Name:        "ICAROUS_KINEMATIC_BANDS",
+		Description: "Kinematic multi bands (track) output from Daidalus",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "numBands", Type: "int8_t", Description: "Number of track bands"},
+			{Name: "type1", Type: "uint8_t", Enum: "ICAROUS_TRACK_BAND_TYPES", Description: "See the TRACK_BAND_TYPES enum."},
+			{Name: "min1", Type: "float", Description: "min angle (degrees)"},
+			{Name: "max1", Type: "float", Description: "max angle (degrees)"},
+			{Name: "type2", Type: "uint8_t", Enum: "ICAROUS_TRACK_BAND_TYPES", Description: "See the TRACK_BAND_TYPES enum."},
+			{Name: "min2", Type: "float", Description: "min angle (degrees)"},
+			{Name: "max2", Type: "float", Description: "max angle (degrees)"},
+			{Name: "type3", Type: "uint8_t", Enum: "ICAROUS_TRACK_BAND_TYPES", Description: "See the TRACK_BAND_TYPES enum."},
+			{Name: "min3", Type: "float", Description: "min angle (degrees)"},
+			{Name: "max3", Type: "float", Description: "max angle (degrees)"},
+			{Name: "type4", Type: "uint8_t", Enum: "ICAROUS_TRACK_BAND_TYPES", Description: "See the TRACK_BAND_TYPES enum."},
+			{Name: "min4", Type: "float", Description: "min angle (degrees)"},
+			{Name: "max4", Type: "float", Description: "max angle (degrees)"},
+			{Name: "type5", Type: "uint8_t", Enum: "ICAROUS_TRACK_BAND_TYPES", Description: "See the TRACK_BAND_TYPES enum."},
+			{Name: "min5", Type: "float", Description: "min angle (degrees)"},
+			{Name: "max5", Type: "float", Description: "max angle (degrees)"},
+		},
+	},
+}
+
 type ICAROUS_FMS_STATE int
 
 const (
@@ -90,7 +125,13 @@ func (e ICAROUS_FMS_STATE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// ICAROUS_FMS_STATEFromString returns the ICAROUS_FMS_STATE value with given name.
+func ICAROUS_FMS_STATEFromString(s string) (ICAROUS_FMS_STATE, error) {
+	var e ICAROUS_FMS_STATE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 type ICAROUS_TRACK_BAND_TYPES int
 
 const (
@@ -140,6 +181,13 @@ func (e ICAROUS_TRACK_BAND_TYPES) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// ICAROUS_TRACK_BAND_TYPESFromString returns the ICAROUS_TRACK_BAND_TYPES value with given name.
+func ICAROUS_TRACK_BAND_TYPESFromString(s string) (ICAROUS_TRACK_BAND_TYPES, error) {
+	var e ICAROUS_TRACK_BAND_TYPES
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // icarous.xml
 
 // ICAROUS heartbeat
@@ -0,0 +1,50 @@
+package dialects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+func TestMetadata(t *testing.T) {
+	md, ok := common.Metadata[(&common.MessageHeartbeat{}).GetID()]
+	require.True(t, ok)
+	require.Equal(t, "HEARTBEAT", md.Name)
+	require.Equal(t, 6, len(md.Fields))
+
+	f, ok := common.Metadata.Field(0, "system_status")
+	require.True(t, ok)
+	require.Equal(t, "uint8_t", f.Type)
+	require.Equal(t, "MAV_STATE", f.Enum)
+
+	f, ok = common.Metadata.Field(22, "param_id")
+	require.True(t, ok)
+	require.Equal(t, &dialect.FieldMetadata{
+		Name:        "param_id",
+		Type:        "char",
+		ArrayLength: 16,
+		Description: f.Description,
+	}, f)
+
+	_, ok = common.Metadata.Field(0, "unknown")
+	require.False(t, ok)
+}
+
+func TestFormat(t *testing.T) {
+	m := &common.MessageHeartbeat{
+		Type:           common.MAV_TYPE_QUADROTOR,
+		Autopilot:      common.MAV_AUTOPILOT_PX4,
+		BaseMode:       common.MAV_MODE_FLAG_SAFETY_ARMED,
+		CustomMode:     3,
+		SystemStatus:   common.MAV_STATE_ACTIVE,
+		MavlinkVersion: 3,
+	}
+
+	require.Equal(t, "HEARTBEAT {type: MAV_TYPE_QUADROTOR, autopilot: MAV_AUTOPILOT_PX4, "+
+		"base_mode: MAV_MODE_FLAG_SAFETY_ARMED, custom_mode: 3, system_status: MAV_STATE_ACTIVE, "+
+		"mavlink_version: 3}",
+		dialect.Format(m, common.Metadata))
+}
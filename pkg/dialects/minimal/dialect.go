@@ -19,6 +19,36 @@ var dial = &dialect.Dialect{3, []msg.Message{
 	&MessageProtocolVersion{},
 }}
 
+// Metadata contains the metadata of the messages of the dialect.
+var Metadata = meta
+
+// metadata is not exposed directly such that it is not displayed in godoc.
+var meta = dialect.Metadata{
+	0: {
+		Name:        "HEARTBEAT",
+		Description: "The heartbeat message shows that a system or component is present and responding. The type and autopilot fields (along with the message component id), allow the receiving system to treat further messages from this system appropriately (e.g. by laying out the user interface based on the autopilot). This microservice is documented at https://mavlink.io/en/services/heartbeat.html",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "type", Type: "uint8_t", Enum: "MAV_TYPE", Description: "Vehicle or component type. For a flight controller component the vehicle type (quadrotor, helicopter, etc.). For other components the component type (e.g. camera, gimbal, etc.). This should be used in preference to component id for identifying the component type."},
+			{Name: "autopilot", Type: "uint8_t", Enum: "MAV_AUTOPILOT", Description: "Autopilot type / class. Use MAV_AUTOPILOT_INVALID for components that are not flight controllers."},
+			{Name: "base_mode", Type: "uint8_t", Enum: "MAV_MODE_FLAG", Description: "System mode bitmap."},
+			{Name: "custom_mode", Type: "uint32_t", Description: "A bitfield for use for autopilot-specific flags"},
+			{Name: "system_status", Type: "uint8_t", Enum: "MAV_STATE", Description: "System status flag."},
+			{Name: "mavlink_version", Type: "uint8_t", Description: "MAVLink version, not writable by user, gets added by protocol because of magic data type: uint8_t_mavlink_version"},
+		},
+	},
+	300: {
+		Name:        "PROTOCOL_VERSION",
+		Description: "Version and capability of protocol version. This message can be requested with MAV_CMD_REQUEST_MESSAGE and is used as part of the handshaking to establish which MAVLink version should be used on the network. Every node should respond to a request for PROTOCOL_VERSION to enable the handshaking. Library implementers should consider adding this into the default decoding state machine to allow the protocol core to respond directly.",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "version", Type: "uint16_t", Description: "Currently active MAVLink version number * 100: v1.0 is 100, v2.0 is 200, etc."},
+			{Name: "min_version", Type: "uint16_t", Description: "Minimum MAVLink version supported"},
+			{Name: "max_version", Type: "uint16_t", Description: "Maximum MAVLink version supported (set to the same value as version by default)"},
+			{Name: "spec_version_hash", Type: "uint8_t", ArrayLength: 8, Description: "The first 8 bytes (not characters printed in hex!) of the git hash."},
+			{Name: "library_version_hash", Type: "uint8_t", ArrayLength: 8, Description: "The first 8 bytes (not characters printed in hex!) of the git hash."},
+		},
+	},
+}
+
 // Micro air vehicle / autopilot classes. This identifies the individual model.
 type MAV_AUTOPILOT int
 
@@ -188,6 +218,13 @@ func (e MAV_AUTOPILOT) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_AUTOPILOTFromString returns the MAV_AUTOPILOT value with given name.
+func MAV_AUTOPILOTFromString(s string) (MAV_AUTOPILOT, error) {
+	var e MAV_AUTOPILOT
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // Component ids (values) for the different types and instances of onboard hardware/software that might make up a MAVLink system (autopilot, cameras, servos, GPS systems, avoidance systems etc.).      Components must use the appropriate ID in their source address when sending messages. Components can also use IDs to determine if they are the intended recipient of an incoming message. The MAV_COMP_ID_ALL value is used to indicate messages that must be processed by all components.      When creating new entries, components that can have multiple instances (e.g. cameras, servos etc.) should be allocated sequential values. An appropriate number of values should be left free after these components to allow the number of instances to be expanded.
 type MAV_COMPONENT int
 
@@ -1148,6 +1185,13 @@ func (e MAV_COMPONENT) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_COMPONENTFromString returns the MAV_COMPONENT value with given name.
+func MAV_COMPONENTFromString(s string) (MAV_COMPONENT, error) {
+	var e MAV_COMPONENT
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // These flags encode the MAV mode.
 type MAV_MODE_FLAG int
 
@@ -1233,6 +1277,28 @@ func (e MAV_MODE_FLAG) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_MODE_FLAGFromString returns the MAV_MODE_FLAG value with given name.
+func MAV_MODE_FLAGFromString(s string) (MAV_MODE_FLAG, error) {
+	var e MAV_MODE_FLAG
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e MAV_MODE_FLAG) Has(flags MAV_MODE_FLAG) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e MAV_MODE_FLAG) Set(flags MAV_MODE_FLAG) MAV_MODE_FLAG {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e MAV_MODE_FLAG) Clear(flags MAV_MODE_FLAG) MAV_MODE_FLAG {
+	return e &^ flags
+}
+
 // These values encode the bit positions of the decode position. These values can be used to read the value of a flag bit by combining the base_mode variable with AND with the flag position value. The result will be either 0 or 1, depending on if the flag is set or not.
 type MAV_MODE_FLAG_DECODE_POSITION int
 
@@ -1318,7 +1384,28 @@ func (e MAV_MODE_FLAG_DECODE_POSITION) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
-//
+// MAV_MODE_FLAG_DECODE_POSITIONFromString returns the MAV_MODE_FLAG_DECODE_POSITION value with given name.
+func MAV_MODE_FLAG_DECODE_POSITIONFromString(s string) (MAV_MODE_FLAG_DECODE_POSITION, error) {
+	var e MAV_MODE_FLAG_DECODE_POSITION
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
+// Has returns whether all the given flags are set.
+func (e MAV_MODE_FLAG_DECODE_POSITION) Has(flags MAV_MODE_FLAG_DECODE_POSITION) bool {
+	return e&flags == flags
+}
+
+// Set returns the value with the given flags set.
+func (e MAV_MODE_FLAG_DECODE_POSITION) Set(flags MAV_MODE_FLAG_DECODE_POSITION) MAV_MODE_FLAG_DECODE_POSITION {
+	return e | flags
+}
+
+// Clear returns the value with the given flags cleared.
+func (e MAV_MODE_FLAG_DECODE_POSITION) Clear(flags MAV_MODE_FLAG_DECODE_POSITION) MAV_MODE_FLAG_DECODE_POSITION {
+	return e &^ flags
+}
+
 type MAV_STATE int
 
 const (
@@ -1410,6 +1497,13 @@ func (e MAV_STATE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_STATEFromString returns the MAV_STATE value with given name.
+func MAV_STATEFromString(s string) (MAV_STATE, error) {
+	var e MAV_STATE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // MAVLINK component type reported in HEARTBEAT message. Flight controllers must report the type of the vehicle on which they are mounted (e.g. MAV_TYPE_OCTOROTOR). All other components must report a value appropriate for their type (e.g. a camera must use MAV_TYPE_CAMERA).
 type MAV_TYPE int
 
@@ -1705,6 +1799,13 @@ func (e MAV_TYPE) String() string {
 	return strconv.FormatInt(int64(e), 10)
 }
 
+// MAV_TYPEFromString returns the MAV_TYPE value with given name.
+func MAV_TYPEFromString(s string) (MAV_TYPE, error) {
+	var e MAV_TYPE
+	err := e.UnmarshalText([]byte(s))
+	return e, err
+}
+
 // minimal.xml
 
 // The heartbeat message shows that a system or component is present and responding. The type and autopilot fields (along with the message component id), allow the receiving system to treat further messages from this system appropriately (e.g. by laying out the user interface based on the autopilot). This microservice is documented at https://mavlink.io/en/services/heartbeat.html
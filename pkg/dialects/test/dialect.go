@@ -15,6 +15,41 @@ var dial = &dialect.Dialect{3, []msg.Message{
 	&MessageTestTypes{},
 }}
 
+// Metadata contains the metadata of the messages of the dialect.
+var Metadata = meta
+
+// metadata is not exposed directly such that it is not displayed in godoc.
+var meta = dialect.Metadata{
+	17000: {
+		Name:        "TEST_TYPES",
+		Description: "Test all field types",
+		Fields: []*dialect.FieldMetadata{
+			{Name: "c", Type: "char", Description: "char"},
+			{Name: "s", Type: "char", ArrayLength: 10, Description: "string"},
+			{Name: "u8", Type: "uint8_t", Description: "uint8_t"},
+			{Name: "u16", Type: "uint16_t", Description: "uint16_t"},
+			{Name: "u32", Type: "uint32_t", Description: "uint32_t"},
+			{Name: "u64", Type: "uint64_t", Description: "uint64_t"},
+			{Name: "s8", Type: "int8_t", Description: "int8_t"},
+			{Name: "s16", Type: "int16_t", Description: "int16_t"},
+			{Name: "s32", Type: "int32_t", Description: "int32_t"},
+			{Name: "s64", Type: "int64_t", Description: "int64_t"},
+			{Name: "f", Type: "float", Description: "float"},
+			{Name: "d", Type: "double", Description: "double"},
+			{Name: "u8_array", Type: "uint8_t", ArrayLength: 3, Description: "uint8_t_array"},
+			{Name: "u16_array", Type: "uint16_t", ArrayLength: 3, Description: "uint16_t_array"},
+			{Name: "u32_array", Type: "uint32_t", ArrayLength: 3, Description: "uint32_t_array"},
+			{Name: "u64_array", Type: "uint64_t", ArrayLength: 3, Description: "uint64_t_array"},
+			{Name: "s8_array", Type: "int8_t", ArrayLength: 3, Description: "int8_t_array"},
+			{Name: "s16_array", Type: "int16_t", ArrayLength: 3, Description: "int16_t_array"},
+			{Name: "s32_array", Type: "int32_t", ArrayLength: 3, Description: "int32_t_array"},
+			{Name: "s64_array", Type: "int64_t", ArrayLength: 3, Description: "int64_t_array"},
+			{Name: "f_array", Type: "float", ArrayLength: 3, Description: "float_array"},
+			{Name: "d_array", Type: "double", ArrayLength: 3, Description: "double_array"},
+		},
+	},
+}
+
 // test.xml
 
 // Test all field types
@@ -0,0 +1,187 @@
+package endpointtest
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestPipe(t *testing.T) {
+	rwc1, rwc2 := Pipe()
+
+	// writes don't block
+	_, err := rwc1.Write([]byte{1, 2, 3})
+	require.NoError(t, err)
+	_, err = rwc1.Write([]byte{4})
+	require.NoError(t, err)
+
+	buf := make([]byte, 2)
+	n, err := rwc2.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2}, buf[:n])
+
+	rwc1.Close()
+
+	// pending data is read before EOF
+	n, err = rwc2.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, []byte{3}, buf[:n])
+
+	n, err = rwc2.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, []byte{4}, buf[:n])
+
+	_, err = rwc2.Read(buf)
+	require.Equal(t, io.EOF, err)
+
+	_, err = rwc2.Write([]byte{1})
+	require.Equal(t, io.ErrClosedPipe, err)
+}
+
+func TestEndpointPipe(t *testing.T) {
+	e1, e2 := EndpointPipe()
+
+	node1, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      10,
+		Endpoints:        []gomavlib.EndpointConf{e1},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      11,
+		Endpoints:        []gomavlib.EndpointConf{e2},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	// events of node2 are not read
+	for i := 0; i < 100; i++ {
+		node2.WriteMessageAll(&common.MessageSystemTime{TimeBootMs: uint32(i)})
+	}
+
+	for evt := range node1.Events() {
+		if fr, ok := evt.(*gomavlib.EventFrame); ok {
+			require.Equal(t, &common.MessageSystemTime{TimeBootMs: 0}, fr.Message())
+			break
+		}
+	}
+}
+
+func TestPeer(t *testing.T) {
+	peer, endpoint, err := NewPeer(PeerConf{
+		Dialect:     common.Dialect,
+		OutSystemID: 1,
+	})
+	require.NoError(t, err)
+	defer peer.Close()
+
+	// the peer acknowledges commands
+	peer.Respond(&common.MessageCommandLong{}, func(fr frame.Frame) []msg.Message {
+		m := fr.GetMessage().(*common.MessageCommandLong)
+		return []msg.Message{&common.MessageCommandAck{
+			Command:         m.Command,
+			Result:          common.MAV_RESULT_ACCEPTED,
+			TargetSystem:    fr.GetSystemID(),
+			TargetComponent: fr.GetComponentID(),
+		}}
+	})
+
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:         common.Dialect,
+		OutVersion:      gomavlib.V2,
+		OutSystemID:     255,
+		Endpoints:       []gomavlib.EndpointConf{endpoint},
+		HeartbeatPeriod: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	go func() {
+		for range node.Events() {
+		}
+	}()
+
+	fr := peer.ExpectFrame(t, func(fr frame.Frame) bool {
+		_, ok := fr.GetMessage().(*common.MessageHeartbeat)
+		return ok
+	})
+	require.Equal(t, byte(255), fr.GetSystemID())
+
+	ack, err := node.SendCommand(context.Background(), &gomavlib.CommandRequest{
+		TargetSystem:    1,
+		TargetComponent: 1,
+		Command:         int(common.MAV_CMD_COMPONENT_ARM_DISARM),
+		Params:          [7]float32{1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, ack.Result)
+
+	peer.ExpectMessage(t, &common.MessageCommandLong{
+		TargetSystem:    1,
+		TargetComponent: 1,
+		Command:         common.MAV_CMD_COMPONENT_ARM_DISARM,
+		Param1:          1,
+	})
+
+	peer.ExpectNoMessage(t, &common.MessageCommandLong{}, 100*time.Millisecond)
+}
+
+type testT struct {
+	failed bool
+}
+
+func (t *testT) Helper() {}
+
+func (t *testT) Fatalf(format string, args ...interface{}) {
+	t.failed = true
+}
+
+func TestPeerExpectFailure(t *testing.T) {
+	peer, endpoint, err := NewPeer(PeerConf{
+		Dialect:     common.Dialect,
+		OutSystemID: 1,
+		Timeout:     200 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer peer.Close()
+
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      255,
+		Endpoints:        []gomavlib.EndpointConf{endpoint},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	node.WriteMessageAll(&common.MessageSystemTime{TimeBootMs: 1})
+
+	ft := &testT{}
+	peer.ExpectMessage(ft, &common.MessageSystemTime{TimeBootMs: 2})
+	require.True(t, ft.failed)
+
+	ft = &testT{}
+	peer.ExpectMessage(ft, &common.MessageSystemTime{TimeBootMs: 2})
+	require.True(t, ft.failed)
+}
+
+func TestPeerError(t *testing.T) {
+	_, _, err := NewPeer(PeerConf{})
+	require.EqualError(t, err, "OutSystemID must be >= 1")
+}
@@ -0,0 +1,284 @@
+package endpointtest
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+// TestingT is the subset of testing.TB used by assertions.
+type TestingT interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+}
+
+// PeerConf allows to configure a Peer.
+type PeerConf struct {
+	// (optional) the dialect used to decode and encode messages.
+	// If not provided, messages are exchanged as msg.MessageRaw.
+	Dialect *dialect.Dialect
+
+	// (optional) Mavlink version used to write messages.
+	// It defaults to V2.
+	OutVersion gomavlib.Version
+
+	// the system id of the peer.
+	OutSystemID byte
+
+	// (optional) the component id of the peer.
+	// It defaults to 1.
+	OutComponentID byte
+
+	// (optional) the maximum time waited by assertions.
+	// It defaults to 5 seconds.
+	Timeout time.Duration
+}
+
+type responder struct {
+	typ reflect.Type
+	cb  func(frame.Frame) []msg.Message
+}
+
+// Peer is a simulated Mavlink device, connected to a node through an
+// in-memory endpoint. It can write messages, respond automatically to
+// received messages and check the frames written by the node.
+type Peer struct {
+	conf        PeerConf
+	rwc         io.ReadWriteCloser
+	transceiver *transceiver.Transceiver
+
+	writeMutex sync.Mutex
+	mutex      sync.Mutex
+	responders []responder
+	frames     []frame.Frame
+	err        error
+	notify     chan struct{}
+	done       chan struct{}
+}
+
+// NewPeer allocates a Peer and returns it, together with the endpoint that
+// must be added to the node under test.
+func NewPeer(conf PeerConf) (*Peer, gomavlib.EndpointConf, error) {
+	if conf.OutVersion == 0 {
+		conf.OutVersion = gomavlib.V2
+	}
+	if conf.OutSystemID < 1 {
+		return nil, nil, fmt.Errorf("OutSystemID must be >= 1")
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = 5 * time.Second
+	}
+
+	var de *dialect.DecEncoder
+	if conf.Dialect != nil {
+		var err error
+		de, err = dialect.NewDecEncoder(conf.Dialect)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	rwc, nodeRWC := Pipe()
+
+	t, err := transceiver.New(transceiver.Conf{
+		Reader:         rwc,
+		Writer:         rwc,
+		DialectDE:      de,
+		OutVersion:     transceiver.Version(conf.OutVersion),
+		OutSystemID:    conf.OutSystemID,
+		OutComponentID: conf.OutComponentID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := &Peer{
+		conf:        conf,
+		rwc:         rwc,
+		transceiver: t,
+		notify:      make(chan struct{}, 1),
+		done:        make(chan struct{}),
+	}
+
+	go p.run()
+
+	return p, gomavlib.EndpointCustom{ReadWriteCloser: nodeRWC}, nil
+}
+
+// Close closes the peer and its endpoint.
+func (p *Peer) Close() {
+	p.rwc.Close()
+	<-p.done
+}
+
+func (p *Peer) run() {
+	defer close(p.done)
+
+	for {
+		fr, err := p.transceiver.Read()
+		if err != nil {
+			// skip invalid frames
+			if _, ok := err.(*transceiver.Error); ok {
+				continue
+			}
+
+			p.mutex.Lock()
+			p.err = err
+			p.mutex.Unlock()
+			p.signal()
+			return
+		}
+
+		for _, m := range p.respond(fr) {
+			p.WriteMessage(m) //nolint:errcheck
+		}
+
+		p.mutex.Lock()
+		p.frames = append(p.frames, fr)
+		p.mutex.Unlock()
+		p.signal()
+	}
+}
+
+func (p *Peer) signal() {
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (p *Peer) respond(fr frame.Frame) []msg.Message {
+	p.mutex.Lock()
+	responders := p.responders
+	p.mutex.Unlock()
+
+	var out []msg.Message
+	for _, r := range responders {
+		if reflect.TypeOf(fr.GetMessage()) == r.typ {
+			out = append(out, r.cb(fr)...)
+		}
+	}
+	return out
+}
+
+// Respond sets a callback that is invoked when a message with the same type
+// of m is received. The messages returned by the callback are written
+// to the node. It allows to script the behavior of the peer.
+func (p *Peer) Respond(m msg.Message, cb func(fr frame.Frame) []msg.Message) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.responders = append(p.responders, responder{reflect.TypeOf(m), cb})
+}
+
+// WriteMessage writes a message to the node.
+func (p *Peer) WriteMessage(m msg.Message) error {
+	p.writeMutex.Lock()
+	defer p.writeMutex.Unlock()
+	return p.transceiver.WriteMessage(m)
+}
+
+// WriteFrame writes a frame to the node, without changing it.
+func (p *Peer) WriteFrame(fr frame.Frame) error {
+	p.writeMutex.Lock()
+	defer p.writeMutex.Unlock()
+	return p.transceiver.WriteFrame(fr)
+}
+
+// ReadFrame returns the next frame written by the node.
+// It returns an error if no frame is received within d.
+func (p *Peer) ReadFrame(d time.Duration) (frame.Frame, error) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	for {
+		p.mutex.Lock()
+		if len(p.frames) != 0 {
+			fr := p.frames[0]
+			p.frames = p.frames[1:]
+			p.mutex.Unlock()
+			return fr, nil
+		}
+		err := p.err
+		p.mutex.Unlock()
+
+		if err != nil {
+			return nil, err
+		}
+
+		select {
+		case <-p.notify:
+		case <-timer.C:
+			return nil, fmt.Errorf("timed out")
+		}
+	}
+}
+
+// ExpectFrame reads frames until it finds one that satisfies match,
+// and returns it. Frames that do not satisfy match are discarded.
+// The test fails if no frame is found within the timeout.
+func (p *Peer) ExpectFrame(t TestingT, match func(fr frame.Frame) bool) frame.Frame {
+	t.Helper()
+
+	deadline := time.Now().Add(p.conf.Timeout)
+
+	for {
+		fr, err := p.ReadFrame(time.Until(deadline))
+		if err != nil {
+			t.Fatalf("expected frame not received: %v", err)
+			return nil
+		}
+
+		if match(fr) {
+			return fr
+		}
+	}
+}
+
+// ExpectMessage reads frames until it finds a message with the same type of
+// m, checks that it is equal to m and returns its frame.
+// Other messages are discarded.
+func (p *Peer) ExpectMessage(t TestingT, m msg.Message) frame.Frame {
+	t.Helper()
+
+	fr := p.ExpectFrame(t, func(fr frame.Frame) bool {
+		return reflect.TypeOf(fr.GetMessage()) == reflect.TypeOf(m)
+	})
+	if fr == nil {
+		return nil
+	}
+
+	if !reflect.DeepEqual(fr.GetMessage(), m) {
+		t.Fatalf("unexpected message: got %+v, expected %+v", fr.GetMessage(), m)
+		return nil
+	}
+
+	return fr
+}
+
+// ExpectNoMessage checks that no message with the same type of m is received
+// within d. Other messages are discarded.
+func (p *Peer) ExpectNoMessage(t TestingT, m msg.Message, d time.Duration) {
+	t.Helper()
+
+	deadline := time.Now().Add(d)
+
+	for {
+		fr, err := p.ReadFrame(time.Until(deadline))
+		if err != nil {
+			return
+		}
+
+		if reflect.TypeOf(fr.GetMessage()) == reflect.TypeOf(m) {
+			t.Fatalf("unexpected message: %+v", fr.GetMessage())
+			return
+		}
+	}
+}
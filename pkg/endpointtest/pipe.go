@@ -0,0 +1,108 @@
+// Package endpointtest contains utilities to test code that uses gomavlib
+// without opening network ports or serial devices: in-memory endpoints,
+// scripted peers and assertions on received frames.
+package endpointtest
+
+import (
+	"io"
+	"sync"
+
+	"github.com/aler9/gomavlib"
+)
+
+// pipeBuffer is a unidirectional, unbounded buffer.
+// Writes never block, therefore nodes connected through a pipe don't need
+// to read their events in order to write.
+type pipeBuffer struct {
+	mutex  sync.Mutex
+	cond   *sync.Cond
+	queue  [][]byte
+	closed bool
+}
+
+func newPipeBuffer() *pipeBuffer {
+	b := &pipeBuffer{}
+	b.cond = sync.NewCond(&b.mutex)
+	return b
+}
+
+func (b *pipeBuffer) write(buf []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	// buffer is reused by the writer, copy it
+	b.queue = append(b.queue, append([]byte(nil), buf...))
+	b.cond.Signal()
+	return len(buf), nil
+}
+
+func (b *pipeBuffer) read(buf []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for len(b.queue) == 0 && !b.closed {
+		b.cond.Wait()
+	}
+
+	if len(b.queue) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(buf, b.queue[0])
+	if n < len(b.queue[0]) {
+		b.queue[0] = b.queue[0][n:]
+	} else {
+		b.queue = b.queue[1:]
+	}
+	return n, nil
+}
+
+func (b *pipeBuffer) close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.closed = true
+	b.cond.Broadcast()
+}
+
+// pipeEnd is one end of a pipe.
+type pipeEnd struct {
+	r *pipeBuffer
+	w *pipeBuffer
+}
+
+func (e *pipeEnd) Read(buf []byte) (int, error) {
+	return e.r.read(buf)
+}
+
+func (e *pipeEnd) Write(buf []byte) (int, error) {
+	return e.w.write(buf)
+}
+
+// Close closes both directions of the pipe.
+// Pending data can still be read by the other end.
+func (e *pipeEnd) Close() error {
+	e.r.close()
+	e.w.close()
+	return nil
+}
+
+// Pipe returns two connected in-memory streams.
+// Writes never block; when one of the streams is closed, the other one
+// returns io.EOF after the pending data has been read.
+func Pipe() (io.ReadWriteCloser, io.ReadWriteCloser) {
+	b1 := newPipeBuffer()
+	b2 := newPipeBuffer()
+	return &pipeEnd{r: b1, w: b2}, &pipeEnd{r: b2, w: b1}
+}
+
+// EndpointPipe returns two endpoints connected by an in-memory pipe,
+// that can be used to connect two nodes. See Pipe.
+func EndpointPipe() (gomavlib.EndpointCustom, gomavlib.EndpointCustom) {
+	rwc1, rwc2 := Pipe()
+	return gomavlib.EndpointCustom{ReadWriteCloser: rwc1},
+		gomavlib.EndpointCustom{ReadWriteCloser: rwc2}
+}
@@ -0,0 +1,197 @@
+package fakevehicle
+
+import (
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type missionUpload struct {
+	channel     *gomavlib.Channel
+	systemID    byte
+	componentID byte
+	missionType common.MAV_MISSION_TYPE
+	items       []*gomavlib.MissionItem
+	count       int
+	last        time.Time
+}
+
+func copyMission(items []*gomavlib.MissionItem) []*gomavlib.MissionItem {
+	out := make([]*gomavlib.MissionItem, len(items))
+	for i, it := range items {
+		cp := *it
+		out[i] = &cp
+	}
+	return out
+}
+
+func boolToUint8(v bool) uint8 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// Mission returns the current mission, geofence or rally point list
+// of the vehicle, depending on the mission type.
+func (v *Vehicle) Mission(missionType common.MAV_MISSION_TYPE) []*gomavlib.MissionItem {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return copyMission(v.missions[missionType])
+}
+
+func (v *Vehicle) missionAck(fr *gomavlib.EventFrame, missionType common.MAV_MISSION_TYPE,
+	res common.MAV_MISSION_RESULT) *common.MessageMissionAck {
+	return &common.MessageMissionAck{
+		TargetSystem:    fr.SystemID(),
+		TargetComponent: fr.ComponentID(),
+		Type:            res,
+		MissionType:     missionType,
+	}
+}
+
+func (v *Vehicle) missionRequest(fr *gomavlib.EventFrame, missionType common.MAV_MISSION_TYPE,
+	seq int) *common.MessageMissionRequestInt {
+	return &common.MessageMissionRequestInt{
+		TargetSystem:    fr.SystemID(),
+		TargetComponent: fr.ComponentID(),
+		Seq:             uint16(seq),
+		MissionType:     missionType,
+	}
+}
+
+func (v *Vehicle) onMissionCount(fr *gomavlib.EventFrame, m *common.MessageMissionCount) []msg.Message {
+	if m.Count == 0 {
+		return v.completeMissionUpload(fr, m.MissionType, nil)
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	// a new upload replaces the current one
+	v.upload = &missionUpload{
+		channel:     fr.Channel,
+		systemID:    fr.SystemID(),
+		componentID: fr.ComponentID(),
+		missionType: m.MissionType,
+		count:       int(m.Count),
+		last:        time.Now(),
+	}
+
+	return []msg.Message{v.missionRequest(fr, m.MissionType, 0)}
+}
+
+func (v *Vehicle) onMissionItemInt(fr *gomavlib.EventFrame, m *common.MessageMissionItemInt) []msg.Message {
+	v.mutex.Lock()
+
+	u := v.upload
+	if u == nil || u.channel != fr.Channel || u.systemID != fr.SystemID() ||
+		u.componentID != fr.ComponentID() || u.missionType != m.MissionType {
+		v.mutex.Unlock()
+		return nil
+	}
+
+	if time.Since(u.last) >= v.conf.MissionUploadTimeout {
+		v.upload = nil
+		v.mutex.Unlock()
+		return nil
+	}
+
+	// the item has already been received or is out of order:
+	// request the expected one again.
+	if int(m.Seq) != len(u.items) {
+		v.mutex.Unlock()
+		return []msg.Message{v.missionRequest(fr, u.missionType, len(u.items))}
+	}
+
+	u.items = append(u.items, &gomavlib.MissionItem{
+		Frame:        int(m.Frame),
+		Command:      int(m.Command),
+		Current:      m.Current != 0,
+		Autocontinue: m.Autocontinue != 0,
+		Params:       [4]float32{m.Param1, m.Param2, m.Param3, m.Param4},
+		X:            m.X,
+		Y:            m.Y,
+		Z:            m.Z,
+	})
+	u.last = time.Now()
+
+	if len(u.items) < u.count {
+		v.mutex.Unlock()
+		return []msg.Message{v.missionRequest(fr, u.missionType, len(u.items))}
+	}
+
+	v.upload = nil
+	v.mutex.Unlock()
+
+	return v.completeMissionUpload(fr, u.missionType, u.items)
+}
+
+func (v *Vehicle) completeMissionUpload(fr *gomavlib.EventFrame, missionType common.MAV_MISSION_TYPE,
+	items []*gomavlib.MissionItem) []msg.Message {
+	res := common.MAV_MISSION_ACCEPTED
+	if v.conf.OnMissionUpload != nil {
+		res = v.conf.OnMissionUpload(missionType, copyMission(items))
+	}
+
+	if res == common.MAV_MISSION_ACCEPTED {
+		v.mutex.Lock()
+		v.missions[missionType] = items
+		v.mutex.Unlock()
+	}
+
+	return []msg.Message{v.missionAck(fr, missionType, res)}
+}
+
+func (v *Vehicle) onMissionRequestList(fr *gomavlib.EventFrame,
+	m *common.MessageMissionRequestList) []msg.Message {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return []msg.Message{&common.MessageMissionCount{
+		TargetSystem:    fr.SystemID(),
+		TargetComponent: fr.ComponentID(),
+		Count:           uint16(len(v.missions[m.MissionType])),
+		MissionType:     m.MissionType,
+	}}
+}
+
+func (v *Vehicle) onMissionRequest(fr *gomavlib.EventFrame, seq uint16,
+	missionType common.MAV_MISSION_TYPE) []msg.Message {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	items := v.missions[missionType]
+	if int(seq) >= len(items) {
+		return []msg.Message{v.missionAck(fr, missionType, common.MAV_MISSION_INVALID_SEQUENCE)}
+	}
+
+	it := items[seq]
+	return []msg.Message{&common.MessageMissionItemInt{
+		TargetSystem:    fr.SystemID(),
+		TargetComponent: fr.ComponentID(),
+		Seq:             seq,
+		Frame:           common.MAV_FRAME(it.Frame),
+		Command:         common.MAV_CMD(it.Command),
+		Current:         boolToUint8(it.Current),
+		Autocontinue:    boolToUint8(it.Autocontinue),
+		Param1:          it.Params[0],
+		Param2:          it.Params[1],
+		Param3:          it.Params[2],
+		Param4:          it.Params[3],
+		X:               it.X,
+		Y:               it.Y,
+		Z:               it.Z,
+		MissionType:     missionType,
+	}}
+}
+
+func (v *Vehicle) onMissionClearAll(fr *gomavlib.EventFrame, m *common.MessageMissionClearAll) []msg.Message {
+	v.mutex.Lock()
+	delete(v.missions, m.MissionType)
+	v.mutex.Unlock()
+
+	return []msg.Message{v.missionAck(fr, m.MissionType, common.MAV_MISSION_ACCEPTED)}
+}
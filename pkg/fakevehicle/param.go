@@ -0,0 +1,169 @@
+package fakevehicle
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// paramBytewise returns whether integer parameters are copied bytewise
+// into floats, like PX4 does, instead of being casted, like Ardupilot does.
+func (v *Vehicle) paramBytewise() bool {
+	return v.conf.Autopilot != common.MAV_AUTOPILOT_ARDUPILOTMEGA
+}
+
+func (v *Vehicle) paramEncode(typ common.MAV_PARAM_TYPE, val float64) float32 {
+	if !v.paramBytewise() {
+		return float32(val)
+	}
+
+	var buf [4]byte
+	switch typ {
+	case common.MAV_PARAM_TYPE_UINT8:
+		buf[0] = uint8(val)
+
+	case common.MAV_PARAM_TYPE_INT8:
+		buf[0] = uint8(int8(val))
+
+	case common.MAV_PARAM_TYPE_UINT16:
+		binary.LittleEndian.PutUint16(buf[:], uint16(val))
+
+	case common.MAV_PARAM_TYPE_INT16:
+		binary.LittleEndian.PutUint16(buf[:], uint16(int16(val)))
+
+	case common.MAV_PARAM_TYPE_UINT32:
+		binary.LittleEndian.PutUint32(buf[:], uint32(val))
+
+	case common.MAV_PARAM_TYPE_INT32:
+		binary.LittleEndian.PutUint32(buf[:], uint32(int32(val)))
+
+	default:
+		return float32(val)
+	}
+
+	return math.Float32frombits(binary.LittleEndian.Uint32(buf[:]))
+}
+
+func (v *Vehicle) paramDecode(typ common.MAV_PARAM_TYPE, val float32) float64 {
+	if !v.paramBytewise() {
+		return float64(val)
+	}
+
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], math.Float32bits(val))
+
+	switch typ {
+	case common.MAV_PARAM_TYPE_UINT8:
+		return float64(buf[0])
+
+	case common.MAV_PARAM_TYPE_INT8:
+		return float64(int8(buf[0]))
+
+	case common.MAV_PARAM_TYPE_UINT16:
+		return float64(binary.LittleEndian.Uint16(buf[:]))
+
+	case common.MAV_PARAM_TYPE_INT16:
+		return float64(int16(binary.LittleEndian.Uint16(buf[:])))
+
+	case common.MAV_PARAM_TYPE_UINT32:
+		return float64(binary.LittleEndian.Uint32(buf[:]))
+
+	case common.MAV_PARAM_TYPE_INT32:
+		return float64(int32(binary.LittleEndian.Uint32(buf[:])))
+	}
+
+	return float64(val)
+}
+
+// paramValue must be called with the mutex locked.
+func (v *Vehicle) paramValue(p *gomavlib.Param) *common.MessageParamValue {
+	return &common.MessageParamValue{
+		ParamId:    p.ID,
+		ParamValue: v.paramEncode(common.MAV_PARAM_TYPE(p.Type), p.Value),
+		ParamType:  common.MAV_PARAM_TYPE(p.Type),
+		ParamCount: uint16(len(v.params)),
+		ParamIndex: uint16(p.Index),
+	}
+}
+
+// paramByID must be called with the mutex locked.
+func (v *Vehicle) paramByID(id string) *gomavlib.Param {
+	for _, p := range v.params {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// Params returns the current parameters of the vehicle.
+func (v *Vehicle) Params() []*gomavlib.Param {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	out := make([]*gomavlib.Param, len(v.params))
+	for i, p := range v.params {
+		cp := *p
+		out[i] = &cp
+	}
+	return out
+}
+
+func (v *Vehicle) onParamRequestList() []msg.Message {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	out := make([]msg.Message, len(v.params))
+	for i, p := range v.params {
+		out[i] = v.paramValue(p)
+	}
+	return out
+}
+
+func (v *Vehicle) onParamRequestRead(m *common.MessageParamRequestRead) []msg.Message {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	var p *gomavlib.Param
+	if m.ParamIndex < 0 {
+		p = v.paramByID(m.ParamId)
+	} else if int(m.ParamIndex) < len(v.params) {
+		p = v.params[m.ParamIndex]
+	}
+
+	if p == nil {
+		return nil
+	}
+
+	return []msg.Message{v.paramValue(p)}
+}
+
+func (v *Vehicle) onParamSet(m *common.MessageParamSet) []msg.Message {
+	v.mutex.Lock()
+	p := v.paramByID(m.ParamId)
+	if p == nil {
+		v.mutex.Unlock()
+		return nil
+	}
+	changed := *p
+	v.mutex.Unlock()
+
+	changed.Value = v.paramDecode(common.MAV_PARAM_TYPE(changed.Type), m.ParamValue)
+
+	// the callback is invoked without the mutex, in order to allow it
+	// to call methods of the vehicle.
+	accepted := v.conf.OnParamSet == nil || v.conf.OnParamSet(&changed)
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if accepted {
+		p.Value = changed.Value
+	}
+
+	// the current value is sent back in any case
+	return []msg.Message{v.paramValue(p)}
+}
@@ -0,0 +1,333 @@
+// Package fakevehicle contains a simulated autopilot, that responds to
+// parameter requests, mission transfers and commands, in order to run
+// end-to-end tests of applications without a real vehicle or a SITL.
+package fakevehicle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// Command is a command received by the vehicle.
+type Command struct {
+	// the command id.
+	Command common.MAV_CMD
+
+	// the command parameters.
+	// In case of COMMAND_INT, Params[4] and Params[5] are zero.
+	Params [7]float32
+
+	// whether the command has been received through a COMMAND_INT.
+	Int bool
+
+	// the coordinate system of a COMMAND_INT.
+	Frame common.MAV_FRAME
+
+	// the X coordinate of a COMMAND_INT.
+	X int32
+
+	// the Y coordinate of a COMMAND_INT.
+	Y int32
+}
+
+// Conf allows to configure a Vehicle.
+type Conf struct {
+	// the endpoints with which the vehicle communicates.
+	Endpoints []gomavlib.EndpointConf
+
+	// (optional) the system id of the vehicle.
+	// It defaults to 1.
+	SystemID byte
+
+	// (optional) the component id of the vehicle.
+	// It defaults to 1 (MAV_COMP_ID_AUTOPILOT1).
+	ComponentID byte
+
+	// (optional) the vehicle type (MAV_TYPE) advertised by heartbeats.
+	// It defaults to MAV_TYPE_QUADROTOR.
+	Type common.MAV_TYPE
+
+	// (optional) the autopilot type (MAV_AUTOPILOT) advertised by heartbeats.
+	// It also selects the encoding of integer parameters: with
+	// MAV_AUTOPILOT_ARDUPILOTMEGA values are casted, otherwise they are
+	// copied bytewise.
+	// It defaults to MAV_AUTOPILOT_GENERIC.
+	Autopilot common.MAV_AUTOPILOT
+
+	// (optional) the period of heartbeats.
+	// It defaults to 1 second.
+	HeartbeatPeriod time.Duration
+
+	// (optional) the initial parameters.
+	Params []*gomavlib.Param
+
+	// (optional) the initial mission.
+	Mission []*gomavlib.MissionItem
+
+	// (optional) the function invoked when a command is received.
+	// It returns the result of the command. Accepted arming, disarming and
+	// mode changes are applied to the state of the vehicle.
+	// It defaults to a function that accepts every command.
+	OnCommand func(cmd *Command) common.MAV_RESULT
+
+	// (optional) the function invoked when a parameter is changed.
+	// It returns whether the change is accepted.
+	// It defaults to a function that accepts every change.
+	OnParamSet func(p *gomavlib.Param) bool
+
+	// (optional) the function invoked when a mission, geofence or rally
+	// point list is uploaded. It returns the result of the upload.
+	// It defaults to a function that accepts every upload.
+	OnMissionUpload func(missionType common.MAV_MISSION_TYPE,
+		items []*gomavlib.MissionItem) common.MAV_MISSION_RESULT
+
+	// (optional) the time after which an unfinished mission upload is
+	// discarded. It defaults to 5 seconds.
+	MissionUploadTimeout time.Duration
+}
+
+// Vehicle is a simulated autopilot.
+type Vehicle struct {
+	conf Conf
+	node *gomavlib.Node
+
+	mutex      sync.Mutex
+	armed      bool
+	customMode uint32
+	params     []*gomavlib.Param
+	missions   map[common.MAV_MISSION_TYPE][]*gomavlib.MissionItem
+	upload     *missionUpload
+
+	done chan struct{}
+}
+
+// New allocates a Vehicle. See Conf for the options.
+func New(conf Conf) (*Vehicle, error) {
+	if conf.SystemID == 0 {
+		conf.SystemID = 1
+	}
+	if conf.ComponentID == 0 {
+		conf.ComponentID = 1
+	}
+	if conf.Type == 0 {
+		conf.Type = common.MAV_TYPE_QUADROTOR
+	}
+	if conf.HeartbeatPeriod == 0 {
+		conf.HeartbeatPeriod = 1 * time.Second
+	}
+	if conf.MissionUploadTimeout == 0 {
+		conf.MissionUploadTimeout = 5 * time.Second
+	}
+
+	v := &Vehicle{
+		conf:     conf,
+		missions: make(map[common.MAV_MISSION_TYPE][]*gomavlib.MissionItem),
+		done:     make(chan struct{}),
+	}
+
+	for i, p := range conf.Params {
+		cp := *p
+		cp.Index = i
+		v.params = append(v.params, &cp)
+	}
+
+	v.missions[common.MAV_MISSION_TYPE_MISSION] = copyMission(conf.Mission)
+
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:                common.Dialect,
+		OutVersion:             gomavlib.V2,
+		OutSystemID:            conf.SystemID,
+		OutComponentID:         conf.ComponentID,
+		Endpoints:              conf.Endpoints,
+		HeartbeatPeriod:        conf.HeartbeatPeriod,
+		HeartbeatSystemType:    int(conf.Type),
+		HeartbeatAutopilotType: int(conf.Autopilot),
+		HeartbeatCallback:      v.heartbeat,
+	})
+	if err != nil {
+		return nil, err
+	}
+	v.node = node
+
+	go v.run()
+
+	return v, nil
+}
+
+// Close closes the vehicle.
+func (v *Vehicle) Close() {
+	v.node.Close()
+	<-v.done
+}
+
+// Node returns the node used by the vehicle, that can be used to write
+// additional messages, like telemetry.
+func (v *Vehicle) Node() *gomavlib.Node {
+	return v.node
+}
+
+// Armed returns whether the vehicle is armed.
+func (v *Vehicle) Armed() bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.armed
+}
+
+// CustomMode returns the current custom mode of the vehicle.
+func (v *Vehicle) CustomMode() uint32 {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.customMode
+}
+
+func (v *Vehicle) heartbeat() gomavlib.HeartbeatContent {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	c := gomavlib.HeartbeatContent{
+		SystemType:    int(v.conf.Type),
+		AutopilotType: int(v.conf.Autopilot),
+		BaseMode:      int(common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED),
+		CustomMode:    v.customMode,
+		SystemStatus:  int(common.MAV_STATE_STANDBY),
+	}
+	if v.armed {
+		c.BaseMode |= int(common.MAV_MODE_FLAG_SAFETY_ARMED)
+		c.SystemStatus = int(common.MAV_STATE_ACTIVE)
+	}
+	return c
+}
+
+func (v *Vehicle) run() {
+	defer close(v.done)
+
+	for evt := range v.node.Events() {
+		fr, ok := evt.(*gomavlib.EventFrame)
+		if !ok {
+			continue
+		}
+
+		for _, m := range v.handle(fr) {
+			v.node.WriteMessageTo(fr.Channel, m)
+		}
+	}
+}
+
+// isTarget returns whether a message is addressed to the vehicle.
+func (v *Vehicle) isTarget(system uint8, component uint8) bool {
+	return (system == 0 || system == v.conf.SystemID) &&
+		(component == 0 || component == v.conf.ComponentID)
+}
+
+// handle processes a received frame and returns the responses.
+func (v *Vehicle) handle(fr *gomavlib.EventFrame) []msg.Message {
+	switch m := fr.Message().(type) {
+	case *common.MessageCommandLong:
+		if !v.isTarget(m.TargetSystem, m.TargetComponent) {
+			return nil
+		}
+		return v.onCommand(fr, &Command{
+			Command: m.Command,
+			Params:  [7]float32{m.Param1, m.Param2, m.Param3, m.Param4, m.Param5, m.Param6, m.Param7},
+		})
+
+	case *common.MessageCommandInt:
+		if !v.isTarget(m.TargetSystem, m.TargetComponent) {
+			return nil
+		}
+		return v.onCommand(fr, &Command{
+			Command: m.Command,
+			Params:  [7]float32{m.Param1, m.Param2, m.Param3, m.Param4, 0, 0, m.Z},
+			Int:     true,
+			Frame:   m.Frame,
+			X:       m.X,
+			Y:       m.Y,
+		})
+
+	case *common.MessageParamRequestList:
+		if !v.isTarget(m.TargetSystem, m.TargetComponent) {
+			return nil
+		}
+		return v.onParamRequestList()
+
+	case *common.MessageParamRequestRead:
+		if !v.isTarget(m.TargetSystem, m.TargetComponent) {
+			return nil
+		}
+		return v.onParamRequestRead(m)
+
+	case *common.MessageParamSet:
+		if !v.isTarget(m.TargetSystem, m.TargetComponent) {
+			return nil
+		}
+		return v.onParamSet(m)
+
+	case *common.MessageMissionCount:
+		if !v.isTarget(m.TargetSystem, m.TargetComponent) {
+			return nil
+		}
+		return v.onMissionCount(fr, m)
+
+	case *common.MessageMissionItemInt:
+		if !v.isTarget(m.TargetSystem, m.TargetComponent) {
+			return nil
+		}
+		return v.onMissionItemInt(fr, m)
+
+	case *common.MessageMissionRequestList:
+		if !v.isTarget(m.TargetSystem, m.TargetComponent) {
+			return nil
+		}
+		return v.onMissionRequestList(fr, m)
+
+	case *common.MessageMissionRequestInt:
+		if !v.isTarget(m.TargetSystem, m.TargetComponent) {
+			return nil
+		}
+		return v.onMissionRequest(fr, m.Seq, m.MissionType)
+
+	case *common.MessageMissionRequest:
+		if !v.isTarget(m.TargetSystem, m.TargetComponent) {
+			return nil
+		}
+		return v.onMissionRequest(fr, m.Seq, m.MissionType)
+
+	case *common.MessageMissionClearAll:
+		if !v.isTarget(m.TargetSystem, m.TargetComponent) {
+			return nil
+		}
+		return v.onMissionClearAll(fr, m)
+	}
+
+	return nil
+}
+
+func (v *Vehicle) onCommand(fr *gomavlib.EventFrame, cmd *Command) []msg.Message {
+	result := common.MAV_RESULT_ACCEPTED
+	if v.conf.OnCommand != nil {
+		result = v.conf.OnCommand(cmd)
+	}
+
+	if result == common.MAV_RESULT_ACCEPTED {
+		v.mutex.Lock()
+		switch cmd.Command {
+		case common.MAV_CMD_COMPONENT_ARM_DISARM:
+			v.armed = (cmd.Params[0] == 1)
+
+		case common.MAV_CMD_DO_SET_MODE:
+			v.customMode = uint32(cmd.Params[1])
+		}
+		v.mutex.Unlock()
+	}
+
+	return []msg.Message{&common.MessageCommandAck{
+		Command:         cmd.Command,
+		Result:          result,
+		TargetSystem:    fr.SystemID(),
+		TargetComponent: fr.ComponentID(),
+	}}
+}
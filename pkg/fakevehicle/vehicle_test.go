@@ -0,0 +1,188 @@
+package fakevehicle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/endpointtest"
+)
+
+func newTestVehicle(t *testing.T, conf Conf) (*Vehicle, *gomavlib.Node) {
+	e1, e2 := endpointtest.EndpointPipe()
+
+	conf.Endpoints = []gomavlib.EndpointConf{e1}
+	conf.HeartbeatPeriod = 50 * time.Millisecond
+	v, err := New(conf)
+	require.NoError(t, err)
+
+	gcs, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      255,
+		Endpoints:        []gomavlib.EndpointConf{e2},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	return v, gcs
+}
+
+func TestVehicleCommands(t *testing.T) {
+	var received []*Command
+
+	v, gcs := newTestVehicle(t, Conf{
+		OnCommand: func(cmd *Command) common.MAV_RESULT {
+			received = append(received, cmd)
+			if cmd.Command == common.MAV_CMD_NAV_TAKEOFF {
+				return common.MAV_RESULT_DENIED
+			}
+			return common.MAV_RESULT_ACCEPTED
+		},
+	})
+	defer v.Close()
+	defer gcs.Close()
+
+	ack, err := gcs.SendCommand(context.Background(), &gomavlib.CommandRequest{
+		TargetSystem:    1,
+		TargetComponent: 1,
+		Command:         int(common.MAV_CMD_COMPONENT_ARM_DISARM),
+		Params:          [7]float32{1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int(common.MAV_RESULT_ACCEPTED), ack.Result)
+	require.Equal(t, true, v.Armed())
+
+	ack, err = gcs.SendCommand(context.Background(), &gomavlib.CommandRequest{
+		TargetSystem:    1,
+		TargetComponent: 1,
+		Command:         int(common.MAV_CMD_DO_SET_MODE),
+		Params:          [7]float32{1, 4},
+		UseInt:          true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int(common.MAV_RESULT_ACCEPTED), ack.Result)
+	require.Equal(t, uint32(4), v.CustomMode())
+
+	ack, err = gcs.SendCommand(context.Background(), &gomavlib.CommandRequest{
+		TargetSystem:    1,
+		TargetComponent: 1,
+		Command:         int(common.MAV_CMD_NAV_TAKEOFF),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int(common.MAV_RESULT_DENIED), ack.Result)
+
+	require.Equal(t, 3, len(received))
+	require.Equal(t, true, received[1].Int)
+
+	// the state is advertised by heartbeats
+	require.Equal(t, int(common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED|common.MAV_MODE_FLAG_SAFETY_ARMED),
+		v.heartbeat().BaseMode)
+}
+
+func TestVehicleParams(t *testing.T) {
+	v, gcs := newTestVehicle(t, Conf{
+		Params: []*gomavlib.Param{
+			{ID: "PARAM_A", Type: int(common.MAV_PARAM_TYPE_INT32), Value: 16777217},
+			{ID: "PARAM_B", Type: int(common.MAV_PARAM_TYPE_REAL32), Value: 1.5},
+			{ID: "PARAM_C", Type: int(common.MAV_PARAM_TYPE_UINT8), Value: 3},
+		},
+		OnParamSet: func(p *gomavlib.Param) bool {
+			return p.ID != "PARAM_C"
+		},
+	})
+	defer v.Close()
+	defer gcs.Close()
+
+	transfer := &gomavlib.ParamTransfer{
+		TargetSystem: 1,
+		Encoding:     gomavlib.ParamEncodingBytewise,
+		Timeout:      200 * time.Millisecond,
+	}
+
+	params, err := gcs.ReadParams(context.Background(), transfer)
+	require.NoError(t, err)
+	require.Equal(t, []*gomavlib.Param{
+		{ID: "PARAM_A", Type: int(common.MAV_PARAM_TYPE_INT32), Value: 16777217, Index: 0},
+		{ID: "PARAM_B", Type: int(common.MAV_PARAM_TYPE_REAL32), Value: 1.5, Index: 1},
+		{ID: "PARAM_C", Type: int(common.MAV_PARAM_TYPE_UINT8), Value: 3, Index: 2},
+	}, params)
+
+	p, err := gcs.WriteParam(context.Background(), transfer, &gomavlib.Param{
+		ID:    "PARAM_A",
+		Type:  int(common.MAV_PARAM_TYPE_INT32),
+		Value: -5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, float64(-5), p.Value)
+
+	// the change is rejected and the current value is returned
+	p, err = gcs.WriteParam(context.Background(), transfer, &gomavlib.Param{
+		ID:    "PARAM_C",
+		Type:  int(common.MAV_PARAM_TYPE_UINT8),
+		Value: 4,
+	})
+	require.NoError(t, err)
+	require.Equal(t, float64(3), p.Value)
+
+	require.Equal(t, float64(-5), v.Params()[0].Value)
+	require.Equal(t, float64(3), v.Params()[2].Value)
+}
+
+func TestVehicleMission(t *testing.T) {
+	v, gcs := newTestVehicle(t, Conf{
+		OnMissionUpload: func(missionType common.MAV_MISSION_TYPE,
+			items []*gomavlib.MissionItem) common.MAV_MISSION_RESULT {
+			if len(items) > 2 {
+				return common.MAV_MISSION_NO_SPACE
+			}
+			return common.MAV_MISSION_ACCEPTED
+		},
+	})
+	defer v.Close()
+	defer gcs.Close()
+
+	items := []*gomavlib.MissionItem{
+		{
+			Frame:        int(common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT),
+			Command:      int(common.MAV_CMD_NAV_TAKEOFF),
+			Autocontinue: true,
+			Z:            10,
+		},
+		{
+			Frame:        int(common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT),
+			Command:      int(common.MAV_CMD_NAV_WAYPOINT),
+			Autocontinue: true,
+			Params:       [4]float32{1, 2, 0, 0},
+			X:            454642100,
+			Y:            91900000,
+			Z:            20,
+		},
+	}
+
+	transfer := &gomavlib.MissionTransfer{
+		TargetSystem: 1,
+		Timeout:      200 * time.Millisecond,
+	}
+
+	err := gcs.UploadMission(context.Background(), transfer, items)
+	require.NoError(t, err)
+	require.Equal(t, items, v.Mission(common.MAV_MISSION_TYPE_MISSION))
+
+	downloaded, err := gcs.DownloadMission(context.Background(), transfer)
+	require.NoError(t, err)
+	require.Equal(t, items, downloaded)
+
+	err = gcs.UploadMission(context.Background(), transfer, append(items, items[0]))
+	require.Error(t, err)
+	require.Equal(t, items, v.Mission(common.MAV_MISSION_TYPE_MISSION))
+}
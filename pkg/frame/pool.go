@@ -0,0 +1,40 @@
+package frame
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	maxPayloadSize = 255
+)
+
+// payloadPool contains buffers that are able to store the payload of any frame.
+var payloadPool = sync.Pool{
+	New: func() interface{} {
+		return new([maxPayloadSize]byte)
+	},
+}
+
+func getPayload(l int) []byte {
+	return payloadPool.Get().(*[maxPayloadSize]byte)[:l]
+}
+
+func putPayload(buf []byte) {
+	buf = buf[:maxPayloadSize]
+	payloadPool.Put((*[maxPayloadSize]byte)(unsafe.Pointer(&buf[0])))
+}
+
+// ReleasePayload returns the content of a raw message, obtained by decoding
+// a frame, to a pool, in order to reuse it when decoding other frames.
+// It must be called only when the content is not used anymore.
+func ReleasePayload(m *msg.MessageRaw) {
+	if cap(m.Content) != maxPayloadSize {
+		return
+	}
+
+	putPayload(m.Content)
+	m.Content = nil
+}
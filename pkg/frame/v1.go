@@ -72,9 +72,10 @@ func (f *V1Frame) Decode(br *bufio.Reader) error {
 	// message
 	var msgEncoded []byte
 	if msgLen > 0 {
-		msgEncoded = make([]byte, msgLen)
+		msgEncoded = getPayload(int(msgLen))
 		_, err = io.ReadFull(br, msgEncoded)
 		if err != nil {
+			putPayload(msgEncoded)
 			return err
 		}
 	}
@@ -5,7 +5,9 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
+	"hash"
 	"io"
+	"sync"
 
 	"github.com/aler9/gomavlib/pkg/msg"
 	"github.com/aler9/gomavlib/pkg/x25"
@@ -19,6 +21,13 @@ const (
 	V2FlagSigned = 0x01
 )
 
+// signatureHashPool contains hashes used to generate signatures.
+var signatureHashPool = sync.Pool{
+	New: func() interface{} {
+		return sha256.New()
+	},
+}
+
 func uint24Decode(in []byte) uint32 {
 	return uint32(in[2])<<16 | uint32(in[1])<<8 | uint32(in[0])
 }
@@ -137,9 +146,10 @@ func (f *V2Frame) Decode(br *bufio.Reader) error {
 	// message
 	var msgEncoded []byte
 	if msgLen > 0 {
-		msgEncoded = make([]byte, msgLen)
+		msgEncoded = getPayload(int(msgLen))
 		_, err = io.ReadFull(br, msgEncoded)
 		if err != nil {
+			putPayload(msgEncoded)
 			return err
 		}
 	}
@@ -232,7 +242,9 @@ func (f *V2Frame) GenChecksum(crcExtra byte) uint16 {
 // GenSignature generates a signature with the given key.
 func (f *V2Frame) GenSignature(key *V2Key) *V2Signature {
 	msg := f.GetMessage().(*msg.MessageRaw)
-	h := sha256.New()
+	h := signatureHashPool.Get().(hash.Hash)
+	defer signatureHashPool.Put(h)
+	h.Reset()
 
 	// secret key
 	h.Write(key[:])
@@ -0,0 +1,201 @@
+package grpcserver
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// protobuf wire types
+const (
+	protoWireVarint  = 0
+	protoWireFixed64 = 1
+	protoWireBytes   = 2
+	protoWireFixed32 = 5
+)
+
+type protoField struct {
+	num      int
+	wireType int
+	varint   uint64
+	bytes    []byte
+}
+
+func protoAppendVarint(buf []byte, v uint64) []byte {
+	for v >= 0x80 {
+		buf = append(buf, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(buf, byte(v))
+}
+
+func protoAppendUint(buf []byte, num int, v uint64) []byte {
+	// zero values are not encoded in proto3
+	if v == 0 {
+		return buf
+	}
+	buf = protoAppendVarint(buf, uint64(num)<<3|protoWireVarint)
+	return protoAppendVarint(buf, v)
+}
+
+func protoAppendBytes(buf []byte, num int, v []byte) []byte {
+	if len(v) == 0 {
+		return buf
+	}
+	buf = protoAppendVarint(buf, uint64(num)<<3|protoWireBytes)
+	buf = protoAppendVarint(buf, uint64(len(v)))
+	return append(buf, v...)
+}
+
+func protoReadVarint(buf []byte) (uint64, int, error) {
+	var v uint64
+	for i := 0; i < len(buf) && i < 10; i++ {
+		v |= uint64(buf[i]&0x7F) << (7 * uint(i))
+		if buf[i] < 0x80 {
+			return v, i + 1, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("invalid varint")
+}
+
+// protoDecode calls cb for every field of a protobuf message.
+func protoDecode(buf []byte, cb func(f *protoField) error) error {
+	for len(buf) > 0 {
+		tag, n, err := protoReadVarint(buf)
+		if err != nil {
+			return err
+		}
+		buf = buf[n:]
+
+		f := &protoField{
+			num:      int(tag >> 3),
+			wireType: int(tag & 0x07),
+		}
+
+		switch f.wireType {
+		case protoWireVarint:
+			f.varint, n, err = protoReadVarint(buf)
+			if err != nil {
+				return err
+			}
+			buf = buf[n:]
+
+		case protoWireFixed64:
+			if len(buf) < 8 {
+				return fmt.Errorf("invalid fixed64")
+			}
+			f.varint = binary.LittleEndian.Uint64(buf)
+			buf = buf[8:]
+
+		case protoWireBytes:
+			l, n, err := protoReadVarint(buf)
+			if err != nil {
+				return err
+			}
+			buf = buf[n:]
+			if uint64(len(buf)) < l {
+				return fmt.Errorf("invalid length")
+			}
+			f.bytes = buf[:l]
+			buf = buf[l:]
+
+		case protoWireFixed32:
+			if len(buf) < 4 {
+				return fmt.Errorf("invalid fixed32")
+			}
+			f.varint = uint64(binary.LittleEndian.Uint32(buf))
+			buf = buf[4:]
+
+		default:
+			return fmt.Errorf("unsupported wire type: %d", f.wireType)
+		}
+
+		err = cb(f)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// subscribeRequest is the SubscribeRequest of proto/gomavlib.proto.
+type subscribeRequest struct {
+	messageIDs  []uint32
+	systemID    uint32
+	componentID uint32
+}
+
+func (r *subscribeRequest) unmarshal(buf []byte) error {
+	return protoDecode(buf, func(f *protoField) error {
+		switch f.num {
+		case 1:
+			// packed or not packed repeated field
+			if f.wireType == protoWireBytes {
+				buf := f.bytes
+				for len(buf) > 0 {
+					v, n, err := protoReadVarint(buf)
+					if err != nil {
+						return err
+					}
+					buf = buf[n:]
+					r.messageIDs = append(r.messageIDs, uint32(v))
+				}
+			} else {
+				r.messageIDs = append(r.messageIDs, uint32(f.varint))
+			}
+
+		case 2:
+			r.systemID = uint32(f.varint)
+
+		case 3:
+			r.componentID = uint32(f.varint)
+		}
+		return nil
+	})
+}
+
+// message is the Message of proto/gomavlib.proto.
+type message struct {
+	systemID    uint32
+	componentID uint32
+	messageID   uint32
+	name        string
+	json        string
+	raw         []byte
+}
+
+func (m *message) marshal() []byte {
+	var buf []byte
+	buf = protoAppendUint(buf, 1, uint64(m.systemID))
+	buf = protoAppendUint(buf, 2, uint64(m.componentID))
+	buf = protoAppendUint(buf, 3, uint64(m.messageID))
+	buf = protoAppendBytes(buf, 4, []byte(m.name))
+	buf = protoAppendBytes(buf, 5, []byte(m.json))
+	buf = protoAppendBytes(buf, 6, m.raw)
+	return buf
+}
+
+func (m *message) unmarshal(buf []byte) error {
+	return protoDecode(buf, func(f *protoField) error {
+		switch f.num {
+		case 1:
+			m.systemID = uint32(f.varint)
+
+		case 2:
+			m.componentID = uint32(f.varint)
+
+		case 3:
+			m.messageID = uint32(f.varint)
+
+		case 4:
+			m.name = string(f.bytes)
+
+		case 5:
+			m.json = string(f.bytes)
+
+		case 6:
+			m.raw = append([]byte(nil), f.bytes...)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,318 @@
+// Package grpcserver contains a server that implements the gRPC service
+// defined in proto/gomavlib.proto, that allows to receive and write the
+// messages of a node from any language that supports gRPC.
+package grpcserver
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	maxMessageSize = 1024 * 1024
+
+	// the period after which a subscription checks whether the node
+	// has been closed.
+	subscribeWaitPeriod = 1 * time.Second
+)
+
+// gRPC status codes
+const (
+	statusOK                = 0
+	statusCanceled          = 1
+	statusInvalidArgument   = 3
+	statusResourceExhausted = 8
+	statusUnimplemented     = 12
+	statusInternal          = 13
+	statusUnavailable       = 14
+)
+
+type grpcError struct {
+	code    int
+	message string
+}
+
+func (e *grpcError) Error() string {
+	return e.message
+}
+
+// readMessage reads a length-prefixed message.
+func readMessage(r io.Reader) ([]byte, error) {
+	var header [5]byte
+	_, err := io.ReadFull(r, header[:])
+	if err != nil {
+		return nil, &grpcError{statusInvalidArgument, "unable to read message"}
+	}
+
+	if header[0] != 0 {
+		return nil, &grpcError{statusUnimplemented, "compression is not supported"}
+	}
+
+	l := binary.BigEndian.Uint32(header[1:])
+	if l > maxMessageSize {
+		return nil, &grpcError{statusResourceExhausted, "message is too big"}
+	}
+
+	buf := make([]byte, l)
+	_, err = io.ReadFull(r, buf)
+	if err != nil {
+		return nil, &grpcError{statusInvalidArgument, "unable to read message"}
+	}
+
+	return buf, nil
+}
+
+// writeMessage writes a length-prefixed message.
+func writeMessage(w http.ResponseWriter, byts []byte) error {
+	buf := make([]byte, 5+len(byts))
+	binary.BigEndian.PutUint32(buf[1:], uint32(len(byts)))
+	copy(buf[5:], byts)
+
+	_, err := w.Write(buf)
+	if err != nil {
+		return err
+	}
+
+	flush(w)
+	return nil
+}
+
+// flush sends buffered data to the client, if supported by the writer.
+func flush(w http.ResponseWriter) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// encodeStatusMessage percent-encodes a status message.
+func encodeStatusMessage(in string) string {
+	var sb strings.Builder
+	for i := 0; i < len(in); i++ {
+		c := in[i]
+		if c < 0x20 || c > 0x7E || c == '%' {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		} else {
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+func encodeMessage(dialectDE *dialect.DecEncoder, evt *gomavlib.EventFrame) (*message, error) {
+	out := &message{
+		systemID:    uint32(evt.SystemID()),
+		componentID: uint32(evt.ComponentID()),
+		messageID:   evt.Message().GetID(),
+	}
+
+	// messages that are not in the dialect are sent in raw format
+	if mr, ok := evt.Message().(*msg.MessageRaw); ok {
+		out.raw = mr.Content
+		return out, nil
+	}
+
+	out.name = dialectDE.MessageDEs[out.messageID].Name()
+
+	byts, err := dialectDE.EncodeMessageJSON(evt.Message())
+	if err != nil {
+		return nil, err
+	}
+	out.json = string(byts)
+
+	return out, nil
+}
+
+// EncodeMessage encodes a received frame into the Message of
+// proto/gomavlib.proto, in protobuf format.
+func EncodeMessage(dialectDE *dialect.DecEncoder, evt *gomavlib.EventFrame) ([]byte, error) {
+	m, err := encodeMessage(dialectDE, evt)
+	if err != nil {
+		return nil, err
+	}
+	return m.marshal(), nil
+}
+
+// ServerConf allows to configure a Server.
+type ServerConf struct {
+	// the node whose messages are served.
+	Node *gomavlib.Node
+
+	// the dialect of the node.
+	Dialect *dialect.Dialect
+}
+
+// Server is a http.Handler that implements the gRPC service defined in
+// proto/gomavlib.proto. Messages are encoded in JSON format.
+// It must be served by a HTTP/2 server (i.e. a http.Server with TLS).
+type Server struct {
+	conf      ServerConf
+	dialectDE *dialect.DecEncoder
+}
+
+// NewServer allocates a Server. See ServerConf for the options.
+func NewServer(conf ServerConf) (*Server, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node is required")
+	}
+	if conf.Dialect == nil {
+		return nil, fmt.Errorf("Dialect is required")
+	}
+
+	dialectDE, err := dialect.NewDecEncoder(conf.Dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		conf:      conf,
+		dialectDE: dialectDE,
+	}, nil
+}
+
+func (s *Server) subscribe(w http.ResponseWriter, r *http.Request) error {
+	byts, err := readMessage(r.Body)
+	if err != nil {
+		return err
+	}
+
+	var req subscribeRequest
+	err = req.unmarshal(byts)
+	if err != nil {
+		return &grpcError{statusInvalidArgument, err.Error()}
+	}
+
+	sub := s.conf.Node.Subscribe(func(evt *gomavlib.EventFrame) bool {
+		if req.systemID != 0 && uint32(evt.SystemID()) != req.systemID {
+			return false
+		}
+		if req.componentID != 0 && uint32(evt.ComponentID()) != req.componentID {
+			return false
+		}
+		if len(req.messageIDs) == 0 {
+			return true
+		}
+		for _, id := range req.messageIDs {
+			if evt.Message().GetID() == id {
+				return true
+			}
+		}
+		return false
+	})
+	defer sub.Close()
+
+	w.WriteHeader(http.StatusOK)
+	flush(w)
+
+	for {
+		evt, err := sub.Wait(r.Context(), subscribeWaitPeriod)
+		switch {
+		case err == gomavlib.ErrTimeout:
+			continue
+
+		case err == context.Canceled || err == context.DeadlineExceeded:
+			return &grpcError{statusCanceled, "canceled"}
+
+		case err != nil:
+			return &grpcError{statusUnavailable, "terminated"}
+		}
+
+		m, err := encodeMessage(s.dialectDE, evt)
+		if err != nil {
+			return &grpcError{statusInternal, err.Error()}
+		}
+
+		err = writeMessage(w, m.marshal())
+		if err != nil {
+			return &grpcError{statusCanceled, err.Error()}
+		}
+	}
+}
+
+func (s *Server) write(w http.ResponseWriter, r *http.Request) error {
+	byts, err := readMessage(r.Body)
+	if err != nil {
+		return err
+	}
+
+	var in message
+	err = in.unmarshal(byts)
+	if err != nil {
+		return &grpcError{statusInvalidArgument, err.Error()}
+	}
+
+	id := in.messageID
+	if in.name != "" {
+		m, ok := s.conf.Dialect.GetMessageByName(in.name)
+		if !ok {
+			return &grpcError{statusInvalidArgument, fmt.Sprintf("message %s is not in the dialect", in.name)}
+		}
+		id = m.GetID()
+	}
+
+	if in.json == "" {
+		in.json = "{}"
+	}
+
+	m, err := s.dialectDE.DecodeMessageJSON(id, []byte(in.json))
+	if err != nil {
+		return &grpcError{statusInvalidArgument, err.Error()}
+	}
+
+	// WriteMessageAll() discards messages silently when the node is closed
+	_, err = s.conf.Node.WriteMessageAllReport(r.Context(), m)
+	switch {
+	case err == context.Canceled || err == context.DeadlineExceeded:
+		return &grpcError{statusCanceled, "canceled"}
+
+	case err != nil:
+		return &grpcError{statusUnavailable, "terminated"}
+	}
+
+	// WriteResponse is empty
+	w.WriteHeader(http.StatusOK)
+	return writeMessage(w, nil)
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.ProtoMajor != 2 || r.Method != http.MethodPost ||
+		!strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "gRPC requests must be sent with HTTP/2", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc")
+
+	var err error
+	switch r.URL.Path {
+	case "/gomavlib.Node/Subscribe":
+		err = s.subscribe(w, r)
+
+	case "/gomavlib.Node/Write":
+		err = s.write(w, r)
+
+	default:
+		err = &grpcError{statusUnimplemented, "unknown method"}
+	}
+
+	code := statusOK
+	if err != nil {
+		gerr, ok := err.(*grpcError)
+		if !ok {
+			gerr = &grpcError{statusInternal, err.Error()}
+		}
+		code = gerr.code
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", encodeStatusMessage(gerr.message))
+	}
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.FormatInt(int64(code), 10))
+}
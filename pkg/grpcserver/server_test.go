@@ -0,0 +1,205 @@
+package grpcserver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func testRequest(t *testing.T, client *http.Client, url string, byts []byte) *http.Response {
+	buf := make([]byte, 5+len(byts))
+	binary.BigEndian.PutUint32(buf[1:], uint32(len(byts)))
+	copy(buf[5:], byts)
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(buf))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/grpc")
+
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, 2, res.ProtoMajor)
+	return res
+}
+
+type MessageHeartbeat struct {
+	Type           uint8
+	Autopilot      uint8
+	BaseMode       uint8
+	CustomMode     uint32
+	SystemStatus   uint8
+	MavlinkVersion uint8
+}
+
+func (*MessageHeartbeat) GetID() uint32 {
+	return 0
+}
+
+var testDialect = &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}} //nolint:govet
+
+func TestServer(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	node1, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          testDialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      10,
+		Endpoints:        []gomavlib.EndpointConf{gomavlib.EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          testDialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      11,
+		Endpoints:        []gomavlib.EndpointConf{gomavlib.EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	// writes are performed before the response is sent, therefore
+	// events must be read in a separate routine
+	node2Frames := make(chan *gomavlib.EventFrame, 10)
+	go func() {
+		for evt := range node2.Events() {
+			if fr, ok := evt.(*gomavlib.EventFrame); ok {
+				node2Frames <- fr
+			}
+		}
+	}()
+
+	s, err := NewServer(ServerConf{
+		Node:    node1,
+		Dialect: testDialect,
+	})
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(s)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	t.Run("write", func(t *testing.T) {
+		res := testRequest(t, srv.Client(), srv.URL+"/gomavlib.Node/Write", (&message{
+			name: "HEARTBEAT",
+			json: `{"type":7,"custom_mode":8}`,
+		}).marshal())
+		defer res.Body.Close()
+
+		byts, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0, 0, 0, 0, 0}, byts)
+		require.Equal(t, "0", res.Trailer.Get("Grpc-Status"))
+
+		fr := <-node2Frames
+		require.Equal(t, &MessageHeartbeat{
+			Type:       7,
+			CustomMode: 8,
+		}, fr.Message())
+	})
+
+	t.Run("write invalid", func(t *testing.T) {
+		res := testRequest(t, srv.Client(), srv.URL+"/gomavlib.Node/Write", (&message{
+			name: "UNKNOWN",
+		}).marshal())
+		defer res.Body.Close()
+
+		_, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, "3", res.Trailer.Get("Grpc-Status"))
+		require.Equal(t, "message UNKNOWN is not in the dialect", res.Trailer.Get("Grpc-Message"))
+	})
+
+	t.Run("subscribe", func(t *testing.T) {
+		// message_ids = [0], system_id = 11
+		res := testRequest(t, srv.Client(), srv.URL+"/gomavlib.Node/Subscribe",
+			[]byte{0x0a, 0x01, 0x00, 0x10, 0x0b})
+		defer res.Body.Close()
+
+		node2.WriteMessageAll(&MessageHeartbeat{
+			Type:           1,
+			Autopilot:      2,
+			BaseMode:       3,
+			CustomMode:     6,
+			SystemStatus:   4,
+			MavlinkVersion: 5,
+		})
+
+		byts, err := readMessage(res.Body)
+		require.NoError(t, err)
+
+		var m message
+		err = m.unmarshal(byts)
+		require.NoError(t, err)
+		require.Equal(t, message{
+			systemID:    11,
+			componentID: 1,
+			messageID:   0,
+			name:        "HEARTBEAT",
+			json: `{"type":1,"autopilot":2,"base_mode":3,"custom_mode":6,` +
+				`"system_status":4,"mavlink_version":5}`,
+		}, m)
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		res := testRequest(t, srv.Client(), srv.URL+"/gomavlib.Node/Unknown", nil)
+		defer res.Body.Close()
+
+		_, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, "12", res.Trailer.Get("Grpc-Status"))
+	})
+}
+
+func TestServerTerminated(t *testing.T) {
+	c1, _ := net.Pipe()
+
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          testDialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      10,
+		Endpoints:        []gomavlib.EndpointConf{gomavlib.EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	s, err := NewServer(ServerConf{
+		Node:    node,
+		Dialect: testDialect,
+	})
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(s)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	node.Close()
+
+	res := testRequest(t, srv.Client(), srv.URL+"/gomavlib.Node/Write", (&message{
+		name: "HEARTBEAT",
+	}).marshal())
+	defer res.Body.Close()
+
+	_, err = ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, "14", res.Trailer.Get("Grpc-Status"))
+	require.Equal(t, "terminated", res.Trailer.Get("Grpc-Message"))
+}
@@ -0,0 +1,660 @@
+// Package httpbridge contains a HTTP bridge, that exposes the state of a
+// node (systems, last received messages, statistics), streams received
+// messages and allows to write messages and send commands in JSON format.
+package httpbridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	streamKeepAlive = 15 * time.Second
+
+	// the period after which the subscription of the cache checks whether
+	// the node has been closed.
+	cacheWaitPeriod = 1 * time.Second
+)
+
+type systemKey struct {
+	systemID    byte
+	componentID byte
+}
+
+type messageKey struct {
+	systemKey
+	messageID uint32
+}
+
+type cachedMessage struct {
+	time time.Time
+	msg  msg.Message
+}
+
+type jsonSystem struct {
+	SystemID      byte       `json:"system_id"`
+	ComponentID   byte       `json:"component_id"`
+	LastSeen      time.Time  `json:"last_seen"`
+	LastHeartbeat *time.Time `json:"last_heartbeat,omitempty"`
+	Type          int        `json:"type"`
+	Autopilot     int        `json:"autopilot"`
+	Capabilities  uint64     `json:"capabilities"`
+	Channels      []string   `json:"channels"`
+}
+
+type jsonMessage struct {
+	SystemID    byte            `json:"system_id,omitempty"`
+	ComponentID byte            `json:"component_id,omitempty"`
+	Time        *time.Time      `json:"time,omitempty"`
+	MessageID   *uint32         `json:"message_id,omitempty"`
+	Name        string          `json:"name,omitempty"`
+	Message     json.RawMessage `json:"message,omitempty"`
+	MessageRaw  []byte          `json:"message_raw,omitempty"`
+}
+
+type jsonCommand struct {
+	TargetSystem    byte       `json:"target_system"`
+	TargetComponent byte       `json:"target_component"`
+	Command         int        `json:"command"`
+	Params          [7]float32 `json:"params"`
+	UseInt          bool       `json:"use_int"`
+	Frame           int        `json:"frame"`
+	X               int32      `json:"x"`
+	Y               int32      `json:"y"`
+}
+
+type jsonCommandAck struct {
+	Result       int   `json:"result"`
+	Progress     uint8 `json:"progress"`
+	ResultParam2 int32 `json:"result_param2"`
+}
+
+type jsonStats struct {
+	BytesIn          uint64 `json:"bytes_in"`
+	BytesOut         uint64 `json:"bytes_out"`
+	FramesIn         uint64 `json:"frames_in"`
+	FramesOut        uint64 `json:"frames_out"`
+	ParseErrors      uint64 `json:"parse_errors"`
+	ChecksumErrors   uint64 `json:"checksum_errors"`
+	DroppedWrites    uint64 `json:"dropped_writes"`
+	FilteredFrames   uint64 `json:"filtered_frames"`
+	LostFrames       uint64 `json:"lost_frames"`
+	SequenceRestarts uint64 `json:"sequence_restarts"`
+	DroppedEvents    uint64 `json:"dropped_events"`
+}
+
+// Conf allows to configure a Bridge.
+type Conf struct {
+	// the node whose state is exposed.
+	Node *gomavlib.Node
+
+	// the dialect of the node.
+	Dialect *dialect.Dialect
+
+	// (optional) the address of a HTTP server that serves the bridge,
+	// i.e. ":8080". If not provided, the bridge can be served with any
+	// HTTP server, since it implements http.Handler.
+	Address string
+}
+
+// Bridge is a http.Handler that exposes the state of a node and allows to
+// write messages and send commands in JSON format. Routes are:
+// GET /systems returns the systems and components that sent frames to the node;
+// GET /messages returns the last message received for every system, component
+// and message type, and supports the system_id and component_id filters;
+// POST /messages writes a message, identified by message_id or name, to all channels;
+// GET /stream streams received messages as Server-Sent Events, or as WebSocket
+// text messages when a WebSocket upgrade is requested, and supports the
+// system_id, component_id and name filters, where name is a comma-separated
+// list of message names;
+// POST /commands sends a command and returns its acknowledgement;
+// GET /stats returns the statistics of the node.
+type Bridge struct {
+	conf      Conf
+	dialectDE *dialect.DecEncoder
+	mux       *http.ServeMux
+	server    *http.Server
+	sub       *gomavlib.Subscription
+	ctx       context.Context
+	ctxCancel func()
+
+	mutex    sync.Mutex
+	messages map[messageKey]*cachedMessage
+
+	// out
+	cacheDone  chan struct{}
+	serverDone chan struct{}
+}
+
+// New allocates a Bridge. See Conf for the options.
+func New(conf Conf) (*Bridge, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node is required")
+	}
+	if conf.Dialect == nil {
+		return nil, fmt.Errorf("Dialect is required")
+	}
+
+	dialectDE, err := dialect.NewDecEncoder(conf.Dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	var ln net.Listener
+	if conf.Address != "" {
+		ln, err = net.Listen("tcp", conf.Address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+
+	b := &Bridge{
+		conf:      conf,
+		dialectDE: dialectDE,
+		mux:       http.NewServeMux(),
+		sub: conf.Node.Subscribe(func(*gomavlib.EventFrame) bool {
+			return true
+		}),
+		ctx:        ctx,
+		ctxCancel:  ctxCancel,
+		messages:   make(map[messageKey]*cachedMessage),
+		cacheDone:  make(chan struct{}),
+		serverDone: make(chan struct{}),
+	}
+
+	b.mux.HandleFunc("/systems", b.onSystems)
+	b.mux.HandleFunc("/messages", b.onMessages)
+	b.mux.HandleFunc("/stream", b.onStream)
+	b.mux.HandleFunc("/commands", b.onCommands)
+	b.mux.HandleFunc("/stats", b.onStats)
+
+	go b.runCache()
+
+	if ln != nil {
+		b.server = &http.Server{Handler: b.mux}
+		go b.runServer(ln)
+	} else {
+		close(b.serverDone)
+	}
+
+	return b, nil
+}
+
+// Close closes the bridge and the streams in progress.
+func (b *Bridge) Close() {
+	b.ctxCancel()
+	if b.server != nil {
+		b.server.Close()
+	}
+	<-b.serverDone
+	<-b.cacheDone
+	b.sub.Close()
+}
+
+// ServeHTTP implements http.Handler.
+func (b *Bridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mux.ServeHTTP(w, r)
+}
+
+func (b *Bridge) runServer(ln net.Listener) {
+	defer close(b.serverDone)
+	b.server.Serve(ln)
+}
+
+// runCache stores the last message received for every system, component
+// and message type.
+func (b *Bridge) runCache() {
+	defer close(b.cacheDone)
+
+	for {
+		evt, err := b.sub.Wait(b.ctx, cacheWaitPeriod)
+		if err == gomavlib.ErrTimeout {
+			continue
+		}
+		if err != nil {
+			return
+		}
+
+		key := messageKey{
+			systemKey{evt.SystemID(), evt.ComponentID()},
+			evt.Message().GetID(),
+		}
+
+		b.mutex.Lock()
+		b.messages[key] = &cachedMessage{
+			time: time.Now(),
+			msg:  evt.Message(),
+		}
+		b.mutex.Unlock()
+	}
+}
+
+func (b *Bridge) encodeMessage(k messageKey, t time.Time, m msg.Message) (jsonMessage, error) {
+	id := k.messageID
+
+	out := jsonMessage{
+		SystemID:    k.systemID,
+		ComponentID: k.componentID,
+		Time:        &t,
+		MessageID:   &id,
+	}
+
+	// messages that are not in the dialect are returned in raw format
+	if mr, ok := m.(*msg.MessageRaw); ok {
+		out.MessageRaw = mr.Content
+		return out, nil
+	}
+
+	out.Name = b.dialectDE.MessageDEs[id].Name()
+
+	var err error
+	out.Message, err = b.dialectDE.EncodeMessageJSON(m)
+	if err != nil {
+		return out, err
+	}
+
+	return out, nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	byts, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(byts)
+}
+
+// parseSystemFilter parses the optional system_id and component_id
+// query parameters.
+func parseSystemFilter(r *http.Request) (func(systemKey) bool, error) {
+	var ids [2]*byte
+
+	for i, name := range []string{"system_id", "component_id"} {
+		v := r.URL.Query().Get(name)
+		if v == "" {
+			continue
+		}
+
+		tmp, err := strconv.ParseUint(v, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s", name)
+		}
+		id := byte(tmp)
+		ids[i] = &id
+	}
+
+	return func(k systemKey) bool {
+		return (ids[0] == nil || *ids[0] == k.systemID) &&
+			(ids[1] == nil || *ids[1] == k.componentID)
+	}, nil
+}
+
+func (b *Bridge) onSystems(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	systems := b.conf.Node.Systems()
+	out := make([]jsonSystem, len(systems))
+
+	for i, sys := range systems {
+		out[i] = jsonSystem{
+			SystemID:     sys.SystemID,
+			ComponentID:  sys.ComponentID,
+			LastSeen:     sys.LastSeen,
+			Type:         sys.Type,
+			Autopilot:    sys.Autopilot,
+			Capabilities: sys.Capabilities,
+			Channels:     make([]string, len(sys.Channels)),
+		}
+
+		if !sys.LastHeartbeat.IsZero() {
+			t := sys.LastHeartbeat
+			out[i].LastHeartbeat = &t
+		}
+
+		for j, ch := range sys.Channels {
+			out[i].Channels[j] = ch.String()
+		}
+	}
+
+	writeJSON(w, out)
+}
+
+func (b *Bridge) onMessages(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		b.onMessagesGet(w, r)
+
+	case http.MethodPost:
+		b.onMessagesPost(w, r)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (b *Bridge) onMessagesGet(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseSystemFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	b.mutex.Lock()
+	keys := make([]messageKey, 0, len(b.messages))
+	entries := make(map[messageKey]*cachedMessage)
+	for k, m := range b.messages {
+		if filter(k.systemKey) {
+			keys = append(keys, k)
+			entries[k] = m
+		}
+	}
+	b.mutex.Unlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].systemID != keys[j].systemID {
+			return keys[i].systemID < keys[j].systemID
+		}
+		if keys[i].componentID != keys[j].componentID {
+			return keys[i].componentID < keys[j].componentID
+		}
+		return keys[i].messageID < keys[j].messageID
+	})
+
+	out := make([]jsonMessage, len(keys))
+	for i, k := range keys {
+		e := entries[k]
+
+		out[i], err = b.encodeMessage(k, e.time, e.msg)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	writeJSON(w, out)
+}
+
+func (b *Bridge) messageID(name string) (uint32, error) {
+	m, ok := b.conf.Dialect.GetMessageByName(name)
+	if !ok {
+		return 0, fmt.Errorf("message %s is not in the dialect", name)
+	}
+	return m.GetID(), nil
+}
+
+func (b *Bridge) onMessagesPost(w http.ResponseWriter, r *http.Request) {
+	var in jsonMessage
+	err := json.NewDecoder(r.Body).Decode(&in)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var id uint32
+	switch {
+	case in.MessageID != nil:
+		id = *in.MessageID
+
+	case in.Name != "":
+		id, err = b.messageID(in.Name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+	default:
+		http.Error(w, "message_id or name not provided", http.StatusBadRequest)
+		return
+	}
+
+	m, err := b.dialectDE.DecodeMessageJSON(id, in.Message)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	b.conf.Node.WriteMessageAll(m)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (b *Bridge) onStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := parseSystemFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var ids map[uint32]struct{}
+	if v := r.URL.Query().Get("name"); v != "" {
+		ids = make(map[uint32]struct{})
+		for _, name := range strings.Split(v, ",") {
+			id, err := b.messageID(name)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			ids[id] = struct{}{}
+		}
+	}
+
+	sub := b.conf.Node.Subscribe(func(evt *gomavlib.EventFrame) bool {
+		if !filter(systemKey{evt.SystemID(), evt.ComponentID()}) {
+			return false
+		}
+		if ids != nil {
+			if _, ok := ids[evt.Message().GetID()]; !ok {
+				return false
+			}
+		}
+		return true
+	})
+	defer sub.Close()
+
+	// the stream is closed when the request is canceled or the bridge is closed
+	ctx, ctxCancel := context.WithCancel(r.Context())
+	defer ctxCancel()
+	go func() {
+		select {
+		case <-b.ctx.Done():
+			ctxCancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if websocket.IsWebSocketUpgrade(r) {
+		b.runStreamWebSocket(ctx, ctxCancel, w, r, sub)
+	} else {
+		b.runStreamSSE(ctx, w, sub)
+	}
+}
+
+func (b *Bridge) encodeFrame(evt *gomavlib.EventFrame) ([]byte, error) {
+	key := messageKey{
+		systemKey{evt.SystemID(), evt.ComponentID()},
+		evt.Message().GetID(),
+	}
+
+	out, err := b.encodeMessage(key, time.Now(), evt.Message())
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(out)
+}
+
+func (b *Bridge) runStreamSSE(ctx context.Context, w http.ResponseWriter, sub *gomavlib.Subscription) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		evt, err := sub.Wait(ctx, streamKeepAlive)
+		if err == gomavlib.ErrTimeout {
+			_, err := w.Write([]byte(": keepalive\n\n"))
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+			continue
+		}
+		if err != nil {
+			return
+		}
+
+		byts, err := b.encodeFrame(evt)
+		if err != nil {
+			continue
+		}
+
+		_, err = w.Write([]byte("data: " + string(byts) + "\n\n"))
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+func (b *Bridge) runStreamWebSocket(ctx context.Context, ctxCancel func(),
+	w http.ResponseWriter, r *http.Request, sub *gomavlib.Subscription) {
+	upgrader := websocket.Upgrader{}
+	wc, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	// incoming messages are discarded; reading is needed to detect
+	// the closure of the connection and to process control messages.
+	readerDone := make(chan struct{})
+	defer func() {
+		wc.Close()
+		<-readerDone
+	}()
+
+	go func() {
+		defer close(readerDone)
+		defer ctxCancel()
+
+		for {
+			_, _, err := wc.NextReader()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		evt, err := sub.Wait(ctx, streamKeepAlive)
+		if err == gomavlib.ErrTimeout {
+			err := wc.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamKeepAlive))
+			if err != nil {
+				return
+			}
+			continue
+		}
+		if err != nil {
+			return
+		}
+
+		byts, err := b.encodeFrame(evt)
+		if err != nil {
+			continue
+		}
+
+		wc.SetWriteDeadline(time.Now().Add(streamKeepAlive))
+		err = wc.WriteMessage(websocket.TextMessage, byts)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (b *Bridge) onCommands(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var in jsonCommand
+	err := json.NewDecoder(r.Body).Decode(&in)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ack, err := b.conf.Node.SendCommand(r.Context(), &gomavlib.CommandRequest{
+		TargetSystem:    in.TargetSystem,
+		TargetComponent: in.TargetComponent,
+		Command:         in.Command,
+		Params:          in.Params,
+		UseInt:          in.UseInt,
+		Frame:           in.Frame,
+		X:               in.X,
+		Y:               in.Y,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusGatewayTimeout)
+		return
+	}
+
+	writeJSON(w, jsonCommandAck{
+		Result:       ack.Result,
+		Progress:     ack.Progress,
+		ResultParam2: ack.ResultParam2,
+	})
+}
+
+func (b *Bridge) onStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s := b.conf.Node.Stats()
+	writeJSON(w, jsonStats{
+		BytesIn:          s.BytesIn,
+		BytesOut:         s.BytesOut,
+		FramesIn:         s.FramesIn,
+		FramesOut:        s.FramesOut,
+		ParseErrors:      s.ParseErrors,
+		ChecksumErrors:   s.ChecksumErrors,
+		DroppedWrites:    s.DroppedWrites,
+		FilteredFrames:   s.FilteredFrames,
+		LostFrames:       s.LostFrames,
+		SequenceRestarts: s.SequenceRestarts,
+		DroppedEvents:    s.DroppedEvents,
+	})
+}
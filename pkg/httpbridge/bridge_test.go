@@ -0,0 +1,243 @@
+package httpbridge
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type MessageHeartbeat struct {
+	Type           uint8
+	Autopilot      uint8
+	BaseMode       uint8
+	CustomMode     uint32
+	SystemStatus   uint8
+	MavlinkVersion uint8
+}
+
+func (*MessageHeartbeat) GetID() uint32 {
+	return 0
+}
+
+var testDialect = &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}} //nolint:govet
+
+func TestBridge(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	node1, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          testDialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      10,
+		Endpoints:        []gomavlib.EndpointConf{gomavlib.EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          testDialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      11,
+		Endpoints:        []gomavlib.EndpointConf{gomavlib.EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	h, err := New(Conf{
+		Node:    node1,
+		Dialect: testDialect,
+	})
+	require.NoError(t, err)
+	defer h.Close()
+
+	node2.WriteMessageAll(&MessageHeartbeat{
+		Type:           1,
+		Autopilot:      2,
+		BaseMode:       3,
+		CustomMode:     6,
+		SystemStatus:   4,
+		MavlinkVersion: 5,
+	})
+
+	for evt := range node1.Events() {
+		if _, ok := evt.(*gomavlib.EventFrame); ok {
+			break
+		}
+	}
+
+	// wait until the message is stored by the bridge
+	for i := 0; i < 100; i++ {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/messages", nil))
+		if w.Body.String() != "[]" {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Run("systems", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/systems", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var res []map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &res)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(res))
+		require.Equal(t, float64(11), res[0]["system_id"])
+		require.Equal(t, float64(1), res[0]["component_id"])
+		require.Equal(t, []interface{}{"custom"}, res[0]["channels"])
+		require.Equal(t, float64(1), res[0]["type"])
+		require.Equal(t, float64(2), res[0]["autopilot"])
+	})
+
+	t.Run("messages get", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/messages?system_id=11", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var res []struct {
+			MessageID uint32          `json:"message_id"`
+			Name      string          `json:"name"`
+			Message   json.RawMessage `json:"message"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &res)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(res))
+		require.Equal(t, uint32(0), res[0].MessageID)
+		require.Equal(t, "HEARTBEAT", res[0].Name)
+		require.Equal(t, `{"type":1,"autopilot":2,"base_mode":3,"custom_mode":6,`+
+			`"system_status":4,"mavlink_version":5}`, string(res[0].Message))
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/messages?system_id=12", nil))
+		require.Equal(t, "[]", w.Body.String())
+	})
+
+	t.Run("messages post", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/messages",
+			bytes.NewReader([]byte(`{"name":"HEARTBEAT","message":{"type":7,"custom_mode":8}}`))))
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		for evt := range node2.Events() {
+			if fr, ok := evt.(*gomavlib.EventFrame); ok {
+				require.Equal(t, &MessageHeartbeat{
+					Type:       7,
+					CustomMode: 8,
+				}, fr.Message())
+				break
+			}
+		}
+	})
+
+	t.Run("messages post invalid", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/messages",
+			bytes.NewReader([]byte(`{"name":"UNKNOWN","message":{}}`))))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var res map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &res)
+		require.NoError(t, err)
+		require.Equal(t, float64(1), res["frames_in"])
+	})
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	type streamMessage struct {
+		SystemID byte            `json:"system_id"`
+		Name     string          `json:"name"`
+		Message  json.RawMessage `json:"message"`
+	}
+
+	t.Run("stream sse", func(t *testing.T) {
+		res, err := http.Get(srv.URL + "/stream?system_id=11&name=HEARTBEAT")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+		node2.WriteMessageAll(&MessageHeartbeat{Type: 9})
+
+		line, err := bufio.NewReader(res.Body).ReadString('\n')
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(line, "data: "))
+
+		var m streamMessage
+		err = json.Unmarshal([]byte(line[len("data: "):]), &m)
+		require.NoError(t, err)
+		require.Equal(t, byte(11), m.SystemID)
+		require.Equal(t, "HEARTBEAT", m.Name)
+		require.Equal(t, `{"type":9,"autopilot":0,"base_mode":0,"custom_mode":0,`+
+			`"system_status":0,"mavlink_version":0}`, string(m.Message))
+	})
+
+	t.Run("stream websocket", func(t *testing.T) {
+		wc, res, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/stream", nil)
+		require.NoError(t, err)
+		defer wc.Close()
+		res.Body.Close()
+
+		node2.WriteMessageAll(&MessageHeartbeat{Type: 10})
+
+		typ, byts, err := wc.ReadMessage()
+		require.NoError(t, err)
+		require.Equal(t, websocket.TextMessage, typ)
+
+		var m streamMessage
+		err = json.Unmarshal(byts, &m)
+		require.NoError(t, err)
+		require.Equal(t, "HEARTBEAT", m.Name)
+		require.Equal(t, `{"type":10,"autopilot":0,"base_mode":0,"custom_mode":0,`+
+			`"system_status":0,"mavlink_version":0}`, string(m.Message))
+	})
+
+	t.Run("stream invalid", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stream?name=UNKNOWN", nil))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestBridgeNoDialect(t *testing.T) {
+	node, err := gomavlib.NewNode(gomavlib.NodeConf{
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      10,
+		Endpoints:        []gomavlib.EndpointConf{},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	_, err = New(Conf{
+		Node: node,
+	})
+	require.EqualError(t, err, "Dialect is required")
+}
@@ -0,0 +1,233 @@
+// Package influxlog contains a writer that converts messages into the InfluxDB
+// line protocol, that can be sent to InfluxDB, Telegraf and other time-series
+// databases, in order to graph telemetry with tools like Grafana.
+// Each message is converted into a point, whose measurement is the message
+// name (i.e. HEARTBEAT), whose tags are the system and component IDs of the
+// sender, and whose fields are the numeric fields of the message.
+// Arrays are split into a field for each element.
+package influxlog
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	keyEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+)
+
+// Point is a point of a time series.
+type Point struct {
+	// the measurement, i.e. "HEARTBEAT".
+	Measurement string
+
+	// the tags, i.e. "system_id".
+	Tags map[string]string
+
+	// the fields. Values are int64 or float64.
+	Fields map[string]interface{}
+
+	// the time.
+	Time time.Time
+}
+
+func formatField(v interface{}) string {
+	switch tv := v.(type) {
+	case int64:
+		return strconv.FormatInt(tv, 10) + "i"
+
+	case float64:
+		return strconv.FormatFloat(tv, 'g', -1, 64)
+	}
+	return ""
+}
+
+func sortedKeys(m map[string]string) []string {
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// LineProtocol returns the point in line protocol format, without the
+// trailing newline. Tags and fields are sorted by key.
+func (p *Point) LineProtocol() string {
+	var b strings.Builder
+	b.WriteString(measurementEscaper.Replace(p.Measurement))
+
+	for _, k := range sortedKeys(p.Tags) {
+		b.WriteString("," + keyEscaper.Replace(k) + "=" + keyEscaper.Replace(p.Tags[k]))
+	}
+
+	fieldKeys := make([]string, 0, len(p.Fields))
+	for k := range p.Fields {
+		fieldKeys = append(fieldKeys, k)
+	}
+	sort.Strings(fieldKeys)
+
+	for i, k := range fieldKeys {
+		if i == 0 {
+			b.WriteByte(' ')
+		} else {
+			b.WriteByte(',')
+		}
+		b.WriteString(keyEscaper.Replace(k) + "=" + formatField(p.Fields[k]))
+	}
+
+	b.WriteString(" " + strconv.FormatInt(p.Time.UnixNano(), 10))
+	return b.String()
+}
+
+// numericValue returns the value of a numeric field as int64 or float64.
+func numericValue(v reflect.Value) (interface{}, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := v.Uint()
+		if u > math.MaxInt64 {
+			return float64(u), true
+		}
+		return int64(u), true
+
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		// NaN and infinite values are not supported by the line protocol
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, false
+		}
+		// avoid printing float32 approximations, i.e. 0.10000000149011612
+		if v.Kind() == reflect.Float32 {
+			f, _ = strconv.ParseFloat(strconv.FormatFloat(f, 'g', -1, 32), 64)
+		}
+		return f, true
+	}
+
+	return nil, false
+}
+
+// NewPoint converts the message of a frame, received at the given time,
+// into a Point. Strings, NaN and infinite values are not included.
+func NewPoint(t time.Time, fr frame.Frame) (*Point, error) {
+	m := fr.GetMessage()
+	if _, ok := m.(*msg.MessageRaw); ok {
+		return nil, fmt.Errorf("message %d is not decoded", m.GetID())
+	}
+
+	de, err := msg.NewDecEncoder(m)
+	if err != nil {
+		return nil, fmt.Errorf("message %T: %s", m, err)
+	}
+
+	return newPoint(t, fr, de), nil
+}
+
+func newPoint(t time.Time, fr frame.Frame, de *msg.DecEncoder) *Point {
+	p := &Point{
+		Measurement: de.Name(),
+		Tags: map[string]string{
+			"system_id":    strconv.FormatUint(uint64(fr.GetSystemID()), 10),
+			"component_id": strconv.FormatUint(uint64(fr.GetComponentID()), 10),
+		},
+		Fields: make(map[string]interface{}),
+		Time:   t,
+	}
+
+	rv := reflect.ValueOf(fr.GetMessage()).Elem()
+
+	for i, fd := range de.Fields() {
+		fv := rv.Field(i)
+
+		if fv.Kind() == reflect.Array {
+			for j := 0; j < fv.Len(); j++ {
+				if v, ok := numericValue(fv.Index(j)); ok {
+					p.Fields[fd.Name+"_"+strconv.Itoa(j)] = v
+				}
+			}
+		} else if v, ok := numericValue(fv); ok {
+			p.Fields[fd.Name] = v
+		}
+	}
+
+	return p
+}
+
+// Writer writes messages in line protocol format.
+type Writer struct {
+	w        io.Writer
+	selected map[reflect.Type]struct{}
+	des      map[reflect.Type]*msg.DecEncoder
+}
+
+// NewWriter allocates a Writer, that writes lines into w.
+// Only messages of the given types are written; if no type
+// is provided, all messages are written.
+func NewWriter(w io.Writer, messages ...msg.Message) (*Writer, error) {
+	iw := &Writer{
+		w:   w,
+		des: make(map[reflect.Type]*msg.DecEncoder),
+	}
+
+	if len(messages) > 0 {
+		iw.selected = make(map[reflect.Type]struct{})
+		for _, m := range messages {
+			_, err := msg.NewDecEncoder(m)
+			if err != nil {
+				return nil, fmt.Errorf("message %T: %s", m, err)
+			}
+			iw.selected[reflect.TypeOf(m)] = struct{}{}
+		}
+	}
+
+	return iw, nil
+}
+
+// WriteFrame writes the message of a frame, received at the given time.
+// Frames with messages that are not selected, that are not decoded
+// (*msg.MessageRaw) or that don't contain numeric fields are ignored.
+// It must not be called by multiple routines in parallel.
+func (iw *Writer) WriteFrame(t time.Time, fr frame.Frame) error {
+	m := fr.GetMessage()
+	if _, ok := m.(*msg.MessageRaw); ok {
+		return nil
+	}
+
+	typ := reflect.TypeOf(m)
+
+	if iw.selected != nil {
+		if _, ok := iw.selected[typ]; !ok {
+			return nil
+		}
+	}
+
+	de, ok := iw.des[typ]
+	if !ok {
+		var err error
+		de, err = msg.NewDecEncoder(m)
+		if err != nil {
+			return fmt.Errorf("message %T: %s", m, err)
+		}
+		iw.des[typ] = de
+	}
+
+	p := newPoint(t, fr, de)
+	if len(p.Fields) == 0 {
+		return nil
+	}
+
+	_, err := io.WriteString(iw.w, p.LineProtocol()+"\n")
+	return err
+}
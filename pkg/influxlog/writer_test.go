@@ -0,0 +1,96 @@
+package influxlog
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type MessageHeartbeat struct {
+	Type           uint8
+	Autopilot      uint8
+	BaseMode       uint8
+	CustomMode     uint32
+	SystemStatus   uint8
+	MavlinkVersion uint8
+}
+
+func (*MessageHeartbeat) GetID() uint32 {
+	return 0
+}
+
+type MessageParamValue struct {
+	ParamId    string `mavlen:"16"` //nolint:golint
+	ParamValue float32
+	ParamType  uint8
+	ParamCount uint16
+	ParamIndex uint16
+}
+
+func (*MessageParamValue) GetID() uint32 {
+	return 22
+}
+
+type MessageTest struct {
+	Values [2]float32
+}
+
+func (*MessageTest) GetID() uint32 {
+	return 100
+}
+
+func TestPointLineProtocol(t *testing.T) {
+	p := &Point{
+		Measurement: "my measurement",
+		Tags:        map[string]string{"b": "2", "a,b": "x=y"},
+		Fields:      map[string]interface{}{"f": 1.5, "i": int64(-3)},
+		Time:        time.Unix(1, 2),
+	}
+	require.Equal(t, `my\ measurement,a\,b=x\=y,b=2 f=1.5,i=-3i 1000000002`, p.LineProtocol())
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, &MessageHeartbeat{}, &MessageParamValue{}, &MessageTest{})
+	require.NoError(t, err)
+
+	for _, m := range []msg.Message{
+		&MessageHeartbeat{Type: 1, Autopilot: 2, BaseMode: 3, CustomMode: 6, SystemStatus: 4, MavlinkVersion: 5},
+		&MessageParamValue{ParamId: "test", ParamValue: 0.1, ParamCount: 2},
+		&MessageTest{Values: [2]float32{float32(math.NaN()), 2}},
+		&msg.MessageRaw{ID: 0, Content: []byte{1}},
+	} {
+		err = w.WriteFrame(time.Unix(1, 0), &frame.V2Frame{
+			SystemID:    1,
+			ComponentID: 2,
+			Message:     m,
+		})
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, "HEARTBEAT,component_id=2,system_id=1 autopilot=2i,base_mode=3i,custom_mode=6i,"+
+		"mavlink_version=5i,system_status=4i,type=1i 1000000000\n"+
+		"PARAM_VALUE,component_id=2,system_id=1 param_count=2i,param_index=0i,param_type=0i,"+
+		"param_value=0.1 1000000000\n"+
+		"TEST,component_id=2,system_id=1 values_1=2 1000000000\n", buf.String())
+}
+
+func TestWriterSelected(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, &MessageTest{})
+	require.NoError(t, err)
+
+	err = w.WriteFrame(time.Unix(1, 0), &frame.V1Frame{
+		SystemID:    1,
+		ComponentID: 2,
+		Message:     &MessageHeartbeat{},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "", buf.String())
+}
@@ -0,0 +1,338 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/grpcserver"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	bridgeReconnectPeriod = 2 * time.Second
+	bridgeConnectTimeout  = 10 * time.Second
+	bridgeQueueSize       = 256
+	bridgeBatchSize       = 64
+
+	// the period after which the subscription checks whether the node
+	// has been closed.
+	bridgeWaitPeriod = 1 * time.Second
+)
+
+type bridgeJSONMessage struct {
+	SystemID    byte            `json:"system_id"`
+	ComponentID byte            `json:"component_id"`
+	MessageID   uint32          `json:"message_id"`
+	Name        string          `json:"name,omitempty"`
+	Message     json.RawMessage `json:"message,omitempty"`
+	MessageRaw  []byte          `json:"message_raw,omitempty"`
+}
+
+type bridgeEntry struct {
+	time time.Time
+	evt  *gomavlib.EventFrame
+}
+
+// BridgeConf allows to configure a Bridge.
+type BridgeConf struct {
+	// the node whose messages are bridged.
+	Node *gomavlib.Node
+
+	// the dialect of the node.
+	Dialect *dialect.Dialect
+
+	// the address of a Kafka broker, i.e. "localhost:9092".
+	Address string
+
+	// (optional) the client identifier.
+	// It defaults to "gomavlib".
+	ClientID string
+
+	// (optional) the topic where received messages are published.
+	// It defaults to "mavlink".
+	Topic string
+
+	// (optional) the format of published messages, "json" or "protobuf".
+	// JSON messages are in the format {"system_id": 1, "component_id": 1,
+	// "message_id": 0, "name": "HEARTBEAT", "message": {...}}, while
+	// protobuf messages are encoded as the Message of proto/gomavlib.proto.
+	// It defaults to "json".
+	Format string
+
+	// (optional) a function that returns the index of the partition where
+	// messages sent by a system are published, given the number of partitions
+	// of the topic. It defaults to a function that returns the system ID
+	// modulo the number of partitions.
+	Partitioner func(systemID byte, partitions int) int
+}
+
+// Bridge publishes the messages received by a node on a Kafka topic, with
+// the system ID as key. The connection is restored automatically when
+// it fails.
+type Bridge struct {
+	conf      BridgeConf
+	dialectDE *dialect.DecEncoder
+	sub       *gomavlib.Subscription
+	queue     chan bridgeEntry
+	ctx       context.Context
+	ctxCancel func()
+
+	// out
+	subDone chan struct{}
+	done    chan struct{}
+}
+
+// NewBridge allocates a Bridge. See BridgeConf for the options.
+func NewBridge(conf BridgeConf) (*Bridge, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node is required")
+	}
+	if conf.Dialect == nil {
+		return nil, fmt.Errorf("Dialect is required")
+	}
+	if conf.Address == "" {
+		return nil, fmt.Errorf("Address is required")
+	}
+	if conf.ClientID == "" {
+		conf.ClientID = "gomavlib"
+	}
+	if conf.Topic == "" {
+		conf.Topic = "mavlink"
+	}
+	if conf.Format == "" {
+		conf.Format = "json"
+	}
+	if conf.Partitioner == nil {
+		conf.Partitioner = func(systemID byte, partitions int) int {
+			return int(systemID) % partitions
+		}
+	}
+
+	switch conf.Format {
+	case "json", "protobuf":
+	default:
+		return nil, fmt.Errorf("invalid Format: %s", conf.Format)
+	}
+
+	dialectDE, err := dialect.NewDecEncoder(conf.Dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+
+	b := &Bridge{
+		conf:      conf,
+		dialectDE: dialectDE,
+		sub: conf.Node.Subscribe(func(*gomavlib.EventFrame) bool {
+			return true
+		}),
+		queue:     make(chan bridgeEntry, bridgeQueueSize),
+		ctx:       ctx,
+		ctxCancel: ctxCancel,
+		subDone:   make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	go b.runSubscription()
+	go b.run()
+
+	return b, nil
+}
+
+// Close closes the bridge.
+func (b *Bridge) Close() {
+	b.ctxCancel()
+	<-b.subDone
+	<-b.done
+	b.sub.Close()
+}
+
+// runSubscription moves the frames of the subscription into the queue,
+// in order to publish them in batches.
+func (b *Bridge) runSubscription() {
+	defer close(b.subDone)
+
+	for {
+		evt, err := b.sub.Wait(b.ctx, bridgeWaitPeriod)
+		if err == gomavlib.ErrTimeout {
+			continue
+		}
+		if err != nil {
+			return
+		}
+
+		// do not block if the broker is slow or unreachable
+		select {
+		case b.queue <- bridgeEntry{time.Now(), evt}:
+		default:
+		}
+	}
+}
+
+func (b *Bridge) run() {
+	defer close(b.done)
+
+	for {
+		b.runConn()
+
+		// wait some seconds before reconnecting
+		timer := time.NewTimer(bridgeReconnectPeriod)
+		select {
+		case <-timer.C:
+		case <-b.ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+func (b *Bridge) runConn() {
+	var md *Metadata
+	dialDone := make(chan struct{})
+	go func() {
+		defer close(dialDone)
+
+		conn, err := Dial(b.conf.Address, b.conf.ClientID, bridgeConnectTimeout)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		md, err = conn.Metadata(b.conf.Topic)
+		if err != nil {
+			md = nil
+		}
+	}()
+
+	select {
+	case <-dialDone:
+	case <-b.ctx.Done():
+		// the connection is closed by the routine
+		return
+	}
+
+	if md == nil {
+		return
+	}
+
+	// connections to the leaders of the partitions, created when needed
+	conns := make(map[int32]*Conn)
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+
+	for {
+		select {
+		case entry := <-b.queue:
+			batch := []bridgeEntry{entry}
+		outer:
+			for len(batch) < bridgeBatchSize {
+				select {
+				case entry := <-b.queue:
+					batch = append(batch, entry)
+				default:
+					break outer
+				}
+			}
+
+			err := b.produce(md, conns, batch)
+			if err != nil {
+				return
+			}
+
+		case <-b.ctx.Done():
+			return
+		}
+	}
+}
+
+func (b *Bridge) produce(md *Metadata, conns map[int32]*Conn, batch []bridgeEntry) error {
+	records := make(map[int][]Record)
+	var partitions []int
+
+	for _, entry := range batch {
+		evt := entry.evt
+
+		value, err := b.encode(evt)
+		if err != nil {
+			continue
+		}
+
+		p := b.conf.Partitioner(evt.SystemID(), len(md.Partitions))
+		if p < 0 || p >= len(md.Partitions) {
+			continue
+		}
+
+		if _, ok := records[p]; !ok {
+			partitions = append(partitions, p)
+		}
+
+		records[p] = append(records[p], Record{
+			Key:   []byte(strconv.FormatUint(uint64(evt.SystemID()), 10)),
+			Value: value,
+			Time:  entry.time,
+		})
+	}
+
+	for _, p := range partitions {
+		partition := md.Partitions[p]
+
+		conn, ok := conns[partition.Leader]
+		if !ok {
+			addr, ok := md.Brokers[partition.Leader]
+			if !ok {
+				return fmt.Errorf("leader of partition %d not found", partition.ID)
+			}
+
+			var err error
+			conn, err = Dial(addr, b.conf.ClientID, bridgeConnectTimeout)
+			if err != nil {
+				return err
+			}
+			conns[partition.Leader] = conn
+		}
+
+		err := conn.Produce(b.conf.Topic, partition.ID, records[p])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Bridge) encode(evt *gomavlib.EventFrame) ([]byte, error) {
+	if b.conf.Format == "protobuf" {
+		return grpcserver.EncodeMessage(b.dialectDE, evt)
+	}
+
+	id := evt.Message().GetID()
+	out := bridgeJSONMessage{
+		SystemID:    evt.SystemID(),
+		ComponentID: evt.ComponentID(),
+		MessageID:   id,
+	}
+
+	// messages that are not in the dialect are sent in raw format
+	if mr, ok := evt.Message().(*msg.MessageRaw); ok {
+		out.MessageRaw = mr.Content
+	} else {
+		out.Name = b.dialectDE.MessageDEs[id].Name()
+
+		byts, err := b.dialectDE.EncodeMessageJSON(evt.Message())
+		if err != nil {
+			return nil, err
+		}
+		out.Message = byts
+	}
+
+	return json.Marshal(out)
+}
@@ -0,0 +1,174 @@
+package kafka
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func bridgeTestReadRequest(t *testing.T, br *bufio.Reader) (int16, uint32, string, []byte) {
+	var header [4]byte
+	_, err := io.ReadFull(br, header[:])
+	require.NoError(t, err)
+
+	buf := make([]byte, binary.BigEndian.Uint32(header[:]))
+	_, err = io.ReadFull(br, buf)
+	require.NoError(t, err)
+
+	apiKey := int16(binary.BigEndian.Uint16(buf))
+	correlationID := binary.BigEndian.Uint32(buf[4:])
+	l := int(binary.BigEndian.Uint16(buf[8:]))
+	clientID := string(buf[10 : 10+l])
+
+	return apiKey, correlationID, clientID, buf[10+l:]
+}
+
+func bridgeTestWriteResponse(t *testing.T, w io.Writer, correlationID uint32, body []byte) {
+	buf := make([]byte, 8+len(body))
+	binary.BigEndian.PutUint32(buf, uint32(4+len(body)))
+	binary.BigEndian.PutUint32(buf[4:], correlationID)
+	copy(buf[8:], body)
+	_, err := w.Write(buf)
+	require.NoError(t, err)
+}
+
+type MessageHeartbeat struct {
+	Type           uint8
+	Autopilot      uint8
+	BaseMode       uint8
+	CustomMode     uint32
+	SystemStatus   uint8
+	MavlinkVersion uint8
+}
+
+func (*MessageHeartbeat) GetID() uint32 {
+	return 0
+}
+
+var testDialect = &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}} //nolint:govet
+
+func TestBridge(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:9094")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	c1, c2 := net.Pipe()
+
+	node1, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          testDialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      10,
+		Endpoints:        []gomavlib.EndpointConf{gomavlib.EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          testDialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      11,
+		Endpoints:        []gomavlib.EndpointConf{gomavlib.EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	b, err := NewBridge(BridgeConf{
+		Node:     node1,
+		Dialect:  testDialect,
+		Address:  "localhost:9094",
+		ClientID: "gomavlib-10-1",
+	})
+	require.NoError(t, err)
+	defer b.Close()
+
+	// metadata
+	nconn, err := ln.Accept()
+	require.NoError(t, err)
+	defer nconn.Close()
+
+	apiKey, correlationID, clientID, _ := bridgeTestReadRequest(t, bufio.NewReader(nconn))
+	require.Equal(t, int16(3), apiKey)
+	require.Equal(t, "gomavlib-10-1", clientID)
+
+	bridgeTestWriteResponse(t, nconn, correlationID, []byte{
+		0x00, 0x00, 0x00, 0x01, // brokers
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x09, 'l', 'o', 'c', 'a', 'l', 'h', 'o', 's', 't',
+		0x00, 0x00, 0x23, 0x86, 0xff, 0xff,
+		0x00, 0x00, 0x00, 0x01, // controller
+		0x00, 0x00, 0x00, 0x01, // topics
+		0x00, 0x00, 0x00, 0x07, 'm', 'a', 'v', 'l', 'i', 'n', 'k', 0x00,
+		0x00, 0x00, 0x00, 0x02, // partitions
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	})
+
+	node2.WriteMessageAll(&MessageHeartbeat{
+		Type:           1,
+		Autopilot:      2,
+		BaseMode:       3,
+		CustomMode:     6,
+		SystemStatus:   4,
+		MavlinkVersion: 5,
+	})
+
+	// produce, on the leader of the partition
+	nconn2, err := ln.Accept()
+	require.NoError(t, err)
+	defer nconn2.Close()
+
+	apiKey, correlationID, _, body := bridgeTestReadRequest(t, bufio.NewReader(nconn2))
+	require.Equal(t, int16(0), apiKey)
+
+	// topic and partition
+	require.Equal(t, []byte{0x00, 0x00, 0x00, 0x01, 0x00, 0x07, 'm', 'a', 'v', 'l', 'i', 'n', 'k',
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01}, body[8:29])
+
+	// record batch, that contains a record with key "11"
+	batch := body[33:]
+	require.Equal(t, []byte{0x00, 0x00, 0x00, 0x01}, batch[57:61])
+	record := batch[61:]
+
+	readVarint := func() int {
+		v, n := binary.Varint(record)
+		record = record[n:]
+		return int(v)
+	}
+
+	readVarint()        // length
+	record = record[1:] // attributes
+	readVarint()        // timestamp delta
+	readVarint()        // offset delta
+	l := readVarint()
+	require.Equal(t, "11", string(record[:l]))
+	record = record[l:]
+	l = readVarint()
+	value := record[:l]
+	require.Equal(t, `{"system_id":11,"component_id":1,"message_id":0,"name":"HEARTBEAT",`+
+		`"message":{"type":1,"autopilot":2,"base_mode":3,"custom_mode":6,"system_status":4,`+
+		`"mavlink_version":5}}`, string(value))
+
+	bridgeTestWriteResponse(t, nconn2, correlationID, []byte{
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x07, 'm', 'a', 'v', 'l', 'i', 'n', 'k',
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0x00, 0x00, 0x00, 0x00,
+	})
+}
@@ -0,0 +1,422 @@
+// Package kafka contains a minimal Kafka producer, that allows to read the
+// metadata of a topic and to produce records into its partitions, with
+// record batches (message format v2) and without compression, and a bridge
+// that publishes the messages received by a node.
+package kafka
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	apiKeyProduce      = 0
+	apiKeyMetadata     = 3
+	apiVersionProduce  = 3
+	apiVersionMetadata = 1
+	maxResponseSize    = 64 * 1024 * 1024
+	recordBatchMagic   = 2
+	acksLeader         = 1
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// errors returned by brokers, as listed in
+// https://kafka.apache.org/protocol#protocol_error_codes
+var brokerErrors = map[int16]string{
+	1:  "offset out of range",
+	2:  "corrupt message",
+	3:  "unknown topic or partition",
+	5:  "leader not available",
+	6:  "not leader for partition",
+	7:  "request timed out",
+	10: "message too large",
+	17: "invalid topic",
+	18: "record list too large",
+	19: "not enough replicas",
+	29: "topic authorization failed",
+}
+
+func brokerError(code int16) error {
+	if msg, ok := brokerErrors[code]; ok {
+		return fmt.Errorf("broker error: %s", msg)
+	}
+	return fmt.Errorf("broker error: code %d", code)
+}
+
+// Record is a record that can be produced.
+type Record struct {
+	// (optional) the key.
+	Key []byte
+
+	// the value.
+	Value []byte
+
+	// the time.
+	Time time.Time
+}
+
+// Partition is a partition of a topic.
+type Partition struct {
+	// the partition ID.
+	ID int32
+
+	// the ID of the broker that is the leader of the partition.
+	Leader int32
+}
+
+// Metadata contains the metadata of a topic.
+type Metadata struct {
+	// the addresses of the brokers, indexed by broker ID.
+	Brokers map[int32]string
+
+	// the partitions of the topic, ordered by ID.
+	Partitions []Partition
+}
+
+// Conn is a client connection to a Kafka broker.
+type Conn struct {
+	nconn         net.Conn
+	br            *bufio.Reader
+	clientID      string
+	timeout       time.Duration
+	mutex         sync.Mutex
+	correlationID int32
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = append(buf, byte(len(s)>>8), byte(len(s)))
+	return append(buf, s...)
+}
+
+func appendInt16(buf []byte, v int16) []byte {
+	return append(buf, byte(v>>8), byte(v))
+}
+
+func appendInt32(buf []byte, v int32) []byte {
+	return append(buf, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func appendInt64(buf []byte, v int64) []byte {
+	return append(appendInt32(buf, int32(v>>32)), byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func appendVarint(buf []byte, v int64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(tmp[:], v)
+	return append(buf, tmp[:n]...)
+}
+
+func appendVarintBytes(buf []byte, v []byte) []byte {
+	if v == nil {
+		return appendVarint(buf, -1)
+	}
+	buf = appendVarint(buf, int64(len(v)))
+	return append(buf, v...)
+}
+
+// appendRecordBatch appends a record batch, as described in
+// https://kafka.apache.org/documentation/#recordbatch
+func appendRecordBatch(buf []byte, records []Record) []byte {
+	firstTimestamp := records[0].Time.UnixNano() / 1000000
+	maxTimestamp := firstTimestamp
+
+	var body []byte
+	for i, r := range records {
+		ts := r.Time.UnixNano() / 1000000
+		if ts > maxTimestamp {
+			maxTimestamp = ts
+		}
+
+		var rec []byte
+		rec = append(rec, 0) // attributes
+		rec = appendVarint(rec, ts-firstTimestamp)
+		rec = appendVarint(rec, int64(i))
+		rec = appendVarintBytes(rec, r.Key)
+		rec = appendVarintBytes(rec, r.Value)
+		rec = appendVarint(rec, 0) // headers
+
+		body = appendVarint(body, int64(len(rec)))
+		body = append(body, rec...)
+	}
+
+	// fields covered by the CRC
+	var crcd []byte
+	crcd = appendInt16(crcd, 0) // attributes
+	crcd = appendInt32(crcd, int32(len(records)-1))
+	crcd = appendInt64(crcd, firstTimestamp)
+	crcd = appendInt64(crcd, maxTimestamp)
+	crcd = appendInt64(crcd, -1) // producer ID
+	crcd = appendInt16(crcd, -1) // producer epoch
+	crcd = appendInt32(crcd, -1) // base sequence
+	crcd = appendInt32(crcd, int32(len(records)))
+	crcd = append(crcd, body...)
+
+	buf = appendInt64(buf, 0) // base offset
+	buf = appendInt32(buf, int32(4+1+4+len(crcd)))
+	buf = appendInt32(buf, -1) // partition leader epoch
+	buf = append(buf, recordBatchMagic)
+	buf = appendInt32(buf, int32(crc32.Checksum(crcd, crc32c)))
+	return append(buf, crcd...)
+}
+
+type reader struct {
+	buf []byte
+	err error
+}
+
+func (r *reader) read(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.buf) < n {
+		r.err = fmt.Errorf("response is too short")
+		return nil
+	}
+	ret := r.buf[:n]
+	r.buf = r.buf[n:]
+	return ret
+}
+
+func (r *reader) int8() int8 {
+	if b := r.read(1); b != nil {
+		return int8(b[0])
+	}
+	return 0
+}
+
+func (r *reader) int16() int16 {
+	if b := r.read(2); b != nil {
+		return int16(binary.BigEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (r *reader) int32() int32 {
+	if b := r.read(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+func (r *reader) int64() int64 {
+	if b := r.read(8); b != nil {
+		return int64(binary.BigEndian.Uint64(b))
+	}
+	return 0
+}
+
+func (r *reader) string() string {
+	l := r.int16()
+	if l < 0 {
+		return ""
+	}
+	return string(r.read(int(l)))
+}
+
+func (r *reader) arrayLen() int {
+	l := r.int32()
+	if l < 0 {
+		return 0
+	}
+	if int(l) > len(r.buf) {
+		r.err = fmt.Errorf("invalid array length")
+		return 0
+	}
+	return int(l)
+}
+
+// NewConn allocates a Conn, that uses an existing connection.
+// Requests fail when the broker doesn't reply within the given timeout.
+func NewConn(nconn net.Conn, clientID string, timeout time.Duration) *Conn {
+	return &Conn{
+		nconn:    nconn,
+		br:       bufio.NewReader(nconn),
+		clientID: clientID,
+		timeout:  timeout,
+	}
+}
+
+// Dial connects to a Kafka broker.
+func Dial(address string, clientID string, timeout time.Duration) (*Conn, error) {
+	nconn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConn(nconn, clientID, timeout), nil
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.nconn.Close()
+}
+
+// request sends a request and reads its response.
+func (c *Conn) request(apiKey int16, apiVersion int16, body []byte) (*reader, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.correlationID++
+	correlationID := c.correlationID
+
+	var buf []byte
+	buf = appendInt32(buf, 0) // size, filled later
+	buf = appendInt16(buf, apiKey)
+	buf = appendInt16(buf, apiVersion)
+	buf = appendInt32(buf, correlationID)
+	buf = appendString(buf, c.clientID)
+	buf = append(buf, body...)
+	binary.BigEndian.PutUint32(buf, uint32(len(buf)-4))
+
+	c.nconn.SetDeadline(time.Now().Add(c.timeout))
+	defer c.nconn.SetDeadline(time.Time{})
+
+	_, err := c.nconn.Write(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	var header [8]byte
+	_, err = io.ReadFull(c.br, header[:])
+	if err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[:4])
+	if size < 4 || size > maxResponseSize {
+		return nil, fmt.Errorf("invalid response size: %d", size)
+	}
+
+	if int32(binary.BigEndian.Uint32(header[4:])) != correlationID {
+		return nil, fmt.Errorf("unexpected correlation ID")
+	}
+
+	res := make([]byte, size-4)
+	_, err = io.ReadFull(c.br, res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reader{buf: res}, nil
+}
+
+// Metadata reads the metadata of a topic.
+func (c *Conn) Metadata(topic string) (*Metadata, error) {
+	var body []byte
+	body = appendInt32(body, 1)
+	body = appendString(body, topic)
+
+	r, err := c.request(apiKeyMetadata, apiVersionMetadata, body)
+	if err != nil {
+		return nil, err
+	}
+
+	md := &Metadata{
+		Brokers: make(map[int32]string),
+	}
+
+	for i, n := 0, r.arrayLen(); i < n; i++ {
+		id := r.int32()
+		host := r.string()
+		port := r.int32()
+		r.string() // rack
+		md.Brokers[id] = net.JoinHostPort(host, strconv.FormatInt(int64(port), 10))
+	}
+
+	r.int32() // controller ID
+
+	found := false
+
+	for i, n := 0, r.arrayLen(); i < n; i++ {
+		code := r.int16()
+		name := r.string()
+		r.int8() // is internal
+
+		var partitions []Partition
+		for j, m := 0, r.arrayLen(); j < m; j++ {
+			r.int16() // error code
+			p := Partition{ID: r.int32(), Leader: r.int32()}
+			for k, o := 0, r.arrayLen(); k < o; k++ {
+				r.int32() // replicas
+			}
+			for k, o := 0, r.arrayLen(); k < o; k++ {
+				r.int32() // in-sync replicas
+			}
+			partitions = append(partitions, p)
+		}
+
+		if r.err == nil && name == topic {
+			if code != 0 {
+				return nil, brokerError(code)
+			}
+			found = true
+			md.Partitions = partitions
+		}
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if !found || len(md.Partitions) == 0 {
+		return nil, fmt.Errorf("topic '%s' not found", topic)
+	}
+
+	sort.Slice(md.Partitions, func(i, j int) bool {
+		return md.Partitions[i].ID < md.Partitions[j].ID
+	})
+
+	return md, nil
+}
+
+// Produce produces records into a partition of a topic, and waits for the
+// acknowledgement of the leader of the partition, that must be the broker
+// of the connection.
+func (c *Conn) Produce(topic string, partition int32, records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	batch := appendRecordBatch(nil, records)
+
+	var body []byte
+	body = appendInt16(body, -1) // transactional ID
+	body = appendInt16(body, acksLeader)
+	body = appendInt32(body, int32(c.timeout/time.Millisecond))
+	body = appendInt32(body, 1)
+	body = appendString(body, topic)
+	body = appendInt32(body, 1)
+	body = appendInt32(body, partition)
+	body = appendInt32(body, int32(len(batch)))
+	body = append(body, batch...)
+
+	r, err := c.request(apiKeyProduce, apiVersionProduce, body)
+	if err != nil {
+		return err
+	}
+
+	for i, n := 0, r.arrayLen(); i < n; i++ {
+		r.string() // topic
+		for j, m := 0, r.arrayLen(); j < m; j++ {
+			r.int32() // partition
+			code := r.int16()
+			r.int64() // base offset
+			r.int64() // log append time
+
+			if r.err == nil && code != 0 {
+				return brokerError(code)
+			}
+		}
+	}
+
+	return r.err
+}
@@ -0,0 +1,192 @@
+package kafka
+
+import (
+	"bufio"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readRequest(t *testing.T, br *bufio.Reader) (int16, int16, int32, *reader) {
+	var size [4]byte
+	_, err := io.ReadFull(br, size[:])
+	require.NoError(t, err)
+
+	buf := make([]byte, binary.BigEndian.Uint32(size[:]))
+	_, err = io.ReadFull(br, buf)
+	require.NoError(t, err)
+
+	r := &reader{buf: buf}
+	apiKey := r.int16()
+	apiVersion := r.int16()
+	correlationID := r.int32()
+	require.Equal(t, "client", r.string())
+	require.NoError(t, r.err)
+
+	return apiKey, apiVersion, correlationID, r
+}
+
+func writeResponse(t *testing.T, w io.Writer, correlationID int32, body []byte) {
+	var buf []byte
+	buf = appendInt32(buf, int32(4+len(body)))
+	buf = appendInt32(buf, correlationID)
+	buf = append(buf, body...)
+	_, err := w.Write(buf)
+	require.NoError(t, err)
+}
+
+func readVarint(t *testing.T, r *reader) int64 {
+	v, n := binary.Varint(r.buf)
+	require.True(t, n > 0)
+	r.buf = r.buf[n:]
+	return v
+}
+
+func TestConn(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:9092")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	brokerDone := make(chan struct{})
+	go func() {
+		defer close(brokerDone)
+
+		nconn, err := ln.Accept()
+		require.NoError(t, err)
+		defer nconn.Close()
+		br := bufio.NewReader(nconn)
+
+		apiKey, apiVersion, correlationID, r := readRequest(t, br)
+		require.Equal(t, int16(apiKeyMetadata), apiKey)
+		require.Equal(t, int16(1), apiVersion)
+		require.Equal(t, 1, r.arrayLen())
+		require.Equal(t, "mavlink", r.string())
+
+		var res []byte
+		res = appendInt32(res, 1)
+		res = appendInt32(res, 7)
+		res = appendString(res, "broker1")
+		res = appendInt32(res, 9093)
+		res = appendInt16(res, -1) // rack
+		res = appendInt32(res, 7)  // controller
+		res = appendInt32(res, 1)
+		res = appendInt16(res, 0)
+		res = appendString(res, "mavlink")
+		res = append(res, 0)
+		res = appendInt32(res, 2)
+		for _, id := range []int32{1, 0} {
+			res = appendInt16(res, 0)
+			res = appendInt32(res, id)
+			res = appendInt32(res, 7)
+			res = appendInt32(res, 1)
+			res = appendInt32(res, 7)
+			res = appendInt32(res, 1)
+			res = appendInt32(res, 7)
+		}
+		writeResponse(t, nconn, correlationID, res)
+
+		apiKey, apiVersion, correlationID, r = readRequest(t, br)
+		require.Equal(t, int16(apiKeyProduce), apiKey)
+		require.Equal(t, int16(3), apiVersion)
+		require.Equal(t, int16(-1), r.int16())
+		require.Equal(t, int16(1), r.int16())
+		require.Equal(t, int32(5000), r.int32())
+		require.Equal(t, 1, r.arrayLen())
+		require.Equal(t, "mavlink", r.string())
+		require.Equal(t, 1, r.arrayLen())
+		require.Equal(t, int32(1), r.int32())
+		r.int32() // record set size
+
+		require.Equal(t, int64(0), r.int64())
+		r.int32() // batch length
+		require.Equal(t, int32(-1), r.int32())
+		require.Equal(t, int8(2), r.int8())
+		crc := uint32(r.int32())
+		require.Equal(t, crc32.Checksum(r.buf, crc32c), crc)
+		require.Equal(t, int16(0), r.int16())
+		require.Equal(t, int32(1), r.int32())
+		require.Equal(t, int64(1000), r.int64())
+		require.Equal(t, int64(1002), r.int64())
+		r.read(8 + 2 + 4)
+		require.Equal(t, int32(2), r.int32())
+
+		for i, exp := range []struct {
+			tsDelta int64
+			key     []byte
+			value   []byte
+		}{
+			{0, []byte("2"), []byte{1, 2}},
+			{2, nil, []byte{3}},
+		} {
+			readVarint(t, r) // length
+			require.Equal(t, int8(0), r.int8())
+			require.Equal(t, exp.tsDelta, readVarint(t, r))
+			require.Equal(t, int64(i), readVarint(t, r))
+
+			l := readVarint(t, r)
+			if exp.key == nil {
+				require.Equal(t, int64(-1), l)
+			} else {
+				require.Equal(t, exp.key, r.read(int(l)))
+			}
+
+			l = readVarint(t, r)
+			require.Equal(t, exp.value, r.read(int(l)))
+			require.Equal(t, int64(0), readVarint(t, r))
+		}
+		require.NoError(t, r.err)
+		require.Equal(t, 0, len(r.buf))
+
+		res = nil
+		res = appendInt32(res, 1)
+		res = appendString(res, "mavlink")
+		res = appendInt32(res, 1)
+		res = appendInt32(res, 1)
+		res = appendInt16(res, 0)
+		res = appendInt64(res, 15)
+		res = appendInt64(res, -1)
+		res = appendInt32(res, 0)
+		writeResponse(t, nconn, correlationID, res)
+
+		// error
+		_, _, correlationID, _ = readRequest(t, br)
+
+		res = nil
+		res = appendInt32(res, 1)
+		res = appendString(res, "mavlink")
+		res = appendInt32(res, 1)
+		res = appendInt32(res, 1)
+		res = appendInt16(res, 6)
+		res = appendInt64(res, -1)
+		res = appendInt64(res, -1)
+		res = appendInt32(res, 0)
+		writeResponse(t, nconn, correlationID, res)
+	}()
+
+	c, err := Dial("localhost:9092", "client", 5*time.Second)
+	require.NoError(t, err)
+	defer c.Close()
+
+	md, err := c.Metadata("mavlink")
+	require.NoError(t, err)
+	require.Equal(t, &Metadata{
+		Brokers:    map[int32]string{7: "broker1:9093"},
+		Partitions: []Partition{{ID: 0, Leader: 7}, {ID: 1, Leader: 7}},
+	}, md)
+
+	err = c.Produce("mavlink", 1, []Record{
+		{Key: []byte("2"), Value: []byte{1, 2}, Time: time.Unix(1, 0)},
+		{Value: []byte{3}, Time: time.Unix(1, 2000000)},
+	})
+	require.NoError(t, err)
+
+	err = c.Produce("mavlink", 1, []Record{{Value: []byte{1}, Time: time.Unix(1, 0)}})
+	require.EqualError(t, err, "broker error: not leader for partition")
+
+	<-brokerDone
+}
@@ -0,0 +1,68 @@
+// Package mmsg allows to write and read multiple UDP datagrams with a single
+// system call (sendmmsg, recvmmsg), where available.
+package mmsg
+
+import (
+	"net"
+)
+
+// WriteBatch writes multiple datagrams to a UDP connection.
+// If addr is nil, the connection must be connected to a remote address.
+// It returns the number of datagrams that have been written.
+// On Linux, datagrams are written with sendmmsg; on other systems, they are
+// written one by one.
+func WriteBatch(conn *net.UDPConn, addr *net.UDPAddr, bufs [][]byte) (int, error) {
+	if len(bufs) == 0 {
+		return 0, nil
+	}
+	return writeBatch(conn, addr, bufs)
+}
+
+func writeBatchFallback(conn *net.UDPConn, addr *net.UDPAddr, bufs [][]byte) (int, error) {
+	for i, buf := range bufs {
+		var err error
+		if addr != nil {
+			_, err = conn.WriteToUDP(buf, addr)
+		} else {
+			_, err = conn.Write(buf)
+		}
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(bufs), nil
+}
+
+// Message is a datagram read by ReadBatch.
+type Message struct {
+	// the buffer that receives the datagram.
+	Buf []byte
+
+	// the size of the datagram, filled by ReadBatch.
+	N int
+
+	// the address of the sender, filled by ReadBatch.
+	Addr *net.UDPAddr
+}
+
+// ReadBatch reads multiple datagrams from a UDP connection.
+// It blocks until at least one datagram is available, then it fills
+// the available messages and returns their number.
+// On Linux, datagrams are read with recvmmsg; on other systems, they are
+// read one by one.
+func ReadBatch(conn *net.UDPConn, msgs []Message) (int, error) {
+	if len(msgs) == 0 {
+		return 0, nil
+	}
+	return readBatch(conn, msgs)
+}
+
+func readBatchFallback(conn *net.UDPConn, msgs []Message) (int, error) {
+	n, addr, err := conn.ReadFromUDP(msgs[0].Buf)
+	if err != nil {
+		return 0, err
+	}
+	msgs[0].N = n
+	msgs[0].Addr = addr
+	return 1, nil
+}
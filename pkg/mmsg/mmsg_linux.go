@@ -0,0 +1,237 @@
+package mmsg
+
+import (
+	"net"
+	"os"
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// mmsghdr is struct mmsghdr of <sys/socket.h>.
+type mmsghdr struct {
+	hdr unix.Msghdr
+	len uint32
+}
+
+func putPort(p *uint16, port int) {
+	b := (*[2]byte)(unsafe.Pointer(p))
+	b[0] = byte(port >> 8)
+	b[1] = byte(port)
+}
+
+// sockaddr encodes an address into the representation used by a socket
+// of the given domain.
+func sockaddr(domain int, addr *net.UDPAddr) (unsafe.Pointer, uint32, bool) {
+	switch domain {
+	case unix.AF_INET:
+		ip4 := addr.IP.To4()
+		if ip4 == nil {
+			return nil, 0, false
+		}
+		sa := &unix.RawSockaddrInet4{Family: unix.AF_INET}
+		putPort(&sa.Port, addr.Port)
+		copy(sa.Addr[:], ip4)
+		return unsafe.Pointer(sa), unix.SizeofSockaddrInet4, true
+
+	case unix.AF_INET6:
+		// IPv4 addresses are converted into IPv4-mapped IPv6 addresses
+		ip6 := addr.IP.To16()
+		if ip6 == nil || addr.Zone != "" {
+			return nil, 0, false
+		}
+		sa := &unix.RawSockaddrInet6{Family: unix.AF_INET6}
+		putPort(&sa.Port, addr.Port)
+		copy(sa.Addr[:], ip6)
+		return unsafe.Pointer(sa), unix.SizeofSockaddrInet6, true
+	}
+
+	return nil, 0, false
+}
+
+func writeBatch(conn *net.UDPConn, addr *net.UDPAddr, bufs [][]byte) (int, error) {
+	rc, err := conn.SyscallConn()
+	if err != nil {
+		return writeBatchFallback(conn, addr, bufs)
+	}
+
+	var name unsafe.Pointer
+	var namelen uint32
+
+	if addr != nil {
+		var domain int
+		var derr error
+		err = rc.Control(func(fd uintptr) {
+			domain, derr = unix.GetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_DOMAIN)
+		})
+		if err != nil || derr != nil {
+			return writeBatchFallback(conn, addr, bufs)
+		}
+
+		var ok bool
+		name, namelen, ok = sockaddr(domain, addr)
+		if !ok {
+			return writeBatchFallback(conn, addr, bufs)
+		}
+	}
+
+	iovs := make([]unix.Iovec, len(bufs))
+	hdrs := make([]mmsghdr, len(bufs))
+
+	for i, buf := range bufs {
+		if len(buf) != 0 {
+			iovs[i].Base = &buf[0]
+		}
+		iovs[i].SetLen(len(buf))
+
+		hdrs[i].hdr.Name = (*byte)(name)
+		hdrs[i].hdr.Namelen = namelen
+		hdrs[i].hdr.Iov = &iovs[i]
+		hdrs[i].hdr.Iovlen = 1
+	}
+
+	done := 0
+	var serr error
+
+	err = rc.Write(func(fd uintptr) bool {
+		for done < len(hdrs) {
+			n, _, errno := unix.Syscall6(unix.SYS_SENDMMSG, fd,
+				uintptr(unsafe.Pointer(&hdrs[done])), uintptr(len(hdrs)-done), 0, 0, 0)
+
+			switch errno {
+			case 0:
+				done += int(n)
+
+			case unix.EINTR:
+
+			case unix.EAGAIN:
+				// wait until the socket is writable
+				return false
+
+			default:
+				serr = errno
+				return true
+			}
+		}
+		return true
+	})
+
+	runtime.KeepAlive(bufs)
+	runtime.KeepAlive(name)
+
+	if err != nil {
+		return done, err
+	}
+
+	if serr != nil {
+		// sendmmsg is not supported by the kernel
+		if serr == unix.ENOSYS {
+			n, err := writeBatchFallback(conn, addr, bufs[done:])
+			return done + n, err
+		}
+		return done, os.NewSyscallError("sendmmsg", serr)
+	}
+
+	return done, nil
+}
+
+func getPort(p *uint16) int {
+	b := (*[2]byte)(unsafe.Pointer(p))
+	return int(b[0])<<8 | int(b[1])
+}
+
+// parseSockaddr decodes the address of a sender.
+func parseSockaddr(rsa *unix.RawSockaddrAny) *net.UDPAddr {
+	switch rsa.Addr.Family {
+	case unix.AF_INET:
+		sa := (*unix.RawSockaddrInet4)(unsafe.Pointer(rsa))
+		ip := make(net.IP, net.IPv4len)
+		copy(ip, sa.Addr[:])
+		return &net.UDPAddr{IP: ip, Port: getPort(&sa.Port)}
+
+	case unix.AF_INET6:
+		sa := (*unix.RawSockaddrInet6)(unsafe.Pointer(rsa))
+		ip := make(net.IP, net.IPv6len)
+		copy(ip, sa.Addr[:])
+		addr := &net.UDPAddr{IP: ip, Port: getPort(&sa.Port)}
+		if sa.Scope_id != 0 {
+			if ifi, err := net.InterfaceByIndex(int(sa.Scope_id)); err == nil {
+				addr.Zone = ifi.Name
+			}
+		}
+		return addr
+	}
+
+	return nil
+}
+
+func readBatch(conn *net.UDPConn, msgs []Message) (int, error) {
+	rc, err := conn.SyscallConn()
+	if err != nil {
+		return readBatchFallback(conn, msgs)
+	}
+
+	iovs := make([]unix.Iovec, len(msgs))
+	names := make([]unix.RawSockaddrAny, len(msgs))
+	hdrs := make([]mmsghdr, len(msgs))
+
+	for i := range msgs {
+		if len(msgs[i].Buf) != 0 {
+			iovs[i].Base = &msgs[i].Buf[0]
+		}
+		iovs[i].SetLen(len(msgs[i].Buf))
+
+		hdrs[i].hdr.Name = (*byte)(unsafe.Pointer(&names[i]))
+		hdrs[i].hdr.Namelen = unix.SizeofSockaddrAny
+		hdrs[i].hdr.Iov = &iovs[i]
+		hdrs[i].hdr.Iovlen = 1
+	}
+
+	n := 0
+	var serr error
+
+	err = rc.Read(func(fd uintptr) bool {
+		for {
+			r, _, errno := unix.Syscall6(unix.SYS_RECVMMSG, fd,
+				uintptr(unsafe.Pointer(&hdrs[0])), uintptr(len(hdrs)), 0, 0, 0)
+
+			switch errno {
+			case 0:
+				n = int(r)
+				return true
+
+			case unix.EINTR:
+
+			case unix.EAGAIN:
+				// wait until the socket is readable
+				return false
+
+			default:
+				serr = errno
+				return true
+			}
+		}
+	})
+
+	runtime.KeepAlive(msgs)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if serr != nil {
+		// recvmmsg is not supported by the kernel
+		if serr == unix.ENOSYS {
+			return readBatchFallback(conn, msgs)
+		}
+		return 0, os.NewSyscallError("recvmmsg", serr)
+	}
+
+	for i := 0; i < n; i++ {
+		msgs[i].N = int(hdrs[i].len)
+		msgs[i].Addr = parseSockaddr(&names[i])
+	}
+
+	return n, nil
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+// +build !linux
+
+package mmsg
+
+import (
+	"net"
+)
+
+func writeBatch(conn *net.UDPConn, addr *net.UDPAddr, bufs [][]byte) (int, error) {
+	return writeBatchFallback(conn, addr, bufs)
+}
+
+func readBatch(conn *net.UDPConn, msgs []Message) (int, error) {
+	return readBatchFallback(conn, msgs)
+}
@@ -0,0 +1,89 @@
+package mmsg
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBatch(t *testing.T) {
+	for _, ca := range []string{"unconnected", "connected"} {
+		t.Run(ca, func(t *testing.T) {
+			srv, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+			require.NoError(t, err)
+			defer srv.Close()
+
+			var conn *net.UDPConn
+			var addr *net.UDPAddr
+			if ca == "unconnected" {
+				conn, err = net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+				addr = srv.LocalAddr().(*net.UDPAddr)
+			} else {
+				conn, err = net.DialUDP("udp4", nil, srv.LocalAddr().(*net.UDPAddr))
+			}
+			require.NoError(t, err)
+			defer conn.Close()
+
+			bufs := [][]byte{{1, 2, 3}, {4}, {5, 6}}
+			n, err := WriteBatch(conn, addr, bufs)
+			require.NoError(t, err)
+			require.Equal(t, 3, n)
+
+			err = srv.SetReadDeadline(time.Now().Add(2 * time.Second))
+			require.NoError(t, err)
+
+			buf := make([]byte, 2048)
+			for _, expected := range bufs {
+				n, _, err := srv.ReadFromUDP(buf)
+				require.NoError(t, err)
+				require.Equal(t, expected, buf[:n])
+			}
+		})
+	}
+}
+
+func TestWriteBatchEmpty(t *testing.T) {
+	n, err := WriteBatch(nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+}
+
+func TestReadBatch(t *testing.T) {
+	srv, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer srv.Close()
+
+	conn, err := net.DialUDP("udp4", nil, srv.LocalAddr().(*net.UDPAddr))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	bufs := [][]byte{{1, 2, 3}, {4}, {5, 6}}
+	for _, buf := range bufs {
+		_, err = conn.Write(buf)
+		require.NoError(t, err)
+	}
+
+	err = srv.SetReadDeadline(time.Now().Add(2 * time.Second))
+	require.NoError(t, err)
+
+	msgs := make([]Message, 8)
+	for i := range msgs {
+		msgs[i].Buf = make([]byte, 2048)
+	}
+
+	var received [][]byte
+	for len(received) < len(bufs) {
+		n, err := ReadBatch(srv, msgs)
+		require.NoError(t, err)
+		require.NotEqual(t, 0, n)
+
+		for _, m := range msgs[:n] {
+			require.Equal(t, conn.LocalAddr().String(), m.Addr.String())
+			received = append(received, append([]byte(nil), m.Buf[:m.N]...))
+		}
+	}
+
+	require.Equal(t, bufs, received)
+}
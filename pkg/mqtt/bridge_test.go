@@ -0,0 +1,123 @@
+package mqtt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type MessageHeartbeat struct {
+	Type           uint8
+	Autopilot      uint8
+	BaseMode       uint8
+	CustomMode     uint32
+	SystemStatus   uint8
+	MavlinkVersion uint8
+}
+
+func (*MessageHeartbeat) GetID() uint32 {
+	return 0
+}
+
+var testDialect = &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}} //nolint:govet
+
+func TestBridge(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:1884")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	c1, c2 := net.Pipe()
+
+	node1, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          testDialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      10,
+		Endpoints:        []gomavlib.EndpointConf{gomavlib.EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          testDialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      11,
+		Endpoints:        []gomavlib.EndpointConf{gomavlib.EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	b, err := NewBridge(BridgeConf{
+		Node:     node1,
+		Dialect:  testDialect,
+		Address:  "localhost:1884",
+		ClientID: "gomavlib-10-1",
+	})
+	require.NoError(t, err)
+	defer b.Close()
+
+	nconn, err := ln.Accept()
+	require.NoError(t, err)
+	defer nconn.Close()
+	br := bufio.NewReader(nconn)
+
+	typ, _, body, err := readPacket(br)
+	require.NoError(t, err)
+	require.Equal(t, byte(packetConnect), typ)
+	require.Equal(t, "gomavlib-10-1", string(body[12:]))
+
+	err = writePacket(nconn, packetConnAck, 0, []byte{0x00, 0x00})
+	require.NoError(t, err)
+
+	typ, _, body, err = readPacket(br)
+	require.NoError(t, err)
+	require.Equal(t, byte(packetSubscribe), typ)
+	require.Equal(t, "mavlink/write", string(body[4:len(body)-1]))
+
+	err = writePacket(nconn, packetSubAck, 0, []byte{body[0], body[1], 0x00})
+	require.NoError(t, err)
+
+	node2.WriteMessageAll(&MessageHeartbeat{
+		Type:           1,
+		Autopilot:      2,
+		BaseMode:       3,
+		CustomMode:     6,
+		SystemStatus:   4,
+		MavlinkVersion: 5,
+	})
+
+	typ, _, body, err = readPacket(br)
+	require.NoError(t, err)
+	require.Equal(t, byte(packetPublish), typ)
+	l := int(binary.BigEndian.Uint16(body))
+	require.Equal(t, "mavlink/11/1/HEARTBEAT", string(body[2:2+l]))
+	require.Equal(t, `{"type":1,"autopilot":2,"base_mode":3,"custom_mode":6,`+
+		`"system_status":4,"mavlink_version":5}`, string(body[2+l:]))
+
+	payload := []byte(`{"name":"HEARTBEAT","message":{"type":7,"custom_mode":8}}`)
+	err = writePacket(nconn, packetPublish, 0, append(appendString(nil, "mavlink/write"), payload...))
+	require.NoError(t, err)
+
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*gomavlib.EventFrame); ok {
+			require.Equal(t, &MessageHeartbeat{
+				Type:       7,
+				CustomMode: 8,
+			}, fr.Message())
+			break
+		}
+	}
+}
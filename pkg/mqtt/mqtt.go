@@ -0,0 +1,315 @@
+// Package mqtt contains a minimal MQTT 3.1.1 client, that allows to publish
+// and receive messages with QoS 0, and a bridge between a node and a MQTT
+// broker.
+package mqtt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	packetConnect     = 1
+	packetConnAck     = 2
+	packetPublish     = 3
+	packetPubAck      = 4
+	packetSubscribe   = 8
+	packetSubAck      = 9
+	packetPingReq     = 12
+	packetPingResp    = 13
+	packetDisconnect  = 14
+	maxRemainingLen   = 268435455
+	protocolLevel311  = 4
+	connectFlagClean  = 0x02
+	connectFlagPass   = 0x40
+	connectFlagUser   = 0x80
+	subAckFailureCode = 0x80
+)
+
+var connAckErrors = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+// ConnectOptions contains the options of a connection.
+type ConnectOptions struct {
+	// the client identifier.
+	ClientID string
+
+	// (optional) the user name.
+	Username string
+
+	// (optional) the password.
+	Password string
+
+	// (optional) the keep alive period. The client must call Ping() at least
+	// once within this period. It defaults to 0, that disables keep alive.
+	KeepAlive time.Duration
+}
+
+// Message is a message received from a subscribed topic.
+type Message struct {
+	Topic   string
+	Payload []byte
+}
+
+// Conn is a client connection to a MQTT broker.
+type Conn struct {
+	nconn      net.Conn
+	br         *bufio.Reader
+	writeMutex sync.Mutex
+	nextID     uint16
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = append(buf, byte(len(s)>>8), byte(len(s)))
+	return append(buf, s...)
+}
+
+func readString(buf []byte) (string, []byte, error) {
+	if len(buf) < 2 {
+		return "", nil, fmt.Errorf("invalid string")
+	}
+	l := int(binary.BigEndian.Uint16(buf))
+	buf = buf[2:]
+	if len(buf) < l {
+		return "", nil, fmt.Errorf("invalid string")
+	}
+	return string(buf[:l]), buf[l:], nil
+}
+
+func writePacket(w io.Writer, typ byte, flags byte, body []byte) error {
+	if len(body) > maxRemainingLen {
+		return fmt.Errorf("packet is too big")
+	}
+
+	buf := []byte{typ<<4 | flags}
+	l := len(body)
+	for {
+		b := byte(l % 128)
+		l /= 128
+		if l > 0 {
+			b |= 0x80
+		}
+		buf = append(buf, b)
+		if l == 0 {
+			break
+		}
+	}
+	buf = append(buf, body...)
+
+	_, err := w.Write(buf)
+	return err
+}
+
+func readPacket(br *bufio.Reader) (byte, byte, []byte, error) {
+	header, err := br.ReadByte()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	l := 0
+	mul := 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, 0, nil, fmt.Errorf("invalid remaining length")
+		}
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, 0, nil, err
+		}
+
+		l += int(b&0x7F) * mul
+		mul *= 128
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	body := make([]byte, l)
+	_, err = io.ReadFull(br, body)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	return header >> 4, header & 0x0F, body, nil
+}
+
+// NewConn allocates a Conn, that performs the handshake with the broker
+// over an existing connection.
+func NewConn(nconn net.Conn, opts ConnectOptions) (*Conn, error) {
+	c := &Conn{
+		nconn: nconn,
+		br:    bufio.NewReader(nconn),
+	}
+
+	flags := byte(connectFlagClean)
+	if opts.Username != "" {
+		flags |= connectFlagUser
+	}
+	if opts.Password != "" {
+		flags |= connectFlagPass
+	}
+
+	keepAlive := uint16(opts.KeepAlive / time.Second)
+
+	body := appendString(nil, "MQTT")
+	body = append(body, protocolLevel311, flags, byte(keepAlive>>8), byte(keepAlive))
+	body = appendString(body, opts.ClientID)
+	if opts.Username != "" {
+		body = appendString(body, opts.Username)
+	}
+	if opts.Password != "" {
+		body = appendString(body, opts.Password)
+	}
+
+	err := writePacket(nconn, packetConnect, 0, body)
+	if err != nil {
+		return nil, err
+	}
+
+	typ, _, body, err := readPacket(c.br)
+	if err != nil {
+		return nil, err
+	}
+
+	if typ != packetConnAck || len(body) != 2 {
+		return nil, fmt.Errorf("unexpected packet: %d", typ)
+	}
+
+	if body[1] != 0 {
+		if msg, ok := connAckErrors[body[1]]; ok {
+			return nil, fmt.Errorf("connection refused: %s", msg)
+		}
+		return nil, fmt.Errorf("connection refused: code %d", body[1])
+	}
+
+	return c, nil
+}
+
+// Dial connects to a MQTT broker.
+func Dial(address string, timeout time.Duration, opts ConnectOptions) (*Conn, error) {
+	nconn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	nconn.SetDeadline(time.Now().Add(timeout))
+
+	c, err := NewConn(nconn, opts)
+	if err != nil {
+		nconn.Close()
+		return nil, err
+	}
+
+	nconn.SetDeadline(time.Time{})
+
+	return c, nil
+}
+
+// Close disconnects from the broker and closes the connection.
+func (c *Conn) Close() error {
+	c.writeMutex.Lock()
+	writePacket(c.nconn, packetDisconnect, 0, nil)
+	c.writeMutex.Unlock()
+
+	return c.nconn.Close()
+}
+
+func (c *Conn) write(typ byte, flags byte, body []byte) error {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+	return writePacket(c.nconn, typ, flags, body)
+}
+
+// Publish publishes a message with QoS 0.
+func (c *Conn) Publish(topic string, payload []byte) error {
+	body := appendString(nil, topic)
+	body = append(body, payload...)
+	return c.write(packetPublish, 0, body)
+}
+
+// Subscribe subscribes to a topic with QoS 0.
+// Messages can then be received with Read().
+func (c *Conn) Subscribe(topic string) error {
+	c.writeMutex.Lock()
+	c.nextID++
+	if c.nextID == 0 {
+		c.nextID = 1
+	}
+	id := c.nextID
+	c.writeMutex.Unlock()
+
+	body := []byte{byte(id >> 8), byte(id)}
+	body = appendString(body, topic)
+	body = append(body, 0)
+	return c.write(packetSubscribe, 0x02, body)
+}
+
+// Ping sends a keep alive request.
+func (c *Conn) Ping() error {
+	return c.write(packetPingReq, 0, nil)
+}
+
+// Read reads the next message received from a subscribed topic.
+func (c *Conn) Read() (*Message, error) {
+	for {
+		typ, flags, body, err := readPacket(c.br)
+		if err != nil {
+			return nil, err
+		}
+
+		switch typ {
+		case packetPublish:
+			topic, rest, err := readString(body)
+			if err != nil {
+				return nil, err
+			}
+
+			// the broker may send messages with a QoS higher than the
+			// requested one: acknowledge them
+			qos := (flags >> 1) & 0x03
+			if qos > 0 {
+				if len(rest) < 2 {
+					return nil, fmt.Errorf("invalid packet identifier")
+				}
+				id := rest[:2]
+				rest = rest[2:]
+
+				if qos == 1 {
+					err := c.write(packetPubAck, 0, id)
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			return &Message{
+				Topic:   topic,
+				Payload: rest,
+			}, nil
+
+		case packetSubAck:
+			if len(body) < 3 {
+				return nil, fmt.Errorf("invalid SUBACK")
+			}
+			if body[2] == subAckFailureCode {
+				return nil, fmt.Errorf("subscription refused")
+			}
+
+		case packetPingResp:
+
+		default:
+			return nil, fmt.Errorf("unexpected packet: %d", typ)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package mqtt
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConn(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:1883")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	brokerDone := make(chan struct{})
+	go func() {
+		defer close(brokerDone)
+
+		nconn, err := ln.Accept()
+		require.NoError(t, err)
+		defer nconn.Close()
+		br := bufio.NewReader(nconn)
+
+		typ, _, body, err := readPacket(br)
+		require.NoError(t, err)
+		require.Equal(t, byte(packetConnect), typ)
+		require.Equal(t, []byte{
+			0x00, 0x04, 'M', 'Q', 'T', 'T', 0x04, 0xc2, 0x00, 0x1e,
+			0x00, 0x06, 'c', 'l', 'i', 'e', 'n', 't',
+			0x00, 0x04, 'u', 's', 'e', 'r',
+			0x00, 0x04, 'p', 'a', 's', 's',
+		}, body)
+
+		err = writePacket(nconn, packetConnAck, 0, []byte{0x00, 0x00})
+		require.NoError(t, err)
+
+		typ, flags, body, err := readPacket(br)
+		require.NoError(t, err)
+		require.Equal(t, byte(packetSubscribe), typ)
+		require.Equal(t, byte(0x02), flags)
+		require.Equal(t, []byte{0x00, 0x01, 0x00, 0x03, 'a', '/', 'b', 0x00}, body)
+
+		err = writePacket(nconn, packetSubAck, 0, []byte{0x00, 0x01, 0x00})
+		require.NoError(t, err)
+
+		typ, _, body, err = readPacket(br)
+		require.NoError(t, err)
+		require.Equal(t, byte(packetPublish), typ)
+		require.Equal(t, []byte{0x00, 0x03, 'c', '/', 'd', 0x01, 0x02}, body)
+
+		// QoS 1 message
+		err = writePacket(nconn, packetPublish, 0x02, []byte{0x00, 0x03, 'a', '/', 'b', 0x00, 0x05, 0x03, 0x04})
+		require.NoError(t, err)
+
+		typ, _, body, err = readPacket(br)
+		require.NoError(t, err)
+		require.Equal(t, byte(packetPubAck), typ)
+		require.Equal(t, []byte{0x00, 0x05}, body)
+
+		typ, _, _, err = readPacket(br)
+		require.NoError(t, err)
+		require.Equal(t, byte(packetDisconnect), typ)
+	}()
+
+	c, err := Dial("localhost:1883", 5*time.Second, ConnectOptions{
+		ClientID:  "client",
+		Username:  "user",
+		Password:  "pass",
+		KeepAlive: 30 * time.Second,
+	})
+	require.NoError(t, err)
+
+	err = c.Subscribe("a/b")
+	require.NoError(t, err)
+
+	err = c.Publish("c/d", []byte{0x01, 0x02})
+	require.NoError(t, err)
+
+	msg, err := c.Read()
+	require.NoError(t, err)
+	require.Equal(t, &Message{
+		Topic:   "a/b",
+		Payload: []byte{0x03, 0x04},
+	}, msg)
+
+	c.Close()
+	<-brokerDone
+}
+
+func TestConnRefused(t *testing.T) {
+	c1, c2 := net.Pipe()
+	defer c1.Close()
+	defer c2.Close()
+
+	go func() {
+		br := bufio.NewReader(c2)
+		readPacket(br)
+		writePacket(c2, packetConnAck, 0, []byte{0x00, 0x05})
+	}()
+
+	_, err := NewConn(c1, ConnectOptions{ClientID: "client"})
+	require.EqualError(t, err, "connection refused: not authorized")
+}
+
+func TestRemainingLength(t *testing.T) {
+	c1, c2 := net.Pipe()
+	defer c1.Close()
+	defer c2.Close()
+
+	payload := make([]byte, 200)
+	go writePacket(c1, packetPublish, 0, payload)
+
+	typ, _, body, err := readPacket(bufio.NewReader(c2))
+	require.NoError(t, err)
+	require.Equal(t, byte(packetPublish), typ)
+	require.Equal(t, payload, body)
+}
@@ -0,0 +1,34 @@
+package msg
+
+import (
+	"reflect"
+)
+
+// Clone returns a deep copy of a message.
+// It allows to retain messages beyond the callback or the event that
+// provided them, without sharing memory with the original.
+func Clone(m Message) Message {
+	if raw, ok := m.(*MessageRaw); ok {
+		return &MessageRaw{
+			ID:      raw.ID,
+			Content: append([]byte(nil), raw.Content...),
+		}
+	}
+
+	// messages are made of numbers, strings and arrays,
+	// therefore a copy of the struct is a deep copy.
+	ret := reflect.New(reflect.TypeOf(m).Elem())
+	ret.Elem().Set(reflect.ValueOf(m).Elem())
+	return ret.Interface().(Message)
+}
+
+// Equal checks whether two messages have the same type and
+// the same field values.
+func Equal(a Message, b Message) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+
+	return reflect.DeepEqual(reflect.ValueOf(a).Elem().Interface(),
+		reflect.ValueOf(b).Elem().Interface())
+}
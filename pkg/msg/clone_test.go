@@ -0,0 +1,35 @@
+package msg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClone(t *testing.T) {
+	m := &MessageTrajectoryRepresentationWaypoints{
+		TimeUsec:    1,
+		ValidPoints: 2,
+	}
+	m.PosX[0] = 3
+
+	c := Clone(m)
+	require.Equal(t, m, c)
+
+	m.PosX[0] = 4
+	require.Equal(t, float32(3), c.(*MessageTrajectoryRepresentationWaypoints).PosX[0])
+
+	raw := &MessageRaw{ID: 1, Content: []byte{1, 2}}
+	c = Clone(raw)
+	require.Equal(t, raw, c)
+
+	raw.Content[0] = 3
+	require.Equal(t, []byte{1, 2}, c.(*MessageRaw).Content)
+}
+
+func TestEqual(t *testing.T) {
+	require.True(t, Equal(&MessageHeartbeat{Type: 1}, &MessageHeartbeat{Type: 1}))
+	require.False(t, Equal(&MessageHeartbeat{Type: 1}, &MessageHeartbeat{Type: 2}))
+	require.False(t, Equal(&MessageHeartbeat{}, &MessageSysStatus{}))
+	require.True(t, Equal(&MessageRaw{ID: 1, Content: []byte{1}}, &MessageRaw{ID: 1, Content: []byte{1}}))
+}
@@ -0,0 +1,139 @@
+package msg
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aler9/gomavlib/pkg/x25"
+)
+
+var reFieldDefType = regexp.MustCompile(`^([a-z0-9_]+)(\[([0-9]+)\])?$`)
+
+// FieldDef is the definition of a message field, as it appears in
+// XML definitions.
+type FieldDef struct {
+	// the type, i.e. "uint8_t", "char[16]" or "uint8_t_mavlink_version".
+	Type string
+
+	// the name, i.e. "target_system".
+	Name string
+
+	// whether the field is an extension.
+	Extension bool
+}
+
+// sortFields reorders fields as described in
+// https://mavlink.io/en/guide/serialization.html#field_reordering
+func sortFields(fields []*decEncoderField) {
+	sort.Slice(fields, func(i, j int) bool {
+		// sort by weight if not extension
+		if !fields[i].isExtension && !fields[j].isExtension {
+			if w1, w2 := fieldTypeSizes[fields[i].ftype], fieldTypeSizes[fields[j].ftype]; w1 != w2 {
+				return w1 > w2
+			}
+		}
+		// sort by original index
+		return fields[i].index < fields[j].index
+	})
+}
+
+// crcExtra computes the CRC extra of sorted fields, as described in
+// https://mavlink.io/en/guide/serialization.html#crc_extra
+func crcExtra(name string, fields []*decEncoderField) byte {
+	h := x25.New()
+	h.Write([]byte(name + " "))
+
+	for _, f := range fields {
+		// skip extensions
+		if f.isExtension {
+			continue
+		}
+
+		h.Write([]byte(fieldTypeString[f.ftype] + " "))
+		h.Write([]byte(f.name + " "))
+
+		if f.arrayLength > 0 {
+			h.Write([]byte{f.arrayLength})
+		}
+	}
+
+	sum := h.Sum16()
+	return byte((sum & 0xFF) ^ (sum >> 8))
+}
+
+func fieldTypeFromDef(typ string) (fieldType, bool) {
+	for ft, str := range fieldTypeString {
+		if str == typ {
+			return ft, true
+		}
+	}
+	return 0, false
+}
+
+// Fields returns the definitions of the message fields, in the order in
+// which they appear in the Go struct.
+func (mde *DecEncoder) Fields() []FieldDef {
+	ret := make([]FieldDef, len(mde.fields))
+
+	for _, f := range mde.fields {
+		typ := fieldTypeString[f.ftype]
+		// a single char is encoded as an array of length 1
+		if f.arrayLength > 0 && !(f.ftype == typeChar && f.arrayLength == 1) {
+			typ += "[" + strconv.FormatUint(uint64(f.arrayLength), 10) + "]"
+		}
+
+		ret[f.index] = FieldDef{
+			Type:      typ,
+			Name:      f.name,
+			Extension: f.isExtension,
+		}
+	}
+
+	return ret
+}
+
+// CRCExtra computes the CRC extra of a message, given its name and its
+// fields, in the order in which they appear in the definition.
+// It allows to compute the CRC extra of messages that are not available
+// as Go structs.
+func CRCExtra(name string, fields []FieldDef) (byte, error) {
+	dfields := make([]*decEncoderField, len(fields))
+
+	for i, f := range fields {
+		matches := reFieldDefType.FindStringSubmatch(f.Type)
+		if matches == nil {
+			return 0, fmt.Errorf("invalid type of field '%s': %s", f.Name, f.Type)
+		}
+
+		// uint8_t_mavlink_version is a uint8_t
+		typ := strings.TrimSuffix(matches[1], "_mavlink_version")
+
+		ftype, ok := fieldTypeFromDef(typ)
+		if !ok {
+			return 0, fmt.Errorf("invalid type of field '%s': %s", f.Name, f.Type)
+		}
+
+		var arrayLength byte
+		if matches[3] != "" {
+			v, err := strconv.ParseUint(matches[3], 10, 8)
+			if err != nil || v == 0 {
+				return 0, fmt.Errorf("invalid array length of field '%s': %s", f.Name, matches[3])
+			}
+			arrayLength = byte(v)
+		}
+
+		dfields[i] = &decEncoderField{
+			ftype:       ftype,
+			name:        f.Name,
+			arrayLength: arrayLength,
+			index:       i,
+			isExtension: f.Extension,
+		}
+	}
+
+	sortFields(dfields)
+	return crcExtra(name, dfields), nil
+}
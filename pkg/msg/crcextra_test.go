@@ -0,0 +1,95 @@
+package msg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRCExtra(t *testing.T) {
+	for _, ca := range []struct {
+		name   string
+		fields []FieldDef
+		crc    byte
+	}{
+		{
+			"HEARTBEAT",
+			[]FieldDef{
+				{Type: "uint8_t", Name: "type"},
+				{Type: "uint8_t", Name: "autopilot"},
+				{Type: "uint8_t", Name: "base_mode"},
+				{Type: "uint32_t", Name: "custom_mode"},
+				{Type: "uint8_t", Name: "system_status"},
+				{Type: "uint8_t_mavlink_version", Name: "mavlink_version"},
+			},
+			50,
+		},
+		{
+			"PARAM_VALUE",
+			[]FieldDef{
+				{Type: "char[16]", Name: "param_id"},
+				{Type: "float", Name: "param_value"},
+				{Type: "uint8_t", Name: "param_type"},
+				{Type: "uint16_t", Name: "param_count"},
+				{Type: "uint16_t", Name: "param_index"},
+			},
+			220,
+		},
+		{
+			"OPTICAL_FLOW",
+			[]FieldDef{
+				{Type: "uint64_t", Name: "time_usec"},
+				{Type: "uint8_t", Name: "sensor_id"},
+				{Type: "int16_t", Name: "flow_x"},
+				{Type: "int16_t", Name: "flow_y"},
+				{Type: "float", Name: "flow_comp_m_x"},
+				{Type: "float", Name: "flow_comp_m_y"},
+				{Type: "uint8_t", Name: "quality"},
+				{Type: "float", Name: "ground_distance"},
+				{Type: "float", Name: "flow_rate_x", Extension: true},
+				{Type: "float", Name: "flow_rate_y", Extension: true},
+			},
+			175,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			crc, err := CRCExtra(ca.name, ca.fields)
+			require.NoError(t, err)
+			require.Equal(t, ca.crc, crc)
+		})
+	}
+}
+
+func TestCRCExtraErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		typ  string
+		err  string
+	}{
+		{"invalid type", "uint128_t", "invalid type of field 'a': uint128_t"},
+		{"invalid syntax", "uint8_t[", "invalid type of field 'a': uint8_t["},
+		{"invalid array length", "uint8_t[300]", "invalid array length of field 'a': 300"},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := CRCExtra("TEST", []FieldDef{{Type: ca.typ, Name: "a"}})
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func TestDecEncoderFields(t *testing.T) {
+	mde, err := NewDecEncoder(&MessageOpticalFlow{})
+	require.NoError(t, err)
+	require.Equal(t, []FieldDef{
+		{Type: "uint64_t", Name: "time_usec"},
+		{Type: "uint8_t", Name: "sensor_id"},
+		{Type: "int16_t", Name: "flow_x"},
+		{Type: "int16_t", Name: "flow_y"},
+		{Type: "float", Name: "flow_comp_m_x"},
+		{Type: "float", Name: "flow_comp_m_y"},
+		{Type: "uint8_t", Name: "quality"},
+		{Type: "float", Name: "ground_distance"},
+		{Type: "float", Name: "flow_rate_x", Extension: true},
+		{Type: "float", Name: "flow_rate_y", Extension: true},
+	}, mde.Fields())
+}
@@ -1,17 +1,13 @@
 package msg
 
 import (
-	"bytes"
 	"encoding/binary"
 	"fmt"
 	"math"
 	"reflect"
 	"regexp"
-	"sort"
 	"strconv"
 	"strings"
-
-	"github.com/aler9/gomavlib/pkg/x25"
 )
 
 type fieldType int
@@ -95,6 +91,7 @@ type decEncoderField struct {
 
 // DecEncoder is an object that allows to decode and encode a Message.
 type DecEncoder struct {
+	name         string
 	fields       []*decEncoderField
 	sizeNormal   byte
 	sizeExtended byte
@@ -114,6 +111,7 @@ func NewDecEncoder(msg Message) (*DecEncoder, error) {
 		return nil, fmt.Errorf("message struct name must begin with 'Message'")
 	}
 	msgName := msgGoToDef(mde.elemType.Name()[len("Message"):])
+	mde.name = msgName
 
 	// collect message fields
 	for i := 0; i < mde.elemType.NumField(); i++ {
@@ -134,8 +132,10 @@ func NewDecEncoder(msg Message) (*DecEncoder, error) {
 		if field.Tag.Get("mavenum") != "" {
 			isEnum = true
 
-			if goType.Kind() != reflect.Int {
-				return nil, fmt.Errorf("an enum must be an int")
+			switch goType.Kind() {
+			case reflect.Int, reflect.Uint32, reflect.Uint64:
+			default:
+				return nil, fmt.Errorf("an enum must be an int, an uint32 or an uint64")
 			}
 
 			tagEnum := field.Tag.Get("mavenum")
@@ -218,50 +218,41 @@ func NewDecEncoder(msg Message) (*DecEncoder, error) {
 		}
 	}
 
-	// reorder fields as described in
-	// https://mavlink.io/en/guide/serialization.html#field_reordering
-	sort.Slice(mde.fields, func(i, j int) bool {
-		// sort by weight if not extension
-		if !mde.fields[i].isExtension && !mde.fields[j].isExtension {
-			if w1, w2 := fieldTypeSizes[mde.fields[i].ftype], fieldTypeSizes[mde.fields[j].ftype]; w1 != w2 {
-				return w1 > w2
-			}
-		}
-		// sort by original index
-		return mde.fields[i].index < mde.fields[j].index
-	})
-
-	// generate CRC extra
-	// https://mavlink.io/en/guide/serialization.html#crc_extra
-	mde.crcExtra = func() byte {
-		h := x25.New()
-		h.Write([]byte(msgName + " "))
-
-		for _, f := range mde.fields {
-			// skip extensions
-			if f.isExtension {
-				continue
-			}
-
-			h.Write([]byte(fieldTypeString[f.ftype] + " "))
-			h.Write([]byte(f.name + " "))
-
-			if f.arrayLength > 0 {
-				h.Write([]byte{f.arrayLength})
-			}
-		}
-		sum := h.Sum16()
-		return byte((sum & 0xFF) ^ (sum >> 8))
-	}()
+	sortFields(mde.fields)
+	mde.crcExtra = crcExtra(msgName, mde.fields)
 
 	return mde, nil
 }
 
+// Name returns the message name, as in the definition, i.e. "HEARTBEAT".
+func (mde *DecEncoder) Name() string {
+	return mde.name
+}
+
 // CRCExtra returns the message CRC extra.
 func (mde *DecEncoder) CRCExtra() byte {
 	return mde.crcExtra
 }
 
+// FieldOffset returns the offset of a field inside the encoded message,
+// given the field name, as in the definition, i.e. "target_system".
+// It allows to read fields without decoding the whole message.
+func (mde *DecEncoder) FieldOffset(name string) (int, bool) {
+	offset := 0
+	for _, f := range mde.fields {
+		if f.name == name {
+			return offset, true
+		}
+
+		if f.arrayLength > 0 {
+			offset += int(fieldTypeSizes[f.ftype]) * int(f.arrayLength)
+		} else {
+			offset += int(fieldTypeSizes[f.ftype])
+		}
+	}
+	return 0, false
+}
+
 // Decode decodes a Message.
 func (mde *DecEncoder) Decode(buf []byte, isV2 bool) (Message, error) {
 	msg := reflect.New(mde.elemType)
@@ -270,8 +261,11 @@ func (mde *DecEncoder) Decode(buf []byte, isV2 bool) (Message, error) {
 		// in V2 buffer length can be > message or < message
 		// in this latter case it must be filled with zeros to support empty-byte de-truncation
 		// and extension fields
+		// The original buffer is not modified, since it can be shared.
 		if len(buf) < int(mde.sizeExtended) {
-			buf = append(buf, bytes.Repeat([]byte{0x00}, int(mde.sizeExtended)-len(buf))...)
+			tmp := make([]byte, mde.sizeExtended)
+			copy(tmp, buf)
+			buf = tmp
 		}
 	} else {
 		// in V1 buffer must fit message perfectly
@@ -280,6 +274,12 @@ func (mde *DecEncoder) Decode(buf []byte, isV2 bool) (Message, error) {
 		}
 	}
 
+	// use the generated decoder if available
+	if u, ok := msg.Interface().(Unmarshaler); ok {
+		u.UnmarshalPayload(buf, isV2)
+		return msg.Interface().(Message), nil
+	}
+
 	// decode field by field
 	for _, f := range mde.fields {
 		// skip extensions in V1 frames
@@ -316,32 +316,37 @@ func (mde *DecEncoder) Encode(msg Message, isV2 bool) ([]byte, error) {
 		buf = make([]byte, mde.sizeNormal)
 	}
 
-	start := buf
+	if m, ok := msg.(Marshaler); ok {
+		// use the generated encoder if available
+		m.MarshalPayload(buf, isV2)
+	} else {
+		start := buf
 
-	// encode field by field
-	for _, f := range mde.fields {
-		// skip extensions in V1 frames
-		if !isV2 && f.isExtension {
-			continue
-		}
+		// encode field by field
+		for _, f := range mde.fields {
+			// skip extensions in V1 frames
+			if !isV2 && f.isExtension {
+				continue
+			}
 
-		target := reflect.ValueOf(msg).Elem().Field(f.index)
+			target := reflect.ValueOf(msg).Elem().Field(f.index)
 
-		switch target.Kind() {
-		case reflect.Array:
-			length := target.Len()
-			for i := 0; i < length; i++ {
-				n := valueEncode(buf, target.Index(i), f)
+			switch target.Kind() {
+			case reflect.Array:
+				length := target.Len()
+				for i := 0; i < length; i++ {
+					n := valueEncode(buf, target.Index(i), f)
+					buf = buf[n:]
+				}
+
+			default:
+				n := valueEncode(buf, target, f)
 				buf = buf[n:]
 			}
-
-		default:
-			n := valueEncode(buf, target, f)
-			buf = buf[n:]
 		}
-	}
 
-	buf = start
+		buf = start
+	}
 
 	// empty-byte truncation
 	// even with truncation, message length must be at least 1 byte
@@ -357,31 +362,50 @@ func (mde *DecEncoder) Encode(msg Message, isV2 bool) ([]byte, error) {
 	return buf, nil
 }
 
+// enumSet sets the value of an enum, whose underlying type can be
+// signed or unsigned.
+func enumSet(target reflect.Value, v uint64) {
+	if target.Kind() == reflect.Int {
+		target.SetInt(int64(v))
+	} else {
+		target.SetUint(v)
+	}
+}
+
+// enumGet returns the value of an enum, whose underlying type can be
+// signed or unsigned.
+func enumGet(target reflect.Value) uint64 {
+	if target.Kind() == reflect.Int {
+		return uint64(target.Int())
+	}
+	return target.Uint()
+}
+
 func valueDecode(target reflect.Value, buf []byte, f *decEncoderField) int {
 	if f.isEnum {
 		switch f.ftype {
 		case typeUint8:
-			target.SetInt(int64(buf[0]))
+			enumSet(target, uint64(buf[0]))
 			return 1
 
 		case typeInt8:
-			target.SetInt(int64(buf[0]))
+			enumSet(target, uint64(buf[0]))
 			return 1
 
 		case typeUint16:
-			target.SetInt(int64(binary.LittleEndian.Uint16(buf)))
+			enumSet(target, uint64(binary.LittleEndian.Uint16(buf)))
 			return 2
 
 		case typeUint32:
-			target.SetInt(int64(binary.LittleEndian.Uint32(buf)))
+			enumSet(target, uint64(binary.LittleEndian.Uint32(buf)))
 			return 4
 
 		case typeInt32:
-			target.SetInt(int64(binary.LittleEndian.Uint32(buf)))
+			enumSet(target, uint64(binary.LittleEndian.Uint32(buf)))
 			return 4
 
 		case typeUint64:
-			target.SetInt(int64(binary.LittleEndian.Uint64(buf)))
+			enumSet(target, binary.LittleEndian.Uint64(buf))
 			return 8
 
 		default:
@@ -448,27 +472,27 @@ func valueEncode(buf []byte, target reflect.Value, f *decEncoderField) int {
 	if f.isEnum {
 		switch f.ftype {
 		case typeUint8:
-			buf[0] = byte(target.Int())
+			buf[0] = byte(enumGet(target))
 			return 1
 
 		case typeInt8:
-			buf[0] = byte(target.Int())
+			buf[0] = byte(enumGet(target))
 			return 1
 
 		case typeUint16:
-			binary.LittleEndian.PutUint16(buf, uint16(target.Int()))
+			binary.LittleEndian.PutUint16(buf, uint16(enumGet(target)))
 			return 2
 
 		case typeUint32:
-			binary.LittleEndian.PutUint32(buf, uint32(target.Int()))
+			binary.LittleEndian.PutUint32(buf, uint32(enumGet(target)))
 			return 4
 
 		case typeInt32:
-			binary.LittleEndian.PutUint32(buf, uint32(target.Int()))
+			binary.LittleEndian.PutUint32(buf, uint32(enumGet(target)))
 			return 4
 
 		case typeUint64:
-			binary.LittleEndian.PutUint64(buf, uint64(target.Int()))
+			binary.LittleEndian.PutUint64(buf, enumGet(target))
 			return 8
 
 		default:
@@ -8,12 +8,12 @@ import (
 )
 
 type (
-	MAV_TYPE              int //nolint:golint
-	MAV_AUTOPILOT         int //nolint:golint
-	MAV_MODE_FLAG         int //nolint:golint
-	MAV_STATE             int //nolint:golint
-	MAV_SYS_STATUS_SENSOR int //nolint:golint
-	MAV_CMD               int //nolint:golint
+	MAV_TYPE              int    //nolint:golint
+	MAV_AUTOPILOT         int    //nolint:golint
+	MAV_MODE_FLAG         int    //nolint:golint
+	MAV_STATE             int    //nolint:golint
+	MAV_SYS_STATUS_SENSOR uint32 //nolint:golint
+	MAV_CMD               int    //nolint:golint
 )
 
 type MessageHeartbeat struct {
@@ -217,6 +217,14 @@ var casesMsgs = []struct {
 		},
 		bytes.Repeat([]byte("\x01"), 31),
 	},
+	{
+		"v1 unsigned enum",
+		false,
+		&MessageSysStatus{
+			OnboardControlSensorsPresent: 0x80000001,
+		},
+		append([]byte("\x01\x00\x00\x80"), bytes.Repeat([]byte("\x00"), 27)...),
+	},
 	{
 		"v1 basic c",
 		false,
@@ -369,3 +377,119 @@ func TestEncode(t *testing.T) {
 		})
 	}
 }
+
+func TestFieldOffset(t *testing.T) {
+	mp, err := NewDecEncoder(&MessageOpticalFlow{})
+	require.NoError(t, err)
+
+	for _, ca := range []struct {
+		name   string
+		offset int
+	}{
+		{"time_usec", 0},
+		{"flow_comp_m_x", 8},
+		{"ground_distance", 16},
+		{"flow_x", 20},
+		{"sensor_id", 24},
+		{"quality", 25},
+		{"flow_rate_x", 26},
+	} {
+		offset, ok := mp.FieldOffset(ca.name)
+		require.True(t, ok)
+		require.Equal(t, ca.offset, offset)
+	}
+
+	_, ok := mp.FieldOffset("unknown")
+	require.False(t, ok)
+}
+
+func TestDecodeDoesNotModifyBuffer(t *testing.T) {
+	mp, err := NewDecEncoder(&MessagePlayTune{})
+	require.NoError(t, err)
+
+	buf := make([]byte, 4, 10)
+	copy(buf[:10], []byte("\x01\x02\x74\x65\xFF\xFF\xFF\xFF\xFF\xFF"))
+
+	msg, err := mp.Decode(buf, true)
+	require.NoError(t, err)
+	require.Equal(t, &MessagePlayTune{
+		TargetSystem:    1,
+		TargetComponent: 2,
+		Tune:            "te",
+	}, msg)
+	require.Equal(t, []byte("\x01\x02\x74\x65\xFF\xFF\xFF\xFF\xFF\xFF"), buf[:10])
+}
+
+type MessagePlayTuneCodec struct {
+	TargetSystem    uint8
+	TargetComponent uint8
+	Tune            string `mavlen:"30"`
+	Tune2           string `mavext:"true" mavlen:"200"`
+}
+
+func (*MessagePlayTuneCodec) GetID() uint32 {
+	return 258
+}
+
+var codecCalls int
+
+func (m *MessagePlayTuneCodec) MarshalPayload(buf []byte, isV2 bool) {
+	codecCalls++
+	buf[0] = m.TargetSystem
+	buf[1] = m.TargetComponent
+	copy(buf[2:32], m.Tune)
+	if !isV2 {
+		return
+	}
+	copy(buf[32:232], m.Tune2)
+}
+
+func (m *MessagePlayTuneCodec) UnmarshalPayload(buf []byte, isV2 bool) {
+	codecCalls++
+	m.TargetSystem = buf[0]
+	m.TargetComponent = buf[1]
+	m.Tune = string(bytes.TrimRight(buf[2:32], "\x00"))
+	if !isV2 {
+		return
+	}
+	m.Tune2 = string(bytes.TrimRight(buf[32:232], "\x00"))
+}
+
+func TestCodec(t *testing.T) {
+	for _, isV2 := range []bool{false, true} {
+		ref := &MessagePlayTune{
+			TargetSystem:    1,
+			TargetComponent: 2,
+			Tune:            "test1",
+		}
+		m := &MessagePlayTuneCodec{
+			TargetSystem:    1,
+			TargetComponent: 2,
+			Tune:            "test1",
+		}
+		if isV2 {
+			ref.Tune2 = "test2"
+			m.Tune2 = "test2"
+		}
+
+		refmp, err := NewDecEncoder(ref)
+		require.NoError(t, err)
+
+		mp, err := NewDecEncoder(m)
+		require.NoError(t, err)
+
+		codecCalls = 0
+
+		byts, err := mp.Encode(m, isV2)
+		require.NoError(t, err)
+
+		refByts, err := refmp.Encode(ref, isV2)
+		require.NoError(t, err)
+		require.Equal(t, refByts, byts)
+
+		dec, err := mp.Decode(byts, isV2)
+		require.NoError(t, err)
+		require.Equal(t, m, dec)
+		require.Equal(t, 2, codecCalls)
+	}
+}
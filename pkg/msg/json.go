@@ -0,0 +1,203 @@
+package msg
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// fieldsInOrder returns fields in the order of the definition.
+func (mde *DecEncoder) fieldsInOrder() []*decEncoderField {
+	ret := make([]*decEncoderField, len(mde.fields))
+	for _, f := range mde.fields {
+		ret[f.index] = f
+	}
+	return ret
+}
+
+func jsonEncodeValue(buf *bytes.Buffer, v reflect.Value, f *decEncoderField) error {
+	if f.isEnum {
+		// encode enums with their names, when available
+		if tm, ok := v.Interface().(encoding.TextMarshaler); ok {
+			if byts, err := tm.MarshalText(); err == nil {
+				enc, _ := json.Marshal(string(byts))
+				buf.Write(enc)
+				return nil
+			}
+		}
+
+		buf.WriteString(strconv.FormatUint(enumGet(v), 10))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		// NaN and infinite values are not supported by JSON: encode them as strings
+		fv := v.Float()
+		switch {
+		case math.IsNaN(fv):
+			buf.WriteString(`"NaN"`)
+			return nil
+
+		case math.IsInf(fv, 1):
+			buf.WriteString(`"+Inf"`)
+			return nil
+
+		case math.IsInf(fv, -1):
+			buf.WriteString(`"-Inf"`)
+			return nil
+		}
+	}
+
+	enc, err := json.Marshal(v.Interface())
+	if err != nil {
+		return err
+	}
+	buf.Write(enc)
+	return nil
+}
+
+func jsonDecodeValue(target reflect.Value, raw json.RawMessage, f *decEncoderField) error {
+	if f.isEnum {
+		var name string
+		if json.Unmarshal(raw, &name) == nil {
+			tu, ok := target.Addr().Interface().(encoding.TextUnmarshaler)
+			if !ok {
+				return fmt.Errorf("enum does not support names")
+			}
+			return tu.UnmarshalText([]byte(name))
+		}
+
+		var v uint64
+		err := json.Unmarshal(raw, &v)
+		if err != nil {
+			return err
+		}
+		enumSet(target, v)
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.Float32, reflect.Float64:
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			switch s {
+			case "NaN":
+				target.SetFloat(math.NaN())
+			case "+Inf", "Inf":
+				target.SetFloat(math.Inf(1))
+			case "-Inf":
+				target.SetFloat(math.Inf(-1))
+			default:
+				return fmt.Errorf("invalid float: %s", s)
+			}
+			return nil
+		}
+	}
+
+	return json.Unmarshal(raw, target.Addr().Interface())
+}
+
+// EncodeJSON encodes a message in JSON format.
+// Fields are named as in the message definition, enums are encoded with
+// their names (or with their numeric value when a name is not available),
+// NaN and infinite floats are encoded as the strings "NaN", "+Inf" and "-Inf".
+func (mde *DecEncoder) EncodeJSON(msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for i, f := range mde.fieldsInOrder() {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+
+		name, _ := json.Marshal(f.name)
+		buf.Write(name)
+		buf.WriteByte(':')
+
+		target := reflect.ValueOf(msg).Elem().Field(f.index)
+
+		if target.Kind() == reflect.Array {
+			buf.WriteByte('[')
+			for j := 0; j < target.Len(); j++ {
+				if j != 0 {
+					buf.WriteByte(',')
+				}
+				err := jsonEncodeValue(&buf, target.Index(j), f)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %s", f.name, err)
+				}
+			}
+			buf.WriteByte(']')
+			continue
+		}
+
+		err := jsonEncodeValue(&buf, target, f)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %s", f.name, err)
+		}
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// DecodeJSON decodes a message in JSON format, produced by EncodeJSON.
+// Enums can be provided by name or by numeric value.
+// Missing fields are left to zero.
+func (mde *DecEncoder) DecodeJSON(byts []byte) (Message, error) {
+	var raw map[string]json.RawMessage
+	err := json.Unmarshal(byts, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := reflect.New(mde.elemType)
+
+	for _, f := range mde.fields {
+		v, ok := raw[f.name]
+		if !ok {
+			continue
+		}
+		delete(raw, f.name)
+
+		target := msg.Elem().Field(f.index)
+
+		if target.Kind() == reflect.Array {
+			var elems []json.RawMessage
+			err := json.Unmarshal(v, &elems)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %s", f.name, err)
+			}
+
+			if len(elems) > target.Len() {
+				return nil, fmt.Errorf("field %s: too many elements", f.name)
+			}
+
+			for j, elem := range elems {
+				err := jsonDecodeValue(target.Index(j), elem, f)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %s", f.name, err)
+				}
+			}
+			continue
+		}
+
+		err := jsonDecodeValue(target, v, f)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %s", f.name, err)
+		}
+	}
+
+	if len(raw) != 0 {
+		for name := range raw {
+			return nil, fmt.Errorf("unknown field: %s", name)
+		}
+	}
+
+	return msg.Interface().(Message), nil
+}
@@ -0,0 +1,119 @@
+package msg
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (e MAV_STATE) MarshalText() ([]byte, error) {
+	if e == 4 {
+		return []byte("MAV_STATE_ACTIVE"), nil
+	}
+	return nil, errors.New("invalid value")
+}
+
+func (e *MAV_STATE) UnmarshalText(text []byte) error {
+	if string(text) == "MAV_STATE_ACTIVE" {
+		*e = 4
+		return nil
+	}
+	return errors.New("invalid value")
+}
+
+func TestJSONEncodeDecode(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		msg  Message
+		enc  string
+	}{
+		{
+			"enums",
+			&MessageHeartbeat{
+				Type:           1,
+				Autopilot:      2,
+				BaseMode:       3,
+				CustomMode:     6,
+				SystemStatus:   4,
+				MavlinkVersion: 3,
+			},
+			`{"type":1,"autopilot":2,"base_mode":3,"custom_mode":6,` +
+				`"system_status":"MAV_STATE_ACTIVE","mavlink_version":3}`,
+		},
+		{
+			"strings",
+			&MessagePlayTune{
+				TargetSystem:    1,
+				TargetComponent: 2,
+				Tune:            "abc",
+				Tune2:           "def",
+			},
+			`{"target_system":1,"target_component":2,"tune":"abc","tune2":"def"}`,
+		},
+		{
+			"mavname",
+			&MessageAhrs{
+				OmegaIx: 1,
+			},
+			`{"omegaIx":1,"omegaIy":0,"omegaIz":0,"accel_weight":0,` +
+				`"renorm_val":0,"error_rp":0,"error_yaw":0}`,
+		},
+		{
+			"arrays",
+			&MessageAttitudeQuaternionCov{
+				TimeUsec:   1,
+				Q:          [4]float32{1, 2, 3, 4},
+				Covariance: [9]float32{1},
+			},
+			`{"time_usec":1,"q":[1,2,3,4],"rollspeed":0,"pitchspeed":0,"yawspeed":0,` +
+				`"covariance":[1,0,0,0,0,0,0,0,0]}`,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			mde, err := NewDecEncoder(ca.msg)
+			require.NoError(t, err)
+
+			enc, err := mde.EncodeJSON(ca.msg)
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, string(enc))
+
+			dec, err := mde.DecodeJSON(enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.msg, dec)
+		})
+	}
+}
+
+func TestJSONNaN(t *testing.T) {
+	mde, err := NewDecEncoder(&MessageAttitudeQuaternionCov{})
+	require.NoError(t, err)
+
+	enc, err := mde.EncodeJSON(&MessageAttitudeQuaternionCov{
+		Rollspeed:  float32(math.NaN()),
+		Pitchspeed: float32(math.Inf(1)),
+		Yawspeed:   float32(math.Inf(-1)),
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"time_usec":0,"q":[0,0,0,0],"rollspeed":"NaN","pitchspeed":"+Inf",`+
+		`"yawspeed":"-Inf","covariance":[0,0,0,0,0,0,0,0,0]}`, string(enc))
+
+	dec, err := mde.DecodeJSON(enc)
+	require.NoError(t, err)
+	m := dec.(*MessageAttitudeQuaternionCov)
+	require.True(t, math.IsNaN(float64(m.Rollspeed)))
+	require.True(t, math.IsInf(float64(m.Pitchspeed), 1))
+	require.True(t, math.IsInf(float64(m.Yawspeed), -1))
+}
+
+func TestJSONDecodeErrors(t *testing.T) {
+	mde, err := NewDecEncoder(&MessageHeartbeat{})
+	require.NoError(t, err)
+
+	_, err = mde.DecodeJSON([]byte(`{"unknown":1}`))
+	require.EqualError(t, err, "unknown field: unknown")
+
+	_, err = mde.DecodeJSON([]byte(`{"system_status":"MAV_STATE_UNKNOWN"}`))
+	require.EqualError(t, err, "field system_status: invalid value")
+}
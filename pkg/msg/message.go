@@ -8,6 +8,24 @@ type Message interface {
 	GetID() uint32
 }
 
+// Marshaler is implemented by messages that are able to encode themselves
+// without reflection. Messages generated by dialect-import with the --codec
+// flag implement it. It is used by DecEncoder when available.
+type Marshaler interface {
+	// MarshalPayload encodes the message into buf, that is zero-filled and
+	// sized to contain all fields, including extensions when isV2 is true.
+	MarshalPayload(buf []byte, isV2 bool)
+}
+
+// Unmarshaler is implemented by messages that are able to decode themselves
+// without reflection. Messages generated by dialect-import with the --codec
+// flag implement it. It is used by DecEncoder when available.
+type Unmarshaler interface {
+	// UnmarshalPayload decodes the message from buf, that is sized to
+	// contain all fields, including extensions when isV2 is true.
+	UnmarshalPayload(buf []byte, isV2 bool)
+}
+
 // MessageRaw is a special struct that contains an unencoded message.
 // It is used:
 //
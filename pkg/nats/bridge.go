@@ -0,0 +1,287 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	bridgeReconnectPeriod = 2 * time.Second
+	bridgeConnectTimeout  = 10 * time.Second
+	bridgePingInterval    = 30 * time.Second
+)
+
+type bridgeJSONMessage struct {
+	MessageID *uint32         `json:"message_id"`
+	Name      string          `json:"name"`
+	Message   json.RawMessage `json:"message"`
+}
+
+// BridgeConf allows to configure a Bridge.
+type BridgeConf struct {
+	// the node whose messages are bridged.
+	Node *gomavlib.Node
+
+	// the dialect of the node.
+	Dialect *dialect.Dialect
+
+	// the address of the NATS server, i.e. "localhost:4222".
+	Address string
+
+	// (optional) the client name.
+	Name string
+
+	// (optional) the user name.
+	Username string
+
+	// (optional) the password.
+	Password string
+
+	// (optional) the authentication token.
+	Token string
+
+	// (optional) the subject where received messages are published. It can
+	// contain the variables {system_id}, {component_id}, {message_id} and
+	// {message_name}. It defaults to "mavlink.{system_id}.{message_name}".
+	Subject string
+
+	// (optional) the subject where messages to be written are read. Messages
+	// must be in the format {"name": "HEARTBEAT", "message": {...}}.
+	// It defaults to "mavlink.write".
+	WriteSubject string
+}
+
+// Bridge publishes the messages received by a node on a NATS server, in
+// JSON format, and writes to all channels of the node the messages
+// published on WriteSubject. The connection is restored automatically
+// when it fails.
+type Bridge struct {
+	conf      BridgeConf
+	dialectDE *dialect.DecEncoder
+	sub       *gomavlib.Subscription
+	ctx       context.Context
+	ctxCancel func()
+
+	// out
+	done chan struct{}
+}
+
+// NewBridge allocates a Bridge. See BridgeConf for the options.
+func NewBridge(conf BridgeConf) (*Bridge, error) {
+	if conf.Node == nil {
+		return nil, fmt.Errorf("Node is required")
+	}
+	if conf.Dialect == nil {
+		return nil, fmt.Errorf("Dialect is required")
+	}
+	if conf.Address == "" {
+		return nil, fmt.Errorf("Address is required")
+	}
+	if conf.Subject == "" {
+		conf.Subject = "mavlink.{system_id}.{message_name}"
+	}
+	if conf.WriteSubject == "" {
+		conf.WriteSubject = "mavlink.write"
+	}
+
+	dialectDE, err := dialect.NewDecEncoder(conf.Dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+
+	b := &Bridge{
+		conf:      conf,
+		dialectDE: dialectDE,
+		sub: conf.Node.Subscribe(func(*gomavlib.EventFrame) bool {
+			return true
+		}),
+		ctx:       ctx,
+		ctxCancel: ctxCancel,
+		done:      make(chan struct{}),
+	}
+
+	go b.run()
+
+	return b, nil
+}
+
+// Close closes the bridge. It must be called before closing the node.
+func (b *Bridge) Close() {
+	b.ctxCancel()
+	<-b.done
+	b.sub.Close()
+}
+
+func (b *Bridge) run() {
+	defer close(b.done)
+
+	for {
+		b.runConn()
+
+		// wait some seconds before reconnecting
+		timer := time.NewTimer(bridgeReconnectPeriod)
+		select {
+		case <-timer.C:
+		case <-b.ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+func (b *Bridge) runConn() {
+	var conn *Conn
+	dialDone := make(chan struct{})
+	go func() {
+		defer close(dialDone)
+		var err error
+		conn, err = Dial(b.conf.Address, bridgeConnectTimeout, ConnectOptions{
+			Name:     b.conf.Name,
+			Username: b.conf.Username,
+			Password: b.conf.Password,
+			Token:    b.conf.Token,
+		})
+		if err != nil {
+			conn = nil
+		}
+	}()
+
+	select {
+	case <-dialDone:
+	case <-b.ctx.Done():
+		go func() {
+			<-dialDone
+			if conn != nil {
+				conn.Close()
+			}
+		}()
+		return
+	}
+
+	if conn == nil {
+		return
+	}
+
+	err := conn.Subscribe(b.conf.WriteSubject)
+	if err != nil {
+		conn.Close()
+		return
+	}
+
+	// the context is canceled when the reader fails
+	connCtx, connCancel := context.WithCancel(b.ctx)
+
+	readerDone := make(chan struct{})
+	defer func() {
+		conn.Close()
+		<-readerDone
+		connCancel()
+	}()
+
+	go func() {
+		defer close(readerDone)
+		defer connCancel()
+
+		for {
+			in, err := conn.Read()
+			if err != nil {
+				return
+			}
+
+			out, err := b.decode(in.Payload)
+			if err != nil {
+				continue
+			}
+
+			b.conf.Node.WriteMessageAll(out)
+		}
+	}()
+
+	for {
+		evt, err := b.sub.Wait(connCtx, bridgePingInterval)
+		if err == gomavlib.ErrTimeout {
+			// send a keep alive request when there's no traffic
+			err := conn.Ping()
+			if err != nil {
+				return
+			}
+			continue
+		}
+		if err != nil {
+			return
+		}
+
+		subject, payload, ok := b.encode(evt)
+		if !ok {
+			continue
+		}
+
+		err = conn.Publish(subject, payload)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (b *Bridge) encode(evt *gomavlib.EventFrame) (string, []byte, bool) {
+	// messages that are not in the dialect are not published
+	if _, ok := evt.Message().(*msg.MessageRaw); ok {
+		return "", nil, false
+	}
+
+	id := evt.Message().GetID()
+
+	payload, err := b.dialectDE.EncodeMessageJSON(evt.Message())
+	if err != nil {
+		return "", nil, false
+	}
+
+	subject := strings.NewReplacer(
+		"{system_id}", strconv.FormatUint(uint64(evt.SystemID()), 10),
+		"{component_id}", strconv.FormatUint(uint64(evt.ComponentID()), 10),
+		"{message_id}", strconv.FormatUint(uint64(id), 10),
+		"{message_name}", b.dialectDE.MessageDEs[id].Name(),
+	).Replace(b.conf.Subject)
+
+	return subject, payload, true
+}
+
+func (b *Bridge) decode(payload []byte) (msg.Message, error) {
+	var in bridgeJSONMessage
+	err := json.Unmarshal(payload, &in)
+	if err != nil {
+		return nil, err
+	}
+
+	var id uint32
+	switch {
+	case in.Name != "":
+		m, ok := b.conf.Dialect.GetMessageByName(in.Name)
+		if !ok {
+			return nil, fmt.Errorf("message %s is not in the dialect", in.Name)
+		}
+		id = m.GetID()
+
+	case in.MessageID != nil:
+		id = *in.MessageID
+
+	default:
+		return nil, fmt.Errorf("message_id or name not provided")
+	}
+
+	if in.Message == nil {
+		in.Message = json.RawMessage("{}")
+	}
+
+	return b.dialectDE.DecodeMessageJSON(id, in.Message)
+}
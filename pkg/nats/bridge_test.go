@@ -0,0 +1,131 @@
+package nats
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type MessageHeartbeat struct {
+	Type           uint8
+	Autopilot      uint8
+	BaseMode       uint8
+	CustomMode     uint32
+	SystemStatus   uint8
+	MavlinkVersion uint8
+}
+
+func (*MessageHeartbeat) GetID() uint32 {
+	return 0
+}
+
+var testDialect = &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}} //nolint:govet
+
+func TestBridge(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:4223")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	c1, c2 := net.Pipe()
+
+	node1, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          testDialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      10,
+		Endpoints:        []gomavlib.EndpointConf{gomavlib.EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          testDialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      11,
+		Endpoints:        []gomavlib.EndpointConf{gomavlib.EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	b, err := NewBridge(BridgeConf{
+		Node:    node1,
+		Dialect: testDialect,
+		Address: "localhost:4223",
+		Name:    "gomavlib-10-1",
+	})
+	require.NoError(t, err)
+	defer b.Close()
+
+	nconn, err := ln.Accept()
+	require.NoError(t, err)
+	defer nconn.Close()
+	br := bufio.NewReader(nconn)
+
+	_, err = nconn.Write([]byte("INFO {}\r\n"))
+	require.NoError(t, err)
+
+	line, err := readLine(br)
+	require.NoError(t, err)
+	require.True(t, strings.Contains(line, `"name":"gomavlib-10-1"`))
+
+	line, err = readLine(br)
+	require.NoError(t, err)
+	require.Equal(t, "PING", line)
+
+	_, err = nconn.Write([]byte("PONG\r\n"))
+	require.NoError(t, err)
+
+	line, err = readLine(br)
+	require.NoError(t, err)
+	require.Equal(t, "SUB mavlink.write 1", line)
+
+	node2.WriteMessageAll(&MessageHeartbeat{
+		Type:           1,
+		Autopilot:      2,
+		BaseMode:       3,
+		CustomMode:     6,
+		SystemStatus:   4,
+		MavlinkVersion: 5,
+	})
+
+	line, err = readLine(br)
+	require.NoError(t, err)
+	parts := strings.Split(line, " ")
+	require.Equal(t, []string{"PUB", "mavlink.11.HEARTBEAT"}, parts[:2])
+	l, err := strconv.Atoi(parts[2])
+	require.NoError(t, err)
+	payload := make([]byte, l+2)
+	_, err = io.ReadFull(br, payload)
+	require.NoError(t, err)
+	require.Equal(t, `{"type":1,"autopilot":2,"base_mode":3,"custom_mode":6,`+
+		`"system_status":4,"mavlink_version":5}`, string(payload[:l]))
+
+	in := `{"name":"HEARTBEAT","message":{"type":7,"custom_mode":8}}`
+	_, err = nconn.Write([]byte("MSG mavlink.write 1 " + strconv.Itoa(len(in)) + "\r\n" + in + "\r\n"))
+	require.NoError(t, err)
+
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*gomavlib.EventFrame); ok {
+			require.Equal(t, &MessageHeartbeat{
+				Type:       7,
+				CustomMode: 8,
+			}, fr.Message())
+			break
+		}
+	}
+}
@@ -0,0 +1,259 @@
+// Package nats contains a minimal NATS client, that allows to publish
+// and receive messages, and a bridge between a node and a NATS server.
+package nats
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	maxLineSize    = 4096
+	maxPayloadSize = 64 * 1024 * 1024
+)
+
+// ConnectOptions contains the options of a connection.
+type ConnectOptions struct {
+	// (optional) the client name.
+	Name string
+
+	// (optional) the user name.
+	Username string
+
+	// (optional) the password.
+	Password string
+
+	// (optional) the authentication token.
+	Token string
+}
+
+// Message is a message received from a subscribed subject.
+type Message struct {
+	Subject string
+	Payload []byte
+}
+
+type connectRequest struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name,omitempty"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+	Lang     string `json:"lang"`
+	Version  string `json:"version"`
+	Protocol int    `json:"protocol"`
+}
+
+// Conn is a client connection to a NATS server.
+type Conn struct {
+	nconn      net.Conn
+	br         *bufio.Reader
+	writeMutex sync.Mutex
+	nextSID    int
+}
+
+func readLine(br *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		part, isPrefix, err := br.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		line = append(line, part...)
+		if len(line) > maxLineSize {
+			return "", fmt.Errorf("line is too long")
+		}
+		if !isPrefix {
+			return string(line), nil
+		}
+	}
+}
+
+func parseError(line string) error {
+	return fmt.Errorf("server error: %s", strings.Trim(strings.TrimSpace(line[len("-ERR"):]), "'"))
+}
+
+// NewConn allocates a Conn, that performs the handshake with the server
+// over an existing connection.
+func NewConn(nconn net.Conn, opts ConnectOptions) (*Conn, error) {
+	c := &Conn{
+		nconn: nconn,
+		br:    bufio.NewReader(nconn),
+	}
+
+	line, err := readLine(c.br)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(line, "INFO ") {
+		return nil, fmt.Errorf("unexpected line: %s", line)
+	}
+
+	req, _ := json.Marshal(connectRequest{
+		Name:     opts.Name,
+		User:     opts.Username,
+		Pass:     opts.Password,
+		Token:    opts.Token,
+		Lang:     "go",
+		Version:  "1.0.0",
+		Protocol: 1,
+	})
+
+	// PING allows to know whether CONNECT has been accepted
+	_, err = nconn.Write([]byte("CONNECT " + string(req) + "\r\nPING\r\n"))
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		line, err := readLine(c.br)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case line == "PONG":
+			return c, nil
+
+		case strings.HasPrefix(line, "-ERR"):
+			return nil, parseError(line)
+
+		case line == "+OK", strings.HasPrefix(line, "INFO "):
+
+		default:
+			return nil, fmt.Errorf("unexpected line: %s", line)
+		}
+	}
+}
+
+// Dial connects to a NATS server.
+func Dial(address string, timeout time.Duration, opts ConnectOptions) (*Conn, error) {
+	nconn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	nconn.SetDeadline(time.Now().Add(timeout))
+
+	c, err := NewConn(nconn, opts)
+	if err != nil {
+		nconn.Close()
+		return nil, err
+	}
+
+	nconn.SetDeadline(time.Time{})
+
+	return c, nil
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.nconn.Close()
+}
+
+func (c *Conn) write(byts []byte) error {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+	_, err := c.nconn.Write(byts)
+	return err
+}
+
+func checkSubject(subject string) error {
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return fmt.Errorf("invalid subject: '%s'", subject)
+	}
+	return nil
+}
+
+// Publish publishes a message.
+func (c *Conn) Publish(subject string, payload []byte) error {
+	err := checkSubject(subject)
+	if err != nil {
+		return err
+	}
+
+	buf := []byte("PUB " + subject + " " + strconv.Itoa(len(payload)) + "\r\n")
+	buf = append(buf, payload...)
+	buf = append(buf, "\r\n"...)
+	return c.write(buf)
+}
+
+// Subscribe subscribes to a subject, that can contain wildcards.
+// Messages can then be received with Read().
+func (c *Conn) Subscribe(subject string) error {
+	err := checkSubject(subject)
+	if err != nil {
+		return err
+	}
+
+	c.writeMutex.Lock()
+	c.nextSID++
+	sid := c.nextSID
+	c.writeMutex.Unlock()
+
+	return c.write([]byte("SUB " + subject + " " + strconv.Itoa(sid) + "\r\n"))
+}
+
+// Ping sends a keep alive request.
+func (c *Conn) Ping() error {
+	return c.write([]byte("PING\r\n"))
+}
+
+// Read reads the next message received from a subscribed subject.
+// Keep alive requests of the server are answered automatically.
+func (c *Conn) Read() (*Message, error) {
+	for {
+		line, err := readLine(c.br)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case strings.HasPrefix(line, "MSG "):
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			parts := strings.Fields(line)
+			if len(parts) != 4 && len(parts) != 5 {
+				return nil, fmt.Errorf("invalid MSG: %s", line)
+			}
+
+			size, err := strconv.Atoi(parts[len(parts)-1])
+			if err != nil || size < 0 || size > maxPayloadSize {
+				return nil, fmt.Errorf("invalid MSG: %s", line)
+			}
+
+			payload := make([]byte, size+2)
+			_, err = io.ReadFull(c.br, payload)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Message{
+				Subject: parts[1],
+				Payload: payload[:size],
+			}, nil
+
+		case line == "PING":
+			err := c.write([]byte("PONG\r\n"))
+			if err != nil {
+				return nil, err
+			}
+
+		case strings.HasPrefix(line, "-ERR"):
+			return nil, parseError(line)
+
+		case line == "PONG", line == "+OK", strings.HasPrefix(line, "INFO "):
+
+		default:
+			return nil, fmt.Errorf("unexpected line: %s", line)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package nats
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConn(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:4222")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	serverDone := make(chan struct{})
+	go func() {
+		defer close(serverDone)
+
+		nconn, err := ln.Accept()
+		require.NoError(t, err)
+		defer nconn.Close()
+		br := bufio.NewReader(nconn)
+
+		_, err = nconn.Write([]byte("INFO {\"server_id\":\"test\"}\r\n"))
+		require.NoError(t, err)
+
+		line, err := readLine(br)
+		require.NoError(t, err)
+		require.Equal(t, `CONNECT {"verbose":false,"pedantic":false,"name":"client",`+
+			`"user":"user","pass":"pass","lang":"go","version":"1.0.0","protocol":1}`, line)
+
+		line, err = readLine(br)
+		require.NoError(t, err)
+		require.Equal(t, "PING", line)
+
+		_, err = nconn.Write([]byte("PONG\r\n"))
+		require.NoError(t, err)
+
+		line, err = readLine(br)
+		require.NoError(t, err)
+		require.Equal(t, "SUB a.> 1", line)
+
+		line, err = readLine(br)
+		require.NoError(t, err)
+		require.Equal(t, "PUB c.d 2", line)
+
+		line, err = readLine(br)
+		require.NoError(t, err)
+		require.Equal(t, "\x01\x02", line)
+
+		_, err = nconn.Write([]byte("PING\r\nMSG a.b 1 5\r\nhe\r\no\r\nMSG a.c 1 reply 0\r\n\r\n-ERR 'Stale Connection'\r\n"))
+		require.NoError(t, err)
+
+		line, err = readLine(br)
+		require.NoError(t, err)
+		require.Equal(t, "PONG", line)
+	}()
+
+	c, err := Dial("localhost:4222", 5*time.Second, ConnectOptions{
+		Name:     "client",
+		Username: "user",
+		Password: "pass",
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	err = c.Subscribe("a.>")
+	require.NoError(t, err)
+
+	err = c.Publish("c.d", []byte{0x01, 0x02})
+	require.NoError(t, err)
+
+	err = c.Publish("c d", nil)
+	require.EqualError(t, err, "invalid subject: 'c d'")
+
+	msg, err := c.Read()
+	require.NoError(t, err)
+	require.Equal(t, &Message{Subject: "a.b", Payload: []byte("he\r\no")}, msg)
+
+	msg, err = c.Read()
+	require.NoError(t, err)
+	require.Equal(t, &Message{Subject: "a.c", Payload: []byte{}}, msg)
+
+	_, err = c.Read()
+	require.EqualError(t, err, "server error: Stale Connection")
+
+	<-serverDone
+}
@@ -0,0 +1,306 @@
+package pcap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+)
+
+const (
+	pcapMagicMicro = 0xA1B2C3D4
+	pcapMagicNano  = 0xA1B23C4D
+
+	pcapngBlockSectionHeader       = 0x0A0D0D0A
+	pcapngBlockInterfaceDesc       = 0x00000001
+	pcapngBlockSimplePacket        = 0x00000003
+	pcapngBlockEnhancedPacket      = 0x00000006
+	pcapngByteOrderMagic           = 0x1A2B3C4D
+	pcapngOptionEnd                = 0
+	pcapngOptionInterfaceTimestamp = 9
+
+	// maximum size of a block, used to prevent allocations of huge buffers
+	// in case of corrupted files.
+	maxBlockSize = 16 * 1024 * 1024
+)
+
+// packet is a link-layer packet read from a capture.
+type packet struct {
+	time     time.Time
+	linkType uint32
+	data     []byte
+}
+
+// fileReader reads packets from a capture file.
+type fileReader interface {
+	readPacket() (*packet, error)
+}
+
+func newFileReader(r io.Reader) (fileReader, error) {
+	var magic [4]byte
+	_, err := io.ReadFull(r, magic[:])
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case binary.LittleEndian.Uint32(magic[:]) == pcapMagicMicro,
+		binary.LittleEndian.Uint32(magic[:]) == pcapMagicNano:
+		return newPcapReader(r, binary.LittleEndian, binary.LittleEndian.Uint32(magic[:]))
+
+	case binary.BigEndian.Uint32(magic[:]) == pcapMagicMicro,
+		binary.BigEndian.Uint32(magic[:]) == pcapMagicNano:
+		return newPcapReader(r, binary.BigEndian, binary.BigEndian.Uint32(magic[:]))
+
+	case binary.LittleEndian.Uint32(magic[:]) == pcapngBlockSectionHeader:
+		pr := &pcapngReader{r: r}
+		err := pr.readSectionHeader()
+		if err != nil {
+			return nil, err
+		}
+		return pr, nil
+	}
+
+	return nil, fmt.Errorf("unsupported file format")
+}
+
+// pcapReader reads packets from a pcap file.
+type pcapReader struct {
+	r        io.Reader
+	order    binary.ByteOrder
+	nano     bool
+	linkType uint32
+}
+
+func newPcapReader(r io.Reader, order binary.ByteOrder, magic uint32) (*pcapReader, error) {
+	var header [20]byte
+	_, err := io.ReadFull(r, header[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &pcapReader{
+		r:        r,
+		order:    order,
+		nano:     magic == pcapMagicNano,
+		linkType: order.Uint32(header[16:]) & 0xFFFF,
+	}, nil
+}
+
+func (pr *pcapReader) readPacket() (*packet, error) {
+	var header [16]byte
+	_, err := io.ReadFull(pr.r, header[:])
+	if err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return nil, err
+	}
+
+	sec := int64(pr.order.Uint32(header[0:]))
+	frac := int64(pr.order.Uint32(header[4:]))
+	if !pr.nano {
+		frac *= 1000
+	}
+
+	le := pr.order.Uint32(header[8:])
+	if le > maxBlockSize {
+		return nil, fmt.Errorf("packet too big")
+	}
+
+	data := make([]byte, le)
+	_, err = io.ReadFull(pr.r, data)
+	if err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return nil, err
+	}
+
+	return &packet{
+		time:     time.Unix(sec, frac),
+		linkType: pr.linkType,
+		data:     data,
+	}, nil
+}
+
+type pcapngInterface struct {
+	linkType       uint32
+	unitsPerSecond uint64
+}
+
+// pcapngReader reads packets from a pcapng file.
+type pcapngReader struct {
+	r          io.Reader
+	order      binary.ByteOrder
+	interfaces []*pcapngInterface
+}
+
+// readSectionHeader reads a section header block, whose type has already been read.
+func (pr *pcapngReader) readSectionHeader() error {
+	var header [8]byte
+	_, err := io.ReadFull(pr.r, header[:])
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case binary.LittleEndian.Uint32(header[4:]) == pcapngByteOrderMagic:
+		pr.order = binary.LittleEndian
+	case binary.BigEndian.Uint32(header[4:]) == pcapngByteOrderMagic:
+		pr.order = binary.BigEndian
+	default:
+		return fmt.Errorf("invalid byte order magic")
+	}
+
+	// interfaces are local to sections
+	pr.interfaces = nil
+
+	le := pr.order.Uint32(header[0:])
+	if le < 12+4 || le > maxBlockSize {
+		return fmt.Errorf("invalid block length")
+	}
+
+	_, err = io.CopyN(ioutil.Discard, pr.r, int64(le-12))
+	return err
+}
+
+func (pr *pcapngReader) readInterface(body []byte) error {
+	if len(body) < 8 {
+		return fmt.Errorf("interface description block too short")
+	}
+
+	intf := &pcapngInterface{
+		linkType:       uint32(pr.order.Uint16(body[0:])),
+		unitsPerSecond: 1000000,
+	}
+
+	// options
+	opts := body[8:]
+	for len(opts) >= 4 {
+		code := pr.order.Uint16(opts[0:])
+		le := int(pr.order.Uint16(opts[2:]))
+		opts = opts[4:]
+		if code == pcapngOptionEnd || len(opts) < le {
+			break
+		}
+
+		if code == pcapngOptionInterfaceTimestamp && le >= 1 {
+			v := opts[0]
+			if (v & 0x80) != 0 {
+				if (v & 0x7F) > 30 {
+					return fmt.Errorf("unsupported timestamp resolution")
+				}
+				intf.unitsPerSecond = 1 << (v & 0x7F)
+			} else {
+				if v > 9 {
+					return fmt.Errorf("unsupported timestamp resolution")
+				}
+				intf.unitsPerSecond = 1
+				for i := byte(0); i < v; i++ {
+					intf.unitsPerSecond *= 10
+				}
+			}
+		}
+
+		opts = opts[(le+3)&^3:]
+	}
+
+	pr.interfaces = append(pr.interfaces, intf)
+	return nil
+}
+
+func (pr *pcapngReader) readPacket() (*packet, error) {
+	for {
+		var header [8]byte
+		_, err := io.ReadFull(pr.r, header[:])
+		if err != nil {
+			if err == io.ErrUnexpectedEOF {
+				err = io.EOF
+			}
+			return nil, err
+		}
+
+		typ := pr.order.Uint32(header[0:])
+
+		if typ == pcapngBlockSectionHeader {
+			// block length must be read with the byte order of the new section
+			pr.r = io.MultiReader(bytes.NewReader(header[4:]), pr.r)
+			err := pr.readSectionHeader()
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		le := pr.order.Uint32(header[4:])
+		if le < 12 || le > maxBlockSize {
+			return nil, fmt.Errorf("invalid block length")
+		}
+
+		block := make([]byte, le-8)
+		_, err = io.ReadFull(pr.r, block)
+		if err != nil {
+			if err == io.ErrUnexpectedEOF {
+				err = io.EOF
+			}
+			return nil, err
+		}
+		body := block[:len(block)-4]
+
+		switch typ {
+		case pcapngBlockInterfaceDesc:
+			err := pr.readInterface(body)
+			if err != nil {
+				return nil, err
+			}
+
+		case pcapngBlockEnhancedPacket:
+			if len(body) < 20 {
+				return nil, fmt.Errorf("enhanced packet block too short")
+			}
+
+			id := pr.order.Uint32(body[0:])
+			if int(id) >= len(pr.interfaces) {
+				return nil, fmt.Errorf("invalid interface id: %d", id)
+			}
+			intf := pr.interfaces[id]
+
+			ts := uint64(pr.order.Uint32(body[4:]))<<32 | uint64(pr.order.Uint32(body[8:]))
+			capLen := pr.order.Uint32(body[12:])
+			if int(capLen) > len(body)-20 {
+				return nil, fmt.Errorf("invalid captured length")
+			}
+
+			sec := ts / intf.unitsPerSecond
+			nsec := (ts % intf.unitsPerSecond) * 1000000000 / intf.unitsPerSecond
+
+			return &packet{
+				time:     time.Unix(int64(sec), int64(nsec)),
+				linkType: intf.linkType,
+				data:     body[20 : 20+capLen],
+			}, nil
+
+		case pcapngBlockSimplePacket:
+			if len(body) < 4 || len(pr.interfaces) == 0 {
+				return nil, fmt.Errorf("invalid simple packet block")
+			}
+
+			origLen := int(pr.order.Uint32(body[0:]))
+			data := body[4:]
+			if origLen < len(data) {
+				data = data[:origLen]
+			}
+
+			// simple packets do not contain timestamps
+			return &packet{
+				linkType: pr.interfaces[0].linkType,
+				data:     data,
+			}, nil
+		}
+
+		// other blocks are ignored
+	}
+}
@@ -0,0 +1,164 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// link types
+const (
+	linkTypeNull     = 0
+	linkTypeEthernet = 1
+	linkTypeRaw      = 101
+	linkTypeLoop     = 108
+	linkTypeLinuxSLL = 113
+)
+
+// transport protocols
+const (
+	protocolTCP = 6
+	protocolUDP = 17
+)
+
+// transport is a transport-layer segment or datagram.
+type transport struct {
+	protocol byte
+	src      string
+	dst      string
+	seq      uint32
+	syn      bool
+	payload  []byte
+}
+
+// decodeLink decodes a link-layer packet.
+func decodeLink(linkType uint32, buf []byte) (*transport, error) {
+	switch linkType {
+	case linkTypeEthernet:
+		if len(buf) < 14 {
+			return nil, fmt.Errorf("ethernet header too short")
+		}
+		etherType := binary.BigEndian.Uint16(buf[12:])
+		buf = buf[14:]
+
+		// VLAN tags
+		for etherType == 0x8100 || etherType == 0x88A8 {
+			if len(buf) < 4 {
+				return nil, fmt.Errorf("VLAN tag too short")
+			}
+			etherType = binary.BigEndian.Uint16(buf[2:])
+			buf = buf[4:]
+		}
+
+		switch etherType {
+		case 0x0800, 0x86DD:
+			return decodeIP(buf)
+		}
+		return nil, nil
+
+	case linkTypeNull, linkTypeLoop:
+		if len(buf) < 4 {
+			return nil, fmt.Errorf("loopback header too short")
+		}
+		return decodeIP(buf[4:])
+
+	case linkTypeRaw:
+		return decodeIP(buf)
+
+	case linkTypeLinuxSLL:
+		if len(buf) < 16 {
+			return nil, fmt.Errorf("SLL header too short")
+		}
+		switch binary.BigEndian.Uint16(buf[14:]) {
+		case 0x0800, 0x86DD:
+			return decodeIP(buf[16:])
+		}
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("unsupported link type: %d", linkType)
+}
+
+// decodeIP decodes an IPv4 or IPv6 packet.
+func decodeIP(buf []byte) (*transport, error) {
+	if len(buf) < 1 {
+		return nil, fmt.Errorf("IP header too short")
+	}
+
+	var protocol byte
+	var srcIP net.IP
+	var dstIP net.IP
+
+	switch buf[0] >> 4 {
+	case 4:
+		if len(buf) < 20 {
+			return nil, fmt.Errorf("IPv4 header too short")
+		}
+		headerLen := int(buf[0]&0x0F) * 4
+		totalLen := int(binary.BigEndian.Uint16(buf[2:]))
+		if headerLen < 20 || totalLen < headerLen || len(buf) < totalLen {
+			return nil, fmt.Errorf("invalid IPv4 length")
+		}
+
+		// fragmented packets are not supported
+		if flags := binary.BigEndian.Uint16(buf[6:]); (flags&0x2000) != 0 || (flags&0x1FFF) != 0 {
+			return nil, nil
+		}
+
+		protocol = buf[9]
+		srcIP = net.IP(buf[12:16])
+		dstIP = net.IP(buf[16:20])
+		buf = buf[headerLen:totalLen]
+
+	case 6:
+		if len(buf) < 40 {
+			return nil, fmt.Errorf("IPv6 header too short")
+		}
+		payloadLen := int(binary.BigEndian.Uint16(buf[4:]))
+		if len(buf) < 40+payloadLen {
+			return nil, fmt.Errorf("invalid IPv6 length")
+		}
+
+		// extension headers are not supported
+		protocol = buf[6]
+		srcIP = net.IP(buf[8:24])
+		dstIP = net.IP(buf[24:40])
+		buf = buf[40 : 40+payloadLen]
+
+	default:
+		return nil, fmt.Errorf("unsupported IP version: %d", buf[0]>>4)
+	}
+
+	switch protocol {
+	case protocolUDP:
+		if len(buf) < 8 {
+			return nil, fmt.Errorf("UDP header too short")
+		}
+		return &transport{
+			protocol: protocolUDP,
+			src:      net.JoinHostPort(srcIP.String(), strconv.FormatUint(uint64(binary.BigEndian.Uint16(buf[0:])), 10)),
+			dst:      net.JoinHostPort(dstIP.String(), strconv.FormatUint(uint64(binary.BigEndian.Uint16(buf[2:])), 10)),
+			payload:  buf[8:],
+		}, nil
+
+	case protocolTCP:
+		if len(buf) < 20 {
+			return nil, fmt.Errorf("TCP header too short")
+		}
+		headerLen := int(buf[12]>>4) * 4
+		if headerLen < 20 || len(buf) < headerLen {
+			return nil, fmt.Errorf("invalid TCP length")
+		}
+		return &transport{
+			protocol: protocolTCP,
+			src:      net.JoinHostPort(srcIP.String(), strconv.FormatUint(uint64(binary.BigEndian.Uint16(buf[0:])), 10)),
+			dst:      net.JoinHostPort(dstIP.String(), strconv.FormatUint(uint64(binary.BigEndian.Uint16(buf[2:])), 10)),
+			seq:      binary.BigEndian.Uint32(buf[4:]),
+			syn:      (buf[13] & 0x02) != 0,
+			payload:  buf[headerLen:],
+		}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,231 @@
+// Package pcap contains a reader that extracts Mavlink frames from network
+// captures in pcap or pcapng format, produced by tcpdump or Wireshark.
+// Frames are extracted from UDP datagrams and TCP streams, over IPv4 or IPv6.
+package pcap
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+// Frame is a frame extracted from a capture.
+type Frame struct {
+	// the time in which the packet that contains the frame was captured.
+	// It is zero if the capture does not contain timestamps.
+	Time time.Time
+
+	// the transport protocol, "udp" or "tcp".
+	Protocol string
+
+	// the source address, in format ip:port.
+	Src string
+
+	// the destination address, in format ip:port.
+	Dst string
+
+	// the frame.
+	Frame frame.Frame
+}
+
+var protocolNames = map[byte]string{
+	protocolUDP: "udp",
+	protocolTCP: "tcp",
+}
+
+type flowKey struct {
+	protocol byte
+	src      string
+	dst      string
+}
+
+// flow is a TCP stream.
+type flow struct {
+	nextSeq uint32
+	buf     []byte
+}
+
+// Reader reads frames from a capture.
+type Reader struct {
+	fr        fileReader
+	dialectDE *dialect.DecEncoder
+	flows     map[flowKey]*flow
+	queue     []*Frame
+}
+
+// NewReader allocates a Reader, that reads frames from a capture in pcap
+// or pcapng format.
+// The dialect is used to validate and decode messages; if it is nil, or if
+// a message is not in the dialect, the message is returned as
+// a *msg.MessageRaw.
+func NewReader(r io.Reader, d *dialect.Dialect) (*Reader, error) {
+	var dialectDE *dialect.DecEncoder
+	if d != nil {
+		var err error
+		dialectDE, err = dialect.NewDecEncoder(d)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fr, err := newFileReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{
+		fr:        fr,
+		dialectDE: dialectDE,
+		flows:     make(map[flowKey]*flow),
+	}, nil
+}
+
+// ReadFrame reads the next frame.
+// Packets that can't be decoded and bytes that do not belong to valid
+// frames are skipped. io.EOF is returned when the end of the capture
+// is reached.
+func (r *Reader) ReadFrame() (*Frame, error) {
+	for len(r.queue) == 0 {
+		pkt, err := r.fr.readPacket()
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := decodeLink(pkt.linkType, pkt.data)
+		if err != nil || t == nil {
+			continue
+		}
+
+		r.processTransport(pkt.time, t)
+	}
+
+	fr := r.queue[0]
+	r.queue = r.queue[1:]
+	return fr, nil
+}
+
+func (r *Reader) processTransport(ts time.Time, t *transport) {
+	if t.protocol == protocolUDP {
+		// frames can't be split between datagrams
+		r.extractFrames(ts, t, t.payload)
+		return
+	}
+
+	key := flowKey{t.protocol, t.src, t.dst}
+	fl, ok := r.flows[key]
+
+	if t.syn {
+		r.flows[key] = &flow{nextSeq: t.seq + 1}
+		return
+	}
+
+	if !ok {
+		fl = &flow{nextSeq: t.seq}
+		r.flows[key] = fl
+	}
+
+	payload := t.payload
+	diff := int32(t.seq - fl.nextSeq)
+
+	switch {
+	// a segment is missing: drop incomplete data
+	case diff > 0:
+		fl.buf = nil
+
+	// retransmission: skip data already received
+	case diff < 0:
+		if int(-diff) >= len(payload) {
+			return
+		}
+		payload = payload[-diff:]
+	}
+
+	fl.nextSeq = t.seq + uint32(len(t.payload))
+	fl.buf = r.extractFrames(ts, t, append(fl.buf, payload...))
+}
+
+// frameLength returns the length of the frame at the beginning of the buffer,
+// or 0 if the buffer is too short to determine it.
+func frameLength(buf []byte) int {
+	switch buf[0] {
+	case frame.V1MagicByte:
+		if len(buf) < 2 {
+			return 0
+		}
+		return 6 + int(buf[1]) + 2
+
+	default: // frame.V2MagicByte
+		if len(buf) < 3 {
+			return 0
+		}
+		le := 10 + int(buf[1]) + 2
+		if (buf[2] & frame.V2FlagSigned) != 0 {
+			le += 13
+		}
+		return le
+	}
+}
+
+// indexMagicByte returns the position of the first magic byte, or -1.
+func indexMagicByte(buf []byte) int {
+	for i, b := range buf {
+		if b == frame.V1MagicByte || b == frame.V2MagicByte {
+			return i
+		}
+	}
+	return -1
+}
+
+// extractFrames extracts frames from a buffer and returns the bytes
+// that belong to an incomplete frame.
+func (r *Reader) extractFrames(ts time.Time, t *transport, buf []byte) []byte {
+	for {
+		// find the beginning of a frame
+		i := indexMagicByte(buf)
+		if i < 0 {
+			return nil
+		}
+		buf = buf[i:]
+
+		le := frameLength(buf)
+		if le == 0 || len(buf) < le {
+			return buf
+		}
+
+		fr, err := r.decodeFrame(buf[:le])
+		if err != nil {
+			// not a valid frame: search for the next magic byte
+			buf = buf[1:]
+			continue
+		}
+
+		r.queue = append(r.queue, &Frame{
+			Time:     ts,
+			Protocol: protocolNames[t.protocol],
+			Src:      t.src,
+			Dst:      t.dst,
+			Frame:    fr,
+		})
+		buf = buf[le:]
+	}
+}
+
+func (r *Reader) decodeFrame(buf []byte) (frame.Frame, error) {
+	tr, err := transceiver.New(transceiver.Conf{
+		Reader:      bytes.NewReader(buf),
+		Writer:      ioutil.Discard,
+		DialectDE:   r.dialectDE,
+		OutVersion:  transceiver.V2,
+		OutSystemID: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tr.Read()
+}
@@ -0,0 +1,161 @@
+package pcap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type MessageHeartbeat struct {
+	Type           uint8
+	Autopilot      uint8
+	BaseMode       uint8
+	CustomMode     uint32
+	SystemStatus   uint8
+	MavlinkVersion uint8
+}
+
+func (*MessageHeartbeat) GetID() uint32 {
+	return 0
+}
+
+var testDialect = &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}} //nolint:govet
+
+var testMessage = &MessageHeartbeat{
+	Type:           1,
+	Autopilot:      2,
+	BaseMode:       3,
+	CustomMode:     6,
+	SystemStatus:   4,
+	MavlinkVersion: 5,
+}
+
+var testFrameEncoded = []byte{
+	0xfd, 0x09, 0x00, 0x00, 0x01, 0x02, 0x03, 0x00, 0x00, 0x00,
+	0x06, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0xe2,
+}
+
+func ethernetIPv4(protocol byte, transport []byte) []byte {
+	ip := make([]byte, 20)
+	ip[0] = 0x45
+	binary.BigEndian.PutUint16(ip[2:], uint16(20+len(transport)))
+	ip[8] = 64
+	ip[9] = protocol
+	copy(ip[12:], []byte{192, 168, 0, 1})
+	copy(ip[16:], []byte{192, 168, 0, 2})
+
+	eth := make([]byte, 14)
+	binary.BigEndian.PutUint16(eth[12:], 0x0800)
+
+	return append(append(eth, ip...), transport...)
+}
+
+func udpDatagram(payload []byte) []byte {
+	udp := make([]byte, 8)
+	binary.BigEndian.PutUint16(udp[0:], 14550)
+	binary.BigEndian.PutUint16(udp[2:], 14551)
+	binary.BigEndian.PutUint16(udp[4:], uint16(8+len(payload)))
+	return append(udp, payload...)
+}
+
+func tcpSegment(seq uint32, flags byte, payload []byte) []byte {
+	tcp := make([]byte, 20)
+	binary.BigEndian.PutUint16(tcp[0:], 5760)
+	binary.BigEndian.PutUint16(tcp[2:], 40000)
+	binary.BigEndian.PutUint32(tcp[4:], seq)
+	tcp[12] = 5 << 4
+	tcp[13] = flags
+	return append(tcp, payload...)
+}
+
+func pcapFile(packets [][]byte) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, []uint32{pcapMagicMicro, 0x00040002, 0, 0, 65535, linkTypeEthernet})
+
+	for i, pkt := range packets {
+		binary.Write(&buf, binary.LittleEndian, []uint32{uint32(1000 + i), 500, uint32(len(pkt)), uint32(len(pkt))})
+		buf.Write(pkt)
+	}
+
+	return buf.Bytes()
+}
+
+func pcapngFile(packets [][]byte) []byte {
+	var buf bytes.Buffer
+
+	// section header block
+	binary.Write(&buf, binary.LittleEndian, []uint32{pcapngBlockSectionHeader, 28, pcapngByteOrderMagic, 1})
+	binary.Write(&buf, binary.LittleEndian, int64(-1))
+	binary.Write(&buf, binary.LittleEndian, uint32(28))
+
+	// interface description block, with nanosecond resolution
+	binary.Write(&buf, binary.LittleEndian, []uint32{pcapngBlockInterfaceDesc, 32, linkTypeEthernet, 65535})
+	binary.Write(&buf, binary.LittleEndian, []uint16{pcapngOptionInterfaceTimestamp, 1})
+	buf.Write([]byte{9, 0, 0, 0})
+	binary.Write(&buf, binary.LittleEndian, []uint32{0, 32})
+
+	for i, pkt := range packets {
+		padded := append(pkt, make([]byte, (4-len(pkt)%4)%4)...)
+		le := uint32(32 + len(padded))
+		ts := uint64(1000+i)*1000000000 + 500000
+		binary.Write(&buf, binary.LittleEndian, []uint32{
+			pcapngBlockEnhancedPacket, le, 0, uint32(ts >> 32), uint32(ts), uint32(len(pkt)), uint32(len(pkt)),
+		})
+		buf.Write(padded)
+		binary.Write(&buf, binary.LittleEndian, le)
+	}
+
+	return buf.Bytes()
+}
+
+func TestReader(t *testing.T) {
+	packets := [][]byte{
+		// UDP datagram with two frames
+		ethernetIPv4(protocolUDP, udpDatagram(append(append([]byte{}, testFrameEncoded...), testFrameEncoded...))),
+		// TCP stream with a frame split between two segments, and a retransmission
+		ethernetIPv4(protocolTCP, tcpSegment(99, 0x02, nil)),
+		ethernetIPv4(protocolTCP, tcpSegment(100, 0x10, append([]byte{0x01, 0x02}, testFrameEncoded[:10]...))),
+		ethernetIPv4(protocolTCP, tcpSegment(100, 0x10, append([]byte{0x01, 0x02}, testFrameEncoded[:10]...))),
+		ethernetIPv4(protocolTCP, tcpSegment(112, 0x10, testFrameEncoded[10:])),
+	}
+
+	for _, ca := range []struct {
+		name string
+		file []byte
+	}{
+		{"pcap", pcapFile(packets)},
+		{"pcapng", pcapngFile(packets)},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			r, err := NewReader(bytes.NewReader(ca.file), testDialect)
+			require.NoError(t, err)
+
+			for i := 0; i < 2; i++ {
+				fr, err := r.ReadFrame()
+				require.NoError(t, err)
+				require.Equal(t, time.Unix(1000, 500000), fr.Time)
+				require.Equal(t, "udp", fr.Protocol)
+				require.Equal(t, "192.168.0.1:14550", fr.Src)
+				require.Equal(t, "192.168.0.2:14551", fr.Dst)
+				require.Equal(t, testMessage, fr.Frame.GetMessage())
+			}
+
+			fr, err := r.ReadFrame()
+			require.NoError(t, err)
+			require.Equal(t, time.Unix(1004, 500000), fr.Time)
+			require.Equal(t, "tcp", fr.Protocol)
+			require.Equal(t, "192.168.0.1:5760", fr.Src)
+			require.Equal(t, testMessage, fr.Frame.GetMessage())
+
+			_, err = r.ReadFrame()
+			require.Equal(t, io.EOF, err)
+		})
+	}
+}
@@ -0,0 +1,278 @@
+// Package proxy allows to establish TCP connections through SOCKS5 and
+// HTTP CONNECT proxies.
+package proxy
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	handshakeTimeout = 10 * time.Second
+)
+
+// Dialer is implemented by objects that can establish connections.
+// It is implemented by *net.Dialer and is compatible with the Dialer
+// interface of golang.org/x/net/proxy.
+type Dialer interface {
+	Dial(network, address string) (net.Conn, error)
+}
+
+// FromURL returns a Dialer that connects through the proxy described by the URL.
+// Supported schemes are socks5:// and http://, credentials can be provided
+// with the user:pass@ syntax.
+// The connection to the proxy is established with forward.
+func FromURL(u *url.URL, forward Dialer) (Dialer, error) {
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy address is missing")
+	}
+
+	_, _, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy address")
+	}
+
+	switch u.Scheme {
+	case "socks5", "socks5h":
+		return &socks5Dialer{
+			address: u.Host,
+			user:    u.User,
+			forward: forward,
+		}, nil
+
+	case "http":
+		return &httpDialer{
+			address: u.Host,
+			user:    u.User,
+			forward: forward,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported proxy scheme '%s'", u.Scheme)
+}
+
+// dialProxy connects to the proxy and performs the handshake within
+// handshakeTimeout.
+func dialProxy(forward Dialer, network string, address string,
+	handshake func(net.Conn) (net.Conn, error)) (net.Conn, error) {
+	if network != "tcp" && network != "tcp4" && network != "tcp6" {
+		return nil, fmt.Errorf("unsupported network '%s'", network)
+	}
+
+	conn, err := forward.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetDeadline(time.Now().Add(handshakeTimeout))
+
+	ret, err := handshake(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	conn.SetDeadline(time.Time{})
+	return ret, nil
+}
+
+type socks5Dialer struct {
+	address string
+	user    *url.Userinfo
+	forward Dialer
+}
+
+// Dial implements Dialer.
+func (d *socks5Dialer) Dial(network, address string) (net.Conn, error) {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port '%s'", portStr)
+	}
+
+	return dialProxy(d.forward, network, d.address, func(conn net.Conn) (net.Conn, error) {
+		err := d.handshake(conn, host, uint16(port))
+		if err != nil {
+			return nil, err
+		}
+		return conn, nil
+	})
+}
+
+func (d *socks5Dialer) handshake(conn net.Conn, host string, port uint16) error {
+	// method selection
+	methods := []byte{0x00} // no authentication
+	if d.user != nil {
+		methods = []byte{0x02} // username / password
+	}
+	_, err := conn.Write(append([]byte{0x05, byte(len(methods))}, methods...))
+	if err != nil {
+		return err
+	}
+
+	var res [2]byte
+	_, err = io.ReadFull(conn, res[:])
+	if err != nil {
+		return err
+	}
+	if res[0] != 0x05 {
+		return fmt.Errorf("invalid SOCKS version (%d)", res[0])
+	}
+
+	switch res[1] {
+	case 0x00:
+
+	case 0x02:
+		if d.user == nil {
+			return fmt.Errorf("proxy requires authentication")
+		}
+
+		user := d.user.Username()
+		pass, _ := d.user.Password()
+		if len(user) > 255 || len(pass) > 255 {
+			return fmt.Errorf("username or password is too long")
+		}
+
+		req := []byte{0x01, byte(len(user))}
+		req = append(req, user...)
+		req = append(req, byte(len(pass)))
+		req = append(req, pass...)
+		_, err := conn.Write(req)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.ReadFull(conn, res[:])
+		if err != nil {
+			return err
+		}
+		if res[1] != 0x00 {
+			return fmt.Errorf("proxy authentication failed")
+		}
+
+	default:
+		return fmt.Errorf("proxy does not support any of the offered authentication methods")
+	}
+
+	// connect request
+	req := []byte{0x05, 0x01, 0x00}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			req = append(req, 0x01)
+			req = append(req, ip4...)
+		} else {
+			req = append(req, 0x04)
+			req = append(req, ip...)
+		}
+	} else {
+		if len(host) > 255 {
+			return fmt.Errorf("host name is too long")
+		}
+		req = append(req, 0x03, byte(len(host)))
+		req = append(req, host...)
+	}
+	req = append(req, byte(port>>8), byte(port))
+
+	_, err = conn.Write(req)
+	if err != nil {
+		return err
+	}
+
+	var header [4]byte
+	_, err = io.ReadFull(conn, header[:])
+	if err != nil {
+		return err
+	}
+	if header[1] != 0x00 {
+		return fmt.Errorf("proxy refused the connection (code %d)", header[1])
+	}
+
+	// discard the bound address
+	var addrLen int
+	switch header[3] {
+	case 0x01:
+		addrLen = net.IPv4len
+
+	case 0x04:
+		addrLen = net.IPv6len
+
+	case 0x03:
+		var l [1]byte
+		_, err := io.ReadFull(conn, l[:])
+		if err != nil {
+			return err
+		}
+		addrLen = int(l[0])
+
+	default:
+		return fmt.Errorf("invalid address type (%d)", header[3])
+	}
+
+	_, err = io.ReadFull(conn, make([]byte, addrLen+2))
+	return err
+}
+
+type httpDialer struct {
+	address string
+	user    *url.Userinfo
+	forward Dialer
+}
+
+// Dial implements Dialer.
+func (d *httpDialer) Dial(network, address string) (net.Conn, error) {
+	return dialProxy(d.forward, network, d.address, func(conn net.Conn) (net.Conn, error) {
+		req := &http.Request{
+			Method: http.MethodConnect,
+			URL:    &url.URL{Opaque: address},
+			Host:   address,
+			Header: make(http.Header),
+		}
+
+		if d.user != nil {
+			pass, _ := d.user.Password()
+			req.Header.Set("Proxy-Authorization", "Basic "+
+				base64.StdEncoding.EncodeToString([]byte(d.user.Username()+":"+pass)))
+		}
+
+		err := req.Write(conn)
+		if err != nil {
+			return nil, err
+		}
+
+		br := bufio.NewReader(conn)
+		res, err := http.ReadResponse(br, req)
+		if err != nil {
+			return nil, err
+		}
+		res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("proxy refused the connection (%s)", res.Status)
+		}
+
+		// the remote end may send data immediately after the response,
+		// and part of it may have been read by the buffered reader
+		return &bufferedConn{Conn: conn, br: br}, nil
+	})
+}
+
+type bufferedConn struct {
+	net.Conn
+	br *bufio.Reader
+}
+
+// Read implements net.Conn.
+func (c *bufferedConn) Read(buf []byte) (int, error) {
+	return c.br.Read(buf)
+}
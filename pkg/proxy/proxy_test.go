@@ -0,0 +1,304 @@
+package proxy
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// runEchoServer starts a TCP server that sends a greeting and then echoes
+// what it receives.
+func runEchoServer(t *testing.T) net.Listener {
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				conn.Write([]byte("hello"))
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	return l
+}
+
+func pipe(a net.Conn, b net.Conn) {
+	go io.Copy(a, b)
+	io.Copy(b, a)
+}
+
+func socks5Handle(conn net.Conn, user string, pass string) error {
+	defer conn.Close()
+
+	var buf [256]byte
+	_, err := io.ReadFull(conn, buf[:2])
+	if err != nil {
+		return err
+	}
+	_, err = io.ReadFull(conn, buf[:buf[1]])
+	if err != nil {
+		return err
+	}
+
+	if user == "" {
+		conn.Write([]byte{0x05, 0x00})
+	} else {
+		conn.Write([]byte{0x05, 0x02})
+
+		_, err := io.ReadFull(conn, buf[:2])
+		if err != nil {
+			return err
+		}
+		_, err = io.ReadFull(conn, buf[:buf[1]])
+		if err != nil {
+			return err
+		}
+		recvUser := string(buf[:len(user)])
+
+		_, err = io.ReadFull(conn, buf[:1])
+		if err != nil {
+			return err
+		}
+		_, err = io.ReadFull(conn, buf[:buf[0]])
+		if err != nil {
+			return err
+		}
+		recvPass := string(buf[:len(pass)])
+
+		if recvUser != user || recvPass != pass {
+			conn.Write([]byte{0x01, 0x01})
+			return fmt.Errorf("wrong credentials")
+		}
+		conn.Write([]byte{0x01, 0x00})
+	}
+
+	_, err = io.ReadFull(conn, buf[:4])
+	if err != nil {
+		return err
+	}
+
+	var host string
+	switch buf[3] {
+	case 0x01:
+		_, err := io.ReadFull(conn, buf[:4])
+		if err != nil {
+			return err
+		}
+		host = net.IP(buf[:4]).String()
+
+	case 0x03:
+		_, err := io.ReadFull(conn, buf[:1])
+		if err != nil {
+			return err
+		}
+		l := int(buf[0])
+		_, err = io.ReadFull(conn, buf[:l])
+		if err != nil {
+			return err
+		}
+		host = string(buf[:l])
+
+	default:
+		return fmt.Errorf("unsupported address type")
+	}
+
+	_, err = io.ReadFull(conn, buf[:2])
+	if err != nil {
+		return err
+	}
+	port := int(buf[0])<<8 | int(buf[1])
+
+	target, err := net.Dial("tcp4", net.JoinHostPort(host, strconv.FormatInt(int64(port), 10)))
+	if err != nil {
+		conn.Write([]byte{0x05, 0x05, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+		return err
+	}
+	defer target.Close()
+
+	conn.Write([]byte{0x05, 0x00, 0x00, 0x01, 127, 0, 0, 1, 0, 0})
+	pipe(conn, target)
+	return nil
+}
+
+func runSOCKS5Server(t *testing.T, user string, pass string) net.Listener {
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go socks5Handle(conn, user, pass)
+		}
+	}()
+
+	return l
+}
+
+func httpHandle(conn net.Conn, user string, pass string) error {
+	defer conn.Close()
+
+	req, err := http.ReadRequest(bufio.NewReader(conn))
+	if err != nil {
+		return err
+	}
+
+	if user != "" {
+		auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+pass))
+		if req.Header.Get("Proxy-Authorization") != auth {
+			conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n\r\n"))
+			return fmt.Errorf("wrong credentials")
+		}
+	}
+
+	if req.Method != http.MethodConnect {
+		conn.Write([]byte("HTTP/1.1 405 Method Not Allowed\r\n\r\n"))
+		return fmt.Errorf("wrong method")
+	}
+
+	target, err := net.Dial("tcp4", req.Host)
+	if err != nil {
+		conn.Write([]byte("HTTP/1.1 502 Bad Gateway\r\n\r\n"))
+		return err
+	}
+	defer target.Close()
+
+	conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+	pipe(conn, target)
+	return nil
+}
+
+func runHTTPServer(t *testing.T, user string, pass string) net.Listener {
+	l, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go httpHandle(conn, user, pass)
+		}
+	}()
+
+	return l
+}
+
+func TestDial(t *testing.T) {
+	echo := runEchoServer(t)
+	defer echo.Close()
+
+	for _, ca := range []struct {
+		name   string
+		scheme string
+		user   *url.Userinfo
+		run    func(*testing.T, string, string) net.Listener
+	}{
+		{"socks5", "socks5", nil, runSOCKS5Server},
+		{"socks5 auth", "socks5", url.UserPassword("myuser", "mypass"), runSOCKS5Server},
+		{"http", "http", nil, runHTTPServer},
+		{"http auth", "http", url.UserPassword("myuser", "mypass"), runHTTPServer},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var user, pass string
+			if ca.user != nil {
+				user = ca.user.Username()
+				pass, _ = ca.user.Password()
+			}
+
+			l := ca.run(t, user, pass)
+			defer l.Close()
+
+			d, err := FromURL(&url.URL{
+				Scheme: ca.scheme,
+				User:   ca.user,
+				Host:   l.Addr().String(),
+			}, &net.Dialer{})
+			require.NoError(t, err)
+
+			conn, err := d.Dial("tcp4", echo.Addr().String())
+			require.NoError(t, err)
+			defer conn.Close()
+
+			buf := make([]byte, 5)
+			_, err = io.ReadFull(conn, buf)
+			require.NoError(t, err)
+			require.Equal(t, []byte("hello"), buf)
+
+			_, err = conn.Write([]byte("testing"))
+			require.NoError(t, err)
+
+			buf = make([]byte, 7)
+			_, err = io.ReadFull(conn, buf)
+			require.NoError(t, err)
+			require.Equal(t, []byte("testing"), buf)
+		})
+	}
+}
+
+func TestDialWrongCredentials(t *testing.T) {
+	echo := runEchoServer(t)
+	defer echo.Close()
+
+	for _, ca := range []struct {
+		scheme string
+		run    func(*testing.T, string, string) net.Listener
+	}{
+		{"socks5", runSOCKS5Server},
+		{"http", runHTTPServer},
+	} {
+		t.Run(ca.scheme, func(t *testing.T) {
+			l := ca.run(t, "myuser", "mypass")
+			defer l.Close()
+
+			d, err := FromURL(&url.URL{
+				Scheme: ca.scheme,
+				User:   url.UserPassword("myuser", "wrong"),
+				Host:   l.Addr().String(),
+			}, &net.Dialer{})
+			require.NoError(t, err)
+
+			_, err = d.Dial("tcp4", echo.Addr().String())
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestFromURLErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		url  string
+		err  string
+	}{
+		{"missing address", "socks5://", "proxy address is missing"},
+		{"invalid address", "socks5://1.2.3.4", "invalid proxy address"},
+		{"unsupported scheme", "ftp://1.2.3.4:21", "unsupported proxy scheme 'ftp'"},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			u, err := url.Parse(ca.url)
+			require.NoError(t, err)
+
+			_, err = FromURL(u, &net.Dialer{})
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
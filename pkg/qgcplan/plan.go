@@ -0,0 +1,460 @@
+// Package qgcplan contains functions to read and write QGroundControl
+// plan files (.plan), and to convert them into mission items, fences
+// and rally points.
+package qgcplan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+
+	"github.com/aler9/gomavlib"
+)
+
+const (
+	itemTypeSimple  = "SimpleItem"
+	itemTypeComplex = "ComplexItem"
+)
+
+// Param is a parameter of an item. NaN values are encoded as null.
+type Param float64
+
+// MarshalJSON implements json.Marshaler.
+func (p Param) MarshalJSON() ([]byte, error) {
+	if math.IsNaN(float64(p)) {
+		return []byte("null"), nil
+	}
+	return json.Marshal(float64(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *Param) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*p = Param(math.NaN())
+		return nil
+	}
+
+	var f float64
+	err := json.Unmarshal(b, &f)
+	if err != nil {
+		return err
+	}
+	*p = Param(f)
+	return nil
+}
+
+// Item is an item of a mission.
+type Item struct {
+	// the item type, SimpleItem or ComplexItem.
+	Type string
+
+	// the command of a simple item (MAV_CMD).
+	Command int
+
+	// the coordinate system of a simple item (MAV_FRAME).
+	Frame int
+
+	// whether to continue to the next item when the item is completed.
+	AutoContinue bool
+
+	// the sequence number used by jumps, starting from 1.
+	DoJumpID int
+
+	// the parameters of a simple item. Parameters 5, 6 and 7 are latitude,
+	// longitude and altitude in case of global coordinate systems.
+	Params [7]Param
+
+	// the type of a complex item (i.e. survey).
+	ComplexItemType string
+
+	// the original content of the item, used to preserve fields that
+	// are not decoded (i.e. the content of complex items).
+	Raw json.RawMessage
+}
+
+type jsonTransectStyleComplexItem struct {
+	Items []*Item `json:"Items"`
+}
+
+type jsonItem struct {
+	Type                     string                        `json:"type"`
+	AutoContinue             bool                          `json:"autoContinue"`
+	Command                  int                           `json:"command"`
+	DoJumpID                 int                           `json:"doJumpId"`
+	Frame                    int                           `json:"frame"`
+	Params                   [7]Param                      `json:"params"`
+	ComplexItemType          string                        `json:"complexItemType"`
+	TransectStyleComplexItem *jsonTransectStyleComplexItem `json:"TransectStyleComplexItem"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (i *Item) MarshalJSON() ([]byte, error) {
+	// complex items can't be generated, therefore their content is copied
+	if i.Type == itemTypeComplex {
+		if i.Raw == nil {
+			return nil, fmt.Errorf("complex items must have a raw content")
+		}
+		return i.Raw, nil
+	}
+
+	// simple items are regenerated, in order to apply changes, and fields
+	// that are not decoded are copied from the original content
+	out := make(map[string]interface{})
+	if i.Raw != nil {
+		err := json.Unmarshal(i.Raw, &out)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out["type"] = itemTypeSimple
+	out["autoContinue"] = i.AutoContinue
+	out["command"] = i.Command
+	out["doJumpId"] = i.DoJumpID
+	out["frame"] = i.Frame
+	out["params"] = i.Params
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Item) UnmarshalJSON(b []byte) error {
+	var ji jsonItem
+	err := json.Unmarshal(b, &ji)
+	if err != nil {
+		return err
+	}
+
+	*i = Item{
+		Type:            ji.Type,
+		Command:         ji.Command,
+		Frame:           ji.Frame,
+		AutoContinue:    ji.AutoContinue,
+		DoJumpID:        ji.DoJumpID,
+		Params:          ji.Params,
+		ComplexItemType: ji.ComplexItemType,
+		Raw:             append(json.RawMessage(nil), b...),
+	}
+	return nil
+}
+
+// simpleItems returns the simple items that a complex item is made of.
+func (i *Item) simpleItems() ([]*Item, error) {
+	var ji jsonItem
+	err := json.Unmarshal(i.Raw, &ji)
+	if err != nil {
+		return nil, err
+	}
+
+	// surveys and corridor scans
+	if ji.TransectStyleComplexItem != nil {
+		return ji.TransectStyleComplexItem.Items, nil
+	}
+
+	return nil, fmt.Errorf("complex item '%s' does not contain simple items", i.ComplexItemType)
+}
+
+// Mission is the mission section of a plan.
+type Mission struct {
+	Version             int        `json:"version"`
+	FirmwareType        int        `json:"firmwareType"`
+	VehicleType         int        `json:"vehicleType"`
+	CruiseSpeed         float64    `json:"cruiseSpeed"`
+	HoverSpeed          float64    `json:"hoverSpeed"`
+	PlannedHomePosition [3]float64 `json:"plannedHomePosition"`
+	Items               []*Item    `json:"items"`
+}
+
+// Circle is a circular area of a geofence.
+type Circle struct {
+	Version   int  `json:"version"`
+	Inclusion bool `json:"inclusion"`
+	Circle    struct {
+		Center [2]float64 `json:"center"`
+		Radius float64    `json:"radius"`
+	} `json:"circle"`
+}
+
+// Polygon is a polygonal area of a geofence.
+type Polygon struct {
+	Version   int          `json:"version"`
+	Inclusion bool         `json:"inclusion"`
+	Polygon   [][2]float64 `json:"polygon"`
+}
+
+// GeoFence is the geofence section of a plan.
+type GeoFence struct {
+	Version      int         `json:"version"`
+	Circles      []*Circle   `json:"circles"`
+	Polygons     []*Polygon  `json:"polygons"`
+	BreachReturn *[3]float64 `json:"breachReturn,omitempty"`
+}
+
+// RallyPoints is the rally point section of a plan.
+type RallyPoints struct {
+	Version int          `json:"version"`
+	Points  [][3]float64 `json:"points"`
+}
+
+// Plan is a QGroundControl plan.
+type Plan struct {
+	FileType      string      `json:"fileType"`
+	Version       int         `json:"version"`
+	GroundStation string      `json:"groundStation"`
+	Mission       Mission     `json:"mission"`
+	GeoFence      GeoFence    `json:"geoFence"`
+	RallyPoints   RallyPoints `json:"rallyPoints"`
+}
+
+// New allocates a Plan, that contains the given mission items, fence and
+// rally points. Fence and rally points can be nil.
+func New(items []*gomavlib.MissionItem, fence *gomavlib.Fence, rally []gomavlib.GeoPoint) *Plan {
+	p := &Plan{
+		FileType:      "Plan",
+		Version:       1,
+		GroundStation: "QGroundControl",
+		Mission: Mission{
+			Version: 2,
+			Items:   []*Item{},
+		},
+		GeoFence: GeoFence{
+			Version:  2,
+			Circles:  []*Circle{},
+			Polygons: []*Polygon{},
+		},
+		RallyPoints: RallyPoints{
+			Version: 2,
+			Points:  [][3]float64{},
+		},
+	}
+
+	for i, mi := range items {
+		p.Mission.Items = append(p.Mission.Items, itemFromMissionItem(mi, i+1))
+	}
+
+	if fence != nil {
+		if rp := fence.ReturnPoint; rp != nil {
+			p.GeoFence.BreachReturn = &[3]float64{rp.Latitude, rp.Longitude, float64(rp.Altitude)}
+		}
+
+		for _, fp := range fence.Polygons {
+			poly := &Polygon{
+				Version:   1,
+				Inclusion: fp.Inclusion,
+				Polygon:   [][2]float64{},
+			}
+			for _, v := range fp.Vertices {
+				poly.Polygon = append(poly.Polygon, [2]float64{v.Latitude, v.Longitude})
+			}
+			p.GeoFence.Polygons = append(p.GeoFence.Polygons, poly)
+		}
+
+		for _, fc := range fence.Circles {
+			circle := &Circle{
+				Version:   1,
+				Inclusion: fc.Inclusion,
+			}
+			circle.Circle.Center = [2]float64{fc.Center.Latitude, fc.Center.Longitude}
+			circle.Circle.Radius = float64(fc.Radius)
+			p.GeoFence.Circles = append(p.GeoFence.Circles, circle)
+		}
+	}
+
+	for _, rp := range rally {
+		p.RallyPoints.Points = append(p.RallyPoints.Points,
+			[3]float64{rp.Latitude, rp.Longitude, float64(rp.Altitude)})
+	}
+
+	return p
+}
+
+// Read reads a plan.
+func Read(r io.Reader) (*Plan, error) {
+	byts, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var p Plan
+	err = json.Unmarshal(byts, &p)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.FileType != "Plan" {
+		return nil, fmt.Errorf("unsupported file type '%s'", p.FileType)
+	}
+
+	return &p, nil
+}
+
+// Write writes a plan.
+func (p *Plan) Write(w io.Writer) error {
+	byts, err := json.MarshalIndent(p, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(byts, '\n'))
+	return err
+}
+
+// MissionItems converts the mission of the plan into mission items,
+// that can be uploaded with Node.UploadMission().
+// Complex items (i.e. surveys) are converted into the simple items they
+// are made of.
+// The planned home position is not included; ArduPilot expects it
+// as the first item.
+func (p *Plan) MissionItems() ([]*gomavlib.MissionItem, error) {
+	var out []*gomavlib.MissionItem
+
+	var add func(items []*Item) error
+	add = func(items []*Item) error {
+		for _, i := range items {
+			switch i.Type {
+			case itemTypeSimple:
+				out = append(out, i.missionItem())
+
+			case itemTypeComplex:
+				sub, err := i.simpleItems()
+				if err != nil {
+					return err
+				}
+				err = add(sub)
+				if err != nil {
+					return err
+				}
+
+			default:
+				return fmt.Errorf("unsupported item type '%s'", i.Type)
+			}
+		}
+		return nil
+	}
+
+	err := add(p.Mission.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Fence converts the geofence of the plan into a Fence, that can be
+// uploaded with Node.UploadFence().
+func (p *Plan) Fence() *gomavlib.Fence {
+	f := &gomavlib.Fence{}
+
+	if br := p.GeoFence.BreachReturn; br != nil {
+		f.ReturnPoint = &gomavlib.GeoPoint{
+			Latitude:  br[0],
+			Longitude: br[1],
+			Altitude:  float32(br[2]),
+		}
+	}
+
+	for _, poly := range p.GeoFence.Polygons {
+		fp := &gomavlib.FencePolygon{
+			Inclusion: poly.Inclusion,
+		}
+		for _, v := range poly.Polygon {
+			fp.Vertices = append(fp.Vertices, gomavlib.GeoPoint{Latitude: v[0], Longitude: v[1]})
+		}
+		f.Polygons = append(f.Polygons, fp)
+	}
+
+	for _, c := range p.GeoFence.Circles {
+		f.Circles = append(f.Circles, &gomavlib.FenceCircle{
+			Inclusion: c.Inclusion,
+			Center:    gomavlib.GeoPoint{Latitude: c.Circle.Center[0], Longitude: c.Circle.Center[1]},
+			Radius:    float32(c.Circle.Radius),
+		})
+	}
+
+	return f
+}
+
+// Rally converts the rally points of the plan into points, that can be
+// uploaded with Node.UploadRallyPoints().
+func (p *Plan) Rally() []gomavlib.GeoPoint {
+	out := make([]gomavlib.GeoPoint, len(p.RallyPoints.Points))
+	for i, rp := range p.RallyPoints.Points {
+		out[i] = gomavlib.GeoPoint{
+			Latitude:  rp[0],
+			Longitude: rp[1],
+			Altitude:  float32(rp[2]),
+		}
+	}
+	return out
+}
+
+// MAV_FRAME values that use global coordinates
+var globalFrames = map[int]struct{}{
+	0:  {}, // MAV_FRAME_GLOBAL
+	3:  {}, // MAV_FRAME_GLOBAL_RELATIVE_ALT
+	5:  {}, // MAV_FRAME_GLOBAL_INT
+	6:  {}, // MAV_FRAME_GLOBAL_RELATIVE_ALT_INT
+	10: {}, // MAV_FRAME_GLOBAL_TERRAIN_ALT
+	11: {}, // MAV_FRAME_GLOBAL_TERRAIN_ALT_INT
+}
+
+const (
+	frameMission = 2 // MAV_FRAME_MISSION
+)
+
+// coordinateScale returns the factor used by MISSION_ITEM_INT to encode
+// parameters 5 and 6 into X and Y, given the coordinate system.
+func coordinateScale(frame int) float64 {
+	if _, ok := globalFrames[frame]; ok {
+		return 1e7
+	}
+	if frame == frameMission {
+		return 1
+	}
+	return 1e4
+}
+
+func (i *Item) missionItem() *gomavlib.MissionItem {
+	scale := coordinateScale(i.Frame)
+
+	coord := func(p Param) int32 {
+		if math.IsNaN(float64(p)) {
+			return 0
+		}
+		return int32(math.Round(float64(p) * scale))
+	}
+
+	return &gomavlib.MissionItem{
+		Frame:        i.Frame,
+		Command:      i.Command,
+		Autocontinue: i.AutoContinue,
+		Params: [4]float32{
+			float32(i.Params[0]), float32(i.Params[1]),
+			float32(i.Params[2]), float32(i.Params[3]),
+		},
+		X: coord(i.Params[4]),
+		Y: coord(i.Params[5]),
+		Z: float32(i.Params[6]),
+	}
+}
+
+func itemFromMissionItem(mi *gomavlib.MissionItem, doJumpID int) *Item {
+	scale := coordinateScale(mi.Frame)
+
+	return &Item{
+		Type:         itemTypeSimple,
+		Command:      mi.Command,
+		Frame:        mi.Frame,
+		AutoContinue: mi.Autocontinue,
+		DoJumpID:     doJumpID,
+		Params: [7]Param{
+			Param(mi.Params[0]), Param(mi.Params[1]),
+			Param(mi.Params[2]), Param(mi.Params[3]),
+			Param(float64(mi.X) / scale),
+			Param(float64(mi.Y) / scale),
+			Param(mi.Z),
+		},
+	}
+}
@@ -0,0 +1,221 @@
+package qgcplan
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+)
+
+var testPlan = `{
+    "fileType": "Plan",
+    "geoFence": {
+        "circles": [
+            {
+                "circle": {
+                    "center": [47.3977, 8.5456],
+                    "radius": 50.5
+                },
+                "inclusion": false,
+                "version": 1
+            }
+        ],
+        "polygons": [
+            {
+                "inclusion": true,
+                "polygon": [
+                    [47.39, 8.54],
+                    [47.40, 8.54],
+                    [47.40, 8.55]
+                ],
+                "version": 1
+            }
+        ],
+        "breachReturn": [47.3975, 8.5455, 30],
+        "version": 2
+    },
+    "groundStation": "QGroundControl",
+    "mission": {
+        "cruiseSpeed": 15,
+        "firmwareType": 12,
+        "hoverSpeed": 5,
+        "items": [
+            {
+                "AMSLAltAboveTerrain": null,
+                "Altitude": 20,
+                "AltitudeMode": 1,
+                "autoContinue": true,
+                "command": 22,
+                "doJumpId": 1,
+                "frame": 3,
+                "params": [15, 0, 0, null, 47.3977419, 8.5455939, 20],
+                "type": "SimpleItem"
+            },
+            {
+                "TransectStyleComplexItem": {
+                    "Items": [
+                        {
+                            "autoContinue": true,
+                            "command": 16,
+                            "doJumpId": 2,
+                            "frame": 3,
+                            "params": [0, 0, 0, null, 47.398, 8.546, 50],
+                            "type": "SimpleItem"
+                        }
+                    ]
+                },
+                "complexItemType": "survey",
+                "type": "ComplexItem",
+                "version": 5
+            },
+            {
+                "autoContinue": true,
+                "command": 20,
+                "doJumpId": 3,
+                "frame": 2,
+                "params": [0, 0, 0, 0, 0, 0, 0],
+                "type": "SimpleItem"
+            }
+        ],
+        "plannedHomePosition": [47.3977419, 8.5455939, 488],
+        "vehicleType": 2,
+        "version": 2
+    },
+    "rallyPoints": {
+        "points": [
+            [47.3978, 8.5457, 25]
+        ],
+        "version": 2
+    },
+    "version": 1
+}
+`
+
+func TestRead(t *testing.T) {
+	p, err := Read(strings.NewReader(testPlan))
+	require.NoError(t, err)
+
+	items, err := p.MissionItems()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(items))
+
+	require.True(t, math.IsNaN(float64(items[0].Params[3])))
+	items[0].Params[3] = 0
+
+	require.Equal(t, &gomavlib.MissionItem{
+		Frame:        3,
+		Command:      22,
+		Autocontinue: true,
+		Params:       [4]float32{15, 0, 0, 0},
+		X:            473977419,
+		Y:            85455939,
+		Z:            20,
+	}, items[0])
+
+	// survey
+	require.Equal(t, 16, items[1].Command)
+	require.Equal(t, int32(473980000), items[1].X)
+	require.Equal(t, int32(85460000), items[1].Y)
+	require.Equal(t, float32(50), items[1].Z)
+
+	require.Equal(t, &gomavlib.MissionItem{
+		Frame:        2,
+		Command:      20,
+		Autocontinue: true,
+	}, items[2])
+
+	require.Equal(t, &gomavlib.Fence{
+		ReturnPoint: &gomavlib.GeoPoint{Latitude: 47.3975, Longitude: 8.5455, Altitude: 30},
+		Polygons: []*gomavlib.FencePolygon{{
+			Inclusion: true,
+			Vertices: []gomavlib.GeoPoint{
+				{Latitude: 47.39, Longitude: 8.54},
+				{Latitude: 47.40, Longitude: 8.54},
+				{Latitude: 47.40, Longitude: 8.55},
+			},
+		}},
+		Circles: []*gomavlib.FenceCircle{{
+			Center: gomavlib.GeoPoint{Latitude: 47.3977, Longitude: 8.5456},
+			Radius: 50.5,
+		}},
+	}, p.Fence())
+
+	require.Equal(t, []gomavlib.GeoPoint{
+		{Latitude: 47.3978, Longitude: 8.5457, Altitude: 25},
+	}, p.Rally())
+}
+
+func TestReadWrite(t *testing.T) {
+	p, err := Read(strings.NewReader(testPlan))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = p.Write(&buf)
+	require.NoError(t, err)
+
+	// the content is preserved, including fields that are not decoded
+	var v1, v2 interface{}
+	require.NoError(t, json.Unmarshal([]byte(testPlan), &v1))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &v2))
+	require.Equal(t, v1, v2)
+}
+
+func TestNew(t *testing.T) {
+	items := []*gomavlib.MissionItem{
+		{
+			Frame:        3,
+			Command:      22,
+			Autocontinue: true,
+			Z:            10,
+		},
+		{
+			Frame:        3,
+			Command:      16,
+			Autocontinue: true,
+			Params:       [4]float32{1, 2, 0, 0},
+			X:            454642100,
+			Y:            91900000,
+			Z:            20,
+		},
+	}
+	fence := &gomavlib.Fence{
+		Circles: []*gomavlib.FenceCircle{{
+			Inclusion: true,
+			Center:    gomavlib.GeoPoint{Latitude: 45.4642, Longitude: 9.19},
+			Radius:    100,
+		}},
+	}
+	rally := []gomavlib.GeoPoint{
+		{Latitude: 45.465, Longitude: 9.191, Altitude: 15},
+	}
+
+	var buf bytes.Buffer
+	err := New(items, fence, rally).Write(&buf)
+	require.NoError(t, err)
+
+	p, err := Read(&buf)
+	require.NoError(t, err)
+
+	items2, err := p.MissionItems()
+	require.NoError(t, err)
+	require.Equal(t, items, items2)
+	require.Equal(t, fence, p.Fence())
+	require.Equal(t, rally, p.Rally())
+}
+
+func TestReadErrors(t *testing.T) {
+	_, err := Read(strings.NewReader(`{"fileType": "GeoFence"}`))
+	require.EqualError(t, err, "unsupported file type 'GeoFence'")
+
+	p, err := Read(strings.NewReader(`{"fileType": "Plan", "mission": {"items": [` +
+		`{"type": "ComplexItem", "complexItemType": "StructureScan"}]}}`))
+	require.NoError(t, err)
+
+	_, err = p.MissionItems()
+	require.EqualError(t, err, "complex item 'StructureScan' does not contain simple items")
+}
@@ -0,0 +1,222 @@
+// Package sqlitelog contains a storage of frames into a SQLite database.
+// Frames are stored in a table named "frames", indexed by reception time,
+// system ID and message ID, in JSON format, that can be read by the provided
+// query helpers or by other tools with the SQLite JSON functions.
+//
+// The package doesn't depend on any SQLite driver: the database must be opened
+// by the user, with a driver of choice, i.e.
+//
+//	import _ "github.com/mattn/go-sqlite3"
+//	db, err := sql.Open("sqlite3", "flights.db")
+package sqlitelog
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS frames (
+	time INTEGER NOT NULL,
+	system_id INTEGER NOT NULL,
+	component_id INTEGER NOT NULL,
+	message_id INTEGER NOT NULL,
+	frame TEXT NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS frames_time ON frames (time)`,
+	`CREATE INDEX IF NOT EXISTS frames_system_message_time ON frames (system_id, message_id, time)`,
+}
+
+// Entry is a frame read from the database.
+type Entry struct {
+	// the reception time.
+	Time time.Time
+
+	// the frame.
+	Frame frame.Frame
+}
+
+// Query contains the criteria that are used to read frames.
+// Empty fields are ignored.
+type Query struct {
+	// read only frames received at or after this time.
+	Start time.Time
+
+	// read only frames received before this time.
+	End time.Time
+
+	// read only frames sent by these systems.
+	SystemIDs []byte
+
+	// read only frames containing these messages.
+	MessageIDs []uint32
+
+	// read frames from the most recent one, instead of the oldest one.
+	Reverse bool
+
+	// the maximum number of frames to read.
+	Limit int
+}
+
+// Store writes frames into a SQLite database and reads them.
+type Store struct {
+	db        *sql.DB
+	dialectDE *dialect.DecEncoder
+	insert    *sql.Stmt
+}
+
+// NewStore allocates a Store, that uses the given database, and creates the
+// schema if it doesn't exist.
+// The dialect is needed to encode and decode messages; it can be nil if
+// frames contain only *msg.MessageRaw messages.
+func NewStore(db *sql.DB, d *dialect.Dialect) (*Store, error) {
+	if d == nil {
+		d = &dialect.Dialect{}
+	}
+
+	dialectDE, err := dialect.NewDecEncoder(d)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stmt := range schema {
+		_, err := db.Exec(stmt)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create schema: %s", err)
+		}
+	}
+
+	insert, err := db.Prepare("INSERT INTO frames (time, system_id, component_id, message_id, frame) " +
+		"VALUES (?, ?, ?, ?, ?)")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		db:        db,
+		dialectDE: dialectDE,
+		insert:    insert,
+	}, nil
+}
+
+// Close closes the Store. The database is not closed.
+func (s *Store) Close() error {
+	return s.insert.Close()
+}
+
+// WriteFrame writes a frame, received at the given time.
+func (s *Store) WriteFrame(t time.Time, fr frame.Frame) error {
+	byts, err := s.dialectDE.EncodeFrameJSON(fr)
+	if err != nil {
+		return fmt.Errorf("unable to encode frame: %s", err)
+	}
+
+	_, err = s.insert.Exec(
+		t.UnixNano()/1000,
+		fr.GetSystemID(),
+		fr.GetComponentID(),
+		fr.GetMessage().GetID(),
+		string(byts))
+	return err
+}
+
+// Query reads the frames that satisfy the given criteria, ordered by
+// reception time.
+func (s *Store) Query(q Query) ([]*Entry, error) {
+	var conds []string
+	var args []interface{}
+
+	if !q.Start.IsZero() {
+		conds = append(conds, "time >= ?")
+		args = append(args, q.Start.UnixNano()/1000)
+	}
+
+	if !q.End.IsZero() {
+		conds = append(conds, "time < ?")
+		args = append(args, q.End.UnixNano()/1000)
+	}
+
+	if len(q.SystemIDs) > 0 {
+		conds = append(conds, "system_id IN (?"+strings.Repeat(", ?", len(q.SystemIDs)-1)+")")
+		for _, id := range q.SystemIDs {
+			args = append(args, id)
+		}
+	}
+
+	if len(q.MessageIDs) > 0 {
+		conds = append(conds, "message_id IN (?"+strings.Repeat(", ?", len(q.MessageIDs)-1)+")")
+		for _, id := range q.MessageIDs {
+			args = append(args, id)
+		}
+	}
+
+	query := "SELECT time, frame FROM frames"
+
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+
+	if q.Reverse {
+		query += " ORDER BY time DESC"
+	} else {
+		query += " ORDER BY time"
+	}
+
+	if q.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, q.Limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ret []*Entry
+
+	for rows.Next() {
+		var ts int64
+		var byts string
+		err := rows.Scan(&ts, &byts)
+		if err != nil {
+			return nil, err
+		}
+
+		fr, err := s.dialectDE.DecodeFrameJSON([]byte(byts))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode frame: %s", err)
+		}
+
+		ret = append(ret, &Entry{
+			Time:  time.Unix(0, ts*1000),
+			Frame: fr,
+		})
+	}
+
+	return ret, rows.Err()
+}
+
+// Last reads the most recent frame that contains the given message
+// and is sent by the given system. It returns nil if there's no such frame.
+func (s *Store) Last(systemID byte, messageID uint32) (*Entry, error) {
+	entries, err := s.Query(Query{
+		SystemIDs:  []byte{systemID},
+		MessageIDs: []uint32{messageID},
+		Reverse:    true,
+		Limit:      1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[0], nil
+}
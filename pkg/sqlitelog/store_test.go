@@ -0,0 +1,176 @@
+package sqlitelog
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// testDriver is a database driver that stores inserted rows and records
+// queries, returning all the stored rows.
+type testDriver struct {
+	mutex   sync.Mutex
+	execs   []string
+	rows    [][]driver.Value
+	queries []string
+	args    [][]driver.Value
+}
+
+func (d *testDriver) Open(name string) (driver.Conn, error) {
+	return &testConn{d}, nil
+}
+
+type testConn struct {
+	d *testDriver
+}
+
+func (c *testConn) Prepare(query string) (driver.Stmt, error) {
+	return &testStmt{c.d, query}, nil
+}
+
+func (c *testConn) Close() error {
+	return nil
+}
+
+func (c *testConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("unsupported")
+}
+
+type testStmt struct {
+	d     *testDriver
+	query string
+}
+
+func (s *testStmt) Close() error {
+	return nil
+}
+
+func (s *testStmt) NumInput() int {
+	return -1
+}
+
+func (s *testStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mutex.Lock()
+	defer s.d.mutex.Unlock()
+
+	s.d.execs = append(s.d.execs, s.query)
+	if strings.HasPrefix(s.query, "INSERT") {
+		s.d.rows = append(s.d.rows, args)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *testStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mutex.Lock()
+	defer s.d.mutex.Unlock()
+
+	s.d.queries = append(s.d.queries, s.query)
+	s.d.args = append(s.d.args, args)
+	return &testRows{rows: s.d.rows}, nil
+}
+
+type testRows struct {
+	rows [][]driver.Value
+}
+
+func (r *testRows) Columns() []string {
+	return []string{"time", "frame"}
+}
+
+func (r *testRows) Close() error {
+	return nil
+}
+
+func (r *testRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	dest[0] = r.rows[0][0]
+	dest[1] = r.rows[0][4]
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func openTestDB(t *testing.T) (*sql.DB, *testDriver) {
+	d := &testDriver{}
+	name := fmt.Sprintf("sqlitelog-test-%p", d)
+	sql.Register(name, d)
+
+	db, err := sql.Open(name, "")
+	require.NoError(t, err)
+	return db, d
+}
+
+type MessageHeartbeat struct {
+	Type           uint8
+	Autopilot      uint8
+	BaseMode       uint8
+	CustomMode     uint32
+	SystemStatus   uint8
+	MavlinkVersion uint8
+}
+
+func (*MessageHeartbeat) GetID() uint32 {
+	return 0
+}
+
+var testDialect = &dialect.Dialect{Version: 3, Messages: []msg.Message{&MessageHeartbeat{}}}
+
+func TestStore(t *testing.T) {
+	db, d := openTestDB(t)
+	defer db.Close()
+
+	s, err := NewStore(db, testDialect)
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.Equal(t, 3, len(d.execs))
+	require.True(t, strings.HasPrefix(d.execs[0], "CREATE TABLE IF NOT EXISTS frames"))
+
+	fr := &frame.V2Frame{
+		SequenceID:  1,
+		SystemID:    2,
+		ComponentID: 3,
+		Message:     &MessageHeartbeat{Type: 1, CustomMode: 6},
+		Checksum:    0x1234,
+	}
+
+	err = s.WriteFrame(time.Unix(1, 2000), fr)
+	require.NoError(t, err)
+
+	require.Equal(t, []driver.Value{int64(1000002), int64(2), int64(3), int64(0)}, d.rows[0][:4])
+
+	entries, err := s.Query(Query{
+		Start:      time.Unix(1, 0),
+		End:        time.Unix(2, 0),
+		SystemIDs:  []byte{2, 4},
+		MessageIDs: []uint32{0},
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*Entry{{Time: time.Unix(1, 2000), Frame: fr}}, entries)
+
+	require.Equal(t, "SELECT time, frame FROM frames WHERE time >= ? AND time < ? "+
+		"AND system_id IN (?, ?) AND message_id IN (?) ORDER BY time LIMIT ?", d.queries[0])
+	require.Equal(t, []driver.Value{
+		int64(1000000), int64(2000000), int64(2), int64(4), int64(0), int64(10),
+	}, d.args[0])
+
+	entry, err := s.Last(2, 0)
+	require.NoError(t, err)
+	require.Equal(t, &Entry{Time: time.Unix(1, 2000), Frame: fr}, entry)
+
+	require.Equal(t, "SELECT time, frame FROM frames WHERE system_id IN (?) AND message_id IN (?) "+
+		"ORDER BY time DESC LIMIT ?", d.queries[1])
+}
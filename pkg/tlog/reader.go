@@ -0,0 +1,105 @@
+package tlog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+const (
+	// must be greater or equal than the buffer size of the transceiver,
+	// in order to allow the transceiver to share the buffer.
+	readBufferSize = 4096
+)
+
+// Reader reads frames from a telemetry log or from a raw capture.
+type Reader struct {
+	raw bool
+	br  *bufio.Reader
+	tr  *transceiver.Transceiver
+}
+
+func newReader(r io.Reader, d *dialect.Dialect, raw bool) (*Reader, error) {
+	var dialectDE *dialect.DecEncoder
+	if d != nil {
+		var err error
+		dialectDE, err = dialect.NewDecEncoder(d)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the transceiver uses the same buffered reader, since it doesn't
+	// allocate a new one when the reader is already buffered.
+	br := bufio.NewReaderSize(r, readBufferSize)
+
+	// the transceiver is used only to decode frames, therefore
+	// outgoing parameters are not relevant
+	tr, err := transceiver.New(transceiver.Conf{
+		Reader:      br,
+		Writer:      ioutil.Discard,
+		DialectDE:   dialectDE,
+		OutVersion:  transceiver.V2,
+		OutSystemID: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{
+		raw: raw,
+		br:  br,
+		tr:  tr,
+	}, nil
+}
+
+// NewReader allocates a Reader, that reads frames from a telemetry log.
+// The dialect is used to decode messages; if it is nil, or if a message
+// is not in the dialect, the message is returned as a *msg.MessageRaw.
+func NewReader(r io.Reader, d *dialect.Dialect) (*Reader, error) {
+	return newReader(r, d, false)
+}
+
+// NewRawReader allocates a Reader, that reads frames from a raw capture,
+// i.e. a sequence of frames without timestamps.
+// The dialect is used to decode messages; if it is nil, or if a message
+// is not in the dialect, the message is returned as a *msg.MessageRaw.
+func NewRawReader(r io.Reader, d *dialect.Dialect) (*Reader, error) {
+	return newReader(r, d, true)
+}
+
+// ReadFrame reads a frame and the time in which it was received.
+// In case of raw captures, the time is always zero.
+// Errors of type *transceiver.Error are not fatal and reading can continue.
+// io.EOF is returned when the end of the log is reached.
+// It must not be called by multiple routines in parallel.
+func (tr *Reader) ReadFrame() (time.Time, frame.Frame, error) {
+	var t time.Time
+
+	if !tr.raw {
+		var ts [8]byte
+		_, err := io.ReadFull(tr.br, ts[:])
+		if err != nil {
+			if err == io.ErrUnexpectedEOF {
+				err = io.EOF
+			}
+			return time.Time{}, nil, err
+		}
+
+		us := int64(binary.BigEndian.Uint64(ts[:]))
+		t = time.Unix(us/1000000, (us%1000000)*1000)
+	}
+
+	fr, err := tr.tr.Read()
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	return t, fr, nil
+}
@@ -0,0 +1,54 @@
+package tlog
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestReader(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, testDialect)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		err = w.WriteFrame(time.Unix(1, int64(i)*1000000), testFrame)
+		require.NoError(t, err)
+	}
+
+	r, err := NewReader(&buf, testDialect)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		ts, fr, err := r.ReadFrame()
+		require.NoError(t, err)
+		require.Equal(t, time.Unix(1, int64(i)*1000000), ts)
+		require.Equal(t, testFrame, fr)
+	}
+
+	_, _, err = r.ReadFrame()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestReaderRaw(t *testing.T) {
+	r, err := NewRawReader(bytes.NewReader(append(testFrameEncoded, testFrameEncoded...)), nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		ts, fr, err := r.ReadFrame()
+		require.NoError(t, err)
+		require.Equal(t, time.Time{}, ts)
+		require.Equal(t, &msg.MessageRaw{
+			ID:      0,
+			Content: []byte{0x06, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05},
+		}, fr.GetMessage())
+	}
+
+	_, _, err = r.ReadFrame()
+	require.Equal(t, io.EOF, err)
+}
@@ -0,0 +1,79 @@
+// Package tlog contains a reader and a writer of telemetry logs (tlog),
+// the format used by QGroundControl and Mission Planner to record flights.
+// A telemetry log is a sequence of frames, each one preceded by its
+// reception time, expressed as a big-endian 8-byte integer that contains
+// the microseconds since 1st January 1970 UTC.
+package tlog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+// Writer writes frames into a telemetry log.
+type Writer struct {
+	w   io.Writer
+	buf bytes.Buffer
+	tr  *transceiver.Transceiver
+}
+
+// NewWriter allocates a Writer, that writes frames into w.
+// The dialect is needed to encode frames whose messages are not encoded
+// (i.e. the ones received by a node that uses the same dialect);
+// it can be nil if frames contain only *msg.MessageRaw messages.
+func NewWriter(w io.Writer, d *dialect.Dialect) (*Writer, error) {
+	var dialectDE *dialect.DecEncoder
+	if d != nil {
+		var err error
+		dialectDE, err = dialect.NewDecEncoder(d)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tw := &Writer{
+		w: w,
+	}
+
+	// the transceiver is used only to encode frames, therefore
+	// outgoing parameters are not relevant
+	tr, err := transceiver.New(transceiver.Conf{
+		Reader:      bytes.NewReader(nil),
+		Writer:      &tw.buf,
+		DialectDE:   dialectDE,
+		OutVersion:  transceiver.V2,
+		OutSystemID: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	tw.tr = tr
+
+	return tw, nil
+}
+
+// WriteFrame writes a frame, received at the given time.
+// It must not be called by multiple routines in parallel.
+func (tw *Writer) WriteFrame(t time.Time, fr frame.Frame) error {
+	tw.buf.Reset()
+
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(t.UnixNano()/1000))
+	tw.buf.Write(ts[:])
+
+	err := tw.tr.WriteFrame(fr)
+	if err != nil {
+		return fmt.Errorf("unable to encode frame: %s", err)
+	}
+
+	// write timestamp and frame with a single call
+	_, err = tw.w.Write(tw.buf.Bytes())
+	return err
+}
@@ -0,0 +1,59 @@
+package tlog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type MessageHeartbeat struct {
+	Type           uint8
+	Autopilot      uint8
+	BaseMode       uint8
+	CustomMode     uint32
+	SystemStatus   uint8
+	MavlinkVersion uint8
+}
+
+func (*MessageHeartbeat) GetID() uint32 {
+	return 0
+}
+
+var testDialect = &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}} //nolint:govet
+
+var testFrame = &frame.V2Frame{
+	SequenceID:  1,
+	SystemID:    2,
+	ComponentID: 3,
+	Message: &MessageHeartbeat{
+		Type:           1,
+		Autopilot:      2,
+		BaseMode:       3,
+		CustomMode:     6,
+		SystemStatus:   4,
+		MavlinkVersion: 5,
+	},
+	Checksum: 0xe206,
+}
+
+var testFrameEncoded = []byte{
+	0xfd, 0x09, 0x00, 0x00, 0x01, 0x02, 0x03, 0x00, 0x00, 0x00,
+	0x06, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0xe2,
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, testDialect)
+	require.NoError(t, err)
+
+	err = w.WriteFrame(time.Unix(1, 2000), testFrame)
+	require.NoError(t, err)
+
+	require.Equal(t, append([]byte{0, 0, 0, 0, 0, 0x0f, 0x42, 0x42}, testFrameEncoded...), buf.Bytes())
+}
@@ -0,0 +1,48 @@
+package transceiver
+
+import (
+	"sync/atomic"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+)
+
+type keySet struct {
+	in  []*frame.V2Key
+	out *frame.V2Key
+}
+
+// Keys contains the keys used to validate incoming frames and to sign
+// outgoing frames. Keys can be changed while transceivers are running.
+// Signature timestamps are not reset when keys are changed, therefore frames
+// received before the change cannot be replayed and outgoing timestamps
+// remain monotonic.
+type Keys struct {
+	v atomic.Value
+}
+
+// NewKeys allocates a Keys.
+func NewKeys(inKeys []*frame.V2Key, outKey *frame.V2Key) *Keys {
+	k := &Keys{}
+	k.Set(inKeys, outKey)
+	return k
+}
+
+// Set changes the keys at once.
+// It can be called by multiple routines in parallel.
+func (k *Keys) Set(inKeys []*frame.V2Key, outKey *frame.V2Key) {
+	ks := &keySet{out: outKey}
+	if len(inKeys) != 0 {
+		ks.in = append([]*frame.V2Key(nil), inKeys...)
+	}
+	k.v.Store(ks)
+}
+
+// In returns the keys used to validate incoming frames.
+func (k *Keys) In() []*frame.V2Key {
+	return k.v.Load().(*keySet).in
+}
+
+// Out returns the key used to sign outgoing frames.
+func (k *Keys) Out() *frame.V2Key {
+	return k.v.Load().(*keySet).out
+}
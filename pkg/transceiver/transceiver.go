@@ -19,8 +19,41 @@ const (
 // 1st January 2015 GMT
 var signatureReferenceDate = time.Date(2015, 0o1, 0o1, 0, 0, 0, 0, time.UTC)
 
+// SignatureTimestamp returns the signature timestamp of the given time,
+// in 10 microsecond units since 1st January 2015 GMT time.
+func SignatureTimestamp(t time.Time) uint64 {
+	return uint64(t.Sub(signatureReferenceDate)) / 10000
+}
+
+// ErrorType is the type of a non-fatal parsing error.
+type ErrorType int
+
+const (
+	// ErrorTypeMagicByte is returned when the magic byte is invalid.
+	ErrorTypeMagicByte ErrorType = iota + 1
+
+	// ErrorTypeFrame is returned when the frame cannot be decoded.
+	ErrorTypeFrame
+
+	// ErrorTypeSignature is returned when the frame signature is missing or invalid.
+	ErrorTypeSignature
+
+	// ErrorTypeChecksum is returned when the frame checksum is invalid.
+	ErrorTypeChecksum
+
+	// ErrorTypeMessage is returned when the message cannot be decoded.
+	ErrorTypeMessage
+)
+
 // Error is the error returned in case of non-fatal parsing errors.
 type Error struct {
+	// the error type
+	Type ErrorType
+
+	// the bytes that caused the error: the invalid magic byte, or the
+	// frame that cannot be decoded or validated.
+	Data []byte
+
 	str string
 }
 
@@ -28,9 +61,10 @@ func (e *Error) Error() string {
 	return e.str
 }
 
-func newError(format string, args ...interface{}) *Error {
+func newError(typ ErrorType, format string, args ...interface{}) *Error {
 	return &Error{
-		str: fmt.Sprintf(format, args...),
+		Type: typ,
+		str:  fmt.Sprintf(format, args...),
 	}
 }
 
@@ -45,9 +79,29 @@ type Conf struct {
 	// If not provided, messages are decoded in the MessageRaw struct.
 	DialectDE *dialect.DecEncoder
 
+	// (optional) do not decode messages when reading frames. Checksums are
+	// validated anyway, while messages are left in the MessageRaw struct
+	// and can be decoded on demand with DecodeMessage().
+	LazyDecoding bool
+
 	// (optional) the secret key used to validate incoming frames.
 	// Non-signed frames are discarded. This feature requires v2 frames.
 	InKey *frame.V2Key
+	// (optional) additional secret keys used to validate incoming frames.
+	// A frame is accepted if its signature is valid with any of InKey and
+	// InKeys, and this allows to rotate keys. This feature requires v2 frames.
+	InKeys []*frame.V2Key
+	// (optional) a holder of keys that overrides InKey, InKeys and OutKey.
+	// It allows to change keys while the transceiver is running, and can be
+	// shared by multiple transceivers.
+	Keys *Keys
+	// (optional) a function that decides whether incoming frames are
+	// accepted, in place of the default policy, that requires frames to be
+	// signed with any of InKey and InKeys, with a timestamp not older than
+	// 10 seconds with respect to the previous frame. It is called for every
+	// frame and returns an error in order to discard it.
+	// The default policy is available in SignatureCheck.Default().
+	SignaturePolicy func(*SignatureCheck) error
 
 	// Mavlink version used to encode messages. See Version
 	// for the available options.
@@ -63,15 +117,36 @@ type Conf struct {
 	// (optional) the secret key used to sign outgoing frames.
 	// This feature requires v2 frames.
 	OutKey *frame.V2Key
+	// (optional) a function that returns the timestamp of signed outgoing
+	// frames. It defaults to SignatureTimestamp(time.Now()). In any case,
+	// timestamps are increased in order to be monotonic.
+	OutSignatureTimestamp func() uint64
+	// (optional) converts frames passed to WriteFrame() into OutVersion, when
+	// their version is different. Frames that cannot be represented
+	// with OutVersion are not written. This feature requires a dialect.
+	OutTranslate bool
+	// (optional) a function that is called when a frame is built from a
+	// message passed to WriteMessage() or WriteMessageComponent(), after its
+	// header has been filled and before it is encoded, checksummed and
+	// signed. It can modify the frame, whose message is shared and must be
+	// replaced instead of modified, or return nil to discard it, in which
+	// case ErrFrameDiscarded is returned.
+	OutFrameHook func(frame.Frame) frame.Frame
 }
 
+// ErrFrameDiscarded is returned when a frame is discarded by OutFrameHook.
+var ErrFrameDiscarded = fmt.Errorf("frame discarded")
+
 // Transceiver is a low-level Mavlink encoder and decoder that works with a Reader and a Writer.
 type Transceiver struct {
-	conf                 Conf
-	readBuffer           *bufio.Reader
-	writeBuffer          []byte
-	curWriteSequenceID   byte
-	curReadSignatureTime uint64
+	conf                  Conf
+	keys                  *Keys
+	curReadKey            *frame.V2Key
+	readBuffer            *bufio.Reader
+	writeBuffer           []byte
+	curWriteSequenceID    byte
+	curWriteSignatureTime uint64
+	curReadSignatureTime  uint64
 }
 
 // New allocates a Transceiver, a low level frame encoder and decoder.
@@ -93,12 +168,24 @@ func New(conf Conf) (*Transceiver, error) {
 	if conf.OutComponentID < 1 {
 		conf.OutComponentID = 1
 	}
-	if conf.OutKey != nil && conf.OutVersion != V2 {
+	keys := conf.Keys
+	if keys == nil {
+		var inKeys []*frame.V2Key
+		if conf.InKey != nil {
+			inKeys = append(inKeys, conf.InKey)
+		}
+		inKeys = append(inKeys, conf.InKeys...)
+
+		keys = NewKeys(inKeys, conf.OutKey)
+	}
+
+	if keys.Out() != nil && conf.OutVersion != V2 {
 		return nil, fmt.Errorf("OutKey requires V2 frames")
 	}
 
 	return &Transceiver{
 		conf:        conf,
+		keys:        keys,
 		readBuffer:  bufio.NewReaderSize(conf.Reader, bufferSize),
 		writeBuffer: make([]byte, 0, bufferSize),
 	}, nil
@@ -121,78 +208,247 @@ func (p *Transceiver) Read() (frame.Frame, error) {
 			return &frame.V2Frame{}, nil
 		}
 
-		return nil, newError("invalid magic byte: %x", magicByte)
+		terr := newError(ErrorTypeMagicByte, "invalid magic byte: %x", magicByte)
+		terr.Data = []byte{magicByte}
+		return nil, terr
 	}()
 	if err != nil {
 		return nil, err
 	}
 
+	// bytes are copied only in case of errors
+	buf := p.peekFrame(magicByte)
+
 	err = f.Decode(p.readBuffer)
 	if err != nil {
-		return nil, newError(err.Error())
+		terr := newError(ErrorTypeFrame, "%s", err.Error())
+		terr.Data = append([]byte{magicByte}, buf...)
+		return nil, terr
 	}
 
-	if p.conf.InKey != nil {
-		ff, ok := f.(*frame.V2Frame)
-		if !ok {
-			return nil, newError("signature required but packet is not v2")
+	err = p.validateAndDecode(f)
+	if err != nil {
+		// payload is not used anymore
+		frame.ReleasePayload(f.GetMessage().(*msg.MessageRaw))
+
+		if terr, ok := err.(*Error); ok {
+			terr.Data = append([]byte{magicByte}, buf...)
 		}
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// peekFrame returns the bytes of the frame that is being read, after the magic
+// byte, without consuming them. They remain valid until the next Read().
+func (p *Transceiver) peekFrame(magicByte byte) []byte {
+	var l int
 
-		if sig := ff.GenSignature(p.conf.InKey); *sig != *ff.Signature {
-			return nil, newError("wrong signature")
+	if magicByte == frame.V1MagicByte {
+		buf, err := p.readBuffer.Peek(1)
+		if err != nil {
+			return nil
+		}
+		l = 5 + int(buf[0]) + 2
+	} else {
+		buf, err := p.readBuffer.Peek(2)
+		if err != nil {
+			return nil
 		}
 
-		// in UDP, packet order is not guaranteed. Therefore, we accept frames
-		// with a timestamp within 10 seconds with respect to the previous frame.
-		if p.curReadSignatureTime > 0 &&
-			ff.SignatureTimestamp < (p.curReadSignatureTime-(10*100000)) {
-			return nil, newError("signature timestamp is too old")
+		switch buf[1] {
+		case 0:
+			l = 9 + int(buf[0]) + 2
+
+		case frame.V2FlagSigned:
+			l = 9 + int(buf[0]) + 2 + 13
+
+		default:
+			// the frame is discarded after the header
+			l = 9
 		}
+	}
+
+	buf, _ := p.readBuffer.Peek(l)
+	return buf
+}
+
+// SignatureCheck contains the details of the signature of an incoming frame,
+// that are passed to SignaturePolicy.
+type SignatureCheck struct {
+	// the frame
+	Frame frame.Frame
+
+	// the key, among InKey and InKeys, that validated the signature.
+	// It is nil if the frame is not signed or no key validated the signature.
+	Key *frame.V2Key
 
-		if ff.SignatureTimestamp > p.curReadSignatureTime {
-			p.curReadSignatureTime = ff.SignatureTimestamp
+	// the most recent signature timestamp of accepted frames.
+	LastTimestamp uint64
+
+	inKeys []*frame.V2Key
+}
+
+// Default applies the default policy, that requires frames to be signed
+// with any of InKey and InKeys, with a timestamp not older than 10 seconds
+// with respect to the previous frame. Frames are accepted if there are no keys.
+func (c *SignatureCheck) Default() error {
+	if c.inKeys == nil {
+		return nil
+	}
+
+	ff, ok := c.Frame.(*frame.V2Frame)
+	if !ok {
+		return newError(ErrorTypeSignature, "signature required but packet is not v2")
+	}
+
+	if ff.Signature == nil {
+		return newError(ErrorTypeSignature, "signature required but packet is not signed")
+	}
+
+	if c.Key == nil {
+		return newError(ErrorTypeSignature, "wrong signature")
+	}
+
+	// in UDP, packet order is not guaranteed. Therefore, we accept frames
+	// with a timestamp within 10 seconds with respect to the previous frame.
+	if c.LastTimestamp > 0 &&
+		(ff.SignatureTimestamp+(10*100000)) < c.LastTimestamp {
+		return newError(ErrorTypeSignature, "signature timestamp is too old")
+	}
+
+	return nil
+}
+
+// matchKey returns the key that validates the signature of a frame.
+func matchKey(f frame.Frame, inKeys []*frame.V2Key) *frame.V2Key {
+	ff, ok := f.(*frame.V2Frame)
+	if !ok || ff.Signature == nil {
+		return nil
+	}
+
+	for _, k := range inKeys {
+		if sig := ff.GenSignature(k); *sig == *ff.Signature {
+			return k
+		}
+	}
+	return nil
+}
+
+// validateAndDecode validates the signature and the checksum of a frame
+// and decodes its message.
+func (p *Transceiver) validateAndDecode(f frame.Frame) error {
+	p.curReadKey = nil
+	inKeys := p.keys.In()
+
+	if inKeys != nil || p.conf.SignaturePolicy != nil {
+		check := &SignatureCheck{
+			Frame:         f,
+			Key:           matchKey(f, inKeys),
+			LastTimestamp: p.curReadSignatureTime,
+			inKeys:        inKeys,
+		}
+
+		var err error
+		if p.conf.SignaturePolicy != nil {
+			err = p.conf.SignaturePolicy(check)
+		} else {
+			err = check.Default()
+		}
+
+		if err != nil {
+			if _, ok := err.(*Error); !ok {
+				err = newError(ErrorTypeSignature, "%s", err.Error())
+			}
+			return err
 		}
+
+		if check.Key != nil {
+			if ts := f.(*frame.V2Frame).SignatureTimestamp; ts > p.curReadSignatureTime {
+				p.curReadSignatureTime = ts
+			}
+		}
+
+		p.curReadKey = check.Key
 	}
 
-	// decode message if in dialect and validate checksum
+	// validate checksum and decode message if in dialect
 	if p.conf.DialectDE != nil {
 		if mp, ok := p.conf.DialectDE.MessageDEs[f.GetMessage().GetID()]; ok {
-			if sum := f.GenChecksum(p.conf.DialectDE.MessageDEs[f.GetMessage().GetID()].CRCExtra()); sum != f.GetChecksum() {
-				return nil, newError("wrong checksum (expected %.4x, got %.4x, id=%d)",
+			if sum := f.GenChecksum(mp.CRCExtra()); sum != f.GetChecksum() {
+				return newError(ErrorTypeChecksum, "wrong checksum (expected %.4x, got %.4x, id=%d)",
 					sum, f.GetChecksum(), f.GetMessage().GetID())
 			}
 
-			_, isV2 := f.(*frame.V2Frame)
-			msg, err := mp.Decode(f.GetMessage().(*msg.MessageRaw).Content, isV2)
-			if err != nil {
-				return nil, newError(err.Error())
-			}
-
-			switch ff := f.(type) {
-			case *frame.V1Frame:
-				ff.Message = msg
-			case *frame.V2Frame:
-				ff.Message = msg
+			if !p.conf.LazyDecoding {
+				m, err := p.DecodeMessage(f)
+				if err != nil {
+					return newError(ErrorTypeMessage, "%s", err.Error())
+				}
+
+				// payload is not used anymore, since it has been decoded
+				frame.ReleasePayload(f.GetMessage().(*msg.MessageRaw))
+
+				switch ff := f.(type) {
+				case *frame.V1Frame:
+					ff.Message = m
+				case *frame.V2Frame:
+					ff.Message = m
+				}
 			}
 		}
 	}
 
-	return f, nil
+	return nil
+}
+
+// ReadKey returns the key that validated the signature of the last frame
+// returned by Read(). It is nil if signatures are not validated.
+// It must not be called by multiple routines in parallel with Read().
+func (p *Transceiver) ReadKey() *frame.V2Key {
+	return p.curReadKey
+}
+
+// DecodeMessage decodes the message of a frame that has been read with
+// LazyDecoding. The frame is not modified. If the message is already
+// decoded or is not in the dialect, it is returned as is.
+// It can be called by multiple routines in parallel.
+func (p *Transceiver) DecodeMessage(f frame.Frame) (msg.Message, error) {
+	raw, ok := f.GetMessage().(*msg.MessageRaw)
+	if !ok || p.conf.DialectDE == nil {
+		return f.GetMessage(), nil
+	}
+
+	mp, ok := p.conf.DialectDE.MessageDEs[raw.ID]
+	if !ok {
+		return raw, nil
+	}
+
+	_, isV2 := f.(*frame.V2Frame)
+	return mp.Decode(raw.Content, isV2)
 }
 
 // WriteMessage writes a Message into the writer.
 // It must not be called by multiple routines in parallel.
 func (p *Transceiver) WriteMessage(m msg.Message) error {
+	return p.WriteMessageComponent(p.conf.OutComponentID, m)
+}
+
+// WriteMessageComponent writes a Message into the writer, on behalf of
+// the component with given ID instead of OutComponentID.
+// It must not be called by multiple routines in parallel.
+func (p *Transceiver) WriteMessageComponent(componentID byte, m msg.Message) error {
 	var fr frame.Frame
 	if p.conf.OutVersion == V1 {
 		fr = &frame.V1Frame{Message: m}
 	} else {
 		fr = &frame.V2Frame{Message: m}
 	}
-	return p.writeFrameAndFill(fr)
+	return p.writeFrameAndFill(fr, componentID)
 }
 
-func (p *Transceiver) writeFrameAndFill(fr frame.Frame) error {
+func (p *Transceiver) writeFrameAndFill(fr frame.Frame, componentID byte) error {
 	if fr.GetMessage() == nil {
 		return fmt.Errorf("message is nil")
 	}
@@ -206,24 +462,33 @@ func (p *Transceiver) writeFrameAndFill(fr frame.Frame) error {
 	case *frame.V1Frame:
 		ff.SequenceID = p.curWriteSequenceID
 		ff.SystemID = p.conf.OutSystemID
-		ff.ComponentID = p.conf.OutComponentID
+		ff.ComponentID = componentID
 	case *frame.V2Frame:
 		ff.SequenceID = p.curWriteSequenceID
 		ff.SystemID = p.conf.OutSystemID
-		ff.ComponentID = p.conf.OutComponentID
+		ff.ComponentID = componentID
 	}
 	p.curWriteSequenceID++
 
+	outKey := p.keys.Out()
+
 	// fill CompatibilityFlag, IncompatibilityFlag if v2
 	if ff, ok := safeFrame.(*frame.V2Frame); ok {
 		ff.CompatibilityFlag = 0
 		ff.IncompatibilityFlag = 0
 
-		if p.conf.OutKey != nil {
+		if outKey != nil {
 			ff.IncompatibilityFlag |= frame.V2FlagSigned
 		}
 	}
 
+	if p.conf.OutFrameHook != nil {
+		safeFrame = p.conf.OutFrameHook(safeFrame)
+		if safeFrame == nil {
+			return ErrFrameDiscarded
+		}
+	}
+
 	// encode message if it is not already encoded
 	if _, ok := safeFrame.GetMessage().(*msg.MessageRaw); !ok {
 		if p.conf.DialectDE == nil {
@@ -259,11 +524,156 @@ func (p *Transceiver) writeFrameAndFill(fr frame.Frame) error {
 	}
 
 	// fill SignatureLinkID, SignatureTimestamp, Signature if v2
-	if ff, ok := safeFrame.(*frame.V2Frame); ok && p.conf.OutKey != nil {
-		ff.SignatureLinkID = p.conf.OutSignatureLinkID
-		// Timestamp in 10 microsecond units since 1st January 2015 GMT time
-		ff.SignatureTimestamp = uint64(time.Since(signatureReferenceDate)) / 10000
-		ff.Signature = ff.GenSignature(p.conf.OutKey)
+	if ff, ok := safeFrame.(*frame.V2Frame); ok && outKey != nil {
+		p.sign(ff, outKey)
+	}
+
+	return p.writeFrame(safeFrame)
+}
+
+// sign fills SignatureLinkID, SignatureTimestamp and Signature of a frame.
+func (p *Transceiver) sign(ff *frame.V2Frame, key *frame.V2Key) {
+	ff.SignatureLinkID = p.conf.OutSignatureLinkID
+	var ts uint64
+	if p.conf.OutSignatureTimestamp != nil {
+		ts = p.conf.OutSignatureTimestamp()
+	} else {
+		ts = SignatureTimestamp(time.Now())
+	}
+
+	// timestamps must be monotonic, even if the clock goes backwards
+	if ts <= p.curWriteSignatureTime {
+		ts = p.curWriteSignatureTime + 1
+	}
+	p.curWriteSignatureTime = ts
+
+	ff.SignatureTimestamp = ts
+	ff.Signature = ff.GenSignature(key)
+}
+
+// translateFrame converts a frame into OutVersion.
+func (p *Transceiver) translateFrame(fr frame.Frame) (frame.Frame, error) {
+	_, isV2 := fr.(*frame.V2Frame)
+	if isV2 == (p.conf.OutVersion == V2) {
+		return fr, nil
+	}
+
+	m := fr.GetMessage()
+	if m == nil {
+		return nil, fmt.Errorf("message is nil")
+	}
+
+	if isV2 && m.GetID() > 0xFF {
+		return nil, fmt.Errorf("frame cannot be translated since message ID %d is not supported by V1 frames",
+			m.GetID())
+	}
+
+	if p.conf.DialectDE == nil {
+		return nil, fmt.Errorf("frame cannot be translated since dialect is nil")
+	}
+
+	mp, ok := p.conf.DialectDE.MessageDEs[m.GetID()]
+	if !ok {
+		return nil, fmt.Errorf("frame cannot be translated since message is not in the dialect")
+	}
+
+	// the payload layout depends on the version, therefore the message
+	// must be decoded and encoded again
+	if raw, ok := m.(*msg.MessageRaw); ok {
+		var err error
+		m, err = mp.Decode(raw.Content, isV2)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	byt, err := mp.Encode(m, !isV2)
+	if err != nil {
+		return nil, err
+	}
+	msgRaw := &msg.MessageRaw{m.GetID(), byt} //nolint:govet
+
+	if ff, ok := fr.(*frame.V2Frame); ok {
+		out := &frame.V1Frame{
+			SequenceID:  ff.SequenceID,
+			SystemID:    ff.SystemID,
+			ComponentID: ff.ComponentID,
+			Message:     msgRaw,
+		}
+		out.Checksum = out.GenChecksum(mp.CRCExtra())
+		return out, nil
+	}
+
+	ff := fr.(*frame.V1Frame)
+	out := &frame.V2Frame{
+		SequenceID:  ff.SequenceID,
+		SystemID:    ff.SystemID,
+		ComponentID: ff.ComponentID,
+		Message:     msgRaw,
+	}
+	outKey := p.keys.Out()
+	if outKey != nil {
+		out.IncompatibilityFlag |= frame.V2FlagSigned
+	}
+	out.Checksum = out.GenChecksum(mp.CRCExtra())
+	if outKey != nil {
+		p.sign(out, outKey)
+	}
+	return out, nil
+}
+
+// WriteFrameRewritten writes a Frame whose header or message has been
+// modified after being read, by computing again its checksum and signature.
+// The frame is signed with OutKey if it is set, otherwise its signature is
+// removed, since it would not be valid anymore.
+// It must not be called by multiple routines in parallel.
+func (p *Transceiver) WriteFrameRewritten(fr frame.Frame) error {
+	m := fr.GetMessage()
+	if m == nil {
+		return fmt.Errorf("message is nil")
+	}
+
+	if p.conf.DialectDE == nil {
+		return fmt.Errorf("frame cannot be rewritten since dialect is nil")
+	}
+
+	mp, ok := p.conf.DialectDE.MessageDEs[m.GetID()]
+	if !ok {
+		return fmt.Errorf("frame cannot be rewritten since message is not in the dialect")
+	}
+
+	// do not touch the original frame
+	safeFrame := fr.Clone()
+
+	if _, ok := m.(*msg.MessageRaw); !ok {
+		_, isV2 := safeFrame.(*frame.V2Frame)
+		byt, err := mp.Encode(m, isV2)
+		if err != nil {
+			return err
+		}
+		m = &msg.MessageRaw{m.GetID(), byt} //nolint:govet
+	}
+
+	switch ff := safeFrame.(type) {
+	case *frame.V1Frame:
+		ff.Message = m
+		ff.Checksum = ff.GenChecksum(mp.CRCExtra())
+
+	case *frame.V2Frame:
+		ff.Message = m
+		outKey := p.keys.Out()
+		if outKey != nil {
+			ff.IncompatibilityFlag |= frame.V2FlagSigned
+		} else {
+			ff.IncompatibilityFlag &^= frame.V2FlagSigned
+			ff.SignatureLinkID = 0
+			ff.SignatureTimestamp = 0
+			ff.Signature = nil
+		}
+		ff.Checksum = ff.GenChecksum(mp.CRCExtra())
+		if outKey != nil {
+			p.sign(ff, outKey)
+		}
 	}
 
 	return p.WriteFrame(safeFrame)
@@ -274,6 +684,18 @@ func (p *Transceiver) writeFrameAndFill(fr frame.Frame) error {
 // This function is intended only for routing pre-existing frames to other nodes,
 // since all frame fields must be filled manually.
 func (p *Transceiver) WriteFrame(fr frame.Frame) error {
+	if p.conf.OutTranslate {
+		var err error
+		fr, err = p.translateFrame(fr)
+		if err != nil {
+			return err
+		}
+	}
+
+	return p.writeFrame(fr)
+}
+
+func (p *Transceiver) writeFrame(fr frame.Frame) error {
 	m := fr.GetMessage()
 	if m == nil {
 		return fmt.Errorf("message is nil")
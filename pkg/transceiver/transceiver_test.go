@@ -3,6 +3,7 @@ package transceiver
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	"testing"
 	"time"
 
@@ -276,6 +277,30 @@ func TestTransceiverDecode(t *testing.T) {
 	}
 }
 
+func TestTransceiverLazyDecoding(t *testing.T) {
+	for _, c := range casesTransceiver {
+		t.Run(c.name, func(t *testing.T) {
+			transceiver, err := New(Conf{
+				Reader:       bytes.NewReader(c.raw),
+				Writer:       bytes.NewBuffer(nil),
+				DialectDE:    c.dialectDE,
+				LazyDecoding: true,
+				OutVersion:   V2,
+				OutSystemID:  1,
+				InKey:        c.key,
+			})
+			require.NoError(t, err)
+			frame, err := transceiver.Read()
+			require.NoError(t, err)
+			require.IsType(t, &msg.MessageRaw{}, frame.GetMessage())
+
+			m, err := transceiver.DecodeMessage(frame)
+			require.NoError(t, err)
+			require.Equal(t, c.frame.GetMessage(), m)
+		})
+	}
+}
+
 func TestTransceiverEncode(t *testing.T) {
 	for _, c := range casesTransceiver {
 		t.Run(c.name, func(t *testing.T) {
@@ -355,6 +380,46 @@ func TestTransceiverWriteMessage(t *testing.T) {
 	}
 }
 
+func TestTransceiverOutFrameHook(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	transceiver, err := New(Conf{
+		Reader:      bytes.NewBuffer(nil),
+		Writer:      buf,
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 1,
+		OutFrameHook: func(fr frame.Frame) frame.Frame {
+			if fr.GetMessage().(*MessageHeartbeat).Type == 2 {
+				return nil
+			}
+			fr.(*frame.V2Frame).ComponentID = 5
+			return fr
+		},
+	})
+	require.NoError(t, err)
+
+	err = transceiver.WriteMessage(&MessageHeartbeat{Type: 2})
+	require.Equal(t, ErrFrameDiscarded, err)
+
+	err = transceiver.WriteMessage(&MessageHeartbeat{Type: 1})
+	require.NoError(t, err)
+
+	reader, err := New(Conf{
+		Reader:      buf,
+		Writer:      bytes.NewBuffer(nil),
+		DialectDE:   testDialectDE,
+		OutVersion:  V2,
+		OutSystemID: 2,
+	})
+	require.NoError(t, err)
+
+	fr, err := reader.Read()
+	require.NoError(t, err)
+	require.Equal(t, byte(1), fr.GetSystemID())
+	require.Equal(t, byte(5), fr.GetComponentID())
+	require.Equal(t, &MessageHeartbeat{Type: 1}, fr.GetMessage())
+}
+
 func TestTransceiverEncodeNilMsg(t *testing.T) {
 	transceiver, err := New(Conf{
 		Reader:      bytes.NewReader(nil),
@@ -402,3 +467,373 @@ func TestTransceiverWriteFrameIsConst(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, f, original)
 }
+
+func TestTransceiverKeyRing(t *testing.T) {
+	key1 := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+	key2 := frame.NewV2Key(bytes.Repeat([]byte("\xA8"), 32))
+	key3 := frame.NewV2Key(bytes.Repeat([]byte("\x13"), 32))
+
+	dialectDE, err := dialect.NewDecEncoder(&dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}) //nolint:govet
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	writer, err := New(Conf{
+		Reader:      bytes.NewBuffer(nil),
+		Writer:      &buf,
+		DialectDE:   dialectDE,
+		OutVersion:  V2,
+		OutSystemID: 1,
+		OutKey:      key2,
+	})
+	require.NoError(t, err)
+
+	err = writer.WriteMessage(&MessageHeartbeat{Type: 1})
+	require.NoError(t, err)
+
+	byts := buf.Bytes()
+
+	reader, err := New(Conf{
+		Reader:      bytes.NewReader(byts),
+		Writer:      bytes.NewBuffer(nil),
+		DialectDE:   dialectDE,
+		InKey:       key1,
+		InKeys:      []*frame.V2Key{key2},
+		OutVersion:  V2,
+		OutSystemID: 2,
+	})
+	require.NoError(t, err)
+
+	fr, err := reader.Read()
+	require.NoError(t, err)
+	require.Equal(t, &MessageHeartbeat{Type: 1}, fr.GetMessage())
+	require.Equal(t, key2, reader.ReadKey())
+
+	reader, err = New(Conf{
+		Reader:      bytes.NewReader(byts),
+		Writer:      bytes.NewBuffer(nil),
+		DialectDE:   dialectDE,
+		InKey:       key1,
+		InKeys:      []*frame.V2Key{key3},
+		OutVersion:  V2,
+		OutSystemID: 2,
+	})
+	require.NoError(t, err)
+
+	_, err = reader.Read()
+	require.EqualError(t, err, "wrong signature")
+	require.Nil(t, reader.ReadKey())
+}
+
+func TestTransceiverSignaturePolicy(t *testing.T) {
+	key1 := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+	key2 := frame.NewV2Key(bytes.Repeat([]byte("\xA8"), 32))
+
+	dialectDE, err := dialect.NewDecEncoder(&dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}) //nolint:govet
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	writer, err := New(Conf{
+		Reader:      bytes.NewBuffer(nil),
+		Writer:      &buf,
+		DialectDE:   dialectDE,
+		OutVersion:  V2,
+		OutSystemID: 1,
+		OutKey:      key2,
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		err = writer.WriteMessage(&MessageHeartbeat{Type: MAV_TYPE(i)})
+		require.NoError(t, err)
+	}
+
+	var checks []*SignatureCheck
+
+	reader, err := New(Conf{
+		Reader:      bytes.NewReader(buf.Bytes()),
+		Writer:      bytes.NewBuffer(nil),
+		DialectDE:   dialectDE,
+		InKeys:      []*frame.V2Key{key1, key2},
+		OutVersion:  V2,
+		OutSystemID: 2,
+		SignaturePolicy: func(c *SignatureCheck) error {
+			checks = append(checks, c)
+			if c.Frame.(*frame.V2Frame).SequenceID == 1 {
+				return fmt.Errorf("rejected")
+			}
+			return c.Default()
+		},
+	})
+	require.NoError(t, err)
+
+	fr, err := reader.Read()
+	require.NoError(t, err)
+	require.Equal(t, &MessageHeartbeat{Type: 0}, fr.GetMessage())
+	require.Equal(t, key2, reader.ReadKey())
+
+	_, err = reader.Read()
+	require.Equal(t, ErrorTypeSignature, err.(*Error).Type)
+	require.EqualError(t, err, "rejected")
+
+	require.Equal(t, 2, len(checks))
+	require.Equal(t, key2, checks[1].Key)
+	require.Equal(t, fr.(*frame.V2Frame).SignatureTimestamp, checks[1].LastTimestamp)
+}
+
+func TestTransceiverErrorData(t *testing.T) {
+	dialectDE, err := dialect.NewDecEncoder(&dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}) //nolint:govet
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	writer, err := New(Conf{
+		Reader:      bytes.NewBuffer(nil),
+		Writer:      &buf,
+		DialectDE:   dialectDE,
+		OutVersion:  V2,
+		OutSystemID: 1,
+	})
+	require.NoError(t, err)
+
+	err = writer.WriteMessage(&MessageHeartbeat{Type: 1})
+	require.NoError(t, err)
+
+	valid := append([]byte(nil), buf.Bytes()...)
+	wrong := append([]byte(nil), valid...)
+	wrong[len(wrong)-1] ^= 0xFF
+
+	raw := append([]byte{0x01}, wrong...)
+	raw = append(raw, valid...)
+	raw = append(raw, valid[:5]...)
+
+	reader, err := New(Conf{
+		Reader:      bytes.NewReader(raw),
+		Writer:      bytes.NewBuffer(nil),
+		DialectDE:   dialectDE,
+		OutVersion:  V2,
+		OutSystemID: 2,
+	})
+	require.NoError(t, err)
+
+	_, err = reader.Read()
+	require.Equal(t, &Error{
+		Type: ErrorTypeMagicByte,
+		Data: []byte{0x01},
+		str:  "invalid magic byte: 1",
+	}, err)
+
+	_, err = reader.Read()
+	require.Equal(t, ErrorTypeChecksum, err.(*Error).Type)
+	require.Equal(t, wrong, err.(*Error).Data)
+
+	fr, err := reader.Read()
+	require.NoError(t, err)
+	require.Equal(t, &MessageHeartbeat{Type: 1}, fr.GetMessage())
+
+	_, err = reader.Read()
+	require.Equal(t, ErrorTypeFrame, err.(*Error).Type)
+	require.Equal(t, valid[:5], err.(*Error).Data)
+}
+
+func TestTransceiverSignatureTimestamp(t *testing.T) {
+	key := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+
+	dialectDE, err := dialect.NewDecEncoder(&dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}) //nolint:govet
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	writer, err := New(Conf{
+		Reader:                bytes.NewBuffer(nil),
+		Writer:                &buf,
+		DialectDE:             dialectDE,
+		OutVersion:            V2,
+		OutSystemID:           1,
+		OutKey:                key,
+		OutSignatureTimestamp: func() uint64 { return 1000 },
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		err = writer.WriteMessage(&MessageHeartbeat{})
+		require.NoError(t, err)
+	}
+
+	reader, err := New(Conf{
+		Reader:      &buf,
+		Writer:      bytes.NewBuffer(nil),
+		DialectDE:   dialectDE,
+		InKey:       key,
+		OutVersion:  V2,
+		OutSystemID: 2,
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		fr, err := reader.Read()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1000+i), fr.(*frame.V2Frame).SignatureTimestamp)
+	}
+}
+
+func TestTransceiverTranslate(t *testing.T) {
+	key := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+
+	t.Run("v2 to v1", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		writer, err := New(Conf{
+			Reader:       bytes.NewBuffer(nil),
+			Writer:       &buf,
+			DialectDE:    testDialectDE,
+			OutVersion:   V1,
+			OutSystemID:  1,
+			OutTranslate: true,
+		})
+		require.NoError(t, err)
+
+		err = writer.WriteFrame(&frame.V2Frame{
+			SequenceID:  3,
+			SystemID:    4,
+			ComponentID: 5,
+			Message: &MessageOpticalFlow{
+				TimeUsec:  3,
+				FlowX:     7,
+				FlowRateX: 2,
+			},
+		})
+		require.NoError(t, err)
+
+		err = writer.WriteFrame(&frame.V2Frame{
+			Message: &MessageTest6{},
+		})
+		require.EqualError(t, err, "frame cannot be translated since message ID 1543 is not supported by V1 frames")
+
+		reader, err := New(Conf{
+			Reader:      &buf,
+			Writer:      bytes.NewBuffer(nil),
+			DialectDE:   testDialectDE,
+			OutVersion:  V1,
+			OutSystemID: 2,
+		})
+		require.NoError(t, err)
+
+		fr, err := reader.Read()
+		require.NoError(t, err)
+		require.Equal(t, &frame.V1Frame{
+			SequenceID:  3,
+			SystemID:    4,
+			ComponentID: 5,
+			Message: &MessageOpticalFlow{
+				TimeUsec: 3,
+				FlowX:    7,
+			},
+			Checksum: fr.GetChecksum(),
+		}, fr)
+	})
+
+	t.Run("v1 to v2", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		writer, err := New(Conf{
+			Reader:       bytes.NewBuffer(nil),
+			Writer:       &buf,
+			DialectDE:    testDialectDE,
+			OutVersion:   V2,
+			OutSystemID:  1,
+			OutKey:       key,
+			OutTranslate: true,
+		})
+		require.NoError(t, err)
+
+		err = writer.WriteFrame(&frame.V1Frame{
+			SequenceID:  3,
+			SystemID:    4,
+			ComponentID: 5,
+			Message: &msg.MessageRaw{ //nolint:govet
+				0,
+				[]byte("\x01\x00\x00\x00\x02\x03\x04\x05\x06"),
+			},
+		})
+		require.NoError(t, err)
+
+		reader, err := New(Conf{
+			Reader:      &buf,
+			Writer:      bytes.NewBuffer(nil),
+			DialectDE:   testDialectDE,
+			InKey:       key,
+			OutVersion:  V2,
+			OutSystemID: 2,
+		})
+		require.NoError(t, err)
+
+		fr, err := reader.Read()
+		require.NoError(t, err)
+		require.IsType(t, &frame.V2Frame{}, fr)
+		require.Equal(t, byte(4), fr.GetSystemID())
+		require.Equal(t, &MessageHeartbeat{
+			Type:           2,
+			Autopilot:      3,
+			BaseMode:       4,
+			CustomMode:     1,
+			SystemStatus:   5,
+			MavlinkVersion: 6,
+		}, fr.GetMessage())
+	})
+}
+
+func TestTransceiverWriteFrameRewritten(t *testing.T) {
+	key := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+
+	for _, ca := range []string{"sign", "strip signature"} {
+		t.Run(ca, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			conf := Conf{
+				Reader:      bytes.NewBuffer(nil),
+				Writer:      &buf,
+				DialectDE:   testDialectDE,
+				OutVersion:  V2,
+				OutSystemID: 1,
+			}
+			if ca == "sign" {
+				conf.OutKey = key
+			}
+			writer, err := New(conf)
+			require.NoError(t, err)
+
+			err = writer.WriteFrameRewritten(&frame.V2Frame{
+				IncompatibilityFlag: frame.V2FlagSigned,
+				SequenceID:          3,
+				SystemID:            4,
+				ComponentID:         5,
+				Message:             &MessageHeartbeat{Type: 1},
+				Checksum:            0x1234,
+				SignatureTimestamp:  1,
+				Signature:           &frame.V2Signature{},
+			})
+			require.NoError(t, err)
+
+			conf = Conf{
+				Reader:      &buf,
+				Writer:      bytes.NewBuffer(nil),
+				DialectDE:   testDialectDE,
+				OutVersion:  V2,
+				OutSystemID: 2,
+			}
+			if ca == "sign" {
+				conf.InKey = key
+			}
+			reader, err := New(conf)
+			require.NoError(t, err)
+
+			fr, err := reader.Read()
+			require.NoError(t, err)
+			require.Equal(t, byte(4), fr.GetSystemID())
+			require.Equal(t, &MessageHeartbeat{Type: 1}, fr.GetMessage())
+			require.Equal(t, ca == "sign", fr.(*frame.V2Frame).IsSigned())
+		})
+	}
+}
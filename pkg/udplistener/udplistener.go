@@ -1,15 +1,21 @@
-// Package udplistener provides a UDP-based Listener.
+// Package udplistener provides a Listener that works with datagram-based
+// sockets, like UDP or Unix datagram sockets.
 package udplistener
 
 import (
 	"net"
 	"sync"
 	"time"
+
+	"github.com/aler9/gomavlib/pkg/mmsg"
 )
 
 // MTU is ~1500
 const bufferSize = 2048
 
+// maximum number of UDP datagrams read with a single system call.
+const readBatchSize = 16
+
 // implements net.Error
 type udpNetError struct {
 	str       string
@@ -33,29 +39,26 @@ var (
 	errTerminated net.Error = udpNetError{"terminated", false}
 )
 
-type connIndex struct {
-	IP   [4]byte
-	Port int
-}
-
 type conn struct {
 	listener      *Listener
-	index         connIndex
-	addr          *net.UDPAddr
+	index         string
+	addr          net.Addr
 	closed        bool
 	readDeadline  time.Time
 	writeDeadline time.Time
 
 	// in
-	read chan []byte
+	read    chan []byte
+	closing chan struct{}
 }
 
-func newConn(listener *Listener, index connIndex, addr *net.UDPAddr) *conn {
+func newConn(listener *Listener, index string, addr net.Addr) *conn {
 	return &conn{
 		listener: listener,
 		index:    index,
 		addr:     addr,
 		read:     make(chan []byte),
+		closing:  make(chan struct{}),
 	}
 }
 
@@ -80,10 +83,15 @@ func (c *conn) Close() error {
 	}
 
 	c.closed = true
-	delete(c.listener.conns, c.index)
 
-	// release anyone waiting on Read()
-	close(c.read)
+	// the connection may have been replaced by a new one
+	if c.listener.conns[c.index] == c {
+		delete(c.listener.conns, c.index)
+	}
+
+	// release anyone waiting on Read() and the reader, if it is
+	// routing a buffer to the connection
+	close(c.closing)
 
 	// close socket when both listener and connections are closed
 	if c.listener.closed && len(c.listener.conns) == 0 {
@@ -105,10 +113,16 @@ func (c *conn) Read(byt []byte) (int, error) {
 		select {
 		case <-readTimer.C:
 			return 0, errTimeout
-		case buf, ok = <-c.read:
+		case buf = <-c.read:
+			ok = true
+		case <-c.closing:
 		}
 	} else {
-		buf, ok = <-c.read
+		select {
+		case buf = <-c.read:
+			ok = true
+		case <-c.closing:
+		}
 	}
 
 	if !ok {
@@ -135,6 +149,35 @@ func (c *conn) Write(byt []byte) (int, error) {
 	return c.listener.pc.WriteTo(byt, c.addr)
 }
 
+// WriteBatch writes multiple datagrams and returns the number of datagrams
+// that have been written. UDP datagrams are written with a single system call,
+// where available.
+func (c *conn) WriteBatch(bufs [][]byte) (int, error) {
+	c.listener.writeMutex.Lock()
+	defer c.listener.writeMutex.Unlock()
+
+	if !c.writeDeadline.IsZero() {
+		err := c.listener.pc.SetWriteDeadline(c.writeDeadline)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if pc, ok := c.listener.pc.(*net.UDPConn); ok {
+		if addr, ok := c.addr.(*net.UDPAddr); ok {
+			return mmsg.WriteBatch(pc, addr, bufs)
+		}
+	}
+
+	for i, buf := range bufs {
+		_, err := c.listener.pc.WriteTo(buf, c.addr)
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(bufs), nil
+}
+
 // SetDeadline implements the net.Conn interface.
 func (c *conn) SetDeadline(time.Time) error {
 	// not implemented
@@ -153,19 +196,21 @@ func (c *conn) SetWriteDeadline(t time.Time) error {
 	return nil
 }
 
-// Listener is a UDP listener.
+// Listener is a datagram-based listener.
 type Listener struct {
 	pc         net.PacketConn
-	conns      map[connIndex]*conn
+	conns      map[string]*conn
 	readMutex  sync.Mutex
 	writeMutex sync.Mutex
 	closed     bool
 
-	accept   chan net.Conn
-	readDone chan struct{}
+	accept    chan net.Conn
+	readDone  chan struct{}
+	terminate chan struct{}
 }
 
 // New allocates a Listener.
+// Network can be "udp", "udp4", "udp6" or "unixgram".
 func New(network, address string) (net.Listener, error) {
 	pc, err := net.ListenPacket(network, address)
 	if err != nil {
@@ -173,10 +218,11 @@ func New(network, address string) (net.Listener, error) {
 	}
 
 	l := &Listener{
-		pc:       pc,
-		conns:    make(map[connIndex]*conn),
-		accept:   make(chan net.Conn),
-		readDone: make(chan struct{}),
+		pc:        pc,
+		conns:     make(map[string]*conn),
+		accept:    make(chan net.Conn),
+		readDone:  make(chan struct{}),
+		terminate: make(chan struct{}),
 	}
 
 	go l.reader()
@@ -196,7 +242,7 @@ func (l *Listener) Close() error {
 	l.closed = true
 
 	// release anyone waiting on Accept()
-	close(l.accept)
+	close(l.terminate)
 
 	// close socket when both listener and connections are closed
 	if len(l.conns) == 0 {
@@ -212,6 +258,11 @@ func (l *Listener) Addr() net.Addr {
 }
 
 func (l *Listener) reader() {
+	if pc, ok := l.pc.(*net.UDPConn); ok {
+		l.readerBatch(pc)
+		return
+	}
+
 	buf := make([]byte, bufferSize)
 
 	for {
@@ -222,42 +273,91 @@ func (l *Listener) reader() {
 			break
 		}
 
-		// use ip and port as connection index
-		uaddr := addr.(*net.UDPAddr)
-		connIndex := connIndex{}
-		connIndex.Port = uaddr.Port
-		copy(connIndex.IP[:], uaddr.IP)
+		// unnamed Unix sockets can't receive replies, ignore them.
+		// The address of a datagram sent by an unbound Unix socket is nil.
+		if addr == nil {
+			continue
+		}
+		if uaddr, ok := addr.(*net.UnixAddr); ok && (uaddr == nil || uaddr.Name == "") {
+			continue
+		}
 
-		func() {
-			l.readMutex.Lock()
-			defer l.readMutex.Unlock()
+		// use the address (ip and port in case of UDP, path in case of Unix sockets)
+		// as connection index
+		connIndex := addr.String()
+
+		l.route(connIndex, addr, buf[:n])
+	}
+}
+
+// readerBatch reads multiple UDP datagrams with a single system call,
+// where available.
+func (l *Listener) readerBatch(pc *net.UDPConn) {
+	msgs := make([]mmsg.Message, readBatchSize)
+	for i := range msgs {
+		msgs[i].Buf = make([]byte, bufferSize)
+	}
+
+	for {
+		// read WITHOUT deadline, like reader()
+		n, err := mmsg.ReadBatch(pc, msgs)
+		if err != nil {
+			break
+		}
 
-			conn, preExisting := l.conns[connIndex]
+		for _, m := range msgs[:n] {
+			l.route(m.Addr.String(), m.Addr, m.Buf[:m.N])
+		}
+	}
+}
 
-			if !preExisting && l.closed {
+// route routes a buffer to the connection associated with an address.
+// The mutex is not held while waiting for Accept() or Read(), since
+// Close() may be called in the meanwhile.
+func (l *Listener) route(connIndex string, addr net.Addr, buf []byte) {
+	for {
+		l.readMutex.Lock()
+		c, preExisting := l.conns[connIndex]
+		if !preExisting {
+			if l.closed {
 				// listener is closed, ignore new connection
-			} else {
-				if !preExisting {
-					conn = newConn(l, connIndex, uaddr)
-					l.conns[connIndex] = conn
-					l.accept <- conn
-				}
-
-				// route buffer to connection
-				conn.read <- buf[:n]
-
-				// wait copy since buffer is shared
-				<-l.readDone
+				l.readMutex.Unlock()
+				return
 			}
-		}()
+
+			c = newConn(l, connIndex, addr)
+			l.conns[connIndex] = c
+		}
+		l.readMutex.Unlock()
+
+		if !preExisting {
+			select {
+			case l.accept <- c:
+			case <-l.terminate:
+				c.Close()
+				return
+			}
+		}
+
+		select {
+		case c.read <- buf:
+			// wait copy since buffer is shared
+			<-l.readDone
+			return
+
+		case <-c.closing:
+			// the connection has been closed (i.e. after a read timeout):
+			// route buffer to a new connection
+		}
 	}
 }
 
 // Accept implements the net.Listener interface.
 func (l *Listener) Accept() (net.Conn, error) {
-	conn, ok := <-l.accept
-	if !ok {
+	select {
+	case c := <-l.accept:
+		return c, nil
+	case <-l.terminate:
 		return nil, errTerminated
 	}
-	return conn, nil
 }
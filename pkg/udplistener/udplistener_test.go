@@ -1,7 +1,10 @@
 package udplistener
 
 import (
+	"io/ioutil"
 	"net"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -128,3 +131,123 @@ func TestUdpListenerDoubleClose(t *testing.T) {
 	l.Close()
 	l.Close()
 }
+
+func TestUdpListenerCloseAfterTimeout(t *testing.T) {
+	l, err := New("udp4", "127.0.0.1:18456")
+	require.NoError(t, err)
+	defer l.Close()
+
+	client, err := net.Dial("udp4", "127.0.0.1:18456")
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.Write([]byte("a"))
+	require.NoError(t, err)
+
+	conn, err := l.Accept()
+	require.NoError(t, err)
+
+	buf := make([]byte, 1024)
+	_, err = conn.Read(buf)
+	require.NoError(t, err)
+
+	err = conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	require.NoError(t, err)
+	_, err = conn.Read(buf)
+	require.Error(t, err)
+
+	// a packet that is received after the timeout must not prevent Close(),
+	// and is routed to a new connection
+	_, err = client.Write([]byte("b"))
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	conn.Close()
+
+	conn, err = l.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, []byte("b"), buf[:n])
+
+	_, err = client.Write([]byte("c"))
+	require.NoError(t, err)
+
+	n, err = conn.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, []byte("c"), buf[:n])
+}
+
+func TestUdpListenerWriteBatch(t *testing.T) {
+	l, err := New("udp4", "127.0.0.1:18456")
+	require.NoError(t, err)
+	defer l.Close()
+
+	client, err := net.Dial("udp4", "127.0.0.1:18456")
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.Write([]byte{1})
+	require.NoError(t, err)
+
+	conn, err := l.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	bufs := [][]byte{{1, 2}, {3}, {4, 5, 6}}
+	n, err := conn.(interface {
+		WriteBatch(bufs [][]byte) (int, error)
+	}).WriteBatch(bufs)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+
+	err = client.SetReadDeadline(time.Now().Add(2 * time.Second))
+	require.NoError(t, err)
+
+	buf := make([]byte, 1024)
+	for _, expected := range bufs {
+		n, err := client.Read(buf)
+		require.NoError(t, err)
+		require.Equal(t, expected, buf[:n])
+	}
+}
+
+func TestUdpListenerUnixgramUnbound(t *testing.T) {
+	dir, err := ioutil.TempDir("", "udplistener")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "server.sock")
+
+	l, err := New("unixgram", path)
+	require.NoError(t, err)
+	defer l.Close()
+
+	// datagrams of unbound sockets are discarded
+	unbound, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	defer unbound.Close()
+
+	_, err = unbound.Write([]byte("unbound"))
+	require.NoError(t, err)
+
+	// the listener keeps serving
+	bound, err := net.DialUnix("unixgram",
+		&net.UnixAddr{Name: filepath.Join(dir, "client.sock"), Net: "unixgram"},
+		&net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	defer bound.Close()
+
+	_, err = bound.Write([]byte("bound"))
+	require.NoError(t, err)
+
+	conn, err := l.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	buf := make([]byte, 1024)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "bound", string(buf[:n]))
+}
@@ -0,0 +1,218 @@
+package vehicle
+
+import (
+	"fmt"
+)
+
+// MAV_AUTOPILOT values
+const (
+	autopilotArdupilot = 3  // MAV_AUTOPILOT_ARDUPILOTMEGA
+	autopilotPX4       = 12 // MAV_AUTOPILOT_PX4
+)
+
+type ardupilotKind int
+
+const (
+	ardupilotCopter ardupilotKind = iota
+	ardupilotPlane
+	ardupilotRover
+	ardupilotSub
+)
+
+// ardupilot firmware, given the vehicle type (MAV_TYPE).
+var ardupilotKinds = map[int]ardupilotKind{
+	1:  ardupilotPlane,  // MAV_TYPE_FIXED_WING
+	2:  ardupilotCopter, // MAV_TYPE_QUADROTOR
+	3:  ardupilotCopter, // MAV_TYPE_COAXIAL
+	4:  ardupilotCopter, // MAV_TYPE_HELICOPTER
+	10: ardupilotRover,  // MAV_TYPE_GROUND_ROVER
+	11: ardupilotRover,  // MAV_TYPE_SURFACE_BOAT
+	12: ardupilotSub,    // MAV_TYPE_SUBMARINE
+	13: ardupilotCopter, // MAV_TYPE_HEXAROTOR
+	14: ardupilotCopter, // MAV_TYPE_OCTOROTOR
+	15: ardupilotCopter, // MAV_TYPE_TRICOPTER
+	19: ardupilotPlane,  // MAV_TYPE_VTOL_DUOROTOR
+	20: ardupilotPlane,  // MAV_TYPE_VTOL_QUADROTOR
+	21: ardupilotPlane,  // MAV_TYPE_VTOL_TILTROTOR
+	22: ardupilotPlane,  // MAV_TYPE_VTOL_RESERVED2
+	23: ardupilotPlane,  // MAV_TYPE_VTOL_RESERVED3
+	24: ardupilotPlane,  // MAV_TYPE_VTOL_RESERVED4
+	25: ardupilotPlane,  // MAV_TYPE_VTOL_RESERVED5
+	29: ardupilotCopter, // MAV_TYPE_DODECAROTOR
+}
+
+// ardupilot modes, given the firmware.
+var ardupilotModes = map[ardupilotKind]map[string]uint32{
+	ardupilotCopter: {
+		"STABILIZE":    0,
+		"ACRO":         1,
+		"ALT_HOLD":     2,
+		"AUTO":         3,
+		"GUIDED":       4,
+		"LOITER":       5,
+		"RTL":          6,
+		"CIRCLE":       7,
+		"LAND":         9,
+		"DRIFT":        11,
+		"SPORT":        13,
+		"FLIP":         14,
+		"AUTOTUNE":     15,
+		"POSHOLD":      16,
+		"BRAKE":        17,
+		"THROW":        18,
+		"AVOID_ADSB":   19,
+		"GUIDED_NOGPS": 20,
+		"SMART_RTL":    21,
+		"FLOWHOLD":     22,
+		"FOLLOW":       23,
+		"ZIGZAG":       24,
+		"SYSTEMID":     25,
+		"AUTOROTATE":   26,
+		"AUTO_RTL":     27,
+	},
+	ardupilotPlane: {
+		"MANUAL":     0,
+		"CIRCLE":     1,
+		"STABILIZE":  2,
+		"TRAINING":   3,
+		"ACRO":       4,
+		"FBWA":       5,
+		"FBWB":       6,
+		"CRUISE":     7,
+		"AUTOTUNE":   8,
+		"AUTO":       10,
+		"RTL":        11,
+		"LOITER":     12,
+		"TAKEOFF":    13,
+		"AVOID_ADSB": 14,
+		"GUIDED":     15,
+		"QSTABILIZE": 17,
+		"QHOVER":     18,
+		"QLOITER":    19,
+		"QLAND":      20,
+		"QRTL":       21,
+		"QAUTOTUNE":  22,
+		"QACRO":      23,
+		"THERMAL":    24,
+	},
+	ardupilotRover: {
+		"MANUAL":    0,
+		"ACRO":      1,
+		"STEERING":  3,
+		"HOLD":      4,
+		"LOITER":    5,
+		"FOLLOW":    6,
+		"SIMPLE":    7,
+		"AUTO":      10,
+		"RTL":       11,
+		"SMART_RTL": 12,
+		"GUIDED":    15,
+	},
+	ardupilotSub: {
+		"STABILIZE": 0,
+		"ACRO":      1,
+		"ALT_HOLD":  2,
+		"AUTO":      3,
+		"GUIDED":    4,
+		"CIRCLE":    7,
+		"SURFACE":   9,
+		"POSHOLD":   16,
+		"MANUAL":    19,
+	},
+}
+
+type px4Mode struct {
+	main uint32
+	sub  uint32
+}
+
+func (m px4Mode) customMode() uint32 {
+	return m.main<<16 | m.sub<<24
+}
+
+// px4 modes, with the same names used by QGroundControl.
+var px4Modes = map[string]px4Mode{
+	"MANUAL":             {1, 0},
+	"ALTCTL":             {2, 0},
+	"POSCTL":             {3, 0},
+	"AUTO.READY":         {4, 1},
+	"AUTO.TAKEOFF":       {4, 2},
+	"AUTO.LOITER":        {4, 3},
+	"AUTO.MISSION":       {4, 4},
+	"AUTO.RTL":           {4, 5},
+	"AUTO.LAND":          {4, 6},
+	"AUTO.FOLLOW_TARGET": {4, 8},
+	"AUTO.PRECLAND":      {4, 9},
+	"ACRO":               {5, 0},
+	"OFFBOARD":           {6, 0},
+	"STABILIZED":         {7, 0},
+	"RATTITUDE":          {8, 0},
+}
+
+func ardupilotModeTable(vehicleType int) (map[string]uint32, error) {
+	kind, ok := ardupilotKinds[vehicleType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported vehicle type (%d)", vehicleType)
+	}
+	return ardupilotModes[kind], nil
+}
+
+// CustomMode returns the custom mode (the custom_mode field of HEARTBEAT)
+// that corresponds to a mode name, given the autopilot (MAV_AUTOPILOT)
+// and the vehicle type (MAV_TYPE). Ardupilot and PX4 are supported.
+// Ardupilot modes are named like in the firmware (i.e. "GUIDED"),
+// PX4 modes are named like in QGroundControl (i.e. "AUTO.MISSION").
+func CustomMode(autopilot int, vehicleType int, name string) (uint32, error) {
+	switch autopilot {
+	case autopilotArdupilot:
+		modes, err := ardupilotModeTable(vehicleType)
+		if err != nil {
+			return 0, err
+		}
+
+		if cm, ok := modes[name]; ok {
+			return cm, nil
+		}
+
+	case autopilotPX4:
+		if m, ok := px4Modes[name]; ok {
+			return m.customMode(), nil
+		}
+
+	default:
+		return 0, fmt.Errorf("unsupported autopilot (%d)", autopilot)
+	}
+
+	return 0, fmt.Errorf("unknown mode '%s'", name)
+}
+
+// ModeName returns the name of a custom mode (the custom_mode field of
+// HEARTBEAT), given the autopilot (MAV_AUTOPILOT) and the vehicle type
+// (MAV_TYPE). It is the inverse of CustomMode().
+func ModeName(autopilot int, vehicleType int, customMode uint32) (string, error) {
+	switch autopilot {
+	case autopilotArdupilot:
+		modes, err := ardupilotModeTable(vehicleType)
+		if err != nil {
+			return "", err
+		}
+
+		for name, cm := range modes {
+			if cm == customMode {
+				return name, nil
+			}
+		}
+
+	case autopilotPX4:
+		for name, m := range px4Modes {
+			if m.customMode() == customMode {
+				return name, nil
+			}
+		}
+
+	default:
+		return "", fmt.Errorf("unsupported autopilot (%d)", autopilot)
+	}
+
+	return "", fmt.Errorf("unknown custom mode (%d)", customMode)
+}
@@ -0,0 +1,48 @@
+package vehicle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomMode(t *testing.T) {
+	for _, ca := range []struct {
+		name        string
+		autopilot   int
+		vehicleType int
+		mode        string
+		customMode  uint32
+	}{
+		{"ardupilot copter", 3, 2, "GUIDED", 4},
+		{"ardupilot plane", 3, 1, "GUIDED", 15},
+		{"ardupilot rover", 3, 10, "HOLD", 4},
+		{"ardupilot sub", 3, 12, "SURFACE", 9},
+		{"px4 manual", 12, 2, "POSCTL", 3 << 16},
+		{"px4 auto", 12, 2, "AUTO.MISSION", 4<<16 | 4<<24},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			cm, err := CustomMode(ca.autopilot, ca.vehicleType, ca.mode)
+			require.NoError(t, err)
+			require.Equal(t, ca.customMode, cm)
+
+			name, err := ModeName(ca.autopilot, ca.vehicleType, ca.customMode)
+			require.NoError(t, err)
+			require.Equal(t, ca.mode, name)
+		})
+	}
+}
+
+func TestCustomModeErrors(t *testing.T) {
+	_, err := CustomMode(8, 2, "GUIDED")
+	require.EqualError(t, err, "unsupported autopilot (8)")
+
+	_, err = CustomMode(3, 6, "GUIDED")
+	require.EqualError(t, err, "unsupported vehicle type (6)")
+
+	_, err = CustomMode(12, 2, "GUIDED")
+	require.EqualError(t, err, "unknown mode 'GUIDED'")
+
+	_, err = ModeName(3, 2, 1000)
+	require.EqualError(t, err, "unknown custom mode (1000)")
+}
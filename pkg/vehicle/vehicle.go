@@ -0,0 +1,174 @@
+// Package vehicle contains a high-level interface to control vehicles
+// (arm, disarm, change mode, take off, land, return to launch), that works
+// with Ardupilot and PX4.
+package vehicle
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/aler9/gomavlib"
+)
+
+// MAV_CMD values
+const (
+	commandNavReturnToLaunch = 20  // MAV_CMD_NAV_RETURN_TO_LAUNCH
+	commandNavLand           = 21  // MAV_CMD_NAV_LAND
+	commandNavTakeoff        = 22  // MAV_CMD_NAV_TAKEOFF
+	commandDoSetMode         = 176 // MAV_CMD_DO_SET_MODE
+	commandArmDisarm         = 400 // MAV_CMD_COMPONENT_ARM_DISARM
+)
+
+const (
+	resultAccepted            = 0 // MAV_RESULT_ACCEPTED
+	modeFlagCustomModeEnabled = 1 // MAV_MODE_FLAG_CUSTOM_MODE_ENABLED
+	paramTypeReal32           = 9 // MAV_PARAM_TYPE_REAL32
+)
+
+var nan = float32(math.NaN())
+
+// Vehicle is a vehicle controlled through a Node.
+// The dialect of the node must contain the command messages, and Events()
+// must be read in a separate routine, otherwise responses of the vehicle
+// can't be received.
+type Vehicle struct {
+	// the node used to communicate with the vehicle.
+	Node *gomavlib.Node
+
+	// (optional) the channel used to communicate with the vehicle.
+	// It defaults to all channels.
+	Channel *gomavlib.Channel
+
+	// the system id of the vehicle.
+	SystemID byte
+
+	// (optional) the component id of the autopilot.
+	// It defaults to 1 (MAV_COMP_ID_AUTOPILOT1).
+	ComponentID byte
+}
+
+func (v *Vehicle) componentID() byte {
+	if v.ComponentID == 0 {
+		return 1
+	}
+	return v.ComponentID
+}
+
+// system returns the autopilot type (MAV_AUTOPILOT) and the vehicle type
+// (MAV_TYPE), as reported by heartbeats.
+func (v *Vehicle) system() (int, int, error) {
+	for _, sys := range v.Node.Systems() {
+		if sys.SystemID == v.SystemID && sys.ComponentID == v.componentID() &&
+			!sys.LastHeartbeat.IsZero() {
+			return sys.Autopilot, sys.Type, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("no heartbeat has been received from the vehicle")
+}
+
+func (v *Vehicle) command(ctx context.Context, cmd int, params [7]float32) error {
+	ack, err := v.Node.SendCommand(ctx, &gomavlib.CommandRequest{
+		Channel:         v.Channel,
+		TargetSystem:    v.SystemID,
+		TargetComponent: v.componentID(),
+		Command:         cmd,
+		Params:          params,
+	})
+	if err != nil {
+		return err
+	}
+
+	if ack.Result != resultAccepted {
+		return fmt.Errorf("command rejected (result %d)", ack.Result)
+	}
+
+	return nil
+}
+
+// Arm arms the vehicle.
+func (v *Vehicle) Arm(ctx context.Context) error {
+	return v.command(ctx, commandArmDisarm, [7]float32{1})
+}
+
+// Disarm disarms the vehicle.
+func (v *Vehicle) Disarm(ctx context.Context) error {
+	return v.command(ctx, commandArmDisarm, [7]float32{0})
+}
+
+// SetMode sets the mode of the vehicle.
+// See CustomMode() for details about names.
+func (v *Vehicle) SetMode(ctx context.Context, name string) error {
+	autopilot, vehicleType, err := v.system()
+	if err != nil {
+		return err
+	}
+
+	cm, err := CustomMode(autopilot, vehicleType, name)
+	if err != nil {
+		return err
+	}
+
+	params := [7]float32{modeFlagCustomModeEnabled}
+
+	// PX4 expects main mode and sub mode in separate parameters
+	if autopilot == autopilotPX4 {
+		params[1] = float32((cm >> 16) & 0xFF)
+		params[2] = float32((cm >> 24) & 0xFF)
+	} else {
+		params[1] = float32(cm)
+	}
+
+	return v.command(ctx, commandDoSetMode, params)
+}
+
+// Takeoff takes off up to an altitude relative to the takeoff position,
+// in meters. The vehicle must be armed.
+// With Ardupilot, the vehicle is switched to GUIDED mode.
+// With PX4, the MIS_TAKEOFF_ALT parameter is set to the altitude.
+func (v *Vehicle) Takeoff(ctx context.Context, altitude float32) error {
+	autopilot, _, err := v.system()
+	if err != nil {
+		return err
+	}
+
+	switch autopilot {
+	case autopilotArdupilot:
+		err := v.SetMode(ctx, "GUIDED")
+		if err != nil {
+			return err
+		}
+
+		return v.command(ctx, commandNavTakeoff, [7]float32{0, 0, 0, nan, 0, 0, altitude})
+
+	case autopilotPX4:
+		// in PX4, the altitude of the command is absolute, while
+		// MIS_TAKEOFF_ALT is relative, therefore the latter is used
+		_, err := v.Node.WriteParam(ctx, &gomavlib.ParamTransfer{
+			Channel:         v.Channel,
+			TargetSystem:    v.SystemID,
+			TargetComponent: v.componentID(),
+		}, &gomavlib.Param{
+			ID:    "MIS_TAKEOFF_ALT",
+			Type:  paramTypeReal32,
+			Value: float64(altitude),
+		})
+		if err != nil {
+			return err
+		}
+
+		return v.command(ctx, commandNavTakeoff, [7]float32{nan, 0, 0, nan, nan, nan, nan})
+	}
+
+	return v.command(ctx, commandNavTakeoff, [7]float32{0, 0, 0, nan, 0, 0, altitude})
+}
+
+// Land lands the vehicle at the current position.
+func (v *Vehicle) Land(ctx context.Context) error {
+	return v.command(ctx, commandNavLand, [7]float32{0, 0, 0, nan, nan, nan, 0})
+}
+
+// ReturnToLaunch returns the vehicle to the launch position.
+func (v *Vehicle) ReturnToLaunch(ctx context.Context) error {
+	return v.command(ctx, commandNavReturnToLaunch, [7]float32{})
+}
@@ -0,0 +1,162 @@
+package vehicle
+
+import (
+	"context"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib"
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+)
+
+// newTestVehicle creates a ground station and a vehicle that acknowledges
+// commands and parameter writes, and returns the received commands.
+func newTestVehicle(t *testing.T, autopilot common.MAV_AUTOPILOT) (*gomavlib.Node, *gomavlib.Node,
+	chan *common.MessageCommandLong) {
+	c1, c2 := net.Pipe()
+
+	gcs, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:          common.Dialect,
+		OutVersion:       gomavlib.V2,
+		OutSystemID:      255,
+		Endpoints:        []gomavlib.EndpointConf{gomavlib.EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+
+	vehicle, err := gomavlib.NewNode(gomavlib.NodeConf{
+		Dialect:                common.Dialect,
+		OutVersion:             gomavlib.V2,
+		OutSystemID:            1,
+		Endpoints:              []gomavlib.EndpointConf{gomavlib.EndpointCustom{c2}},
+		HeartbeatSystemType:    int(common.MAV_TYPE_QUADROTOR),
+		HeartbeatAutopilotType: int(autopilot),
+		HeartbeatPeriod:        50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	commands := make(chan *common.MessageCommandLong, 10)
+
+	go func() {
+		for evt := range vehicle.Events() {
+			frm, ok := evt.(*gomavlib.EventFrame)
+			if !ok {
+				continue
+			}
+
+			switch m := frm.Message().(type) {
+			case *common.MessageCommandLong:
+				commands <- m
+				vehicle.WriteMessageTo(frm.Channel, &common.MessageCommandAck{
+					Command:         m.Command,
+					Result:          common.MAV_RESULT_ACCEPTED,
+					TargetSystem:    frm.SystemID(),
+					TargetComponent: frm.ComponentID(),
+				})
+
+			case *common.MessageParamSet:
+				vehicle.WriteMessageTo(frm.Channel, &common.MessageParamValue{
+					ParamId:    m.ParamId,
+					ParamValue: m.ParamValue,
+					ParamType:  m.ParamType,
+					ParamCount: 1,
+				})
+			}
+		}
+	}()
+
+	// wait for a heartbeat
+	for {
+		evt := <-gcs.Events()
+		if frm, ok := evt.(*gomavlib.EventFrame); ok {
+			if _, ok := frm.Message().(*common.MessageHeartbeat); ok {
+				break
+			}
+		}
+	}
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	return gcs, vehicle, commands
+}
+
+func TestVehicleArdupilot(t *testing.T) {
+	gcs, vehicle, commands := newTestVehicle(t, common.MAV_AUTOPILOT_ARDUPILOTMEGA)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	v := &Vehicle{Node: gcs, SystemID: 1}
+	ctx := context.Background()
+
+	err := v.Arm(ctx)
+	require.NoError(t, err)
+	cmd := <-commands
+	require.Equal(t, common.MAV_CMD_COMPONENT_ARM_DISARM, cmd.Command)
+	require.Equal(t, float32(1), cmd.Param1)
+
+	err = v.Takeoff(ctx, 10)
+	require.NoError(t, err)
+	cmd = <-commands
+	require.Equal(t, common.MAV_CMD_DO_SET_MODE, cmd.Command)
+	require.Equal(t, float32(4), cmd.Param2) // GUIDED
+	cmd = <-commands
+	require.Equal(t, common.MAV_CMD_NAV_TAKEOFF, cmd.Command)
+	require.Equal(t, float32(10), cmd.Param7)
+
+	err = v.ReturnToLaunch(ctx)
+	require.NoError(t, err)
+	cmd = <-commands
+	require.Equal(t, common.MAV_CMD_NAV_RETURN_TO_LAUNCH, cmd.Command)
+
+	err = v.Land(ctx)
+	require.NoError(t, err)
+	cmd = <-commands
+	require.Equal(t, common.MAV_CMD_NAV_LAND, cmd.Command)
+
+	err = v.Disarm(ctx)
+	require.NoError(t, err)
+	cmd = <-commands
+	require.Equal(t, common.MAV_CMD_COMPONENT_ARM_DISARM, cmd.Command)
+	require.Equal(t, float32(0), cmd.Param1)
+}
+
+func TestVehiclePX4(t *testing.T) {
+	gcs, vehicle, commands := newTestVehicle(t, common.MAV_AUTOPILOT_PX4)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	v := &Vehicle{Node: gcs, SystemID: 1}
+	ctx := context.Background()
+
+	err := v.SetMode(ctx, "AUTO.LOITER")
+	require.NoError(t, err)
+	cmd := <-commands
+	require.Equal(t, common.MAV_CMD_DO_SET_MODE, cmd.Command)
+	require.Equal(t, float32(1), cmd.Param1)
+	require.Equal(t, float32(4), cmd.Param2)
+	require.Equal(t, float32(3), cmd.Param3)
+
+	err = v.Takeoff(ctx, 15)
+	require.NoError(t, err)
+	cmd = <-commands
+	require.Equal(t, common.MAV_CMD_NAV_TAKEOFF, cmd.Command)
+	require.True(t, math.IsNaN(float64(cmd.Param7)))
+}
+
+func TestVehicleNoHeartbeat(t *testing.T) {
+	gcs, vehicle, _ := newTestVehicle(t, common.MAV_AUTOPILOT_PX4)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	v := &Vehicle{Node: gcs, SystemID: 2}
+
+	err := v.SetMode(context.Background(), "POSCTL")
+	require.EqualError(t, err, "no heartbeat has been received from the vehicle")
+}
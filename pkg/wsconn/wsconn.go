@@ -0,0 +1,205 @@
+// Package wsconn allows to use WebSocket connections as net.Conn and net.Listener.
+package wsconn
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Conn wraps a WebSocket connection into a net.Conn.
+// Every Write() is sent as a separate binary message, while Read() returns
+// the content of incoming binary messages. Text messages are discarded.
+type Conn struct {
+	wc     *websocket.Conn
+	reader io.Reader
+}
+
+// New allocates a Conn.
+func New(wc *websocket.Conn) *Conn {
+	return &Conn{
+		wc: wc,
+	}
+}
+
+// Close implements the net.Conn interface.
+func (c *Conn) Close() error {
+	return c.wc.Close()
+}
+
+// Read implements the net.Conn interface.
+func (c *Conn) Read(buf []byte) (int, error) {
+	for {
+		if c.reader == nil {
+			typ, r, err := c.wc.NextReader()
+			if err != nil {
+				return 0, err
+			}
+
+			if typ != websocket.BinaryMessage {
+				continue
+			}
+
+			c.reader = r
+		}
+
+		n, err := c.reader.Read(buf)
+		if err == io.EOF {
+			c.reader = nil
+			if n == 0 {
+				continue
+			}
+			return n, nil
+		}
+		return n, err
+	}
+}
+
+// Write implements the net.Conn interface.
+func (c *Conn) Write(buf []byte) (int, error) {
+	err := c.wc.WriteMessage(websocket.BinaryMessage, buf)
+	if err != nil {
+		return 0, err
+	}
+	return len(buf), nil
+}
+
+// LocalAddr implements the net.Conn interface.
+func (c *Conn) LocalAddr() net.Addr {
+	return c.wc.LocalAddr()
+}
+
+// RemoteAddr implements the net.Conn interface.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.wc.RemoteAddr()
+}
+
+// SetDeadline implements the net.Conn interface.
+func (c *Conn) SetDeadline(t time.Time) error {
+	err := c.wc.SetReadDeadline(t)
+	if err != nil {
+		return err
+	}
+	return c.wc.SetWriteDeadline(t)
+}
+
+// SetReadDeadline implements the net.Conn interface.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.wc.SetReadDeadline(t)
+}
+
+// SetWriteDeadline implements the net.Conn interface.
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	return c.wc.SetWriteDeadline(t)
+}
+
+// Dial connects to a WebSocket server.
+// The URL must be in format ws://host:port/path or wss://host:port/path.
+func Dial(url string, timeout time.Duration, tlsConfig *tls.Config) (*Conn, error) {
+	dialer := &websocket.Dialer{
+		HandshakeTimeout: timeout,
+		TLSClientConfig:  tlsConfig,
+	}
+
+	wc, res, err := dialer.Dial(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res.Body.Close()
+
+	return New(wc), nil
+}
+
+// Listener is a WebSocket listener.
+type Listener struct {
+	ln        net.Listener
+	s         *http.Server
+	closeOnce sync.Once
+
+	// in
+	terminate chan struct{}
+
+	// out
+	accept chan net.Conn
+}
+
+// Listen allocates a Listener, that accepts WebSocket connections
+// on the given address and path.
+func Listen(address string, path string, tlsConfig *tls.Config) (*Listener, error) {
+	ln, err := net.Listen("tcp4", address)
+	if err != nil {
+		return nil, err
+	}
+
+	if tlsConfig != nil {
+		ln = tls.NewListener(ln, tlsConfig)
+	}
+
+	l := &Listener{
+		ln:        ln,
+		terminate: make(chan struct{}),
+		accept:    make(chan net.Conn),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, l.handle)
+
+	l.s = &http.Server{
+		Handler: mux,
+	}
+
+	go l.s.Serve(ln)
+
+	return l, nil
+}
+
+func (l *Listener) handle(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{
+		// connections are allowed from any origin, in order to allow
+		// browser-based clients hosted elsewhere.
+		CheckOrigin: func(*http.Request) bool {
+			return true
+		},
+	}
+
+	wc, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	select {
+	case l.accept <- New(wc):
+	case <-l.terminate:
+		wc.Close()
+	}
+}
+
+// Close implements the net.Listener interface.
+func (l *Listener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.terminate)
+		l.s.Close()
+	})
+	return nil
+}
+
+// Addr implements the net.Listener interface.
+func (l *Listener) Addr() net.Addr {
+	return l.ln.Addr()
+}
+
+// Accept implements the net.Listener interface.
+func (l *Listener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.accept:
+		return conn, nil
+	case <-l.terminate:
+		return nil, fmt.Errorf("terminated")
+	}
+}
@@ -0,0 +1,67 @@
+package wsconn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConn(t *testing.T) {
+	testBuf1 := []byte("testing testing 1 2 3")
+	testBuf2 := []byte("second part")
+
+	l, err := Listen("127.0.0.1:18456", "/mavlink", nil)
+	require.NoError(t, err)
+	defer l.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		conn, err := l.Accept()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		buf := make([]byte, 1024)
+		n, err := conn.Read(buf)
+		require.NoError(t, err)
+		require.Equal(t, testBuf1, buf[:n])
+
+		_, err = conn.Write(testBuf2)
+		require.NoError(t, err)
+	}()
+
+	conn, err := Dial("ws://127.0.0.1:18456/mavlink", 5*time.Second, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write(testBuf1)
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	var recv []byte
+	for len(recv) < len(testBuf2) {
+		n, err := conn.Read(buf)
+		require.NoError(t, err)
+		recv = append(recv, buf[:n]...)
+	}
+	require.Equal(t, testBuf2, recv)
+
+	<-done
+}
+
+func TestListenerClose(t *testing.T) {
+	l, err := Listen("127.0.0.1:18456", "/", nil)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := l.Accept()
+		require.Error(t, err)
+	}()
+
+	l.Close()
+	<-done
+}
@@ -42,7 +42,7 @@ func (x *X25) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
-// Sum16 returns the curren thash.
+// Sum16 returns the current hash.
 func (x *X25) Sum16() uint16 {
 	return x.crc
 }
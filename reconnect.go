@@ -0,0 +1,114 @@
+package gomavlib
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReconnectPolicy is the strategy used by endpoints that connect to remote
+// devices (TCP, UDP, TLS, WebSocket and Unix clients, serial ports in auto
+// mode) to reconnect after a connection attempt has failed.
+type ReconnectPolicy struct {
+	// (optional) the delay before the first reconnection attempt.
+	// It defaults to 2 seconds.
+	InitialDelay time.Duration
+
+	// (optional) the factor by which the delay is multiplied after every
+	// failed attempt. It defaults to 1, that means that the delay is constant.
+	Multiplier float64
+
+	// (optional) the maximum delay between two attempts.
+	// It defaults to zero, that means no maximum.
+	MaxDelay time.Duration
+
+	// (optional) the maximum number of consecutive failed attempts, after
+	// which the endpoint stops reconnecting and its channel is closed.
+	// It defaults to zero, that means no maximum.
+	MaxAttempts int
+}
+
+func (p ReconnectPolicy) validate() error {
+	if p.InitialDelay < 0 {
+		return fmt.Errorf("InitialDelay must be positive")
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		return fmt.Errorf("Multiplier must be greater or equal than 1")
+	}
+	if p.MaxDelay < 0 {
+		return fmt.Errorf("MaxDelay must be positive")
+	}
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("MaxAttempts must be positive")
+	}
+	return nil
+}
+
+// reconnecter computes the delays of reconnection attempts.
+type reconnecter struct {
+	policy   ReconnectPolicy
+	attempts int
+	delay    time.Duration
+}
+
+func newReconnecter(policy ReconnectPolicy, defaultDelay time.Duration) *reconnecter {
+	if policy.InitialDelay == 0 {
+		policy.InitialDelay = defaultDelay
+	}
+	if policy.Multiplier == 0 {
+		policy.Multiplier = 1
+	}
+	return &reconnecter{
+		policy: policy,
+	}
+}
+
+// next is called after a failed attempt and returns the delay before the
+// next one, or false if attempts are exhausted.
+func (r *reconnecter) next() (time.Duration, bool) {
+	r.attempts++
+	if r.policy.MaxAttempts != 0 && r.attempts >= r.policy.MaxAttempts {
+		return 0, false
+	}
+
+	if r.attempts == 1 {
+		r.delay = r.policy.InitialDelay
+	} else {
+		r.delay = time.Duration(float64(r.delay) * r.policy.Multiplier)
+	}
+
+	if r.policy.MaxDelay != 0 && r.delay > r.policy.MaxDelay {
+		r.delay = r.policy.MaxDelay
+	}
+
+	return r.delay, true
+}
+
+// reset is called after a successful attempt.
+func (r *reconnecter) reset() {
+	r.attempts = 0
+}
+
+// wait reports a failed attempt and waits before the next one.
+// It returns false if terminate is closed or attempts are exhausted.
+func (r *reconnecter) wait(err error, events chan *EventReconnect, terminate chan struct{}) bool {
+	delay, ok := r.next()
+	if !ok {
+		return false
+	}
+
+	select {
+	case events <- &EventReconnect{Attempt: r.attempts, Delay: delay, Error: err}:
+	case <-terminate:
+		return false
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-terminate:
+		return false
+	}
+}
@@ -0,0 +1,347 @@
+package gomavlib
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	routerConfDefaultTCPServerPort = 5760
+)
+
+type routerConfSection struct {
+	typ    string
+	name   string
+	values map[string]string
+}
+
+func (s *routerConfSection) label() string {
+	if s.name != "" {
+		return s.typ + " " + s.name
+	}
+	return s.typ
+}
+
+func (s *routerConfSection) required(key string) (string, error) {
+	v, ok := s.values[strings.ToLower(key)]
+	if !ok || v == "" {
+		return "", fmt.Errorf("[%s]: %s is missing", s.label(), key)
+	}
+	return v, nil
+}
+
+func (s *routerConfSection) address() (string, error) {
+	addr, err := s.required("Address")
+	if err != nil {
+		return "", err
+	}
+
+	port, err := s.required("Port")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return "", fmt.Errorf("[%s]: invalid port '%s'", s.label(), port)
+	}
+
+	return net.JoinHostPort(addr, port), nil
+}
+
+func (s *routerConfSection) bool(key string) (bool, error) {
+	v, ok := s.values[strings.ToLower(key)]
+	if !ok {
+		return false, nil
+	}
+
+	switch strings.ToLower(v) {
+	case "true", "1", "yes", "on":
+		return true, nil
+
+	case "false", "0", "no", "off", "":
+		return false, nil
+	}
+
+	return false, fmt.Errorf("[%s]: invalid %s '%s'", s.label(), key, v)
+}
+
+func (s *routerConfSection) ids(key string, max uint64) ([]uint32, error) {
+	v, ok := s.values[strings.ToLower(key)]
+	if !ok || v == "" {
+		return nil, nil
+	}
+
+	var ret []uint32
+	for _, part := range strings.Split(v, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil || id > max {
+			return nil, fmt.Errorf("[%s]: invalid %s '%s'", s.label(), key, v)
+		}
+		ret = append(ret, uint32(id))
+	}
+
+	return ret, nil
+}
+
+func (s *routerConfSection) byteIDs(key string) ([]byte, error) {
+	ids, err := s.ids(key, 255)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []byte
+	for _, id := range ids {
+		ret = append(ret, byte(id))
+	}
+	return ret, nil
+}
+
+// filter wraps an endpoint configuration with the filters of the section.
+func (s *routerConfSection) filter(conf EndpointConf) (EndpointConf, error) {
+	// filters on outgoing frames can't be honored, since they are not
+	// supported by EndpointFilter.
+	for _, key := range []string{
+		"allowmsgidout", "blockmsgidout",
+		"allowsrccompout", "blocksrccompout",
+		"allowsrcsysout", "blocksrcsysout",
+	} {
+		if v, ok := s.values[strings.ToLower(key)]; ok && v != "" {
+			return nil, fmt.Errorf("[%s]: filters on outgoing messages are not supported", s.label())
+		}
+	}
+
+	f := EndpointFilter{EndpointConf: conf}
+	var err error
+
+	f.AllowMessageIDs, err = s.ids("AllowMsgIdIn", 0xFFFFFF)
+	if err != nil {
+		return nil, err
+	}
+
+	f.DenyMessageIDs, err = s.ids("BlockMsgIdIn", 0xFFFFFF)
+	if err != nil {
+		return nil, err
+	}
+
+	f.AllowSystemIDs, err = s.byteIDs("AllowSrcSysIn")
+	if err != nil {
+		return nil, err
+	}
+
+	f.DenySystemIDs, err = s.byteIDs("BlockSrcSysIn")
+	if err != nil {
+		return nil, err
+	}
+
+	f.AllowComponentIDs, err = s.byteIDs("AllowSrcCompIn")
+	if err != nil {
+		return nil, err
+	}
+
+	f.DenyComponentIDs, err = s.byteIDs("BlockSrcCompIn")
+	if err != nil {
+		return nil, err
+	}
+
+	if f.AllowMessageIDs == nil && f.DenyMessageIDs == nil &&
+		f.AllowSystemIDs == nil && f.DenySystemIDs == nil &&
+		f.AllowComponentIDs == nil && f.DenyComponentIDs == nil {
+		return conf, nil
+	}
+
+	return f, nil
+}
+
+func (s *routerConfSection) uartEndpoint() (EndpointConf, error) {
+	device, err := s.required("Device")
+	if err != nil {
+		return nil, err
+	}
+
+	conf := EndpointSerial{}
+
+	// multiple baud rates are tried in sequence
+	if v, ok := s.values["baud"]; ok && v != "" {
+		for _, part := range strings.Split(v, ",") {
+			baud, err := strconv.ParseUint(strings.TrimSpace(part), 10, 31)
+			if err != nil || baud == 0 {
+				return nil, fmt.Errorf("[%s]: invalid baud '%s'", s.label(), v)
+			}
+			conf.AutoBaudRates = append(conf.AutoBaudRates, int(baud))
+		}
+	} else {
+		conf.AutoBaudRates = []int{115200}
+	}
+
+	if len(conf.AutoBaudRates) == 1 {
+		conf.Address = device + ":" + strconv.FormatInt(int64(conf.AutoBaudRates[0]), 10)
+		conf.AutoBaudRates = nil
+	} else {
+		conf.Address = device
+		conf.AutoBaud = true
+	}
+
+	conf.RTSCTS, err = s.bool("FlowControl")
+	if err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+func (s *routerConfSection) udpEndpoint() (EndpointConf, error) {
+	mode, err := s.required("Mode")
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := s.address()
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(mode) {
+	case "normal", "client":
+		return EndpointUDPClient{Address: address}, nil
+
+	case "eavesdropping", "server":
+		return EndpointUDPServer{Address: address}, nil
+	}
+
+	return nil, fmt.Errorf("[%s]: invalid mode '%s'", s.label(), mode)
+}
+
+func (s *routerConfSection) tcpEndpoint() (EndpointConf, error) {
+	address, err := s.address()
+	if err != nil {
+		return nil, err
+	}
+
+	return EndpointTCPClient{Address: address}, nil
+}
+
+func parseRouterConf(r io.Reader) ([]*routerConfSection, error) {
+	var sections []*routerConfSection
+	var cur *routerConfSection
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, fmt.Errorf("line %d: invalid section", n)
+			}
+
+			fields := strings.Fields(line[1 : len(line)-1])
+			if len(fields) == 0 || len(fields) > 2 {
+				return nil, fmt.Errorf("line %d: invalid section", n)
+			}
+
+			cur = &routerConfSection{
+				typ:    fields[0],
+				values: make(map[string]string),
+			}
+			if len(fields) == 2 {
+				cur.name = fields[1]
+			}
+			sections = append(sections, cur)
+			continue
+		}
+
+		if cur == nil {
+			return nil, fmt.Errorf("line %d: value outside of a section", n)
+		}
+
+		i := strings.IndexByte(line, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("line %d: invalid value", n)
+		}
+
+		cur.values[strings.ToLower(strings.TrimSpace(line[:i]))] = strings.TrimSpace(line[i+1:])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return sections, nil
+}
+
+// ReadMavlinkRouterConf reads a configuration file of mavlink-routerd and
+// returns a NodeConf with the router mode enabled and the corresponding
+// endpoints: UartEndpoint, UdpEndpoint and TcpEndpoint sections and the
+// TCP server enabled by the TcpServerPort key of the General section.
+// Filters on incoming messages are converted into EndpointFilter, while
+// filters on outgoing messages are not supported and produce an error.
+// Other keys are ignored. Dialect, OutVersion and OutSystemID must be filled
+// by the caller before passing the configuration to NewNode().
+func ReadMavlinkRouterConf(r io.Reader) (*NodeConf, error) {
+	sections, err := parseRouterConf(r)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := &NodeConf{
+		RouterEnable:     true,
+		HeartbeatDisable: true,
+	}
+
+	tcpServerPort := uint64(routerConfDefaultTCPServerPort)
+
+	for _, s := range sections {
+		var ec EndpointConf
+
+		switch strings.ToLower(s.typ) {
+		case "general":
+			if v, ok := s.values["tcpserverport"]; ok && v != "" {
+				tcpServerPort, err = strconv.ParseUint(v, 10, 16)
+				if err != nil {
+					return nil, fmt.Errorf("[%s]: invalid TcpServerPort '%s'", s.label(), v)
+				}
+			}
+			continue
+
+		case "uartendpoint":
+			ec, err = s.uartEndpoint()
+
+		case "udpendpoint":
+			ec, err = s.udpEndpoint()
+
+		case "tcpendpoint":
+			ec, err = s.tcpEndpoint()
+
+		default:
+			return nil, fmt.Errorf("[%s]: unsupported section", s.label())
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		ec, err = s.filter(ec)
+		if err != nil {
+			return nil, err
+		}
+
+		conf.Endpoints = append(conf.Endpoints, ec)
+	}
+
+	// a port equal to zero disables the server
+	if tcpServerPort != 0 {
+		conf.Endpoints = append(conf.Endpoints, EndpointTCPServer{
+			Address: ":" + strconv.FormatUint(tcpServerPort, 10),
+		})
+	}
+
+	return conf, nil
+}
@@ -0,0 +1,101 @@
+package gomavlib
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadMavlinkRouterConf(t *testing.T) {
+	conf, err := ReadMavlinkRouterConf(strings.NewReader(`
+# comment
+[General]
+TcpServerPort = 5790
+ReportStats = false
+
+[UartEndpoint alpha]
+Device = /dev/ttyS0
+Baud = 57600, 115200
+FlowControl = true
+
+[UartEndpoint bravo]
+Device = /dev/ttyUSB0
+
+[UdpEndpoint charlie]
+Mode = Eavesdropping
+Address = 0.0.0.0
+Port = 14550
+BlockMsgIdIn = 76, 11
+
+[UdpEndpoint delta]
+Mode = Normal
+Address = ::1
+Port = 14560
+
+[TcpEndpoint echo]
+Address = 127.0.0.1
+Port = 25760
+RetryTimeout = 10
+`))
+	require.NoError(t, err)
+	require.Equal(t, &NodeConf{
+		RouterEnable:     true,
+		HeartbeatDisable: true,
+		Endpoints: []EndpointConf{
+			EndpointSerial{
+				Address:       "/dev/ttyS0",
+				AutoBaud:      true,
+				AutoBaudRates: []int{57600, 115200},
+				RTSCTS:        true,
+			},
+			EndpointSerial{Address: "/dev/ttyUSB0:115200"},
+			EndpointFilter{
+				EndpointConf:   EndpointUDPServer{Address: "0.0.0.0:14550"},
+				DenyMessageIDs: []uint32{76, 11},
+			},
+			EndpointUDPClient{Address: "[::1]:14560"},
+			EndpointTCPClient{Address: "127.0.0.1:25760"},
+			EndpointTCPServer{Address: ":5790"},
+		},
+	}, conf)
+}
+
+func TestReadMavlinkRouterConfErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		conf string
+		err  string
+	}{
+		{
+			"value outside section",
+			"Device = /dev/ttyS0\n",
+			"line 1: value outside of a section",
+		},
+		{
+			"unsupported section",
+			"[LogEndpoint a]\n",
+			"[LogEndpoint a]: unsupported section",
+		},
+		{
+			"missing device",
+			"[UartEndpoint a]\nBaud = 57600\n",
+			"[UartEndpoint a]: Device is missing",
+		},
+		{
+			"invalid mode",
+			"[UdpEndpoint a]\nMode = other\nAddress = 0.0.0.0\nPort = 14550\n",
+			"[UdpEndpoint a]: invalid mode 'other'",
+		},
+		{
+			"outgoing filter",
+			"[TcpEndpoint a]\nAddress = 127.0.0.1\nPort = 5760\nAllowMsgIdOut = 0\n",
+			"[TcpEndpoint a]: filters on outgoing messages are not supported",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := ReadMavlinkRouterConf(strings.NewReader(ca.conf))
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
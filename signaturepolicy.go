@@ -0,0 +1,59 @@
+package gomavlib
+
+import (
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/transceiver"
+)
+
+// SignatureCheck contains the details of the signature of an incoming frame,
+// that are passed to SignaturePolicy.
+type SignatureCheck struct {
+	// the channel from which the frame was received
+	Channel *Channel
+
+	// the frame
+	Frame frame.Frame
+
+	// whether the frame is signed
+	Signed bool
+
+	// the link id of the signature
+	LinkID byte
+
+	// the timestamp of the signature
+	Timestamp uint64
+
+	// the key, among the accepted ones, that validated the signature.
+	// It is nil if the frame is not signed or no key validated the signature.
+	Key *frame.V2Key
+
+	// the most recent signature timestamp of the frames accepted by the channel
+	LastTimestamp uint64
+
+	check *transceiver.SignatureCheck
+}
+
+func newSignatureCheck(ch *Channel, c *transceiver.SignatureCheck) *SignatureCheck {
+	sc := &SignatureCheck{
+		Channel:       ch,
+		Frame:         c.Frame,
+		Key:           c.Key,
+		LastTimestamp: c.LastTimestamp,
+		check:         c,
+	}
+
+	if ff, ok := c.Frame.(*frame.V2Frame); ok && ff.Signature != nil {
+		sc.Signed = true
+		sc.LinkID = ff.SignatureLinkID
+		sc.Timestamp = ff.SignatureTimestamp
+	}
+
+	return sc
+}
+
+// Default applies the default policy, that requires frames to be signed
+// with any of the accepted keys, with a timestamp not older than 10 seconds
+// with respect to the previous frame. Frames are accepted if there are no keys.
+func (c *SignatureCheck) Default() error {
+	return c.check.Default()
+}
@@ -0,0 +1,115 @@
+package gomavlib
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestNodeSignaturePolicy(t *testing.T) {
+	key := frame.NewV2Key(bytes.Repeat([]byte("\x4F"), 32))
+
+	c1, c2 := net.Pipe()
+	c3, c4 := net.Pipe()
+
+	var mutex sync.Mutex
+	var checks []*SignatureCheck
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}, EndpointCustom{c3}},
+		HeartbeatDisable: true,
+		InKey:            key,
+		SignaturePolicy: func(c *SignatureCheck) error {
+			mutex.Lock()
+			checks = append(checks, c)
+			mutex.Unlock()
+
+			// accept non signed frames from a trusted system
+			if !c.Signed && c.Frame.GetSystemID() == 12 {
+				return nil
+			}
+
+			// the key can be used by a single system
+			if c.Key == key && c.Frame.GetSystemID() != 13 {
+				return fmt.Errorf("key not allowed for system %d", c.Frame.GetSystemID())
+			}
+
+			return c.Default()
+		},
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	for _, conf := range []NodeConf{
+		{
+			OutSystemID: 11,
+			Endpoints:   []EndpointConf{EndpointCustom{c2}},
+			OutKey:      key,
+		},
+		{
+			OutSystemID: 12,
+			Endpoints:   []EndpointConf{EndpointCustom{c4}},
+		},
+	} {
+		conf.Dialect = &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}} //nolint:govet
+		conf.OutVersion = V2
+		conf.HeartbeatDisable = true
+
+		node, err := NewNode(conf)
+		require.NoError(t, err)
+		defer node.Close()
+
+		go func() {
+			for range node.Events() {
+			}
+		}()
+
+		node.WriteMessageAll(&MessageHeartbeat{Type: 1})
+	}
+
+	frameReceived := false
+	parseErrorReceived := false
+
+	for evt := range node1.Events() {
+		switch tevt := evt.(type) {
+		case *EventFrame:
+			require.Equal(t, byte(12), tevt.SystemID())
+			require.Nil(t, tevt.Key)
+			frameReceived = true
+
+		case *EventParseError:
+			require.Equal(t, ParseErrorSignature, tevt.Reason)
+			require.EqualError(t, tevt.Error, "key not allowed for system 11")
+			parseErrorReceived = true
+		}
+
+		if frameReceived && parseErrorReceived {
+			break
+		}
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for _, c := range checks {
+		if c.Frame.GetSystemID() == 11 {
+			require.Equal(t, true, c.Signed)
+			require.Equal(t, key, c.Key)
+			require.NotEqual(t, uint64(0), c.Timestamp)
+		} else {
+			require.Equal(t, false, c.Signed)
+			require.Nil(t, c.Key)
+		}
+	}
+}
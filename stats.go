@@ -0,0 +1,135 @@
+package gomavlib
+
+import (
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// Stats contains statistics about a Channel, an Endpoint or a Node.
+type Stats struct {
+	// received bytes
+	BytesIn uint64
+	// sent bytes
+	BytesOut uint64
+	// received frames
+	FramesIn uint64
+	// sent frames
+	FramesOut uint64
+	// frames that could not be parsed, including the ones
+	// with a wrong checksum
+	ParseErrors uint64
+	// frames with a wrong checksum
+	ChecksumErrors uint64
+	// frames that could not be written
+	DroppedWrites uint64
+	// received frames that were discarded by the filters of the endpoint
+	FilteredFrames uint64
+	// frames that were lost before being received, detected through gaps
+	// in sequence numbers
+	LostFrames uint64
+	// gaps in sequence numbers that were too large to be caused by lost
+	// frames, and were caused by senders that restarted instead
+	SequenceRestarts uint64
+	// events that were discarded because the event queue was full.
+	// It is available in the statistics of the node only.
+	DroppedEvents uint64
+	// average round-trip time, measured with PING and TIMESYNC messages.
+	// It is available in the statistics of channels only.
+	RTT time.Duration
+}
+
+// PacketLoss returns the percentage of frames that were lost before being
+// received.
+func (s Stats) PacketLoss() float64 {
+	return lossPercentage(s.FramesIn, s.LostFrames)
+}
+
+// statsCounters contains statistics that are updated atomically.
+// It must contain 64-bit values only, in order to guarantee their alignment.
+type statsCounters struct {
+	bytesIn          uint64
+	bytesOut         uint64
+	framesIn         uint64
+	framesOut        uint64
+	parseErrors      uint64
+	checksumErrors   uint64
+	droppedWrites    uint64
+	filteredFrames   uint64
+	lostFrames       uint64
+	sequenceRestarts uint64
+	droppedEvents    uint64
+	rtt              int64
+}
+
+func (sc *statsCounters) get() Stats {
+	return Stats{
+		BytesIn:          atomic.LoadUint64(&sc.bytesIn),
+		BytesOut:         atomic.LoadUint64(&sc.bytesOut),
+		FramesIn:         atomic.LoadUint64(&sc.framesIn),
+		FramesOut:        atomic.LoadUint64(&sc.framesOut),
+		ParseErrors:      atomic.LoadUint64(&sc.parseErrors),
+		ChecksumErrors:   atomic.LoadUint64(&sc.checksumErrors),
+		DroppedWrites:    atomic.LoadUint64(&sc.droppedWrites),
+		FilteredFrames:   atomic.LoadUint64(&sc.filteredFrames),
+		LostFrames:       atomic.LoadUint64(&sc.lostFrames),
+		SequenceRestarts: atomic.LoadUint64(&sc.sequenceRestarts),
+		DroppedEvents:    atomic.LoadUint64(&sc.droppedEvents),
+		RTT:              time.Duration(atomic.LoadInt64(&sc.rtt)),
+	}
+}
+
+// addRTT adds a round-trip time sample to the average.
+// It must be called by a single routine.
+func (sc *statsCounters) addRTT(rtt time.Duration) {
+	cur := time.Duration(atomic.LoadInt64(&sc.rtt))
+	if cur != 0 {
+		rtt = cur + (rtt-cur)/8
+	}
+	atomic.StoreInt64(&sc.rtt, int64(rtt))
+}
+
+// statsGroup allows to update the statistics of a channel, of its endpoint
+// and of the node at once.
+type statsGroup []*statsCounters
+
+func (sg statsGroup) add(field func(*statsCounters) *uint64, v uint64) {
+	for _, sc := range sg {
+		atomic.AddUint64(field(sc), v)
+	}
+}
+
+func statsBytesIn(sc *statsCounters) *uint64          { return &sc.bytesIn }
+func statsBytesOut(sc *statsCounters) *uint64         { return &sc.bytesOut }
+func statsFramesIn(sc *statsCounters) *uint64         { return &sc.framesIn }
+func statsFramesOut(sc *statsCounters) *uint64        { return &sc.framesOut }
+func statsParseErrors(sc *statsCounters) *uint64      { return &sc.parseErrors }
+func statsChecksumErrors(sc *statsCounters) *uint64   { return &sc.checksumErrors }
+func statsDroppedWrites(sc *statsCounters) *uint64    { return &sc.droppedWrites }
+func statsFilteredFrames(sc *statsCounters) *uint64   { return &sc.filteredFrames }
+func statsLostFrames(sc *statsCounters) *uint64       { return &sc.lostFrames }
+func statsSequenceRestarts(sc *statsCounters) *uint64 { return &sc.sequenceRestarts }
+
+// statsReader counts the bytes read from a io.Reader.
+type statsReader struct {
+	r  io.Reader
+	sg statsGroup
+}
+
+func (r *statsReader) Read(buf []byte) (int, error) {
+	n, err := r.r.Read(buf)
+	r.sg.add(statsBytesIn, uint64(n))
+	return n, err
+}
+
+// statsWriter counts the bytes written to a io.Writer.
+type statsWriter struct {
+	w  io.Writer
+	sg statsGroup
+}
+
+func (w *statsWriter) Write(buf []byte) (int, error) {
+	n, err := w.w.Write(buf)
+	w.sg.add(statsBytesOut, uint64(n))
+	return n, err
+}
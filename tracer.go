@@ -0,0 +1,99 @@
+package gomavlib
+
+import (
+	"context"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// names of the spans created by the node.
+const (
+	SpanReceive = "mavlink.receive"
+	SpanRoute   = "mavlink.route"
+	SpanWrite   = "mavlink.write"
+)
+
+// TraceAttribute is an attribute of a span.
+// Values are strings or integers.
+type TraceAttribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Span is a span created by a Tracer.
+type Span interface {
+	// SetError marks the span as failed.
+	SetError(err error)
+	// End completes the span.
+	End()
+}
+
+// Tracer creates spans that describe the flow of frames through the node:
+// SpanReceive is created when a frame is received and ends when the frame has
+// been processed (its context is available in EventFrame.Context());
+// SpanRoute is created, as a child of SpanReceive, when a frame is forwarded
+// by the router; SpanWrite is created when a message or frame is written to a
+// channel and, in case of routed frames, it is a child of SpanRoute.
+// It allows to correlate Mavlink traffic with the traces of other services,
+// and can be implemented by wrapping an OpenTelemetry tracer.
+// Start is called by multiple routines in parallel.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...TraceAttribute) (context.Context, Span)
+}
+
+// tracedWrite is a message or frame whose write is traced as a child of ctx.
+type tracedWrite struct {
+	ctx  context.Context
+	what interface{}
+}
+
+// unwrapWrite returns the message or frame wrapped by a write request,
+// together with the report and the tracing context, if any.
+func unwrapWrite(what interface{}) (interface{}, *reportedWrite, context.Context) {
+	var rw *reportedWrite
+	ctx := context.Background()
+
+	if tmp, ok := what.(*reportedWrite); ok {
+		rw = tmp
+		what = rw.what
+	}
+
+	if tmp, ok := what.(*tracedWrite); ok {
+		ctx = tmp.ctx
+		what = tmp.what
+	}
+
+	return what, rw, ctx
+}
+
+func frameTraceAttributes(ch *Channel, fr frame.Frame) []TraceAttribute {
+	return []TraceAttribute{
+		{"mavlink.channel", ch.String()},
+		{"mavlink.system_id", int(fr.GetSystemID())},
+		{"mavlink.component_id", int(fr.GetComponentID())},
+		{"mavlink.message_id", int(fr.GetMessage().GetID())},
+	}
+}
+
+func writeTraceAttributes(ch *Channel, what interface{}) []TraceAttribute {
+	switch wh := what.(type) {
+	case msg.Message:
+		return []TraceAttribute{
+			{"mavlink.channel", ch.String()},
+			{"mavlink.message_id", int(wh.GetID())},
+		}
+
+	case componentMessage:
+		return []TraceAttribute{
+			{"mavlink.channel", ch.String()},
+			{"mavlink.component_id", int(wh.componentID)},
+			{"mavlink.message_id", int(wh.m.GetID())},
+		}
+
+	case frame.Frame:
+		return frameTraceAttributes(ch, wh)
+	}
+
+	return []TraceAttribute{{"mavlink.channel", ch.String()}}
+}
@@ -0,0 +1,161 @@
+package gomavlib
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+type testSpanKey struct{}
+
+type testSpan struct {
+	id     int
+	parent int
+	name   string
+	attrs  []TraceAttribute
+	err    error
+	ended  bool
+	tracer *testTracer
+}
+
+func (s *testSpan) SetError(err error) {
+	s.tracer.mutex.Lock()
+	defer s.tracer.mutex.Unlock()
+	s.err = err
+}
+
+func (s *testSpan) End() {
+	s.tracer.mutex.Lock()
+	defer s.tracer.mutex.Unlock()
+	s.ended = true
+}
+
+type testTracer struct {
+	mutex sync.Mutex
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string,
+	attrs ...TraceAttribute) (context.Context, Span) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	parent, _ := ctx.Value(testSpanKey{}).(int)
+	s := &testSpan{
+		id:     len(t.spans) + 1,
+		parent: parent,
+		name:   name,
+		attrs:  attrs,
+		tracer: t,
+	}
+	t.spans = append(t.spans, s)
+
+	return context.WithValue(ctx, testSpanKey{}, s.id), s
+}
+
+// find returns a copy of the first ended span with given name and parent.
+func (t *testTracer) find(name string, parent int) *testSpan {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, s := range t.spans {
+		if s.name == name && s.ended && (parent < 0 || s.parent == parent) {
+			cpy := *s
+			return &cpy
+		}
+	}
+	return nil
+}
+
+func TestNodeTracer(t *testing.T) {
+	c1, c2 := net.Pipe()
+	c3, c4 := net.Pipe()
+	tracer := &testTracer{}
+
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointCustom{c1}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	router, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}, EndpointCustom{c3}},
+		HeartbeatDisable: true,
+		RouterEnable:     true,
+		Tracer:           tracer,
+	})
+	require.NoError(t, err)
+	defer router.Close()
+
+	recvCtx := make(chan context.Context, 1)
+	go func() {
+		for evt := range router.Events() {
+			if fr, ok := evt.(*EventFrame); ok {
+				recvCtx <- fr.Context()
+			}
+		}
+	}()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      12,
+		Endpoints:        []EndpointConf{EndpointCustom{c4}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	node1.WriteMessageAll(&MessageHeartbeat{Type: 1})
+
+	for evt := range node2.Events() {
+		if _, ok := evt.(*EventFrame); ok {
+			break
+		}
+	}
+
+	// the context of the event is the one of the receive span
+	ctx := <-recvCtx
+	recvID, _ := ctx.Value(testSpanKey{}).(int)
+	require.NotEqual(t, 0, recvID)
+
+	var recv *testSpan
+	var write *testSpan
+	for i := 0; i < 50; i++ {
+		recv = tracer.find(SpanReceive, 0)
+		if route := tracer.find(SpanRoute, recvID); route != nil {
+			write = tracer.find(SpanWrite, route.id)
+		}
+		if recv != nil && write != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	require.NotNil(t, recv)
+	require.Equal(t, recvID, recv.id)
+
+	require.NotNil(t, write)
+	require.NoError(t, write.err)
+	require.Contains(t, write.attrs, TraceAttribute{"mavlink.system_id", 10})
+	require.Contains(t, write.attrs, TraceAttribute{"mavlink.message_id", 0})
+}
@@ -0,0 +1,230 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// ErrTimeout is returned by Subscription.Wait() when no frame is received
+// within the timeout.
+var ErrTimeout = errorTimeout
+
+// ErrNoResponse is returned by Node.Transact() when no response is received
+// after all the attempts.
+var ErrNoResponse = fmt.Errorf("no response received")
+
+// Subscription receives the incoming frames that match a filter.
+// It can be used to implement custom protocols on top of the node.
+// Frames are buffered; if the buffer is full, frames are discarded.
+type Subscription struct {
+	n   *Node
+	sub *frameSubscriber
+}
+
+// Subscribe starts receiving the incoming frames accepted by the given
+// function, that is called from the routines of the channels and therefore
+// must not block. The subscription must be closed with Close() when
+// it is no longer needed.
+func (n *Node) Subscribe(filter func(*EventFrame) bool) *Subscription {
+	return &Subscription{
+		n:   n,
+		sub: n.frameSubscribers.subscribe(filter),
+	}
+}
+
+// Close stops the subscription.
+func (s *Subscription) Close() {
+	s.n.frameSubscribers.unsubscribe(s.sub)
+}
+
+// Wait waits for the next frame of the subscription.
+// It returns ErrTimeout if no frame is received within the timeout.
+func (s *Subscription) Wait(ctx context.Context, timeout time.Duration) (*EventFrame, error) {
+	return s.sub.wait(ctx, s.n, timeout)
+}
+
+// Transact performs a transaction by using the frames of the subscription.
+// It is useful when a protocol is made of multiple transactions that share
+// the same subscription, like mission transfers.
+func (s *Subscription) Transact(ctx context.Context, tr *Transaction) (*EventFrame, error) {
+	return s.n.transact(ctx, s.sub, tr)
+}
+
+// Transaction is a request followed by a response, that can be performed
+// with Node.Transact().
+type Transaction struct {
+	// (optional) the channel where the request is sent and the response
+	// is received. It defaults to all channels.
+	Channel *Channel
+
+	// the request.
+	Request msg.Message
+
+	// (optional) a function that returns the request of every attempt,
+	// that replaces Request. It allows to change the request between
+	// attempts, like the confirmation field of COMMAND_LONG. If it returns
+	// nil, nothing is sent in that attempt, that is useful to wait for
+	// further responses of a request that has already been sent.
+	RequestFunc func(attempt int) msg.Message
+
+	// the function that accepts the response (i.e. COMMAND_ACK,
+	// PARAM_VALUE, MISSION_ACK).
+	// It is optional when the transaction is performed with
+	// Subscription.Transact(), in which case all frames are accepted.
+	Match func(*EventFrame) bool
+
+	// (optional) a function that detects partial responses, i.e.
+	// COMMAND_ACK with MAV_RESULT_IN_PROGRESS. When a partial response
+	// is received, the request is not sent anymore and the transaction waits
+	// for the final response until InProgressTimeout expires.
+	InProgress func(*EventFrame) bool
+
+	// (optional) the time to wait for the final response after a partial
+	// response. It is restarted by every partial response, since they
+	// usually report the progress of the request. It defaults to Timeout.
+	InProgressTimeout time.Duration
+
+	// (optional) the time to wait for a response before sending the
+	// request again. It defaults to 1 second.
+	Timeout time.Duration
+
+	// (optional) the maximum number of times the request is sent.
+	// It defaults to 3.
+	Attempts int
+
+	// (optional) the factor by which the timeout is multiplied after
+	// every attempt. It defaults to 1.
+	Backoff float64
+}
+
+func (tr *Transaction) fill() *Transaction {
+	rc := *tr
+	if rc.Timeout == 0 {
+		rc.Timeout = 1 * time.Second
+	}
+	if rc.Attempts == 0 {
+		rc.Attempts = 3
+	}
+	if rc.Backoff == 0 {
+		rc.Backoff = 1
+	}
+	if rc.InProgressTimeout == 0 {
+		rc.InProgressTimeout = rc.Timeout
+	}
+	return &rc
+}
+
+func (tr *Transaction) request(attempt int) msg.Message {
+	if tr.RequestFunc != nil {
+		return tr.RequestFunc(attempt)
+	}
+	return tr.Request
+}
+
+func (tr *Transaction) accepts(evt *EventFrame) bool {
+	if tr.Channel != nil && evt.Channel != tr.Channel {
+		return false
+	}
+	return tr.Match == nil || tr.Match(evt)
+}
+
+// waitUntil waits for a frame of the subscriber until the deadline expires.
+func (n *Node) waitUntil(ctx context.Context, sub *frameSubscriber, deadline time.Time) (*EventFrame, error) {
+	timeout := deadline.Sub(n.conf.Clock.Now())
+	if timeout <= 0 {
+		return nil, errorTimeout
+	}
+	return sub.wait(ctx, n, timeout)
+}
+
+// transact sends a request and waits for a frame of the subscriber accepted
+// by the transaction. The request is sent again when the frame is not
+// received within the timeout.
+func (n *Node) transact(ctx context.Context, sub *frameSubscriber, tr *Transaction) (*EventFrame, error) {
+	tr = tr.fill()
+	timeout := tr.Timeout
+
+	for attempt := 0; attempt < tr.Attempts; attempt++ {
+		if req := tr.request(attempt); req != nil {
+			n.writeMessageToOrAll(tr.Channel, req)
+		}
+
+		// the timeout is not restarted by frames that are not accepted
+		deadline := n.conf.Clock.Now().Add(timeout)
+
+		for {
+			evt, err := n.waitUntil(ctx, sub, deadline)
+			if err == errorTimeout {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if !tr.accepts(evt) {
+				continue
+			}
+
+			// request is being processed: stop sending it and wait
+			// for the final response
+			if tr.InProgress != nil && tr.InProgress(evt) {
+				return n.transactInProgress(ctx, sub, tr)
+			}
+
+			return evt, nil
+		}
+
+		timeout = time.Duration(float64(timeout) * tr.Backoff)
+	}
+
+	return nil, ErrNoResponse
+}
+
+// transactInProgress waits for the final response of a request that is
+// being processed.
+func (n *Node) transactInProgress(ctx context.Context, sub *frameSubscriber, tr *Transaction) (*EventFrame, error) {
+	deadline := n.conf.Clock.Now().Add(tr.InProgressTimeout)
+
+	for {
+		evt, err := n.waitUntil(ctx, sub, deadline)
+		if err == errorTimeout {
+			return nil, ErrNoResponse
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !tr.accepts(evt) {
+			continue
+		}
+
+		if tr.InProgress(evt) {
+			deadline = n.conf.Clock.Now().Add(tr.InProgressTimeout)
+			continue
+		}
+
+		return evt, nil
+	}
+}
+
+// Transact sends a request, waits for the response accepted by
+// Transaction.Match and returns it. The request is sent again when the
+// response is not received within the timeout; ErrNoResponse is returned
+// after all the attempts.
+// It is the base of the protocol helpers (commands, parameters, missions,
+// logs, cameras) and can be used to implement custom protocols.
+// Events() must be read in a separate routine, otherwise the response
+// can't be received.
+func (n *Node) Transact(ctx context.Context, tr *Transaction) (*EventFrame, error) {
+	if tr.Match == nil {
+		return nil, fmt.Errorf("the transaction has no matcher")
+	}
+
+	sub := n.frameSubscribers.subscribe(tr.accepts)
+	defer n.frameSubscribers.unsubscribe(sub)
+
+	return n.transact(ctx, sub, tr)
+}
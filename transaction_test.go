@@ -0,0 +1,311 @@
+package gomavlib
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialects/common"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestNodeTransact(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	go func() {
+		received := 0
+		for evt := range vehicle.Events() {
+			frm, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			m, ok := frm.Message().(*common.MessageMissionRequestList)
+			if !ok || m.TargetSystem != 1 {
+				continue
+			}
+
+			// ignore the first attempt
+			received++
+			if received == 1 {
+				continue
+			}
+
+			// unrelated message, that must be ignored
+			vehicle.WriteMessageTo(frm.Channel, &common.MessageMissionAck{
+				TargetSystem: 255,
+			})
+			vehicle.WriteMessageTo(frm.Channel, &common.MessageMissionCount{
+				TargetSystem: 255,
+				Count:        uint16(received),
+			})
+		}
+	}()
+
+	attempts := []int{}
+
+	evt, err := gcs.Transact(context.Background(), &Transaction{
+		RequestFunc: func(attempt int) msg.Message {
+			attempts = append(attempts, attempt)
+			return &common.MessageMissionRequestList{
+				TargetSystem: 1,
+			}
+		},
+		Match: func(evt *EventFrame) bool {
+			_, ok := evt.Message().(*common.MessageMissionCount)
+			return ok && evt.SystemID() == 1
+		},
+		Timeout: 200 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint16(2), evt.Message().(*common.MessageMissionCount).Count)
+	require.Equal(t, []int{0, 1}, attempts)
+}
+
+func TestNodeTransactInProgress(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	go func() {
+		for evt := range vehicle.Events() {
+			frm, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			if _, ok := frm.Message().(*common.MessageMissionRequestList); !ok {
+				continue
+			}
+
+			vehicle.WriteMessageTo(frm.Channel, &common.MessageMissionCount{
+				TargetSystem: 255,
+				Count:        0,
+			})
+
+			// the final response is sent after the timeout
+			time.Sleep(300 * time.Millisecond)
+
+			vehicle.WriteMessageTo(frm.Channel, &common.MessageMissionCount{
+				TargetSystem: 255,
+				Count:        5,
+			})
+		}
+	}()
+
+	sent := 0
+
+	evt, err := gcs.Transact(context.Background(), &Transaction{
+		RequestFunc: func(attempt int) msg.Message {
+			sent++
+			return &common.MessageMissionRequestList{
+				TargetSystem: 1,
+			}
+		},
+		Match: func(evt *EventFrame) bool {
+			_, ok := evt.Message().(*common.MessageMissionCount)
+			return ok
+		},
+		InProgress: func(evt *EventFrame) bool {
+			return evt.Message().(*common.MessageMissionCount).Count == 0
+		},
+		Timeout: 500 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint16(5), evt.Message().(*common.MessageMissionCount).Count)
+	require.Equal(t, 1, sent)
+}
+
+func TestNodeTransactNoResponse(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	go func() {
+		for range vehicle.Events() {
+		}
+	}()
+
+	_, err := gcs.Transact(context.Background(), &Transaction{
+		Request: &common.MessageMissionRequestList{
+			TargetSystem: 1,
+		},
+		Match: func(evt *EventFrame) bool {
+			return true
+		},
+		Timeout:  50 * time.Millisecond,
+		Attempts: 2,
+	})
+	require.Equal(t, ErrNoResponse, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err = gcs.Transact(ctx, &Transaction{
+		Request: &common.MessageMissionRequestList{
+			TargetSystem: 1,
+		},
+		Match: func(evt *EventFrame) bool {
+			return true
+		},
+		Timeout: 1 * time.Second,
+	})
+	require.Equal(t, context.Canceled, err)
+}
+
+func TestNodeTransactTimeoutNotRestarted(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	go func() {
+		for range vehicle.Events() {
+		}
+	}()
+
+	// frames that are not accepted are received faster than the timeout
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(20 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				vehicle.WriteMessageAll(&common.MessageMissionAck{})
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	start := time.Now()
+
+	_, err := gcs.Transact(context.Background(), &Transaction{
+		Request: &common.MessageMissionRequestList{
+			TargetSystem: 1,
+		},
+		Match: func(evt *EventFrame) bool {
+			_, ok := evt.Message().(*common.MessageMissionCount)
+			return ok
+		},
+		Timeout:  200 * time.Millisecond,
+		Attempts: 1,
+	})
+	require.Equal(t, ErrNoResponse, err)
+	require.True(t, time.Since(start) < 1*time.Second)
+}
+
+func TestNodeTransactInProgressTimeout(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	go func() {
+		for evt := range vehicle.Events() {
+			frm, ok := evt.(*EventFrame)
+			if !ok {
+				continue
+			}
+
+			if _, ok := frm.Message().(*common.MessageMissionRequestList); !ok {
+				continue
+			}
+
+			// the final response is never sent
+			vehicle.WriteMessageTo(frm.Channel, &common.MessageMissionCount{
+				TargetSystem: 255,
+				Count:        0,
+			})
+		}
+	}()
+
+	sent := 0
+	start := time.Now()
+
+	_, err := gcs.Transact(context.Background(), &Transaction{
+		RequestFunc: func(attempt int) msg.Message {
+			sent++
+			return &common.MessageMissionRequestList{
+				TargetSystem: 1,
+			}
+		},
+		Match: func(evt *EventFrame) bool {
+			_, ok := evt.Message().(*common.MessageMissionCount)
+			return ok
+		},
+		InProgress: func(evt *EventFrame) bool {
+			return evt.Message().(*common.MessageMissionCount).Count == 0
+		},
+		Timeout:           2 * time.Second,
+		InProgressTimeout: 100 * time.Millisecond,
+	})
+	require.Equal(t, ErrNoResponse, err)
+	require.Equal(t, 1, sent)
+	require.True(t, time.Since(start) < 1*time.Second)
+}
+
+func TestNodeSubscribe(t *testing.T) {
+	gcs, vehicle := newTestNodePair(t)
+	defer gcs.Close()
+	defer vehicle.Close()
+
+	go func() {
+		for range gcs.Events() {
+		}
+	}()
+
+	go func() {
+		for range vehicle.Events() {
+		}
+	}()
+
+	sub := gcs.Subscribe(func(evt *EventFrame) bool {
+		_, ok := evt.Message().(*common.MessageMissionAck)
+		return ok
+	})
+	defer sub.Close()
+
+	vehicle.WriteMessageAll(&common.MessageMissionCount{})
+	vehicle.WriteMessageAll(&common.MessageMissionAck{Type: 3})
+
+	evt, err := sub.Wait(context.Background(), 1*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, common.MAV_MISSION_RESULT(3), evt.Message().(*common.MessageMissionAck).Type)
+
+	_, err = sub.Wait(context.Background(), 50*time.Millisecond)
+	require.Equal(t, ErrTimeout, err)
+}
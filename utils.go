@@ -4,14 +4,23 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"reflect"
 	"time"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
 )
 
 var errorTerminated = fmt.Errorf("terminated")
 
+var errorTimeout = fmt.Errorf("timed out")
+
 // netTimedConn forces a net.Conn to use timeouts
 type netTimedConn struct {
 	conn net.Conn
+
+	// (optional) it defaults to netReadTimeout.
+	readTimeout time.Duration
 }
 
 func (c *netTimedConn) Close() error {
@@ -19,7 +28,12 @@ func (c *netTimedConn) Close() error {
 }
 
 func (c *netTimedConn) Read(buf []byte) (int, error) {
-	err := c.conn.SetReadDeadline(time.Now().Add(netReadTimeout))
+	readTimeout := c.readTimeout
+	if readTimeout == 0 {
+		readTimeout = netReadTimeout
+	}
+
+	err := c.conn.SetReadDeadline(time.Now().Add(readTimeout))
 	if err != nil {
 		return 0, err
 	}
@@ -34,8 +48,74 @@ func (c *netTimedConn) Write(buf []byte) (int, error) {
 	return c.conn.Write(buf)
 }
 
+func (c *netTimedConn) writeBatch(bufs [][]byte) (int, error) {
+	err := c.conn.SetWriteDeadline(time.Now().Add(netWriteTimeout))
+	if err != nil {
+		return 0, err
+	}
+	return connWriteBatch(c.conn, bufs)
+}
+
 func randomByte() byte {
 	var buf [1]byte
 	rand.Read(buf[:])
 	return buf[0]
 }
+
+// dialectMessage returns the message of a dialect with given ID, if it
+// exists and corresponds to the standard (i.e. has the standard CRC extra).
+func dialectMessage(d *dialect.Dialect, id uint32, crcExtra byte) msg.Message {
+	if d == nil {
+		return nil
+	}
+
+	for _, m := range d.Messages {
+		if m.GetID() == id {
+			mde, err := msg.NewDecEncoder(m)
+			if err != nil || mde.CRCExtra() != crcExtra {
+				return nil
+			}
+			return m
+		}
+	}
+	return nil
+}
+
+// newMessage allocates a message with the same type of given message.
+func newMessage(m msg.Message) reflect.Value {
+	return reflect.New(reflect.TypeOf(m).Elem())
+}
+
+// msgValue returns the reflect.Value of the struct of a message.
+func msgValue(m msg.Message) reflect.Value {
+	return reflect.ValueOf(m).Elem()
+}
+
+// reflectUint returns the value of an integer or enum field.
+// Enums are signed or unsigned depending on their values.
+func reflectUint(v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(v.Int())
+	}
+	return v.Uint()
+}
+
+// reflectInt returns the value of an integer or enum field.
+func reflectInt(v reflect.Value) int64 {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	}
+	return v.Int()
+}
+
+// reflectSetInt sets the value of an integer or enum field.
+func reflectSetInt(v reflect.Value, i int64) {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(uint64(i))
+	default:
+		v.SetInt(i)
+	}
+}
@@ -0,0 +1,107 @@
+package gomavlib
+
+import (
+	"io"
+	"net"
+
+	"github.com/aler9/gomavlib/pkg/mmsg"
+)
+
+// maximum number of frames written with a single system call.
+const writeBatchMaxSize = 32
+
+// batchWriter is implemented by connections that can write multiple frames
+// with a single system call.
+type batchWriter interface {
+	writeBatch(bufs [][]byte) (int, error)
+}
+
+// connWriteBatch writes multiple frames to a connection and returns the
+// number of frames that have been written.
+// UDP datagrams are written with sendmmsg, while streams are written with
+// writev, where available.
+func connWriteBatch(conn net.Conn, bufs [][]byte) (int, error) {
+	switch tconn := conn.(type) {
+	case *net.UDPConn:
+		return mmsg.WriteBatch(tconn, nil, bufs)
+
+	case interface {
+		WriteBatch(bufs [][]byte) (int, error)
+	}:
+		return tconn.WriteBatch(bufs)
+	}
+
+	// net.Buffers consumes the slice, copy it
+	nb := net.Buffers(append([][]byte(nil), bufs...))
+	n, err := nb.WriteTo(conn)
+
+	count := 0
+	for _, buf := range bufs {
+		if n < int64(len(buf)) {
+			break
+		}
+		n -= int64(len(buf))
+		count++
+	}
+
+	return count, err
+}
+
+// writeBatcher is the writer of a channel that supports batches.
+// Frames are written directly, unless they are written between begin() and
+// flush(): in this case, they are collected and written together.
+type writeBatcher struct {
+	w      batchWriter
+	direct io.Writer
+	sg     statsGroup
+	active bool
+	bufs   [][]byte
+	n      int
+}
+
+func (b *writeBatcher) Write(buf []byte) (int, error) {
+	if !b.active {
+		return b.direct.Write(buf)
+	}
+
+	// buffer is reused by the transceiver, copy it
+	if b.n < len(b.bufs) {
+		b.bufs[b.n] = append(b.bufs[b.n][:0], buf...)
+	} else {
+		b.bufs = append(b.bufs, append([]byte(nil), buf...))
+	}
+	b.n++
+
+	return len(buf), nil
+}
+
+// begin starts collecting frames.
+func (b *writeBatcher) begin() {
+	b.active = true
+}
+
+// len returns the number of collected frames.
+func (b *writeBatcher) len() int {
+	return b.n
+}
+
+// flush writes the collected frames and returns the number of frames
+// that have been written.
+func (b *writeBatcher) flush() (int, error) {
+	b.active = false
+
+	if b.n == 0 {
+		return 0, nil
+	}
+
+	bufs := b.bufs[:b.n]
+	b.n = 0
+
+	n, err := b.w.writeBatch(bufs)
+
+	for _, buf := range bufs[:n] {
+		b.sg.add(statsBytesOut, uint64(len(buf)))
+	}
+
+	return n, err
+}
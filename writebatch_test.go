@@ -0,0 +1,205 @@
+package gomavlib
+
+import (
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestConnWriteBatchStream(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sconn, err := ln.Accept()
+	require.NoError(t, err)
+	defer sconn.Close()
+
+	n, err := connWriteBatch(conn, [][]byte{{1, 2}, {3}, {4, 5, 6}})
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+
+	buf := make([]byte, 6)
+	_, err = io.ReadFull(sconn, buf)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3, 4, 5, 6}, buf)
+}
+
+// testBatchRWC records the frames written by a channel.
+// The first write blocks until unblock is closed.
+type testBatchRWC struct {
+	mutex   sync.Mutex
+	first   bool
+	unblock chan struct{}
+	closed  chan struct{}
+	frames  int
+	batches []int
+	done    chan struct{}
+	total   int
+}
+
+func (c *testBatchRWC) Read(buf []byte) (int, error) {
+	<-c.closed
+	return 0, io.EOF
+}
+
+func (c *testBatchRWC) Close() error {
+	close(c.closed)
+	return nil
+}
+
+func (c *testBatchRWC) add(frames int, batch bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.frames += frames
+	if batch {
+		c.batches = append(c.batches, frames)
+	}
+	if c.frames == c.total {
+		close(c.done)
+	}
+}
+
+func (c *testBatchRWC) Write(buf []byte) (int, error) {
+	c.mutex.Lock()
+	first := !c.first
+	c.first = true
+	c.mutex.Unlock()
+
+	if first {
+		<-c.unblock
+	}
+
+	c.add(1, false)
+	return len(buf), nil
+}
+
+func (c *testBatchRWC) writeBatch(bufs [][]byte) (int, error) {
+	c.add(len(bufs), true)
+	return len(bufs), nil
+}
+
+// testBatchEndpoint is an endpoint that supports batches.
+type testBatchEndpoint struct {
+	*testBatchRWC
+}
+
+func (conf testBatchEndpoint) init() (Endpoint, error) {
+	return conf, nil
+}
+
+func (testBatchEndpoint) isEndpoint() {}
+
+func (conf testBatchEndpoint) Conf() EndpointConf {
+	return conf
+}
+
+func (testBatchEndpoint) Label() string {
+	return "batch"
+}
+
+func TestNodeWriteBatch(t *testing.T) {
+	rwc := &testBatchRWC{
+		unblock: make(chan struct{}),
+		closed:  make(chan struct{}),
+		done:    make(chan struct{}),
+		total:   101,
+	}
+
+	node, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{EndpointQueue{
+			EndpointConf: testBatchEndpoint{rwc},
+			Size:         200,
+		}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node.Close()
+
+	go func() {
+		for range node.Events() {
+		}
+	}()
+
+	// the first frame blocks the writer, the others are queued
+	for i := 0; i < 101; i++ {
+		node.WriteMessageAll(&MessageHeartbeat{Type: 1})
+	}
+	close(rwc.unblock)
+	<-rwc.done
+
+	rwc.mutex.Lock()
+	defer rwc.mutex.Unlock()
+
+	require.NotEqual(t, 0, len(rwc.batches))
+	for _, b := range rwc.batches {
+		require.True(t, b > 1 && b <= writeBatchMaxSize)
+	}
+
+	require.Equal(t, uint64(101), node.Stats().FramesOut)
+}
+
+func TestNodeUDPWriteBatch(t *testing.T) {
+	node1, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		Endpoints:        []EndpointConf{EndpointUDPServer{Address: "127.0.0.1:5600"}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 11,
+		Endpoints: []EndpointConf{EndpointQueue{
+			EndpointConf: EndpointUDPClient{Address: "127.0.0.1:5600"},
+			Size:         100,
+		}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	// wait connection to server
+	time.Sleep(500 * time.Millisecond)
+
+	for i := 0; i < 50; i++ {
+		node2.WriteMessageAll(&MessageHeartbeat{Type: MAV_TYPE(i)})
+	}
+
+	count := 0
+	for evt := range node1.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			require.Equal(t, &MessageHeartbeat{Type: MAV_TYPE(count)}, fr.Message())
+			count++
+			if count == 50 {
+				break
+			}
+		}
+	}
+
+	require.Equal(t, uint64(50), node2.Stats().FramesOut)
+}
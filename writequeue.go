@@ -0,0 +1,205 @@
+package gomavlib
+
+import (
+	"sync"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+const (
+	writeQueueDefaultSize = 64
+)
+
+// Priority is the priority of an outgoing message.
+// When outgoing frames are queued (see EndpointQueue and EndpointRateLimit),
+// frames with a higher priority are written before the others.
+type Priority int
+
+// priorities.
+const (
+	// telemetry, parameters, logs and any other message.
+	PriorityTelemetry Priority = iota
+	// messages of the mission protocol.
+	PriorityMission
+	// heartbeats and commands.
+	PriorityCommand
+
+	priorityCount = iota
+)
+
+// DefaultPriority returns the default priority of a message, that depends
+// on its ID.
+func DefaultPriority(m msg.Message) Priority {
+	switch m.GetID() {
+	case 0, // HEARTBEAT
+		11, // SET_MODE
+		75, // COMMAND_INT
+		76, // COMMAND_LONG
+		77, // COMMAND_ACK
+		80: // COMMAND_CANCEL
+		return PriorityCommand
+
+	case 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, // MISSION_*
+		51, // MISSION_REQUEST_INT
+		73: // MISSION_ITEM_INT
+		return PriorityMission
+	}
+
+	return PriorityTelemetry
+}
+
+// EndpointQueue wraps an endpoint configuration and adds a queue of outgoing
+// frames to each channel of the endpoint. Frames are written in order of
+// priority, therefore heartbeats and commands are not delayed by large
+// transfers on slow links, and a slow channel does not block the others.
+// When the queue is full, frames with the lowest priority are discarded and
+// counted in the DroppedWrites statistic.
+type EndpointQueue struct {
+	// the wrapped endpoint configuration.
+	EndpointConf
+
+	// (optional) the maximum number of queued frames. It defaults to 64.
+	Size int
+}
+
+// writeQueue is a bounded queue of outgoing messages and frames,
+// that returns them in order of priority.
+// It supports a single consumer.
+type writeQueue struct {
+	size int
+
+	mutex  sync.Mutex
+	items  [priorityCount][]interface{}
+	count  int
+	closed bool
+	notify chan struct{}
+}
+
+func newWriteQueue(size int) *writeQueue {
+	return &writeQueue{
+		size:   size,
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// push adds an item to the queue. When the queue is full, the oldest item with
+// the lowest priority is discarded, or the item itself if it has the lowest
+// priority. It returns the discarded item and false if an item was discarded.
+func (q *writeQueue) push(what interface{}, p Priority) (interface{}, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var dropped interface{}
+	ok := true
+
+	if q.count >= q.size {
+		lowest := Priority(0)
+		for len(q.items[lowest]) == 0 {
+			lowest++
+		}
+
+		if lowest >= p {
+			return what, false
+		}
+
+		dropped = q.items[lowest][0]
+		q.items[lowest][0] = nil
+		q.items[lowest] = q.items[lowest][1:]
+		q.count--
+		ok = false
+	}
+
+	q.items[p] = append(q.items[p], what)
+	q.count++
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+
+	return dropped, ok
+}
+
+// pop returns the oldest item with the highest priority. It blocks until an
+// item is available, and returns false when the queue is closed and empty.
+func (q *writeQueue) pop() (interface{}, bool) {
+	for {
+		what, ok, closed := q.tryPop()
+		if ok {
+			return what, true
+		}
+
+		if closed {
+			return nil, false
+		}
+
+		<-q.notify
+	}
+}
+
+// tryPop returns the item with the highest priority, without waiting.
+// It also returns whether the queue is closed.
+func (q *writeQueue) tryPop() (interface{}, bool, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.count > 0 {
+		for p := priorityCount - 1; p >= 0; p-- {
+			if len(q.items[p]) != 0 {
+				what := q.items[p][0]
+				q.items[p][0] = nil
+				q.items[p] = q.items[p][1:]
+				q.count--
+				return what, true, q.closed
+			}
+		}
+	}
+
+	return nil, false, q.closed
+}
+
+// close closes the queue. Items that are already queued can still be read.
+func (q *writeQueue) close() {
+	q.mutex.Lock()
+	q.closed = true
+	q.mutex.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// writePriority returns the priority of a message or frame written to a channel.
+func (n *Node) writePriority(what interface{}) Priority {
+	what, _, _ = unwrapWrite(what)
+
+	var m msg.Message
+	switch wh := what.(type) {
+	case msg.Message:
+		m = wh
+
+	case componentMessage:
+		m = wh.m
+
+	case frame.Frame:
+		m = wh.GetMessage()
+	}
+
+	if m == nil {
+		return PriorityTelemetry
+	}
+
+	var p Priority
+	if n.conf.WritePriority != nil {
+		p = n.conf.WritePriority(m)
+	} else {
+		p = DefaultPriority(m)
+	}
+
+	if p < 0 || p >= priorityCount {
+		return PriorityTelemetry
+	}
+	return p
+}
@@ -0,0 +1,154 @@
+package gomavlib
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+func TestDefaultPriority(t *testing.T) {
+	require.Equal(t, PriorityCommand, DefaultPriority(&MessageHeartbeat{}))
+	require.Equal(t, PriorityTelemetry, DefaultPriority(&MessageRequestDataStream{}))
+	require.Equal(t, PriorityMission, DefaultPriority(&msg.MessageRaw{ID: 44}))
+	require.Equal(t, PriorityCommand, DefaultPriority(&msg.MessageRaw{ID: 76}))
+}
+
+func TestWriteQueue(t *testing.T) {
+	q := newWriteQueue(3)
+
+	for _, it := range []struct {
+		what interface{}
+		p    Priority
+	}{
+		{"t1", PriorityTelemetry},
+		{"m1", PriorityMission},
+		{"t2", PriorityTelemetry},
+	} {
+		_, ok := q.push(it.what, it.p)
+		require.Equal(t, true, ok)
+	}
+
+	// the queue is full: the oldest item with the lowest priority is discarded
+	dropped, ok := q.push("c1", PriorityCommand)
+	require.Equal(t, false, ok)
+	require.Equal(t, "t1", dropped)
+
+	// the queue is full and the item has the lowest priority
+	dropped, ok = q.push("t3", PriorityTelemetry)
+	require.Equal(t, false, ok)
+	require.Equal(t, "t3", dropped)
+
+	q.close()
+
+	var items []interface{}
+	for {
+		what, ok := q.pop()
+		if !ok {
+			break
+		}
+		items = append(items, what)
+	}
+	require.Equal(t, []interface{}{"c1", "m1", "t2"}, items)
+}
+
+func TestWriteQueuePopBlocks(t *testing.T) {
+	q := newWriteQueue(3)
+
+	done := make(chan interface{})
+	go func() {
+		what, _ := q.pop()
+		done <- what
+	}()
+
+	select {
+	case <-done:
+		t.Errorf("pop should block")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	q.push("t1", PriorityTelemetry)
+	require.Equal(t, "t1", <-done)
+}
+
+func TestNodeWritePriority(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect: &dialect.Dialect{3, []msg.Message{ //nolint:govet
+			&MessageHeartbeat{},
+			&MessageRequestDataStream{},
+		}},
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointRateLimit{
+				EndpointConf:    EndpointCustom{c1},
+				FramesPerSecond: 10,
+				Burst:           100 * time.Millisecond,
+			},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	node2, err := NewNode(NodeConf{
+		Dialect: &dialect.Dialect{3, []msg.Message{ //nolint:govet
+			&MessageHeartbeat{},
+			&MessageRequestDataStream{},
+		}},
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	for i := 0; i < 4; i++ {
+		node1.WriteMessageAll(&MessageRequestDataStream{ReqStreamId: uint8(i)})
+	}
+	node1.WriteMessageAll(&MessageHeartbeat{Type: 1})
+
+	// the heartbeat overtakes the frames that are waiting in the queue
+	var recv []msg.Message
+	for evt := range node2.Events() {
+		if fr, ok := evt.(*EventFrame); ok {
+			recv = append(recv, fr.Message())
+			if len(recv) == 5 {
+				break
+			}
+		}
+	}
+
+	pos := 0
+	for i, m := range recv {
+		if _, ok := m.(*MessageHeartbeat); ok {
+			pos = i
+		}
+	}
+	require.True(t, pos <= 2)
+	require.Equal(t, &MessageRequestDataStream{ReqStreamId: 3}, recv[4])
+}
+
+func TestNodeEndpointQueueErrors(t *testing.T) {
+	_, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointQueue{EndpointConf: EndpointCustom{nil}, Size: -1},
+		},
+	})
+	require.EqualError(t, err, "invalid queue size")
+}
@@ -0,0 +1,196 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aler9/gomavlib/pkg/frame"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+var (
+	errorQueueFull      = fmt.Errorf("queue is full")
+	errorFrameDiscarded = fmt.Errorf("frame discarded by RewriteFrame")
+	errorChannelClosed  = fmt.Errorf("channel is closed")
+)
+
+// WriteReport is the outcome of the write of a message or frame to a channel.
+type WriteReport struct {
+	// the channel.
+	Channel *Channel
+	// the error that prevented the write, or nil if the write succeeded.
+	Error error
+}
+
+// reportedWrite is a message or frame whose outcome is reported back to the
+// caller once it has been written to all the channels it was dispatched to.
+type reportedWrite struct {
+	what interface{}
+
+	mutex   sync.Mutex
+	reports []*WriteReport
+	pending int
+	sealed  bool
+	done    chan struct{}
+}
+
+func newReportedWrite(what interface{}) *reportedWrite {
+	return &reportedWrite{
+		what: what,
+		done: make(chan struct{}),
+	}
+}
+
+// add is called before dispatching the write to a channel.
+func (rw *reportedWrite) add() {
+	rw.mutex.Lock()
+	defer rw.mutex.Unlock()
+	rw.pending++
+}
+
+// seal is called after the write has been dispatched to all channels.
+func (rw *reportedWrite) seal() {
+	rw.mutex.Lock()
+	defer rw.mutex.Unlock()
+	rw.sealed = true
+	if rw.pending == 0 {
+		close(rw.done)
+	}
+}
+
+// report is called by channels after the write has been performed or discarded.
+func (rw *reportedWrite) report(ch *Channel, err error) {
+	rw.mutex.Lock()
+	defer rw.mutex.Unlock()
+	rw.reports = append(rw.reports, &WriteReport{Channel: ch, Error: err})
+	rw.pending--
+	if rw.sealed && rw.pending == 0 {
+		close(rw.done)
+	}
+}
+
+func (rw *reportedWrite) wait(ctx context.Context, nodeCtx context.Context) ([]*WriteReport, error) {
+	select {
+	case <-rw.done:
+		return rw.reports, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-nodeCtx.Done():
+		return nil, errorTerminated
+	}
+}
+
+// dispatchWrite sends a message or frame to a channel.
+func dispatchWrite(ch *Channel, what interface{}) {
+	if rw, ok := what.(*reportedWrite); ok {
+		rw.add()
+	}
+	ch.write <- what
+}
+
+// sealWrite is called after a message or frame has been sent to all channels.
+func sealWrite(what interface{}) {
+	if rw, ok := what.(*reportedWrite); ok {
+		rw.seal()
+	}
+}
+
+func (n *Node) writeToReport(ctx context.Context, channel *Channel, what interface{}) error {
+	rw := newReportedWrite(what)
+
+	select {
+	case n.writeTo <- writeToReq{channel, rw}:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-n.ctx.Done():
+		return errorTerminated
+	}
+
+	reports, err := rw.wait(ctx, n.ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(reports) == 0 {
+		return errorChannelClosed
+	}
+	return reports[0].Error
+}
+
+func (n *Node) writeAllReport(ctx context.Context, what interface{}) ([]*WriteReport, error) {
+	rw := newReportedWrite(what)
+
+	select {
+	case n.writeAll <- rw:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-n.ctx.Done():
+		return nil, errorTerminated
+	}
+
+	return rw.wait(ctx, n.ctx)
+}
+
+func (n *Node) writeExceptReport(ctx context.Context, exceptChannel *Channel,
+	what interface{}) ([]*WriteReport, error) {
+	rw := newReportedWrite(what)
+
+	select {
+	case n.writeExcept <- writeExceptReq{exceptChannel, rw}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-n.ctx.Done():
+		return nil, errorTerminated
+	}
+
+	return rw.wait(ctx, n.ctx)
+}
+
+// WriteMessageToReport writes a message to given channel and waits until
+// the write is performed. It returns the error that prevented the write,
+// if any (for instance, a full queue or a closed connection).
+func (n *Node) WriteMessageToReport(ctx context.Context, channel *Channel, m msg.Message) error {
+	return n.writeToReport(ctx, channel, m)
+}
+
+// WriteMessageAllReport writes a message to all channels and waits until
+// all writes are performed. It returns the outcome of the write on every channel.
+func (n *Node) WriteMessageAllReport(ctx context.Context, m msg.Message) ([]*WriteReport, error) {
+	return n.writeAllReport(ctx, m)
+}
+
+// WriteMessageExceptReport writes a message to all channels except specified
+// channel and waits until all writes are performed. It returns the outcome
+// of the write on every channel.
+func (n *Node) WriteMessageExceptReport(ctx context.Context, exceptChannel *Channel,
+	m msg.Message) ([]*WriteReport, error) {
+	return n.writeExceptReport(ctx, exceptChannel, m)
+}
+
+// WriteFrameToReport writes a frame to given channel and waits until
+// the write is performed. It returns the error that prevented the write,
+// if any.
+// This function is intended only for routing pre-existing frames to other nodes,
+// since all frame fields must be filled manually.
+func (n *Node) WriteFrameToReport(ctx context.Context, channel *Channel, fr frame.Frame) error {
+	return n.writeToReport(ctx, channel, fr)
+}
+
+// WriteFrameAllReport writes a frame to all channels and waits until
+// all writes are performed. It returns the outcome of the write on every channel.
+// This function is intended only for routing pre-existing frames to other nodes,
+// since all frame fields must be filled manually.
+func (n *Node) WriteFrameAllReport(ctx context.Context, fr frame.Frame) ([]*WriteReport, error) {
+	return n.writeAllReport(ctx, fr)
+}
+
+// WriteFrameExceptReport writes a frame to all channels except specified
+// channel and waits until all writes are performed. It returns the outcome
+// of the write on every channel.
+// This function is intended only for routing pre-existing frames to other nodes,
+// since all frame fields must be filled manually.
+func (n *Node) WriteFrameExceptReport(ctx context.Context, exceptChannel *Channel,
+	fr frame.Frame) ([]*WriteReport, error) {
+	return n.writeExceptReport(ctx, exceptChannel, fr)
+}
@@ -0,0 +1,172 @@
+package gomavlib
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gomavlib/pkg/dialect"
+	"github.com/aler9/gomavlib/pkg/msg"
+)
+
+// failingRWC is a io.ReadWriteCloser whose writes always fail.
+type failingRWC struct {
+	closed chan struct{}
+}
+
+func (c *failingRWC) Read(buf []byte) (int, error) {
+	<-c.closed
+	return 0, fmt.Errorf("closed")
+}
+
+func (c *failingRWC) Write(buf []byte) (int, error) {
+	return 0, fmt.Errorf("broken link")
+}
+
+func (c *failingRWC) Close() error {
+	close(c.closed)
+	return nil
+}
+
+func TestNodeWriteReport(t *testing.T) {
+	c1, c2 := net.Pipe()
+	broken := &failingRWC{closed: make(chan struct{})}
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointCustom{c1},
+			EndpointCustom{broken},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+
+	node2, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      11,
+		Endpoints:        []EndpointConf{EndpointCustom{c2}},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node2.Close()
+
+	go func() {
+		for range node2.Events() {
+		}
+	}()
+
+	var working *Channel
+	var failing *Channel
+	for evt := range node1.Events() {
+		if evt, ok := evt.(*EventChannelOpen); ok {
+			if evt.Channel.Endpoint().Conf().(EndpointCustom).ReadWriteCloser == broken {
+				failing = evt.Channel
+			} else {
+				working = evt.Channel
+			}
+			if working != nil && failing != nil {
+				break
+			}
+		}
+	}
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	reports, err := node1.WriteMessageAllReport(ctx, &MessageHeartbeat{Type: 1})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(reports))
+
+	for _, r := range reports {
+		if r.Channel == working {
+			require.NoError(t, r.Error)
+		} else {
+			require.Equal(t, failing, r.Channel)
+			require.EqualError(t, r.Error, "broken link")
+		}
+	}
+
+	err = node1.WriteMessageToReport(ctx, working, &MessageHeartbeat{Type: 2})
+	require.NoError(t, err)
+
+	err = node1.WriteMessageToReport(ctx, failing, &MessageHeartbeat{Type: 3})
+	require.EqualError(t, err, "broken link")
+
+	reports, err = node1.WriteMessageExceptReport(ctx, failing, &MessageHeartbeat{Type: 4})
+	require.NoError(t, err)
+	require.Equal(t, []*WriteReport{{Channel: working}}, reports)
+
+	require.Equal(t, uint64(2), node1.Stats().DroppedWrites)
+}
+
+func TestNodeWriteReportQueueFull(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	node1, err := NewNode(NodeConf{
+		Dialect:     &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:  V2,
+		OutSystemID: 10,
+		Endpoints: []EndpointConf{
+			EndpointQueue{EndpointConf: EndpointCustom{c1}, Size: 1},
+		},
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	defer node1.Close()
+	defer c2.Close()
+
+	var ch *Channel
+	for evt := range node1.Events() {
+		if evt, ok := evt.(*EventChannelOpen); ok {
+			ch = evt.Channel
+			break
+		}
+	}
+
+	go func() {
+		for range node1.Events() {
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// nobody reads from c2, therefore the first frame blocks the writer,
+	// the second one is queued and the third one is discarded.
+	errs := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			errs <- node1.WriteMessageToReport(ctx, ch, &MessageHeartbeat{})
+		}()
+	}
+
+	require.Equal(t, errorQueueFull, <-errs)
+}
+
+func TestNodeWriteReportTerminated(t *testing.T) {
+	node, err := NewNode(NodeConf{
+		Dialect:          &dialect.Dialect{3, []msg.Message{&MessageHeartbeat{}}}, //nolint:govet
+		OutVersion:       V2,
+		OutSystemID:      10,
+		HeartbeatDisable: true,
+	})
+	require.NoError(t, err)
+	node.Close()
+
+	_, err = node.WriteMessageAllReport(context.Background(), &MessageHeartbeat{})
+	require.Equal(t, errorTerminated, err)
+}